@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -19,6 +20,7 @@ import (
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/podman/v5/pkg/containersconf"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/inspect"
 	"github.com/containers/podman/v5/pkg/signal"
@@ -252,6 +254,28 @@ func getSecrets(cmd *cobra.Command, toComplete string, cType completeType) ([]st
 	return suggestions, cobra.ShellCompDirectiveNoFileComp
 }
 
+func getContainerGroups(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	suggestions := []string{}
+
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	groups, err := engine.ContainerGroupList(registry.GetContext())
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	for _, g := range groups {
+		if strings.HasPrefix(g.Name, toComplete) {
+			suggestions = append(suggestions, g.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 func getRegistries() ([]string, cobra.ShellCompDirective) {
 	regs, err := sysregistriesv2.UnqualifiedSearchRegistries(nil)
 	if err != nil {
@@ -603,6 +627,33 @@ func AutocompleteSecretCreate(cmd *cobra.Command, args []string, toComplete stri
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteContainerGroups - Autocomplete container groups.
+func AutocompleteContainerGroups(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getContainerGroups(cmd, toComplete)
+}
+
+// AutocompleteSecretUpdate - Autocomplete the secret, then the file with the
+// new secret data.
+func AutocompleteSecretUpdate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return getSecrets(cmd, toComplete, completeDefault)
+	case 1:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteSecretNotify - Autocomplete how containers are notified of an
+// updated secret.
+// -> "signal", "restart"
+func AutocompleteSecretNotify(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{entities.SecretNotifySignal, entities.SecretNotifyRestart}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImages - Autocomplete images.
 func AutocompleteImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -702,6 +753,21 @@ func AutocompleteCommitCommand(cmd *cobra.Command, args []string, toComplete str
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteContainerMigrateCommand - Autocomplete podman container migrate command args.
+func AutocompleteContainerMigrateCommand(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	switch len(args) {
+	case 0:
+		return getContainers(cmd, toComplete, completeDefault, "running")
+	case 1:
+		return AutocompleteSystemConnections(cmd, args, toComplete)
+	}
+	// don't complete more than 2 args
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteCpCommand - Autocomplete podman cp command args.
 func AutocompleteCpCommand(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -754,6 +820,9 @@ func AutocompleteExecCommand(cmd *cobra.Command, args []string, toComplete strin
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 	if len(args) == 0 {
+		if cmd.Parent().Name() == "pod" {
+			return getPods(cmd, toComplete, completeDefault, "running", "degraded")
+		}
 		return getContainers(cmd, toComplete, completeDefault, "running")
 	}
 	return nil, cobra.ShellCompDirectiveDefault
@@ -856,6 +925,21 @@ func AutoCompleteFarms(cmd *cobra.Command, args []string, toComplete string) ([]
 	return suggestions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteProxyProfile - Autocomplete the proxy profiles of containers.conf.
+func AutocompleteProxyProfile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	conf, err := containersconf.New(podmanConfig.ContainersConfDefaultsRO.LoadedModules())
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	suggestions := make([]string, 0, len(conf.Containers.ProxyProfiles))
+	for name := range conf.Containers.ProxyProfiles {
+		suggestions = append(suggestions, name)
+	}
+	slices.Sort(suggestions)
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteSystemConnections - Autocomplete system connections.
 func AutocompleteSystemConnections(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -1020,6 +1104,27 @@ func AutocompleteRestartOption(cmd *cobra.Command, args []string, toComplete str
 	return restartOptions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteSecurityProfile - Autocomplete security profiles.
+// -> "privileged", "baseline", "restricted"
+func AutocompleteSecurityProfile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return define.SecurityProfiles, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteKeyring - Autocomplete keyring modes.
+// -> "private", "inherit", "none"
+func AutocompleteKeyring(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return define.KeyringModes, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteStartCondition - Autocomplete start conditions.
+func AutocompleteStartCondition(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kv := keyValueCompletion{
+		define.StartConditionPathExists + "=": func(s string) ([]string, cobra.ShellCompDirective) { return nil, cobra.ShellCompDirectiveDefault },
+		define.StartConditionTCP + "=":        nil,
+	}
+	return completeKeyValues(toComplete, kv)
+}
+
 // AutocompleteSecurityOption - Autocomplete security options options.
 func AutocompleteSecurityOption(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	kv := keyValueCompletion{
@@ -1150,10 +1255,11 @@ func AutocompleteNetworkFlag(cmd *cobra.Command, args []string, toComplete strin
 		"ns:": func(_ string) ([]string, cobra.ShellCompDirective) {
 			return nil, cobra.ShellCompDirectiveDefault
 		},
-		"bridge":  nil,
-		"none":    nil,
-		"host":    nil,
-		"private": nil,
+		"bridge":           nil,
+		"none":             nil,
+		"host":             nil,
+		"host-gateway-all": nil,
+		"private":          nil,
 		"slirp4netns:": func(s string) ([]string, cobra.ShellCompDirective) {
 			skv := keyValueCompletion{
 				"allow_host_loopback=": getBoolCompletion,
@@ -1421,19 +1527,19 @@ func getMethodNames(f reflect.Value, prefix string) []formatSuggestion {
 func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	event := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{events.Attach.String(), events.AutoUpdate.String(), events.Checkpoint.String(), events.Cleanup.String(),
-			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(),
+			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(), events.ExecReaped.String(),
 			events.Exited.String(), events.Export.String(), events.Import.String(), events.Init.String(), events.Kill.String(),
 			events.LoadFromArchive.String(), events.Mount.String(), events.NetworkConnect.String(),
 			events.NetworkDisconnect.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
-			events.PullError.String(), events.Push.String(), events.Refresh.String(), events.Remove.String(),
+			events.PullError.String(), events.Push.String(), events.Refresh.String(), events.Reload.String(), events.Remove.String(),
 			events.Rename.String(), events.Renumber.String(), events.Restart.String(), events.Restore.String(),
 			events.Save.String(), events.Start.String(), events.Stop.String(), events.Sync.String(), events.Tag.String(),
 			events.Unmount.String(), events.Unpause.String(), events.Untag.String(), events.Update.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	eventTypes := func(_ string) ([]string, cobra.ShellCompDirective) {
-		return []string{events.Container.String(), events.Image.String(), events.Network.String(),
-			events.Pod.String(), events.System.String(), events.Volume.String(),
+		return []string{events.Container.String(), events.Custom.String(), events.Image.String(), events.Network.String(),
+			events.Pod.String(), events.Process.String(), events.System.String(), events.Volume.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	kv := keyValueCompletion{
@@ -1532,6 +1638,12 @@ func AutocompleteImageSaveFormat(cmd *cobra.Command, args []string, toComplete s
 	return ValidSaveFormats, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteGraphFormat - Autocomplete system graph format options.
+// -> "dot", "json"
+func AutocompleteGraphFormat(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"dot", "json"}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteWaitCondition - Autocomplete wait condition options.
 // -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"
 func AutocompleteWaitCondition(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1612,9 +1724,11 @@ var containerStatuses = []string{"created", "running", "paused", "stopped", "exi
 // AutocompletePsFilters - Autocomplete ps filter options.
 func AutocompletePsFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	kv := keyValueCompletion{
-		"ancestor=": func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
-		"before=":   func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
-		"exited=":   nil,
+		"ancestor=":   func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
+		"annotation=": nil,
+		"before=":     func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
+		"exited=":     nil,
+		"group=":      func(s string) ([]string, cobra.ShellCompDirective) { return getContainerGroups(cmd, s) },
 		"health=": func(_ string) ([]string, cobra.ShellCompDirective) {
 			return []string{define.HealthCheckHealthy,
 				define.HealthCheckUnhealthy}, cobra.ShellCompDirectiveNoFileComp
@@ -1623,6 +1737,7 @@ func AutocompletePsFilters(cmd *cobra.Command, args []string, toComplete string)
 		"label=":   nil,
 		"name=":    func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeNames) },
 		"network=": func(s string) ([]string, cobra.ShellCompDirective) { return getNetworks(cmd, s, completeDefault) },
+		"note=":    nil,
 		"pod=":     func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
 		"since=":   func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
 		"status=": func(_ string) ([]string, cobra.ShellCompDirective) {
@@ -1701,6 +1816,16 @@ func AutocompleteNetworkFilters(cmd *cobra.Command, args []string, toComplete st
 	return completeKeyValues(toComplete, kv)
 }
 
+// AutocompleteCheckpointFilters - Autocomplete container checkpoint ls --filter options.
+func AutocompleteCheckpointFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kv := keyValueCompletion{
+		"container=":      func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
+		"pre-checkpoint=": getBoolCompletion,
+		"until=":          nil,
+	}
+	return completeKeyValues(toComplete, kv)
+}
+
 // AutocompleteVolumeFilters - Autocomplete volume ls --filter options.
 func AutocompleteVolumeFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	local := func(_ string) ([]string, cobra.ShellCompDirective) {
@@ -1711,6 +1836,7 @@ func AutocompleteVolumeFilters(cmd *cobra.Command, args []string, toComplete str
 		"after=":    getImg,
 		"dangling=": getBoolCompletion,
 		"driver=":   local,
+		"image=":    getImg,
 		"label=":    nil,
 		"name=":     func(s string) ([]string, cobra.ShellCompDirective) { return getVolumes(cmd, s) },
 		"opt=":      nil,
@@ -1763,6 +1889,24 @@ func AutocompleteClone(cmd *cobra.Command, args []string, toComplete string) ([]
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteCloneVolumes - Autocomplete container clone --volumes modes.
+// -> "share", "copy", "new"
+func AutocompleteCloneVolumes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{entities.CloneVolumesShare, entities.CloneVolumesCopy, entities.CloneVolumesNew}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteMigrateDB - Autocomplete system migrate --new-db backends.
+// -> "sqlite", "boltdb"
+func AutocompleteMigrateDB(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"sqlite", "boltdb"}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteServiceEndpointClass - Autocomplete system service endpoint classes.
+// -> "build=", "pull=", "exec="
+func AutocompleteServiceEndpointClass(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"build=", "pull=", "exec="}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
 // AutocompleteSSH - Autocomplete ssh modes
 func AutocompleteSSH(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -152,6 +152,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(envFileFlagName, completion.AutocompleteDefault)
 
+		envFileTemplateFlagName := "env-file-template"
+		createFlags.StringVar(
+			&cf.EnvFileTemplate,
+			envFileTemplateFlagName, "",
+			"Read in an env file template resolved from secrets and host environment at container start",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(envFileTemplateFlagName, completion.AutocompleteDefault)
+
 		exposeFlagName := "expose"
 		createFlags.StringSliceVar(
 			&cf.Expose,
@@ -222,6 +230,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"Set proxy environment variables in the container based on the host proxy vars",
 		)
 
+		proxyProfileFlagName := "proxy-profile"
+		createFlags.StringVar(
+			&cf.ProxyProfile,
+			proxyProfileFlagName, "",
+			"Inject the proxy settings of the named containers.conf proxy `profile`",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(proxyProfileFlagName, AutocompleteProxyProfile)
+
 		hostUserFlagName := "hostuser"
 		createFlags.StringSliceVar(
 			&cf.HostUsers,
@@ -386,6 +402,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"read-only-tmpfs", cf.ReadWriteTmpFS,
 			"When running --read-only containers mount read-write tmpfs on /dev, /dev/shm, /run, /tmp and /var/tmp",
 		)
+		publishSocketFlagName := "publish-socket"
+		createFlags.StringSliceVar(
+			&cf.PublishSocket,
+			publishSocketFlagName, []string{},
+			"Bind a socket on the host and pass it to the container using socket activation",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(publishSocketFlagName, completion.AutocompleteNone)
+
 		requiresFlagName := "requires"
 		createFlags.StringSliceVar(
 			&cf.Requires,
@@ -576,6 +600,52 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(workdirFlagName, completion.AutocompleteDefault)
 
+		createFlags.BoolVar(
+			&cf.WatchProcesses,
+			"watch-processes", false,
+			"Write an event whenever a process starts or exits inside the container",
+		)
+
+		provisionScriptFlagName := "provision-script"
+		createFlags.StringVar(
+			&cf.ProvisionScript,
+			provisionScriptFlagName, "",
+			"Run the script inside the container once, on its first start",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(provisionScriptFlagName, completion.AutocompleteDefault)
+
+		onExitNotifyFlagName := "on-exit-notify"
+		createFlags.StringArrayVar(
+			&cf.OnExitNotify,
+			onExitNotifyFlagName, []string{},
+			"Notify `webhook=URL`, script=PATH or email=ADDRESS when the container exits",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(onExitNotifyFlagName, completion.AutocompleteNone)
+
+		onExitNotifyTemplateFlagName := "on-exit-notify-template"
+		createFlags.StringVar(
+			&cf.OnExitTemplate,
+			onExitNotifyTemplateFlagName, "",
+			"Go template of the on-exit notifications",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(onExitNotifyTemplateFlagName, completion.AutocompleteNone)
+
+		conditionFlagName := "condition"
+		createFlags.StringArrayVar(
+			&cf.Conditions,
+			conditionFlagName, []string{},
+			"Wait for `path-exists=PATH` or tcp=HOST:PORT on the host before starting the container",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(conditionFlagName, AutocompleteStartCondition)
+
+		conditionTimeoutFlagName := "condition-timeout"
+		createFlags.StringVar(
+			&cf.ConditionTimeout,
+			conditionTimeoutFlagName, define.DefaultStartConditionsTimeout.String(),
+			"Maximum time to wait for the start conditions, 0 waits indefinitely",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(conditionTimeoutFlagName, completion.AutocompleteNone)
+
 		seccompPolicyFlagName := "seccomp-policy"
 		createFlags.StringVar(
 			&cf.SeccompPolicy,
@@ -683,6 +753,22 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(securityOptFlagName, AutocompleteSecurityOption)
 
+		securityProfileFlagName := "security-profile"
+		createFlags.StringVar(
+			&cf.SecurityProfile,
+			securityProfileFlagName, "",
+			"Enforce the security `profile` (privileged, baseline, restricted) on the container",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(securityProfileFlagName, AutocompleteSecurityProfile)
+
+		keyringFlagName := "keyring"
+		createFlags.StringVar(
+			&cf.Keyring,
+			keyringFlagName, "",
+			"Keyring `mode` of the container (private, inherit, none)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(keyringFlagName, AutocompleteKeyring)
+
 		subgidnameFlagName := "subgidname"
 		createFlags.StringVar(
 			&cf.SubUIDName,
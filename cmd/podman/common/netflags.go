@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/completion"
@@ -81,6 +82,13 @@ func DefineNetFlags(cmd *cobra.Command) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(networkAliasFlagName, completion.AutocompleteNone)
 
+	networkOptFlagName := "network-opt"
+	netFlags.StringSlice(
+		networkOptFlagName, []string{},
+		"Set the MTU or the offloads of the network interfaces in bridge networks (e.g. mtu=1400,tso=off)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(networkOptFlagName, completion.AutocompleteNone)
+
 	publishFlagName := "publish"
 	netFlags.StringSliceP(
 		publishFlagName, "p", []string{},
@@ -202,6 +210,28 @@ func NetFlagsToNetOptions(opts *entities.NetOptions, flags pflag.FlagSet) (*enti
 		opts.Networks = networks
 	}
 
+	if flags.Changed("network-opt") {
+		networkOpts, err := flags.GetStringSlice("network-opt")
+		if err != nil {
+			return nil, err
+		}
+		// if pod create --infra=false
+		if infra, err := flags.GetBool("infra"); err == nil && !infra {
+			return nil, fmt.Errorf("cannot set --network-opt without infra container: %w", define.ErrInvalidArg)
+		}
+		if !opts.Network.IsBridge() && !opts.Network.IsDefault() {
+			return nil, fmt.Errorf("--network-opt can only be set when the network mode is bridge: %w", define.ErrInvalidArg)
+		}
+		opts.InterfaceOptions = make(map[string]string, len(networkOpts))
+		for _, opt := range networkOpts {
+			key, value, ok := strings.Cut(opt, "=")
+			if !ok || key == "" {
+				return nil, fmt.Errorf("invalid network option %q, it must be in the key=value format: %w", opt, define.ErrInvalidArg)
+			}
+			opts.InterfaceOptions[key] = value
+		}
+	}
+
 	if flags.Changed("ip") || flags.Changed("ip6") || flags.Changed("mac-address") || flags.Changed("network-alias") {
 		// if there is no network we add the default
 		if len(opts.Networks) == 0 {
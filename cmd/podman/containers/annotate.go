@@ -0,0 +1,99 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	annotateDescription = `Attach operational annotations to a container, or list them if none are given.
+
+  The annotations are kept in the Podman database apart from the OCI annotations of the container, so they can be changed at any time and are not visible inside the container. Containers can be filtered by them with podman ps --filter annotation=KEY[=VALUE].`
+	annotateCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "annotate [options] CONTAINER [KEY=VALUE...]",
+		Short:             "Set operator annotations of a container",
+		Long:              annotateDescription,
+		RunE:              annotate,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman container annotate web owner=team-a ticket=OPS-123
+  podman container annotate --rm ticket web
+  podman container annotate web`,
+	}
+
+	annotateRemove []string
+	annotateFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: annotateCommand,
+		Parent:  containerCmd,
+	})
+	flags := annotateCommand.Flags()
+
+	rmFlagName := "rm"
+	flags.StringArrayVar(&annotateRemove, rmFlagName, []string{}, "Remove the annotation with the given key")
+	_ = annotateCommand.RegisterFlagCompletionFunc(rmFlagName, completion.AutocompleteNone)
+
+	formatFlagName := "format"
+	flags.StringVar(&annotateFormat, formatFlagName, "", "Print the annotations as JSON with 'json'")
+	_ = annotateCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+}
+
+func annotate(cmd *cobra.Command, args []string) error {
+	options := entities.ContainerAnnotateOptions{
+		Set:    make(map[string]string, len(args)-1),
+		Remove: annotateRemove,
+	}
+	for _, arg := range args[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid annotation %q, must be KEY=VALUE", arg)
+		}
+		if value == "" {
+			return fmt.Errorf("annotation %q has no value, use --rm to remove it", key)
+		}
+		options.Set[key] = value
+	}
+	if annotateFormat != "" && !report.IsJSON(annotateFormat) {
+		return errors.New("only 'json' is supported by --format")
+	}
+
+	annotations, err := registry.ContainerEngine().ContainerAnnotate(registry.Context(), args[0], options)
+	if err != nil {
+		return err
+	}
+	// Only list the annotations if no change was requested.
+	if len(options.Set) > 0 || len(options.Remove) > 0 {
+		return nil
+	}
+
+	if report.IsJSON(annotateFormat) {
+		b, err := json.MarshalIndent(annotations, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, annotations[key])
+	}
+	return nil
+}
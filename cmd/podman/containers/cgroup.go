@@ -0,0 +1,129 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cgroupDescription = `Display the cgroup of one or more running containers.
+
+  Shows the cgroup path, its location in the cgroup filesystem, the systemd unit and slice it belongs to and whether the unit's cgroup subtree is delegated.`
+
+	cgroupCommand = &cobra.Command{
+		Use:   "cgroup [options] CONTAINER [CONTAINER...]",
+		Short: "Display the cgroup of one or more containers",
+		Long:  cgroupDescription,
+		RunE:  cgroup,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
+		},
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman container cgroup ctrID
+  podman container cgroup --format '{{.FullPath}}' ctrID
+  podman container cgroup --format json --latest`,
+	}
+)
+
+var cgroupOpts struct {
+	Format    string
+	Latest    bool
+	NoHeading bool
+}
+
+// cgroupReporter holds the cgroup details of one container.
+type cgroupReporter struct {
+	ID        string
+	Name      string
+	Manager   string
+	Path      string
+	FullPath  string
+	Unit      string
+	Slice     string
+	Delegated bool
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: cgroupCommand,
+		Parent:  containerCmd,
+	})
+	flags := cgroupCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&cgroupOpts.Format, formatFlagName, "", "Pretty-print cgroups to JSON or using a Go template")
+	_ = cgroupCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&cgroupReporter{}))
+
+	flags.BoolVarP(&cgroupOpts.NoHeading, "noheading", "n", false, "Do not print headers")
+	validate.AddLatestFlag(cgroupCommand, &cgroupOpts.Latest)
+}
+
+func cgroup(cmd *cobra.Command, args []string) error {
+	args = utils.RemoveSlash(args)
+	inspectOpts := entities.InspectOptions{Latest: cgroupOpts.Latest}
+	ctrs, errs, err := registry.ContainerEngine().ContainerInspect(registry.GetContext(), args, inspectOpts)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	reports := make([]cgroupReporter, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		if ctr.State.CgroupPath == "" {
+			return fmt.Errorf("container %s has no cgroup, it must be running with cgroups enabled", ctr.Name)
+		}
+		reports = append(reports, cgroupReporter{
+			ID:        ctr.ID,
+			Name:      ctr.Name,
+			Manager:   ctr.HostConfig.CgroupManager,
+			Path:      ctr.State.CgroupPath,
+			FullPath:  ctr.State.CgroupFullPath,
+			Unit:      ctr.State.CgroupUnit,
+			Slice:     ctr.State.CgroupSlice,
+			Delegated: ctr.State.CgroupDelegated,
+		})
+	}
+
+	if report.IsJSON(cgroupOpts.Format) {
+		b, err := json.MarshalIndent(reports, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, cgroupOpts.Format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, "{{range .}}{{.ID | printf \"%.12s\"}}\t{{.Name}}\t{{.Manager}}\t{{.Unit}}\t{{.Delegated}}\t{{.FullPath}}\n{{end -}}")
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !cgroupOpts.NoHeading {
+		headers := report.Headers(cgroupReporter{}, map[string]string{
+			"ID":       "CONTAINER ID",
+			"FullPath": "CGROUP PATH",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reports)
+}
@@ -68,6 +68,10 @@ func init() {
 	flags.BoolVarP(&checkpointOptions.PreCheckPoint, "pre-checkpoint", "P", false, "Dump container's memory information only, leave the container running")
 	flags.BoolVar(&checkpointOptions.WithPrevious, "with-previous", false, "Checkpoint container with pre-checkpoint images")
 
+	preDumpChainFlagName := "pre-dump-chain"
+	flags.IntVar(&checkpointOptions.PreDumpChain, preDumpChainFlagName, 0, "Take `N` iterative pre-dumps of the container's memory before checkpointing it")
+	_ = checkpointCommand.RegisterFlagCompletionFunc(preDumpChainFlagName, completion.AutocompleteNone)
+
 	createImageFlagName := "create-image"
 	flags.StringVarP(&checkpointOptions.CreateImage, createImageFlagName, "", "", "Create checkpoint image with specified name")
 	_ = checkpointCommand.RegisterFlagCompletionFunc(createImageFlagName, completion.AutocompleteNone)
@@ -119,7 +123,13 @@ func checkpoint(cmd *cobra.Command, args []string) error {
 	if checkpointOptions.WithPrevious && checkpointOptions.PreCheckPoint {
 		return errors.New("--with-previous can not be used with --pre-checkpoint")
 	}
-	if (checkpointOptions.WithPrevious || checkpointOptions.PreCheckPoint) && !criu.MemTrack() {
+	if checkpointOptions.PreDumpChain < 0 {
+		return errors.New("--pre-dump-chain must not be negative")
+	}
+	if checkpointOptions.PreDumpChain > 0 && (checkpointOptions.WithPrevious || checkpointOptions.PreCheckPoint) {
+		return errors.New("--pre-dump-chain can not be used with --pre-checkpoint or --with-previous")
+	}
+	if (checkpointOptions.WithPrevious || checkpointOptions.PreCheckPoint || checkpointOptions.PreDumpChain > 0) && !criu.MemTrack() {
 		return errors.New("system (architecture/kernel/CRIU) does not support memory tracking")
 	}
 	responses, err := registry.ContainerEngine().ContainerCheckpoint(context.Background(), args, checkpointOptions)
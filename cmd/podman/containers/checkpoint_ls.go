@@ -0,0 +1,143 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkpointListDescription = `List the checkpoints exported with podman container checkpoint --export.`
+	checkpointListCommand     = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Args:              validate.NoArgs,
+		Short:             "List exported checkpoints",
+		Long:              checkpointListDescription,
+		RunE:              checkpointList,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman container checkpoint ls
+  podman container checkpoint ls --filter container=web --format json`,
+	}
+
+	checkpointListFilters []string
+	checkpointListFormat  string
+)
+
+// checkpointReporter formats a checkpoint record for the default table output.
+type checkpointReporter struct {
+	*define.CheckpointRecord
+}
+
+func (c checkpointReporter) Container() string {
+	if c.ContainerName != "" {
+		return c.ContainerName
+	}
+	return c.ContainerID[:min(len(c.ContainerID), 12)]
+}
+
+func (c checkpointReporter) Created() string {
+	return units.HumanDuration(time.Since(c.CheckpointRecord.Created)) + " ago"
+}
+
+func (c checkpointReporter) Size() string {
+	return units.HumanSize(float64(c.CheckpointRecord.Size))
+}
+
+func (c checkpointReporter) Type() string {
+	if c.PreCheckpoint {
+		return "pre-checkpoint"
+	}
+	return "checkpoint"
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkpointListCommand,
+		Parent:  checkpointCommand,
+	})
+	flags := checkpointListCommand.Flags()
+
+	filterFlagName := "filter"
+	flags.StringArrayVarP(&checkpointListFilters, filterFlagName, "f", []string{}, "Filter checkpoint output")
+	_ = checkpointListCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompleteCheckpointFilters)
+
+	formatFlagName := "format"
+	flags.StringVar(&checkpointListFormat, formatFlagName, "{{range .}}{{.ID}}\t{{.Container}}\t{{.Type}}\t{{.Created}}\t{{.Size}}\t{{.Path}}\n{{end -}}", "Format checkpoint output using Go template")
+	_ = checkpointListCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&checkpointReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+	flags.BoolP("quiet", "q", false, "Print only the paths of the checkpoints")
+}
+
+func checkpointList(cmd *cobra.Command, _ []string) error {
+	filters, err := parse.FilterArgumentsIntoFilters(checkpointListFilters)
+	if err != nil {
+		return err
+	}
+	records, err := registry.ContainerEngine().ContainerCheckpointList(registry.Context(), entities.CheckpointListOptions{Filters: filters})
+	if err != nil {
+		return err
+	}
+
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		for _, record := range records {
+			fmt.Println(record.Path)
+		}
+		return nil
+	}
+
+	if report.IsJSON(checkpointListFormat) {
+		b, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]checkpointReporter, 0, len(records))
+	for _, record := range records {
+		reporters = append(reporters, checkpointReporter{record})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, checkpointListFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(checkpointReporter{}, map[string]string{
+			"ID":        "ID",
+			"Container": "CONTAINER",
+			"Type":      "TYPE",
+			"Created":   "CREATED",
+			"Size":      "SIZE",
+			"Path":      "PATH",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
@@ -0,0 +1,75 @@
+package containers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkpointPruneDescription = `Remove the checkpoints exported with podman container checkpoint --export.
+
+  Records of checkpoints whose archive was removed are pruned as well. A pre-checkpoint is kept as long as a checkpoint based on it is kept.`
+	checkpointPruneCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "prune [options]",
+		Args:              validate.NoArgs,
+		Short:             "Remove exported checkpoints",
+		Long:              checkpointPruneDescription,
+		RunE:              checkpointPrune,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman container checkpoint prune --filter until=24h
+  podman container checkpoint prune --force --filter container=web`,
+	}
+
+	checkpointPruneFilters []string
+	checkpointPruneForce   bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkpointPruneCommand,
+		Parent:  checkpointCommand,
+	})
+	flags := checkpointPruneCommand.Flags()
+	flags.BoolVarP(&checkpointPruneForce, "force", "f", false, "Do not prompt for confirmation.  The default is false")
+
+	filterFlagName := "filter"
+	flags.StringArrayVar(&checkpointPruneFilters, filterFlagName, []string{}, "Provide filter values (e.g. 'until=<timestamp>')")
+	_ = checkpointPruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompleteCheckpointFilters)
+}
+
+func checkpointPrune(cmd *cobra.Command, _ []string) error {
+	if !checkpointPruneForce {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Println("WARNING! This will remove all exported checkpoints matching the filters.")
+		fmt.Print("Are you sure you want to continue? [y/N] ")
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if strings.ToLower(answer)[0] != 'y' {
+			return nil
+		}
+	}
+
+	filters, err := parse.FilterArgumentsIntoFilters(checkpointPruneFilters)
+	if err != nil {
+		return err
+	}
+	responses, err := registry.ContainerEngine().ContainerCheckpointPrune(registry.Context(), entities.CheckpointPruneOptions{Filters: filters})
+	if err != nil {
+		return err
+	}
+	return utils.PrintVolumePruneResults(responses, false)
+}
@@ -2,6 +2,7 @@ package containers
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
@@ -40,6 +41,10 @@ func cloneFlags(cmd *cobra.Command) {
 	forceFlagName := "force"
 	flags.BoolVarP(&ctrClone.Force, forceFlagName, "f", false, "force the existing container to be destroyed")
 
+	volumesFlagName := "volumes"
+	flags.StringVar(&ctrClone.Volumes, volumesFlagName, entities.CloneVolumesShare, "how to clone named volumes: share, copy or new")
+	_ = cmd.RegisterFlagCompletionFunc(volumesFlagName, common.AutocompleteCloneVolumes)
+
 	common.DefineCreateDefaults(&ctrClone.CreateOpts)
 	common.DefineCreateFlags(cmd, &ctrClone.CreateOpts, entities.CloneMode)
 }
@@ -75,6 +80,14 @@ func clone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot set --force without --destroy: %w", define.ErrInvalidArg)
 	}
 
+	switch ctrClone.Volumes {
+	case entities.CloneVolumesShare, entities.CloneVolumesNew:
+	case entities.CloneVolumesCopy:
+		ctrClone.VolumeCopyProgress = os.Stderr
+	default:
+		return fmt.Errorf("invalid --volumes %q, must be one of share, copy or new: %w", ctrClone.Volumes, define.ErrInvalidArg)
+	}
+
 	ctrClone.ID = args[0]
 	ctrClone.CreateOpts.IsClone = true
 	rep, err := registry.ContainerEngine().ContainerClone(registry.GetContext(), ctrClone)
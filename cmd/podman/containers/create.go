@@ -329,6 +329,12 @@ func CreateInit(c *cobra.Command, vals entities.ContainerCreateOptions, isInfra
 		vals.Entrypoint = &val
 	}
 
+	if registry.IsRemote() && (len(vals.Volume) > 0 || len(vals.Mount) > 0) {
+		if err := setupMachinePathResolver(); err != nil {
+			return vals, err
+		}
+	}
+
 	// Docker-compatibility: the "-h" flag for run/create is reserved for
 	// the hostname (see https://github.com/containers/podman/issues/1367).
 
@@ -0,0 +1,205 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultToolsImage is the image providing debugging tools when none is
+// given. Its binaries are statically linked, so they also run when mounted
+// into another container by exec --with-tools.
+const defaultToolsImage = "docker.io/library/busybox:latest"
+
+// debugNamespaces are the namespaces of the target which can be shared.
+var debugNamespaces = []string{"ipc", "net", "pid", "user", "uts"}
+
+var (
+	debugDescription = `Run an ephemeral container sharing the namespaces of a running container.
+
+  The debug container brings its own tools, which makes it possible to inspect containers built from minimal or distroless images. By default it shares the PID and network namespaces of the target and is removed when it exits. With --target-root the root filesystem of the target is reachable at /proc/1/root.`
+
+	debugCommand = &cobra.Command{
+		Use:               "debug [options] CONTAINER [COMMAND [ARG...]]",
+		Short:             "Debug a running container with an ephemeral container",
+		Long:              debugDescription,
+		RunE:              debug,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteExecCommand,
+		Example: `podman debug -it ctrID
+  podman debug -it --image registry.fedoraproject.org/fedora:latest --target-root myCtr
+  podman debug --share pid,net,ipc ctrID ps aux`,
+	}
+
+	containerDebugCommand = &cobra.Command{
+		Use:               debugCommand.Use,
+		Short:             debugCommand.Short,
+		Long:              debugCommand.Long,
+		RunE:              debugCommand.RunE,
+		Args:              debugCommand.Args,
+		ValidArgsFunction: debugCommand.ValidArgsFunction,
+		Example: `podman container debug -it ctrID
+  podman container debug -it --image registry.fedoraproject.org/fedora:latest --target-root myCtr
+  podman container debug --share pid,net,ipc ctrID ps aux`,
+	}
+)
+
+var debugOpts struct {
+	DetachKeys  string
+	Image       string
+	Interactive bool
+	Name        string
+	Pull        string
+	Rm          bool
+	Share       []string
+	TargetRoot  bool
+	TTY         bool
+}
+
+func debugFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.SetInterspersed(false)
+
+	detachKeysFlagName := "detach-keys"
+	flags.StringVar(&debugOpts.DetachKeys, detachKeysFlagName, containerConfig.DetachKeys(), "Select the key sequence for detaching the debug container. Format is a single character [a-Z] or ctrl-<value> where <value> is one of: a-z, @, ^, [, , or _")
+	_ = cmd.RegisterFlagCompletionFunc(detachKeysFlagName, common.AutocompleteDetachKeys)
+
+	imageFlagName := "image"
+	flags.StringVar(&debugOpts.Image, imageFlagName, defaultToolsImage, "Image providing the debugging tools")
+	_ = cmd.RegisterFlagCompletionFunc(imageFlagName, common.AutocompleteImages)
+
+	flags.BoolVarP(&debugOpts.Interactive, "interactive", "i", false, "Keep STDIN open even if not attached")
+
+	nameFlagName := "name"
+	flags.StringVar(&debugOpts.Name, nameFlagName, "", "Assign a name to the debug container")
+	_ = cmd.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	pullFlagName := "pull"
+	flags.StringVar(&debugOpts.Pull, pullFlagName, config.PullPolicyMissing.String(), `Pull image policy ("always"|"missing"|"never"|"newer")`)
+	_ = cmd.RegisterFlagCompletionFunc(pullFlagName, common.AutocompletePullOption)
+
+	flags.BoolVar(&debugOpts.Rm, "rm", true, "Remove the debug container when it exits")
+
+	shareFlagName := "share"
+	flags.StringSliceVar(&debugOpts.Share, shareFlagName, []string{"pid", "net"}, "Namespaces of the target to share ("+strings.Join(debugNamespaces, ", ")+")")
+	_ = cmd.RegisterFlagCompletionFunc(shareFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&debugOpts.TargetRoot, "target-root", false, "Give access to the root filesystem of the target at /proc/1/root, requires sharing the pid namespace")
+	flags.BoolVarP(&debugOpts.TTY, "tty", "t", false, "Allocate a pseudo-TTY for the debug container")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: debugCommand,
+	})
+	debugFlags(debugCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerDebugCommand,
+		Parent:  containerCmd,
+	})
+	debugFlags(containerDebugCommand)
+}
+
+func debug(_ *cobra.Command, args []string) error {
+	for _, ns := range debugOpts.Share {
+		if !slices.Contains(debugNamespaces, ns) {
+			return fmt.Errorf("invalid namespace %q for --share, must be one of %s", ns, strings.Join(debugNamespaces, ", "))
+		}
+	}
+	if debugOpts.TargetRoot && !slices.Contains(debugOpts.Share, "pid") {
+		return errors.New("--target-root requires sharing the pid namespace of the target")
+	}
+	if debugOpts.TTY && debugOpts.Interactive && !term.IsTerminal(int(os.Stdin.Fd())) {
+		logrus.Warnf("The input device is not a TTY. The --tty and --interactive flags might not work properly")
+	}
+
+	args = utils.RemoveSlash(args)
+	ctrs, errs, err := registry.ContainerEngine().ContainerInspect(registry.GetContext(), args[:1], entities.InspectOptions{})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	target := ctrs[0]
+	if !target.State.Running {
+		return fmt.Errorf("container %s is not running, only running containers can be debugged", target.Name)
+	}
+
+	pullPolicy, err := config.ParsePullPolicy(debugOpts.Pull)
+	if err != nil {
+		return err
+	}
+	pullReport, err := registry.ImageEngine().Pull(registry.GetContext(), debugOpts.Image, entities.ImagePullOptions{PullPolicy: pullPolicy})
+	if err != nil {
+		return err
+	}
+
+	imageName := debugOpts.Image
+	if _, err := alltransports.ParseImageName(imageName); err == nil {
+		imageName = pullReport.Images[0]
+	}
+
+	s := specgen.NewSpecGenerator(imageName, false)
+	s.RawImageName = debugOpts.Image
+	s.Name = debugOpts.Name
+	s.Command = args[1:]
+	s.Terminal = &debugOpts.TTY
+	s.Stdin = &debugOpts.Interactive
+	s.Remove = &debugOpts.Rm
+	fromTarget := specgen.Namespace{NSMode: specgen.FromContainer, Value: target.ID}
+	for _, ns := range debugOpts.Share {
+		switch ns {
+		case "ipc":
+			s.IpcNS = fromTarget
+		case "net":
+			s.NetNS = fromTarget
+		case "pid":
+			s.PidNS = fromTarget
+		case "user":
+			s.UserNS = fromTarget
+		case "uts":
+			s.UtsNS = fromTarget
+		}
+	}
+	if debugOpts.TargetRoot {
+		// Entering the root of another process is subject to ptrace
+		// access checks.
+		s.CapAdd = append(s.CapAdd, "CAP_SYS_PTRACE")
+		s.Env = map[string]string{"TARGET_ROOT": "/proc/1/root"}
+	}
+
+	runOpts := entities.ContainerRunOptions{
+		DetachKeys:   debugOpts.DetachKeys,
+		OutputStream: os.Stdout,
+		ErrorStream:  os.Stderr,
+		Rm:           debugOpts.Rm,
+		SigProxy:     true,
+		Spec:         s,
+	}
+	if debugOpts.Interactive {
+		runOpts.InputStream = os.Stdin
+	}
+
+	report, err := registry.ContainerEngine().ContainerRun(registry.GetContext(), runOpts)
+	// report.ExitCode is set by ContainerRun even it returns an error
+	if report != nil {
+		registry.SetExitCode(report.ExitCode)
+	}
+	return err
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/validate"
@@ -50,6 +51,7 @@ var (
 	envInput, envFile []string
 	execOpts          entities.ExecOptions
 	execDetach        bool
+	execToolsImage    string
 )
 
 func execFlags(cmd *cobra.Command) {
@@ -91,6 +93,11 @@ func execFlags(cmd *cobra.Command) {
 	flags.StringVarP(&execOpts.WorkDir, workdirFlagName, "w", "", "Working directory inside the container")
 	_ = cmd.RegisterFlagCompletionFunc(workdirFlagName, completion.AutocompleteDefault)
 
+	withToolsFlagName := "with-tools"
+	flags.StringVar(&execToolsImage, withToolsFlagName, "", "Mount the tools of an image into the container for the exec session")
+	flags.Lookup(withToolsFlagName).NoOptDefVal = defaultToolsImage
+	_ = cmd.RegisterFlagCompletionFunc(withToolsFlagName, common.AutocompleteImages)
+
 	waitFlagName := "wait"
 	flags.Int32(waitFlagName, 0, "Total seconds to wait for container to start")
 	_ = flags.MarkHidden(waitFlagName)
@@ -155,6 +162,14 @@ func exec(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if execToolsImage != "" {
+		pullReport, err := registry.ImageEngine().Pull(registry.GetContext(), execToolsImage, entities.ImagePullOptions{PullPolicy: config.PullPolicyMissing})
+		if err != nil {
+			return err
+		}
+		execOpts.ToolsImage = pullReport.Images[0]
+	}
+
 	if cmd.Flags().Changed("wait") {
 		seconds, err := cmd.Flags().GetInt32("wait")
 		if err != nil {
@@ -0,0 +1,48 @@
+//go:build !remote
+
+package containers
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execInspectDescription = `Display the configuration and state of one or more exec sessions in JSON.
+
+  Sessions are read from the database, so detached exec sessions which exited can be inspected until they are removed.`
+
+	execInspectCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "exec-inspect SESSION [SESSION...]",
+		Args:              cobra.MinimumNArgs(1),
+		Short:             "Display the details of exec sessions",
+		Long:              execInspectDescription,
+		RunE:              execInspect,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman container exec-inspect 3f1e0a5c9b2d`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: execInspectCommand,
+		Parent:  containerCmd,
+	})
+}
+
+func execInspect(cmd *cobra.Command, args []string) error {
+	inspects, err := registry.ContainerEngine().ContainerExecInspect(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(inspects, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
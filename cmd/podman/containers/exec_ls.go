@@ -0,0 +1,133 @@
+//go:build !remote
+
+package containers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execListDescription = `List the exec sessions of the given containers, or of all containers if none is given.
+
+  Sessions are read from the database, so detached exec sessions which exited are listed until they are removed.`
+
+	execListCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "exec-ls [options] [CONTAINER...]",
+		Aliases:           []string{"exec-list"},
+		Short:             "List exec sessions",
+		Long:              execListDescription,
+		RunE:              execList,
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman container exec-ls
+  podman container exec-ls --format json myCtr`,
+	}
+
+	execListFormat  string
+	execListQuiet   bool
+	execListNoTrunc bool
+)
+
+// execListReporter formats an exec session for the default table output.
+type execListReporter struct {
+	*entities.ContainerExecListReport
+	noTrunc bool
+}
+
+func (e execListReporter) ID() string {
+	if e.noTrunc {
+		return e.ContainerExecListReport.ID
+	}
+	return e.ContainerExecListReport.ID[0:12]
+}
+
+func (e execListReporter) ContainerID() string {
+	if e.noTrunc {
+		return e.ContainerExecListReport.ContainerID
+	}
+	return e.ContainerExecListReport.ContainerID[0:12]
+}
+
+func (e execListReporter) Command() string {
+	return strings.Join(e.ContainerExecListReport.Command, " ")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: execListCommand,
+		Parent:  containerCmd,
+	})
+	flags := execListCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&execListFormat, formatFlagName, "{{range .}}{{.ID}}\t{{.ContainerID}}\t{{.Command}}\t{{.State}}\t{{.PID}}\t{{.ExitCode}}\n{{end -}}", "Format exec session output using Go template")
+	_ = execListCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&execListReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+	flags.BoolVar(&execListNoTrunc, "no-trunc", false, "Do not truncate the output")
+	flags.BoolVarP(&execListQuiet, "quiet", "q", false, "Print the IDs of the exec sessions only")
+}
+
+func execList(cmd *cobra.Command, args []string) error {
+	sessions, err := registry.ContainerEngine().ContainerExecList(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+
+	if execListQuiet {
+		for _, session := range sessions {
+			fmt.Println(session.ID)
+		}
+		return nil
+	}
+
+	if report.IsJSON(execListFormat) {
+		b, err := json.MarshalIndent(sessions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]execListReporter, 0, len(sessions))
+	for _, session := range sessions {
+		reporters = append(reporters, execListReporter{session, execListNoTrunc})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, execListFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(execListReporter{}, map[string]string{
+			"ID":          "ID",
+			"ContainerID": "CONTAINER",
+			"Command":     "COMMAND",
+			"State":       "STATE",
+			"PID":         "PID",
+			"ExitCode":    "EXIT CODE",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
@@ -32,11 +32,13 @@ func init() {
 	inspectOpts = new(entities.InspectOptions)
 	flags := inspectCmd.Flags()
 	flags.BoolVarP(&inspectOpts.Size, "size", "s", false, "Display total file size")
+	flags.BoolVar(&inspectOpts.EffectiveConfig, "effective-config", false, "Display the containers.conf settings the container was created with")
 
 	formatFlagName := "format"
 	flags.StringVarP(&inspectOpts.Format, formatFlagName, "f", "json", "Format the output to a Go template or json")
 	_ = inspectCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&define.InspectContainerData{}))
 
+	inspect.AddFieldsFlag(inspectCmd, inspectOpts)
 	validate.AddLatestFlag(inspectCmd, &inspectOpts.Latest)
 }
 
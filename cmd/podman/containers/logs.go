@@ -117,6 +117,7 @@ func logsFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&logsOptions.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
 	flags.BoolVarP(&logsOptions.Colors, "color", "", false, "Output the containers with different colors in the log.")
 	flags.BoolVarP(&logsOptions.Names, "names", "n", false, "Output the container name in the log")
+	flags.BoolVar(&logsOptions.Redact, "redact", false, "Mask secret values and configured patterns in the log output")
 
 	flags.SetInterspersed(false)
 	_ = flags.MarkHidden("details")
@@ -0,0 +1,7 @@
+//go:build !windows || !(amd64 || arm64)
+
+package containers
+
+func setupMachinePathResolver() error {
+	return nil
+}
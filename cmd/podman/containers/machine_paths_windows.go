@@ -0,0 +1,78 @@
+//go:build windows && (amd64 || arm64)
+
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/machine/define"
+	"github.com/containers/podman/v5/pkg/machine/env"
+	"github.com/containers/podman/v5/pkg/machine/provider"
+	"github.com/containers/podman/v5/pkg/machine/vmconfigs"
+	"github.com/containers/podman/v5/pkg/specgen"
+)
+
+// connectedMachine returns the configuration of the machine the current
+// connection points to, together with its provider type.
+func connectedMachine() (*vmconfigs.MachineConfig, define.VMType, error) {
+	machineProvider, err := provider.Get()
+	if err != nil {
+		return nil, define.UnknownVirt, fmt.Errorf("getting machine provider: %w", err)
+	}
+	dirs, err := env.GetMachineDirs(machineProvider.VMType())
+	if err != nil {
+		return nil, define.UnknownVirt, err
+	}
+	machines, err := vmconfigs.LoadMachinesInDir(dirs)
+	if err != nil {
+		return nil, define.UnknownVirt, fmt.Errorf("listing machines: %w", err)
+	}
+	uri, err := url.Parse(registry.PodmanConfig().URI)
+	if err != nil {
+		return nil, define.UnknownVirt, err
+	}
+	port, err := strconv.Atoi(uri.Port())
+	if err != nil {
+		return nil, define.UnknownVirt, fmt.Errorf("parsing connection port: %w", err)
+	}
+	for _, mc := range machines {
+		if mc.SSH.Port == port {
+			return mc, machineProvider.VMType(), nil
+		}
+	}
+	return nil, define.UnknownVirt, fmt.Errorf("could not find a machine for connection %q", registry.PodmanConfig().URI)
+}
+
+// setupMachinePathResolver makes Windows host paths used in --volume and
+// --mount resolve through the mount table of the machine the client talks
+// to.  Missing or unshared paths are thus reported by the client instead of
+// failing inside the VM.
+func setupMachinePathResolver() error {
+	if !registry.PodmanConfig().MachineMode || !specgen.WinPathTranslationEnabled() {
+		return nil
+	}
+	mc, vmType, err := connectedMachine()
+	if err != nil {
+		return err
+	}
+	specgen.SetWinPathResolver(func(hostPath string) (string, error) {
+		if _, err := os.Stat(hostPath); err != nil {
+			return "", fmt.Errorf("host path %q cannot be used as volume source: %w", hostPath, err)
+		}
+		// WSL mounts all drives under /mnt, there is no mount table to consult.
+		if vmType == define.WSLVirt {
+			return specgen.WSLMountPath(hostPath)
+		}
+		guestPath, err := vmconfigs.HostPathToGuest(mc.Mounts, hostPath)
+		if errors.Is(err, vmconfigs.ErrPathNotShared) {
+			return "", fmt.Errorf("%w: add it with \"podman machine init --volume\" or set %s=1 to pass it unmodified", err, specgen.NoWinPathTranslationEnv)
+		}
+		return guestPath, err
+	})
+	return nil
+}
@@ -0,0 +1,98 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/migrate"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateDescription = `
+   podman container migrate
+
+   Migrates a running container to the host of a system connection. The container is checkpointed,
+   its image and root file-system changes are sent over the connection, and it is restored there.
+`
+	migrateCommand = &cobra.Command{
+		Use:               "migrate [options] CONTAINER DESTINATION",
+		Short:             "Migrate a running container to another host",
+		Long:              migrateDescription,
+		RunE:              migrateContainer,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompleteContainerMigrateCommand,
+		Example: `podman container migrate web server2
+  podman container migrate --tcp-established web server2
+  podman container migrate --keep --name web-new web server2`,
+	}
+)
+
+var migrateOptions migrate.Options
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: migrateCommand,
+		Parent:  containerCmd,
+	})
+	flags := migrateCommand.Flags()
+	flags.BoolVarP(&migrateOptions.Keep, "keep", "k", false, "Keep the checkpointed container on this host")
+	flags.BoolVar(&migrateOptions.TCPEstablished, "tcp-established", false, "Migrate a container with established TCP connections")
+	flags.BoolVar(&migrateOptions.FileLocks, "file-locks", false, "Migrate a container with file locks")
+	flags.BoolVar(&migrateOptions.IgnoreStaticIP, "ignore-static-ip", false, "Ignore IP address set via --static-ip")
+	flags.BoolVar(&migrateOptions.IgnoreStaticMAC, "ignore-static-mac", false, "Ignore MAC address set via --mac-address")
+	flags.BoolVar(&migrateOptions.IgnoreVolumes, "ignore-volumes", false, "Do not migrate volumes associated with container")
+
+	nameFlagName := "name"
+	flags.StringVarP(&migrateOptions.Name, nameFlagName, "n", "", "Specify new name for the container on the destination")
+	_ = migrateCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	publishFlagName := "publish"
+	flags.StringSliceVarP(&migrateOptions.PublishPorts, publishFlagName, "p", []string{}, "Publish a container's port, or a range of ports, to the destination host (default [])")
+	_ = migrateCommand.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
+
+	compressFlagName := "compress"
+	flags.StringP(compressFlagName, "c", "zstd", "Select compression algorithm (gzip, none, zstd) for the checkpoint sent to the destination")
+	_ = migrateCommand.RegisterFlagCompletionFunc(compressFlagName, common.AutocompleteCheckpointCompressType)
+}
+
+func migrateContainer(cmd *cobra.Command, args []string) error {
+	compress, _ := cmd.Flags().GetString("compress")
+	switch strings.ToLower(compress) {
+	case "none":
+		migrateOptions.Compression = archive.Uncompressed
+	case "gzip":
+		migrateOptions.Compression = archive.Gzip
+	case "zstd":
+		migrateOptions.Compression = archive.Zstd
+	default:
+		return fmt.Errorf("selected compression algorithm (%q) not supported. Please select one from: gzip, none, zstd", compress)
+	}
+	if rootless.IsRootless() {
+		return errors.New("migrating a container requires root")
+	}
+	if migrateOptions.Name != "" && migrateOptions.TCPEstablished {
+		return errors.New("--tcp-established cannot be used with --name")
+	}
+
+	destination, err := migrate.NewDestination(args[1])
+	if err != nil {
+		return err
+	}
+	source := &migrate.Engines{
+		Containers: registry.ContainerEngine(),
+		Images:     registry.ImageEngine(),
+	}
+	id, err := migrate.Migrate(registry.GetContext(), source, destination, strings.TrimPrefix(args[0], "/"), migrateOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(id)
+	return nil
+}
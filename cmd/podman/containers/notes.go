@@ -0,0 +1,142 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notesDescription = `Attach a note to a container, or list its notes if no note is given.
+
+  Notes record operational context such as maintenance done on a container. They are kept in the Podman database and removed together with the container. Containers can be searched by their notes with podman ps --filter note=TEXT.`
+	notesCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "notes [options] CONTAINER [NOTE]",
+		Short:             "Add and list notes of a container",
+		Long:              notesDescription,
+		RunE:              notes,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman notes web "rotated certs 2024-05-01"
+  podman notes web
+  podman notes --rm 3 web`,
+	}
+
+	notesContainerCommand = &cobra.Command{
+		Annotations:       notesCommand.Annotations,
+		Use:               notesCommand.Use,
+		Short:             notesCommand.Short,
+		Long:              notesCommand.Long,
+		RunE:              notesCommand.RunE,
+		Args:              notesCommand.Args,
+		ValidArgsFunction: notesCommand.ValidArgsFunction,
+		Example:           strings.ReplaceAll(notesCommand.Example, "podman notes", "podman container notes"),
+	}
+
+	notesOptions entities.ContainerNotesOptions
+	notesFormat  string
+)
+
+// noteReporter formats a container note for the default table output.
+type noteReporter struct {
+	*define.ContainerNote
+}
+
+func (n noteReporter) Created() string {
+	return units.HumanDuration(time.Since(n.ContainerNote.Created)) + " ago"
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: notesCommand,
+	})
+	notesFlags(notesCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: notesContainerCommand,
+		Parent:  containerCmd,
+	})
+	notesFlags(notesContainerCommand)
+}
+
+func notesFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	rmFlagName := "rm"
+	flags.Int64SliceVar(&notesOptions.Remove, rmFlagName, []int64{}, "Remove the note with the given ID")
+	_ = cmd.RegisterFlagCompletionFunc(rmFlagName, completion.AutocompleteNone)
+
+	formatFlagName := "format"
+	flags.StringVar(&notesFormat, formatFlagName, "{{range .}}{{.ID}}\t{{.Created}}\t{{.Text}}\n{{end -}}", "Format note output using Go template")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&noteReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+}
+
+func notes(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		notesOptions.Add = args[1]
+	}
+	containerNotes, err := registry.ContainerEngine().ContainerNotes(registry.Context(), args[0], notesOptions)
+	if err != nil {
+		return err
+	}
+	// Print the ID of an added note, it is the most recent one.
+	if notesOptions.Add != "" {
+		fmt.Println(containerNotes[len(containerNotes)-1].ID)
+		return nil
+	}
+	if len(notesOptions.Remove) > 0 {
+		return nil
+	}
+
+	if report.IsJSON(notesFormat) {
+		b, err := json.MarshalIndent(containerNotes, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]noteReporter, 0, len(containerNotes))
+	for _, note := range containerNotes {
+		reporters = append(reporters, noteReporter{note})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, notesFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(noteReporter{}, map[string]string{
+			"ID":      "ID",
+			"Created": "CREATED",
+			"Text":    "NOTE",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
@@ -0,0 +1,64 @@
+package containers
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	refreshDescription = `Rebuilds a container from the latest version of its image.
+
+  The container keeps its ID, name, volumes, networks and dependencies. A running container is restarted from the new image.`
+	refreshCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "refresh [options] CONTAINER",
+		Short:             "Rebuild a container from an updated image",
+		Long:              refreshDescription,
+		RunE:              refresh,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman container refresh ctrID
+  podman container refresh --pull=never myctr
+  podman container refresh --image quay.io/example/app:v2 myctr
+  podman container refresh --takeover-ports myctr`,
+	}
+)
+
+var (
+	refreshOptions entities.ContainerRefreshOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: refreshCommand,
+		Parent:  containerCmd,
+	})
+	flags := refreshCommand.Flags()
+
+	imageFlagName := "image"
+	flags.StringVar(&refreshOptions.Image, imageFlagName, "", "Image to rebuild the container from instead of the image it was created from")
+	_ = refreshCommand.RegisterFlagCompletionFunc(imageFlagName, common.AutocompleteImages)
+
+	pullFlagName := "pull"
+	flags.StringVar(&refreshOptions.PullPolicy, pullFlagName, config.PullPolicyNewer.String(), `Pull image policy ("always"|"missing"|"never"|"newer")`)
+	_ = refreshCommand.RegisterFlagCompletionFunc(pullFlagName, common.AutocompletePullOption)
+
+	flags.BoolVarP(&refreshOptions.Quiet, "quiet", "q", false, "Suppress output information when pulling images")
+	flags.BoolVar(&refreshOptions.TakeoverPorts, "takeover-ports", false, "Hand the published sockets of the container to its replacement without closing them")
+}
+
+func refresh(cmd *cobra.Command, args []string) error {
+	args = utils.RemoveSlash(args)
+	report, err := registry.ContainerEngine().ContainerRefresh(registry.GetContext(), args[0], refreshOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	tm "github.com/buger/goterm"
 	"github.com/containers/common/pkg/completion"
@@ -15,6 +16,7 @@ import (
 	"github.com/containers/podman/v5/cmd/podman/validate"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/util"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
@@ -30,7 +32,8 @@ var (
 		ValidArgsFunction: common.AutocompleteContainersRunning,
 		Example: `podman stats --all --no-stream
   podman stats ctrID
-  podman stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID`,
+  podman stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID
+  podman stats --history 1h ctrID`,
 	}
 
 	containerStatsCommand = &cobra.Command{
@@ -42,7 +45,8 @@ var (
 		ValidArgsFunction: statsCommand.ValidArgsFunction,
 		Example: `podman container stats --all --no-stream
   podman container stats ctrID
-  podman container stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID`,
+  podman container stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID
+  podman container stats --history 1h ctrID`,
 	}
 )
 
@@ -55,6 +59,7 @@ type statsOptionsCLI struct {
 	NoReset  bool
 	NoStream bool
 	Interval int
+	History  string
 }
 
 var (
@@ -77,6 +82,9 @@ func statFlags(cmd *cobra.Command) {
 	intervalFlagName := "interval"
 	flags.IntVarP(&statsOptions.Interval, intervalFlagName, "i", 5, "Time in seconds between stats reports")
 	_ = cmd.RegisterFlagCompletionFunc(intervalFlagName, completion.AutocompleteNone)
+	historyFlagName := "history"
+	flags.StringVar(&statsOptions.History, historyFlagName, "", "Show the stats recorded by the stats collector of the system service since the given timestamp or duration (e.g. 1h)")
+	_ = cmd.RegisterFlagCompletionFunc(historyFlagName, completion.AutocompleteNone)
 }
 
 func init() {
@@ -110,10 +118,17 @@ func checkStatOptions(cmd *cobra.Command, args []string) error {
 	if opts > 1 {
 		return errors.New("--all, --latest and containers cannot be used together")
 	}
+	if statsOptions.History != "" && len(args) == 0 {
+		return errors.New("--history requires one or more containers")
+	}
 	return nil
 }
 
 func stats(cmd *cobra.Command, args []string) error {
+	if statsOptions.History != "" {
+		return statsHistory(cmd, putils.RemoveSlash(args))
+	}
+
 	// Convert to the entities options.  We should not leak CLI-only
 	// options into the backend and separate concerns.
 	opts := entities.ContainerStatsOptions{
@@ -138,8 +153,28 @@ func stats(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// statsHistory prints the stats of the given containers recorded by the stats
+// collector of the system service.
+func statsHistory(cmd *cobra.Command, args []string) error {
+	since, err := util.ParseInputTime(statsOptions.History, true)
+	if err != nil {
+		return fmt.Errorf("invalid --history %q: %w", statsOptions.History, err)
+	}
+
+	var reports []define.ContainerStats
+	for _, ctr := range args {
+		history, err := registry.ContainerEngine().ContainerStatsHistory(registry.Context(), ctr, entities.ContainerStatsHistoryOptions{Since: since})
+		if err != nil {
+			return err
+		}
+		reports = append(reports, history...)
+	}
+	return outputStats(cmd, reports)
+}
+
 func outputStats(cmd *cobra.Command, reports []define.ContainerStats) error {
 	headers := report.Headers(define.ContainerStats{}, map[string]string{
+		"Time":          "TIME",
 		"ID":            "ID",
 		"UpTime":        "CPU TIME",
 		"CPUPerc":       "CPU %",
@@ -151,7 +186,7 @@ func outputStats(cmd *cobra.Command, reports []define.ContainerStats) error {
 		"BlockIO":       "BLOCK IO",
 		"PIDS":          "PIDS",
 	})
-	if !statsOptions.NoReset {
+	if !statsOptions.NoReset && statsOptions.History == "" {
 		tm.Clear()
 		tm.MoveCursor(1, 1)
 		tm.Flush()
@@ -172,6 +207,9 @@ func outputStats(cmd *cobra.Command, reports []define.ContainerStats) error {
 		rpt, err = rpt.Parse(report.OriginUser, statsOptions.Format)
 	} else {
 		format := "{{range .}}{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDS}}\t{{.UpTime}}\t{{.AVGCPU}}\n{{end -}}"
+		if statsOptions.History != "" {
+			format = "{{range .}}{{.Time}}\t{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDS}}\t{{.UpTime}}\t{{.AVGCPU}}\n{{end -}}"
+		}
 		rpt, err = rpt.Parse(report.OriginPodman, format)
 	}
 	if err != nil {
@@ -197,6 +235,11 @@ func (s *containerStats) ID() string {
 	return s.ContainerID[0:12]
 }
 
+// Time returns the time the stats were taken at.
+func (s *containerStats) Time() string {
+	return time.Unix(0, int64(s.SystemNano)).Format(time.DateTime)
+}
+
 func (s *containerStats) CPUPerc() string {
 	return floatToPercentString(s.CPU)
 }
@@ -255,6 +298,7 @@ func combineBytesValues(a, b uint64) string {
 
 func outputJSON(stats []containerStats) error {
 	type jstat struct {
+		Time       string `json:"time,omitempty"`
 		Id         string `json:"id"` //nolint:revive,stylecheck
 		Name       string `json:"name"`
 		CPUTime    string `json:"cpu_time"`
@@ -268,7 +312,12 @@ func outputJSON(stats []containerStats) error {
 	}
 	jstats := make([]jstat, 0, len(stats))
 	for _, j := range stats {
+		var statsTime string
+		if statsOptions.History != "" {
+			statsTime = j.Time()
+		}
 		jstats = append(jstats, jstat{
+			Time:       statsTime,
 			Id:         j.ID(),
 			Name:       j.Name,
 			CPUTime:    j.Up(),
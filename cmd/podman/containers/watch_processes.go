@@ -0,0 +1,31 @@
+package containers
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchProcessesCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "watch-processes CONTAINER",
+		Hidden:            true,
+		Short:             "Write events for the processes of a container",
+		Long:              "Write a process event whenever a process starts or exits inside of the container, until it stops. This command is used internally for containers created with --watch-processes.",
+		RunE:              watchProcesses,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: watchProcessesCommand,
+		Parent:  containerCmd,
+	})
+}
+
+func watchProcesses(_ *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerWatchProcesses(registry.GetContext(), args[0])
+}
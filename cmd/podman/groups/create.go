@@ -0,0 +1,60 @@
+package groups
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/parse"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createDescription = `Create a container group selecting the containers matching all of the given filters.
+
+  The containers of the group are selected whenever the group is used, as @NAME in place of container names or IDs.`
+	createCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "create [options] NAME",
+		Short:             "Create a container group",
+		Long:              createDescription,
+		RunE:              create,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman group create web --filter label=tier=web
+  podman restart @web`,
+	}
+
+	createFilters []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: createCmd,
+		Parent:  groupCmd,
+	})
+	flags := createCmd.Flags()
+
+	filterFlagName := "filter"
+	flags.StringArrayVarP(&createFilters, filterFlagName, "f", []string{}, "Select the containers of the group, as for podman ps")
+	_ = createCmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePsFilters)
+}
+
+func create(cmd *cobra.Command, args []string) error {
+	filters, err := parse.FilterArgumentsIntoFilters(createFilters)
+	if err != nil {
+		return err
+	}
+	if len(filters) == 0 {
+		return fmt.Errorf("at least one --filter must be given")
+	}
+
+	group, err := registry.ContainerEngine().ContainerGroupCreate(registry.Context(), args[0], entities.ContainerGroupCreateOptions{Filters: filters})
+	if err != nil {
+		return err
+	}
+	fmt.Println(group.Name)
+	return nil
+}
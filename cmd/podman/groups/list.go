@@ -0,0 +1,121 @@
+package groups
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Args:              cobra.NoArgs,
+		Short:             "List container groups",
+		Long:              "List container groups with their filters and the containers currently in them.",
+		RunE:              list,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman group ls
+  podman group ls --format json`,
+	}
+
+	listFormat string
+)
+
+// groupReporter formats a container group for the default table output.
+type groupReporter struct {
+	*entities.ContainerGroupReport
+}
+
+// Filters returns the filters of the group as given to podman group create.
+func (r groupReporter) Filters() string {
+	filters := make([]string, 0, len(r.ContainerGroup.Filters))
+	for key, values := range r.ContainerGroup.Filters {
+		for _, value := range values {
+			filters = append(filters, key+"="+value)
+		}
+	}
+	sort.Strings(filters)
+	return strings.Join(filters, ",")
+}
+
+// Members returns the number of containers in the group.
+func (r groupReporter) Members() int {
+	return len(r.Containers)
+}
+
+func (r groupReporter) Created() string {
+	return units.HumanDuration(time.Since(r.ContainerGroup.Created)) + " ago"
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  groupCmd,
+	})
+	flags := listCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFormat, formatFlagName, "{{range .}}{{.Name}}\t{{.Filters}}\t{{.Members}}\t{{.Created}}\n{{end -}}", "Format group output using Go template")
+	_ = listCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&groupReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	groups, err := registry.ContainerEngine().ContainerGroupList(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(listFormat) {
+		b, err := json.MarshalIndent(groups, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]groupReporter, 0, len(groups))
+	for _, group := range groups {
+		reporters = append(reporters, groupReporter{group})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, listFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(groupReporter{}, map[string]string{
+			"Name":    "NAME",
+			"Filters": "FILTERS",
+			"Members": "CONTAINERS",
+			"Created": "CREATED",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
@@ -0,0 +1,43 @@
+package groups
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rmCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "rm NAME [NAME...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove container groups",
+		Long:              "Remove container groups. The containers of the groups are not affected.",
+		RunE:              rm,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteContainerGroups,
+		Example:           `podman group rm web`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rmCmd,
+		Parent:  groupCmd,
+	})
+}
+
+func rm(cmd *cobra.Command, args []string) error {
+	var errs []error
+	for _, name := range args {
+		if err := registry.ContainerEngine().ContainerGroupRm(registry.Context(), name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Println(name)
+	}
+	return errorhandling.JoinErrors(errs)
+}
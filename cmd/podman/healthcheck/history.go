@@ -0,0 +1,126 @@
+package healthcheck
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyDescription = `Displays the recorded healthcheck runs of a container, oldest first.
+
+  The number of runs kept is set by healthcheck_max_log_count in containers.conf.`
+
+	historyCmd = &cobra.Command{
+		Use:               "history [options] CONTAINER",
+		Short:             "Show the healthcheck history of a container",
+		Long:              historyDescription,
+		Example:           `podman healthcheck history mywebapp`,
+		RunE:              history,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+	}
+
+	historyOpts = struct {
+		format  string
+		noTrunc bool
+	}{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: historyCmd,
+		Parent:  healthCmd,
+	})
+
+	flags := historyCmd.Flags()
+	formatFlagName := "format"
+	flags.StringVar(&historyOpts.format, formatFlagName, "", "Change the output to JSON or a Go template")
+	_ = historyCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&historyReporter{}))
+
+	flags.BoolVar(&historyOpts.noTrunc, "no-trunc", false, "Do not truncate the output of the healthcheck runs")
+}
+
+func history(cmd *cobra.Command, args []string) error {
+	entries, err := registry.ContainerEngine().HealthCheckHistory(registry.Context(), args[0], entities.HealthCheckHistoryOptions{})
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(historyOpts.format) {
+		if len(entries) == 0 {
+			_, err := fmt.Fprintf(os.Stdout, "[]\n")
+			return err
+		}
+		enc := registry.JSONLibrary().NewEncoder(os.Stdout)
+		return enc.Encode(entries)
+	}
+
+	hr := make([]historyReporter, 0, len(entries))
+	for _, e := range entries {
+		hr = append(hr, historyReporter{e})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, historyOpts.format)
+	} else {
+		format := "{{range .}}{{.Started}}\t{{.Status}}\t{{.ExitCode}}\t{{.Output}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		hdrs := report.Headers(historyReporter{}, map[string]string{
+			"ExitCode": "EXIT CODE",
+		})
+		if err := rpt.Execute(hdrs); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(hr)
+}
+
+type historyReporter struct {
+	define.HealthCheckHistoryEntry
+}
+
+func (h historyReporter) Started() string {
+	started, err := time.Parse(time.RFC3339Nano, h.Start)
+	if err != nil {
+		return h.Start
+	}
+	return units.HumanDuration(time.Since(started)) + " ago"
+}
+
+func (h historyReporter) Status() string {
+	if h.HealthCheckHistoryEntry.Status == "" {
+		return "-"
+	}
+	return h.HealthCheckHistoryEntry.Status
+}
+
+func (h historyReporter) Output() string {
+	output := strings.TrimSpace(h.HealthCheckHistoryEntry.Output)
+	if historyOpts.noTrunc {
+		return output
+	}
+	output, _, _ = strings.Cut(output, "\n")
+	if len(output) > 50 {
+		output = output[:47] + "..."
+	}
+	return output
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/utils"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
@@ -41,10 +42,11 @@ var (
 	}
 
 	opts = struct {
-		human   bool
-		noTrunc bool
-		quiet   bool
-		format  string
+		human      bool
+		noTrunc    bool
+		quiet      bool
+		provenance bool
+		format     string
 	}{}
 )
 
@@ -70,15 +72,19 @@ func historyFlags(cmd *cobra.Command) {
 
 	flags.BoolVarP(&opts.human, "human", "H", true, "Display sizes and dates in human readable format")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate the output")
+	flags.BoolVar(&opts.provenance, "provenance", false, "Display the sources the image was pulled from and the images pulled from them over time")
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Display the numeric IDs only")
 	flags.SetNormalizeFunc(utils.AliasFlags)
 }
 
 func history(cmd *cobra.Command, args []string) error {
-	results, err := registry.ImageEngine().History(registry.Context(), args[0], entities.ImageHistoryOptions{})
+	results, err := registry.ImageEngine().History(registry.Context(), args[0], entities.ImageHistoryOptions{Provenance: opts.provenance})
 	if err != nil {
 		return err
 	}
+	if opts.provenance {
+		return printProvenance(cmd, results.Provenance)
+	}
 
 	if report.IsJSON(opts.format) {
 		var err error
@@ -135,6 +141,67 @@ func history(cmd *cobra.Command, args []string) error {
 	return rpt.Execute(hr)
 }
 
+func printProvenance(cmd *cobra.Command, provenance []*define.ImageProvenance) error {
+	if report.IsJSON(opts.format) {
+		if len(provenance) == 0 {
+			_, err := fmt.Fprintf(os.Stdout, "[]\n")
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		return enc.Encode(provenance)
+	}
+
+	pr := make([]provenanceReporter, 0, len(provenance))
+	for _, p := range provenance {
+		pr = append(pr, provenanceReporter{p})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	var err error
+	switch {
+	case opts.quiet:
+		rpt, err = rpt.Parse(report.OriginUser, "{{range .}}{{.ImageID}}\n{{end -}}")
+	case cmd.Flags().Changed("format"):
+		rpt, err = rpt.Parse(report.OriginUser, cmd.Flag("format").Value.String())
+	default:
+		format := "{{range .}}{{.Pulled}}\t{{.Source}}\t{{.ImageID}}\t{{.Digest}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		hdrs := report.Headers(provenanceReporter{}, map[string]string{
+			"ImageID": "IMAGE ID",
+		})
+		if err := rpt.Execute(hdrs); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(pr)
+}
+
+type provenanceReporter struct {
+	*define.ImageProvenance
+}
+
+func (p provenanceReporter) Pulled() string {
+	if opts.human {
+		return units.HumanDuration(time.Since(p.ImageProvenance.Pulled)) + " ago"
+	}
+	return p.ImageProvenance.Pulled.Format(time.RFC3339)
+}
+
+func (p provenanceReporter) ImageID() string {
+	if !opts.noTrunc && len(p.ImageProvenance.ImageID) >= 12 {
+		return p.ImageProvenance.ImageID[0:12]
+	}
+	return p.ImageProvenance.ImageID
+}
+
 type historyReporter struct {
 	entities.ImageHistoryLayer
 }
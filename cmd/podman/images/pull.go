@@ -79,6 +79,7 @@ func init() {
 func pullFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 
+	flags.BoolVar(&pullOptions.AcceptNewDigest, "accept-new-digest", false, "Trust and pin the digest of the image if it changed since the tag was first pulled")
 	flags.BoolVarP(&pullOptions.AllTags, "all-tags", "a", false, "All tagged images in the repository will be pulled")
 
 	credsFlagName := "creds"
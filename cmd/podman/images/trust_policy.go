@@ -0,0 +1,26 @@
+package images
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trustPolicyDescription = `Safely edit the trust policy in policy.json and the signature lookaside locations in registries.d.
+  The files are validated and replaced atomically.`
+	trustPolicyCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "policy",
+		Short:       "Edit and test the trust policy",
+		Long:        trustPolicyDescription,
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: trustPolicyCmd,
+		Parent:  trustCmd,
+	})
+}
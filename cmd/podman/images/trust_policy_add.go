@@ -0,0 +1,67 @@
+package images
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trustPolicyAddDescription = `Add the trust policy of a scope of a transport, or the default policy with "default".
+
+  The public key files must exist.  An existing policy is only replaced with --replace.`
+	trustPolicyAddCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "add [options] SCOPE",
+		Short:             "Add a trust policy",
+		Long:              trustPolicyAddDescription,
+		RunE:              trustPolicyAdd,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteRegistries,
+		Example: `podman image trust policy add --type reject default
+  podman image trust policy add --type sigstoreSigned --pubkeysfile /etc/pki/containers/quay.pub quay.io/podman
+  podman image trust policy add --type signedBy --pubkeysfile /etc/pki/containers/key.gpg --lookaside https://example.com/sigstore registry.example.com`,
+	}
+)
+
+var (
+	trustPolicyAddOptions entities.TrustPolicyAddOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: trustPolicyAddCommand,
+		Parent:  trustPolicyCmd,
+	})
+	flags := trustPolicyAddCommand.Flags()
+
+	lookasideFlagName := "lookaside"
+	flags.StringVar(&trustPolicyAddOptions.Lookaside, lookasideFlagName, "", "Location of the signatures of the scope, added to registries.d")
+	_ = trustPolicyAddCommand.RegisterFlagCompletionFunc(lookasideFlagName, completion.AutocompleteNone)
+
+	flags.StringVar(&trustPolicyAddOptions.PolicyPath, "policypath", "", "")
+	_ = flags.MarkHidden("policypath")
+
+	pubkeysfileFlagName := "pubkeysfile"
+	flags.StringArrayVarP(&trustPolicyAddOptions.PubKeysFile, pubkeysfileFlagName, "f", []string{}, "Path of a public key to trust for SCOPE, may be used multiple times")
+	_ = trustPolicyAddCommand.RegisterFlagCompletionFunc(pubkeysfileFlagName, completion.AutocompleteDefault)
+
+	flags.StringVar(&trustPolicyAddOptions.RegistryPath, "registrypath", "", "")
+	_ = flags.MarkHidden("registrypath")
+
+	flags.BoolVar(&trustPolicyAddOptions.Replace, "replace", false, "Replace the existing trust policy of the scope")
+
+	transportFlagName := "transport"
+	flags.StringVar(&trustPolicyAddOptions.Transport, transportFlagName, "docker", "Transport of the scope")
+	_ = trustPolicyAddCommand.RegisterFlagCompletionFunc(transportFlagName, completion.AutocompleteNone)
+
+	typeFlagName := "type"
+	flags.StringVarP(&trustPolicyAddOptions.Type, typeFlagName, "t", "signedBy", "Trust type, accept values: signedBy(default), sigstoreSigned, accept, reject")
+	_ = trustPolicyAddCommand.RegisterFlagCompletionFunc(typeFlagName, common.AutocompleteTrustType)
+}
+
+func trustPolicyAdd(cmd *cobra.Command, args []string) error {
+	return registry.ImageEngine().TrustPolicyAdd(registry.Context(), args[0], trustPolicyAddOptions)
+}
@@ -0,0 +1,49 @@
+package images
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trustPolicyRemoveDescription = `Remove the trust policy of a scope of a transport, and the lookaside location added for it by podman image trust policy add.`
+	trustPolicyRemoveCommand     = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "remove [options] SCOPE",
+		Aliases:           []string{"rm"},
+		Short:             "Remove a trust policy",
+		Long:              trustPolicyRemoveDescription,
+		RunE:              trustPolicyRemove,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteRegistries,
+		Example:           `podman image trust policy remove quay.io/podman`,
+	}
+)
+
+var (
+	trustPolicyRemoveOptions entities.TrustPolicyRemoveOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: trustPolicyRemoveCommand,
+		Parent:  trustPolicyCmd,
+	})
+	flags := trustPolicyRemoveCommand.Flags()
+
+	flags.StringVar(&trustPolicyRemoveOptions.PolicyPath, "policypath", "", "")
+	_ = flags.MarkHidden("policypath")
+	flags.StringVar(&trustPolicyRemoveOptions.RegistryPath, "registrypath", "", "")
+	_ = flags.MarkHidden("registrypath")
+
+	transportFlagName := "transport"
+	flags.StringVar(&trustPolicyRemoveOptions.Transport, transportFlagName, "docker", "Transport of the scope")
+	_ = trustPolicyRemoveCommand.RegisterFlagCompletionFunc(transportFlagName, completion.AutocompleteNone)
+}
+
+func trustPolicyRemove(cmd *cobra.Command, args []string) error {
+	return registry.ImageEngine().TrustPolicyRemove(registry.Context(), args[0], trustPolicyRemoveOptions)
+}
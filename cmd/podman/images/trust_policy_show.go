@@ -0,0 +1,97 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trustPolicyShowDescription = `Display the trust policy, or with --test the trust policy applying to an image and the scopes looked up to find it.`
+	trustPolicyShowCommand     = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "show [options]",
+		Short:             "Display the trust policy",
+		Long:              trustPolicyShowDescription,
+		RunE:              trustPolicyShow,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman image trust policy show
+  podman image trust policy show --test quay.io/podman/stable`,
+	}
+)
+
+var (
+	trustPolicyShowOptions   entities.ShowTrustOptions
+	trustPolicyShowNoHeading bool
+	trustPolicyShowTest      string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: trustPolicyShowCommand,
+		Parent:  trustPolicyCmd,
+	})
+	flags := trustPolicyShowCommand.Flags()
+	flags.BoolVarP(&trustPolicyShowOptions.JSON, "json", "j", false, "Output as json")
+	flags.BoolVarP(&trustPolicyShowNoHeading, "noheading", "n", false, "Do not print column headings")
+	flags.StringVar(&trustPolicyShowOptions.PolicyPath, "policypath", "", "")
+	_ = flags.MarkHidden("policypath")
+	flags.BoolVar(&trustPolicyShowOptions.Raw, "raw", false, "Output raw policy file")
+	flags.StringVar(&trustPolicyShowOptions.RegistryPath, "registrypath", "", "")
+	_ = flags.MarkHidden("registrypath")
+
+	testFlagName := "test"
+	flags.StringVar(&trustPolicyShowTest, testFlagName, "", "Show the trust policy applying to `IMAGE`")
+	_ = trustPolicyShowCommand.RegisterFlagCompletionFunc(testFlagName, common.AutocompleteImages)
+}
+
+func trustPolicyShow(cmd *cobra.Command, args []string) error {
+	if trustPolicyShowTest == "" {
+		trust, err := registry.ImageEngine().ShowTrust(registry.Context(), args, trustPolicyShowOptions)
+		if err != nil {
+			return err
+		}
+		switch {
+		case trustPolicyShowOptions.Raw:
+			fmt.Println(string(trust.Raw))
+			return nil
+		case trustPolicyShowOptions.JSON:
+			b, err := json.MarshalIndent(trust.Policies, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+			return nil
+		}
+		return printTrustPolicies(cmd, trust.Policies, trustPolicyShowNoHeading)
+	}
+
+	if trustPolicyShowOptions.Raw {
+		return errors.New("--raw and --test are mutually exclusive")
+	}
+	match, err := registry.ImageEngine().TrustPolicyTest(registry.Context(), trustPolicyShowTest, entities.TrustPolicyTestOptions{
+		PolicyPath:   trustPolicyShowOptions.PolicyPath,
+		RegistryPath: trustPolicyShowOptions.RegistryPath,
+	})
+	if err != nil {
+		return err
+	}
+	if trustPolicyShowOptions.JSON {
+		b, err := json.MarshalIndent(match, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "Image: %s\nChecked scopes: %s\n", match.Image, strings.Join(match.Checked, ", "))
+	return printTrustPolicies(cmd, match.Policies, trustPolicyShowNoHeading)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/trust"
 	"github.com/spf13/cobra"
 )
 
@@ -63,6 +64,11 @@ func showTrust(cmd *cobra.Command, args []string) error {
 		fmt.Println(string(b))
 		return nil
 	}
+	return printTrustPolicies(cmd, trust.Policies, noHeading)
+}
+
+// printTrustPolicies prints the trust policies as a table.
+func printTrustPolicies(cmd *cobra.Command, policies []*trust.Policy, noHeading bool) error {
 	rpt := report.New(os.Stdout, cmd.Name())
 	defer rpt.Flush()
 
@@ -73,7 +79,7 @@ func showTrust(cmd *cobra.Command, args []string) error {
 		"GPGId":          "Id",
 		"SignatureStore": "Store",
 	})
-	rpt, err = rpt.Parse(report.OriginPodman,
+	rpt, err := rpt.Parse(report.OriginPodman,
 		"{{range . }}{{.Transport}}\t{{.RepoName}}\t{{.Type}}\t{{.GPGId}}\t{{.SignatureStore}}\n{{end -}}")
 	if err != nil {
 		return err
@@ -83,5 +89,5 @@ func showTrust(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	}
-	return rpt.Execute(trust.Policies)
+	return rpt.Execute(policies)
 }
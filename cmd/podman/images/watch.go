@@ -0,0 +1,134 @@
+package images
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/auth"
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchDescription = `Watch images for new digests on their registry and pull them as soon as they are pushed.
+
+  Each image is checked at the interval given after "=", or at the --interval otherwise.  Without images, the images of the containers with the "registry" auto-update policy are watched.
+  With --auto-restart, the systemd units of the containers with an auto-update policy using a pulled image are restarted, as with podman auto-update.`
+	watchCmd = &cobra.Command{
+		Use:               "watch [options] [IMAGE[=INTERVAL]...]",
+		Short:             "Watch images and pull them when their digest changes",
+		Long:              watchDescription,
+		RunE:              watch,
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image watch quay.io/team/web:latest
+  podman image watch --auto-restart --interval 10m
+  podman image watch --auto-restart quay.io/team/web:latest=1m quay.io/team/db:latest=1h`,
+	}
+
+	watchOpts = struct {
+		entities.ImageWatchOptions
+		format    string
+		tlsVerify bool
+	}{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: watchCmd,
+		Parent:  imageCmd,
+	})
+
+	flags := watchCmd.Flags()
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&watchOpts.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = watchCmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&watchOpts.AutoRestart, "auto-restart", false, "Restart the systemd units of the containers with an auto-update policy using a pulled image")
+
+	formatFlagName := "format"
+	flags.StringVar(&watchOpts.format, formatFlagName, "", "Change the output format to JSON or a Go template")
+	_ = watchCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.ImageWatchReport{}))
+
+	intervalFlagName := "interval"
+	flags.DurationVar(&watchOpts.Interval, intervalFlagName, 5*time.Minute, "Interval between two checks of the images without their own interval")
+	_ = watchCmd.RegisterFlagCompletionFunc(intervalFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&watchOpts.Rollback, "rollback", true, "Rollback to previous image if restarting a unit with a pulled image fails")
+	flags.BoolVar(&watchOpts.tlsVerify, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+}
+
+func watch(cmd *cobra.Command, args []string) error {
+	if watchOpts.Interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(watchOpts.Authfile); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("tls-verify") {
+		watchOpts.SkipTLSVerify = types.NewOptionalBool(!watchOpts.tlsVerify)
+	}
+	watchOpts.Images = args
+
+	var (
+		rpt    *report.Formatter
+		doJSON bool
+	)
+	if cmd.Flags().Changed("format") {
+		doJSON = report.IsJSON(watchOpts.format)
+		if !doJSON {
+			var err error
+			// Use OriginUnknown so it does not add an extra range since it
+			// will only be called for each single element and not a slice.
+			rpt, err = report.New(os.Stdout, cmd.Name()).Parse(report.OriginUnknown, watchOpts.format)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	watchReports, err := registry.ImageEngine().Watch(registry.Context(), watchOpts.ImageWatchOptions)
+	if err != nil {
+		return err
+	}
+	for r := range watchReports {
+		switch {
+		case doJSON:
+			jsonReport, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(jsonReport))
+		case rpt != nil:
+			if err := rpt.Execute(r); err != nil {
+				return err
+			}
+		default:
+			printWatchReport(r)
+		}
+	}
+	return nil
+}
+
+// printWatchReport prints a human-readable line for the pulled image and each
+// restarted container of r.
+func printWatchReport(r entities.ImageWatchReport) {
+	timestamp := r.Time.Format(time.RFC3339)
+	if r.ID != "" {
+		fmt.Printf("%s %s pulled %s\n", timestamp, r.Image, r.Digest)
+	}
+	for _, update := range r.Updates {
+		fmt.Printf("%s %s restarted unit %s of container %s (%s): %s\n", timestamp, r.Image, update.SystemdUnit, update.ContainerID[:12], update.ContainerName, update.Updated)
+	}
+	if r.Error != "" {
+		fmt.Fprintf(os.Stderr, "%s %s: %s\n", timestamp, r.Image, r.Error)
+	}
+}
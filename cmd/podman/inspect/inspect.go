@@ -9,11 +9,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/containers/common/pkg/completion"
 	"github.com/containers/common/pkg/report"
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/validate"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/inspect"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +26,7 @@ func AddInspectFlagSet(cmd *cobra.Command) *entities.InspectOptions {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.Size, "size", "s", false, "Display total file size")
+	flags.BoolVar(&opts.EffectiveConfig, "effective-config", false, "Display the containers.conf settings the container was created with")
 
 	formatFlagName := "format"
 	flags.StringVarP(&opts.Format, formatFlagName, "f", "json", "Format the output to a Go template or json")
@@ -33,10 +36,19 @@ func AddInspectFlagSet(cmd *cobra.Command) *entities.InspectOptions {
 	flags.StringVarP(&opts.Type, typeFlagName, "t", common.AllType, "Specify inspect-object type")
 	_ = cmd.RegisterFlagCompletionFunc(typeFlagName, common.AutocompleteInspectType)
 
+	AddFieldsFlag(cmd, &opts)
+
 	validate.AddLatestFlag(cmd, &opts.Latest)
 	return &opts
 }
 
+// AddFieldsFlag adds the --fields flag to select the inspected fields.
+func AddFieldsFlag(cmd *cobra.Command, opts *entities.InspectOptions) {
+	fieldsFlagName := "fields"
+	cmd.Flags().StringSliceVar(&opts.Fields, fieldsFlagName, nil, "Only compute and print the given fields, e.g. State.Status")
+	_ = cmd.RegisterFlagCompletionFunc(fieldsFlagName, completion.AutocompleteNone)
+}
+
 // Inspect inspects the specified container/image/pod/volume names or IDs.
 func Inspect(namesOrIDs []string, options entities.InspectOptions) error {
 	inspector, err := newInspector(options)
@@ -66,6 +78,9 @@ func newInspector(options entities.InspectOptions) (*inspector, error) {
 	if options.Type == common.PodType && options.Size {
 		return nil, fmt.Errorf("size is not supported for type %q", common.PodType)
 	}
+	if options.EffectiveConfig && options.Type != common.ContainerType && options.Type != common.AllType {
+		return nil, fmt.Errorf("effective-config is not supported for type %q", options.Type)
+	}
 	return &inspector{
 		containerEngine: registry.ContainerEngine(),
 		imageEngine:     registry.ImageEngine(),
@@ -163,6 +178,13 @@ func (i *inspector) inspect(namesOrIDs []string) error {
 	var err error
 	switch {
 	case report.IsJSON(i.options.Format) || i.options.Format == "":
+		if len(i.options.Fields) > 0 {
+			for j := range data {
+				if data[j], err = inspect.SelectFields(data[j], i.options.Fields); err != nil {
+					return err
+				}
+			}
+		}
 		err = printJSON(data)
 	default:
 		// Landing here implies user has given a custom --format
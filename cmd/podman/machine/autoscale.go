@@ -0,0 +1,81 @@
+//go:build amd64 || arm64
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/machine/autoscale"
+	"github.com/containers/podman/v5/pkg/machine/env"
+	"github.com/containers/podman/v5/pkg/machine/vmconfigs"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autoscaleCmd = &cobra.Command{
+		Use:               "autoscale [options] [MACHINE]",
+		Hidden:            true,
+		Short:             "Scale the resources of a running machine",
+		Long:              "Adjust the CPUs and memory of a running machine to the container load until it stops",
+		PersistentPreRunE: machinePreRunE,
+		RunE:              autoscaleMachine,
+		Args:              cobra.MaximumNArgs(1),
+		Example:           `podman machine autoscale podman-machine-default`,
+		ValidArgsFunction: autocompleteMachine,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: autoscaleCmd,
+		Parent:  machineCmd,
+	})
+}
+
+func autoscaleMachine(_ *cobra.Command, args []string) error {
+	vmName := defaultMachineName
+	if len(args) > 0 && len(args[0]) > 0 {
+		vmName = args[0]
+	}
+
+	dirs, err := env.GetMachineDirs(provider.VMType())
+	if err != nil {
+		return err
+	}
+	mc, err := vmconfigs.LoadMachineByName(vmName, dirs)
+	if err != nil {
+		return err
+	}
+	return autoscale.Run(context.Background(), mc, provider, autoscale.DefaultInterval)
+}
+
+// startAutoscaler runs "podman machine autoscale" in the background for
+// machines with auto-scaling enabled.  It exits on its own when the machine
+// stops.
+func startAutoscaler(mc *vmconfigs.MachineConfig) error {
+	if mc.Resources.AutoScale == nil {
+		return nil
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	scaleArgs := []string{}
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		scaleArgs = append(scaleArgs, "--log-level=debug")
+	}
+	scaleArgs = append(scaleArgs, "machine", "autoscale", mc.Name)
+
+	logrus.Debugf("Going to start autoscaler using command: %s %v", executable, scaleArgs)
+	scaleCmd := exec.Command(executable, scaleArgs...)
+	if err := scaleCmd.Start(); err != nil {
+		return fmt.Errorf("unable to start autoscaler: %w", err)
+	}
+	logrus.Infof("Started machine autoscaler as PID %d", scaleCmd.Process.Pid)
+	return scaleCmd.Process.Release()
+}
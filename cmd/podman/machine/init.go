@@ -3,8 +3,10 @@
 package machine
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/common/pkg/strongunits"
@@ -40,6 +42,8 @@ var (
 
 // Flags which have a meaning when unspecified that differs from the flag default
 type InitOptionalFlags struct {
+	FromWSL            string
+	ImageURL           string
 	UserModeNetworking bool
 }
 
@@ -116,6 +120,18 @@ func init() {
 		logrus.Error("unable to mark image-path flag deprecated")
 	}
 
+	ImageURLFlagName := "image-url"
+	flags.StringVar(&initOptionalFlags.ImageURL, ImageURLFlagName, "", "Download the bootable image from an http(s) URL and verify its checksum")
+	_ = initCmd.RegisterFlagCompletionFunc(ImageURLFlagName, completion.AutocompleteNone)
+
+	ImageChecksumFlagName := "image-checksum"
+	flags.StringVar(&initOpts.ImageChecksum, ImageChecksumFlagName, "", "Expected checksum (sha256:HEX) of the image downloaded from --image-url or an http(s) --image")
+	_ = initCmd.RegisterFlagCompletionFunc(ImageChecksumFlagName, completion.AutocompleteNone)
+
+	FromWSLFlagName := "from-wsl"
+	flags.StringVar(&initOptionalFlags.FromWSL, FromWSLFlagName, "", "Create the machine from a copy of an existing WSL distribution")
+	_ = initCmd.RegisterFlagCompletionFunc(FromWSLFlagName, completion.AutocompleteNone)
+
 	VolumeFlagName := "volume"
 	flags.StringArrayVarP(&initOpts.Volumes, VolumeFlagName, "v", cfg.ContainersConfDefaultsRO.Machine.Volumes.Get(), "Volumes to mount, source:target")
 	_ = initCmd.RegisterFlagCompletionFunc(VolumeFlagName, completion.AutocompleteDefault)
@@ -201,6 +217,10 @@ func initMachine(cmd *cobra.Command, args []string) error {
 		initOpts.UserModeNetworking = &initOptionalFlags.UserModeNetworking
 	}
 
+	if err := setInitImageSource(cmd); err != nil {
+		return err
+	}
+
 	if cmd.Flags().Changed("memory") {
 		if err := checkMaxMemory(strongunits.MiB(initOpts.Memory)); err != nil {
 			return err
@@ -250,3 +270,39 @@ func checkMaxMemory(newMem strongunits.MiB) error {
 	}
 	return nil
 }
+
+// setInitImageSource applies --image-url, --image-checksum and --from-wsl,
+// which are all alternative ways to specify --image.
+func setInitImageSource(cmd *cobra.Command) error {
+	changed := 0
+	for _, name := range []string{"image", "image-path", "image-url", "from-wsl"} {
+		if cmd.Flags().Changed(name) {
+			changed++
+		}
+	}
+	if changed > 1 {
+		return errors.New("only one of --image, --image-url and --from-wsl can be specified")
+	}
+
+	switch {
+	case cmd.Flags().Changed("image-url"):
+		if !strings.HasPrefix(initOptionalFlags.ImageURL, "https://") && !strings.HasPrefix(initOptionalFlags.ImageURL, "http://") {
+			return fmt.Errorf("--image-url must be an http or https URL: %q", initOptionalFlags.ImageURL)
+		}
+		initOpts.Image = initOptionalFlags.ImageURL
+		initOpts.VerifyImage = true
+	case cmd.Flags().Changed("from-wsl"):
+		if provider.VMType() != define.WSLVirt {
+			return fmt.Errorf("--from-wsl is only supported with the %s provider", define.WSLVirt.String())
+		}
+		initOpts.Image = define.WSLDistroScheme + initOptionalFlags.FromWSL
+	}
+
+	if initOpts.ImageChecksum != "" {
+		if !strings.HasPrefix(initOpts.Image, "https://") && !strings.HasPrefix(initOpts.Image, "http://") {
+			return errors.New("--image-checksum requires an http or https image")
+		}
+		initOpts.VerifyImage = true
+	}
+	return nil
+}
@@ -3,6 +3,8 @@
 package machine
 
 import (
+	"errors"
+
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/common/pkg/strongunits"
 	"github.com/containers/podman/v5/cmd/podman/registry"
@@ -32,6 +34,7 @@ var (
 )
 
 type SetFlags struct {
+	AutoScale          string
 	CPUs               uint64
 	DiskSize           uint64
 	Memory             uint64
@@ -50,6 +53,14 @@ func init() {
 	rootfulFlagName := "rootful"
 	flags.BoolVar(&setFlags.Rootful, rootfulFlagName, false, "Whether this machine should prefer rootful container execution")
 
+	autoScaleFlagName := "auto-scale"
+	flags.StringVar(
+		&setFlags.AutoScale,
+		autoScaleFlagName, "",
+		"Scale CPUs and memory (MiB) to the container load within cpus=MIN-MAX,memory=MIN-MAX, or \"off\"",
+	)
+	_ = setCmd.RegisterFlagCompletionFunc(autoScaleFlagName, completion.AutocompleteNone)
+
 	cpusFlagName := "cpus"
 	flags.Uint64Var(
 		&setFlags.CPUs,
@@ -106,6 +117,21 @@ func setMachine(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("rootful") {
 		setOpts.Rootful = &setFlags.Rootful
 	}
+	if cmd.Flags().Changed("auto-scale") {
+		if cmd.Flags().Changed("cpus") || cmd.Flags().Changed("memory") {
+			return errors.New("--auto-scale cannot be combined with --cpus or --memory")
+		}
+		autoScale, err := define.ParseAutoScale(setFlags.AutoScale)
+		if err != nil {
+			return err
+		}
+		if !autoScale.IsZero() {
+			if err := checkMaxMemory(autoScale.MaxMemory); err != nil {
+				return err
+			}
+		}
+		setOpts.AutoScale = &autoScale
+	}
 	if cmd.Flags().Changed("cpus") {
 		setOpts.CPUs = &setFlags.CPUs
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/containers/podman/v5/pkg/machine/env"
 	"github.com/containers/podman/v5/pkg/machine/shim"
 	"github.com/containers/podman/v5/pkg/machine/vmconfigs"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -70,6 +71,9 @@ func start(_ *cobra.Command, args []string) error {
 	if err := shim.Start(mc, provider, dirs, startOpts); err != nil {
 		return err
 	}
+	if err := startAutoscaler(mc); err != nil {
+		logrus.Warnf("Machine %q started but auto-scaling is not active: %v", vmName, err)
+	}
 	fmt.Printf("Machine %q started successfully\n", vmName)
 	newMachineEvent(events.Start, events.Event{Name: vmName})
 	return nil
@@ -10,6 +10,7 @@ import (
 	_ "github.com/containers/podman/v5/cmd/podman/completion"
 	_ "github.com/containers/podman/v5/cmd/podman/farm"
 	_ "github.com/containers/podman/v5/cmd/podman/generate"
+	_ "github.com/containers/podman/v5/cmd/podman/groups"
 	_ "github.com/containers/podman/v5/cmd/podman/healthcheck"
 	_ "github.com/containers/podman/v5/cmd/podman/images"
 	_ "github.com/containers/podman/v5/cmd/podman/kube"
@@ -22,6 +23,7 @@ import (
 	_ "github.com/containers/podman/v5/cmd/podman/secrets"
 	_ "github.com/containers/podman/v5/cmd/podman/system"
 	_ "github.com/containers/podman/v5/cmd/podman/system/connection"
+	_ "github.com/containers/podman/v5/cmd/podman/templates"
 	"github.com/containers/podman/v5/cmd/podman/validate"
 	_ "github.com/containers/podman/v5/cmd/podman/volumes"
 	"github.com/containers/podman/v5/pkg/domain/entities"
@@ -0,0 +1,99 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkDisturbDescription = `Inject latency and packet loss in the outgoing traffic of a running container for a limited time.
+
+  The disturbance is reverted when the duration elapsed or the command is interrupted.`
+	networkDisturbCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "disturb [options] CONTAINER",
+		Short:             "Inject latency and packet loss in the network of a container",
+		Long:              networkDisturbDescription,
+		RunE:              networkDisturb,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman network disturb --latency 100ms ctr1
+  podman network disturb --latency 100ms --loss 1% --duration 5m ctr1
+  podman network disturb --loss 10% --interface eth1 --duration 0 ctr1
+  podman network disturb --clear ctr1`,
+	}
+)
+
+var (
+	networkDisturbOptions entities.NetworkDisturbOptions
+	networkDisturbLoss    string
+)
+
+func networkDisturbFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	latencyFlagName := "latency"
+	flags.DurationVar(&networkDisturbOptions.Latency, latencyFlagName, 0, "Latency added to the outgoing packets")
+	_ = cmd.RegisterFlagCompletionFunc(latencyFlagName, completion.AutocompleteNone)
+
+	lossFlagName := "loss"
+	flags.StringVar(&networkDisturbLoss, lossFlagName, "", "Percentage of outgoing packets dropped")
+	_ = cmd.RegisterFlagCompletionFunc(lossFlagName, completion.AutocompleteNone)
+
+	durationFlagName := "duration"
+	flags.DurationVar(&networkDisturbOptions.Duration, durationFlagName, time.Minute, "Revert the disturbance after the duration, 0 to keep it until interrupted")
+	_ = cmd.RegisterFlagCompletionFunc(durationFlagName, completion.AutocompleteNone)
+
+	interfaceFlagName := "interface"
+	flags.StringVar(&networkDisturbOptions.Interface, interfaceFlagName, "", "Interface of the container to disturb, all but the loopback by default")
+	_ = cmd.RegisterFlagCompletionFunc(interfaceFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&networkDisturbOptions.Clear, "clear", false, "Revert all disturbances of the container")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkDisturbCommand,
+		Parent:  networkCmd,
+	})
+	networkDisturbFlags(networkDisturbCommand)
+}
+
+func networkDisturb(cmd *cobra.Command, args []string) error {
+	if networkDisturbOptions.Clear {
+		for _, name := range []string{"latency", "loss", "duration", "interface"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--clear and --%s cannot be used together", name)
+			}
+		}
+		return registry.ContainerEngine().NetworkDisturb(registry.Context(), args[0], networkDisturbOptions)
+	}
+
+	if networkDisturbLoss != "" {
+		loss, err := strconv.ParseFloat(strings.TrimSuffix(networkDisturbLoss, "%"), 64)
+		if err != nil {
+			return fmt.Errorf("invalid packet loss %q: %w", networkDisturbLoss, err)
+		}
+		networkDisturbOptions.Loss = loss
+	}
+
+	ctx, stop := signal.NotifyContext(registry.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if networkDisturbOptions.Duration > 0 {
+		fmt.Fprintf(os.Stderr, "Disturbing the network of %s for %s, interrupt to revert earlier\n", args[0], networkDisturbOptions.Duration)
+	} else {
+		fmt.Fprintf(os.Stderr, "Disturbing the network of %s, interrupt to revert\n", args[0])
+	}
+	return registry.ContainerEngine().NetworkDisturb(ctx, args[0], networkDisturbOptions)
+}
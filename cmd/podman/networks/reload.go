@@ -1,15 +1,18 @@
 package network
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/utils"
 	"github.com/containers/podman/v5/cmd/podman/validate"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/systemd"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/spf13/pflag"
 )
 
 var (
@@ -21,20 +24,36 @@ var (
 		Long:        networkReloadDescription,
 		RunE:        networkReload,
 		Args: func(cmd *cobra.Command, args []string) error {
-			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
+			return validate.CheckAllLatestAndIDFile(cmd, args, true, "")
 		},
 		ValidArgsFunction: common.AutocompleteContainers,
 		Example: `podman network reload 3c13ef6dd843
-  podman network reload test1 test2`,
+  podman network reload test1 test2
+  podman network reload --pod mypod
+  podman network reload --all --watch`,
 	}
 )
 
 var (
 	reloadOptions entities.NetworkReloadOptions
+	reloadWatch   bool
+	reloadVerbose bool
 )
 
-func reloadFlags(flags *pflag.FlagSet) {
+func reloadFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
 	flags.BoolVarP(&reloadOptions.All, "all", "a", false, "Reload network configuration of all containers")
+
+	podFlagName := "pod"
+	flags.StringSliceVar(&reloadOptions.Pods, podFlagName, nil, "Reload network configuration of all containers in the pod")
+	_ = cmd.RegisterFlagCompletionFunc(podFlagName, common.AutocompletePods)
+
+	projectFlagName := "project"
+	flags.StringSliceVar(&reloadOptions.Projects, projectFlagName, nil, "Reload network configuration of all containers of the compose project")
+	_ = cmd.RegisterFlagCompletionFunc(projectFlagName, completion.AutocompleteNone)
+
+	flags.BoolVarP(&reloadWatch, "watch", "w", false, "Reload again whenever firewalld reloads or restarts")
+	flags.BoolVarP(&reloadVerbose, "verbose", "v", false, "Print the port mappings of each container")
 }
 
 func init() {
@@ -42,11 +61,37 @@ func init() {
 		Command: networkReloadCommand,
 		Parent:  networkCmd,
 	})
-	reloadFlags(networkReloadCommand.Flags())
+	reloadFlags(networkReloadCommand)
 	validate.AddLatestFlag(networkReloadCommand, &reloadOptions.Latest)
 }
 
 func networkReload(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !reloadOptions.All && !reloadOptions.Latest &&
+		len(reloadOptions.Pods) == 0 && len(reloadOptions.Projects) == 0 {
+		return errors.New("specify one or more containers, --pod, --project, --latest or --all")
+	}
+
+	if !reloadWatch {
+		return reload(args)
+	}
+
+	events, err := systemd.WatchFirewalld(registry.Context())
+	if err != nil {
+		return fmt.Errorf("watching for firewalld reloads: %w", err)
+	}
+	if err := reload(args); err != nil {
+		logrus.Error(err)
+	}
+	for range events {
+		logrus.Info("Firewall rules were flushed, reloading container networks")
+		if err := reload(args); err != nil {
+			logrus.Error(err)
+		}
+	}
+	return nil
+}
+
+func reload(args []string) error {
 	responses, err := registry.ContainerEngine().NetworkReload(registry.Context(), args, reloadOptions)
 	if err != nil {
 		return err
@@ -56,6 +101,11 @@ func networkReload(cmd *cobra.Command, args []string) error {
 	for _, r := range responses {
 		if r.Err == nil {
 			fmt.Println(r.Id)
+			if reloadVerbose {
+				for _, port := range r.Ports {
+					fmt.Printf("  %s\n", port)
+				}
+			}
 		} else {
 			errs = append(errs, r.Err)
 		}
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkReserveCommand = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "reserve",
+		Short:       "Manage reserved addresses of networks",
+		Long:        "Reserve IP and MAC addresses of networks for a container or pod, so they are never assigned to another container",
+		RunE:        validate.SubCommandExists,
+	}
+
+	networkReserveIPDescription = `Reserve an IP address of a network for a container or pod.
+
+  The container is given the address whenever it joins the network without requesting a static IP, and no other container is given the address. The container does not need to exist yet. The network is inferred from the subnet of the address if not given.`
+	networkReserveIPCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "ip [options] ADDRESS",
+		Short:             "Reserve an IP address of a network",
+		Long:              networkReserveIPDescription,
+		RunE:              networkReserve,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman network reserve ip 10.89.0.50 --for name=web
+  podman network reserve ip --network mynet fd00::50 --for name=mypod`,
+	}
+
+	networkReserveMACDescription = `Reserve a MAC address of a network for a container or pod.
+
+  The container is given the address whenever it joins the network without requesting a static MAC address, and no other container is given the address.`
+	networkReserveMACCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "mac [options] ADDRESS",
+		Short:             "Reserve a MAC address of a network",
+		Long:              networkReserveMACDescription,
+		RunE:              networkReserve,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman network reserve mac --network mynet 92:d0:c6:0a:29:33 --for name=web`,
+	}
+)
+
+var (
+	networkReserveOptions entities.NetworkReserveOptions
+	networkReserveFor     string
+)
+
+func networkReserveFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	networkFlagName := "network"
+	flags.StringVar(&networkReserveOptions.Network, networkFlagName, "", "Network the address belongs to")
+	_ = cmd.RegisterFlagCompletionFunc(networkFlagName, common.AutocompleteNetworks)
+
+	forFlagName := "for"
+	flags.StringVar(&networkReserveFor, forFlagName, "", "Container or pod the address is reserved for, as name=NAME")
+	_ = cmd.RegisterFlagCompletionFunc(forFlagName, completion.AutocompleteNone)
+	_ = cmd.MarkFlagRequired(forFlagName)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkReserveCommand,
+		Parent:  networkCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkReserveIPCommand,
+		Parent:  networkReserveCommand,
+	})
+	networkReserveFlags(networkReserveIPCommand)
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkReserveMACCommand,
+		Parent:  networkReserveCommand,
+	})
+	networkReserveFlags(networkReserveMACCommand)
+	_ = networkReserveMACCommand.MarkFlagRequired("network")
+}
+
+func networkReserve(cmd *cobra.Command, args []string) error {
+	key, name, ok := strings.Cut(networkReserveFor, "=")
+	if !ok || key != "name" || name == "" {
+		return fmt.Errorf("invalid --for %q, must be name=NAME", networkReserveFor)
+	}
+	networkReserveOptions.Container = name
+
+	reservation, err := registry.ContainerEngine().NetworkReserve(registry.Context(), args[0], networkReserveOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s reserved on network %s for %s\n", reservation.Address, reservation.Network, reservation.Container)
+	return nil
+}
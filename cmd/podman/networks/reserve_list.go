@@ -0,0 +1,107 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkReserveListDescription = `List the reserved addresses of a network, or of all networks if none is given.`
+	networkReserveListCommand     = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "ls [options] [NETWORK]",
+		Aliases:           []string{"list"},
+		Args:              cobra.MaximumNArgs(1),
+		Short:             "List reserved addresses of networks",
+		Long:              networkReserveListDescription,
+		RunE:              networkReserveList,
+		ValidArgsFunction: common.AutocompleteNetworks,
+		Example: `podman network reserve ls
+  podman network reserve ls --format json mynet`,
+	}
+
+	networkReserveListFormat string
+)
+
+// reservationReporter formats a network reservation for the default table
+// output.
+type reservationReporter struct {
+	*define.NetworkReservation
+}
+
+func (r reservationReporter) Created() string {
+	return units.HumanDuration(time.Since(r.NetworkReservation.Created)) + " ago"
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkReserveListCommand,
+		Parent:  networkReserveCommand,
+	})
+	flags := networkReserveListCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&networkReserveListFormat, formatFlagName, "{{range .}}{{.Network}}\t{{.Address}}\t{{.Container}}\t{{.Created}}\n{{end -}}", "Format reservation output using Go template")
+	_ = networkReserveListCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&reservationReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+}
+
+func networkReserveList(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	reservations, err := registry.ContainerEngine().NetworkReservations(registry.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(networkReserveListFormat) {
+		b, err := json.MarshalIndent(reservations, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]reservationReporter, 0, len(reservations))
+	for _, reservation := range reservations {
+		reporters = append(reporters, reservationReporter{reservation})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, networkReserveListFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(reservationReporter{}, map[string]string{
+			"Network":   "NETWORK",
+			"Address":   "ADDRESS",
+			"Container": "RESERVED FOR",
+			"Created":   "CREATED",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
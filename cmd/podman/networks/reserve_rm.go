@@ -0,0 +1,47 @@
+package network
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkUnreserveDescription = `Remove the reservation of IP or MAC addresses. The network of an IP address can be omitted if it is only reserved on one network.`
+	networkUnreserveCommand     = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "rm [options] ADDRESS [ADDRESS...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove reserved addresses of networks",
+		Long:              networkUnreserveDescription,
+		RunE:              networkUnreserve,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman network reserve rm 10.89.0.50
+  podman network reserve rm --network mynet 92:d0:c6:0a:29:33`,
+	}
+
+	networkUnreserveNetwork string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkUnreserveCommand,
+		Parent:  networkReserveCommand,
+	})
+	flags := networkUnreserveCommand.Flags()
+
+	networkFlagName := "network"
+	flags.StringVar(&networkUnreserveNetwork, networkFlagName, "", "Network the addresses belong to")
+	_ = networkUnreserveCommand.RegisterFlagCompletionFunc(networkFlagName, common.AutocompleteNetworks)
+}
+
+func networkUnreserve(cmd *cobra.Command, args []string) error {
+	for _, address := range args {
+		if err := registry.ContainerEngine().NetworkUnreserve(registry.Context(), networkUnreserveNetwork, address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package pods
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	envLib "github.com/containers/podman/v5/pkg/env"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podExecDescription = `Execute the specified command in all running containers of a pod, or in the ones with the given labels.
+
+  The commands run concurrently. Each line of their output is prefixed with the name of the container it comes from. The exit code is the highest exit code of all commands.`
+
+	podExecCommand = &cobra.Command{
+		Use:               "exec [options] POD COMMAND [ARG...]",
+		Short:             "Run a process in the running containers of a pod",
+		Long:              podExecDescription,
+		RunE:              podExec,
+		ValidArgsFunction: common.AutocompleteExecCommand,
+		Example: `podman pod exec mypod sync
+  podman pod exec --label app=cache mypod redis-cli flushall
+  podman pod exec -w /tmp -e DEBUG=1 --latest ls`,
+	}
+)
+
+var podExecOpts struct {
+	entities.ExecOptions
+	Env     []string
+	EnvFile []string
+	Labels  []string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: podExecCommand,
+		Parent:  podCmd,
+	})
+	podmanConfig := registry.PodmanConfig()
+	flags := podExecCommand.Flags()
+	flags.SetInterspersed(false)
+
+	envFlagName := "env"
+	flags.StringArrayVarP(&podExecOpts.Env, envFlagName, "e", []string{}, "Set environment variables")
+	_ = podExecCommand.RegisterFlagCompletionFunc(envFlagName, completion.AutocompleteNone)
+
+	envFileFlagName := "env-file"
+	flags.StringArrayVar(&podExecOpts.EnvFile, envFileFlagName, []string{}, "Read in a file of environment variables")
+	_ = podExecCommand.RegisterFlagCompletionFunc(envFileFlagName, completion.AutocompleteDefault)
+
+	labelFlagName := "label"
+	flags.StringArrayVar(&podExecOpts.Labels, labelFlagName, []string{}, "Only run the command in containers with the given label (key or key=value)")
+	_ = podExecCommand.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&podExecOpts.Privileged, "privileged", podmanConfig.ContainersConfDefaultsRO.Containers.Privileged, "Give the process extended Linux capabilities inside the containers.  The default is false")
+
+	userFlagName := "user"
+	flags.StringVarP(&podExecOpts.User, userFlagName, "u", "", "Sets the username or UID used and optionally the groupname or GID for the specified command")
+	_ = podExecCommand.RegisterFlagCompletionFunc(userFlagName, common.AutocompleteUserFlag)
+
+	workdirFlagName := "workdir"
+	flags.StringVarP(&podExecOpts.WorkDir, workdirFlagName, "w", "", "Working directory inside the containers")
+	_ = podExecCommand.RegisterFlagCompletionFunc(workdirFlagName, completion.AutocompleteDefault)
+
+	validate.AddLatestFlag(podExecCommand, &podExecOpts.Latest)
+}
+
+func podExec(_ *cobra.Command, args []string) error {
+	var nameOrIDs []string
+	if !podExecOpts.Latest {
+		if len(args) == 0 {
+			return errors.New("exec requires the name or ID of a pod or the --latest flag")
+		}
+		nameOrIDs = []string{args[0]}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return errors.New("exec requires a command to run")
+	}
+
+	execOpts := podExecOpts.ExecOptions
+	execOpts.Cmd = args
+	execOpts.Latest = false
+	execOpts.Envs = make(map[string]string)
+	for _, f := range podExecOpts.EnvFile {
+		fileEnv, err := envLib.ParseFile(f)
+		if err != nil {
+			return err
+		}
+		execOpts.Envs = envLib.Join(execOpts.Envs, fileEnv)
+	}
+	cliEnv, err := envLib.ParseSlice(podExecOpts.Env)
+	if err != nil {
+		return fmt.Errorf("parsing environment variables: %w", err)
+	}
+	execOpts.Envs = envLib.Join(execOpts.Envs, cliEnv)
+
+	engine := registry.ContainerEngine()
+	pods, errs, err := engine.PodInspect(registry.Context(), nameOrIDs, entities.InspectOptions{Latest: podExecOpts.Latest})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	pod := pods[0]
+
+	filters := map[string][]string{"pod": {pod.ID}}
+	if len(podExecOpts.Labels) > 0 {
+		filters["label"] = podExecOpts.Labels
+	}
+	ctrs, err := engine.ContainerList(registry.Context(), entities.ContainerListOptions{Filters: filters})
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		outputMu sync.Mutex
+		resultMu sync.Mutex
+		exitCode int
+		started  int
+	)
+	errs = nil
+	for _, ctr := range ctrs {
+		if ctr.IsInfra {
+			continue
+		}
+		started++
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = ctr.Names[0]
+		}
+		stdout := newPrefixWriter(os.Stdout, &outputMu, name)
+		stderr := newPrefixWriter(os.Stderr, &outputMu, name)
+		streams := define.AttachStreams{
+			OutputStream: stdout,
+			ErrorStream:  stderr,
+			AttachOutput: true,
+			AttachError:  true,
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			ec, err := engine.ContainerExec(registry.Context(), id, execOpts, streams)
+			_ = stdout.Flush()
+			_ = stderr.Flush()
+
+			resultMu.Lock()
+			defer resultMu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+			if ec > exitCode {
+				exitCode = ec
+			}
+		}(ctr.ID)
+	}
+	if started == 0 {
+		return fmt.Errorf("no running container in pod %s matches", pod.Name)
+	}
+	wg.Wait()
+
+	registry.SetExitCode(exitCode)
+	return errors.Join(errs...)
+}
+
+// prefixWriter writes complete lines to out, each prefixed with the name of
+// the container they come from. Writers sharing a mutex do not interleave
+// their lines.
+type prefixWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, mu *sync.Mutex, name string) *prefixWriter {
+	return &prefixWriter{
+		out:    out,
+		mu:     mu,
+		prefix: []byte(strings.TrimPrefix(name, "/") + " "),
+	}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line if it was not terminated by a newline.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.out.Write(w.prefix); err != nil {
+		return err
+	}
+	_, err := w.out.Write(line)
+	return err
+}
@@ -91,6 +91,7 @@ func logsFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&logsPodOptions.Names, "names", "n", false, "Output container names instead of container IDs in the log")
 	flags.BoolVarP(&logsPodOptions.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
 	flags.BoolVarP(&logsPodOptions.Colors, "color", "", false, "Output the containers within a pod with different colors in the log")
+	flags.BoolVar(&logsPodOptions.Redact, "redact", false, "Mask secret values and configured patterns in the log output")
 
 	flags.SetInterspersed(false)
 	_ = flags.MarkHidden("details")
@@ -50,6 +50,10 @@ func init() {
 	flags := startCommand.Flags()
 	flags.BoolVarP(&startOptions.All, "all", "a", false, "Restart all running pods")
 
+	parallelFlagName := "parallel"
+	flags.UintVar(&startOptions.Parallel, parallelFlagName, 0, "Maximum number of containers started concurrently, the number of parallel jobs if 0")
+	_ = startCommand.RegisterFlagCompletionFunc(parallelFlagName, completion.AutocompleteNone)
+
 	podIDFileFlagName := "pod-id-file"
 	flags.StringArrayVarP(&startOptions.PodIDFiles, podIDFileFlagName, "", nil, "Read the pod ID from the file")
 	_ = startCommand.RegisterFlagCompletionFunc(podIDFileFlagName, completion.AutocompleteDefault)
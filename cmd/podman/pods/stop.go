@@ -53,6 +53,10 @@ func init() {
 	flags.BoolVarP(&stopOptions.All, "all", "a", false, "Stop all running pods")
 	flags.BoolVarP(&stopOptions.Ignore, "ignore", "i", false, "Ignore errors when a specified pod is missing")
 
+	parallelFlagName := "parallel"
+	flags.UintVar(&stopOptions.Parallel, parallelFlagName, 0, "Maximum number of containers stopped concurrently, the number of parallel jobs if 0")
+	_ = stopCommand.RegisterFlagCompletionFunc(parallelFlagName, completion.AutocompleteNone)
+
 	timeFlagName := "time"
 	flags.IntVarP(&stopOptions.timeoutCLI, timeFlagName, "t", int(containerConfig.Engine.StopTimeout), "Seconds to wait for pod stop before killing the container")
 	_ = stopCommand.RegisterFlagCompletionFunc(timeFlagName, completion.AutocompleteNone)
@@ -0,0 +1,65 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/specgenutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podUpdateDescription = `Updates the configuration of an existing pod.
+
+  The ports published by the pod are replaced by those given with --publish. The port forwarding of a running pod is updated without restarting it.`
+	updateCommand = &cobra.Command{
+		Use:               "update [options] POD",
+		Short:             "Update an existing pod",
+		Long:              podUpdateDescription,
+		RunE:              update,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod update --publish 8081:80 mypod
+  podman pod update --publish 8080:80 --publish 8443:443 mypod`,
+	}
+)
+
+var (
+	updatePublish []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCommand,
+		Parent:  podCmd,
+	})
+	flags := updateCommand.Flags()
+
+	publishFlagName := "publish"
+	flags.StringSliceVarP(&updatePublish, publishFlagName, "p", []string{}, "Publish a port, or a range of ports, of the pod to the host, replacing the published ports")
+	_ = updateCommand.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	if !cmd.Flags().Changed("publish") {
+		return errors.New("must specify at least one configuration option to update")
+	}
+	ports, err := specgenutil.CreatePortBindings(updatePublish)
+	if err != nil {
+		return err
+	}
+	id, err := registry.ContainerEngine().PodUpdate(context.Background(), &entities.PodUpdateOptions{
+		NameOrID:     args[0],
+		PortMappings: ports,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(id)
+	return nil
+}
@@ -72,6 +72,10 @@ func NewContainerEngine(cmd *cobra.Command, args []string) (entities.ContainerEn
 			logrus.Debugf("Performing system renumber, runtime validation checks will be relaxed")
 			podmanOptions.IsRenumber = true
 		}
+		if cmd.Name() == "service" && cmd.Parent().Name() == "system" {
+			podmanOptions.IsService = true
+			podmanOptions.ServiceMetrics, _ = cmd.Flags().GetBool("metrics")
+		}
 		engine, err := infra.NewContainerEngine(&podmanOptions)
 		if err != nil {
 			return nil, err
@@ -113,7 +113,8 @@ func init() {
 }
 
 func Execute() {
-	if err := rootCmd.ExecuteContext(registry.GetContextWithOptions()); err != nil {
+	err := rootCmd.ExecuteContext(registry.GetContextWithOptions())
+	if err != nil {
 		if registry.GetExitCode() == 0 {
 			registry.SetExitCode(define.ExecErrorCodeGeneric)
 		}
@@ -142,6 +143,8 @@ func Execute() {
 		}
 	}
 
+	shutdownTracing(err)
+
 	os.Exit(registry.GetExitCode())
 }
 
@@ -231,9 +234,9 @@ func setupRemoteConnection(podmanConfig *entities.PodmanConfig) string {
 func persistentPreRunE(cmd *cobra.Command, args []string) error {
 	logrus.Debugf("Called %s.PersistentPreRunE(%s)", cmd.Name(), strings.Join(os.Args, " "))
 
-	// Help, completion and commands with subcommands are special cases, no need for more setup
+	// Help, completion and commands that only group subcommands are special cases, no need for more setup
 	// Completion cmd is used to generate the shell scripts
-	if cmd.Name() == "help" || cmd.Name() == "completion" || cmd.HasSubCommands() {
+	if cmd.Name() == "help" || cmd.Name() == "completion" || validate.IsSubCommandGroup(cmd) {
 		requireCleanup = false
 		return nil
 	}
@@ -252,7 +255,9 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		// To make it easier for users we will look into the checkpoint archive and
 		// set the runtime to the one used during checkpointing.
 		if cmd.Name() == "restore" {
-			if cmd.Flag("import").Changed {
+			// Other restore commands, e.g. podman volume backup restore,
+			// have no --import flag.
+			if importFlag := cmd.Flag("import"); importFlag != nil && importFlag.Changed {
 				runtime, err := crutils.CRGetRuntimeFromArchive(cmd.Flag("import").Value.String())
 				if err != nil {
 					return fmt.Errorf(
@@ -313,6 +318,10 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := setupTracing(cmd); err != nil {
+		return err
+	}
+
 	// Prep the engines
 	if _, err := registry.NewImageEngine(cmd, args); err != nil {
 		// Note: this is gross, but it is the hand we are dealt
@@ -568,7 +577,6 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 		namespaceFlagName := "namespace"
 		pFlags.StringVar(&podmanConfig.ContainersConf.Engine.Namespace, namespaceFlagName, podmanConfig.ContainersConfDefaultsRO.Engine.Namespace, "Set the libpod namespace, used to create separate views of the containers and pods on the system")
 		_ = cmd.RegisterFlagCompletionFunc(namespaceFlagName, completion.AutocompleteNone)
-		_ = pFlags.MarkHidden(namespaceFlagName)
 
 		networkBackendFlagName := "network-backend"
 		pFlags.StringVar(&podmanConfig.ContainersConf.Network.NetworkBackend, networkBackendFlagName, podmanConfig.ContainersConfDefaultsRO.Network.NetworkBackend, `Network backend to use ("cni"|"netavark")`)
@@ -591,6 +599,8 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 
 		pFlags.BoolVar(&podmanConfig.TransientStore, "transient-store", false, "Enable transient container storage")
 
+		pFlags.BoolVar(&podmanConfig.ReadOnlyState, "read-only-state", false, "Open the container state database read only, commands modifying it fail")
+
 		pFlags.StringArrayVar(&podmanConfig.PullOptions, "pull-option", nil, "Specify an option to change how the image is pulled")
 
 		runtimeFlagName := "runtime"
@@ -66,33 +66,11 @@ func create(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
 	var err error
-	path := args[1]
-
-	var reader io.Reader
-	switch {
-	case env:
-		envValue := os.Getenv(path)
-		if envValue == "" {
-			return fmt.Errorf("cannot create store secret data: environment variable %s is not set", path)
-		}
-		reader = strings.NewReader(envValue)
-	case path == "-" || path == "/dev/stdin":
-		stat, err := os.Stdin.Stat()
-		if err != nil {
-			return err
-		}
-		if (stat.Mode() & os.ModeNamedPipe) == 0 {
-			return errors.New("if `-` is used, data must be passed into stdin")
-		}
-		reader = os.Stdin
-	default:
-		file, err := os.Open(path)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
-		reader = file
+	reader, err := openSecretData(args[1], env)
+	if err != nil {
+		return err
 	}
+	defer reader.Close()
 
 	createOpts.Labels, err = parse.GetAllLabels([]string{}, labels)
 	if err != nil {
@@ -106,3 +84,27 @@ func create(cmd *cobra.Command, args []string) error {
 	fmt.Println(report.ID)
 	return nil
 }
+
+// openSecretData opens the secret data at path, which is a file, "-" for
+// stdin, or the name of an environment variable if fromEnv is set.
+func openSecretData(path string, fromEnv bool) (io.ReadCloser, error) {
+	switch {
+	case fromEnv:
+		envValue := os.Getenv(path)
+		if envValue == "" {
+			return nil, fmt.Errorf("cannot create store secret data: environment variable %s is not set", path)
+		}
+		return io.NopCloser(strings.NewReader(envValue)), nil
+	case path == "-" || path == "/dev/stdin":
+		stat, err := os.Stdin.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if (stat.Mode() & os.ModeNamedPipe) == 0 {
+			return nil, errors.New("if `-` is used, data must be passed into stdin")
+		}
+		return io.NopCloser(os.Stdin), nil
+	default:
+		return os.Open(path)
+	}
+}
@@ -0,0 +1,63 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateCmd = &cobra.Command{
+		Use:   "update [options] SECRET FILE|-",
+		Short: "Update the data of a secret",
+		Long:  "Replace the data of a secret and update the copies of the secret mounted into containers. Input can be a path to a file or \"-\" (read from stdin).",
+		RunE:  update,
+		Args:  cobra.ExactArgs(2),
+		Example: `podman secret update mysecret /path/to/secret
+  printf "newdata" | podman secret update --notify signal mysecret -
+  podman secret update --notify restart mysecret /path/to/secret`,
+		ValidArgsFunction: common.AutocompleteSecretUpdate,
+	}
+)
+
+var (
+	updateOpts = entities.SecretUpdateOptions{}
+	updateEnv  = false
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCmd,
+		Parent:  secretCmd,
+	})
+
+	flags := updateCmd.Flags()
+
+	flags.BoolVar(&updateEnv, "env", false, "Read secret data from environment variable")
+
+	notifyFlagName := "notify"
+	flags.StringVar(&updateOpts.Notify, notifyFlagName, "", "Notify the running containers using the secret: \"signal\" or \"restart\"")
+	_ = updateCmd.RegisterFlagCompletionFunc(notifyFlagName, common.AutocompleteSecretNotify)
+
+	signalFlagName := "signal"
+	flags.StringVarP(&updateOpts.Signal, signalFlagName, "s", "SIGHUP", "Signal sent to the containers with --notify=signal")
+	_ = updateCmd.RegisterFlagCompletionFunc(signalFlagName, common.AutocompleteStopSignal)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	reader, err := openSecretData(args[1], updateEnv)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	report, err := registry.ContainerEngine().SecretUpdate(context.Background(), args[0], reader, updateOpts)
+	if report != nil {
+		fmt.Println(report.ID)
+	}
+	return err
+}
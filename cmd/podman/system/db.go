@@ -0,0 +1,26 @@
+//go:build !remote
+
+package system
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "db",
+		Short:       "Manage the Podman database",
+		Long:        "Manage the database holding the containers, pods and volumes of Podman",
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: dbCmd,
+		Parent:  systemCmd,
+	})
+}
@@ -0,0 +1,45 @@
+//go:build !remote
+
+package system
+
+import (
+	"path/filepath"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbBackupDescription = `
+        podman system db backup FILE
+
+        Write a consistent copy of the database to FILE while containers keep running.
+`
+
+	dbBackupCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "backup FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Back up the database",
+		Long:              dbBackupDescription,
+		RunE:              dbBackup,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example:           `podman system db backup /var/backups/podman.db`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: dbBackupCommand,
+		Parent:  dbCmd,
+	})
+}
+
+func dbBackup(cmd *cobra.Command, args []string) error {
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	return registry.ContainerEngine().SystemDBBackup(registry.Context(), path)
+}
@@ -0,0 +1,97 @@
+//go:build !remote
+
+package system
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbCheckDescription = `
+        podman system db check
+
+        Check the database for consistency and report orphaned entries.
+`
+
+	dbCheckCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "check [options]",
+		Args:              validate.NoArgs,
+		Short:             "Check the database for consistency",
+		Long:              dbCheckDescription,
+		RunE:              dbCheck,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system db check
+  podman system db check --format json`,
+	}
+
+	dbCheckFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: dbCheckCommand,
+		Parent:  dbCmd,
+	})
+	flags := dbCheckCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&dbCheckFormat, formatFlagName, "", "Format the report as JSON")
+	_ = dbCheckCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+}
+
+func dbCheck(cmd *cobra.Command, args []string) error {
+	checkReport, err := registry.ContainerEngine().SystemDBCheck(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(dbCheckFormat):
+		b, err := json.MarshalIndent(checkReport, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	case dbCheckFormat == "":
+		printDBCheckReport(checkReport)
+	default:
+		return fmt.Errorf("unknown --format argument: %q", dbCheckFormat)
+	}
+
+	if checkReport.HasProblems() {
+		return errors.New("problems detected in the database")
+	}
+	return nil
+}
+
+func printDBCheckReport(checkReport *define.DBCheckReport) {
+	if !checkReport.HasProblems() {
+		fmt.Printf("No problems detected in the %s database\n", checkReport.Backend)
+		return
+	}
+	for _, msg := range checkReport.Errors {
+		fmt.Printf("Database error: %s\n", msg)
+	}
+	for _, id := range checkReport.OrphanExitCodes {
+		fmt.Printf("Orphaned exit code of container %s\n", id)
+	}
+	for _, id := range checkReport.OrphanExecSessions {
+		fmt.Printf("Orphaned exec session %s\n", id)
+	}
+	for _, dep := range checkReport.OrphanDependencies {
+		fmt.Printf("Orphaned container dependency %s\n", dep)
+	}
+	for _, id := range checkReport.MissingStorage {
+		fmt.Printf("Container %s has no storage\n", id)
+	}
+}
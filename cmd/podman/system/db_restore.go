@@ -0,0 +1,68 @@
+//go:build !remote
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbRestoreDescription = `
+        podman system db restore FILE
+
+        Replace the database with a backup created by podman system db backup. All containers must be stopped.
+`
+
+	dbRestoreCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "restore [options] FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Restore the database from a backup",
+		Long:              dbRestoreDescription,
+		RunE:              dbRestore,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example:           `podman system db restore /var/backups/podman.db`,
+	}
+
+	dbRestoreForce bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: dbRestoreCommand,
+		Parent:  dbCmd,
+	})
+	flags := dbRestoreCommand.Flags()
+	flags.BoolVarP(&dbRestoreForce, "force", "f", false, "Do not prompt for confirmation")
+}
+
+func dbRestore(cmd *cobra.Command, args []string) error {
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !dbRestoreForce {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Printf(`WARNING! This will replace all containers, pods and volumes known to podman
+with the ones recorded in %s.
+Are you sure you want to continue? [y/N] `, path)
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if strings.ToLower(answer)[0] != 'y' {
+			return nil
+		}
+	}
+
+	return registry.ContainerEngine().SystemDBRestore(registry.Context(), path)
+}
@@ -0,0 +1,40 @@
+//go:build !remote
+
+package system
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbVacuumDescription = `
+        podman system db vacuum
+
+        Rebuild the database to reclaim the space of removed entries and truncate its write-ahead log.
+`
+
+	dbVacuumCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "vacuum",
+		Args:              validate.NoArgs,
+		Short:             "Reclaim unused space in the database",
+		Long:              dbVacuumDescription,
+		RunE:              dbVacuum,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman system db vacuum`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: dbVacuumCommand,
+		Parent:  dbCmd,
+	})
+}
+
+func dbVacuum(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().SystemDBVacuum(registry.Context())
+}
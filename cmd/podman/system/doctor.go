@@ -0,0 +1,80 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorDescription = `Check the environment podman runs in.
+
+  Runs a series of checks of the subordinate IDs, cgroups, storage driver, database, network backend, conmon and OCI runtime, and prints how to fix the problems found.`
+
+	doctorCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "doctor [options]",
+		Args:              validate.NoArgs,
+		Short:             "Check the environment for common problems",
+		Long:              doctorDescription,
+		RunE:              doctor,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman doctor
+  podman doctor --format json`,
+	}
+
+	doctorFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: doctorCommand,
+	})
+	flags := doctorCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&doctorFormat, formatFlagName, "", "Format the report as JSON")
+	_ = doctorCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+}
+
+func doctor(cmd *cobra.Command, args []string) error {
+	doctorReport, err := registry.ContainerEngine().SystemDoctor(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(doctorFormat):
+		b, err := json.MarshalIndent(doctorReport, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	case doctorFormat == "":
+		printDoctorReport(doctorReport)
+	default:
+		return fmt.Errorf("unknown --format argument: %q", doctorFormat)
+	}
+
+	if doctorReport.Failed() {
+		return errors.New("problems detected in the environment")
+	}
+	return nil
+}
+
+func printDoctorReport(doctorReport *define.DoctorReport) {
+	for _, check := range doctorReport.Checks {
+		fmt.Printf("[%s] %s: %s\n", strings.ToUpper(string(check.Status)), check.Name, check.Message)
+		if check.Remediation != "" {
+			fmt.Printf("       Fix: %s\n", check.Remediation)
+		}
+	}
+}
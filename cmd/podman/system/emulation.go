@@ -0,0 +1,33 @@
+//go:build !remote
+
+package system
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/emulation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emulationCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "emulation",
+		Short:       "Manage the emulation of foreign architectures",
+		Long:        "Manage the qemu-user emulation of foreign architectures registered with binfmt_misc",
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: emulationCmd,
+		Parent:  systemCmd,
+	})
+}
+
+// autocompleteEmulationArchs completes the architectures which can be
+// emulated.
+func autocompleteEmulationArchs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return emulation.Architectures(), cobra.ShellCompDirectiveNoFileComp
+}
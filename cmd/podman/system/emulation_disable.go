@@ -0,0 +1,39 @@
+//go:build !remote
+
+package system
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emulationDisableDescription = `
+        podman system emulation disable [ARCH...]
+
+        Unregister the qemu-user handlers of the given architectures from binfmt_misc.
+        Without architectures, all qemu-user handlers are unregistered.
+`
+
+	emulationDisableCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "disable [ARCH...]",
+		Args:              cobra.ArbitraryArgs,
+		Short:             "Disable the emulation of foreign architectures",
+		Long:              emulationDisableDescription,
+		RunE:              emulationDisable,
+		ValidArgsFunction: autocompleteEmulationArchs,
+		Example:           `podman system emulation disable arm64`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: emulationDisableCommand,
+		Parent:  emulationCmd,
+	})
+}
+
+func emulationDisable(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().SystemEmulationDisable(registry.Context(), args)
+}
@@ -0,0 +1,49 @@
+//go:build !remote
+
+package system
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emulationEnableDescription = `
+        podman system emulation enable [ARCH...]
+
+        Register the qemu-user handlers of the given architectures with binfmt_misc, so that images built for them can be run.
+        Without architectures, all qemu-user handlers installed on the host are registered.
+`
+
+	emulationEnableCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "enable [options] [ARCH...]",
+		Args:              cobra.ArbitraryArgs,
+		Short:             "Enable the emulation of foreign architectures",
+		Long:              emulationEnableDescription,
+		RunE:              emulationEnable,
+		ValidArgsFunction: autocompleteEmulationArchs,
+		Example: `podman system emulation enable arm64 s390x
+  podman system emulation enable --image docker.io/tonistiigi/binfmt riscv64`,
+	}
+
+	emulationEnableOpts entities.SystemEmulationEnableOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: emulationEnableCommand,
+		Parent:  emulationCmd,
+	})
+	flags := emulationEnableCommand.Flags()
+
+	imageFlagName := "image"
+	flags.StringVar(&emulationEnableOpts.Image, imageFlagName, "", "Helper `image` registering its qemu-user handlers instead of those of the host")
+	_ = emulationEnableCommand.RegisterFlagCompletionFunc(imageFlagName, common.AutocompleteImages)
+}
+
+func emulationEnable(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().SystemEmulationEnable(registry.Context(), args, emulationEnableOpts)
+}
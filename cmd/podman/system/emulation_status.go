@@ -0,0 +1,87 @@
+//go:build !remote
+
+package system
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emulationStatusDescription = `
+        podman system emulation status
+
+        Show the foreign platforms which can be emulated and whether images built for them can be run.
+`
+
+	emulationStatusCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "status [options]",
+		Args:              validate.NoArgs,
+		Short:             "Show the emulation of foreign architectures",
+		Long:              emulationStatusDescription,
+		RunE:              emulationStatus,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system emulation status
+  podman system emulation status --format json`,
+	}
+
+	emulationStatusFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: emulationStatusCommand,
+		Parent:  emulationCmd,
+	})
+	flags := emulationStatusCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&emulationStatusFormat, formatFlagName, "", "Change the output to JSON or a Go template")
+	_ = emulationStatusCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.SystemEmulationPlatform{}))
+}
+
+func emulationStatus(cmd *cobra.Command, args []string) error {
+	statusReport, err := registry.ContainerEngine().SystemEmulationStatus(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(emulationStatusFormat) {
+		b, err := json.MarshalIndent(statusReport, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, emulationStatusFormat)
+	} else {
+		format := "{{range .}}{{.Platform}}\t{{.Installed}}\t{{.Registered}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		hdrs := report.Headers(entities.SystemEmulationPlatform{}, nil)
+		if err := rpt.Execute(hdrs); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(statusReport.Platforms)
+}
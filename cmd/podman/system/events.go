@@ -73,6 +73,12 @@ type Event struct {
 	HealthStatus string `json:"health_status,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:",omitempty"`
+	// ProcessID is the PID of the process in a process event
+	ProcessID int `json:",omitempty"`
+	// ProcessCommand is the command of the process in a process event
+	ProcessCommand string `json:",omitempty"`
+	// Message is the user-supplied message of a custom event
+	Message string `json:",omitempty"`
 
 	events.Details
 }
@@ -91,6 +97,9 @@ func newEventFromLibpodEvent(e *events.Event) Event {
 		Details:           e.Details,
 		TimeNano:          e.Time.UnixNano(),
 		Error:             e.Error,
+		ProcessID:         e.ProcessID,
+		ProcessCommand:    e.ProcessCommand,
+		Message:           e.Message,
 	}
 }
 
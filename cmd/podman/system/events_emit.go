@@ -0,0 +1,76 @@
+package system
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsEmitDescription = `Emit a custom event for a container.
+
+  The event is written to the podman event stream with the custom type, using the given type as its status.`
+	eventsEmitCommand = &cobra.Command{
+		Use:               "emit [options]",
+		Args:              validate.NoArgs,
+		Short:             "Emit a custom event for a container",
+		Long:              eventsEmitDescription,
+		RunE:              eventsEmit,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman events emit --container ctrID --message "deployment started"
+  podman events emit --container ctrID --type backup --message "backup completed"`,
+	}
+
+	systemEventsEmitCommand = &cobra.Command{
+		Args:              eventsEmitCommand.Args,
+		Use:               eventsEmitCommand.Use,
+		Short:             eventsEmitCommand.Short,
+		Long:              eventsEmitCommand.Long,
+		RunE:              eventsEmitCommand.RunE,
+		ValidArgsFunction: eventsEmitCommand.ValidArgsFunction,
+		Example:           `podman system events emit --container ctrID --message "deployment started"`,
+	}
+)
+
+var (
+	eventsEmitContainer string
+	eventsEmitOptions   entities.ContainerEmitEventOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: systemEventsEmitCommand,
+		Parent:  systemEventsCommand,
+	})
+	eventsEmitFlags(systemEventsEmitCommand)
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: eventsEmitCommand,
+		Parent:  eventsCommand,
+	})
+	eventsEmitFlags(eventsEmitCommand)
+}
+
+func eventsEmitFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	containerFlagName := "container"
+	flags.StringVar(&eventsEmitContainer, containerFlagName, "", "Container to emit the event for")
+	_ = cmd.RegisterFlagCompletionFunc(containerFlagName, common.AutocompleteContainers)
+	_ = cmd.MarkFlagRequired(containerFlagName)
+
+	typeFlagName := "type"
+	flags.StringVar(&eventsEmitOptions.Type, typeFlagName, events.Custom.String(), "User-defined type of the event")
+	_ = cmd.RegisterFlagCompletionFunc(typeFlagName, completion.AutocompleteNone)
+
+	messageFlagName := "message"
+	flags.StringVar(&eventsEmitOptions.Message, messageFlagName, "", "Message of the event")
+	_ = cmd.RegisterFlagCompletionFunc(messageFlagName, completion.AutocompleteNone)
+}
+
+func eventsEmit(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerEmitEvent(registry.Context(), eventsEmitContainer, eventsEmitOptions)
+}
@@ -0,0 +1,103 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphDescription = `Export the dependency graph of all containers, pods, volumes, and networks.
+
+  The graph is printed in the DOT language of Graphviz or as JSON.`
+
+	graphCommand = &cobra.Command{
+		Use:               "graph [options]",
+		Args:              validate.NoArgs,
+		Short:             "Export the dependency graph of containers, pods, volumes, and networks",
+		Long:              graphDescription,
+		RunE:              graph,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system graph | dot -Tsvg -o graph.svg
+  podman system graph --format json`,
+	}
+
+	graphFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: graphCommand,
+		Parent:  systemCmd,
+	})
+	flags := graphCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&graphFormat, formatFlagName, "dot", "Output format of the graph: dot or json")
+	_ = graphCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteGraphFormat)
+}
+
+func graph(cmd *cobra.Command, args []string) error {
+	if graphFormat != "dot" && graphFormat != "json" {
+		return fmt.Errorf("unknown --format argument: %q, must be dot or json", graphFormat)
+	}
+
+	graphReport, err := registry.ContainerEngine().SystemGraph(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	if graphFormat == "json" {
+		b, err := json.MarshalIndent(graphReport, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Print(graphDOT(graphReport))
+	return nil
+}
+
+// graphDOTShapes are the node shapes of the node types in the DOT output.
+var graphDOTShapes = map[string]string{
+	"container": "box",
+	"pod":       "box3d",
+	"volume":    "cylinder",
+	"network":   "ellipse",
+}
+
+// graphDOT formats the graph in the DOT language of Graphviz.
+func graphDOT(graphReport *entities.SystemGraphReport) string {
+	var b strings.Builder
+	b.WriteString("digraph podman {\n")
+	for _, n := range graphReport.Nodes {
+		label := n.Name
+		if label == "" {
+			label = n.ID
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", graphDOTID(n.Type, n.ID), graphDOTQuote(n.Type+"\n"+label), graphDOTShapes[n.Type])
+	}
+	for _, e := range graphReport.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", graphDOTID("container", e.From), graphDOTID(e.Type, e.To))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// graphDOTID returns the DOT node ID of a node, volumes and networks may
+// share a name so the type is part of the ID.
+func graphDOTID(nodeType, id string) string {
+	return graphDOTQuote(nodeType + ":" + id)
+}
+
+func graphDOTQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
 	"github.com/containers/podman/v5/cmd/podman/registry"
 	"github.com/containers/podman/v5/cmd/podman/validate"
 	"github.com/containers/podman/v5/libpod/define"
@@ -50,10 +51,15 @@ func init() {
 	newRuntimeFlagName := "new-runtime"
 	flags.StringVar(&migrateOptions.NewRuntime, newRuntimeFlagName, "", "Specify a new runtime for all containers")
 	_ = migrateCommand.RegisterFlagCompletionFunc(newRuntimeFlagName, completion.AutocompleteNone)
+
+	newDBFlagName := "new-db"
+	flags.StringVar(&migrateOptions.NewDB, newDBFlagName, "", "Migrate the database to a new backend (sqlite, boltdb)")
+	_ = migrateCommand.RegisterFlagCompletionFunc(newDBFlagName, common.AutocompleteMigrateDB)
 }
 
 func migrate(cmd *cobra.Command, args []string) {
-	if err := registry.ContainerEngine().Migrate(registry.Context(), migrateOptions); err != nil {
+	report, err := registry.ContainerEngine().Migrate(registry.Context(), migrateOptions)
+	if err != nil {
 		fmt.Println(err)
 
 		// FIXME change this to return the error like other commands
@@ -61,5 +67,8 @@ func migrate(cmd *cobra.Command, args []string) {
 		//nolint:gocritic
 		os.Exit(define.ExecErrorCodeGeneric)
 	}
+	if report != nil {
+		fmt.Printf("Migrated %d containers, %d pods, %d volumes to %s\n", report.Containers, report.Pods, report.Volumes, report.Path)
+	}
 	os.Exit(0)
 }
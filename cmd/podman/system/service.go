@@ -40,9 +40,18 @@ Enable a listening service for API access to Podman commands.
 	}
 
 	srvArgs = struct {
-		CorsHeaders string
-		PProfAddr   string
-		Timeout     uint
+		CorsHeaders      string
+		PProfAddr        string
+		Timeout          uint
+		DrainTime        uint
+		ViewerUIDs       []uint
+		ViewerCNs        []string
+		RateLimits       map[string]int
+		MaxInFlight      map[string]int
+		DBCheckpoint     uint
+		StatsInterval    uint
+		StatsHistorySize uint
+		RestartInterval  uint
 	}{}
 )
 
@@ -61,12 +70,57 @@ func init() {
 	_ = srvCmd.RegisterFlagCompletionFunc(timeFlagName, completion.AutocompleteNone)
 	flags.SetNormalizeFunc(aliasTimeoutFlag)
 
+	drainTimeFlagName := "drain-time"
+	flags.UintVar(&srvArgs.DrainTime, drainTimeFlagName, 10,
+		"Time in seconds to wait for in-flight requests when shutting down")
+	_ = srvCmd.RegisterFlagCompletionFunc(drainTimeFlagName, completion.AutocompleteNone)
+
 	flags.StringVarP(&srvArgs.CorsHeaders, "cors", "", "", "Set CORS Headers")
 	_ = srvCmd.RegisterFlagCompletionFunc("cors", completion.AutocompleteNone)
 
 	flags.StringVarP(&srvArgs.PProfAddr, "pprof-address", "", "",
 		"Binding network address for pprof profile endpoints, default: do not expose endpoints")
 	_ = flags.MarkHidden("pprof-address")
+
+	viewerUIDFlagName := "viewer-uid"
+	flags.UintSliceVar(&srvArgs.ViewerUIDs, viewerUIDFlagName, nil, "Restrict clients connecting with this UID over a unix socket to read-only endpoints")
+	_ = srvCmd.RegisterFlagCompletionFunc(viewerUIDFlagName, completion.AutocompleteNone)
+
+	viewerCNFlagName := "viewer-cn"
+	flags.StringSliceVar(&srvArgs.ViewerCNs, viewerCNFlagName, nil, "Restrict clients authenticating with a TLS client certificate with this common name to read-only endpoints")
+	_ = srvCmd.RegisterFlagCompletionFunc(viewerCNFlagName, completion.AutocompleteNone)
+
+	rateLimitFlagName := "rate-limit"
+	flags.StringToIntVar(&srvArgs.RateLimits, rateLimitFlagName, nil, "Limit requests per minute of each client to an endpoint class (build, pull, exec), e.g. build=10")
+	_ = srvCmd.RegisterFlagCompletionFunc(rateLimitFlagName, common.AutocompleteServiceEndpointClass)
+
+	maxInFlightFlagName := "max-inflight"
+	flags.StringToIntVar(&srvArgs.MaxInFlight, maxInFlightFlagName, nil, "Limit concurrent requests of each client to an endpoint class (build, pull, exec), e.g. exec=4")
+	_ = srvCmd.RegisterFlagCompletionFunc(maxInFlightFlagName, common.AutocompleteServiceEndpointClass)
+
+	dbCheckpointFlagName := "db-checkpoint-interval"
+	flags.UintVar(&srvArgs.DBCheckpoint, dbCheckpointFlagName, 0,
+		"Interval in seconds to truncate the write-ahead log of the database.  Use 0 to disable periodic checkpoints")
+	_ = srvCmd.RegisterFlagCompletionFunc(dbCheckpointFlagName, completion.AutocompleteNone)
+
+	statsIntervalFlagName := "stats-interval"
+	flags.UintVar(&srvArgs.StatsInterval, statsIntervalFlagName, 0,
+		"Interval in seconds to record the stats of running containers for podman stats --history.  Use 0 to disable the stats collector")
+	_ = srvCmd.RegisterFlagCompletionFunc(statsIntervalFlagName, completion.AutocompleteNone)
+
+	statsHistorySizeFlagName := "stats-history-size"
+	flags.UintVar(&srvArgs.StatsHistorySize, statsHistorySizeFlagName, 720,
+		"Number of stats samples kept per container by the stats collector")
+	_ = srvCmd.RegisterFlagCompletionFunc(statsHistorySizeFlagName, completion.AutocompleteNone)
+
+	restartIntervalFlagName := "restart-interval"
+	flags.UintVar(&srvArgs.RestartInterval, restartIntervalFlagName, 0,
+		"Interval in seconds to restart the exited containers whose restart policy requires it.  0 disables restarting containers")
+	_ = srvCmd.RegisterFlagCompletionFunc(restartIntervalFlagName, completion.AutocompleteNone)
+
+	// Read by registry.NewContainerEngine, the runtime records the metrics
+	// from its creation.
+	flags.Bool("metrics", false, "Record the latencies of database operations and the events of the service for the metrics endpoint")
 }
 
 func aliasTimeoutFlag(_ *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -99,11 +153,25 @@ func service(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	viewerUIDs := make([]uint32, 0, len(srvArgs.ViewerUIDs))
+	for _, uid := range srvArgs.ViewerUIDs {
+		viewerUIDs = append(viewerUIDs, uint32(uid))
+	}
+
 	return restService(cmd.Flags(), registry.PodmanConfig(), entities.ServiceOptions{
-		CorsHeaders: srvArgs.CorsHeaders,
-		PProfAddr:   srvArgs.PProfAddr,
-		Timeout:     time.Duration(srvArgs.Timeout) * time.Second,
-		URI:         apiURI,
+		CorsHeaders:          srvArgs.CorsHeaders,
+		PProfAddr:            srvArgs.PProfAddr,
+		Timeout:              time.Duration(srvArgs.Timeout) * time.Second,
+		DrainTimeout:         time.Duration(srvArgs.DrainTime) * time.Second,
+		URI:                  apiURI,
+		ViewerUIDs:           viewerUIDs,
+		ViewerCNs:            srvArgs.ViewerCNs,
+		RateLimits:           srvArgs.RateLimits,
+		MaxInFlight:          srvArgs.MaxInFlight,
+		DBCheckpointInterval: time.Duration(srvArgs.DBCheckpoint) * time.Second,
+		StatsInterval:        time.Duration(srvArgs.StatsInterval) * time.Second,
+		StatsHistorySize:     srvArgs.StatsHistorySize,
+		RestartInterval:      time.Duration(srvArgs.RestartInterval) * time.Second,
 	})
 }
 
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 
 	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod"
 	api "github.com/containers/podman/v5/pkg/api/server"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/infra"
@@ -124,6 +125,9 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 	maybeMoveToSubCgroup()
 
 	maybeStartServiceReaper()
+	if err := setServiceLogLevel(flags, libpodRuntime); err != nil {
+		return err
+	}
 	infra.StartWatcher(libpodRuntime)
 	server, err := api.NewServerWithSettings(libpodRuntime, listener, opts)
 	if err != nil {
@@ -141,3 +145,21 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 	}
 	return err
 }
+
+// setServiceLogLevel sets the log level of the service to the one of
+// containers.conf unless it is given on the command line.
+func setServiceLogLevel(flags *pflag.FlagSet, rt *libpod.Runtime) error {
+	if flags.Changed("log-level") || flags.Changed("debug") {
+		return nil
+	}
+	conf := rt.GetPodmanConfigNoCopy()
+	if conf.Engine.ServiceLogLevel == "" {
+		return nil
+	}
+	level, err := logrus.ParseLevel(conf.Engine.ServiceLogLevel)
+	if err != nil {
+		return fmt.Errorf("service_log_level: %w", err)
+	}
+	logrus.SetLevel(level)
+	return nil
+}
@@ -0,0 +1,94 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createDescription = `Create a container from a container template.
+
+  The template is pulled from a registry, or read from a file holding the JSON generated by podman generate spec. The image of the template is pulled if it is missing.`
+	createCmd = &cobra.Command{
+		Use:               "create [options] {SOURCE|FILE}",
+		Short:             "Create a container from a container template",
+		Long:              createDescription,
+		RunE:              create,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman template create --name web quay.io/team/web-template:1.0
+  podman template create web.json`,
+	}
+
+	createOptions registryOptions
+	createName    string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: createCmd,
+		Parent:  templateCmd,
+	})
+	createOptions.addFlags(createCmd)
+	flags := createCmd.Flags()
+
+	nameFlagName := "name"
+	flags.StringVar(&createName, nameFlagName, "", "Assign a name to the container")
+	_ = createCmd.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+}
+
+func create(cmd *cobra.Command, args []string) error {
+	if err := createOptions.parse(cmd); err != nil {
+		return err
+	}
+
+	spec, err := readSpecFile(args[0])
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		report, err := registry.ContainerEngine().ContainerTemplatePull(registry.Context(), args[0], entities.ContainerTemplatePullOptions{
+			ContainerTemplateRegistryOptions: createOptions.ContainerTemplateRegistryOptions,
+		})
+		if err != nil {
+			return err
+		}
+		spec = report.Spec
+	}
+
+	s := new(specgen.SpecGenerator)
+	if err := json.Unmarshal(spec, s); err != nil {
+		return fmt.Errorf("parsing container spec: %w", err)
+	}
+	s.Name = createName
+
+	if s.Image != "" && s.Rootfs == "" {
+		if _, err := registry.ImageEngine().Pull(registry.Context(), s.Image, entities.ImagePullOptions{
+			Authfile:        createOptions.Authfile,
+			CertDir:         createOptions.CertDir,
+			Username:        createOptions.Username,
+			Password:        createOptions.Password,
+			SignaturePolicy: createOptions.SignaturePolicy,
+			SkipTLSVerify:   createOptions.SkipTLSVerify,
+			PullPolicy:      config.PullPolicyMissing,
+			Writer:          os.Stderr,
+		}); err != nil {
+			return err
+		}
+	}
+
+	report, err := registry.ContainerEngine().ContainerCreate(registry.Context(), s)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
@@ -0,0 +1,63 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullCmd = &cobra.Command{
+		Use:               "pull [options] SOURCE",
+		Short:             "Pull a container template from a registry",
+		Long:              "Pull a container template from a registry and print its spec, as generated by podman generate spec.",
+		RunE:              pull,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman template pull quay.io/team/web-template:1.0
+  podman template pull --filename web.json quay.io/team/web-template:1.0`,
+	}
+
+	pullOptions  registryOptions
+	pullFilename string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: pullCmd,
+		Parent:  templateCmd,
+	})
+	pullOptions.addFlags(pullCmd)
+	flags := pullCmd.Flags()
+
+	filenameFlagName := "filename"
+	flags.StringVarP(&pullFilename, filenameFlagName, "f", "", "Write the spec to the specified path")
+	_ = pullCmd.RegisterFlagCompletionFunc(filenameFlagName, completion.AutocompleteDefault)
+}
+
+func pull(cmd *cobra.Command, args []string) error {
+	if err := pullOptions.parse(cmd); err != nil {
+		return err
+	}
+
+	report, err := registry.ContainerEngine().ContainerTemplatePull(registry.Context(), args[0], entities.ContainerTemplatePullOptions{
+		ContainerTemplateRegistryOptions: pullOptions.ContainerTemplateRegistryOptions,
+	})
+	if err != nil {
+		return err
+	}
+
+	if pullFilename != "" {
+		if err := os.WriteFile(pullFilename, report.Spec, 0o644); err != nil {
+			return err
+		}
+		fmt.Println(pullFilename)
+		return nil
+	}
+	fmt.Println(string(report.Spec))
+	return nil
+}
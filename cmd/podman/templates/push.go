@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushDescription = `Push the run configuration of a container to a registry as a container template.
+
+  The template is taken from an existing container, or from a file holding the JSON generated by podman generate spec. Templates are stored as OCI artifacts, versioned by their tag.`
+	pushCmd = &cobra.Command{
+		Use:               "push [options] {CONTAINER|FILE} DESTINATION",
+		Short:             "Push a container template to a registry",
+		Long:              pushDescription,
+		RunE:              push,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman template push web quay.io/team/web-template:1.0
+  podman template push --annotation org.opencontainers.image.description="Web frontend" web.json quay.io/team/web-template:1.1`,
+	}
+
+	pushOptions     registryOptions
+	pushAnnotations []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: pushCmd,
+		Parent:  templateCmd,
+	})
+	pushOptions.addFlags(pushCmd)
+	flags := pushCmd.Flags()
+
+	annotationFlagName := "annotation"
+	flags.StringArrayVar(&pushAnnotations, annotationFlagName, nil, "Add an annotation to the template manifest (key=value)")
+	_ = pushCmd.RegisterFlagCompletionFunc(annotationFlagName, completion.AutocompleteNone)
+}
+
+func push(cmd *cobra.Command, args []string) error {
+	if err := pushOptions.parse(cmd); err != nil {
+		return err
+	}
+
+	annotations := make(map[string]string, len(pushAnnotations))
+	for _, annotation := range pushAnnotations {
+		key, value, ok := strings.Cut(annotation, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid annotation %q, must be key=value", annotation)
+		}
+		annotations[key] = value
+	}
+
+	spec, err := readSpecFile(args[0])
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		if spec, err = containerSpec(cmd, args[0]); err != nil {
+			return err
+		}
+	}
+
+	report, err := registry.ContainerEngine().ContainerTemplatePush(registry.Context(), args[1], entities.ContainerTemplatePushOptions{
+		ContainerTemplateRegistryOptions: pushOptions.ContainerTemplateRegistryOptions,
+		Spec:                             spec,
+		Annotations:                      annotations,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Digest)
+	return nil
+}
+
+// containerSpec returns the spec of the given container, referring to its
+// image by name instead of ID so it can be used on other hosts.
+func containerSpec(cmd *cobra.Command, nameOrID string) ([]byte, error) {
+	report, err := registry.ContainerEngine().GenerateSpec(registry.Context(), &entities.GenerateSpecOptions{ID: nameOrID})
+	if err != nil {
+		return nil, err
+	}
+	s := new(specgen.SpecGenerator)
+	if err := json.Unmarshal(report.Data, s); err != nil {
+		return nil, err
+	}
+	if s.Rootfs == "" {
+		data, errs, err := registry.ContainerEngine().ContainerInspect(registry.Context(), []string{nameOrID}, entities.InspectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		if data[0].ImageName != "" {
+			s.Image = data[0].ImageName
+		}
+	}
+	return json.Marshal(s)
+}
@@ -0,0 +1,99 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/auth"
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman _template_
+	templateCmd = &cobra.Command{
+		Use:   "template",
+		Short: "Manage container templates",
+		Long:  "Share the run configurations of containers as templates stored in registries",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: templateCmd,
+	})
+}
+
+// registryOptions are the options of the registry holding a template, as
+// given on the command line.
+type registryOptions struct {
+	entities.ContainerTemplateRegistryOptions
+	creds     string
+	tlsVerify bool
+}
+
+func (o *registryOptions) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&o.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = cmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	certDirFlagName := "cert-dir"
+	flags.StringVar(&o.CertDir, certDirFlagName, "", "Path to a directory containing TLS certificates and keys")
+	_ = cmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+
+	credsFlagName := "creds"
+	flags.StringVar(&o.creds, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	_ = cmd.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&o.tlsVerify, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+
+	signaturePolicyFlagName := "signature-policy"
+	flags.StringVar(&o.SignaturePolicy, signaturePolicyFlagName, "", "Path to a signature-policy file")
+	_ = flags.MarkHidden(signaturePolicyFlagName)
+}
+
+// parse completes the options from the flags which need parsing.
+func (o *registryOptions) parse(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("tls-verify") {
+		o.SkipTLSVerify = types.NewOptionalBool(!o.tlsVerify)
+	}
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(o.Authfile); err != nil {
+			return err
+		}
+	}
+	if o.creds != "" {
+		creds, err := util.ParseRegistryCreds(o.creds)
+		if err != nil {
+			return err
+		}
+		o.Username = creds.Username
+		o.Password = creds.Password
+	}
+	return nil
+}
+
+// readSpecFile returns the content of the file at path, or nil if there is
+// no such file.
+func readSpecFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/pkg/containersconf"
+	"github.com/containers/podman/v5/pkg/tracing"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// tracingProvider exports the spans of the process, if tracing is
+	// configured.
+	tracingProvider *sdktrace.TracerProvider
+	// commandSpan is the span of the command, the root of its trace.
+	commandSpan trace.Span
+)
+
+// setupTracing enables tracing if a tracing exporter is configured in
+// containers.conf and starts the span of cmd, which becomes the parent of the
+// spans of the operations it makes. The system service traces the requests it
+// serves instead.
+func setupTracing(cmd *cobra.Command) error {
+	if registry.IsRemote() {
+		return nil
+	}
+	conf, err := containersconf.New(registry.PodmanConfig().ContainersConfDefaultsRO.LoadedModules())
+	if err != nil {
+		return err
+	}
+	engineConfig := conf.Engine
+	if engineConfig.TracingExporter == "" {
+		return nil
+	}
+
+	provider, err := tracing.Setup(engineConfig.TracingExporter, engineConfig.TracingEndpoint)
+	if err != nil {
+		return fmt.Errorf("setting up tracing: %w", err)
+	}
+	tracingProvider = provider
+	logrus.Debugf("Exporting traces with the %s exporter to %s", engineConfig.TracingExporter, engineConfig.TracingEndpoint)
+
+	if cmd.Name() == "service" && cmd.Parent().Name() == "system" {
+		return nil
+	}
+	// The arguments are not recorded, they may hold secrets.
+	ctx, span := otel.Tracer("github.com/containers/podman/v5/cmd/podman").Start(registry.Context(), cmd.CommandPath())
+	commandSpan = span
+	cmd.SetContext(registry.ContextWithOptions(ctx))
+	tracing.SetProcessSpan(ctx)
+	return nil
+}
+
+// shutdownTracing ends the span of the command with its error, if any, and
+// exports the spans not exported yet.
+func shutdownTracing(cmdErr error) {
+	if tracingProvider == nil {
+		return
+	}
+	if commandSpan != nil {
+		if cmdErr != nil {
+			commandSpan.RecordError(cmdErr)
+			commandSpan.SetStatus(codes.Error, cmdErr.Error())
+		}
+		commandSpan.End()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := tracingProvider.Shutdown(ctx); err != nil {
+		logrus.Warnf("Failed to export traces: %v", err)
+	}
+}
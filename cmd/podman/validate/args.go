@@ -3,6 +3,7 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -31,6 +32,18 @@ func SubCommandExists(cmd *cobra.Command, args []string) error {
 	return fmt.Errorf("missing command '%[1]s COMMAND'", cmd.CommandPath())
 }
 
+// IsSubCommandGroup returns true if the command only groups its subcommands and
+// has no action of its own besides SubCommandExists.
+func IsSubCommandGroup(cmd *cobra.Command) bool {
+	if !cmd.HasSubCommands() {
+		return false
+	}
+	if cmd.RunE == nil {
+		return cmd.Run == nil
+	}
+	return reflect.ValueOf(cmd.RunE).Pointer() == reflect.ValueOf(SubCommandExists).Pointer()
+}
+
 // IDOrLatestArgs used to validate a nameOrId was provided or the "--latest" flag
 func IDOrLatestArgs(cmd *cobra.Command, args []string) error {
 	if len(args) > 1 {
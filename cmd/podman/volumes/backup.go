@@ -0,0 +1,26 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "backup",
+		Short:       "Manage scheduled volume backups",
+		Long:        "Back up volumes on a schedule, keeping a number of backups on a local or ssh:// target, and restore them",
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupCmd,
+		Parent:  volumeCmd,
+	})
+}
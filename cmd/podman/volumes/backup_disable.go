@@ -0,0 +1,35 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupDisableDescription = `Stop backing up a volume on a schedule. Existing backups are kept and can still be restored.`
+
+	backupDisableCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "disable VOLUME",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Disable scheduled backups of a volume",
+		Long:              backupDisableDescription,
+		RunE:              backupDisable,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example:           `podman volume backup disable myvol`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupDisableCommand,
+		Parent:  backupCmd,
+	})
+}
+
+func backupDisable(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().VolumeBackupDisable(registry.Context(), args[0])
+}
@@ -0,0 +1,61 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupEnableDescription = `Back up a volume on a schedule.
+
+  The backups are zstd compressed tar archives written to a directory on the host or to an ssh://[USER@]HOST[:PORT]/DIR target by the Podman service. The oldest backups are removed from the target once more than --keep backups exist.`
+
+	backupEnableCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "enable [options] VOLUME",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Enable scheduled backups of a volume",
+		Long:              backupEnableDescription,
+		RunE:              backupEnable,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume backup enable --target /backups myvol
+  podman volume backup enable --schedule hourly --keep 24 --target ssh://backup@example.com/backups myvol`,
+	}
+
+	backupEnableOpts = entities.VolumeBackupEnableOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupEnableCommand,
+		Parent:  backupCmd,
+	})
+	flags := backupEnableCommand.Flags()
+
+	scheduleFlagName := "schedule"
+	flags.StringVar(&backupEnableOpts.Schedule, scheduleFlagName, string(define.VolumeBackupDaily), "How often the volume is backed up (hourly, daily or weekly)")
+	_ = backupEnableCommand.RegisterFlagCompletionFunc(scheduleFlagName, completion.AutocompleteNone)
+
+	keepFlagName := "keep"
+	flags.IntVar(&backupEnableOpts.Keep, keepFlagName, 7, "Number of backups kept on the target")
+	_ = backupEnableCommand.RegisterFlagCompletionFunc(keepFlagName, completion.AutocompleteNone)
+
+	targetFlagName := "target"
+	flags.StringVar(&backupEnableOpts.Target, targetFlagName, "", "Directory or ssh://[USER@]HOST[:PORT]/DIR URL the backups are written to")
+	_ = backupEnableCommand.RegisterFlagCompletionFunc(targetFlagName, completion.AutocompleteDefault)
+	_ = backupEnableCommand.MarkFlagRequired(targetFlagName)
+
+	identityFlagName := "identity"
+	flags.StringVar(&backupEnableOpts.Identity, identityFlagName, "", "SSH private key used for ssh:// targets")
+	_ = backupEnableCommand.RegisterFlagCompletionFunc(identityFlagName, completion.AutocompleteDefault)
+}
+
+func backupEnable(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().VolumeBackupEnable(registry.Context(), args[0], backupEnableOpts)
+}
@@ -0,0 +1,46 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupInspectDescription = `Display the backup policy and the backups of one or more volumes in JSON.`
+
+	backupInspectCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "inspect VOLUME [VOLUME...]",
+		Args:              cobra.MinimumNArgs(1),
+		Short:             "Display the backups of volumes",
+		Long:              backupInspectDescription,
+		RunE:              backupInspect,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example:           `podman volume backup inspect myvol`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupInspectCommand,
+		Parent:  backupCmd,
+	})
+}
+
+func backupInspect(cmd *cobra.Command, args []string) error {
+	reports, err := registry.ContainerEngine().VolumeBackupInspect(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
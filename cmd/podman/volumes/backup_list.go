@@ -0,0 +1,119 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupListDescription = `List the backups of a volume, or of all volumes if none is given, oldest first.`
+
+	backupListCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "ls [options] [VOLUME]",
+		Aliases:           []string{"list"},
+		Args:              cobra.MaximumNArgs(1),
+		Short:             "List volume backups",
+		Long:              backupListDescription,
+		RunE:              backupList,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume backup ls
+  podman volume backup ls --format json myvol`,
+	}
+
+	backupListFormat string
+)
+
+// backupReporter formats a volume backup for the default table output.
+type backupReporter struct {
+	*define.VolumeBackup
+}
+
+func (b backupReporter) ID() string {
+	return strconv.FormatInt(b.VolumeBackup.ID, 10)
+}
+
+func (b backupReporter) Created() string {
+	return units.HumanDuration(time.Since(b.VolumeBackup.Created)) + " ago"
+}
+
+func (b backupReporter) Size() string {
+	return units.HumanSizeWithPrecision(float64(b.VolumeBackup.Size), 3)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupListCommand,
+		Parent:  backupCmd,
+	})
+	flags := backupListCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&backupListFormat, formatFlagName, "{{range .}}{{.ID}}\t{{.Volume}}\t{{.Created}}\t{{.Size}}\t{{.Archive}}\n{{end -}}", "Format backup output using Go template")
+	_ = backupListCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&backupReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+}
+
+func backupList(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	backups, err := registry.ContainerEngine().VolumeBackupList(registry.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(backupListFormat) {
+		b, err := json.MarshalIndent(backups, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]backupReporter, 0, len(backups))
+	for _, backup := range backups {
+		reporters = append(reporters, backupReporter{backup})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, backupListFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(backupReporter{}, map[string]string{
+			"ID":      "ID",
+			"Volume":  "VOLUME",
+			"Created": "CREATED",
+			"Size":    "SIZE",
+			"Archive": "ARCHIVE",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
@@ -0,0 +1,54 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupRestoreDescription = `Replace the contents of a volume with one of its backups.
+
+  Restores the backup with the given ID, as printed by podman volume backup ls, or the latest backup of the volume if no ID is given. The digest of the archive is verified before the volume is changed.`
+
+	backupRestoreCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "restore VOLUME [ID]",
+		Args:              cobra.RangeArgs(1, 2),
+		Short:             "Restore a volume from a backup",
+		Long:              backupRestoreDescription,
+		RunE:              backupRestore,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume backup restore myvol
+  podman volume backup restore myvol 42`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupRestoreCommand,
+		Parent:  backupCmd,
+	})
+}
+
+func backupRestore(cmd *cobra.Command, args []string) error {
+	var id int64
+	if len(args) > 1 {
+		var err error
+		id, err = strconv.ParseInt(args[1], 10, 64)
+		if err != nil || id < 1 {
+			return fmt.Errorf("invalid backup ID %q", args[1])
+		}
+	}
+	backup, err := registry.ContainerEngine().VolumeBackupRestore(registry.Context(), args[0], id)
+	if err != nil {
+		return err
+	}
+	fmt.Println(backup.Archive)
+	return nil
+}
@@ -0,0 +1,43 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupRunDescription = `Back up volumes now, regardless of their schedule.
+
+  Backs up the given volumes, or all volumes with scheduled backups if none are given, and prints the paths of the new archives.`
+
+	backupRunCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "run [VOLUME...]",
+		Short:             "Back up volumes now",
+		Long:              backupRunDescription,
+		RunE:              backupRun,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume backup run
+  podman volume backup run myvol`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupRunCommand,
+		Parent:  backupCmd,
+	})
+}
+
+func backupRun(cmd *cobra.Command, args []string) error {
+	backups, err := registry.ContainerEngine().VolumeBackupRun(registry.Context(), args)
+	for _, backup := range backups {
+		fmt.Println(backup.Archive)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotCreateDescription = `Take a snapshot of the contents of a volume.
+
+  The files of the snapshot are reflinks of the files of the volume on file systems supporting them, e.g. btrfs and XFS, and copies otherwise. Prints the name of the snapshot.`
+
+	snapshotCreateCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "create [options] VOLUME",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Take a snapshot of a volume",
+		Long:              snapshotCreateDescription,
+		RunE:              snapshotCreate,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot create myvol
+  podman volume snapshot create --name before-upgrade myvol`,
+	}
+
+	snapshotCreateName string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotCreateCommand,
+		Parent:  snapshotCmd,
+	})
+	flags := snapshotCreateCommand.Flags()
+
+	nameFlagName := "name"
+	flags.StringVar(&snapshotCreateName, nameFlagName, "", "Name of the snapshot, the current time by default")
+	_ = snapshotCreateCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+}
+
+func snapshotCreate(cmd *cobra.Command, args []string) error {
+	snapshot, err := registry.ContainerEngine().VolumeSnapshotCreate(registry.Context(), args[0], snapshotCreateName)
+	if err != nil {
+		return err
+	}
+	fmt.Println(snapshot.Name)
+	return nil
+}
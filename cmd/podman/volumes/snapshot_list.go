@@ -0,0 +1,120 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotListDescription = `List the snapshots of a volume, or of all volumes if none is given, oldest first.`
+
+	snapshotListCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "ls [options] [VOLUME]",
+		Aliases:           []string{"list"},
+		Args:              cobra.MaximumNArgs(1),
+		Short:             "List volume snapshots",
+		Long:              snapshotListDescription,
+		RunE:              snapshotList,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot ls
+  podman volume snapshot ls --format json myvol`,
+	}
+
+	snapshotListFormat string
+)
+
+// snapshotReporter formats a volume snapshot for the default table output.
+type snapshotReporter struct {
+	*define.VolumeSnapshot
+}
+
+func (s snapshotReporter) ID() string {
+	return strconv.FormatInt(s.VolumeSnapshot.ID, 10)
+}
+
+func (s snapshotReporter) Created() string {
+	return units.HumanDuration(time.Since(s.VolumeSnapshot.Created)) + " ago"
+}
+
+func (s snapshotReporter) Size() string {
+	return units.HumanSizeWithPrecision(float64(s.VolumeSnapshot.Size), 3)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotListCommand,
+		Parent:  snapshotCmd,
+	})
+	flags := snapshotListCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&snapshotListFormat, formatFlagName, "{{range .}}{{.ID}}\t{{.Volume}}\t{{.Name}}\t{{.Method}}\t{{.Created}}\t{{.Size}}\n{{end -}}", "Format snapshot output using Go template")
+	_ = snapshotListCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&snapshotReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+}
+
+func snapshotList(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	snapshots, err := registry.ContainerEngine().VolumeSnapshotList(registry.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(snapshotListFormat) {
+		b, err := json.MarshalIndent(snapshots, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	reporters := make([]snapshotReporter, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		reporters = append(reporters, snapshotReporter{snapshot})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	origin := report.OriginPodman
+	if cmd.Flag("format").Changed {
+		origin = report.OriginUser
+	}
+	rpt, err = rpt.Parse(origin, snapshotListFormat)
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(snapshotReporter{}, map[string]string{
+			"ID":      "ID",
+			"Volume":  "VOLUME",
+			"Name":    "NAME",
+			"Method":  "METHOD",
+			"Created": "CREATED",
+			"Size":    "SIZE",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reporters)
+}
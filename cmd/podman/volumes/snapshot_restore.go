@@ -0,0 +1,45 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotRestoreDescription = `Replace the contents of a volume with one of its snapshots.
+
+  The snapshot is given by its name or ID, as printed by podman volume snapshot ls. The volume must not be used by a running container.`
+
+	snapshotRestoreCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "restore VOLUME SNAPSHOT",
+		Args:              cobra.ExactArgs(2),
+		Short:             "Restore a volume from a snapshot",
+		Long:              snapshotRestoreDescription,
+		RunE:              snapshotRestore,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot restore myvol before-upgrade
+  podman volume snapshot restore myvol 42`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotRestoreCommand,
+		Parent:  snapshotCmd,
+	})
+}
+
+func snapshotRestore(cmd *cobra.Command, args []string) error {
+	snapshot, err := registry.ContainerEngine().VolumeSnapshotRestore(registry.Context(), args[0], args[1])
+	if err != nil {
+		return err
+	}
+	fmt.Println(snapshot.Name)
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build !remote
+
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/cmd/podman/common"
+	"github.com/containers/podman/v5/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotRmDescription = `Remove snapshots of a volume.
+
+  The snapshots are given by their names or IDs, as printed by podman volume snapshot ls. The snapshots of a volume are also removed with the volume.`
+
+	snapshotRmCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "rm VOLUME SNAPSHOT [SNAPSHOT...]",
+		Aliases:           []string{"remove"},
+		Args:              cobra.MinimumNArgs(2),
+		Short:             "Remove volume snapshots",
+		Long:              snapshotRmDescription,
+		RunE:              snapshotRm,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot rm myvol before-upgrade
+  podman volume snapshot rm myvol 41 42`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotRmCommand,
+		Parent:  snapshotCmd,
+	})
+}
+
+func snapshotRm(cmd *cobra.Command, args []string) error {
+	snapshots, err := registry.ContainerEngine().VolumeSnapshotRemove(registry.Context(), args[0], args[1:])
+	for _, snapshot := range snapshots {
+		fmt.Println(snapshot.Name)
+	}
+	return err
+}
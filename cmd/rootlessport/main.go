@@ -262,13 +262,29 @@ func serve(listener net.Listener, pm rkport.Manager) {
 	}
 }
 
+// updateRequest replaces the forwarded ports, as sent by podman pod update.
+// A request consisting of a JSON string only reloads the ports with a new
+// child IP.
+type updateRequest struct {
+	ChildIP  string              `json:"childIP"`
+	Mappings []types.PortMapping `json:"mappings"`
+}
+
 func handler(ctx context.Context, conn io.Reader, pm rkport.Manager) error {
-	var childIP string
+	var request json.RawMessage
 	dec := json.NewDecoder(conn)
-	err := dec.Decode(&childIP)
+	err := dec.Decode(&request)
 	if err != nil {
 		return fmt.Errorf("rootless port failed to decode ports: %w", err)
 	}
+	var update *updateRequest
+	var childIP string
+	if err := json.Unmarshal(request, &childIP); err != nil {
+		update = new(updateRequest)
+		if err := json.Unmarshal(request, update); err != nil {
+			return fmt.Errorf("rootless port failed to decode ports: %w", err)
+		}
+	}
 	portStatus, err := pm.ListPorts(ctx)
 	if err != nil {
 		return fmt.Errorf("rootless port failed to list ports: %w", err)
@@ -279,6 +295,13 @@ func handler(ctx context.Context, conn io.Reader, pm rkport.Manager) error {
 			return fmt.Errorf("rootless port failed to remove port: %w", err)
 		}
 	}
+	if update != nil {
+		// add the new ports
+		if err := exposePorts(pm, update.Mappings, update.ChildIP); err != nil {
+			return fmt.Errorf("rootless port failed to add port: %w", err)
+		}
+		return nil
+	}
 	// add the ports with the new child IP
 	for _, status := range portStatus {
 		// set the new child IP
@@ -0,0 +1,415 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/tracing"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+)
+
+// auditLog appends audit entries to a file, one JSON object per line.
+type auditLog struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// newAuditLog opens the audit log at the given path for appending, creating
+// it if needed.
+func newAuditLog(path string) (*auditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("creating audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	return &auditLog{file: file}, nil
+}
+
+// write appends the entry to the log. Each entry is written with a single
+// write, so entries of concurrent Podman processes are not interleaved.
+func (l *auditLog) write(entry *define.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("audit log is closed: %w", define.ErrRuntimeStopped)
+	}
+	_, err = l.file.Write(data)
+	return err
+}
+
+func (l *auditLog) close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// AuditEnabled returns true if an audit log is configured.
+func (r *Runtime) AuditEnabled() bool {
+	return r.audit != nil
+}
+
+// Audit appends the entry to the audit log, if one is configured. The time
+// and PID of the entry are filled in if unset.
+func (r *Runtime) Audit(entry *define.AuditEntry) error {
+	if r.audit == nil {
+		return nil
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	if entry.PID == 0 {
+		entry.PID = os.Getpid()
+	}
+	return r.audit.write(entry)
+}
+
+// auditState wraps the state of the runtime, recording every modification in
+// the audit log. Reads are passed through unchanged.
+type auditState struct {
+	State
+	log *auditLog
+}
+
+// wrapState returns the state recording modifications in the audit log if one
+// is configured and recording metrics and traces if they are enabled, or the
+// state unchanged otherwise.
+func (r *Runtime) wrapState(state State) State {
+	if r.audit != nil {
+		state = &auditState{State: state, log: r.audit}
+	}
+	if r.metrics != nil || tracing.Enabled() {
+		state = &instrumentedState{State: state, runtime: r}
+	}
+	return state
+}
+
+// backingState returns the database state of the runtime, without the audit
+// and instrumentation wrappers.
+func (r *Runtime) backingState() State {
+	state := r.state
+	if s, ok := state.(*instrumentedState); ok {
+		state = s.State
+	}
+	if s, ok := state.(*auditState); ok {
+		state = s.State
+	}
+	return state
+}
+
+// record appends an entry for the modification to the audit log. Failing to
+// write the entry does not fail the modification, which already happened.
+func (s *auditState) record(operation, objType, id, name, detail string, opErr error) {
+	entry := &define.AuditEntry{
+		Time:      time.Now(),
+		Source:    define.AuditSourceState,
+		UID:       os.Getuid(),
+		PID:       os.Getpid(),
+		Operation: operation,
+		Type:      objType,
+		ID:        id,
+		Name:      name,
+		Detail:    detail,
+	}
+	if opErr != nil {
+		entry.Error = opErr.Error()
+	}
+	if err := s.log.write(entry); err != nil {
+		logrus.Errorf("Writing %s of %s %s to audit log: %v", operation, objType, id, err)
+	}
+}
+
+func (s *auditState) recordContainer(operation string, ctr *Container, detail string, err error) {
+	s.record(operation, "container", ctr.ID(), ctr.Name(), detail, err)
+}
+
+func (s *auditState) recordPod(operation string, pod *Pod, detail string, err error) {
+	s.record(operation, "pod", pod.ID(), pod.Name(), detail, err)
+}
+
+func (s *auditState) recordVolume(operation string, volume *Volume, err error) {
+	s.record(operation, "volume", volume.Name(), volume.Name(), "", err)
+}
+
+func (s *auditState) AddContainer(ctr *Container) error {
+	err := s.State.AddContainer(ctr)
+	s.recordContainer("AddContainer", ctr, "", err)
+	return err
+}
+
+func (s *auditState) RemoveContainer(ctr *Container) error {
+	err := s.State.RemoveContainer(ctr)
+	s.recordContainer("RemoveContainer", ctr, "", err)
+	return err
+}
+
+func (s *auditState) AddContainers(ctrs []*Container) error {
+	err := s.State.AddContainers(ctrs)
+	for _, ctr := range ctrs {
+		s.recordContainer("AddContainers", ctr, "", err)
+	}
+	return err
+}
+
+func (s *auditState) RemoveContainers(ctrs []*Container) error {
+	err := s.State.RemoveContainers(ctrs)
+	for _, ctr := range ctrs {
+		s.recordContainer("RemoveContainers", ctr, "", err)
+	}
+	return err
+}
+
+func (s *auditState) SaveContainer(ctr *Container) error {
+	err := s.State.SaveContainer(ctr)
+	s.recordContainer("SaveContainer", ctr, ctr.state.State.String(), err)
+	return err
+}
+
+func (s *auditState) NetworkConnect(ctr *Container, network string, opts types.PerNetworkOptions) error {
+	err := s.State.NetworkConnect(ctr, network, opts)
+	s.recordContainer("NetworkConnect", ctr, network, err)
+	return err
+}
+
+func (s *auditState) NetworkModify(ctr *Container, network string, opts types.PerNetworkOptions) error {
+	err := s.State.NetworkModify(ctr, network, opts)
+	s.recordContainer("NetworkModify", ctr, network, err)
+	return err
+}
+
+func (s *auditState) NetworkDisconnect(ctr *Container, network string) error {
+	err := s.State.NetworkDisconnect(ctr, network)
+	s.recordContainer("NetworkDisconnect", ctr, network, err)
+	return err
+}
+
+func (s *auditState) SetContainerMetadata(id, key, value string) error {
+	err := s.State.SetContainerMetadata(id, key, value)
+	s.record("SetContainerMetadata", "container", id, "", key, err)
+	return err
+}
+
+func (s *auditState) AddExecSession(ctr *Container, session *ExecSession) error {
+	err := s.State.AddExecSession(ctr, session)
+	s.recordContainer("AddExecSession", ctr, session.ID(), err)
+	return err
+}
+
+func (s *auditState) RemoveExecSession(session *ExecSession) error {
+	err := s.State.RemoveExecSession(session)
+	s.record("RemoveExecSession", "container", session.ContainerID(), "", session.ID(), err)
+	return err
+}
+
+func (s *auditState) RemoveContainerExecSessions(ctr *Container) error {
+	err := s.State.RemoveContainerExecSessions(ctr)
+	s.recordContainer("RemoveContainerExecSessions", ctr, "", err)
+	return err
+}
+
+func (s *auditState) RewriteContainerConfig(ctr *Container, newCfg *ContainerConfig) error {
+	err := s.State.RewriteContainerConfig(ctr, newCfg)
+	s.recordContainer("RewriteContainerConfig", ctr, "", err)
+	return err
+}
+
+func (s *auditState) SafeRewriteContainerConfig(ctr *Container, oldName, newName string, newCfg *ContainerConfig) error {
+	err := s.State.SafeRewriteContainerConfig(ctr, oldName, newName, newCfg)
+	s.recordContainer("SafeRewriteContainerConfig", ctr, newName, err)
+	return err
+}
+
+func (s *auditState) RenameContainer(ctr *Container, newName string) error {
+	err := s.State.RenameContainer(ctr, newName)
+	s.recordContainer("RenameContainer", ctr, newName, err)
+	return err
+}
+
+func (s *auditState) UpdateContainerResources(ctr *Container, resources *spec.LinuxResources) error {
+	err := s.State.UpdateContainerResources(ctr, resources)
+	s.recordContainer("UpdateContainerResources", ctr, "", err)
+	return err
+}
+
+func (s *auditState) AddPod(pod *Pod) error {
+	err := s.State.AddPod(pod)
+	s.recordPod("AddPod", pod, "", err)
+	return err
+}
+
+func (s *auditState) RemovePod(pod *Pod) error {
+	err := s.State.RemovePod(pod)
+	s.recordPod("RemovePod", pod, "", err)
+	return err
+}
+
+func (s *auditState) RemovePodContainers(pod *Pod) error {
+	err := s.State.RemovePodContainers(pod)
+	s.recordPod("RemovePodContainers", pod, "", err)
+	return err
+}
+
+func (s *auditState) AddContainerToPod(pod *Pod, ctr *Container) error {
+	err := s.State.AddContainerToPod(pod, ctr)
+	s.recordContainer("AddContainerToPod", ctr, pod.ID(), err)
+	return err
+}
+
+func (s *auditState) RemoveContainerFromPod(pod *Pod, ctr *Container) error {
+	err := s.State.RemoveContainerFromPod(pod, ctr)
+	s.recordContainer("RemoveContainerFromPod", ctr, pod.ID(), err)
+	return err
+}
+
+func (s *auditState) RewritePodConfig(pod *Pod, newCfg *PodConfig) error {
+	err := s.State.RewritePodConfig(pod, newCfg)
+	s.recordPod("RewritePodConfig", pod, "", err)
+	return err
+}
+
+func (s *auditState) SavePod(pod *Pod) error {
+	err := s.State.SavePod(pod)
+	s.recordPod("SavePod", pod, "", err)
+	return err
+}
+
+func (s *auditState) AddVolume(volume *Volume) error {
+	err := s.State.AddVolume(volume)
+	s.recordVolume("AddVolume", volume, err)
+	return err
+}
+
+func (s *auditState) RemoveVolume(volume *Volume) error {
+	err := s.State.RemoveVolume(volume)
+	s.recordVolume("RemoveVolume", volume, err)
+	return err
+}
+
+func (s *auditState) SaveVolume(volume *Volume) error {
+	err := s.State.SaveVolume(volume)
+	s.recordVolume("SaveVolume", volume, err)
+	return err
+}
+
+func (s *auditState) RewriteVolumeConfig(volume *Volume, newCfg *VolumeConfig) error {
+	err := s.State.RewriteVolumeConfig(volume, newCfg)
+	s.recordVolume("RewriteVolumeConfig", volume, err)
+	return err
+}
+
+func (s *auditState) SetVolumeBackupPolicy(policy *define.VolumeBackupPolicy) error {
+	err := s.State.SetVolumeBackupPolicy(policy)
+	s.record("SetVolumeBackupPolicy", "volume", policy.Volume, policy.Volume, "", err)
+	return err
+}
+
+func (s *auditState) RemoveVolumeBackupPolicy(volume string) error {
+	err := s.State.RemoveVolumeBackupPolicy(volume)
+	s.record("RemoveVolumeBackupPolicy", "volume", volume, volume, "", err)
+	return err
+}
+
+func (s *auditState) AddVolumeBackup(backup *define.VolumeBackup) error {
+	err := s.State.AddVolumeBackup(backup)
+	s.record("AddVolumeBackup", "volume", backup.Volume, backup.Volume, backup.Target, err)
+	return err
+}
+
+func (s *auditState) RemoveVolumeBackup(id int64) error {
+	err := s.State.RemoveVolumeBackup(id)
+	s.record("RemoveVolumeBackup", "volume", "", "", strconv.FormatInt(id, 10), err)
+	return err
+}
+
+func (s *auditState) AddVolumeSnapshot(snapshot *define.VolumeSnapshot) error {
+	err := s.State.AddVolumeSnapshot(snapshot)
+	s.record("AddVolumeSnapshot", "volume", snapshot.Volume, snapshot.Volume, snapshot.Name, err)
+	return err
+}
+
+func (s *auditState) RemoveVolumeSnapshot(id int64) error {
+	err := s.State.RemoveVolumeSnapshot(id)
+	s.record("RemoveVolumeSnapshot", "volume", "", "", strconv.FormatInt(id, 10), err)
+	return err
+}
+
+func (s *auditState) AddNetworkReservation(reservation *define.NetworkReservation) error {
+	err := s.State.AddNetworkReservation(reservation)
+	s.record("AddNetworkReservation", "network", reservation.Network, reservation.Network, reservation.Address+" for "+reservation.Container, err)
+	return err
+}
+
+func (s *auditState) RemoveNetworkReservation(network, address string) error {
+	err := s.State.RemoveNetworkReservation(network, address)
+	s.record("RemoveNetworkReservation", "network", network, network, address, err)
+	return err
+}
+
+func (s *auditState) AddContainerNote(id string, note *define.ContainerNote) error {
+	err := s.State.AddContainerNote(id, note)
+	s.record("AddContainerNote", "container", id, "", note.Text, err)
+	return err
+}
+
+func (s *auditState) RemoveContainerNote(id string, noteID int64) error {
+	err := s.State.RemoveContainerNote(id, noteID)
+	s.record("RemoveContainerNote", "container", id, "", strconv.FormatInt(noteID, 10), err)
+	return err
+}
+
+func (s *auditState) AddCheckpoint(record *define.CheckpointRecord) error {
+	err := s.State.AddCheckpoint(record)
+	s.record("AddCheckpoint", "container", record.ContainerID, record.ContainerName, record.Path, err)
+	return err
+}
+
+func (s *auditState) RemoveCheckpoint(id int64) error {
+	err := s.State.RemoveCheckpoint(id)
+	s.record("RemoveCheckpoint", "container", "", "", strconv.FormatInt(id, 10), err)
+	return err
+}
+
+func (s *auditState) AddContainerGroup(group *define.ContainerGroup) error {
+	err := s.State.AddContainerGroup(group)
+	s.record("AddContainerGroup", "group", group.Name, group.Name, "", err)
+	return err
+}
+
+func (s *auditState) RemoveContainerGroup(name string) error {
+	err := s.State.RemoveContainerGroup(name)
+	s.record("RemoveContainerGroup", "group", name, name, "", err)
+	return err
+}
+
+func (s *auditState) AddImageProvenance(record *define.ImageProvenance) error {
+	err := s.State.AddImageProvenance(record)
+	s.record("AddImageProvenance", "image", record.ImageID, record.Source, "", err)
+	return err
+}
+
+func (s *auditState) SetImageDigestPin(pin *define.ImageProvenance) error {
+	err := s.State.SetImageDigestPin(pin)
+	s.record("SetImageDigestPin", "image", pin.ImageID, pin.Source, "", err)
+	return err
+}
@@ -0,0 +1,103 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditState(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	logPath := filepath.Join(t.TempDir(), "audit", "audit.log")
+	runtime.audit, err = newAuditLog(logPath)
+	require.NoError(t, err)
+	defer runtime.audit.close()
+
+	sqlState, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	runtime.state = runtime.wrapState(sqlState)
+	defer runtime.state.Close()
+	assert.Equal(t, sqlState, runtime.backingState())
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, runtime.state.AddContainer(ctr))
+	// Failed modifications are recorded with their error.
+	assert.Error(t, runtime.state.AddContainer(ctr))
+	require.NoError(t, runtime.state.RenameContainer(ctr, "renamed"))
+	require.NoError(t, runtime.state.RemoveContainer(ctr))
+	// Reads are not recorded.
+	_, err = runtime.state.AllContainers(false)
+	require.NoError(t, err)
+
+	group := &define.ContainerGroup{Name: "web", Filters: map[string][]string{"label": {"app=web"}}}
+	require.NoError(t, runtime.state.AddContainerGroup(group))
+	require.NoError(t, runtime.state.RemoveContainerGroup("web"))
+
+	require.NoError(t, runtime.Audit(&define.AuditEntry{
+		Source:    define.AuditSourceAPI,
+		UID:       1000,
+		Identity:  "uid=1000",
+		Operation: "DELETE",
+		Detail:    "/v5.0.0/libpod/containers/test1",
+		Status:    200,
+	}))
+
+	file, err := os.Open(logPath)
+	require.NoError(t, err)
+	defer file.Close()
+	var entries []define.AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry define.AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, entries, 7)
+	for _, entry := range entries[:4] {
+		assert.Equal(t, define.AuditSourceState, entry.Source)
+		assert.Equal(t, os.Getuid(), entry.UID)
+		assert.Equal(t, os.Getpid(), entry.PID)
+		assert.Equal(t, "container", entry.Type)
+		assert.Equal(t, ctr.ID(), entry.ID)
+		assert.False(t, entry.Time.IsZero())
+	}
+	assert.Equal(t, "AddContainer", entries[0].Operation)
+	assert.Empty(t, entries[0].Error)
+	assert.Equal(t, "AddContainer", entries[1].Operation)
+	assert.NotEmpty(t, entries[1].Error)
+	assert.Equal(t, "RenameContainer", entries[2].Operation)
+	assert.Equal(t, "renamed", entries[2].Detail)
+	assert.Equal(t, "RemoveContainer", entries[3].Operation)
+
+	for i, operation := range []string{"AddContainerGroup", "RemoveContainerGroup"} {
+		entry := entries[4+i]
+		assert.Equal(t, operation, entry.Operation)
+		assert.Equal(t, "group", entry.Type)
+		assert.Equal(t, "web", entry.Name)
+		assert.Empty(t, entry.Error)
+	}
+
+	assert.Equal(t, define.AuditSourceAPI, entries[6].Source)
+	assert.Equal(t, "uid=1000", entries[6].Identity)
+	assert.Equal(t, os.Getpid(), entries[6].PID)
+	assert.False(t, entries[6].Time.IsZero())
+}
@@ -4,29 +4,34 @@ package libpod
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io/fs"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/storage/pkg/fileutils"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 	bolt "go.etcd.io/bbolt"
 )
 
 // BoltState is a state implementation backed by a Bolt DB
 type BoltState struct {
-	valid   bool
-	dbPath  string
-	dbLock  sync.Mutex
-	runtime *Runtime
+	valid    bool
+	readOnly bool
+	dbPath   string
+	dbLock   sync.Mutex
+	runtime  *Runtime
 }
 
 // A brief description of the format of the BoltDB state:
@@ -71,6 +76,15 @@ type BoltState struct {
 //   read the exit code from the containers bucket.  Hence, exit codes go into
 //   their own bucket.  To avoid the rather expensive JSON (un)marshalling, we
 //   have two buckets: one for the exit codes, the other for the timestamps.
+// - exitHistoryBkt: Contains a sub-bucket for each container holding all
+//   exit codes recorded for it as JSON, keyed by a sequence number in
+//   big-endian order. Unlike exitCodeBkt, it is kept when the state is
+//   refreshed and pruned only by age.
+// - imageProvenanceBkt: Records the pulls of images as JSON, keyed by a
+//   sequence number in big-endian order so they are iterated in the order
+//   they were added.
+// - imageDigestPinBkt: Maps image sources to the JSON encoded pull their
+//   digest is pinned to by trust-on-first-use pulls.
 
 // NewBoltState creates a new bolt-backed state database
 func NewBoltState(path string, runtime *Runtime) (State, error) {
@@ -78,6 +92,7 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 	state := new(BoltState)
 	state.dbPath = path
 	state.runtime = runtime
+	state.readOnly = runtime.readOnlyState
 
 	logrus.Debugf("Initializing boltdb state at %s", path)
 
@@ -94,7 +109,7 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 		logrus.Debugf("Allowing deprecated database backend due to CI_DESIRED_DATABASE.")
 	}
 
-	db, err := bolt.Open(path, 0600, nil)
+	db, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: state.readOnly})
 	if err != nil {
 		return nil, fmt.Errorf("opening database %s: %w", path, err)
 	}
@@ -119,7 +134,10 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 		runtimeConfigBkt,
 		exitCodeBkt,
 		exitCodeTimeStampBkt,
+		exitHistoryBkt,
 		volCtrsBkt,
+		imageProvenanceBkt,
+		imageDigestPinBkt,
 	}
 
 	// Does the DB need an update?
@@ -141,6 +159,9 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 		state.valid = true
 		return state, nil
 	}
+	if state.readOnly {
+		return nil, fmt.Errorf("database %s is missing buckets and cannot be updated read only: %w", path, define.ErrDBBadConfig)
+	}
 
 	// Ensure schema is properly created in DB
 	err = db.Update(func(tx *bolt.Tx) error {
@@ -507,6 +528,14 @@ func (s *BoltState) ValidateDBConfig(runtime *Runtime) error {
 	return nil
 }
 
+// SetNamespace is only supported by the BoltDB state for the empty namespace.
+func (s *BoltState) SetNamespace(ns string) error {
+	if ns != "" {
+		return fmt.Errorf("namespaces require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+	}
+	return nil
+}
+
 // GetContainerName returns the name associated with a given ID.
 // Returns ErrNoSuchCtr if the ID does not exist.
 func (s *BoltState) GetContainerName(id string) (string, error) {
@@ -791,7 +820,7 @@ func (s *BoltState) AddContainer(ctr *Container) error {
 		return fmt.Errorf("cannot add a container that belongs to a pod with AddContainer - use AddContainerToPod: %w", define.ErrInvalidArg)
 	}
 
-	return s.addContainer(ctr, nil)
+	return s.addContainers([]*Container{ctr})
 }
 
 // RemoveContainer removes a container from the state
@@ -813,7 +842,48 @@ func (s *BoltState) RemoveContainer(ctr *Container) error {
 	defer s.deferredCloseDBCon(db)
 
 	err = db.Update(func(tx *bolt.Tx) error {
-		return s.removeContainer(ctr, nil, tx)
+		return s.removeContainer(ctr, tx)
+	})
+	return err
+}
+
+// AddContainers adds the given containers to the state in a single
+// transaction. Containers that belong to a pod are added to the pod as well.
+func (s *BoltState) AddContainers(ctrs []*Container) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	for _, ctr := range ctrs {
+		if !ctr.valid {
+			return define.ErrCtrRemoved
+		}
+	}
+
+	return s.addContainers(ctrs)
+}
+
+// RemoveContainers removes the given containers from the state in a single
+// transaction. Containers that belong to a pod are removed from the pod as
+// well.
+func (s *BoltState) RemoveContainers(ctrs []*Container) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, ctr := range ctrs {
+			if err := s.removeContainer(ctr, tx); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 	return err
 }
@@ -954,9 +1024,51 @@ func (s *BoltState) ContainerInUse(ctr *Container) ([]string, error) {
 	return depCtrs, nil
 }
 
+// ContainerDependents returns the edges of the dependency graph of all
+// containers depending on the given container, directly or transitively.
+func (s *BoltState) ContainerDependents(ctr *Container) ([]StateDependency, error) {
+	return s.containerDependencyGraph(ctr, func(ctrDB *bolt.Bucket, id string) ([]StateDependency, error) {
+		dependsBkt := ctrDB.Bucket(dependenciesBkt)
+		if dependsBkt == nil {
+			return nil, fmt.Errorf("container %s has no dependencies bucket: %w", id, define.ErrInternal)
+		}
+		var edges []StateDependency
+		err := dependsBkt.ForEach(func(depID, _ []byte) error {
+			edges = append(edges, StateDependency{ID: string(depID), DependencyID: id})
+			return nil
+		})
+		return edges, err
+	})
+}
+
+// ContainerDependencies returns the edges of the dependency graph of all
+// containers the given container depends on, directly or transitively.
+func (s *BoltState) ContainerDependencies(ctr *Container) ([]StateDependency, error) {
+	return s.containerDependencyGraph(ctr, func(ctrDB *bolt.Bucket, id string) ([]StateDependency, error) {
+		depCtr := new(Container)
+		depCtr.config = new(ContainerConfig)
+		if err := json.Unmarshal(ctrDB.Get(configKey), depCtr.config); err != nil {
+			return nil, fmt.Errorf("unmarshalling container %s config from DB: %w", id, err)
+		}
+		var edges []StateDependency
+		for _, depID := range depCtr.Dependencies() {
+			edges = append(edges, StateDependency{ID: id, DependencyID: depID})
+		}
+		return edges, nil
+	})
+}
+
 // AllContainers retrieves all the containers in the database
 // If `loadState` is set, the containers' state will be loaded as well.
 func (s *BoltState) AllContainers(loadState bool) ([]*Container, error) {
+	return s.AllContainersFiltered(loadState, nil)
+}
+
+// AllContainersFiltered retrieves the containers in the database matching the
+// given filters. Containers not in the given pods or without the given name
+// prefixes are skipped before their config is unmarshalled.
+// If `loadState` is set, the containers' state will be loaded as well.
+func (s *BoltState) AllContainersFiltered(loadState bool, filters *StateContainerFilters) ([]*Container, error) {
 	if !s.valid {
 		return nil, define.ErrDBClosed
 	}
@@ -989,13 +1101,17 @@ func (s *BoltState) AllContainers(loadState bool) ([]*Container, error) {
 				return fmt.Errorf("state is inconsistent - container ID %s in all containers, but container not found: %w", string(id), define.ErrInternal)
 			}
 
+			if !filters.matchesPod(string(ctrExists.Get(podIDKey))) || !filters.matchesName(string(name)) {
+				return nil
+			}
+
 			ctr := new(Container)
 			ctr.config = new(ContainerConfig)
 			ctr.state = new(ContainerState)
 
 			if err := s.getContainerFromDB(id, ctr, ctrBucket, loadState); err != nil {
 				logrus.Errorf("Error retrieving container from database: %v", err)
-			} else {
+			} else if filters.matchesLabels(ctr.config.Labels) && filters.matchesConfig(ctr.config) {
 				ctrs = append(ctrs, ctr)
 			}
 
@@ -1006,7 +1122,7 @@ func (s *BoltState) AllContainers(loadState bool) ([]*Container, error) {
 		return nil, err
 	}
 
-	return ctrs, nil
+	return filters.applyLimit(ctrs), nil
 }
 
 // GetNetworks returns the networks this container is a part of.
@@ -1178,6 +1294,31 @@ func (s *BoltState) GetNetworks(ctr *Container) (map[string]types.PerNetworkOpti
 	return networks, nil
 }
 
+// NetworkContainers returns the containers connected to the given network.
+// The BoltDB state does not index containers by network, so the networks of
+// all containers are checked.
+func (s *BoltState) NetworkContainers(network string) ([]*Container, error) {
+	ctrs, err := s.AllContainers(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var netCtrs []*Container
+	for _, ctr := range ctrs {
+		networks, err := s.GetNetworks(ctr)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return nil, err
+		}
+		if _, ok := networks[network]; ok {
+			netCtrs = append(netCtrs, ctr)
+		}
+	}
+	return netCtrs, nil
+}
+
 // NetworkConnect adds the given container to the given network. If aliases are
 // specified, those will be added to the given network.
 func (s *BoltState) NetworkConnect(ctr *Container, network string, opts types.PerNetworkOptions) error {
@@ -1346,8 +1487,62 @@ func (s *BoltState) GetContainerConfig(id string) (*ContainerConfig, error) {
 	return config, nil
 }
 
+// SetContainerMetadata is not supported by the BoltDB state.
+func (s *BoltState) SetContainerMetadata(id, key, value string) error {
+	return fmt.Errorf("container metadata requires the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// GetContainerMetadata returns no metadata as container metadata is not
+// supported by the BoltDB state.
+func (s *BoltState) GetContainerMetadata(id string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// AddContainerNote is not supported by the BoltDB state.
+func (s *BoltState) AddContainerNote(id string, note *define.ContainerNote) error {
+	return fmt.Errorf("container notes require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// GetContainerNotes returns no notes as container notes are not supported by
+// the BoltDB state.
+func (s *BoltState) GetContainerNotes(id string) ([]*define.ContainerNote, error) {
+	return []*define.ContainerNote{}, nil
+}
+
+// RemoveContainerNote is not supported by the BoltDB state.
+func (s *BoltState) RemoveContainerNote(id string, noteID int64) error {
+	return fmt.Errorf("container notes require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// AddHealthCheckLog is not supported by the BoltDB state, the healthcheck
+// log is kept in the run directory of the container instead.
+func (s *BoltState) AddHealthCheckLog(id string, entry *define.HealthCheckHistoryEntry, maxEntries uint) error {
+	return fmt.Errorf("healthcheck history requires the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// GetHealthCheckLog is not supported by the BoltDB state.
+func (s *BoltState) GetHealthCheckLog(id string) ([]define.HealthCheckHistoryEntry, error) {
+	return nil, fmt.Errorf("healthcheck history requires the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// AddContainerStats is not supported by the BoltDB state.
+func (s *BoltState) AddContainerStats(id string, stats *define.ContainerStats, maxSamples uint) error {
+	return fmt.Errorf("stats history requires the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// ContainerStatsHistory is not supported by the BoltDB state.
+func (s *BoltState) ContainerStatsHistory(id string, since time.Time) ([]*define.ContainerStats, error) {
+	return nil, fmt.Errorf("stats history requires the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
 // AddContainerExitCode adds the exit code for the specified container to the database.
 func (s *BoltState) AddContainerExitCode(id string, exitCode int32) error {
+	return s.addContainerExitCode(id, exitCode, time.Now())
+}
+
+// addContainerExitCode adds the exit code for the specified container to the
+// database, recorded at the given time.
+func (s *BoltState) addContainerExitCode(id string, exitCode int32, timeStamp time.Time) error {
 	if len(id) == 0 {
 		return define.ErrEmptyID
 	}
@@ -1364,7 +1559,7 @@ func (s *BoltState) AddContainerExitCode(id string, exitCode int32) error {
 
 	rawID := []byte(id)
 	rawExitCode := []byte(strconv.Itoa(int(exitCode)))
-	rawTimeStamp, err := time.Now().MarshalText()
+	rawTimeStamp, err := timeStamp.MarshalText()
 	if err != nil {
 		return fmt.Errorf("marshalling exit-code time stamp: %w", err)
 	}
@@ -1389,8 +1584,80 @@ func (s *BoltState) AddContainerExitCode(id string, exitCode int32) error {
 			return fmt.Errorf("adding exit-code time stamp of container %s to DB: %w", id, err)
 		}
 
-		return nil
+		return addContainerExitHistory(tx, rawID, define.ContainerExit{ExitCode: exitCode, ExitedAt: timeStamp})
+	})
+}
+
+// addContainerExitHistory appends exits to the exit history of the container
+// with the given ID.
+func addContainerExitHistory(tx *bolt.Tx, rawID []byte, exits ...define.ContainerExit) error {
+	historyBucket, err := getExitHistoryBucket(tx)
+	if err != nil {
+		return err
+	}
+	ctrHistory, err := historyBucket.CreateBucketIfNotExists(rawID)
+	if err != nil {
+		return fmt.Errorf("creating exit history bucket of container %s: %w", string(rawID), err)
+	}
+
+	for _, exit := range exits {
+		exitJSON, err := json.Marshal(exit)
+		if err != nil {
+			return fmt.Errorf("marshalling exit of container %s: %w", string(rawID), err)
+		}
+		seq, err := ctrHistory.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		if err := ctrHistory.Put(key, exitJSON); err != nil {
+			return fmt.Errorf("adding exit of container %s to exit history: %w", string(rawID), err)
+		}
+	}
+	return nil
+}
+
+// ContainerExitHistory returns the exits recorded for the container with the
+// given full ID, oldest first.
+func (s *BoltState) ContainerExitHistory(id string) ([]define.ContainerExit, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	exits := []define.ContainerExit{}
+	err = db.View(func(tx *bolt.Tx) error {
+		historyBucket, err := getExitHistoryBucket(tx)
+		if err != nil {
+			return err
+		}
+		ctrHistory := historyBucket.Bucket([]byte(id))
+		if ctrHistory == nil {
+			return nil
+		}
+		return ctrHistory.ForEach(func(_, v []byte) error {
+			var exit define.ContainerExit
+			if err := json.Unmarshal(v, &exit); err != nil {
+				return fmt.Errorf("unmarshalling exit history of container %s: %w", id, err)
+			}
+			exits = append(exits, exit)
+			return nil
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
+	return exits, nil
 }
 
 // GetContainerExitCode returns the exit code for the specified container.
@@ -1470,8 +1737,114 @@ func (s *BoltState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error)
 	})
 }
 
-// PruneContainerExitCodes removes exit codes older than 5 minutes unless the associated
-// container still exists.
+// allContainerExitHistory returns the exit histories of all containers in the
+// database, keyed by container ID.
+func (s *BoltState) allContainerExitHistory() (map[string][]define.ContainerExit, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	result := make(map[string][]define.ContainerExit)
+	return result, db.View(func(tx *bolt.Tx) error {
+		historyBucket, err := getExitHistoryBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return historyBucket.ForEachBucket(func(rawID []byte) error {
+			return historyBucket.Bucket(rawID).ForEach(func(_, v []byte) error {
+				var exit define.ContainerExit
+				if err := json.Unmarshal(v, &exit); err != nil {
+					return fmt.Errorf("unmarshalling exit history of container %s: %w", string(rawID), err)
+				}
+				result[string(rawID)] = append(result[string(rawID)], exit)
+				return nil
+			})
+		})
+	})
+}
+
+// setContainerExitHistory replaces the exit history of the container with the
+// given ID.
+func (s *BoltState) setContainerExitHistory(id string, exits []define.ContainerExit) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		historyBucket, err := getExitHistoryBucket(tx)
+		if err != nil {
+			return err
+		}
+		rawID := []byte(id)
+		if historyBucket.Bucket(rawID) != nil {
+			if err := historyBucket.DeleteBucket(rawID); err != nil {
+				return fmt.Errorf("removing exit history bucket of container %s from DB: %w", id, err)
+			}
+		}
+		if len(exits) == 0 {
+			return nil
+		}
+		return addContainerExitHistory(tx, rawID, exits...)
+	})
+}
+
+// allContainerExitCodes returns all exit codes in the database with the time
+// they were added, keyed by container ID.
+func (s *BoltState) allContainerExitCodes() (map[string]containerExitCode, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	result := make(map[string]containerExitCode)
+	return result, db.View(func(tx *bolt.Tx) error {
+		exitCodeBucket, err := getExitCodeBucket(tx)
+		if err != nil {
+			return err
+		}
+		timeStampBucket, err := getExitCodeTimeStampBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return exitCodeBucket.ForEach(func(rawID, rawExitCode []byte) error {
+			exitCode, err := strconv.Atoi(string(rawExitCode))
+			if err != nil {
+				return fmt.Errorf("converting raw exit code %v of container %s: %w", rawExitCode, string(rawID), err)
+			}
+			entry := containerExitCode{ExitCode: int32(exitCode)}
+			if rawTimeStamp := timeStampBucket.Get(rawID); rawTimeStamp != nil {
+				if err := entry.TimeStamp.UnmarshalText(rawTimeStamp); err != nil {
+					return fmt.Errorf("converting raw time stamp %v of container %s from DB: %w", rawTimeStamp, string(rawID), err)
+				}
+			}
+			result[string(rawID)] = entry
+			return nil
+		})
+	})
+}
+
+// PruneContainerExitCodes removes exit codes older than the exit code
+// retention unless the associated container still exists, and the exit
+// history older than the retention.
 func (s *BoltState) PruneContainerExitCodes() error {
 	if !s.valid {
 		return define.ErrDBClosed
@@ -1483,78 +1856,674 @@ func (s *BoltState) PruneContainerExitCodes() error {
 	}
 	defer s.deferredCloseDBCon(db)
 
-	toRemoveIDs := []string{}
+	toRemoveIDs := []string{}
+
+	threshold := s.runtime.exitCodeRetention()
+	err = db.View(func(tx *bolt.Tx) error {
+		timeStampBucket, err := getExitCodeTimeStampBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrsBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return timeStampBucket.ForEach(func(rawID, rawTimeStamp []byte) error {
+			if ctrsBucket.Bucket(rawID) != nil {
+				// If the container still exists, don't prune
+				// its exit code since we may still need it.
+				return nil
+			}
+			var timeStamp time.Time
+			if err := timeStamp.UnmarshalText(rawTimeStamp); err != nil {
+				return fmt.Errorf("converting raw time stamp %v of container %s from DB: %w", rawTimeStamp, string(rawID), err)
+			}
+			if time.Since(timeStamp) > threshold {
+				toRemoveIDs = append(toRemoveIDs, string(rawID))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("reading exit codes to prune: %w", err)
+	}
+
+	if len(toRemoveIDs) > 0 {
+		err = db.Update(func(tx *bolt.Tx) error {
+			exitCodeBucket, err := getExitCodeBucket(tx)
+			if err != nil {
+				return err
+			}
+			timeStampBucket, err := getExitCodeTimeStampBucket(tx)
+			if err != nil {
+				return err
+			}
+
+			var finalErr error
+			for _, id := range toRemoveIDs {
+				rawID := []byte(id)
+				if err := exitCodeBucket.Delete(rawID); err != nil {
+					if finalErr != nil {
+						logrus.Error(finalErr)
+					}
+					finalErr = fmt.Errorf("removing exit code of container %s from DB: %w", id, err)
+				}
+				if err := timeStampBucket.Delete(rawID); err != nil {
+					if finalErr != nil {
+						logrus.Error(finalErr)
+					}
+					finalErr = fmt.Errorf("removing exit code timestamp of container %s from DB: %w", id, err)
+				}
+			}
+
+			return finalErr
+		})
+		if err != nil {
+			return fmt.Errorf("pruning exit codes: %w", err)
+		}
+	}
+
+	expiredExits := make(map[string][][]byte)
+	err = db.View(func(tx *bolt.Tx) error {
+		historyBucket, err := getExitHistoryBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return historyBucket.ForEachBucket(func(rawID []byte) error {
+			c := historyBucket.Bucket(rawID).Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				var exit define.ContainerExit
+				if err := json.Unmarshal(v, &exit); err != nil {
+					return fmt.Errorf("unmarshalling exit history of container %s: %w", string(rawID), err)
+				}
+				if time.Since(exit.ExitedAt) > threshold {
+					expiredExits[string(rawID)] = append(expiredExits[string(rawID)], slices.Clone(k))
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("reading exit history to prune: %w", err)
+	}
+
+	if len(expiredExits) > 0 {
+		err = db.Update(func(tx *bolt.Tx) error {
+			historyBucket, err := getExitHistoryBucket(tx)
+			if err != nil {
+				return err
+			}
+
+			for id, keys := range expiredExits {
+				ctrHistory := historyBucket.Bucket([]byte(id))
+				if ctrHistory == nil {
+					continue
+				}
+				for _, k := range keys {
+					if err := ctrHistory.Delete(k); err != nil {
+						return fmt.Errorf("removing exit history of container %s from DB: %w", id, err)
+					}
+				}
+				if k, _ := ctrHistory.Cursor().First(); k == nil {
+					if err := historyBucket.DeleteBucket([]byte(id)); err != nil {
+						return fmt.Errorf("removing exit history bucket of container %s from DB: %w", id, err)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("pruning exit history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AddImageProvenance records a pull of an image. Nothing is recorded if the
+// last pull from the same source resulted in the same image and digest.
+func (s *BoltState) AddImageProvenance(record *define.ImageProvenance) error {
+	if record.ImageID == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshalling provenance of image %s: %w", record.ImageID, err)
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		provenanceBucket, err := getImageProvenanceBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		c := provenanceBucket.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			last := new(define.ImageProvenance)
+			if err := json.Unmarshal(v, last); err != nil {
+				return fmt.Errorf("unmarshalling image provenance: %w", err)
+			}
+			if last.Source != record.Source {
+				continue
+			}
+			if last.ImageID == record.ImageID && last.Digest == record.Digest {
+				return nil
+			}
+			break
+		}
+
+		seq, err := provenanceBucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		if err := provenanceBucket.Put(key, recordJSON); err != nil {
+			return fmt.Errorf("adding provenance of image %s to DB: %w", record.ImageID, err)
+		}
+		return nil
+	})
+}
+
+// ImageProvenance returns the pulls of the image with the given ID, together
+// with all other pulls from the sources the image was pulled from, oldest
+// first.
+func (s *BoltState) ImageProvenance(imageID string) ([]*define.ImageProvenance, error) {
+	if imageID == "" {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	records := []*define.ImageProvenance{}
+	err = db.View(func(tx *bolt.Tx) error {
+		provenanceBucket, err := getImageProvenanceBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		var all []*define.ImageProvenance
+		sources := make(map[string]bool)
+		if err := provenanceBucket.ForEach(func(_, v []byte) error {
+			record := new(define.ImageProvenance)
+			if err := json.Unmarshal(v, record); err != nil {
+				return fmt.Errorf("unmarshalling image provenance: %w", err)
+			}
+			if record.ImageID == imageID {
+				sources[record.Source] = true
+			}
+			all = append(all, record)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, record := range all {
+			if record.ImageID == imageID || sources[record.Source] {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SetImageDigestPin pins the digest of an image source to the given pull,
+// replacing any earlier pin of the source.
+func (s *BoltState) SetImageDigestPin(pin *define.ImageProvenance) error {
+	if pin.ImageID == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	pinJSON, err := json.Marshal(pin)
+	if err != nil {
+		return fmt.Errorf("marshalling digest pin of image source %s: %w", pin.Source, err)
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		pinBucket, err := getImageDigestPinBucket(tx)
+		if err != nil {
+			return err
+		}
+		if err := pinBucket.Put([]byte(pin.Source), pinJSON); err != nil {
+			return fmt.Errorf("pinning digest of image source %s in DB: %w", pin.Source, err)
+		}
+		return nil
+	})
+}
+
+// ImageDigestPin returns the pull the given image source is pinned to.
+func (s *BoltState) ImageDigestPin(source string) (*define.ImageProvenance, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	pin := new(define.ImageProvenance)
+	err = db.View(func(tx *bolt.Tx) error {
+		pinBucket, err := getImageDigestPinBucket(tx)
+		if err != nil {
+			return err
+		}
+		pinJSON := pinBucket.Get([]byte(source))
+		if pinJSON == nil {
+			return fmt.Errorf("image source %s: %w", source, define.ErrNoSuchImageDigestPin)
+		}
+		if err := json.Unmarshal(pinJSON, pin); err != nil {
+			return fmt.Errorf("unmarshalling digest pin of image source %s: %w", source, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pin, nil
+}
+
+// allImageProvenance returns all pulls of images, oldest first, and all
+// digest pins in the database.
+func (s *BoltState) allImageProvenance() ([]*define.ImageProvenance, []*define.ImageProvenance, error) {
+	if !s.valid {
+		return nil, nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	var records, pins []*define.ImageProvenance
+	err = db.View(func(tx *bolt.Tx) error {
+		provenanceBucket, err := getImageProvenanceBucket(tx)
+		if err != nil {
+			return err
+		}
+		pinBucket, err := getImageDigestPinBucket(tx)
+		if err != nil {
+			return err
+		}
+		// The keys are big-endian sequence numbers, so records are
+		// iterated in the order they were added.
+		if err := provenanceBucket.ForEach(func(_, v []byte) error {
+			record := new(define.ImageProvenance)
+			if err := json.Unmarshal(v, record); err != nil {
+				return fmt.Errorf("unmarshalling image provenance: %w", err)
+			}
+			records = append(records, record)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return pinBucket.ForEach(func(k, v []byte) error {
+			pin := new(define.ImageProvenance)
+			if err := json.Unmarshal(v, pin); err != nil {
+				return fmt.Errorf("unmarshalling digest pin of image source %s: %w", string(k), err)
+			}
+			pins = append(pins, pin)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, pins, nil
+}
+
+// SetVolumeBackupPolicy is not supported by the BoltDB state.
+func (s *BoltState) SetVolumeBackupPolicy(policy *define.VolumeBackupPolicy) error {
+	return fmt.Errorf("volume backups require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// RemoveVolumeBackupPolicy is not supported by the BoltDB state.
+func (s *BoltState) RemoveVolumeBackupPolicy(volume string) error {
+	return fmt.Errorf("volume backups require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// VolumeBackupPolicies returns no policies as volume backups are not
+// supported by the BoltDB state.
+func (s *BoltState) VolumeBackupPolicies() ([]*define.VolumeBackupPolicy, error) {
+	return []*define.VolumeBackupPolicy{}, nil
+}
+
+// AddVolumeBackup is not supported by the BoltDB state.
+func (s *BoltState) AddVolumeBackup(backup *define.VolumeBackup) error {
+	return fmt.Errorf("volume backups require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// VolumeBackups returns no backups as volume backups are not supported by
+// the BoltDB state.
+func (s *BoltState) VolumeBackups(volume string) ([]*define.VolumeBackup, error) {
+	return []*define.VolumeBackup{}, nil
+}
+
+// RemoveVolumeBackup is not supported by the BoltDB state.
+func (s *BoltState) RemoveVolumeBackup(id int64) error {
+	return fmt.Errorf("volume backups require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// AddNetworkReservation is not supported by the BoltDB state.
+func (s *BoltState) AddNetworkReservation(reservation *define.NetworkReservation) error {
+	return fmt.Errorf("network reservations require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// RemoveNetworkReservation is not supported by the BoltDB state.
+func (s *BoltState) RemoveNetworkReservation(network, address string) error {
+	return fmt.Errorf("network reservations require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// AddContainerGroup is not supported by the BoltDB state.
+func (s *BoltState) AddContainerGroup(group *define.ContainerGroup) error {
+	return fmt.Errorf("container groups require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// RemoveContainerGroup is not supported by the BoltDB state.
+func (s *BoltState) RemoveContainerGroup(name string) error {
+	return fmt.Errorf("container groups require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// ContainerGroup returns ErrNoSuchContainerGroup as container groups are not
+// supported by the BoltDB state.
+func (s *BoltState) ContainerGroup(name string) (*define.ContainerGroup, error) {
+	return nil, fmt.Errorf("container group %s: %w", name, define.ErrNoSuchContainerGroup)
+}
+
+// ContainerGroups returns no groups as container groups are not supported by
+// the BoltDB state.
+func (s *BoltState) ContainerGroups() ([]*define.ContainerGroup, error) {
+	return []*define.ContainerGroup{}, nil
+}
+
+// NetworkReservations returns no reservations as network reservations are
+// not supported by the BoltDB state.
+func (s *BoltState) NetworkReservations(network string) ([]*define.NetworkReservation, error) {
+	return []*define.NetworkReservation{}, nil
+}
+
+// AddCheckpoint is not supported by the BoltDB state.
+func (s *BoltState) AddCheckpoint(record *define.CheckpointRecord) error {
+	return fmt.Errorf("checkpoint records require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// Checkpoints returns no checkpoints as checkpoint records are not supported
+// by the BoltDB state.
+func (s *BoltState) Checkpoints(ctrID string) ([]*define.CheckpointRecord, error) {
+	return []*define.CheckpointRecord{}, nil
+}
+
+// RemoveCheckpoint is not supported by the BoltDB state.
+func (s *BoltState) RemoveCheckpoint(id int64) error {
+	return fmt.Errorf("checkpoint records require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// AddVolumeSnapshot is not supported by the BoltDB state.
+func (s *BoltState) AddVolumeSnapshot(snapshot *define.VolumeSnapshot) error {
+	return fmt.Errorf("volume snapshots require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// VolumeSnapshots returns no snapshots as volume snapshots are not supported
+// by the BoltDB state.
+func (s *BoltState) VolumeSnapshots(volume string) ([]*define.VolumeSnapshot, error) {
+	return []*define.VolumeSnapshot{}, nil
+}
+
+// RemoveVolumeSnapshot is not supported by the BoltDB state.
+func (s *BoltState) RemoveVolumeSnapshot(id int64) error {
+	return fmt.Errorf("volume snapshots require the sqlite or postgres database backend: %w", define.ErrNotImplemented)
+}
+
+// Verify checks the consistency of the database and reports orphaned
+// entries.
+func (s *BoltState) Verify() (*define.DBCheckReport, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	report := &define.DBCheckReport{Backend: config.DBBackendBoltDB.String()}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			report.Errors = append(report.Errors, "consistency check: "+err.Error())
+		}
+
+		idBucket, err := getIDBucket(tx)
+		if err != nil {
+			return err
+		}
+		namesBucket, err := getNamesBucket(tx)
+		if err != nil {
+			return err
+		}
+		ctrBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+		allCtrsBucket, err := getAllCtrsBucket(tx)
+		if err != nil {
+			return err
+		}
+		podBucket, err := getPodBucket(tx)
+		if err != nil {
+			return err
+		}
+		execBucket, err := getExecBucket(tx)
+		if err != nil {
+			return err
+		}
+		timeStampBucket, err := getExitCodeTimeStampBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		err = allCtrsBucket.ForEach(func(id, _ []byte) error {
+			ctrDB := ctrBucket.Bucket(id)
+			if ctrDB == nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("container %s is listed but has no bucket", string(id)))
+				return nil
+			}
+			if ctrDB.Get(configKey) == nil || ctrDB.Get(stateKey) == nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("container %s is missing its config or state", string(id)))
+			}
+			if depsBkt := ctrDB.Bucket(dependenciesBkt); depsBkt != nil {
+				// The bucket lists the containers depending on this one.
+				return depsBkt.ForEach(func(depending, _ []byte) error {
+					if ctrBucket.Bucket(depending) == nil {
+						report.OrphanDependencies = append(report.OrphanDependencies, fmt.Sprintf("%s -> %s", string(depending), string(id)))
+					}
+					return nil
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = ctrBucket.ForEach(func(id, _ []byte) error {
+			if allCtrsBucket.Get(id) == nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("container %s has a bucket but is not listed", string(id)))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 
-	threshold := time.Minute * 5
-	err = db.View(func(tx *bolt.Tx) error {
-		timeStampBucket, err := getExitCodeTimeStampBucket(tx)
+		err = idBucket.ForEach(func(id, _ []byte) error {
+			if ctrBucket.Bucket(id) == nil && podBucket.Bucket(id) == nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("ID %s is registered but no container or pod exists", string(id)))
+			}
+			return nil
+		})
 		if err != nil {
 			return err
 		}
 
-		ctrsBucket, err := getCtrBucket(tx)
+		err = namesBucket.ForEach(func(name, id []byte) error {
+			if idBucket.Get(id) == nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("name %s is registered for ID %s which is not registered", string(name), string(id)))
+			}
+			return nil
+		})
 		if err != nil {
 			return err
 		}
 
-		return timeStampBucket.ForEach(func(rawID, rawTimeStamp []byte) error {
-			if ctrsBucket.Bucket(rawID) != nil {
-				// If the container still exists, don't prune
-				// its exit code since we may still need it.
+		err = execBucket.ForEach(func(sessionID, ctrID []byte) error {
+			if ctrBucket.Bucket(ctrID) == nil {
+				report.OrphanExecSessions = append(report.OrphanExecSessions, string(sessionID))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return timeStampBucket.ForEach(func(id, rawTimeStamp []byte) error {
+			if ctrBucket.Bucket(id) != nil {
 				return nil
 			}
 			var timeStamp time.Time
 			if err := timeStamp.UnmarshalText(rawTimeStamp); err != nil {
-				return fmt.Errorf("converting raw time stamp %v of container %s from DB: %w", rawTimeStamp, string(rawID), err)
+				report.Errors = append(report.Errors, fmt.Sprintf("exit code time stamp of container %s cannot be decoded: %v", string(id), err))
+				return nil
 			}
-			if time.Since(timeStamp) > threshold {
-				toRemoveIDs = append(toRemoveIDs, string(rawID))
+			if time.Since(timeStamp) > s.runtime.exitCodeRetention() {
+				report.OrphanExitCodes = append(report.OrphanExitCodes, string(id))
 			}
 			return nil
 		})
 	})
 	if err != nil {
-		return fmt.Errorf("reading exit codes to prune: %w", err)
+		return nil, err
 	}
 
-	if len(toRemoveIDs) > 0 {
-		err = db.Update(func(tx *bolt.Tx) error {
-			exitCodeBucket, err := getExitCodeBucket(tx)
-			if err != nil {
-				return err
-			}
-			timeStampBucket, err := getExitCodeTimeStampBucket(tx)
-			if err != nil {
-				return err
-			}
+	return report, nil
+}
 
-			var finalErr error
-			for _, id := range toRemoveIDs {
-				rawID := []byte(id)
-				if err := exitCodeBucket.Delete(rawID); err != nil {
-					if finalErr != nil {
-						logrus.Error(finalErr)
-					}
-					finalErr = fmt.Errorf("removing exit code of container %s from DB: %w", id, err)
-				}
-				if err := timeStampBucket.Delete(rawID); err != nil {
-					if finalErr != nil {
-						logrus.Error(finalErr)
-					}
-					finalErr = fmt.Errorf("removing exit code timestamp of container %s from DB: %w", id, err)
-				}
-			}
+// Vacuum is not supported by the BoltDB state.
+func (s *BoltState) Vacuum() error {
+	return fmt.Errorf("vacuuming the BoltDB database: %w", define.ErrNotImplemented)
+}
 
-			return finalErr
-		})
-		if err != nil {
-			return fmt.Errorf("pruning exit codes: %w", err)
-		}
+// CheckpointWAL is a no-op as BoltDB does not use a write-ahead log.
+func (s *BoltState) CheckpointWAL() error {
+	if !s.valid {
+		return define.ErrDBClosed
 	}
-
 	return nil
 }
 
+// Backup writes a consistent copy of the database to the given path from
+// within a read-only transaction.
+func (s *BoltState) Backup(path string) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating database backup: %w", err)
+	}
+	defer f.Close()
+
+	if err := db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(f)
+		return err
+	}); err != nil {
+		return fmt.Errorf("backing up database to %s: %w", path, err)
+	}
+
+	return f.Sync()
+}
+
+// RecordMaintenance is not supported by the BoltDB state.
+func (s *BoltState) RecordMaintenance(operation, result string) error {
+	return fmt.Errorf("recording maintenance of the BoltDB database: %w", define.ErrNotImplemented)
+}
+
+// StateChanges is not supported by the BoltDB state.
+func (s *BoltState) StateChanges(afterID int64) ([]*define.StateChange, error) {
+	return nil, fmt.Errorf("retrieving state changes from the BoltDB database: %w", define.ErrNotImplemented)
+}
+
+// LatestStateChange is not supported by the BoltDB state.
+func (s *BoltState) LatestStateChange() (int64, error) {
+	return 0, fmt.Errorf("retrieving state changes from the BoltDB database: %w", define.ErrNotImplemented)
+}
+
+// DatabaseInfo describes the database file. Maintenance operations are not
+// recorded by the BoltDB state.
+func (s *BoltState) DatabaseInfo() (*define.DatabaseBackendInfo, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	info := &define.DatabaseBackendInfo{
+		Type:      config.DBBackendBoltDB.String(),
+		Path:      s.dbPath,
+		Integrity: define.DBIntegrityUnchecked,
+	}
+	st, err := os.Stat(s.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving database size: %w", err)
+	}
+	info.Size = st.Size()
+	return info, nil
+}
+
 // AddExecSession adds an exec session to the state.
 func (s *BoltState) AddExecSession(ctr *Container, session *ExecSession) error {
 	if !s.valid {
@@ -1647,6 +2616,60 @@ func (s *BoltState) GetExecSession(id string) (string, error) {
 	return ctrID, err
 }
 
+// ExecSession retrieves the exec session with the given ID from the state of
+// its container.
+func (s *BoltState) ExecSession(id string) (*ExecSession, error) {
+	ctrID, err := s.GetExecSession(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctr := new(Container)
+	ctr.config = new(ContainerConfig)
+	ctr.state = new(ContainerState)
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		ctrBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return s.getContainerFromDB([]byte(ctrID), ctr, ctrBucket, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return execSessionFromState(ctr, id), nil
+}
+
+// AllExecSessions retrieves all exec sessions in the database from the states
+// of their containers.
+func (s *BoltState) AllExecSessions() ([]*ExecSession, error) {
+	ctrs, err := s.AllContainers(true)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []*ExecSession{}
+	for _, ctr := range ctrs {
+		ids, err := s.GetContainerExecSessions(ctr)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			sessions = append(sessions, execSessionFromState(ctr, id))
+		}
+	}
+	return sessions, nil
+}
+
 // RemoveExecSession removes references to the given exec session in the
 // database.
 func (s *BoltState) RemoveExecSession(session *ExecSession) error {
@@ -1914,65 +2937,9 @@ func (s *BoltState) SafeRewriteContainerConfig(ctr *Container, oldName, newName
 
 	err = db.Update(func(tx *bolt.Tx) error {
 		if newName != "" {
-			idBkt, err := getIDBucket(tx)
-			if err != nil {
-				return err
-			}
-			namesBkt, err := getNamesBucket(tx)
-			if err != nil {
-				return err
-			}
-			allCtrsBkt, err := getAllCtrsBucket(tx)
-			if err != nil {
+			if err := renameContainerInBuckets(tx, ctr, oldName, newName); err != nil {
 				return err
 			}
-
-			needsRename := true
-			if exists := namesBkt.Get([]byte(newName)); exists != nil {
-				if string(exists) == ctr.ID() {
-					// Name already associated with the ID
-					// of this container. No need for a
-					// rename.
-					needsRename = false
-				} else {
-					return fmt.Errorf("name %s already in use, cannot rename container %s: %w", newName, ctr.ID(), define.ErrCtrExists)
-				}
-			}
-
-			if needsRename {
-				// We do have to remove the old name. The other
-				// buckets are ID-indexed so we just need to
-				// overwrite the values there.
-				if err := namesBkt.Delete([]byte(oldName)); err != nil {
-					return fmt.Errorf("deleting container %s old name from DB for rename: %w", ctr.ID(), err)
-				}
-				if err := idBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
-					return fmt.Errorf("renaming container %s in ID bucket in DB: %w", ctr.ID(), err)
-				}
-				if err := namesBkt.Put([]byte(newName), []byte(ctr.ID())); err != nil {
-					return fmt.Errorf("adding new name %s for container %s in DB: %w", newName, ctr.ID(), err)
-				}
-				if err := allCtrsBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
-					return fmt.Errorf("renaming container %s in all containers bucket in DB: %w", ctr.ID(), err)
-				}
-				if ctr.config.Pod != "" {
-					podsBkt, err := getPodBucket(tx)
-					if err != nil {
-						return err
-					}
-					podBkt := podsBkt.Bucket([]byte(ctr.config.Pod))
-					if podBkt == nil {
-						return fmt.Errorf("bucket for pod %s does not exist: %w", ctr.config.Pod, define.ErrInternal)
-					}
-					podCtrBkt := podBkt.Bucket(containersBkt)
-					if podCtrBkt == nil {
-						return fmt.Errorf("pod %s does not have a containers bucket: %w", ctr.config.Pod, define.ErrInternal)
-					}
-					if err := podCtrBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
-						return fmt.Errorf("renaming container %s in pod %s members bucket: %w", ctr.ID(), ctr.config.Pod, err)
-					}
-				}
-			}
 		}
 
 		ctrBkt, err := getCtrBucket(tx)
@@ -1995,6 +2962,119 @@ func (s *BoltState) SafeRewriteContainerConfig(ctr *Container, oldName, newName
 	return err
 }
 
+// RenameContainer renames the given container. Its configuration is read,
+// renamed and written back in a single transaction.
+func (s *BoltState) RenameContainer(ctr *Container, newName string) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return define.ErrCtrRemoved
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		ctrBkt, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrDB := ctrBkt.Bucket([]byte(ctr.ID()))
+		if ctrDB == nil {
+			ctr.valid = false
+			return fmt.Errorf("no container with ID %q found in DB: %w", ctr.ID(), define.ErrNoSuchCtr)
+		}
+
+		config := new(ContainerConfig)
+		if err := json.Unmarshal(ctrDB.Get(configKey), config); err != nil {
+			return fmt.Errorf("unmarshalling container %s config from DB: %w", ctr.ID(), err)
+		}
+		if config.Name == newName {
+			return nil
+		}
+
+		if err := renameContainerInBuckets(tx, ctr, config.Name, newName); err != nil {
+			return err
+		}
+
+		config.Name = newName
+		configJSON, err := json.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("marshalling new configuration JSON for container %s: %w", ctr.ID(), err)
+		}
+		if err := ctrDB.Put(configKey, configJSON); err != nil {
+			return fmt.Errorf("updating container %s config JSON: %w", ctr.ID(), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctr.config.Name = newName
+	return nil
+}
+
+// UpdateContainerResources sets the resource limits of the given container.
+// Its configuration is read, updated and written back in a single transaction.
+func (s *BoltState) UpdateContainerResources(ctr *Container, resources *spec.LinuxResources) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return define.ErrCtrRemoved
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		ctrBkt, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrDB := ctrBkt.Bucket([]byte(ctr.ID()))
+		if ctrDB == nil {
+			ctr.valid = false
+			return fmt.Errorf("no container with ID %q found in DB: %w", ctr.ID(), define.ErrNoSuchCtr)
+		}
+
+		config := new(ContainerConfig)
+		if err := json.Unmarshal(ctrDB.Get(configKey), config); err != nil {
+			return fmt.Errorf("unmarshalling container %s config from DB: %w", ctr.ID(), err)
+		}
+
+		setConfigResources(config, resources)
+		configJSON, err := json.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("marshalling new configuration JSON for container %s: %w", ctr.ID(), err)
+		}
+		if err := ctrDB.Put(configKey, configJSON); err != nil {
+			return fmt.Errorf("updating container %s config JSON: %w", ctr.ID(), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	setConfigResources(ctr.config, resources)
+	return nil
+}
+
 // RewritePodConfig rewrites a pod's configuration.
 // WARNING: This function is DANGEROUS. Do not use without reading the full
 // comment on this function in state.go.
@@ -3319,7 +4399,11 @@ func (s *BoltState) AddContainerToPod(pod *Pod, ctr *Container) error {
 		return fmt.Errorf("container %s is not part of pod %s: %w", ctr.ID(), pod.ID(), define.ErrNoSuchCtr)
 	}
 
-	return s.addContainer(ctr, pod)
+	err := s.addContainers([]*Container{ctr})
+	if errors.Is(err, define.ErrNoSuchPod) {
+		pod.valid = false
+	}
+	return err
 }
 
 // RemoveContainerFromPod removes a container from an existing pod
@@ -3348,8 +4432,11 @@ func (s *BoltState) RemoveContainerFromPod(pod *Pod, ctr *Container) error {
 	defer s.deferredCloseDBCon(db)
 
 	err = db.Update(func(tx *bolt.Tx) error {
-		return s.removeContainer(ctr, pod, tx)
+		return s.removeContainer(ctr, tx)
 	})
+	if errors.Is(err, define.ErrNoSuchPod) {
+		pod.valid = false
+	}
 	return err
 }
 
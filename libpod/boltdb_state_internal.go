@@ -31,6 +31,10 @@ const (
 
 	exitCodeName          = "exit-code"
 	exitCodeTimeStampName = "exit-code-time-stamp"
+	exitHistoryName       = "exit-code-history"
+
+	imageProvenanceName = "image-provenance"
+	imageDigestPinName  = "image-digest-pin"
 
 	configName         = "config"
 	stateName          = "state"
@@ -69,6 +73,10 @@ var (
 
 	exitCodeBkt          = []byte(exitCodeName)
 	exitCodeTimeStampBkt = []byte(exitCodeTimeStampName)
+	exitHistoryBkt       = []byte(exitHistoryName)
+
+	imageProvenanceBkt = []byte(imageProvenanceName)
+	imageDigestPinBkt  = []byte(imageDigestPinName)
 
 	configKey     = []byte(configName)
 	stateKey      = []byte(stateName)
@@ -186,6 +194,10 @@ func checkRuntimeConfig(db *bolt.DB, rt *Runtime) error {
 	if len(missingFields) == 0 {
 		return nil
 	}
+	if db.IsReadOnly() {
+		logrus.Debugf("Database is read only, not adding %d missing runtime config fields", len(missingFields))
+		return nil
+	}
 
 	// Populate missing fields
 	return db.Update(func(tx *bolt.Tx) error {
@@ -279,7 +291,8 @@ func (s *BoltState) getDBCon() (*bolt.DB, error) {
 	// https://www.sqlite.org/src/artifact/c230a7a24?ln=994-1081
 	s.dbLock.Lock()
 
-	db, err := bolt.Open(s.dbPath, 0600, nil)
+	// Read-only connections share the file lock with each other.
+	db, err := bolt.Open(s.dbPath, 0600, &bolt.Options{ReadOnly: s.readOnly})
 	if err != nil {
 		return nil, fmt.Errorf("opening database %s: %w", s.dbPath, err)
 	}
@@ -403,6 +416,30 @@ func getExitCodeTimeStampBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	return bkt, nil
 }
 
+func getExitHistoryBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(exitHistoryBkt)
+	if bkt == nil {
+		return nil, fmt.Errorf("exit history bucket not found in DB: %w", define.ErrDBBadConfig)
+	}
+	return bkt, nil
+}
+
+func getImageProvenanceBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(imageProvenanceBkt)
+	if bkt == nil {
+		return nil, fmt.Errorf("image provenance bucket not found in DB: %w", define.ErrDBBadConfig)
+	}
+	return bkt, nil
+}
+
+func getImageDigestPinBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(imageDigestPinBkt)
+	if bkt == nil {
+		return nil, fmt.Errorf("image digest pin bucket not found in DB: %w", define.ErrDBBadConfig)
+	}
+	return bkt, nil
+}
+
 func getVolumeContainersBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	bkt := tx.Bucket(volCtrsBkt)
 	if bkt == nil {
@@ -605,9 +642,17 @@ func (s *BoltState) getVolumeFromDB(name []byte, volume *Volume, volBkt *bolt.Bu
 	return nil
 }
 
-// Add a container to the DB
-// If pod is not nil, the container is added to the pod as well
-func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
+// boltContainer holds the marshalled data of a container to be added to the
+// DB, so it can be prepared before we get the db lock
+type boltContainer struct {
+	ctr        *Container
+	configJSON []byte
+	stateJSON  []byte
+	networks   map[string][]byte
+}
+
+// Prepare a container to be added to the DB
+func newBoltContainer(ctr *Container) (*boltContainer, error) {
 	// Set the original networks to nil. We can save some space by not storing it in the config
 	// since we store it in a different mutable bucket anyway.
 	configNetworks := ctr.config.Networks
@@ -616,34 +661,50 @@ func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
 	// JSON container structs to insert into DB
 	configJSON, err := json.Marshal(ctr.config)
 	if err != nil {
-		return fmt.Errorf("marshalling container %s config to JSON: %w", ctr.ID(), err)
+		return nil, fmt.Errorf("marshalling container %s config to JSON: %w", ctr.ID(), err)
 	}
 	stateJSON, err := json.Marshal(ctr.state)
 	if err != nil {
-		return fmt.Errorf("marshalling container %s state to JSON: %w", ctr.ID(), err)
+		return nil, fmt.Errorf("marshalling container %s state to JSON: %w", ctr.ID(), err)
 	}
-	dependsCtrs := ctr.Dependencies()
-
-	ctrID := []byte(ctr.ID())
-	ctrName := []byte(ctr.Name())
 
 	// make sure to marshal the network options before we get the db lock
 	networks := make(map[string][]byte, len(configNetworks))
 	for net, opts := range configNetworks {
 		// Check that we don't have any empty network names
 		if net == "" {
-			return fmt.Errorf("network names cannot be an empty string: %w", define.ErrInvalidArg)
+			return nil, fmt.Errorf("network names cannot be an empty string: %w", define.ErrInvalidArg)
 		}
 		if opts.InterfaceName == "" {
-			return fmt.Errorf("network interface name cannot be an empty string: %w", define.ErrInvalidArg)
+			return nil, fmt.Errorf("network interface name cannot be an empty string: %w", define.ErrInvalidArg)
 		}
 		optBytes, err := json.Marshal(opts)
 		if err != nil {
-			return fmt.Errorf("marshalling network options JSON for container %s: %w", ctr.ID(), err)
+			return nil, fmt.Errorf("marshalling network options JSON for container %s: %w", ctr.ID(), err)
 		}
 		networks[net] = optBytes
 	}
 
+	return &boltContainer{
+		ctr:        ctr,
+		configJSON: configJSON,
+		stateJSON:  stateJSON,
+		networks:   networks,
+	}, nil
+}
+
+// Add containers to the DB in a single transaction
+// Containers that are part of a pod are added to the pod as well
+func (s *BoltState) addContainers(ctrs []*Container) error {
+	newCtrs := make([]*boltContainer, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		newCtr, err := newBoltContainer(ctr)
+		if err != nil {
+			return err
+		}
+		newCtrs = append(newCtrs, newCtr)
+	}
+
 	db, err := s.getDBCon()
 	if err != nil {
 		return err
@@ -651,184 +712,197 @@ func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
 	defer s.deferredCloseDBCon(db)
 
 	err = db.Update(func(tx *bolt.Tx) error {
-		idsBucket, err := getIDBucket(tx)
-		if err != nil {
-			return err
+		for _, newCtr := range newCtrs {
+			if err := s.addContainerWithTx(newCtr, tx); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	return err
+}
 
-		namesBucket, err := getNamesBucket(tx)
-		if err != nil {
-			return err
-		}
+// Add a container to the DB with the given transaction
+func (s *BoltState) addContainerWithTx(newCtr *boltContainer, tx *bolt.Tx) error {
+	ctr := newCtr.ctr
+	ctrID := []byte(ctr.ID())
+	ctrName := []byte(ctr.Name())
+	podID := ctr.config.Pod
+	dependsCtrs := ctr.Dependencies()
 
-		ctrBucket, err := getCtrBucket(tx)
-		if err != nil {
-			return err
-		}
+	idsBucket, err := getIDBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		allCtrsBucket, err := getAllCtrsBucket(tx)
-		if err != nil {
-			return err
-		}
+	namesBucket, err := getNamesBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		volBkt, err := getVolBucket(tx)
-		if err != nil {
-			return err
-		}
+	ctrBucket, err := getCtrBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		// If a pod was given, check if it exists
-		var podDB *bolt.Bucket
-		var podCtrs *bolt.Bucket
-		if pod != nil {
-			podBucket, err := getPodBucket(tx)
-			if err != nil {
-				return err
-			}
+	allCtrsBucket, err := getAllCtrsBucket(tx)
+	if err != nil {
+		return err
+	}
 
-			podID := []byte(pod.ID())
+	volBkt, err := getVolBucket(tx)
+	if err != nil {
+		return err
+	}
 
-			podDB = podBucket.Bucket(podID)
-			if podDB == nil {
-				pod.valid = false
-				return fmt.Errorf("pod %s does not exist in database: %w", pod.ID(), define.ErrNoSuchPod)
-			}
-			podCtrs = podDB.Bucket(containersBkt)
-			if podCtrs == nil {
-				return fmt.Errorf("pod %s does not have a containers bucket: %w", pod.ID(), define.ErrInternal)
-			}
+	// If the container is part of a pod, check if it exists
+	var podDB *bolt.Bucket
+	var podCtrs *bolt.Bucket
+	if podID != "" {
+		podBucket, err := getPodBucket(tx)
+		if err != nil {
+			return err
 		}
 
-		// Check if we already have a container with the given ID and name
-		idExist := idsBucket.Get(ctrID)
-		if idExist != nil {
-			err = define.ErrCtrExists
-			if allCtrsBucket.Get(idExist) == nil {
-				err = define.ErrPodExists
-			}
-			return fmt.Errorf("ID \"%s\" is in use: %w", ctr.ID(), err)
+		podDB = podBucket.Bucket([]byte(podID))
+		if podDB == nil {
+			return fmt.Errorf("pod %s does not exist in database: %w", podID, define.ErrNoSuchPod)
 		}
-		nameExist := namesBucket.Get(ctrName)
-		if nameExist != nil {
-			err = define.ErrCtrExists
-			if allCtrsBucket.Get(nameExist) == nil {
-				err = define.ErrPodExists
-			}
-			return fmt.Errorf("name \"%s\" is in use: %w", ctr.Name(), err)
+		podCtrs = podDB.Bucket(containersBkt)
+		if podCtrs == nil {
+			return fmt.Errorf("pod %s does not have a containers bucket: %w", podID, define.ErrInternal)
 		}
+	}
 
-		// No overlapping containers
-		// Add the new container to the DB
-		if err := idsBucket.Put(ctrID, ctrName); err != nil {
-			return fmt.Errorf("adding container %s ID to DB: %w", ctr.ID(), err)
-		}
-		if err := namesBucket.Put(ctrName, ctrID); err != nil {
-			return fmt.Errorf("adding container %s name (%s) to DB: %w", ctr.ID(), ctr.Name(), err)
+	// Check if we already have a container with the given ID and name
+	idExist := idsBucket.Get(ctrID)
+	if idExist != nil {
+		err = define.ErrCtrExists
+		if allCtrsBucket.Get(idExist) == nil {
+			err = define.ErrPodExists
 		}
-		if err := allCtrsBucket.Put(ctrID, ctrName); err != nil {
-			return fmt.Errorf("adding container %s to all containers bucket in DB: %w", ctr.ID(), err)
+		return fmt.Errorf("ID \"%s\" is in use: %w", ctr.ID(), err)
+	}
+	nameExist := namesBucket.Get(ctrName)
+	if nameExist != nil {
+		err = define.ErrCtrExists
+		if allCtrsBucket.Get(nameExist) == nil {
+			err = define.ErrPodExists
 		}
+		return fmt.Errorf("name \"%s\" is in use: %w", ctr.Name(), err)
+	}
 
-		newCtrBkt, err := ctrBucket.CreateBucket(ctrID)
-		if err != nil {
-			return fmt.Errorf("adding container %s bucket to DB: %w", ctr.ID(), err)
-		}
+	// No overlapping containers
+	// Add the new container to the DB
+	if err := idsBucket.Put(ctrID, ctrName); err != nil {
+		return fmt.Errorf("adding container %s ID to DB: %w", ctr.ID(), err)
+	}
+	if err := namesBucket.Put(ctrName, ctrID); err != nil {
+		return fmt.Errorf("adding container %s name (%s) to DB: %w", ctr.ID(), ctr.Name(), err)
+	}
+	if err := allCtrsBucket.Put(ctrID, ctrName); err != nil {
+		return fmt.Errorf("adding container %s to all containers bucket in DB: %w", ctr.ID(), err)
+	}
 
-		if err := newCtrBkt.Put(configKey, configJSON); err != nil {
-			return fmt.Errorf("adding container %s config to DB: %w", ctr.ID(), err)
-		}
-		if err := newCtrBkt.Put(stateKey, stateJSON); err != nil {
-			return fmt.Errorf("adding container %s state to DB: %w", ctr.ID(), err)
+	newCtrBkt, err := ctrBucket.CreateBucket(ctrID)
+	if err != nil {
+		return fmt.Errorf("adding container %s bucket to DB: %w", ctr.ID(), err)
+	}
+
+	if err := newCtrBkt.Put(configKey, newCtr.configJSON); err != nil {
+		return fmt.Errorf("adding container %s config to DB: %w", ctr.ID(), err)
+	}
+	if err := newCtrBkt.Put(stateKey, newCtr.stateJSON); err != nil {
+		return fmt.Errorf("adding container %s state to DB: %w", ctr.ID(), err)
+	}
+	if podID != "" {
+		if err := newCtrBkt.Put(podIDKey, []byte(podID)); err != nil {
+			return fmt.Errorf("adding container %s pod to DB: %w", ctr.ID(), err)
 		}
-		if pod != nil {
-			if err := newCtrBkt.Put(podIDKey, []byte(pod.ID())); err != nil {
-				return fmt.Errorf("adding container %s pod to DB: %w", ctr.ID(), err)
-			}
+	}
+	if len(newCtr.networks) > 0 {
+		ctrNetworksBkt, err := newCtrBkt.CreateBucket(networksBkt)
+		if err != nil {
+			return fmt.Errorf("creating networks bucket for container %s: %w", ctr.ID(), err)
 		}
-		if len(networks) > 0 {
-			ctrNetworksBkt, err := newCtrBkt.CreateBucket(networksBkt)
-			if err != nil {
-				return fmt.Errorf("creating networks bucket for container %s: %w", ctr.ID(), err)
+		for network, opts := range newCtr.networks {
+			if err := ctrNetworksBkt.Put([]byte(network), opts); err != nil {
+				return fmt.Errorf("adding network %q to networks bucket for container %s: %w", network, ctr.ID(), err)
 			}
-			for network, opts := range networks {
-				if err := ctrNetworksBkt.Put([]byte(network), opts); err != nil {
-					return fmt.Errorf("adding network %q to networks bucket for container %s: %w", network, ctr.ID(), err)
-				}
-			}
-		}
-
-		if _, err := newCtrBkt.CreateBucket(dependenciesBkt); err != nil {
-			return fmt.Errorf("creating dependencies bucket for container %s: %w", ctr.ID(), err)
 		}
+	}
 
-		// Add dependencies for the container
-		for _, dependsCtr := range dependsCtrs {
-			depCtrID := []byte(dependsCtr)
+	if _, err := newCtrBkt.CreateBucket(dependenciesBkt); err != nil {
+		return fmt.Errorf("creating dependencies bucket for container %s: %w", ctr.ID(), err)
+	}
 
-			depCtrBkt := ctrBucket.Bucket(depCtrID)
-			if depCtrBkt == nil {
-				return fmt.Errorf("container %s depends on container %s, but it does not exist in the DB: %w", ctr.ID(), dependsCtr, define.ErrNoSuchCtr)
-			}
+	// Add dependencies for the container
+	for _, dependsCtr := range dependsCtrs {
+		depCtrID := []byte(dependsCtr)
 
-			depCtrPod := depCtrBkt.Get(podIDKey)
-			if pod != nil {
-				// If we're part of a pod, make sure the dependency is part of the same pod
-				if depCtrPod == nil {
-					return fmt.Errorf("container %s depends on container %s which is not in pod %s: %w", ctr.ID(), dependsCtr, pod.ID(), define.ErrInvalidArg)
-				}
+		depCtrBkt := ctrBucket.Bucket(depCtrID)
+		if depCtrBkt == nil {
+			return fmt.Errorf("container %s depends on container %s, but it does not exist in the DB: %w", ctr.ID(), dependsCtr, define.ErrNoSuchCtr)
+		}
 
-				if string(depCtrPod) != pod.ID() {
-					return fmt.Errorf("container %s depends on container %s which is in a different pod (%s): %w", ctr.ID(), dependsCtr, string(depCtrPod), define.ErrInvalidArg)
-				}
-			} else if depCtrPod != nil {
-				// If we're not part of a pod, we cannot depend on containers in a pod
-				return fmt.Errorf("container %s depends on container %s which is in a pod - containers not in pods cannot depend on containers in pods: %w", ctr.ID(), dependsCtr, define.ErrInvalidArg)
+		depCtrPod := depCtrBkt.Get(podIDKey)
+		if podID != "" {
+			// If we're part of a pod, make sure the dependency is part of the same pod
+			if depCtrPod == nil {
+				return fmt.Errorf("container %s depends on container %s which is not in pod %s: %w", ctr.ID(), dependsCtr, podID, define.ErrInvalidArg)
 			}
 
-			depCtrDependsBkt := depCtrBkt.Bucket(dependenciesBkt)
-			if depCtrDependsBkt == nil {
-				return fmt.Errorf("container %s does not have a dependencies bucket: %w", dependsCtr, define.ErrInternal)
-			}
-			if err := depCtrDependsBkt.Put(ctrID, ctrName); err != nil {
-				return fmt.Errorf("adding ctr %s as dependency of container %s: %w", ctr.ID(), dependsCtr, err)
+			if string(depCtrPod) != podID {
+				return fmt.Errorf("container %s depends on container %s which is in a different pod (%s): %w", ctr.ID(), dependsCtr, string(depCtrPod), define.ErrInvalidArg)
 			}
+		} else if depCtrPod != nil {
+			// If we're not part of a pod, we cannot depend on containers in a pod
+			return fmt.Errorf("container %s depends on container %s which is in a pod - containers not in pods cannot depend on containers in pods: %w", ctr.ID(), dependsCtr, define.ErrInvalidArg)
 		}
 
-		// Add ctr to pod
-		if pod != nil && podCtrs != nil {
-			if err := podCtrs.Put(ctrID, ctrName); err != nil {
-				return fmt.Errorf("adding container %s to pod %s: %w", ctr.ID(), pod.ID(), err)
-			}
+		depCtrDependsBkt := depCtrBkt.Bucket(dependenciesBkt)
+		if depCtrDependsBkt == nil {
+			return fmt.Errorf("container %s does not have a dependencies bucket: %w", dependsCtr, define.ErrInternal)
+		}
+		if err := depCtrDependsBkt.Put(ctrID, ctrName); err != nil {
+			return fmt.Errorf("adding ctr %s as dependency of container %s: %w", ctr.ID(), dependsCtr, err)
 		}
+	}
 
-		// Add container to named volume dependencies buckets
-		for _, vol := range ctr.config.NamedVolumes {
-			volDB := volBkt.Bucket([]byte(vol.Name))
-			if volDB == nil {
-				return fmt.Errorf("no volume with name %s found in database when adding container %s: %w", vol.Name, ctr.ID(), define.ErrNoSuchVolume)
-			}
+	// Add ctr to pod
+	if podCtrs != nil {
+		if err := podCtrs.Put(ctrID, ctrName); err != nil {
+			return fmt.Errorf("adding container %s to pod %s: %w", ctr.ID(), podID, err)
+		}
+	}
 
-			ctrDepsBkt, err := volDB.CreateBucketIfNotExists(volDependenciesBkt)
-			if err != nil {
-				return fmt.Errorf("creating volume %s dependencies bucket to add container %s: %w", vol.Name, ctr.ID(), err)
-			}
-			if depExists := ctrDepsBkt.Get(ctrID); depExists == nil {
-				if err := ctrDepsBkt.Put(ctrID, ctrID); err != nil {
-					return fmt.Errorf("adding container %s to volume %s dependencies: %w", ctr.ID(), vol.Name, err)
-				}
+	// Add container to named volume dependencies buckets
+	for _, name := range ctr.volumeNames() {
+		volDB := volBkt.Bucket([]byte(name))
+		if volDB == nil {
+			return fmt.Errorf("no volume with name %s found in database when adding container %s: %w", name, ctr.ID(), define.ErrNoSuchVolume)
+		}
+
+		ctrDepsBkt, err := volDB.CreateBucketIfNotExists(volDependenciesBkt)
+		if err != nil {
+			return fmt.Errorf("creating volume %s dependencies bucket to add container %s: %w", name, ctr.ID(), err)
+		}
+		if depExists := ctrDepsBkt.Get(ctrID); depExists == nil {
+			if err := ctrDepsBkt.Put(ctrID, ctrID); err != nil {
+				return fmt.Errorf("adding container %s to volume %s dependencies: %w", ctr.ID(), name, err)
 			}
 		}
+	}
 
-		return nil
-	})
-	return err
+	return nil
 }
 
 // Remove a container from the DB
-// If pod is not nil, the container is treated as belonging to a pod, and
-// will be removed from the pod as well
-func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error {
+// If the container is part of a pod, it will be removed from the pod as well
+func (s *BoltState) removeContainer(ctr *Container, tx *bolt.Tx) error {
 	ctrID := []byte(ctr.ID())
 	ctrName := []byte(ctr.Name())
+	podID := ctr.config.Pod
 
 	idsBucket, err := getIDBucket(tx)
 	if err != nil {
@@ -857,18 +931,15 @@ func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error
 
 	// Does the pod exist?
 	var podDB *bolt.Bucket
-	if pod != nil {
+	if podID != "" {
 		podBucket, err := getPodBucket(tx)
 		if err != nil {
 			return err
 		}
 
-		podID := []byte(pod.ID())
-
-		podDB = podBucket.Bucket(podID)
+		podDB = podBucket.Bucket([]byte(podID))
 		if podDB == nil {
-			pod.valid = false
-			return fmt.Errorf("no pod with ID %s found in DB: %w", pod.ID(), define.ErrNoSuchPod)
+			return fmt.Errorf("no pod with ID %s found in DB: %w", podID, define.ErrNoSuchPod)
 		}
 	}
 
@@ -879,19 +950,19 @@ func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error
 		return fmt.Errorf("no container with ID %s found in DB: %w", ctr.ID(), define.ErrNoSuchCtr)
 	}
 
-	if podDB != nil && pod != nil {
+	if podDB != nil {
 		// Check if the container is in the pod, remove it if it is
 		podCtrs := podDB.Bucket(containersBkt)
 		if podCtrs == nil {
 			// Malformed pod
-			logrus.Errorf("Pod %s malformed in database, missing containers bucket!", pod.ID())
+			logrus.Errorf("Pod %s malformed in database, missing containers bucket!", podID)
 		} else {
 			ctrInPod := podCtrs.Get(ctrID)
 			if ctrInPod == nil {
-				return fmt.Errorf("container %s is not in pod %s: %w", ctr.ID(), pod.ID(), define.ErrNoSuchCtr)
+				return fmt.Errorf("container %s is not in pod %s: %w", ctr.ID(), podID, define.ErrNoSuchCtr)
 			}
 			if err := podCtrs.Delete(ctrID); err != nil {
-				return fmt.Errorf("removing container %s from pod %s: %w", ctr.ID(), pod.ID(), err)
+				return fmt.Errorf("removing container %s from pod %s: %w", ctr.ID(), podID, err)
 			}
 		}
 	}
@@ -977,8 +1048,8 @@ func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error
 	}
 
 	// Remove container from named volume dependencies buckets
-	for _, vol := range ctr.config.NamedVolumes {
-		volDB := volBkt.Bucket([]byte(vol.Name))
+	for _, name := range ctr.volumeNames() {
+		volDB := volBkt.Bucket([]byte(name))
 		if volDB == nil {
 			// Let's assume the volume was already deleted and
 			// continue to remove the container
@@ -987,11 +1058,11 @@ func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error
 
 		ctrDepsBkt := volDB.Bucket(volDependenciesBkt)
 		if ctrDepsBkt == nil {
-			return fmt.Errorf("volume %s is missing container dependencies bucket, cannot remove container %s from dependencies: %w", vol.Name, ctr.ID(), define.ErrInternal)
+			return fmt.Errorf("volume %s is missing container dependencies bucket, cannot remove container %s from dependencies: %w", name, ctr.ID(), define.ErrInternal)
 		}
 		if depExists := ctrDepsBkt.Get(ctrID); depExists == nil {
 			if err := ctrDepsBkt.Delete(ctrID); err != nil {
-				return fmt.Errorf("deleting container %s dependency on volume %s: %w", ctr.ID(), vol.Name, err)
+				return fmt.Errorf("deleting container %s dependency on volume %s: %w", ctr.ID(), name, err)
 			}
 		}
 	}
@@ -1056,3 +1127,134 @@ func (s *BoltState) lookupContainerID(idOrName string, ctrBucket, namesBucket *b
 	}
 	return id, nil
 }
+
+// renameContainerInBuckets changes the name of the given container from
+// oldName to newName in the name registry and in the container lists.
+func renameContainerInBuckets(tx *bolt.Tx, ctr *Container, oldName, newName string) error {
+	idBkt, err := getIDBucket(tx)
+	if err != nil {
+		return err
+	}
+	namesBkt, err := getNamesBucket(tx)
+	if err != nil {
+		return err
+	}
+	allCtrsBkt, err := getAllCtrsBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	needsRename := true
+	if exists := namesBkt.Get([]byte(newName)); exists != nil {
+		if string(exists) == ctr.ID() {
+			// Name already associated with the ID
+			// of this container. No need for a
+			// rename.
+			needsRename = false
+		} else {
+			return fmt.Errorf("name %s already in use, cannot rename container %s: %w", newName, ctr.ID(), define.ErrCtrExists)
+		}
+	}
+
+	if needsRename {
+		// We do have to remove the old name. The other
+		// buckets are ID-indexed so we just need to
+		// overwrite the values there.
+		if err := namesBkt.Delete([]byte(oldName)); err != nil {
+			return fmt.Errorf("deleting container %s old name from DB for rename: %w", ctr.ID(), err)
+		}
+		if err := idBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
+			return fmt.Errorf("renaming container %s in ID bucket in DB: %w", ctr.ID(), err)
+		}
+		if err := namesBkt.Put([]byte(newName), []byte(ctr.ID())); err != nil {
+			return fmt.Errorf("adding new name %s for container %s in DB: %w", newName, ctr.ID(), err)
+		}
+		if err := allCtrsBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
+			return fmt.Errorf("renaming container %s in all containers bucket in DB: %w", ctr.ID(), err)
+		}
+		if ctr.config.Pod != "" {
+			podsBkt, err := getPodBucket(tx)
+			if err != nil {
+				return err
+			}
+			podBkt := podsBkt.Bucket([]byte(ctr.config.Pod))
+			if podBkt == nil {
+				return fmt.Errorf("bucket for pod %s does not exist: %w", ctr.config.Pod, define.ErrInternal)
+			}
+			podCtrBkt := podBkt.Bucket(containersBkt)
+			if podCtrBkt == nil {
+				return fmt.Errorf("pod %s does not have a containers bucket: %w", ctr.config.Pod, define.ErrInternal)
+			}
+			if err := podCtrBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
+				return fmt.Errorf("renaming container %s in pod %s members bucket: %w", ctr.ID(), ctr.config.Pod, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// containerDependencyGraph walks the dependency graph from the given container
+// in a single transaction. edgesOf returns the edges leading from the
+// container with the given bucket and ID to its neighbours in the walked
+// direction.
+func (s *BoltState) containerDependencyGraph(ctr *Container, edgesOf func(ctrDB *bolt.Bucket, id string) ([]StateDependency, error)) ([]StateDependency, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return nil, define.ErrCtrRemoved
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	edges := []StateDependency{}
+	err = db.View(func(tx *bolt.Tx) error {
+		ctrBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		visited := map[string]bool{ctr.ID(): true}
+		queue := []string{ctr.ID()}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			ctrDB := ctrBucket.Bucket([]byte(id))
+			if ctrDB == nil {
+				if id == ctr.ID() {
+					ctr.valid = false
+				}
+				return fmt.Errorf("no container with ID %q found in DB: %w", id, define.ErrNoSuchCtr)
+			}
+
+			next, err := edgesOf(ctrDB, id)
+			if err != nil {
+				return err
+			}
+			for _, edge := range next {
+				edges = append(edges, edge)
+				neighbour := edge.ID
+				if neighbour == id {
+					neighbour = edge.DependencyID
+				}
+				if !visited[neighbour] {
+					visited[neighbour] = true
+					queue = append(queue, neighbour)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edges, nil
+}
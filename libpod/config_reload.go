@@ -0,0 +1,141 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/podman/v5/pkg/containersconf"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// registriesSetting names the registries.conf files in the settings
+	// listed by reload events.
+	registriesSetting = "registries"
+	// serviceLogLevelSetting is the containers.conf setting of the log
+	// level of the system service.
+	serviceLogLevelSetting = "engine.service_log_level"
+
+	// reloadedAttribute and restartAttribute are the attributes of a
+	// reload event listing the applied settings and the settings
+	// requiring a restart.
+	reloadedAttribute = "reloaded"
+	restartAttribute  = "restart_required"
+)
+
+// reloadableEngineSettings are the settings of the [engine] table applied by
+// Reload. The settings of the [containers] table are defaults of new
+// containers and are all reloadable.
+var reloadableEngineSettings = map[string]bool{
+	"compat_api_enforce_docker_hub":        true,
+	"events_container_create_inspect_data": true,
+	"healthcheck_events":                   true,
+	"image_default_transport":              true,
+	"image_parallel_copies":                true,
+	"pull_policy":                          true,
+	"retry":                                true,
+	"retry_delay":                          true,
+	"service_log_level":                    true,
+	"stop_timeout":                         true,
+}
+
+// PrepareReload reads the containers.conf files as the base Reload compares
+// them with to find the changed settings. Long-running processes call it
+// before they start watching the configuration files.
+func (r *Runtime) PrepareReload() error {
+	base, err := config.New(&config.Options{Modules: r.config.LoadedModules()})
+	if err != nil {
+		return err
+	}
+	podmanBase, err := containersconf.New(base.LoadedModules())
+	if err != nil {
+		return err
+	}
+	r.reloadBase = base
+	r.podmanReloadBase = podmanBase
+	return nil
+}
+
+// isReloadable returns true if the setting of the given table can be applied
+// without restarting Podman.
+func isReloadable(table, key string) bool {
+	switch table {
+	case "containers":
+		return true
+	case "engine":
+		return reloadableEngineSettings[key]
+	}
+	return false
+}
+
+// tomlName returns the name of the struct field in containers.conf, or an
+// empty string if it is not read from containers.conf.
+func tomlName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// applyReloadableSettings compares the configurations read from the
+// containers.conf files before and after a change, either the settings of
+// containers/common or the ones only known to Podman. It returns a copy of
+// current with the changed reloadable settings applied, the changed settings
+// it applied and the changed settings requiring a restart, as table.key
+// names.
+func applyReloadableSettings[T config.Config | containersconf.Config](current, base, updated *T) (*T, []string, []string) {
+	conf := *current
+	var reloaded, restart []string
+
+	confValue := reflect.ValueOf(&conf).Elem()
+	baseValue := reflect.ValueOf(base).Elem()
+	updatedValue := reflect.ValueOf(updated).Elem()
+	for i := 0; i < confValue.NumField(); i++ {
+		table := tomlName(confValue.Type().Field(i))
+		if table == "" || confValue.Field(i).Kind() != reflect.Struct {
+			continue
+		}
+		confTable := confValue.Field(i)
+		baseTable := baseValue.Field(i)
+		updatedTable := updatedValue.Field(i)
+		for j := 0; j < confTable.NumField(); j++ {
+			key := tomlName(confTable.Type().Field(j))
+			if key == "" || reflect.DeepEqual(baseTable.Field(j).Interface(), updatedTable.Field(j).Interface()) {
+				continue
+			}
+			setting := table + "." + key
+			if !isReloadable(table, key) {
+				restart = append(restart, setting)
+				continue
+			}
+			confTable.Field(j).Set(updatedTable.Field(j))
+			reloaded = append(reloaded, setting)
+		}
+	}
+	return &conf, reloaded, restart
+}
+
+// newReloadEvent writes a system event listing the settings applied by a
+// reload of the configuration and the settings requiring a restart.
+func (r *Runtime) newReloadEvent(reloaded, restart []string) {
+	e := events.NewEvent(events.Reload)
+	e.Type = events.System
+	e.Attributes = make(map[string]string)
+	if len(reloaded) > 0 {
+		e.Attributes[reloadedAttribute] = strings.Join(reloaded, ",")
+	}
+	if len(restart) > 0 {
+		e.Attributes[restartAttribute] = strings.Join(restart, ",")
+	}
+	if err := r.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write system event: %q", err)
+	}
+}
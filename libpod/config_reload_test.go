@@ -0,0 +1,41 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyReloadableSettings(t *testing.T) {
+	base := new(config.Config)
+	base.Containers.LogDriver = "k8s-file"
+	base.Engine.PullPolicy = "missing"
+	base.Engine.NumLocks = 2048
+
+	// The current configuration holds command-line overrides, they are
+	// kept unless the setting changed in the files.
+	current := *base
+	current.Engine.EventsLogger = "file"
+
+	updated := *base
+	updated.Containers.LogDriver = "journald"
+	updated.Engine.PullPolicy = "newer"
+	updated.Engine.NumLocks = 4096
+
+	conf, reloaded, restart := applyReloadableSettings(&current, base, &updated)
+	assert.Equal(t, []string{"containers.log_driver", "engine.pull_policy"}, reloaded)
+	assert.Equal(t, []string{"engine.num_locks"}, restart)
+	assert.Equal(t, "journald", conf.Containers.LogDriver)
+	assert.Equal(t, "newer", conf.Engine.PullPolicy)
+	assert.Equal(t, uint32(2048), conf.Engine.NumLocks, "settings requiring a restart are not applied")
+	assert.Equal(t, "file", conf.Engine.EventsLogger)
+	assert.Equal(t, "k8s-file", current.Containers.LogDriver, "the current configuration is not modified")
+
+	conf, reloaded, restart = applyReloadableSettings(conf, &updated, &updated)
+	assert.Empty(t, reloaded)
+	assert.Empty(t, restart)
+	assert.Equal(t, "journald", conf.Containers.LogDriver)
+}
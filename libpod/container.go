@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -107,6 +108,19 @@ type Container struct {
 	// Functions called on a batched container will not lock or sync
 	batched bool
 
+	// saveDeferrals counts the nested deferSaves() calls in progress.
+	// While it is non-zero, save() only marks the state dirty and the
+	// state is written once when the outermost call finishes.
+	saveDeferrals int
+	// stateDirty indicates that the state was changed by a deferred
+	// save() and has not been written to the database yet.
+	stateDirty bool
+
+	// traceCtx carries the span of the traced operation running on the
+	// container, the parent of the spans of its state operations. It is
+	// only set while the operation runs.
+	traceCtx context.Context
+
 	valid      bool
 	lock       lock.Locker
 	runtime    *Runtime
@@ -118,6 +132,11 @@ type Container struct {
 	rootlessPortSyncR *os.File
 	rootlessPortSyncW *os.File
 
+	// takeoverSockets are the listen sockets taken over from the previous
+	// run of the container, used instead of binding new sockets when the
+	// container is started next.
+	takeoverSockets []*os.File
+
 	// perNetworkOpts should be set when you want to use special network
 	// options when calling network setup/teardown. This should be used for
 	// container restore or network reload for example. Leave this nil if
@@ -166,6 +185,10 @@ type ContainerState struct {
 	PID int `json:"pid,omitempty"`
 	// ConmonPID is the PID of the container's conmon
 	ConmonPID int `json:"conmonPid,omitempty"`
+	// ListenSocketInodes are the inodes of the listen sockets of the
+	// running container, which conmon keeps open, in the order of the
+	// ListenSockets of the config.
+	ListenSocketInodes []uint64 `json:"listenSocketInodes,omitempty"`
 	// ExecSessions contains all exec sessions that are associated with this
 	// container.
 	ExecSessions map[string]*ExecSession `json:"newExecSessions,omitempty"`
@@ -182,6 +205,14 @@ type ContainerState struct {
 	// To read this field use container.getNetworkStatus() instead, this will
 	// take care of migrating the old DEPRECATED network status to the new format.
 	NetworkStatus map[string]types.StatusBlock `json:"networkStatus,omitempty"`
+	// NetworkDisturbances are the network disturbances currently applied
+	// to the network namespace of the container. They are reverted along
+	// with the network namespace.
+	NetworkDisturbances []define.NetworkDisturbance `json:"networkDisturbances,omitempty"`
+	// MachinePortsExposed indicates that the port mappings of a container
+	// in the host network namespace are currently forwarded to the podman
+	// machine host via gvproxy.
+	MachinePortsExposed bool `json:"machinePortsExposed,omitempty"`
 	// BindMounts contains files that will be bind-mounted into the
 	// container when it is mounted.
 	// These include /etc/hosts and /etc/resolv.conf
@@ -209,6 +240,9 @@ type ContainerState struct {
 	// healthcheck. The container will be restarted if this exceed a set
 	// number in the startup HC config.
 	StartupHCFailureCount int `json:"startupHCFailureCount,omitempty"`
+	// Provisioned indicates that the provision script of the container
+	// succeeded and must not run again.
+	Provisioned bool `json:"provisioned,omitempty"`
 	// HCUnitName records the name of the healthcheck unit.
 	// Automatically generated when the healthcheck is started.
 	HCUnitName string `json:"hcUnitName,omitempty"`
@@ -235,8 +269,28 @@ type ContainerState struct {
 	RestoredTime     time.Time `json:"restoredTime,omitempty"`
 	CheckpointLog    string    `json:"checkpointLog,omitempty"`
 	CheckpointPath   string    `json:"checkpointPath,omitempty"`
-	RestoreLog       string    `json:"restoreLog,omitempty"`
-	Restored         bool      `json:"restored,omitempty"`
+	// CheckpointChain are the directories, relative to the bundle, of the
+	// pre-dumps the checkpoint of the container is based on, oldest
+	// first.
+	CheckpointChain []string `json:"checkpointChain,omitempty"`
+	RestoreLog      string   `json:"restoreLog,omitempty"`
+	Restored        bool     `json:"restored,omitempty"`
+
+	// SizeCache holds the sizes of the container's root filesystem as
+	// computed while it was not mounted. It is cleared whenever the root
+	// filesystem is mounted, as it can only be written to while mounted.
+	SizeCache *ContainerSize `json:"sizeCache,omitempty"`
+}
+
+// ContainerSize holds the sizes of a container's root filesystem.
+type ContainerSize struct {
+	// RootFsSize is the size of the root filesystem, including the image.
+	RootFsSize int64 `json:"rootFsSize"`
+	// RWSize is the size of the writable layer.
+	RWSize int64 `json:"rwSize"`
+	// ComputedAt is when the sizes were computed. It is older than the
+	// current time if they were read from the size cache.
+	ComputedAt time.Time `json:"computedAt"`
 }
 
 // ContainerNamedVolume is a named volume that will be mounted into the
@@ -280,6 +334,13 @@ type ContainerImageVolume struct {
 	ReadWrite bool `json:"rw"`
 	// SubPath determines which part of the image will be mounted into the container.
 	SubPath string `json:"subPath,omitempty"`
+	// Persist keeps the changes written to a read-write image volume in an
+	// anonymous volume, so they survive restarts of the container.
+	Persist bool `json:"persist,omitempty"`
+	// UpperVolume is the name of the anonymous volume holding the upper and
+	// work directories of the overlay of a persistent image volume. It is
+	// created together with the container.
+	UpperVolume string `json:"upperVolume,omitempty"`
 }
 
 // ContainerSecret is a secret that is mounted in a container
@@ -400,6 +461,12 @@ func (c *Container) PodID() string {
 	return c.config.Pod
 }
 
+// PodNetworkOptOut returns whether the container uses its own network
+// namespace instead of the one shared by its pod.
+func (c *Container) PodNetworkOptOut() bool {
+	return c.config.PodNetworkOptOut
+}
+
 // Namespace returns the libpod namespace the container is in.
 // Namespaces are used to logically separate containers and pods in the state.
 func (c *Container) Namespace() string {
@@ -449,6 +516,22 @@ func (c *Container) NamedVolumes() []*ContainerNamedVolume {
 	return volumes
 }
 
+// volumeNames returns the names of all volumes used by the container: its
+// named volumes and the volumes holding the changes of its persistent image
+// volumes.
+func (c *Container) volumeNames() []string {
+	names := make([]string, 0, len(c.config.NamedVolumes)+len(c.config.ImageVolumes))
+	for _, vol := range c.config.NamedVolumes {
+		names = append(names, vol.Name)
+	}
+	for _, vol := range c.config.ImageVolumes {
+		if vol.UpperVolume != "" {
+			names = append(names, vol.UpperVolume)
+		}
+	}
+	return names
+}
+
 // Privileged returns whether the container is privileged
 func (c *Container) Privileged() bool {
 	return c.config.Privileged
@@ -1143,26 +1226,34 @@ func (c *Container) cGroupPath() (string, error) {
 
 // RootFsSize returns the root FS size of the container
 func (c *Container) RootFsSize() (int64, error) {
-	if !c.batched {
-		c.lock.Lock()
-		defer c.lock.Unlock()
-		if err := c.syncContainer(); err != nil {
-			return -1, fmt.Errorf("updating container %s state: %w", c.ID(), err)
-		}
+	size, err := c.Size()
+	if err != nil {
+		return -1, err
 	}
-	return c.rootFsSize()
+	return size.RootFsSize, nil
 }
 
 // RWSize returns the rw size of the container
 func (c *Container) RWSize() (int64, error) {
+	size, err := c.Size()
+	if err != nil {
+		return -1, err
+	}
+	return size.RWSize, nil
+}
+
+// Size returns the root FS and rw sizes of the container.
+// Sizes computed while the container is not mounted are cached until it is
+// mounted again, so they are not recomputed on every call.
+func (c *Container) Size() (*ContainerSize, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
 		if err := c.syncContainer(); err != nil {
-			return -1, fmt.Errorf("updating container %s state: %w", c.ID(), err)
+			return nil, fmt.Errorf("updating container %s state: %w", c.ID(), err)
 		}
 	}
-	return c.rwSize()
+	return c.size()
 }
 
 // IDMappings returns the UID/GID mapping used for the container
@@ -1268,11 +1359,119 @@ func (c *Container) Umask() string {
 	return c.config.Umask
 }
 
+// Keyring returns the keyring mode of the container. Containers created
+// without one use the keyring option of containers.conf.
+func (c *Container) Keyring() string {
+	if c.config.Keyring != "" {
+		return c.config.Keyring
+	}
+	if c.runtime.config.Containers.EnableKeyring {
+		return define.KeyringPrivate
+	}
+	return define.KeyringInherit
+}
+
 // Secrets return the secrets in the container
 func (c *Container) Secrets() []*ContainerSecret {
 	return c.config.Secrets
 }
 
+// EffectiveConfig returns the snapshot of the containers.conf settings the
+// container was created with, or nil if it was created by an older version of
+// Podman.
+func (c *Container) EffectiveConfig() map[string]any {
+	return c.config.EffectiveConfig
+}
+
+// Metadata returns the metadata entries attached to the container with
+// SetMetadata.
+func (c *Container) Metadata() (map[string]string, error) {
+	if !c.valid {
+		return nil, define.ErrCtrRemoved
+	}
+	return c.runtime.state.GetContainerMetadata(c.ID())
+}
+
+// SetMetadata attaches a durable metadata entry to the container, replacing
+// any earlier value of the key, without rewriting the container's
+// configuration. An empty value removes the entry. Entries are removed
+// together with the container.
+func (c *Container) SetMetadata(key, value string) error {
+	if !c.valid {
+		return define.ErrCtrRemoved
+	}
+	return c.runtime.state.SetContainerMetadata(c.ID(), key, value)
+}
+
+// operatorAnnotationPrefix is the prefix of the metadata keys holding the
+// annotations set with SetOperatorAnnotation, keeping them apart from the
+// metadata recorded by Podman itself.
+const operatorAnnotationPrefix = "annotation."
+
+// OperatorAnnotations returns the annotations operators attached to the
+// container with SetOperatorAnnotation. Unlike the OCI annotations of the
+// container, they are not passed to the OCI runtime.
+func (c *Container) OperatorAnnotations() (map[string]string, error) {
+	metadata, err := c.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	annotations := make(map[string]string)
+	for key, value := range metadata {
+		if name, ok := strings.CutPrefix(key, operatorAnnotationPrefix); ok {
+			annotations[name] = value
+		}
+	}
+	return annotations, nil
+}
+
+// SetOperatorAnnotation sets an annotation of the container, replacing any
+// earlier value of the key. An empty value removes the annotation. The
+// annotations can be changed at any time as they are kept apart from the
+// configuration of the container.
+func (c *Container) SetOperatorAnnotation(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("annotation key must not be empty: %w", define.ErrInvalidArg)
+	}
+	return c.SetMetadata(operatorAnnotationPrefix+key, value)
+}
+
+// Notes returns the notes attached to the container with AddNote, oldest
+// first.
+func (c *Container) Notes() ([]*define.ContainerNote, error) {
+	if !c.valid {
+		return nil, define.ErrCtrRemoved
+	}
+	return c.runtime.state.GetContainerNotes(c.ID())
+}
+
+// AddNote attaches a note to the container. Notes are removed together with
+// the container.
+func (c *Container) AddNote(text string) (*define.ContainerNote, error) {
+	if !c.valid {
+		return nil, define.ErrCtrRemoved
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("note must not be empty: %w", define.ErrInvalidArg)
+	}
+	note := &define.ContainerNote{
+		Created: time.Now(),
+		Text:    text,
+	}
+	if err := c.runtime.state.AddContainerNote(c.ID(), note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// RemoveNote removes the note with the given ID from the container.
+func (c *Container) RemoveNote(id int64) error {
+	if !c.valid {
+		return define.ErrCtrRemoved
+	}
+	return c.runtime.state.RemoveContainerNote(c.ID(), id)
+}
+
 // Networks gets all the networks this container is connected to.
 // Please do NOT use ctr.config.Networks, as this can be changed from those
 // values at runtime via network connect and disconnect.
@@ -84,6 +84,11 @@ func (c *Container) Init(ctx context.Context, recursive bool) error {
 // running before starting the container. The recursive parameter, if set, will start all
 // dependencies before starting this container.
 func (c *Container) Start(ctx context.Context, recursive bool) (finalErr error) {
+	ctx, endSpan := c.startSpan(ctx, "container start")
+	defer func() {
+		endSpan(finalErr)
+	}()
+
 	defer func() {
 		if finalErr != nil {
 			// Have to re-lock.
@@ -109,12 +114,18 @@ func (c *Container) Start(ctx context.Context, recursive bool) (finalErr error)
 			return err
 		}
 	}
-	if err := c.prepareToStart(ctx, recursive); err != nil {
+	// Preparing and starting the container save its state several times,
+	// write it once after the container was started instead.
+	if err := c.deferSaves(func() error {
+		if err := c.prepareToStart(ctx, recursive); err != nil {
+			return err
+		}
+		// Start the container
+		return c.start()
+	}); err != nil {
 		return err
 	}
-
-	// Start the container
-	if err := c.start(); err != nil {
+	if err := c.provision(); err != nil {
 		return err
 	}
 	return c.waitForHealthy(ctx)
@@ -147,6 +158,14 @@ func (c *Container) Update(resources *spec.LinuxResources, restartPolicy *string
 // ordering of the two such that no output from the container is lost (e.g. the
 // Attach call occurs before Start).
 func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, keys string, resize <-chan resize.TerminalSize, start bool) (retChan <-chan error, finalErr error) {
+	if start {
+		var endSpan func(error)
+		ctx, endSpan = c.startSpan(ctx, "container start")
+		defer func() {
+			endSpan(finalErr)
+		}()
+	}
+
 	defer func() {
 		if finalErr != nil {
 			// Have to re-lock.
@@ -230,6 +249,9 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 	}
 
 	if start {
+		if err := c.provision(); err != nil {
+			return nil, err
+		}
 		if err := c.waitForHealthy(ctx); err != nil {
 			return nil, err
 		}
@@ -270,6 +292,11 @@ func (c *Container) Stop() error {
 // manually. If timeout is 0, SIGKILL will be used immediately to kill the
 // container.
 func (c *Container) StopWithTimeout(timeout uint) (finalErr error) {
+	_, endSpan := c.startSpan(context.Background(), "container stop")
+	defer func() {
+		endSpan(finalErr)
+	}()
+
 	defer func() {
 		if finalErr != nil {
 			// Have to re-lock.
@@ -337,6 +364,54 @@ func (c *Container) Kill(signal uint) error {
 	return c.save()
 }
 
+// UsesSecret returns whether the secret with the given ID is mounted into the
+// container or set as one of its environment variables.
+func (c *Container) UsesSecret(id string) bool {
+	for _, secr := range c.config.Secrets {
+		if secr.ID == id {
+			return true
+		}
+	}
+	for _, secr := range c.config.EnvSecrets {
+		if secr.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshSecret rewrites the container's copy of the mounted secret with the
+// given ID from the current secret data, which a running container sees
+// immediately. Environment variable secrets are only read when the container
+// starts.
+// If signal is not 0, it is then sent to the container if it is running, so
+// the container can reload the secret. Unlike Kill, this does not mark the
+// container as stopped by the user.
+func (c *Container) RefreshSecret(id string, signal uint) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	for _, secr := range c.config.Secrets {
+		if secr.ID != id {
+			continue
+		}
+		if err := c.extractSecretToCtrStorage(secr); err != nil {
+			return fmt.Errorf("refreshing secret %s of container %s: %w", secr.Name, c.ID(), err)
+		}
+	}
+
+	if signal == 0 || c.state.State != define.ContainerStateRunning {
+		return nil
+	}
+	return c.ociRuntime.KillContainer(c, signal, false)
+}
+
 // HTTPAttach forwards an attach session over a hijacked HTTP session.
 // HTTPAttach will consume and close the included httpCon, which is expected to
 // be sourced from a hijacked HTTP connection.
@@ -808,6 +883,15 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 // Cleanup unmounts all mount points in container and cleans up container storage
 // It also cleans up the network stack
 func (c *Container) Cleanup(ctx context.Context) error {
+	// The on-exit notification is sent once the container is unlocked, so
+	// slow notifiers do not block other operations on the container.
+	var notification *define.ExitNotification
+	defer func() {
+		if notification != nil {
+			c.sendExitNotification(ctx, notification)
+		}
+	}()
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -835,6 +919,9 @@ func (c *Container) Cleanup(ctx context.Context) error {
 		return nil
 	}
 
+	// Notify about every exit, including those followed by a restart.
+	notification = c.exitNotification(ctx)
+
 	// Handle restart policy.
 	// Returns a bool indicating whether we actually restarted.
 	// If we did, don't proceed to cleanup - just exit.
@@ -1005,6 +1092,11 @@ type ContainerCheckpointOptions struct {
 	PreCheckPoint bool
 	// Dump container with Pre Checkpoint images
 	WithPrevious bool
+	// PreDumpChain is the number of iterative pre-dumps of the memory of
+	// the container taken before it is checkpointed. Each pre-dump only
+	// contains the memory changed since the previous one, which keeps the
+	// time the container is frozen by the checkpoint short.
+	PreDumpChain int
 	// ImportPrevious tells the API to restore container with two
 	// images. One is TargetFile, the other is ImportPrevious.
 	ImportPrevious string
@@ -1031,6 +1123,16 @@ type ContainerCheckpointOptions struct {
 	// FileLocks tells the API to checkpoint/restore a container
 	// with file-locks
 	FileLocks bool
+	// SkipRecord tells the API to not record the checkpoint exported
+	// to TargetFile, e.g. as it is a temporary file.
+	SkipRecord bool
+
+	// preDumpDir is the directory, relative to the bundle, a pre-dump of
+	// a pre-dump chain is written to.
+	preDumpDir string
+	// parentDir is the directory, relative to the bundle, of the pre-dump
+	// the checkpoint is based on.
+	parentDir string
 }
 
 // Checkpoint checkpoints a container
@@ -1053,6 +1155,13 @@ func (c *Container) Checkpoint(ctx context.Context, options ContainerCheckpointO
 		}
 	}
 
+	if options.PreDumpChain < 0 {
+		return nil, 0, fmt.Errorf("the length of the pre-dump chain must not be negative: %w", define.ErrInvalidArg)
+	}
+	if options.PreDumpChain > 0 && (options.PreCheckPoint || options.WithPrevious) {
+		return nil, 0, fmt.Errorf("a pre-dump chain cannot be combined with a pre-checkpoint: %w", define.ErrInvalidArg)
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
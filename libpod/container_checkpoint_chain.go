@@ -0,0 +1,114 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	metadata "github.com/checkpoint-restore/checkpointctl/lib"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/sirupsen/logrus"
+)
+
+// preDumpChain takes the given number of pre-dumps of the container, each one
+// based on the previous one, and returns their directories relative to the
+// bundle, oldest first.
+func (c *Container) preDumpChain(length int) ([]string, error) {
+	chain := make([]string, 0, length)
+	for i := 1; i <= length; i++ {
+		preDump := ContainerCheckpointOptions{
+			PreCheckPoint: true,
+			preDumpDir:    fmt.Sprintf(preDumpChainDir, i),
+		}
+		if len(chain) > 0 {
+			preDump.parentDir = chain[len(chain)-1]
+		}
+		logrus.Debugf("Taking pre-dump %d of %d of container %s", i, length, c.ID())
+		if _, err := c.ociRuntime.CheckpointContainer(c, preDump); err != nil {
+			removeCheckpointDirs(c.bundlePath(), append(chain, preDump.preDumpDir))
+			return nil, fmt.Errorf("taking pre-dump %d of %d: %w", i, length, err)
+		}
+		chain = append(chain, preDump.preDumpDir)
+		if preDump.parentDir != "" {
+			if err := relinkCheckpointParent(filepath.Join(c.bundlePath(), preDump.preDumpDir), preDump.parentDir); err != nil {
+				removeCheckpointDirs(c.bundlePath(), chain)
+				return nil, err
+			}
+		}
+	}
+	return chain, nil
+}
+
+// relinkCheckpointParent points the parent link of the images in imagePath to
+// the pre-dump in parentDir, relative to the bundle.
+// There is a bug from criu: https://github.com/checkpoint-restore/criu/issues/116
+// We have to change the symbolic link from absolute path to relative path
+func relinkCheckpointParent(imagePath, parentDir string) error {
+	link := filepath.Join(imagePath, "parent")
+	if err := os.Remove(link); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.Symlink(filepath.Join("..", parentDir), link)
+}
+
+// checkpointChain follows the parent links from the checkpoint in bundlePath
+// and returns the directories of the pre-dump chain it is based on, oldest
+// first. The pre-checkpoint of --with-previous is checked, but not returned.
+func checkpointChain(bundlePath string) ([]string, error) {
+	var chain []string
+	dir := metadata.CheckpointDirectory
+	for {
+		target, err := os.Readlink(filepath.Join(bundlePath, dir, "parent"))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return chain, nil
+			}
+			return nil, err
+		}
+		parent := filepath.Base(target)
+		if parent == dir || slices.Contains(chain, parent) {
+			return nil, fmt.Errorf("pre-dump %s of the checkpoint chain is referenced twice", parent)
+		}
+		if err := fileutils.Exists(filepath.Join(bundlePath, parent, "inventory.img")); err != nil {
+			return nil, fmt.Errorf("pre-dump %s of the checkpoint chain is missing: %w", parent, err)
+		}
+		if parent != preCheckpointDir {
+			chain = slices.Insert(chain, 0, parent)
+		}
+		dir = parent
+	}
+}
+
+// removeCheckpointDirs removes the given directories relative to bundlePath.
+func removeCheckpointDirs(bundlePath string, dirs []string) {
+	for _, dir := range dirs {
+		if err := os.RemoveAll(filepath.Join(bundlePath, dir)); err != nil {
+			logrus.Debugf("Non-fatal: removal of pre-dump directory (%s) failed: %v", dir, err)
+		}
+	}
+}
+
+// removeCheckpointChain removes the pre-dump chain of the checkpoint of the
+// container.
+func (c *Container) removeCheckpointChain() {
+	removeCheckpointDirs(c.bundlePath(), c.state.CheckpointChain)
+	c.state.CheckpointChain = nil
+}
+
+// checkpointChainPaths returns the paths of the pre-dump chain of the
+// checkpoint of the container.
+func (c *Container) checkpointChainPaths() []string {
+	if len(c.state.CheckpointChain) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(c.state.CheckpointChain))
+	for _, dir := range c.state.CheckpointChain {
+		paths = append(paths, filepath.Join(c.bundlePath(), dir))
+	}
+	return paths
+}
@@ -0,0 +1,54 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metadata "github.com/checkpoint-restore/checkpointctl/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointChain(t *testing.T) {
+	bundle := t.TempDir()
+	addImages := func(dir, parent string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(bundle, dir), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(bundle, dir, "inventory.img"), nil, 0o600))
+		if parent != "" {
+			require.NoError(t, relinkCheckpointParent(filepath.Join(bundle, dir), parent))
+		}
+	}
+
+	addImages(metadata.CheckpointDirectory, "")
+	chain, err := checkpointChain(bundle)
+	require.NoError(t, err)
+	assert.Empty(t, chain)
+
+	first := fmt.Sprintf(preDumpChainDir, 1)
+	second := fmt.Sprintf(preDumpChainDir, 2)
+	addImages(first, "")
+	addImages(second, first)
+	require.NoError(t, relinkCheckpointParent(filepath.Join(bundle, metadata.CheckpointDirectory), second))
+	chain, err = checkpointChain(bundle)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first, second}, chain)
+
+	// The pre-checkpoint of --with-previous is checked but not tracked.
+	addImages(preCheckpointDir, "")
+	require.NoError(t, relinkCheckpointParent(filepath.Join(bundle, first), preCheckpointDir))
+	chain, err = checkpointChain(bundle)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first, second}, chain)
+
+	removeCheckpointDirs(bundle, []string{first})
+	_, err = checkpointChain(bundle)
+	assert.ErrorContains(t, err, "pre-dump "+first+" of the checkpoint chain is missing")
+
+	addImages(first, second)
+	_, err = checkpointChain(bundle)
+	assert.ErrorContains(t, err, "referenced twice")
+}
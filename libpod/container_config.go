@@ -317,6 +317,13 @@ type ContainerNetworkConfig struct {
 	NetMode namespaces.NetworkMode `json:"networkMode,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// InterfaceOptions are the MTU and the offloads set on the interfaces
+	// of the container in its bridge networks, e.g. mtu=1400 or tso=off.
+	InterfaceOptions map[string]string `json:"interfaceOptions,omitempty"`
+	// PodNetworkOptOut indicates that the container is part of a pod
+	// sharing its network namespace, but was explicitly given its own
+	// network namespace.
+	PodNetworkOptOut bool `json:"podNetworkOptOut,omitempty"`
 }
 
 // ContainerImageConfig is an embedded sub-config providing image configuration
@@ -424,6 +431,10 @@ type ContainerMiscConfig struct {
 	// PreserveFD is a list of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process.
 	PreserveFD []uint `json:"preserveFd,omitempty"`
+	// ListenSockets are sockets bound on the host and passed to the
+	// container using socket activation, as the first file descriptors
+	// after 0, 1 and 2.
+	ListenSockets []define.ListenSocket `json:"listenSockets,omitempty"`
 	// Timezone is the timezone inside the container.
 	// Local means it has the same timezone as the host machine
 	Timezone string `json:"timezone,omitempty"`
@@ -437,6 +448,9 @@ type ContainerMiscConfig struct {
 	DeviceHostSrc []spec.LinuxDevice `json:"device_host_src,omitempty"`
 	// EnvSecrets are secrets that are set as environment variables
 	EnvSecrets map[string]*secrets.Secret `json:"secret_env,omitempty"`
+	// EnvTemplate is an env file template resolved at container start.
+	// Only the template is stored, never the resolved values.
+	EnvTemplate string `json:"env_template,omitempty"`
 	// InitContainerType specifies if the container is an initcontainer
 	// and if so, what type: always or once are possible non-nil entries
 	InitContainerType string `json:"init_container_type,omitempty"`
@@ -447,6 +461,42 @@ type ContainerMiscConfig struct {
 	MountAllDevices bool `json:"mountAllDevices"`
 	// ReadWriteTmpfs indicates whether all tmpfs should be mounted readonly when in ReadOnly mode
 	ReadWriteTmpfs bool `json:"readWriteTmpfs"`
+	// WatchProcesses indicates whether an event is written for every
+	// process starting or exiting inside the container.
+	WatchProcesses bool `json:"watchProcesses,omitempty"`
+	// ProvisionScript is the content of a script run inside the container
+	// once, on its first start.
+	ProvisionScript string `json:"provisionScript,omitempty"`
+	// OnExitNotify are the notifiers, in the TYPE=TARGET form parsed by
+	// define.ParseExitNotifier, notified when the container exits.
+	OnExitNotify []string `json:"onExitNotify,omitempty"`
+	// OnExitNotifyTemplate is the Go template of the on-exit
+	// notifications. If empty, a default is used.
+	OnExitNotifyTemplate string `json:"onExitNotifyTemplate,omitempty"`
+	// ProxyProfile is the name of the containers.conf proxy profile
+	// injected into the container when it was created.
+	ProxyProfile string `json:"proxyProfile,omitempty"`
+	// SecurityProfile is the security profile enforced on the container
+	// when it was created.
+	SecurityProfile string `json:"securityProfile,omitempty"`
+	// SecurityProfileOverridden is the security profile of containers.conf
+	// overridden by SecurityProfile, if any.
+	SecurityProfileOverridden string `json:"securityProfileOverridden,omitempty"`
+	// Keyring is the keyring mode of the container: private, inherit or
+	// none. If empty, the keyring option of containers.conf is used.
+	Keyring string `json:"keyring,omitempty"`
+	// StartConditions are the conditions on the host, in the TYPE=VALUE
+	// form parsed by define.ParseStartCondition, which must be met before
+	// the container is started.
+	StartConditions []string `json:"startConditions,omitempty"`
+	// StartConditionsTimeout is how long the start conditions are waited
+	// for. Zero waits indefinitely.
+	StartConditionsTimeout time.Duration `json:"startConditionsTimeout,omitempty"`
+	// EffectiveConfig is the snapshot of the containers, engine and network
+	// tables of containers.conf in effect when the container was created,
+	// keyed by their containers.conf names. It is nil for containers
+	// created by older versions of Podman.
+	EffectiveConfig map[string]any `json:"effectiveConfig,omitempty"`
 }
 
 // InfraInherit contains the compatible options inheritable from the infra container
@@ -79,6 +79,12 @@ type ExecConfig struct {
 	// exiting, and the exit command being executed. If set to 0, there is
 	// no delay. If set, ExitCommand must also be set.
 	ExitCommandDelay uint `json:"exitCommandDelay,omitempty"`
+	// ToolsImage is an image whose root filesystem is mounted read-only
+	// in the container for the duration of the exec session, with its
+	// binary directories appended to PATH. This makes tools available in
+	// containers from images without them. The mount point is given by
+	// the PODMAN_TOOLS environment variable of the session.
+	ToolsImage string `json:"toolsImage,omitempty"`
 }
 
 // ExecSession contains information on a single exec session attached to a given
@@ -99,6 +105,18 @@ type ExecSession struct {
 	PID int `json:"pid,omitempty"`
 	// ExitCode is the exit code of the exec session, if it has exited.
 	ExitCode int `json:"exitCode,omitempty"`
+	// OwnerPID is the PID of the Podman process which created the exec
+	// session to run it attached and remove it once it exited. It is 0
+	// for sessions which are kept until they are removed explicitly.
+	// Sessions whose owner died are removed by ReapExecSessions.
+	OwnerPID int `json:"ownerPid,omitempty"`
+	// OwnerStartTime is the start time of the process with OwnerPID, in
+	// clock ticks since boot, so a process reusing the PID is not taken
+	// for the owner. It is 0 if unknown.
+	OwnerStartTime uint64 `json:"ownerStartTime,omitempty"`
+	// StartedAt is the time the process of the exec session was started.
+	// It is reset when the session is started again.
+	StartedAt time.Time `json:"startedAt,omitempty"`
 
 	// Config is the configuration of this exec session.
 	// Cannot be empty.
@@ -115,6 +133,16 @@ func (e *ExecSession) ContainerID() string {
 	return e.ContainerId
 }
 
+// execSessionFromState returns the exec session with the given ID from the
+// state of the given container. Sessions missing from the state only have
+// their IDs set.
+func execSessionFromState(ctr *Container, id string) *ExecSession {
+	if session, ok := ctr.state.ExecSessions[id]; ok {
+		return session
+	}
+	return &ExecSession{Id: id, ContainerId: ctr.ID()}
+}
+
 // Inspect inspects the given exec session and produces detailed output on its
 // configuration and current state.
 func (e *ExecSession) Inspect() (*define.InspectExecSession, error) {
@@ -161,6 +189,12 @@ type legacyExecSession struct {
 // ExecCreate creates a new exec session for the container.
 // The session is not started. The ID of the new exec session will be returned.
 func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
+	return c.execCreate(config, 0)
+}
+
+// execCreate creates a new exec session owned by the process with the given
+// PID, see ExecSession.OwnerPID.
+func (c *Container) execCreate(config *ExecConfig, ownerPID int) (string, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -180,6 +214,11 @@ func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
 	if config.ExitCommandDelay > 0 && len(config.ExitCommand) == 0 {
 		return "", fmt.Errorf("must provide a non-empty exit command if giving an exit command delay: %w", define.ErrInvalidArg)
 	}
+	if config.ToolsImage != "" {
+		if _, _, err := c.runtime.libimageRuntime.LookupImage(config.ToolsImage, nil); err != nil {
+			return "", fmt.Errorf("looking up tools image: %w", err)
+		}
+	}
 
 	// Verify that we are in a good state to continue
 	if !c.ensureState(define.ContainerStateRunning) {
@@ -208,6 +247,14 @@ func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
 	session.Id = sessionID
 	session.ContainerId = c.ID()
 	session.State = define.ExecStateCreated
+	session.OwnerPID = ownerPID
+	if ownerPID != 0 {
+		startTime, err := processStartTime(ownerPID)
+		if err != nil {
+			logrus.Debugf("Reading start time of exec session owner %d: %v", ownerPID, err)
+		}
+		session.OwnerStartTime = startTime
+	}
 	session.Config = new(ExecConfig)
 	if err := JSONDeepCopy(config, session.Config); err != nil {
 		return "", fmt.Errorf("copying exec configuration into exec session: %w", err)
@@ -280,6 +327,7 @@ func (c *Container) ExecStart(sessionID string) error {
 	// Update and save session to reflect PID/running
 	session.PID = pid
 	session.State = define.ExecStateRunning
+	session.StartedAt = time.Now()
 
 	return c.save()
 }
@@ -337,6 +385,7 @@ func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachS
 	// Update and save session to reflect PID/running
 	session.PID = pid
 	session.State = define.ExecStateRunning
+	session.StartedAt = time.Now()
 
 	if err := c.save(); err != nil {
 		lastErr = err
@@ -507,6 +556,7 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 
 	session.PID = pid
 	session.State = define.ExecStateRunning
+	session.StartedAt = time.Now()
 
 	if err := c.save(); err != nil {
 		lastErr = err
@@ -698,6 +748,11 @@ func (c *Container) ExecRemove(sessionID string, force bool) error {
 		}
 	}
 
+	// The tools image is still mounted if the session failed to start.
+	if err := c.unmountExecTools(session.ID()); err != nil {
+		logrus.Warnf("Unmounting tools of container %s exec session %s: %v", c.ID(), session.ID(), err)
+	}
+
 	// First remove exec session from DB.
 	if err := c.runtime.state.RemoveExecSession(session); err != nil {
 		return err
@@ -765,7 +820,7 @@ func (c *Container) Exec(config *ExecConfig, streams *define.AttachStreams, resi
 // run, and remove an exec session. Returns exit code and error. Exit code is
 // not guaranteed to be set sanely if error is not nil.
 func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resizeChan <-chan resize.TerminalSize, isHealthcheck bool) (exitCode int, retErr error) {
-	sessionID, err := c.ExecCreate(config)
+	sessionID, err := c.execCreate(config, os.Getpid())
 	if err != nil {
 		return -1, err
 	}
@@ -834,6 +889,10 @@ func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resi
 // the container when os.RemoveAll($bundlePath) fails with ENOTEMPTY or EBUSY
 // errors.
 func (c *Container) cleanupExecBundle(sessionID string) (err error) {
+	if err := c.unmountExecTools(sessionID); err != nil {
+		logrus.Warnf("Unmounting tools of container %s exec session %s: %v", c.ID(), sessionID, err)
+	}
+
 	path := c.execBundlePath(sessionID)
 	for attempts := 0; attempts < 50; attempts++ {
 		err = os.RemoveAll(path)
@@ -1026,7 +1085,7 @@ func (c *Container) getActiveExecSessions() ([]string, error) {
 				session.PID = 0
 				session.State = define.ExecStateStopped
 
-				c.newExecDiedEvent(session.ID(), exitCode)
+				c.newExecDiedEvent(session.ID(), exitCode, session.StartedAt)
 
 				needSave = true
 			}
@@ -1052,6 +1111,134 @@ func (c *Container) getActiveExecSessions() ([]string, error) {
 	return activeSessions, lastErr
 }
 
+// reapExecSessions removes the exec sessions of the container whose process
+// exited and whose owner died before removing them, like sessions left by a
+// crashed `podman exec`. Running sessions whose process exited without
+// running their exit command are marked as stopped first, and removed as
+// well if they have no owner. Sessions in dbSessions, the sessions of the
+// container in the database, which are missing from the container's state
+// are removed as well.
+// Returns the IDs of the removed sessions.
+func (c *Container) reapExecSessions(dbSessions []*ExecSession) ([]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return nil, err
+	}
+
+	reaped := []string{}
+	var lastErr error
+	for _, session := range dbSessions {
+		if _, ok := c.state.ExecSessions[session.ID()]; ok {
+			continue
+		}
+		if err := c.runtime.state.RemoveExecSession(session); err != nil {
+			if lastErr != nil {
+				logrus.Errorf("Reaping container %s exec sessions: %v", c.ID(), lastErr)
+			}
+			lastErr = err
+			continue
+		}
+		c.newExecReapedEvent(session.ID())
+		reaped = append(reaped, session.ID())
+	}
+
+	// Instead of saving once per session, do it once at the end.
+	needSave := false
+	for id, session := range c.state.ExecSessions {
+		died := false
+		if session.State == define.ExecStateRunning {
+			alive, err := c.ociRuntime.ExecUpdateStatus(c, id)
+			if err != nil {
+				if lastErr != nil {
+					logrus.Errorf("Reaping container %s exec sessions: %v", c.ID(), lastErr)
+				}
+				lastErr = err
+				continue
+			}
+			if alive {
+				continue
+			}
+			exitCode, err := c.readExecExitCode(id)
+			if err != nil {
+				logrus.Debugf("Reading exit code of container %s exec session %s: %v", c.ID(), id, err)
+			}
+			session.ExitCode = exitCode
+			session.PID = 0
+			session.State = define.ExecStateStopped
+			c.newExecDiedEvent(id, exitCode, session.StartedAt)
+			needSave = true
+			died = true
+
+			if err := c.cleanupExecBundle(id); err != nil {
+				logrus.Errorf("Cleaning up container %s exec session %s: %v", c.ID(), id, err)
+			}
+		}
+
+		if session.OwnerPID == 0 {
+			// Sessions without owner are kept for their exit code to
+			// be read, unless their process died without running the
+			// exit command.
+			if !died {
+				continue
+			}
+			logrus.Infof("Reaping container %s exec session %s whose process exited without cleaning up", c.ID(), id)
+		} else {
+			if execOwnerAlive(session) {
+				continue
+			}
+			logrus.Infof("Reaping container %s exec session %s whose owner %d exited", c.ID(), id, session.OwnerPID)
+		}
+		if err := c.unmountExecTools(id); err != nil {
+			logrus.Warnf("Unmounting tools of container %s exec session %s: %v", c.ID(), id, err)
+		}
+		if err := c.runtime.state.RemoveExecSession(session); err != nil {
+			if lastErr != nil {
+				logrus.Errorf("Reaping container %s exec sessions: %v", c.ID(), lastErr)
+			}
+			lastErr = err
+			continue
+		}
+		delete(c.state.ExecSessions, id)
+		needSave = true
+		c.newExecReapedEvent(id)
+		reaped = append(reaped, id)
+	}
+	if needSave {
+		if err := c.save(); err != nil {
+			if lastErr != nil {
+				logrus.Errorf("Reaping container %s exec sessions: %v", c.ID(), lastErr)
+			}
+			lastErr = err
+		}
+	}
+
+	return reaped, lastErr
+}
+
+// processAlive returns whether a process with the given PID exists.
+func processAlive(pid int) bool {
+	return unix.Kill(pid, 0) != unix.ESRCH
+}
+
+// execOwnerAlive returns whether the owner of the exec session is alive, and
+// not replaced by another process reusing its PID.
+func execOwnerAlive(session *ExecSession) bool {
+	if !processAlive(session.OwnerPID) {
+		return false
+	}
+	if session.OwnerStartTime == 0 {
+		return true
+	}
+	startTime, err := processStartTime(session.OwnerPID)
+	if err != nil {
+		// The process exited since it was found alive.
+		return !errors.Is(err, os.ErrNotExist)
+	}
+	return startTime == session.OwnerStartTime
+}
+
 // removeAllExecSessions stops and removes all the container's exec sessions
 func (c *Container) removeAllExecSessions() error {
 	knownSessions := c.getKnownExecSessions()
@@ -1110,6 +1297,14 @@ func prepareForExec(c *Container, session *ExecSession) (*ExecOptions, error) {
 	opts.ExitCommandDelay = session.Config.ExitCommandDelay
 	opts.Privileged = session.Config.Privileged
 
+	if session.Config.ToolsImage != "" {
+		env, err := c.mountExecTools(session)
+		if err != nil {
+			return nil, err
+		}
+		opts.Env = env
+	}
+
 	return opts, nil
 }
 
@@ -1143,12 +1338,17 @@ func retrieveAndWriteExecExitCode(c *Container, sessionID string) error {
 }
 
 func justWriteExecExitCode(c *Container, sessionID string, exitCode int, emitEvent bool) error {
+	session, ok := c.state.ExecSessions[sessionID]
+
 	// Write an event first
 	if emitEvent {
-		c.newExecDiedEvent(sessionID, exitCode)
+		var startedAt time.Time
+		if ok {
+			startedAt = session.StartedAt
+		}
+		c.newExecDiedEvent(sessionID, exitCode, startedAt)
 	}
 
-	session, ok := c.state.ExecSessions[sessionID]
 	if !ok {
 		// Exec session already removed.
 		logrus.Infof("Container %s exec session %s already removed from database", c.ID(), sessionID)
@@ -0,0 +1,17 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+func (c *Container) mountExecTools(session *ExecSession) (map[string]string, error) {
+	return nil, fmt.Errorf("tools images for exec sessions are not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+func (c *Container) unmountExecTools(sessionID string) error {
+	return nil
+}
@@ -0,0 +1,156 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// execToolsDir is the directory in the container below which the tools
+// images of exec sessions are mounted.
+const execToolsDir = "/run/podman-tools"
+
+// execToolsMountPath returns where the tools image of an exec session is
+// mounted in the container.
+func execToolsMountPath(sessionID string) string {
+	return filepath.Join(execToolsDir, sessionID)
+}
+
+// execToolsFile returns the path of the file recording the ID of the tools
+// image mounted for an exec session. It lives in the exec bundle so the image
+// is unmounted exactly once, whichever way the session ends.
+func (c *Container) execToolsFile(sessionID string) string {
+	return filepath.Join(c.execBundlePath(sessionID), "tools-image")
+}
+
+// mountExecTools mounts the root filesystem of the tools image of an exec
+// session into the mount namespace of the running container, and returns
+// the environment of the session with the tools added to its PATH.
+func (c *Container) mountExecTools(session *ExecSession) (_ map[string]string, retErr error) {
+	img, _, err := c.runtime.libimageRuntime.LookupImage(session.Config.ToolsImage, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Images are mounted without an upper layer, so the tools are read
+	// only.
+	hostPath, err := img.Mount(context.Background(), nil, c.config.MountLabel)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(c.execToolsFile(session.ID()), []byte(img.ID()), 0o600); err != nil {
+		if err := img.Unmount(false); err != nil {
+			logrus.Errorf("Unmounting tools image %s: %v", img.ID(), err)
+		}
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := c.unmountExecTools(session.ID()); err != nil {
+				logrus.Errorf("Unmounting tools of container %s exec session %s: %v", c.ID(), session.ID(), err)
+			}
+		}
+	}()
+
+	// A bind mount cannot cross mount namespaces, but a detached copy of
+	// the mount can be attached in any of them.
+	treeFD, err := unix.OpenTree(unix.AT_FDCWD, hostPath, unix.OPEN_TREE_CLONE|unix.OPEN_TREE_CLOEXEC|unix.AT_RECURSIVE)
+	if err != nil {
+		return nil, fmt.Errorf("cloning mount of tools image: %w", err)
+	}
+	defer unix.Close(treeFD)
+
+	mountPath := execToolsMountPath(session.ID())
+	if err := c.joinMountAndExec(func() error {
+		if err := os.MkdirAll(mountPath, 0o755); err != nil {
+			return err
+		}
+		return unix.MoveMount(treeFD, "", unix.AT_FDCWD, mountPath, unix.MOVE_MOUNT_F_EMPTY_PATH)
+	}); err != nil {
+		return nil, fmt.Errorf("mounting tools image at %s in container: %w", mountPath, err)
+	}
+
+	return c.execToolsEnv(session.Config.Environment, mountPath), nil
+}
+
+// execToolsEnv returns env with the binary directories of the tools mounted
+// at mountPath appended to PATH, so the binaries of the container take
+// precedence.
+func (c *Container) execToolsEnv(env map[string]string, mountPath string) map[string]string {
+	env = maps.Clone(env)
+	if env == nil {
+		env = make(map[string]string)
+	}
+
+	path, ok := env["PATH"]
+	if !ok && c.config.Spec.Process != nil {
+		for _, e := range c.config.Spec.Process.Env {
+			if value, found := strings.CutPrefix(e, "PATH="); found {
+				path = value
+			}
+		}
+	}
+	dirs := make([]string, 0, 5)
+	if path != "" {
+		dirs = append(dirs, path)
+	}
+	for _, dir := range []string{"usr/local/bin", "usr/bin", "bin", "usr/sbin", "sbin"} {
+		dirs = append(dirs, filepath.Join(mountPath, dir))
+	}
+	env["PATH"] = strings.Join(dirs, ":")
+	env["PODMAN_TOOLS"] = mountPath
+	return env
+}
+
+// unmountExecTools removes the tools image of an exec session from the
+// container and unmounts it. It does nothing if no tools image is mounted.
+func (c *Container) unmountExecTools(sessionID string) error {
+	imageID, err := os.ReadFile(c.execToolsFile(sessionID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var errs []error
+	// The mount disappears with the mount namespace of a stopped
+	// container.
+	if c.state.State == define.ContainerStateRunning {
+		mountPath := execToolsMountPath(sessionID)
+		if err := c.joinMountAndExec(func() error {
+			if err := unix.Unmount(mountPath, unix.MNT_DETACH); err != nil && !errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOENT) {
+				return err
+			}
+			if err := os.Remove(mountPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("removing tools from container: %w", err))
+		}
+	}
+
+	img, _, err := c.runtime.libimageRuntime.LookupImage(string(imageID), nil)
+	if err == nil {
+		err = img.Unmount(false)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("unmounting tools image %s: %w", imageID, err))
+	}
+
+	if err := os.Remove(c.execToolsFile(sessionID)); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecToolsEnv(t *testing.T) {
+	c := Container{
+		config: &ContainerConfig{
+			Spec: &spec.Spec{
+				Process: &spec.Process{
+					Env: []string{"HOME=/root", "PATH=/app"},
+				},
+			},
+		},
+	}
+	tools := "/run/podman-tools/abc"
+	toolsPath := "/run/podman-tools/abc/usr/local/bin:/run/podman-tools/abc/usr/bin:/run/podman-tools/abc/bin:/run/podman-tools/abc/usr/sbin:/run/podman-tools/abc/sbin"
+
+	sessionEnv := map[string]string{"A": "b"}
+	env := c.execToolsEnv(sessionEnv, tools)
+	assert.Equal(t, map[string]string{
+		"A":            "b",
+		"PATH":         "/app:" + toolsPath,
+		"PODMAN_TOOLS": tools,
+	}, env)
+	// The configuration of the session must not change.
+	assert.Equal(t, map[string]string{"A": "b"}, sessionEnv)
+
+	// PATH of the session overrides the one of the container.
+	env = c.execToolsEnv(map[string]string{"PATH": "/opt"}, tools)
+	assert.Equal(t, "/opt:"+toolsPath, env["PATH"])
+
+	// Without any PATH, only the tools are searched.
+	c.config.Spec.Process.Env = nil
+	env = c.execToolsEnv(nil, tools)
+	assert.Equal(t, toolsPath, env["PATH"])
+}
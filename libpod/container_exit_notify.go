@@ -0,0 +1,190 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/logs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// exitNotifyLogLines is the number of log lines included in on-exit
+	// notifications.
+	exitNotifyLogLines = 20
+	// exitNotifyTimeout bounds the time spent sending the on-exit
+	// notifications of a container.
+	exitNotifyTimeout = 30 * time.Second
+)
+
+// exitNotifyEmailTemplate is the default template of e-mail notifications.
+// Webhooks and scripts receive the notification as JSON by default.
+const exitNotifyEmailTemplate = `Container {{.Name}} ({{.ID}}) of image {{.Image}} exited with code {{.ExitCode}} at {{.ExitedAt}}.{{if .OOMKilled}}
+It was killed because it ran out of memory.{{end}}
+
+Last log lines:
+{{range .Logs}}{{.}}
+{{end}}`
+
+// exitNotification returns the notification of the exit of the container, or
+// nil if it has no on-exit notifiers or was stopped by the user. It must be
+// called with the container locked, once its exit code has been recorded.
+func (c *Container) exitNotification(ctx context.Context) *define.ExitNotification {
+	if len(c.config.OnExitNotify) == 0 || c.state.StoppedByUser {
+		return nil
+	}
+
+	_, imageName := c.Image()
+	n := &define.ExitNotification{
+		ID:        c.ID(),
+		Name:      c.Name(),
+		Image:     imageName,
+		ExitCode:  c.state.ExitCode,
+		OOMKilled: c.state.OOMKilled,
+		ExitedAt:  c.state.FinishedTime,
+	}
+	lines, err := c.lastLogLines(ctx, exitNotifyLogLines)
+	if err != nil {
+		logrus.Debugf("Reading logs of container %s for its on-exit notification: %v", c.ID(), err)
+	}
+	n.Logs = lines
+	return n
+}
+
+// sendExitNotification sends the notification to all on-exit notifiers of the
+// container. Failures are logged and do not fail the cleanup of the container.
+// The container does not need to be locked.
+func (c *Container) sendExitNotification(ctx context.Context, n *define.ExitNotification) {
+	ctx, cancel := context.WithTimeout(ctx, exitNotifyTimeout)
+	defer cancel()
+
+	for _, s := range c.config.OnExitNotify {
+		notifier, err := define.ParseExitNotifier(s)
+		if err != nil {
+			logrus.Errorf("Container %s: %v", c.ID(), err)
+			continue
+		}
+		if err := c.notifyExit(ctx, notifier, n); err != nil {
+			logrus.Errorf("Sending on-exit notification of container %s to %s: %v", c.ID(), notifier, err)
+			continue
+		}
+		logrus.Debugf("Sent on-exit notification of container %s to %s", c.ID(), notifier)
+	}
+}
+
+// exitNotificationPayload renders the notification for the given notifier
+// with the template of the container.
+func (c *Container) exitNotificationPayload(notifier define.ExitNotifier, n *define.ExitNotification) ([]byte, error) {
+	tmpl := c.config.OnExitNotifyTemplate
+	if tmpl == "" {
+		if notifier.Type != define.ExitNotifyEmail {
+			return json.Marshal(n)
+		}
+		tmpl = exitNotifyEmailTemplate
+	}
+	t, err := define.ParseExitNotifyTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, n); err != nil {
+		return nil, fmt.Errorf("executing on-exit notification template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Container) notifyExit(ctx context.Context, notifier define.ExitNotifier, n *define.ExitNotification) error {
+	payload, err := c.exitNotificationPayload(notifier, n)
+	if err != nil {
+		return err
+	}
+
+	switch notifier.Type {
+	case define.ExitNotifyWebhook:
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, notifier.Target, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook responded with %s", resp.Status)
+		}
+		return nil
+	case define.ExitNotifyScript:
+		cmd := exec.CommandContext(ctx, notifier.Target)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(),
+			"PODMAN_CONTAINER_ID="+n.ID,
+			"PODMAN_CONTAINER_NAME="+n.Name,
+			"PODMAN_EXIT_CODE="+strconv.Itoa(int(n.ExitCode)),
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	case define.ExitNotifyEmail:
+		sendmail, err := exec.LookPath("sendmail")
+		if err != nil {
+			return err
+		}
+		var msg bytes.Buffer
+		fmt.Fprintf(&msg, "To: %s\r\nSubject: Container %s exited with code %d\r\n\r\n", notifier.Target, n.Name, n.ExitCode)
+		msg.Write(payload)
+		cmd := exec.CommandContext(ctx, sendmail, "-i", "--", notifier.Target)
+		cmd.Stdin = &msg
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown on-exit notifier type %q: %w", notifier.Type, define.ErrInvalidArg)
+	}
+}
+
+// lastLogLines returns the last n lines logged by the container. Lines split
+// into partial log entries are joined.
+func (c *Container) lastLogLines(ctx context.Context, n int) ([]string, error) {
+	logChannel := make(chan *logs.LogLine)
+	options := &logs.LogOptions{
+		Tail:      int64(n),
+		WaitGroup: &sync.WaitGroup{},
+	}
+	if err := c.ReadLog(ctx, options, logChannel, 0); err != nil {
+		return nil, err
+	}
+	go func() {
+		options.WaitGroup.Wait()
+		close(logChannel)
+	}()
+
+	var lines []string
+	partial := false
+	for line := range logChannel {
+		if partial {
+			lines[len(lines)-1] += line.Msg
+		} else {
+			lines = append(lines, line.Msg)
+		}
+		partial = line.Partial()
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
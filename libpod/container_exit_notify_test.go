@@ -0,0 +1,123 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getExitNotifyTestCtr(t *testing.T) *Container {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+
+	ctr.config.LogDriver = define.KubernetesLogging
+	ctr.config.LogPath = filepath.Join(t.TempDir(), "ctr.log")
+	ctr.state.ExitCode = 3
+	ctr.state.FinishedTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return ctr
+}
+
+func TestWithOnExitNotify(t *testing.T) {
+	tests := []struct {
+		notifiers []string
+		tmpl      string
+		err       string
+	}{
+		{[]string{"webhook=https://example.com/hook", "script=/usr/local/bin/alert", "email=ops@example.com"}, "", ""},
+		{[]string{"webhook=ftp://example.com"}, "", "must be an http or https URL"},
+		{[]string{"script=alert"}, "", "must be an absolute path"},
+		{[]string{"email=not an address"}, "", "invalid on-exit e-mail address"},
+		{[]string{"pager=123"}, "", "invalid on-exit notifier type"},
+		{[]string{"webhook"}, "", "must be webhook=URL"},
+		{[]string{"script=/bin/true"}, "{{.ExitCode", "invalid on-exit notification template"},
+	}
+	for _, tt := range tests {
+		ctr := getExitNotifyTestCtr(t)
+		ctr.valid = false
+		err := WithOnExitNotify(tt.notifiers, tt.tmpl)(ctr)
+		if tt.err == "" {
+			assert.NoError(t, err, tt.notifiers)
+			assert.Equal(t, tt.notifiers, ctr.config.OnExitNotify)
+		} else {
+			assert.ErrorContains(t, err, tt.err, tt.notifiers)
+			assert.ErrorIs(t, err, define.ErrInvalidArg, tt.notifiers)
+		}
+	}
+}
+
+func TestExitNotificationLogs(t *testing.T) {
+	ctr := getExitNotifyTestCtr(t)
+	assert.Nil(t, ctr.exitNotification(context.Background()), "no notifiers")
+
+	ctr.config.OnExitNotify = []string{"script=/bin/true"}
+	var log strings.Builder
+	for i := 0; i < exitNotifyLogLines+5; i++ {
+		log.WriteString("2024-01-01T00:00:00.000000000Z stdout F line " + strings.Repeat("x", i) + "\n")
+	}
+	log.WriteString("2024-01-01T00:00:00.000000000Z stderr P fatal \n")
+	log.WriteString("2024-01-01T00:00:00.000000000Z stderr F error\n")
+	require.NoError(t, os.WriteFile(ctr.LogPath(), []byte(log.String()), 0o600))
+
+	n := ctr.exitNotification(context.Background())
+	require.NotNil(t, n)
+	assert.Equal(t, ctr.ID(), n.ID)
+	assert.Equal(t, int32(3), n.ExitCode)
+	require.Len(t, n.Logs, exitNotifyLogLines)
+	assert.Equal(t, "fatal error", n.Logs[len(n.Logs)-1])
+	assert.Equal(t, "line "+strings.Repeat("x", exitNotifyLogLines+4), n.Logs[len(n.Logs)-2])
+
+	ctr.state.StoppedByUser = true
+	assert.Nil(t, ctr.exitNotification(context.Background()), "stopped by user")
+}
+
+func TestSendExitNotification(t *testing.T) {
+	ctr := getExitNotifyTestCtr(t)
+	n := &define.ExitNotification{
+		ID:       ctr.ID(),
+		Name:     ctr.Name(),
+		ExitCode: 3,
+		Logs:     []string{"fatal error"},
+	}
+
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "notify.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\n{ echo \"$PODMAN_CONTAINER_NAME $PODMAN_EXIT_CODE\"; cat; } > "+out+"\n"), 0o755))
+
+	// Webhooks receive JSON by default.
+	ctr.config.OnExitNotify = []string{"webhook=" + server.URL}
+	ctr.sendExitNotification(context.Background(), n)
+	var got define.ExitNotification
+	require.NoError(t, json.Unmarshal(<-received, &got))
+	assert.Equal(t, *n, got)
+
+	// Scripts get the rendered template on their input.
+	ctr.config.OnExitNotify = []string{"script=" + script}
+	ctr.config.OnExitNotifyTemplate = "{{.Name}} died: {{range .Logs}}{{.}}{{end}}"
+	ctr.sendExitNotification(context.Background(), n)
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, ctr.Name()+" 3\n"+ctr.Name()+" died: fatal error", string(content))
+}
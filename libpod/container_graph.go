@@ -5,9 +5,11 @@ package libpod
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/parallel"
 	"github.com/sirupsen/logrus"
 )
 
@@ -41,6 +43,13 @@ func (cg *ContainerGraph) DependencyMap() (dependencies map[*Container][]*Contai
 
 // BuildContainerGraph builds a dependency graph based on the container slice.
 func BuildContainerGraph(ctrs []*Container) (*ContainerGraph, error) {
+	return buildContainerGraph(ctrs, false)
+}
+
+// buildContainerGraph builds a dependency graph based on the container slice.
+// The dependencies which are not in the slice are left out of the graph if
+// ignoreMissing is true, and are an error otherwise.
+func buildContainerGraph(ctrs []*Container, ignoreMissing bool) (*ContainerGraph, error) {
 	graph := new(ContainerGraph)
 	graph.nodes = make(map[string]*containerNode)
 	graph.notDependedOnNodes = make(map[string]*containerNode)
@@ -58,6 +67,12 @@ func BuildContainerGraph(ctrs []*Container) (*ContainerGraph, error) {
 	// Now add edges based on dependencies
 	for _, node := range graph.nodes {
 		deps := node.container.Dependencies()
+		if ignoreMissing {
+			deps = slices.DeleteFunc(deps, func(dep string) bool {
+				_, ok := graph.nodes[dep]
+				return !ok
+			})
+		}
 		for _, dep := range deps {
 			// Get the dep's node
 			depNode, ok := graph.nodes[dep]
@@ -93,6 +108,51 @@ func BuildContainerGraph(ctrs []*Container) (*ContainerGraph, error) {
 	return graph, nil
 }
 
+// dependentsRemovalOrder orders the containers of the graph of dependents of
+// the container root, as returned by State.ContainerDependents, so that each
+// container comes before the containers it depends on. root is not included.
+func dependentsRemovalOrder(root string, edges []StateDependency) ([]string, error) {
+	if len(edges) == 0 {
+		return nil, nil
+	}
+
+	dependents := make(map[string]int)
+	dependencies := make(map[string][]string)
+	for _, edge := range edges {
+		dependents[edge.DependencyID]++
+		dependencies[edge.ID] = append(dependencies[edge.ID], edge.DependencyID)
+		if _, ok := dependents[edge.ID]; !ok {
+			dependents[edge.ID] = 0
+		}
+	}
+
+	var queue []string
+	for id, n := range dependents {
+		if n == 0 {
+			queue = append(queue, id)
+		}
+	}
+	slices.Sort(queue)
+
+	order := make([]string, 0, len(dependents))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, dep := range dependencies[id] {
+			dependents[dep]--
+			if dependents[dep] == 0 && dep != root {
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	if len(order) != len(dependents)-1 {
+		return nil, fmt.Errorf("cycle found in dependency graph of container %s: %w", root, define.ErrInternal)
+	}
+	return order, nil
+}
+
 // Detect cycles in a container graph using Tarjan's strongly connected
 // components algorithm
 // Return true if a cycle is found, false otherwise
@@ -192,47 +252,98 @@ func detectCycles(graph *ContainerGraph) (bool, error) {
 	return false, nil
 }
 
-// Visit a node on a container graph and start the container, or set an error if
-// a dependency failed to start. if restart is true, startNode will restart the node instead of starting it.
-func startNode(ctx context.Context, node *containerNode, setError bool, ctrErrors map[string]error, ctrsVisited map[string]bool, restart bool) {
-	// First, check if we have already visited the node
-	if ctrsVisited[node.id] {
-		return
+// walkGraph calls fn on every node of the graph, on at most parallelism nodes
+// concurrently, or the number of parallel jobs if parallelism is 0. fn is
+// called on a node once it returned for all the dependencies of the node, or
+// for all the containers depending on it if reverse is true. If fn failed for
+// one of these and skip is not nil, fn is not called on the node, which fails
+// with the error returned by skip instead.
+// The errors of the nodes are returned, mapped by container ID.
+func walkGraph(graph *ContainerGraph, parallelism uint, reverse bool, fn func(*containerNode) error, skip func(*containerNode) error) map[string]error {
+	if parallelism == 0 {
+		parallelism = parallel.GetMaxThreads()
+	}
+	waitsFor := func(node *containerNode) []*containerNode {
+		if reverse {
+			return node.dependedOn
+		}
+		return node.dependsOn
+	}
+	unblocks := func(node *containerNode) []*containerNode {
+		if reverse {
+			return node.dependsOn
+		}
+		return node.dependedOn
 	}
 
-	// If setError is true, a dependency of us failed
-	// Mark us as failed and recurse
-	if setError {
-		// Mark us as visited, and set an error
-		ctrsVisited[node.id] = true
-		ctrErrors[node.id] = fmt.Errorf("a dependency of container %s failed to start: %w", node.id, define.ErrCtrStateInvalid)
-
-		// Hit anyone who depends on us, and set errors on them too
-		for _, successor := range node.dependedOn {
-			startNode(ctx, successor, true, ctrErrors, ctrsVisited, restart)
+	var ready []*containerNode
+	pending := make(map[string]int, len(graph.nodes))
+	for id, node := range graph.nodes {
+		pending[id] = len(waitsFor(node))
+		if pending[id] == 0 {
+			ready = append(ready, node)
 		}
+	}
 
-		return
+	ctrErrors := make(map[string]error)
+	var finish func(node *containerNode, err error)
+	finish = func(node *containerNode, err error) {
+		if err != nil {
+			ctrErrors[node.id] = err
+		}
+		for _, next := range unblocks(node) {
+			pending[next.id]--
+			if pending[next.id] > 0 {
+				continue
+			}
+			if skip != nil && slices.ContainsFunc(waitsFor(next), func(n *containerNode) bool { return ctrErrors[n.id] != nil }) {
+				finish(next, skip(next))
+				continue
+			}
+			ready = append(ready, next)
+		}
 	}
 
-	// Have all our dependencies started?
-	// If not, don't visit the node yet
-	depsVisited := true
-	for _, dep := range node.dependsOn {
-		depsVisited = depsVisited && ctrsVisited[dep.id]
+	type result struct {
+		node *containerNode
+		err  error
 	}
-	if !depsVisited {
-		// Don't visit us yet, all dependencies are not up
-		// We'll hit the dependencies eventually, and when we do it will
-		// recurse here
-		return
+	results := make(chan result)
+	running := uint(0)
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && running < parallelism {
+			node := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				results <- result{node: node, err: fn(node)}
+			}()
+		}
+		res := <-results
+		running--
+		finish(res.node, res.err)
 	}
+	return ctrErrors
+}
 
-	// Going to try to start the container, mark us as visited
-	ctrsVisited[node.id] = true
-
-	ctrErrored := false
+// startGraph starts the containers of the graph, or restarts them if restart
+// is true, each once its dependencies started, with at most parallelism
+// containers started concurrently, or the number of parallel jobs if
+// parallelism is 0. Containers whose dependencies failed to start are not
+// started.
+// The errors of the containers are returned, mapped by container ID.
+func startGraph(ctx context.Context, graph *ContainerGraph, parallelism uint, restart bool) map[string]error {
+	return walkGraph(graph, parallelism, false, func(node *containerNode) error {
+		return node.start(ctx, restart)
+	}, func(node *containerNode) error {
+		return fmt.Errorf("a dependency of container %s failed to start: %w", node.id, define.ErrCtrStateInvalid)
+	})
+}
 
+// start starts the container of the node, or restarts it if restart is true,
+// unless it is an init container. A container which is running is not started
+// again.
+func (node *containerNode) start(ctx context.Context, restart bool) error {
 	// Check if dependencies are running
 	// Graph traversal means we should have started them
 	// But they could have died before we got here
@@ -240,48 +351,31 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 	// the dependencies
 	depsStopped, err := node.container.checkDependenciesRunning()
 	if err != nil {
-		ctrErrors[node.id] = err
-		ctrErrored = true
+		return err
 	} else if len(depsStopped) > 0 {
 		// Our dependencies are not running
 		depsList := strings.Join(depsStopped, ",")
-		ctrErrors[node.id] = fmt.Errorf("the following dependencies of container %s are not running: %s: %w", node.id, depsList, define.ErrCtrStateInvalid)
-		ctrErrored = true
+		return fmt.Errorf("the following dependencies of container %s are not running: %s: %w", node.id, depsList, define.ErrCtrStateInvalid)
 	}
 
-	// Lock before we start
 	node.container.lock.Lock()
+	defer node.container.lock.Unlock()
 
 	// Sync the container to pick up current state
-	if !ctrErrored {
-		if err := node.container.syncContainer(); err != nil {
-			ctrErrored = true
-			ctrErrors[node.id] = err
-		}
+	if err := node.container.syncContainer(); err != nil {
+		return err
 	}
 
-	// Start the container (only if it is not running)
-	if !ctrErrored && len(node.container.config.InitContainerType) < 1 {
-		if !restart && node.container.state.State != define.ContainerStateRunning {
-			if err := node.container.initAndStart(ctx); err != nil {
-				ctrErrored = true
-				ctrErrors[node.id] = err
-			}
-		}
-		if restart && node.container.state.State != define.ContainerStatePaused && node.container.state.State != define.ContainerStateUnknown {
-			if err := node.container.restartWithTimeout(ctx, node.container.config.StopTimeout); err != nil {
-				ctrErrored = true
-				ctrErrors[node.id] = err
-			}
-		}
+	if len(node.container.config.InitContainerType) > 0 {
+		return nil
 	}
-
-	node.container.lock.Unlock()
-
-	// Recurse to anyone who depends on us and start them
-	for _, successor := range node.dependedOn {
-		startNode(ctx, successor, ctrErrored, ctrErrors, ctrsVisited, restart)
+	if !restart && node.container.state.State != define.ContainerStateRunning {
+		return node.container.initAndStart(ctx)
+	}
+	if restart && node.container.state.State != define.ContainerStatePaused && node.container.state.State != define.ContainerStateUnknown {
+		return node.container.restartWithTimeout(ctx, node.container.config.StopTimeout)
 	}
+	return nil
 }
 
 // Visit a node on the container graph and remove it, or set an error if it
@@ -3,10 +3,18 @@
 package libpod
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/lock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildContainerGraphNoCtrsIsEmpty(t *testing.T) {
@@ -88,6 +96,33 @@ func TestBuildContainerGraphTwoCtrOneEdge(t *testing.T) {
 	assert.Equal(t, ctr2.ID(), graph.notDependedOnNodes[ctr2.ID()].id)
 }
 
+func TestBuildContainerGraphMissingDependency(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		t.Fatalf("Error setting up locks: %v", err)
+	}
+
+	ctr1, err := getTestCtr1(manager)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	assert.NoError(t, err)
+	ctr3, err := getTestCtrN("3", manager)
+	assert.NoError(t, err)
+	ctr2.config.UserNsCtr = ctr1.config.ID
+	ctr3.config.NetNsCtr = ctr2.config.ID
+
+	_, err = BuildContainerGraph([]*Container{ctr2, ctr3})
+	assert.ErrorIs(t, err, define.ErrNoSuchCtr)
+
+	graph, err := buildContainerGraph([]*Container{ctr2, ctr3}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(graph.nodes))
+	assert.Equal(t, 1, len(graph.noDepNodes))
+	assert.Equal(t, ctr2.ID(), graph.noDepNodes[0].id)
+	assert.Equal(t, 1, len(graph.notDependedOnNodes))
+	assert.Equal(t, ctr3.ID(), graph.notDependedOnNodes[ctr3.ID()].id)
+}
+
 func TestBuildContainerGraphTwoCtrCycle(t *testing.T) {
 	manager, err := lock.NewInMemoryManager(16)
 	if err != nil {
@@ -311,3 +346,134 @@ func TestBuildContainerGraphFourContainersNoneInCycle(t *testing.T) {
 	assert.Equal(t, 2, len(graph.noDepNodes))
 	assert.Equal(t, 2, len(graph.notDependedOnNodes))
 }
+
+func TestDependentsRemovalOrder(t *testing.T) {
+	order, err := dependentsRemovalOrder("1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, order)
+
+	// 2 and 3 depend on 1, 4 depends on 2 and 3, 5 depends on 4 and 1.
+	order, err = dependentsRemovalOrder("1", []StateDependency{
+		{ID: "2", DependencyID: "1"},
+		{ID: "3", DependencyID: "1"},
+		{ID: "4", DependencyID: "2"},
+		{ID: "4", DependencyID: "3"},
+		{ID: "5", DependencyID: "4"},
+		{ID: "5", DependencyID: "1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5", "4", "2", "3"}, order)
+
+	_, err = dependentsRemovalOrder("1", []StateDependency{
+		{ID: "2", DependencyID: "1"},
+		{ID: "3", DependencyID: "2"},
+		{ID: "2", DependencyID: "3"},
+	})
+	assert.ErrorIs(t, err, define.ErrInternal)
+}
+
+// getTestGraph returns the graph of four containers where the second and the
+// third depend on the first, and the fourth depends on the second.
+func getTestGraph(t *testing.T) (*ContainerGraph, []*Container) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	ctrs := make([]*Container, 4)
+	for i := range ctrs {
+		ctrs[i], err = getTestCtrN(strconv.Itoa(i+1), manager)
+		require.NoError(t, err)
+	}
+	ctrs[1].config.UserNsCtr = ctrs[0].ID()
+	ctrs[2].config.UserNsCtr = ctrs[0].ID()
+	ctrs[3].config.IPCNsCtr = ctrs[1].ID()
+
+	graph, err := BuildContainerGraph(ctrs)
+	require.NoError(t, err)
+	return graph, ctrs
+}
+
+func TestWalkGraph(t *testing.T) {
+	graph, ctrs := getTestGraph(t)
+
+	var lock sync.Mutex
+	var order []string
+	var running, maxRunning int
+	// The second and the third containers only return once both started,
+	// which requires them to run concurrently.
+	var independent sync.WaitGroup
+	independent.Add(2)
+	ctrErrors := walkGraph(graph, 2, false, func(node *containerNode) error {
+		lock.Lock()
+		order = append(order, node.id)
+		running++
+		maxRunning = max(maxRunning, running)
+		lock.Unlock()
+		if node.id == ctrs[1].ID() || node.id == ctrs[2].ID() {
+			independent.Done()
+			done := make(chan struct{})
+			go func() {
+				independent.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				return errors.New("independent containers were not run concurrently")
+			}
+		}
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	}, nil)
+	assert.Empty(t, ctrErrors)
+	assert.Equal(t, 2, maxRunning)
+	require.Len(t, order, 4)
+	assert.Equal(t, ctrs[0].ID(), order[0])
+	assert.Equal(t, ctrs[3].ID(), order[3])
+}
+
+func TestWalkGraphReverse(t *testing.T) {
+	graph, ctrs := getTestGraph(t)
+
+	var order []string
+	ctrErrors := walkGraph(graph, 1, true, func(node *containerNode) error {
+		order = append(order, node.id)
+		return nil
+	}, nil)
+	assert.Empty(t, ctrErrors)
+	require.Len(t, order, 4)
+	assert.Equal(t, ctrs[0].ID(), order[3])
+	assert.Less(t, slices.Index(order, ctrs[3].ID()), slices.Index(order, ctrs[1].ID()))
+}
+
+func TestWalkGraphErrors(t *testing.T) {
+	graph, ctrs := getTestGraph(t)
+
+	failed := errors.New("failed")
+	var called []string
+	ctrErrors := walkGraph(graph, 1, false, func(node *containerNode) error {
+		called = append(called, node.id)
+		if node.id == ctrs[1].ID() {
+			return failed
+		}
+		return nil
+	}, func(node *containerNode) error {
+		return fmt.Errorf("a dependency of container %s failed: %w", node.id, define.ErrCtrStateInvalid)
+	})
+	assert.ElementsMatch(t, []string{ctrs[0].ID(), ctrs[1].ID(), ctrs[2].ID()}, called)
+	assert.Len(t, ctrErrors, 2)
+	assert.ErrorIs(t, ctrErrors[ctrs[1].ID()], failed)
+	assert.ErrorIs(t, ctrErrors[ctrs[3].ID()], define.ErrCtrStateInvalid)
+
+	// Without skip, the containers depending on a failed one are still
+	// walked.
+	called = nil
+	ctrErrors = walkGraph(graph, 0, false, func(node *containerNode) error {
+		if node.id == ctrs[0].ID() {
+			return failed
+		}
+		return nil
+	}, nil)
+	assert.Len(t, ctrErrors, 1)
+	assert.ErrorIs(t, ctrErrors[ctrs[0].ID()], failed)
+}
@@ -17,26 +17,70 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// inspectSections selects the top-level fields of the inspect data which are
+// computed. A nil selection computes all of them.
+type inspectSections map[string]bool
+
+// want returns whether any of the given top-level fields is selected.
+func (s inspectSections) want(fields ...string) bool {
+	if s == nil {
+		return true
+	}
+	for _, field := range fields {
+		if s[field] {
+			return true
+		}
+	}
+	return false
+}
+
 // inspectLocked inspects a container for low-level information.
 // The caller must held c.lock.
 func (c *Container) inspectLocked(size bool) (*define.InspectContainerData, error) {
-	storeCtr, err := c.runtime.store.Container(c.ID())
-	if err != nil {
-		return nil, fmt.Errorf("getting container from store %q: %w", c.ID(), err)
-	}
-	layer, err := c.runtime.store.Layer(storeCtr.LayerID)
-	if err != nil {
-		return nil, fmt.Errorf("reading information about layer %q: %w", storeCtr.LayerID, err)
-	}
-	driverData, err := driver.GetDriverData(c.runtime.store, layer.ID)
-	if err != nil {
-		return nil, fmt.Errorf("getting graph driver info %q: %w", c.ID(), err)
+	return c.inspectSectionsLocked(size, nil)
+}
+
+// inspectSectionsLocked inspects a container for the selected top-level
+// fields. The caller must held c.lock.
+func (c *Container) inspectSectionsLocked(size bool, sections inspectSections) (*define.InspectContainerData, error) {
+	var driverData *define.DriverData
+	if sections.want("Driver", "GraphDriver") {
+		storeCtr, err := c.runtime.store.Container(c.ID())
+		if err != nil {
+			return nil, fmt.Errorf("getting container from store %q: %w", c.ID(), err)
+		}
+		layer, err := c.runtime.store.Layer(storeCtr.LayerID)
+		if err != nil {
+			return nil, fmt.Errorf("reading information about layer %q: %w", storeCtr.LayerID, err)
+		}
+		driverData, err = driver.GetDriverData(c.runtime.store, layer.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting graph driver info %q: %w", c.ID(), err)
+		}
 	}
-	return c.getContainerInspectData(size, driverData)
+	return c.getContainerInspectData(size, driverData, sections)
 }
 
 // Inspect a container for low-level information
 func (c *Container) Inspect(size bool) (*define.InspectContainerData, error) {
+	return c.InspectFields(size, nil)
+}
+
+// InspectFields inspects a container for low-level information, computing
+// only the top-level fields of the given dot-separated field paths, e.g. State
+// for State.Status. Expensive fields like the sizes and the graph driver data
+// are skipped unless requested. All fields are computed if none are given.
+func (c *Container) InspectFields(size bool, fields []string) (*define.InspectContainerData, error) {
+	var sections inspectSections
+	if len(fields) > 0 {
+		sections = make(inspectSections, len(fields))
+		for _, field := range fields {
+			top, _, _ := strings.Cut(field, ".")
+			sections[top] = true
+		}
+		size = sections.want("SizeRw", "SizeRootFs", "SizeComputedAt")
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -46,7 +90,7 @@ func (c *Container) Inspect(size bool) (*define.InspectContainerData, error) {
 		}
 	}
 
-	return c.inspectLocked(size)
+	return c.inspectSectionsLocked(size, sections)
 }
 
 func (c *Container) volumesFrom() ([]string, error) {
@@ -60,7 +104,7 @@ func (c *Container) volumesFrom() ([]string, error) {
 	return nil, nil
 }
 
-func (c *Container) getContainerInspectData(size bool, driverData *define.DriverData) (*define.InspectContainerData, error) {
+func (c *Container) getContainerInspectData(size bool, driverData *define.DriverData, sections inspectSections) (*define.InspectContainerData, error) {
 	config := c.config
 	runtimeInfo := c.state
 	ctrSpec, err := c.specFromState()
@@ -102,18 +146,24 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 	}
 
 	namedVolumes, mounts := c.SortUserVolumes(ctrSpec)
-	inspectMounts, err := c.GetMounts(namedVolumes, c.config.ImageVolumes, mounts)
-	if err != nil {
-		return nil, err
+	var inspectMounts []define.InspectMount
+	if sections.want("Mounts") {
+		inspectMounts, err = c.GetMounts(namedVolumes, c.config.ImageVolumes, mounts)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	cgroupPath, err := c.cGroupPath()
-	if err != nil {
-		// Handle the case where the container is not running or has no cgroup.
-		if errors.Is(err, define.ErrNoCgroups) || errors.Is(err, define.ErrCtrStopped) {
-			cgroupPath = ""
-		} else {
-			return nil, err
+	var cgroupPath string
+	if sections.want("State") {
+		cgroupPath, err = c.cGroupPath()
+		if err != nil {
+			// Handle the case where the container is not running or has no cgroup.
+			if errors.Is(err, define.ErrNoCgroups) || errors.Is(err, define.ErrCtrStopped) {
+				cgroupPath = ""
+			} else {
+				return nil, err
+			}
 		}
 	}
 
@@ -123,33 +173,36 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		Path:    path,
 		Args:    args,
 		State: &define.InspectContainerState{
-			OciVersion:     ctrSpec.Version,
-			Status:         runtimeInfo.State.String(),
-			Running:        runtimeInfo.State == define.ContainerStateRunning,
-			Paused:         runtimeInfo.State == define.ContainerStatePaused,
-			OOMKilled:      runtimeInfo.OOMKilled,
-			Dead:           runtimeInfo.State.String() == "bad state",
-			Pid:            runtimeInfo.PID,
-			ConmonPid:      runtimeInfo.ConmonPID,
-			ExitCode:       runtimeInfo.ExitCode,
-			Error:          runtimeInfo.Error,
-			StartedAt:      runtimeInfo.StartedTime,
-			FinishedAt:     runtimeInfo.FinishedTime,
-			Checkpointed:   runtimeInfo.Checkpointed,
-			CgroupPath:     cgroupPath,
-			RestoredAt:     runtimeInfo.RestoredTime,
-			CheckpointedAt: runtimeInfo.CheckpointedTime,
-			Restored:       runtimeInfo.Restored,
-			CheckpointPath: runtimeInfo.CheckpointPath,
-			CheckpointLog:  runtimeInfo.CheckpointLog,
-			RestoreLog:     runtimeInfo.RestoreLog,
-			StoppedByUser:  c.state.StoppedByUser,
+			OciVersion:      ctrSpec.Version,
+			Status:          runtimeInfo.State.String(),
+			Running:         runtimeInfo.State == define.ContainerStateRunning,
+			Paused:          runtimeInfo.State == define.ContainerStatePaused,
+			OOMKilled:       runtimeInfo.OOMKilled,
+			Dead:            runtimeInfo.State.String() == "bad state",
+			Pid:             runtimeInfo.PID,
+			ConmonPid:       runtimeInfo.ConmonPID,
+			ExitCode:        runtimeInfo.ExitCode,
+			Error:           runtimeInfo.Error,
+			StartedAt:       runtimeInfo.StartedTime,
+			FinishedAt:      runtimeInfo.FinishedTime,
+			Checkpointed:    runtimeInfo.Checkpointed,
+			CgroupPath:      cgroupPath,
+			RestoredAt:      runtimeInfo.RestoredTime,
+			CheckpointedAt:  runtimeInfo.CheckpointedTime,
+			Restored:        runtimeInfo.Restored,
+			CheckpointPath:  runtimeInfo.CheckpointPath,
+			CheckpointLog:   runtimeInfo.CheckpointLog,
+			CheckpointChain: c.checkpointChainPaths(),
+			RestoreLog:      runtimeInfo.RestoreLog,
+			StoppedByUser:   c.state.StoppedByUser,
+			Provisioned:     c.state.Provisioned,
 		},
 		Image:                   config.RootfsImageID,
 		ImageName:               config.RootfsImageName,
 		Namespace:               config.Namespace,
 		Rootfs:                  config.Rootfs,
 		Pod:                     config.Pod,
+		PodNetworkOptOut:        config.PodNetworkOptOut,
 		ResolvConfPath:          resolvPath,
 		HostnamePath:            hostnamePath,
 		HostsPath:               hostsPath,
@@ -159,12 +212,10 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		PidFile:                 config.PidFile,
 		Name:                    config.Name,
 		RestartCount:            int32(runtimeInfo.RestartCount),
-		Driver:                  driverData.Name,
 		MountLabel:              config.MountLabel,
 		ProcessLabel:            config.ProcessLabel,
 		AppArmorProfile:         ctrSpec.Process.ApparmorProfile,
 		ExecIDs:                 execIDs,
-		GraphDriver:             driverData,
 		Mounts:                  inspectMounts,
 		Dependencies:            c.Dependencies(),
 		IsInfra:                 c.IsInfra(),
@@ -173,7 +224,19 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		LockNumber:              c.lock.ID(),
 	}
 
-	if config.RootfsImageID != "" { // May not be set if the container was created with --rootfs
+	if sections.want("ExitHistory") {
+		data.ExitHistory, err = c.runtime.state.ContainerExitHistory(c.ID())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if driverData != nil {
+		data.Driver = driverData.Name
+		data.GraphDriver = driverData
+	}
+
+	if config.RootfsImageID != "" && sections.want("ImageDigest") { // May not be set if the container was created with --rootfs
 		image, _, err := c.runtime.libimageRuntime.LookupImage(config.RootfsImageID, nil)
 		if err != nil {
 			return nil, err
@@ -190,49 +253,60 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		data.OCIConfigPath = c.state.ConfigPath
 	}
 
-	// Check if healthcheck is not nil and --no-healthcheck option is not set.
-	// If --no-healthcheck is set Test will be always set to `[NONE]`, so the
-	// inspect status should be set to nil.
-	if c.config.HealthCheckConfig != nil && !(len(c.config.HealthCheckConfig.Test) == 1 && c.config.HealthCheckConfig.Test[0] == "NONE") {
-		// This container has a healthcheck defined in it; we need to add its state
-		healthCheckState, err := c.getHealthCheckLog()
-		if err != nil {
-			// An error here is not considered fatal; no health state will be displayed
-			logrus.Error(err)
+	if sections.want("State") {
+		fillCgroupInspect(data.State)
+		if data.State.Running && c.Keyring() != define.KeyringInherit {
+			data.State.KeyringKeys = keyringKeys(c.ID())
+		}
+
+		// Check if healthcheck is not nil and --no-healthcheck option is not set.
+		// If --no-healthcheck is set Test will be always set to `[NONE]`, so the
+		// inspect status should be set to nil.
+		if c.config.HealthCheckConfig != nil && !(len(c.config.HealthCheckConfig.Test) == 1 && c.config.HealthCheckConfig.Test[0] == "NONE") {
+			// This container has a healthcheck defined in it; we need to add its state
+			healthCheckState, err := c.getHealthCheckLog()
+			if err != nil {
+				// An error here is not considered fatal; no health state will be displayed
+				logrus.Error(err)
+			} else {
+				data.State.Health = &healthCheckState
+			}
 		} else {
-			data.State.Health = &healthCheckState
+			data.State.Health = nil
 		}
-	} else {
-		data.State.Health = nil
 	}
 
-	networkConfig, err := c.getContainerNetworkInfo()
-	if err != nil {
-		return nil, err
+	if sections.want("NetworkSettings") {
+		networkConfig, err := c.getContainerNetworkInfo()
+		if err != nil {
+			return nil, err
+		}
+		data.NetworkSettings = networkConfig
 	}
-	data.NetworkSettings = networkConfig
 
-	inspectConfig := c.generateInspectContainerConfig(ctrSpec)
-	data.Config = inspectConfig
-
-	hostConfig, err := c.generateInspectContainerHostConfig(ctrSpec, namedVolumes, mounts)
-	if err != nil {
-		return nil, err
+	if sections.want("Config") {
+		inspectConfig := c.generateInspectContainerConfig(ctrSpec)
+		data.Config = inspectConfig
 	}
-	data.HostConfig = hostConfig
 
-	if size {
-		rootFsSize, err := c.rootFsSize()
+	if sections.want("HostConfig") {
+		hostConfig, err := c.generateInspectContainerHostConfig(ctrSpec, namedVolumes, mounts)
 		if err != nil {
-			logrus.Errorf("Getting rootfs size %q: %v", config.ID, err)
+			return nil, err
 		}
-		data.SizeRootFs = rootFsSize
+		data.HostConfig = hostConfig
+	}
 
-		rwSize, err := c.rwSize()
+	if size {
+		sizes, err := c.size()
 		if err != nil {
-			logrus.Errorf("Getting rw size %q: %v", config.ID, err)
+			logrus.Errorf("Getting size %q: %v", config.ID, err)
 		}
+		rwSize := sizes.RWSize
+		computedAt := sizes.ComputedAt
+		data.SizeRootFs = sizes.RootFsSize
 		data.SizeRw = &rwSize
+		data.SizeComputedAt = &computedAt
 	}
 	return data, nil
 }
@@ -279,6 +353,8 @@ func (c *Container) GetMounts(namedVolumes []*ContainerNamedVolume, imageVolumes
 		mountStruct.Destination = volume.Dest
 		mountStruct.Source = volume.Source
 		mountStruct.RW = volume.ReadWrite
+		// The changes of persistent image volumes are kept in a volume.
+		mountStruct.Name = volume.UpperVolume
 
 		inspectMounts = append(inspectMounts, mountStruct)
 	}
@@ -412,6 +488,17 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 
 	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction.String()
 
+	ctrConfig.OnExitNotify = c.config.OnExitNotify
+
+	ctrConfig.StartConditions = c.config.StartConditions
+
+	ctrConfig.ProxyProfile = c.config.ProxyProfile
+
+	ctrConfig.SecurityProfile = c.config.SecurityProfile
+	ctrConfig.SecurityProfileOverridden = c.config.SecurityProfileOverridden
+
+	ctrConfig.Keyring = c.Keyring()
+
 	ctrConfig.CreateCommand = c.config.CreateCommand
 
 	ctrConfig.Timezone = c.config.Timezone
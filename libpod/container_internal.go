@@ -60,6 +60,9 @@ const (
 	artifactsDir      = "artifacts"
 	execDirPermission = 0755
 	preCheckpointDir  = "pre-checkpoint"
+	// preDumpChainDir is the directory of the nth pre-dump of a pre-dump
+	// chain.
+	preDumpChainDir = "pre-checkpoint-%d"
 )
 
 // rootFsSize gets the size of the container, which can be divided notionally
@@ -109,6 +112,54 @@ func (c *Container) rwSize() (int64, error) {
 	return layerSize, nil
 }
 
+// size gets the root FS and rw sizes of the container, using the size cache
+// if it is valid. The returned sizes are never nil, but are incomplete if an
+// error is returned.
+func (c *Container) size() (*ContainerSize, error) {
+	if c.state.SizeCache != nil && !c.sizeMayChange() {
+		return c.state.SizeCache, nil
+	}
+
+	size := &ContainerSize{ComputedAt: time.Now()}
+	rootFsSize, rootFsErr := c.rootFsSize()
+	if rootFsErr != nil {
+		rootFsErr = fmt.Errorf("getting rootfs size: %w", rootFsErr)
+	}
+	rwSize, rwErr := c.rwSize()
+	if rwErr != nil {
+		rwErr = fmt.Errorf("getting rw size: %w", rwErr)
+	}
+	size.RootFsSize = rootFsSize
+	size.RWSize = rwSize
+	if err := errors.Join(rootFsErr, rwErr); err != nil {
+		return size, err
+	}
+
+	if !c.sizeMayChange() {
+		c.state.SizeCache = size
+		if err := c.save(); err != nil {
+			logrus.Warnf("Caching size of container %s: %v", c.ID(), err)
+		}
+	}
+	return size, nil
+}
+
+// sizeMayChange returns whether the size of the container's root filesystem
+// may change without libpod noticing, in which case it must not be cached.
+// This is the case while the root filesystem is mounted, or if it is not
+// managed by c/storage at all.
+func (c *Container) sizeMayChange() bool {
+	if c.config.Rootfs != "" || c.runtime.store == nil {
+		return true
+	}
+	mounted, err := c.runtime.storageService.MountedContainerImage(c.ID())
+	if err != nil {
+		logrus.Debugf("Checking whether container %s is mounted: %v", c.ID(), err)
+		return true
+	}
+	return mounted > 0
+}
+
 // bundlePath returns the path to the container's root filesystem - where the OCI spec will be
 // placed, amongst other things
 func (c *Container) bundlePath() string {
@@ -337,6 +388,9 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 	if err := c.start(); err != nil {
 		return false, err
 	}
+	if err := c.provision(); err != nil {
+		return false, err
+	}
 	return true, c.waitForHealthy(ctx)
 }
 
@@ -352,11 +406,81 @@ func (c *Container) ensureState(states ...define.ContainerStatus) bool {
 	return false
 }
 
+// restartAction is how restartByPolicy restarts a container.
+type restartAction int
+
+const (
+	// restartActionNone does not restart the container.
+	restartActionNone restartAction = iota
+	// restartActionCleanup cleans up the stopped container, which handles
+	// its restart policy.
+	restartActionCleanup
+	// restartActionRestart restarts the exited container.
+	restartActionRestart
+	// restartActionStart starts the container and its dependencies after
+	// a reboot.
+	restartActionStart
+)
+
+// restartByPolicyAction returns how the container must be restarted by
+// restartByPolicy. Must be called with the container lock held and the state
+// synced.
+func (c *Container) restartByPolicyAction() restartAction {
+	switch {
+	case c.state.State == define.ContainerStateStopped && c.shouldRestart():
+		return restartActionCleanup
+	case c.state.State == define.ContainerStateExited && c.shouldRestart():
+		return restartActionRestart
+	case c.state.State == define.ContainerStateExited && c.config.RestartPolicy == define.RestartPolicyAlways &&
+		!c.state.StoppedByUser && !c.state.RestartPolicyMatch:
+		return restartActionStart
+	}
+	return restartActionNone
+}
+
+// restartByPolicy restarts the container if it exited and its restart policy
+// requires a restart which did not happen: podman container cleanup, which
+// conmon runs when the container exits, did not run or failed, the restart
+// itself failed, or the host rebooted. Only containers with the always
+// restart policy are restarted after a reboot, as a reboot forgets which
+// containers were stopped by the user. Returns whether a restart was
+// attempted.
+func (c *Container) restartByPolicy(ctx context.Context) (bool, error) {
+	c.lock.Lock()
+	unlock := sync.OnceFunc(c.lock.Unlock)
+	defer unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+
+	switch c.restartByPolicyAction() {
+	case restartActionCleanup:
+		// Cleaning up the container handles its restart policy, as
+		// when conmon runs podman container cleanup.
+		unlock()
+		return true, c.Cleanup(ctx)
+	case restartActionRestart:
+		_, err := c.handleRestartPolicy(ctx)
+		return true, err
+	case restartActionStart:
+		// The dependencies of the container are started as well,
+		// they were stopped by the reboot too.
+		unlock()
+		return true, c.Start(ctx, true)
+	}
+	return false, nil
+}
+
 // Sync this container with on-disk state and runtime status
 // Should only be called with container lock held
 // This function should suffice to ensure a container's state is accurate and
 // it is valid for use.
 func (c *Container) syncContainer() error {
+	// Do not reload the state over changes that were not written yet.
+	if err := c.flushSaves(); err != nil {
+		return err
+	}
 	if err := c.runtime.state.UpdateContainer(c); err != nil {
 		return err
 	}
@@ -658,6 +782,7 @@ func resetContainerState(state *ContainerState) {
 	state.HCUnitName = ""
 	state.NetNS = ""
 	state.NetworkStatus = nil
+	state.NetworkDisturbances = nil
 }
 
 // Refresh refreshes the container's state after a restart.
@@ -808,13 +933,56 @@ func (c *Container) getArtifactPath(name string) string {
 }
 
 // save container state to the database
+// If saves are deferred, the state is only marked dirty and written when the
+// outermost deferSaves() call finishes.
 func (c *Container) save() error {
+	if c.saveDeferrals > 0 {
+		c.stateDirty = true
+		return nil
+	}
+	return c.saveNow()
+}
+
+// saveNow writes the container state to the database even if saves are
+// deferred. It must be used when the state has to be on disk before the next
+// step, e.g. when a process was created that has to be tracked.
+func (c *Container) saveNow() error {
 	if err := c.runtime.state.SaveContainer(c); err != nil {
 		return fmt.Errorf("saving container %s state: %w", c.ID(), err)
 	}
+	c.stateDirty = false
 	return nil
 }
 
+// flushSaves writes the container state to the database if a deferred save
+// left it dirty. It must be called before the container lock is released.
+func (c *Container) flushSaves() error {
+	if !c.stateDirty {
+		return nil
+	}
+	return c.saveNow()
+}
+
+// deferSaves coalesces the saves of the container state done by fn into a
+// single write after it returns, as every write to the database is synced to
+// disk. Calls can be nested, the state is written by the outermost one. The
+// container lock must be held for the whole call.
+func (c *Container) deferSaves(fn func() error) error {
+	c.saveDeferrals++
+	err := fn()
+	c.saveDeferrals--
+	if c.saveDeferrals > 0 {
+		return err
+	}
+	if flushErr := c.flushSaves(); flushErr != nil {
+		if err == nil {
+			return flushErr
+		}
+		logrus.Errorf("Saving container %s state: %v", c.ID(), flushErr)
+	}
+	return err
+}
+
 // Checks the container is in the right state, then initializes the container in preparation to start the container.
 // If recursive is true, each of the container's dependencies will be started.
 // Otherwise, this function will return with error if there are dependencies of this container that aren't running.
@@ -829,6 +997,10 @@ func (c *Container) prepareToStart(ctx context.Context, recursive bool) (retErr
 		return fmt.Errorf("container %s must be in Created or Stopped state to be started: %w", c.ID(), define.ErrCtrStateInvalid)
 	}
 
+	if err := c.waitForStartConditions(ctx); err != nil {
+		return err
+	}
+
 	if !recursive {
 		if err := c.checkDependenciesAndHandleError(); err != nil {
 			return err
@@ -914,14 +1086,8 @@ func (c *Container) startDependencies(ctx context.Context) error {
 		return fmt.Errorf("all dependencies have dependencies of %s: %w", c.ID(), define.ErrNoSuchCtr)
 	}
 
-	ctrErrors := make(map[string]error)
-	ctrsVisited := make(map[string]bool)
-
-	// Traverse the graph beginning at nodes with no dependencies
-	for _, node := range graph.noDepNodes {
-		startNode(ctx, node, false, ctrErrors, ctrsVisited, true)
-	}
-
+	// Start the graph beginning at nodes with no dependencies
+	ctrErrors := startGraph(ctx, graph, 0, true)
 	if len(ctrErrors) > 0 {
 		logrus.Errorf("Starting some container dependencies")
 		for _, e := range ctrErrors {
@@ -933,38 +1099,48 @@ func (c *Container) startDependencies(ctx context.Context) error {
 }
 
 // getAllDependencies is a precursor to starting dependencies.
-// To start a container with all of its dependencies, we need to recursively find all dependencies
-// a container has, as well as each of those containers' dependencies, and so on
-// To do so, keep track of containers already visited (so there aren't redundant state lookups),
-// and recursively search until we have reached the leafs of every dependency node.
+// To start a container with all of its dependencies, we need to find all dependencies
+// a container has, as well as each of those containers' dependencies, and so on.
+// The dependency graph is retrieved from the state at once, and walked until we have
+// reached the leafs of every dependency node or a running dependency.
 // Since we need to start all dependencies for our original container to successfully start, we propagate any errors
 // in looking up dependencies.
 // Note: this function is currently meant as a robust solution to a narrow problem: start an infra-container when
 // a container in the pod is run. It has not been tested for performance past one level, so expansion of recursive start
 // must be tested first.
 func (c *Container) getAllDependencies(visited map[string]*Container) error {
-	depIDs := c.Dependencies()
-	if len(depIDs) == 0 {
+	if len(c.Dependencies()) == 0 {
 		return nil
 	}
-	for _, depID := range depIDs {
-		if _, ok := visited[depID]; !ok {
-			dep, err := c.runtime.state.Container(depID)
-			if err != nil {
-				return err
-			}
-			status, err := dep.State()
-			if err != nil {
-				return err
-			}
-			// if the dependency is already running, we can assume its dependencies are also running
-			// so no need to add them to those we need to start
-			if status != define.ContainerStateRunning {
-				visited[depID] = dep
-				if err := dep.getAllDependencies(visited); err != nil {
-					return err
-				}
-			}
+	edges, err := c.runtime.state.ContainerDependencies(c)
+	if err != nil {
+		return err
+	}
+	dependencies := make(map[string][]string)
+	for _, edge := range edges {
+		dependencies[edge.ID] = append(dependencies[edge.ID], edge.DependencyID)
+	}
+
+	queue := slices.Clone(dependencies[c.ID()])
+	for len(queue) > 0 {
+		depID := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[depID]; ok {
+			continue
+		}
+		dep, err := c.runtime.state.Container(depID)
+		if err != nil {
+			return err
+		}
+		status, err := dep.State()
+		if err != nil {
+			return err
+		}
+		// if the dependency is already running, we can assume its dependencies are also running
+		// so no need to add them to those we need to start
+		if status != define.ContainerStateRunning {
+			visited[depID] = dep
+			queue = append(queue, dependencies[depID]...)
 		}
 	}
 	return nil
@@ -1039,6 +1215,10 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		return err
 	}
 
+	if err := c.checkImagePlatform(ctx); err != nil {
+		return err
+	}
+
 	// Generate the OCI newSpec
 	newSpec, cleanupFunc, err := c.generateSpec(ctx)
 	if err != nil {
@@ -1126,7 +1306,9 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		}
 	}
 
-	if err := c.save(); err != nil {
+	// Conmon's PID must be on disk before shutdown signals are handled
+	// again, so the state is written even if saves are deferred.
+	if err := c.saveNow(); err != nil {
 		return err
 	}
 
@@ -1233,26 +1415,31 @@ func (c *Container) initAndStart(ctx context.Context) (retErr error) {
 		}
 	}()
 
-	if err := c.prepare(); err != nil {
-		return err
-	}
-
-	// If we are ContainerStateStopped we need to remove from runtime
-	// And reset to ContainerStateConfigured
-	if c.state.State == define.ContainerStateStopped {
-		logrus.Debugf("Recreating container %s in OCI runtime", c.ID())
-
-		if err := c.reinit(ctx, false); err != nil {
+	if err := c.deferSaves(func() error {
+		if err := c.prepare(); err != nil {
 			return err
 		}
-	} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
-		if err := c.init(ctx, false); err != nil {
-			return err
+
+		// If we are ContainerStateStopped we need to remove from runtime
+		// And reset to ContainerStateConfigured
+		if c.state.State == define.ContainerStateStopped {
+			logrus.Debugf("Recreating container %s in OCI runtime", c.ID())
+
+			if err := c.reinit(ctx, false); err != nil {
+				return err
+			}
+		} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
+			if err := c.init(ctx, false); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Now start the container
-	if err := c.start(); err != nil {
+		// Now start the container
+		return c.start()
+	}); err != nil {
+		return err
+	}
+	if err := c.provision(); err != nil {
 		return err
 	}
 	return c.waitForHealthy(ctx)
@@ -1300,6 +1487,12 @@ func (c *Container) start() error {
 
 	c.newContainerEvent(events.Start)
 
+	if c.config.WatchProcesses {
+		if err := c.startProcessWatcher(); err != nil {
+			logrus.Errorf("Watching processes of container %s: %v", c.ID(), err)
+		}
+	}
+
 	return c.save()
 }
 
@@ -1609,23 +1802,28 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 			}
 		}
 	}()
-	if err := c.prepare(); err != nil {
-		return err
-	}
-
-	if c.state.State == define.ContainerStateStopped {
-		// Reinitialize the container if we need to
-		if err := c.reinit(ctx, false); err != nil {
+	if err := c.deferSaves(func() error {
+		if err := c.prepare(); err != nil {
 			return err
 		}
-	} else if c.state.State == define.ContainerStateConfigured ||
-		c.state.State == define.ContainerStateExited {
-		// Initialize the container
-		if err := c.init(ctx, false); err != nil {
-			return err
+
+		if c.state.State == define.ContainerStateStopped {
+			// Reinitialize the container if we need to
+			if err := c.reinit(ctx, false); err != nil {
+				return err
+			}
+		} else if c.state.State == define.ContainerStateConfigured ||
+			c.state.State == define.ContainerStateExited {
+			// Initialize the container
+			if err := c.init(ctx, false); err != nil {
+				return err
+			}
 		}
+		return c.start()
+	}); err != nil {
+		return err
 	}
-	if err := c.start(); err != nil {
+	if err := c.provision(); err != nil {
 		return err
 	}
 	return c.waitForHealthy(ctx)
@@ -1851,6 +2049,10 @@ func (c *Container) mountNamedVolume(v *ContainerNamedVolume, mountpoint string)
 	if err := vol.update(); err != nil {
 		return nil, err
 	}
+	// The container can write to the volume from now on.
+	if err := vol.invalidateSize(); err != nil {
+		return nil, err
+	}
 	_, hasNoCopy := vol.config.Options["nocopy"]
 	if vol.state.NeedsCopyUp && !slices.Contains(v.Options, "nocopy") && !hasNoCopy {
 		logrus.Debugf("Copying up contents from container %s to volume %s", c.ID(), vol.Name())
@@ -2027,13 +2229,19 @@ func (c *Container) cleanupStorage() error {
 			continue
 		}
 
+		vol.lock.Lock()
 		if vol.needsMount() {
-			vol.lock.Lock()
 			if err := vol.unmount(false); err != nil {
 				reportErrorf("unmounting volume %s for container %s: %w", vol.Name(), c.ID(), err)
 			}
-			vol.lock.Unlock()
 		}
+		// A size computed while the container was running is stale.
+		if err := vol.update(); err != nil {
+			reportErrorf("updating volume %s for container %s: %w", vol.Name(), c.ID(), err)
+		} else if err := vol.invalidateSize(); err != nil {
+			reportErrorf("invalidating size of volume %s for container %s: %w", vol.Name(), c.ID(), err)
+		}
+		vol.lock.Unlock()
 	}
 
 	markUnmounted()
@@ -2300,7 +2508,13 @@ func (c *Container) saveSpec(spec *spec.Spec) error {
 	if err != nil {
 		return fmt.Errorf("exporting runtime spec for container %s to JSON: %w", c.ID(), err)
 	}
-	if err := os.WriteFile(jsonPath, fileJSON, 0644); err != nil {
+	// The values resolved from an env file template must only be readable
+	// by the user running the container.
+	var mode os.FileMode = 0644
+	if c.config.EnvTemplate != "" {
+		mode = 0600
+	}
+	if err := os.WriteFile(jsonPath, fileJSON, mode); err != nil {
 		return fmt.Errorf("writing runtime spec JSON for container %s to disk: %w", c.ID(), err)
 	}
 
@@ -2448,6 +2662,13 @@ func (c *Container) mount() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("mounting storage for container %s: %w", c.ID(), err)
 	}
+	// The root filesystem can be written to from now on.
+	if c.state.SizeCache != nil {
+		c.state.SizeCache = nil
+		if err := c.save(); err != nil {
+			return "", err
+		}
+	}
 	mountPoint, err = filepath.EvalSymlinks(mountPoint)
 	if err != nil {
 		return "", fmt.Errorf("resolving storage path for container %s: %w", c.ID(), err)
@@ -2656,10 +2877,6 @@ func (c *Container) update(resources *spec.LinuxResources, restartPolicy *string
 		return fmt.Errorf("must provide restart policy if updating restart retries: %w", define.ErrInvalidArg)
 	}
 
-	oldResources := c.config.Spec.Linux.Resources
-	oldRestart := c.config.RestartPolicy
-	oldRetries := c.config.RestartRetries
-
 	if restartPolicy != nil {
 		if err := define.ValidateRestartPolicy(*restartPolicy); err != nil {
 			return err
@@ -2670,6 +2887,19 @@ func (c *Container) update(resources *spec.LinuxResources, restartPolicy *string
 				return fmt.Errorf("cannot set restart policy retries unless policy is on-failure: %w", define.ErrInvalidArg)
 			}
 		}
+	}
+
+	// The limits are persisted before they are applied, so that they are
+	// used when the container is restarted.
+	if resources != nil {
+		if err := c.runtime.state.UpdateContainerResources(c, resources); err != nil {
+			return err
+		}
+	}
+
+	if restartPolicy != nil {
+		oldRestart := c.config.RestartPolicy
+		oldRetries := c.config.RestartRetries
 
 		c.config.RestartPolicy = *restartPolicy
 		if restartRetries != nil {
@@ -2677,21 +2907,13 @@ func (c *Container) update(resources *spec.LinuxResources, restartPolicy *string
 		} else {
 			c.config.RestartRetries = 0
 		}
-	}
 
-	if resources != nil {
-		if c.config.Spec.Linux == nil {
-			c.config.Spec.Linux = new(spec.Linux)
+		if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+			// Assume DB write failed, revert to old restart policy
+			c.config.RestartPolicy = oldRestart
+			c.config.RestartRetries = oldRetries
+			return err
 		}
-		c.config.Spec.Linux.Resources = resources
-	}
-
-	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
-		// Assume DB write failed, revert to old resources block
-		c.config.Spec.Linux.Resources = oldResources
-		c.config.RestartPolicy = oldRestart
-		c.config.RestartRetries = oldRetries
-		return err
 	}
 
 	if c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused) && resources != nil {
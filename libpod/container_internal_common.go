@@ -33,6 +33,7 @@ import (
 	"github.com/containers/common/pkg/apparmor"
 	"github.com/containers/common/pkg/chown"
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/machine"
 	"github.com/containers/common/pkg/subscriptions"
 	"github.com/containers/common/pkg/umask"
 	is "github.com/containers/image/v5/storage"
@@ -41,6 +42,7 @@ import (
 	"github.com/containers/podman/v5/pkg/annotations"
 	"github.com/containers/podman/v5/pkg/checkpoint/crutils"
 	"github.com/containers/podman/v5/pkg/criu"
+	"github.com/containers/podman/v5/pkg/env"
 	"github.com/containers/podman/v5/pkg/lookup"
 	"github.com/containers/podman/v5/pkg/rootless"
 	"github.com/containers/podman/v5/pkg/util"
@@ -177,6 +179,34 @@ func getOverlayUpperAndWorkDir(options []string) (string, string, error) {
 	return upperDir, workDir, nil
 }
 
+// persistentImageVolumeMount mounts an overlay of the given image path whose
+// upper and work directories are kept in the upper volume of the image
+// volume, so the changes written to it survive restarts of the container.
+func (c *Container) persistentImageVolumeMount(contentDir, imagePath string, volume *ContainerImageVolume) (spec.Mount, error) {
+	vol, err := c.runtime.state.Volume(volume.UpperVolume)
+	if err != nil {
+		return spec.Mount{}, fmt.Errorf("retrieving volume %s of image volume %q:%q: %w", volume.UpperVolume, volume.Source, volume.Dest, err)
+	}
+	mountPoint, err := vol.MountPoint()
+	if err != nil {
+		return spec.Mount{}, err
+	}
+	upperDir := filepath.Join(mountPoint, "upper")
+	workDir := filepath.Join(mountPoint, "work")
+	for _, dir := range []string{upperDir, workDir} {
+		if err := idtools.MkdirAllAs(dir, 0o755, c.RootUID(), c.RootGID()); err != nil {
+			return spec.Mount{}, fmt.Errorf("creating directory %s of image volume %q:%q: %w", dir, volume.Source, volume.Dest, err)
+		}
+	}
+	overlayOpts := &overlay.Options{RootUID: c.RootUID(),
+		RootGID:                c.RootGID(),
+		UpperDirOptionFragment: upperDir,
+		WorkDirOptionFragment:  workDir,
+		GraphOpts:              c.runtime.store.GraphOptions(),
+	}
+	return overlay.MountWithOptions(contentDir, imagePath, volume.Dest, overlayOpts)
+}
+
 // Generate spec for a container
 // Accepts a map of the container's dependencies
 func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFuncRet func(), err error) {
@@ -472,7 +502,9 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		}
 
 		var overlayMount spec.Mount
-		if volume.ReadWrite {
+		if volume.UpperVolume != "" {
+			overlayMount, err = c.persistentImageVolumeMount(contentDir, imagePath, volume)
+		} else if volume.ReadWrite {
 			overlayMount, err = overlay.Mount(contentDir, imagePath, volume.Dest, c.RootUID(), c.RootGID(), c.runtime.store.GraphOptions())
 		} else {
 			overlayMount, err = overlay.MountReadOnly(contentDir, imagePath, volume.Dest, c.RootUID(), c.RootGID(), c.runtime.store.GraphOptions())
@@ -646,6 +678,13 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 			g.AddProcessEnv(name, string(data))
 		}
 	}
+	templateEnv, err := c.resolveEnvTemplate()
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, val := range templateEnv {
+		g.AddProcessEnv(name, val)
+	}
 
 	// Pass down the LISTEN_* environment (see #10443).
 	for _, key := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
@@ -658,6 +697,12 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 			g.AddProcessEnv(key, val)
 		}
 	}
+	// Published sockets replace the sockets passed to Podman.
+	if len(c.config.ListenSockets) > 0 {
+		g.AddProcessEnv("LISTEN_PID", "1")
+		g.AddProcessEnv("LISTEN_FDS", strconv.Itoa(len(c.config.ListenSockets)))
+		g.AddProcessEnv("LISTEN_FDNAMES", strings.Join(listenSocketNames(c.config.ListenSockets), ":"))
+	}
 
 	// setup rlimits
 	nofileSet := false
@@ -1076,6 +1121,7 @@ func (c *Container) exportCheckpoint(options ContainerCheckpointOptions) error {
 		includeFiles = append(includeFiles, preCheckpointDir)
 	} else {
 		includeFiles = append(includeFiles, metadata.CheckpointDirectory)
+		includeFiles = append(includeFiles, c.state.CheckpointChain...)
 	}
 	// Get root file-system changes included in the checkpoint archive
 	var addToTarFiles []string
@@ -1214,10 +1260,28 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 	c.state.CheckpointLog = path.Join(c.bundlePath(), "dump.log")
 	c.state.CheckpointPath = c.CheckpointPath()
 
+	var chain []string
+	if !options.PreCheckPoint {
+		// A new checkpoint replaces the one the old chain belongs to.
+		c.removeCheckpointChain()
+		if options.PreDumpChain > 0 {
+			var err error
+			if chain, err = c.preDumpChain(options.PreDumpChain); err != nil {
+				return nil, 0, err
+			}
+			options.parentDir = chain[len(chain)-1]
+		}
+	}
+	if options.WithPrevious {
+		options.parentDir = preCheckpointDir
+	}
+
 	runtimeCheckpointDuration, err := c.ociRuntime.CheckpointContainer(c, options)
 	if err != nil {
+		removeCheckpointDirs(c.bundlePath(), chain)
 		return nil, 0, err
 	}
+	c.state.CheckpointChain = chain
 
 	// Keep the content of /dev/shm directory
 	if c.config.ShmDir != "" && c.state.BindMounts["/dev/shm"] == c.config.ShmDir {
@@ -1252,11 +1316,8 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 
 	defer c.newContainerEvent(events.Checkpoint)
 
-	// There is a bug from criu: https://github.com/checkpoint-restore/criu/issues/116
-	// We have to change the symbolic link from absolute path to relative path
-	if options.WithPrevious {
-		os.Remove(path.Join(c.CheckpointPath(), "parent"))
-		if err := os.Symlink("../pre-checkpoint", path.Join(c.CheckpointPath(), "parent")); err != nil {
+	if options.parentDir != "" {
+		if err := relinkCheckpointParent(c.CheckpointPath(), options.parentDir); err != nil {
 			return nil, 0, err
 		}
 	}
@@ -1265,6 +1326,9 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 		if err := c.exportCheckpoint(options); err != nil {
 			return nil, 0, err
 		}
+		if !options.SkipRecord {
+			c.recordCheckpoint(options)
+		}
 	} else {
 		if err := c.createCheckpointImage(ctx, options); err != nil {
 			return nil, 0, err
@@ -1378,6 +1442,14 @@ func (c *Container) importCheckpointImage(ctx context.Context, imageID string) e
 		metadata.PodDumpFile,
 	}
 
+	chain, err := filepath.Glob(filepath.Join(mountPoint, strings.ReplaceAll(preDumpChainDir, "%d", "*")))
+	if err != nil {
+		return err
+	}
+	for _, dir := range chain {
+		checkpoint = append(checkpoint, filepath.Base(dir))
+	}
+
 	for _, name := range checkpoint {
 		src := filepath.Join(mountPoint, name)
 		dst := filepath.Join(c.bundlePath(), name)
@@ -1453,6 +1525,15 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		return nil, 0, fmt.Errorf("a complete checkpoint for this container cannot be found, cannot restore: %w", err)
 	}
 
+	// The checkpoint may be based on pre-dumps, either taken locally or
+	// imported along with it. CRIU follows the chain from the checkpoint,
+	// so all of its pre-dumps must be present.
+	chain, err := checkpointChain(c.bundlePath())
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot restore container %s: %w", c.ID(), err)
+	}
+	c.state.CheckpointChain = chain
+
 	if err := crutils.CRCreateFileWithLabel(c.bundlePath(), "restore.log", c.MountLabel()); err != nil {
 		return nil, 0, err
 	}
@@ -1482,7 +1563,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 
 	// Read network configuration from checkpoint
 	var netStatus map[string]types.StatusBlock
-	_, err := metadata.ReadJSONFile(&netStatus, c.bundlePath(), metadata.NetworkStatusFile)
+	_, err = metadata.ReadJSONFile(&netStatus, c.bundlePath(), metadata.NetworkStatusFile)
 	if err != nil {
 		logrus.Infof("Failed to unmarshal network status, cannot restore the same ip/mac: %v", err)
 	}
@@ -1787,6 +1868,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		if err != nil {
 			logrus.Debugf("Non-fatal: removal of pre-checkpoint directory (%s) failed: %v", c.PreCheckPointPath(), err)
 		}
+		c.removeCheckpointChain()
 		err = os.RemoveAll(c.CheckpointVolumesPath())
 		if err != nil {
 			logrus.Debugf("Non-fatal: removal of checkpoint volumes directory (%s) failed: %v", c.CheckpointVolumesPath(), err)
@@ -2038,6 +2120,20 @@ rootless=%d
 		c.state.BindMounts[containerenvPath] = containerenvHostPath
 	}
 
+	// Mount the provision script until it succeeded once.
+	provisionPath := filepath.Join(runPath, provisionScriptName)
+	delete(c.state.BindMounts, provisionPath)
+	if c.config.ProvisionScript != "" && !c.state.Provisioned {
+		scriptHostPath, err := c.writeStringToRundir(provisionScriptName, c.config.ProvisionScript)
+		if err != nil {
+			return fmt.Errorf("creating provision script for container %s: %w", c.ID(), err)
+		}
+		if err := os.Chmod(scriptHostPath, 0o755); err != nil {
+			return fmt.Errorf("making provision script of container %s executable: %w", c.ID(), err)
+		}
+		c.state.BindMounts[provisionPath] = scriptHostPath
+	}
+
 	// Add Subscription Mounts
 	subscriptionMounts := subscriptions.MountsWithUIDGID(c.config.MountLabel, c.state.RunDir, c.runtime.config.Containers.DefaultMountsFile, c.state.Mountpoint, c.RootUID(), c.RootGID(), rootless.IsRootless(), false)
 	for _, mount := range subscriptionMounts {
@@ -2319,13 +2415,20 @@ func (c *Container) addHosts() error {
 		}
 	}
 
+	hostContainersInternalIP := etchosts.GetHostContainersInternalIPExcluding(
+		c.runtime.config, c.state.NetworkStatus, c.runtime.network, exclude)
+	// With gvproxy the name is normally answered by its DNS server, pin the
+	// address for containers that may not query it.
+	if hostContainersInternalIP == "" && c.runtime.config.Containers.HostContainersInternalIP == "" && machine.IsGvProxyBased() {
+		hostContainersInternalIP = machineHostContainersInternalIP()
+	}
+
 	return etchosts.New(&etchosts.Params{
-		BaseFile:     baseHostFile,
-		ExtraHosts:   c.config.HostAdd,
-		ContainerIPs: containerIPsEntries,
-		HostContainersInternalIP: etchosts.GetHostContainersInternalIPExcluding(
-			c.runtime.config, c.state.NetworkStatus, c.runtime.network, exclude),
-		TargetFile: targetFile,
+		BaseFile:                 baseHostFile,
+		ExtraHosts:               c.config.HostAdd,
+		ContainerIPs:             containerIPsEntries,
+		HostContainersInternalIP: hostContainersInternalIP,
+		TargetFile:               targetFile,
 	})
 }
 
@@ -3049,3 +3152,19 @@ func maybeClampOOMScoreAdj(oomScoreValue int) (int, error) {
 	}
 	return oomScoreValue, nil
 }
+
+// resolveEnvTemplate resolves the env file template of the container, if any,
+// against the secrets store and the environment of this process.
+func (c *Container) resolveEnvTemplate() (map[string]string, error) {
+	if c.config.EnvTemplate == "" {
+		return nil, nil
+	}
+	manager, err := c.runtime.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	return env.ResolveTemplate(c.config.EnvTemplate, func(name string) ([]byte, error) {
+		_, data, err := manager.LookupSecretData(name)
+		return data, err
+	})
+}
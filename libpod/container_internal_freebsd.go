@@ -398,3 +398,10 @@ func (c *Container) hasPrivateUTS() bool {
 	// specification.
 	return true
 }
+
+// processStartTime returns the start time of the process with the given PID.
+// It is not implemented on FreeBSD, the start time is 0, which is treated as
+// unknown.
+func processStartTime(pid int) (uint64, error) {
+	return 0, nil
+}
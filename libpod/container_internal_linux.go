@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -93,6 +94,11 @@ func (c *Container) prepare() error {
 			// Assign NetNS attributes to container
 			c.state.NetNS = netNS
 			c.state.NetworkStatus = networkStatus
+		} else if c.publishesMachineHostPorts() {
+			tmpStateLock.Lock()
+			defer tmpStateLock.Unlock()
+
+			createNetNSErr = c.exposeMachineHostPorts()
 		}
 	}()
 	// Mount storage if not mounted
@@ -171,6 +177,13 @@ func (c *Container) cleanupNetwork() error {
 	if netDisabled {
 		return nil
 	}
+	if c.publishesMachineHostPorts() {
+		c.unexposeMachineHostPorts()
+		if c.valid {
+			return c.save()
+		}
+		return nil
+	}
 	if c.state.NetNS == "" {
 		logrus.Debugf("Network is already cleaned up, skipping...")
 		return nil
@@ -183,6 +196,7 @@ func (c *Container) cleanupNetwork() error {
 
 	c.state.NetNS = ""
 	c.state.NetworkStatus = nil
+	c.state.NetworkDisturbances = nil
 
 	if c.valid {
 		return c.save()
@@ -813,3 +827,22 @@ func (c *Container) hasPrivateUTS() bool {
 	}
 	return privateUTS
 }
+
+// processStartTime returns the start time of the process with the given PID,
+// in clock ticks since boot, which tells it apart from a later process
+// reusing its PID.
+func processStartTime(pid int) (uint64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+	// The command name in parentheses may contain spaces, the fields after
+	// it start with the state, the third field of the file. The start
+	// time is the 22nd.
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("parsing /proc/%d/stat: too few fields", pid)
+	}
+	return strconv.ParseUint(fields[19], 10, 64)
+}
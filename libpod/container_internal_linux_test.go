@@ -3,10 +3,12 @@
 package libpod
 
 import (
+	"os"
 	"testing"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateUserPasswdEntry(t *testing.T) {
@@ -60,3 +62,18 @@ func TestGenerateUserGroupEntry(t *testing.T) {
 	}
 	assert.Equal(t, group, "567890:x:567890:567890\n")
 }
+
+func TestExecOwnerAlive(t *testing.T) {
+	startTime, err := processStartTime(os.Getpid())
+	require.NoError(t, err)
+	assert.NotZero(t, startTime)
+
+	session := &ExecSession{OwnerPID: os.Getpid(), OwnerStartTime: startTime}
+	assert.True(t, execOwnerAlive(session))
+	// A session of an owner whose PID was reused.
+	session.OwnerStartTime = startTime + 1
+	assert.False(t, execOwnerAlive(session))
+	// The start time is unknown for sessions created before it was recorded.
+	session.OwnerStartTime = 0
+	assert.True(t, execOwnerAlive(session))
+}
@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,10 +12,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
 	"github.com/containers/storage/pkg/idtools"
 	stypes "github.com/containers/storage/types"
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // hookPath is the path to an example hook executable.
@@ -213,3 +218,191 @@ func init() {
 		panic("we need a reliable executable path on Windows")
 	}
 }
+
+func TestSizeExternalRootfsNotCached(t *testing.T) {
+	dir := t.TempDir()
+	c := Container{
+		runtime: &Runtime{},
+		config: &ContainerConfig{
+			ID: "123abc",
+			ContainerRootFSConfig: ContainerRootFSConfig{
+				Rootfs: dir,
+			},
+		},
+		state: &ContainerState{},
+	}
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "file"), make([]byte, 100), 0o644))
+	size, err := c.size()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), size.RWSize)
+	assert.Nil(t, c.state.SizeCache)
+
+	// A rootfs not managed by c/storage may change at any time.
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "file2"), make([]byte, 50), 0o644))
+	size, err = c.size()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150), size.RWSize)
+}
+
+func TestDeferSaves(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	rt := new(Runtime)
+	rt.config = new(config.Config)
+	rt.lockManager = manager
+
+	state, err := newSqliteState(rt, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+	rt.state = state
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.runtime = rt
+	require.NoError(t, state.AddContainer(ctr))
+
+	stored := func() define.ContainerStatus {
+		var stateJSON string
+		require.NoError(t, state.conn.QueryRow("SELECT JSON FROM ContainerState WHERE ID=?;", ctr.ID()).Scan(&stateJSON))
+		dbState := new(ContainerState)
+		require.NoError(t, json.Unmarshal([]byte(stateJSON), dbState))
+		return dbState.State
+	}
+	// Saves are written once, by the outermost call.
+	err = ctr.deferSaves(func() error {
+		ctr.state.State = define.ContainerStateCreated
+		if err := ctr.save(); err != nil {
+			return err
+		}
+		return ctr.deferSaves(func() error {
+			ctr.state.Mounted = false
+			return ctr.save()
+		})
+	})
+	require.NoError(t, err)
+	assert.False(t, ctr.stateDirty)
+	assert.Equal(t, define.ContainerStateCreated, stored())
+
+	err = ctr.deferSaves(func() error {
+		ctr.state.State = define.ContainerStateRunning
+		require.NoError(t, ctr.save())
+		assert.Equal(t, define.ContainerStateCreated, stored())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateRunning, stored())
+
+	// The state is written even if the operation fails.
+	testErr := errors.New("test error")
+	err = ctr.deferSaves(func() error {
+		ctr.state.State = define.ContainerStateStopped
+		require.NoError(t, ctr.save())
+		return testErr
+	})
+	assert.ErrorIs(t, err, testErr)
+	assert.Equal(t, define.ContainerStateStopped, stored())
+
+	// Syncing does not reload the state over unwritten changes.
+	err = ctr.deferSaves(func() error {
+		ctr.state.State = define.ContainerStateConfigured
+		require.NoError(t, ctr.save())
+		if err := ctr.syncContainer(); err != nil {
+			return err
+		}
+		assert.Equal(t, define.ContainerStateConfigured, ctr.state.State)
+		assert.False(t, ctr.stateDirty)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, define.ContainerStateConfigured, stored())
+}
+
+func TestRestartByPolicyAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		retries uint
+		state   ContainerState
+		action  restartAction
+	}{
+		{
+			name:   "exited with policy match",
+			policy: define.RestartPolicyAlways,
+			state:  ContainerState{State: define.ContainerStateExited, RestartPolicyMatch: true},
+			action: restartActionRestart,
+		},
+		{
+			name:   "stopped with policy match",
+			policy: define.RestartPolicyAlways,
+			state:  ContainerState{State: define.ContainerStateStopped, RestartPolicyMatch: true},
+			action: restartActionCleanup,
+		},
+		{
+			name:   "exited after reboot",
+			policy: define.RestartPolicyAlways,
+			state:  ContainerState{State: define.ContainerStateExited},
+			action: restartActionStart,
+		},
+		{
+			name:   "stopped by user",
+			policy: define.RestartPolicyAlways,
+			state:  ContainerState{State: define.ContainerStateExited, StoppedByUser: true, RestartPolicyMatch: true},
+			action: restartActionNone,
+		},
+		{
+			name:   "running",
+			policy: define.RestartPolicyAlways,
+			state:  ContainerState{State: define.ContainerStateRunning, RestartPolicyMatch: true},
+			action: restartActionNone,
+		},
+		{
+			name:   "no restart policy",
+			policy: define.RestartPolicyNo,
+			state:  ContainerState{State: define.ContainerStateExited, RestartPolicyMatch: true},
+			action: restartActionNone,
+		},
+		{
+			name:   "on-failure with exit code",
+			policy: define.RestartPolicyOnFailure,
+			state:  ContainerState{State: define.ContainerStateExited, ExitCode: 1, RestartPolicyMatch: true},
+			action: restartActionRestart,
+		},
+		{
+			name:   "on-failure without exit code",
+			policy: define.RestartPolicyOnFailure,
+			state:  ContainerState{State: define.ContainerStateExited, RestartPolicyMatch: true},
+			action: restartActionNone,
+		},
+		{
+			name:    "on-failure out of retries",
+			policy:  define.RestartPolicyOnFailure,
+			retries: 3,
+			state:   ContainerState{State: define.ContainerStateExited, ExitCode: 1, RestartCount: 3, RestartPolicyMatch: true},
+			action:  restartActionNone,
+		},
+		{
+			name:   "on-failure after reboot",
+			policy: define.RestartPolicyOnFailure,
+			state:  ContainerState{State: define.ContainerStateExited, ExitCode: 1},
+			action: restartActionNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.state
+			c := Container{
+				config: &ContainerConfig{
+					ID: "123abc",
+					ContainerMiscConfig: ContainerMiscConfig{
+						RestartPolicy:  tt.policy,
+						RestartRetries: tt.retries,
+					},
+				},
+				state: &state,
+			}
+			assert.Equal(t, tt.action, c.restartByPolicyAction())
+		})
+	}
+}
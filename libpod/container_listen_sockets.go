@@ -0,0 +1,96 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// listenSocketFiles returns the published sockets of the container. The
+// sockets taken over from the previous run of the container are used if there
+// are any, otherwise new sockets are bound on the host.
+func (c *Container) listenSocketFiles() ([]*os.File, error) {
+	if len(c.takeoverSockets) > 0 {
+		files := c.takeoverSockets
+		c.takeoverSockets = nil
+		return files, nil
+	}
+
+	files := make([]*os.File, 0, len(c.config.ListenSockets))
+	for _, socket := range c.config.ListenSockets {
+		file, err := bindListenSocket(socket)
+		if err != nil {
+			closeFiles(files)
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// bindListenSocket binds the socket on the host and returns its file.
+func bindListenSocket(socket define.ListenSocket) (*os.File, error) {
+	var (
+		file *os.File
+		err  error
+	)
+	switch socket.Protocol {
+	case "udp":
+		var conn net.PacketConn
+		if conn, err = net.ListenPacket("udp", socket.Address()); err == nil {
+			file, err = conn.(*net.UDPConn).File()
+			conn.Close()
+		}
+	default:
+		var listener net.Listener
+		if listener, err = net.Listen("tcp", socket.Address()); err == nil {
+			file, err = listener.(*net.TCPListener).File()
+			listener.Close()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("binding published socket %s: %w", socket, err)
+	}
+	return file, nil
+}
+
+// listenSocketInodes returns the inodes of the sockets, to find them in conmon
+// later. Sockets without a known inode cannot be taken over.
+func listenSocketInodes(files []*os.File) []uint64 {
+	if len(files) == 0 {
+		return nil
+	}
+	inodes := make([]uint64, 0, len(files))
+	for _, file := range files {
+		var stat unix.Stat_t
+		if err := unix.Fstat(int(file.Fd()), &stat); err != nil {
+			logrus.Warnf("Getting inode of published socket %s: %v", file.Name(), err)
+			return nil
+		}
+		inodes = append(inodes, stat.Ino)
+	}
+	return inodes
+}
+
+// listenSocketNames returns the names of the published sockets passed in
+// LISTEN_FDNAMES, in the format protocol-port.
+func listenSocketNames(sockets []define.ListenSocket) []string {
+	names := make([]string, 0, len(sockets))
+	for _, socket := range sockets {
+		names = append(names, fmt.Sprintf("%s-%d", socket.Protocol, socket.Port))
+	}
+	return names
+}
+
+func closeFiles(files []*os.File) {
+	for _, file := range files {
+		errorhandling.CloseQuiet(file)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// takeListenSockets is not supported on FreeBSD, which cannot duplicate file
+// descriptors of other processes.
+func (c *Container) takeListenSockets() ([]*os.File, error) {
+	return nil, fmt.Errorf("taking over published sockets: %w", define.ErrNotImplemented)
+}
@@ -0,0 +1,67 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"golang.org/x/sys/unix"
+)
+
+// takeListenSockets duplicates the published sockets conmon keeps open for the
+// running container, so they stay open while the container is replaced.
+func (c *Container) takeListenSockets() ([]*os.File, error) {
+	if len(c.state.ListenSocketInodes) != len(c.config.ListenSockets) || c.state.ConmonPID == 0 {
+		return nil, fmt.Errorf("published sockets of container %s are unknown, restart the container to take them over later: %w", c.ID(), define.ErrInternal)
+	}
+
+	pidfd, err := unix.PidfdOpen(c.state.ConmonPID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("opening conmon process of container %s: %w", c.ID(), err)
+	}
+	defer unix.Close(pidfd)
+
+	fdDir := filepath.Join("/proc", strconv.Itoa(c.state.ConmonPID), "fd")
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil, fmt.Errorf("listing file descriptors of conmon: %w", err)
+	}
+	fds := make(map[uint64]int, len(entries))
+	for _, entry := range entries {
+		link, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var inode uint64
+		if _, err := fmt.Sscanf(link, "socket:[%d]", &inode); err != nil {
+			continue
+		}
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if _, ok := fds[inode]; !ok {
+			fds[inode] = fd
+		}
+	}
+
+	files := make([]*os.File, 0, len(c.config.ListenSockets))
+	for i, socket := range c.config.ListenSockets {
+		target, ok := fds[c.state.ListenSocketInodes[i]]
+		if !ok {
+			closeFiles(files)
+			return nil, fmt.Errorf("published socket %s of container %s is not open in conmon: %w", socket, c.ID(), define.ErrInternal)
+		}
+		fd, err := unix.PidfdGetfd(pidfd, target, 0)
+		if err != nil {
+			closeFiles(files)
+			return nil, fmt.Errorf("taking over published socket %s of container %s: %w", socket, c.ID(), err)
+		}
+		files = append(files, os.NewFile(uintptr(fd), socket.String()))
+	}
+	return files, nil
+}
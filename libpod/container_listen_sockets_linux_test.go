@@ -0,0 +1,62 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeListenSockets(t *testing.T) {
+	ctr := &Container{
+		config: &ContainerConfig{},
+		state:  &ContainerState{},
+	}
+	ctr.config.ListenSockets = []define.ListenSocket{
+		{HostIP: "127.0.0.1", Protocol: "tcp"},
+		{HostIP: "127.0.0.1", Protocol: "udp"},
+	}
+	// Unknown sockets cannot be taken over.
+	_, err := ctr.takeListenSockets()
+	assert.ErrorIs(t, err, define.ErrInternal)
+
+	files, err := ctr.listenSocketFiles()
+	require.NoError(t, err)
+	defer closeFiles(files)
+	require.Len(t, files, 2)
+
+	// The sockets are taken from the process keeping them open, this
+	// process takes the place of conmon.
+	ctr.state.ConmonPID = os.Getpid()
+	ctr.state.ListenSocketInodes = listenSocketInodes(files)
+	require.Len(t, ctr.state.ListenSocketInodes, 2)
+	taken, err := ctr.takeListenSockets()
+	require.NoError(t, err)
+	defer closeFiles(taken)
+	require.Len(t, taken, 2)
+	assert.Equal(t, ctr.state.ListenSocketInodes, listenSocketInodes(taken))
+
+	// The taken socket keeps listening after the original is closed.
+	listener, err := net.FileListener(taken[0])
+	require.NoError(t, err)
+	defer listener.Close()
+	addr := listener.Addr().String()
+	closeFiles(files[:1])
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	conn.Close()
+
+	// Taken sockets are used instead of binding new ones.
+	ctr.takeoverSockets = taken
+	used, err := ctr.listenSocketFiles()
+	require.NoError(t, err)
+	assert.Equal(t, taken, used)
+	assert.Nil(t, ctr.takeoverSockets)
+
+	assert.Equal(t, []string{"tcp-0", "udp-0"}, listenSocketNames(ctr.config.ListenSockets))
+}
@@ -35,6 +35,37 @@ func (r *Runtime) Log(ctx context.Context, containers []*Container, options *log
 	return nil
 }
 
+// LogRedactor returns a redactor masking the values of the secrets of the
+// container and the matches of the log_redact_patterns of containers.conf in
+// its log lines.
+func (c *Container) LogRedactor() (*logs.Redactor, error) {
+	var names []string
+	for _, secr := range c.config.Secrets {
+		names = append(names, secr.Name)
+	}
+	for _, secr := range c.config.EnvSecrets {
+		names = append(names, secr.Name)
+	}
+	var values []string
+	if len(names) > 0 {
+		manager, err := c.runtime.SecretsManager()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			_, data, err := manager.LookupSecretData(name)
+			if err != nil {
+				// The secret may have been removed since the
+				// container was created.
+				logrus.Warnf("Unable to redact secret %s in the logs of container %s: %v", name, c.ID(), err)
+				continue
+			}
+			values = append(values, string(data))
+		}
+	}
+	return logs.NewRedactor(values, c.runtime.podmanConf.Containers.LogRedactPatterns)
+}
+
 // ReadLog reads a container's log based on the input options and returns log lines over a channel.
 func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
 	switch c.LogDriver() {
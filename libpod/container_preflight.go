@@ -0,0 +1,135 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/common/libimage/platform"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/emulation"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// platformPreflightAuto enables installed qemu-user emulation for
+	// images of foreign architectures.
+	platformPreflightAuto = "auto"
+	// platformPreflightError fails if the host cannot run the image.
+	platformPreflightError = "error"
+	// platformPreflightOff skips the check.
+	platformPreflightOff = "off"
+)
+
+// checkImagePlatform verifies that the host can run the platform of the image
+// of the container, natively or through qemu-user emulation registered with
+// binfmt_misc, so that a mismatch fails with a clear error instead of an
+// "exec format error" of the container process.
+func (c *Container) checkImagePlatform(ctx context.Context) error {
+	policy, err := c.runtime.platformPreflightPolicy()
+	if err != nil || policy == platformPreflightOff {
+		return err
+	}
+	if c.config.RootfsImageID == "" {
+		return nil
+	}
+
+	hostOS, hostArch, hostVariant := platform.Normalize(runtime.GOOS, runtime.GOARCH, "")
+	lookupOptions := &libimage.LookupImageOptions{OS: hostOS, Architecture: hostArch, Variant: hostVariant}
+	_, _, err = c.runtime.libimageRuntime.LookupImage(c.config.RootfsImageID, lookupOptions)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, storage.ErrImageUnknown) {
+		return fmt.Errorf("checking platform of image %s: %w", c.config.RootfsImageID, err)
+	}
+
+	img, _, err := c.runtime.libimageRuntime.LookupImage(c.config.RootfsImageID, nil)
+	if err != nil {
+		// The image is gone, leave it to the OCI runtime to fail.
+		logrus.Debugf("Skipping platform check of container %s: %v", c.ID(), err)
+		return nil
+	}
+	data, err := img.Inspect(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inspecting image %s: %w", c.config.RootfsImageID, err)
+	}
+	// Images with a dedicated OCI runtime, such as WebAssembly images,
+	// are not run by the kernel of the host.
+	if c.runtime.config.Engine.ImagePlatformToRuntime(data.Os, data.Architecture) != c.runtime.config.Engine.OCIRuntime {
+		return nil
+	}
+
+	return c.runtime.checkPlatformEmulation(policy, c.config.RootfsImageName, data.Os, data.Architecture)
+}
+
+// CheckPlatformEmulation verifies that the host can run the named image of the
+// given platform, natively or through qemu-user emulation registered with
+// binfmt_misc. Unless disabled by the platform_preflight option of
+// containers.conf, the installed qemu-user emulation of the architecture is
+// enabled if Podman runs as root. It returns define.ErrPlatformNotSupported
+// if the image cannot be run.
+func (r *Runtime) CheckPlatformEmulation(name, osName, arch string) error {
+	policy, err := r.platformPreflightPolicy()
+	if err != nil || policy == platformPreflightOff {
+		return err
+	}
+	hostOS, hostArch, _ := platform.Normalize(runtime.GOOS, runtime.GOARCH, "")
+	osName, arch, _ = platform.Normalize(osName, arch, "")
+	if osName == hostOS && arch == hostArch {
+		return nil
+	}
+	return r.checkPlatformEmulation(policy, name, osName, arch)
+}
+
+// platformPreflightPolicy returns the platform_preflight option of
+// containers.conf.
+func (r *Runtime) platformPreflightPolicy() (string, error) {
+	switch policy := r.podmanConf.Engine.PlatformPreflight; policy {
+	case "":
+		return platformPreflightAuto, nil
+	case platformPreflightAuto, platformPreflightError, platformPreflightOff:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid platform_preflight %q in containers.conf, must be one of %q, %q or %q: %w",
+			policy, platformPreflightAuto, platformPreflightError, platformPreflightOff, define.ErrInvalidArg)
+	}
+}
+
+// checkPlatformEmulation verifies that images of the given foreign platform
+// can be run through qemu-user emulation, enabling it according to the
+// policy. name names the image in errors.
+func (r *Runtime) checkPlatformEmulation(policy, name, osName, arch string) error {
+	hostOS, hostArch, hostVariant := platform.Normalize(runtime.GOOS, runtime.GOARCH, "")
+	imagePlatform := platform.ToString(osName, arch, "")
+	hostPlatform := platform.ToString(hostOS, hostArch, hostVariant)
+	if osName != hostOS {
+		return fmt.Errorf("image %s is built for %s and cannot run on %s: %w", name, imagePlatform, hostPlatform, define.ErrPlatformNotSupported)
+	}
+
+	if slices.Contains(emulation.Registered(), imagePlatform) {
+		logrus.Debugf("Running %s of platform %s through qemu-user emulation", name, imagePlatform)
+		return nil
+	}
+
+	if policy == platformPreflightAuto && !rootless.IsRootless() {
+		err := emulation.Enable(arch)
+		if err == nil {
+			logrus.Infof("Enabled qemu-user emulation of %s for %s", arch, name)
+			return nil
+		}
+		if !errors.Is(err, emulation.ErrNotInstalled) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("image %s is built for %s and cannot run on %s: no qemu-user emulation of %s is registered with binfmt_misc, install qemu-user-static and run \"podman system emulation enable %s\" as root: %w",
+		name, imagePlatform, hostPlatform, arch, arch, define.ErrPlatformNotSupported)
+}
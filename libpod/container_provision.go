@@ -0,0 +1,69 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// provisionScriptName is the name of the provision script in the run
+// directory of the container, on the host and in the container.
+const provisionScriptName = ".podman-provision"
+
+// provision runs the provision script of the container unless it succeeded
+// before. The container must be locked and running. If the script fails, the
+// container is stopped and the script runs again on the next start.
+func (c *Container) provision() error {
+	if c.config.ProvisionScript == "" || c.state.Provisioned {
+		return nil
+	}
+
+	runPath, err := c.getPlatformRunPath()
+	if err != nil {
+		return fmt.Errorf("cannot determine run directory for container: %w", err)
+	}
+	output := &bytes.Buffer{}
+	streams := &define.AttachStreams{
+		OutputStream: output,
+		ErrorStream:  output,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	config := new(ExecConfig)
+	config.Command = []string{filepath.Join(runPath, provisionScriptName)}
+
+	// The exec session locks the container itself.
+	if !c.batched {
+		c.lock.Unlock()
+	}
+	logrus.Debugf("Running provision script of container %s", c.ID())
+	exitCode, execErr := c.exec(config, streams, nil, false)
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if execErr == nil && exitCode == 0 {
+		logrus.Debugf("Provision script of container %s succeeded: %s", c.ID(), output.String())
+		c.state.Provisioned = true
+		return c.save()
+	}
+
+	if execErr == nil {
+		execErr = fmt.Errorf("exit code %d: %s", exitCode, strings.TrimSpace(output.String()))
+	}
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if err := c.stop(c.StopTimeout()); err != nil {
+			logrus.Errorf("Stopping container %s after its provision script failed: %v", c.ID(), err)
+		}
+	}
+	return fmt.Errorf("provision script of container %s failed: %w", c.ID(), execErr)
+}
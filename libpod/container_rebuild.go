@@ -0,0 +1,237 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Rebuild recreates the root filesystem of the container from the given
+// image, keeping the ID, name, volumes, networks and dependencies of the
+// container. The entrypoint, command, environment, working directory and
+// labels the container inherited from its current image are replaced by those
+// of the new image, while settings given at creation are kept. Changes to the
+// root filesystem of the container are discarded.
+// A running container is stopped and started again from the new image. If
+// takeoverPorts is set, the sockets published by the container are kept open
+// in the meantime and handed to the new process, so connections are queued
+// instead of refused while the container is replaced.
+// If the container already uses the image, nothing is done.
+func (c *Container) Rebuild(ctx context.Context, img *libimage.Image, imageName string, takeoverPorts bool) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.IsInfra() {
+		return fmt.Errorf("cannot rebuild infra container %s: %w", c.ID(), define.ErrInvalidArg)
+	}
+	if c.config.RootfsImageID == "" {
+		return fmt.Errorf("container %s was not created from an image and cannot be rebuilt: %w", c.ID(), define.ErrInvalidArg)
+	}
+	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStateStopped, define.ContainerStateExited) {
+		return fmt.Errorf("cannot rebuild container %s in state %s: %w", c.ID(), c.state.State.String(), define.ErrCtrStateInvalid)
+	}
+	if takeoverPorts && len(c.config.ListenSockets) == 0 {
+		return fmt.Errorf("container %s has no published sockets to take over: %w", c.ID(), define.ErrInvalidArg)
+	}
+	if img.ID() == c.config.RootfsImageID {
+		logrus.Debugf("Container %s already uses image %s", c.ID(), img.ID())
+		return nil
+	}
+
+	newConfig := c.Config()
+	if newConfig == nil {
+		return fmt.Errorf("copying config of container %s: %w", c.ID(), define.ErrInternal)
+	}
+	newData, err := img.Inspect(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inspecting image %s: %w", imageName, err)
+	}
+	oldImage, _, err := c.runtime.libimageRuntime.LookupImage(c.config.RootfsImageID, nil)
+	if err != nil {
+		return fmt.Errorf("looking up current image of container %s: %w", c.ID(), err)
+	}
+	oldData, err := oldImage.Inspect(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inspecting image %s: %w", c.config.RootfsImageID, err)
+	}
+	rebaseImageConfig(newConfig, oldData.Config, newData.Config)
+	newConfig.RootfsImageID = img.ID()
+	newConfig.RootfsImageName = imageName
+	newConfig.RawImageName = imageName
+
+	wasRunning := c.state.State == define.ContainerStateRunning
+	if wasRunning && takeoverPorts {
+		sockets, err := c.takeListenSockets()
+		if err != nil {
+			return err
+		}
+		c.takeoverSockets = sockets
+		// The sockets are closed if the container is not started
+		// again.
+		defer func() {
+			closeFiles(c.takeoverSockets)
+			c.takeoverSockets = nil
+		}()
+	}
+	if wasRunning {
+		if err := c.stop(c.StopTimeout()); err != nil {
+			return err
+		}
+	}
+	if err := c.cleanup(ctx); err != nil {
+		return err
+	}
+
+	// The storage of the container is identified by the ID of the
+	// container, so the old root filesystem must go before the new one is
+	// created. If anything fails, the container is set up from its old
+	// image again.
+	oldConfig := c.config
+	if err := c.teardownStorage(); err != nil {
+		return err
+	}
+	c.state.State = define.ContainerStateConfigured
+	c.config = newConfig
+	err = c.setupStorage(ctx)
+	if err == nil {
+		err = c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config)
+		if err != nil {
+			if rmErr := c.teardownStorage(); rmErr != nil {
+				logrus.Errorf("Removing storage of container %s: %v", c.ID(), rmErr)
+			}
+		}
+	}
+	if err != nil {
+		c.config = oldConfig
+		if rollbackErr := c.setupStorage(ctx); rollbackErr != nil {
+			logrus.Errorf("Restoring storage of container %s from image %s: %v", c.ID(), oldConfig.RootfsImageID, rollbackErr)
+		}
+		if saveErr := c.save(); saveErr != nil {
+			logrus.Errorf("Saving state of container %s: %v", c.ID(), saveErr)
+		}
+		return fmt.Errorf("rebuilding container %s from image %s: %w", c.ID(), imageName, err)
+	}
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	c.newContainerEvent(events.Update)
+
+	if !wasRunning {
+		return nil
+	}
+	if err := c.prepareToStart(ctx, true); err != nil {
+		return err
+	}
+	if err := c.start(); err != nil {
+		return err
+	}
+	if err := c.provision(); err != nil {
+		return err
+	}
+	return c.waitForHealthy(ctx)
+}
+
+// rebaseImageConfig replaces the settings the container config inherited from
+// the old image config by those of the new image config. Settings which differ
+// from the old image were given at creation and are kept.
+func rebaseImageConfig(ctrConfig *ContainerConfig, oldImage, newImage *v1.ImageConfig) {
+	if oldImage == nil {
+		oldImage = &v1.ImageConfig{}
+	}
+	if newImage == nil {
+		newImage = &v1.ImageConfig{}
+	}
+
+	entrypoint, command := ctrConfig.Entrypoint, ctrConfig.Command
+	if slices.Equal(ctrConfig.Entrypoint, oldImage.Entrypoint) {
+		entrypoint = newImage.Entrypoint
+		// The command of the image is only used with its entrypoint.
+		if slices.Equal(ctrConfig.Command, oldImage.Cmd) {
+			command = newImage.Cmd
+		}
+	}
+
+	if ctrConfig.Labels != nil || len(newImage.Labels) > 0 {
+		labels := make(map[string]string, len(ctrConfig.Labels))
+		for k, v := range ctrConfig.Labels {
+			if old, ok := oldImage.Labels[k]; !ok || old != v {
+				labels[k] = v
+			}
+		}
+		for k, v := range newImage.Labels {
+			if _, ok := labels[k]; !ok {
+				labels[k] = v
+			}
+		}
+		ctrConfig.Labels = labels
+	}
+
+	if ctrConfig.Spec != nil && ctrConfig.Spec.Process != nil {
+		process := ctrConfig.Spec.Process
+
+		// Any prefix of the arguments, such as the init binary, is
+		// kept.
+		oldArgs := append(slices.Clone(ctrConfig.Entrypoint), ctrConfig.Command...)
+		if len(oldArgs) <= len(process.Args) && slices.Equal(process.Args[len(process.Args)-len(oldArgs):], oldArgs) {
+			args := slices.Clone(process.Args[:len(process.Args)-len(oldArgs)])
+			args = append(args, entrypoint...)
+			process.Args = append(args, command...)
+		}
+
+		process.Env = rebaseEnv(process.Env, oldImage.Env, newImage.Env)
+
+		if process.Cwd == workingDirOrRoot(oldImage.WorkingDir) {
+			process.Cwd = workingDirOrRoot(newImage.WorkingDir)
+		}
+	}
+
+	ctrConfig.Entrypoint, ctrConfig.Command = entrypoint, command
+}
+
+// rebaseEnv drops the variables of env which are unchanged from oldImage and
+// adds the variables of newImage which are not set in env.
+func rebaseEnv(env, oldImage, newImage []string) []string {
+	result := make([]string, 0, len(env)+len(newImage))
+	set := make(map[string]bool, len(env))
+	for _, e := range env {
+		if slices.Contains(oldImage, e) {
+			continue
+		}
+		result = append(result, e)
+		name, _, _ := strings.Cut(e, "=")
+		set[name] = true
+	}
+	for _, e := range newImage {
+		name, _, _ := strings.Cut(e, "=")
+		if !set[name] {
+			result = append(result, e)
+			set[name] = true
+		}
+	}
+	return result
+}
+
+// workingDirOrRoot returns the working directory of an image, which defaults
+// to the root directory.
+func workingDirOrRoot(dir string) string {
+	if dir == "" {
+		return "/"
+	}
+	return dir
+}
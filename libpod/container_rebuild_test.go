@@ -0,0 +1,108 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebaseImageConfig(t *testing.T) {
+	oldImage := &v1.ImageConfig{
+		Entrypoint: []string{"/entrypoint.sh"},
+		Cmd:        []string{"serve"},
+		Env:        []string{"PATH=/usr/bin", "VERSION=1"},
+		WorkingDir: "/app",
+		Labels:     map[string]string{"version": "1", "vendor": "acme"},
+	}
+	newImage := &v1.ImageConfig{
+		Entrypoint: []string{"/docker-entrypoint.sh"},
+		Cmd:        []string{"serve", "--all"},
+		Env:        []string{"PATH=/usr/local/bin:/usr/bin", "VERSION=2", "NEW=1"},
+		Labels:     map[string]string{"version": "2", "vendor": "acme"},
+	}
+
+	tests := []struct {
+		name       string
+		entrypoint []string
+		command    []string
+		args       []string
+		env        []string
+		cwd        string
+		labels     map[string]string
+
+		expectEntrypoint []string
+		expectCommand    []string
+		expectArgs       []string
+		expectEnv        []string
+		expectCwd        string
+		expectLabels     map[string]string
+	}{
+		{
+			name:       "inherited from image",
+			entrypoint: []string{"/entrypoint.sh"},
+			command:    []string{"serve"},
+			args:       []string{"/entrypoint.sh", "serve"},
+			env:        []string{"PATH=/usr/bin", "VERSION=1", "container=podman"},
+			cwd:        "/app",
+			labels:     map[string]string{"version": "1", "vendor": "acme"},
+
+			expectEntrypoint: []string{"/docker-entrypoint.sh"},
+			expectCommand:    []string{"serve", "--all"},
+			expectArgs:       []string{"/docker-entrypoint.sh", "serve", "--all"},
+			expectEnv:        []string{"container=podman", "PATH=/usr/local/bin:/usr/bin", "VERSION=2", "NEW=1"},
+			expectCwd:        "/",
+			expectLabels:     map[string]string{"version": "2", "vendor": "acme"},
+		},
+		{
+			name:       "given at creation",
+			entrypoint: []string{"/entrypoint.sh"},
+			command:    []string{"debug"},
+			args:       []string{"/run/podman-init", "--", "/entrypoint.sh", "debug"},
+			env:        []string{"PATH=/usr/bin", "VERSION=3"},
+			cwd:        "/data",
+			labels:     map[string]string{"version": "1", "vendor": "custom"},
+
+			expectEntrypoint: []string{"/docker-entrypoint.sh"},
+			expectCommand:    []string{"debug"},
+			expectArgs:       []string{"/run/podman-init", "--", "/docker-entrypoint.sh", "debug"},
+			expectEnv:        []string{"VERSION=3", "PATH=/usr/local/bin:/usr/bin", "NEW=1"},
+			expectCwd:        "/data",
+			expectLabels:     map[string]string{"version": "2", "vendor": "custom"},
+		},
+		{
+			name:       "entrypoint given at creation",
+			entrypoint: []string{"/bin/sh"},
+			command:    []string{"serve"},
+			args:       []string{"/bin/sh", "serve"},
+			cwd:        "/app",
+
+			expectEntrypoint: []string{"/bin/sh"},
+			expectCommand:    []string{"serve"},
+			expectArgs:       []string{"/bin/sh", "serve"},
+			expectEnv:        []string{"PATH=/usr/local/bin:/usr/bin", "VERSION=2", "NEW=1"},
+			expectCwd:        "/",
+			expectLabels:     map[string]string{"version": "2", "vendor": "acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrConfig := &ContainerConfig{Spec: &spec.Spec{Process: &spec.Process{Args: tt.args, Env: tt.env, Cwd: tt.cwd}}}
+			ctrConfig.Entrypoint = tt.entrypoint
+			ctrConfig.Command = tt.command
+			ctrConfig.Labels = tt.labels
+
+			rebaseImageConfig(ctrConfig, oldImage, newImage)
+			assert.Equal(t, tt.expectEntrypoint, ctrConfig.Entrypoint)
+			assert.Equal(t, tt.expectCommand, ctrConfig.Command)
+			assert.Equal(t, tt.expectArgs, ctrConfig.Spec.Process.Args)
+			assert.Equal(t, tt.expectEnv, ctrConfig.Spec.Process.Env)
+			assert.Equal(t, tt.expectCwd, ctrConfig.Spec.Process.Cwd)
+			assert.Equal(t, tt.expectLabels, ctrConfig.Labels)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// startConditionInitialBackoff is how long to wait before checking
+	// unmet start conditions again, doubled after every check.
+	startConditionInitialBackoff = 100 * time.Millisecond
+	// startConditionMaxBackoff caps the time between two checks of the
+	// start conditions and the time spent on connection attempts.
+	startConditionMaxBackoff = 5 * time.Second
+)
+
+// waitForStartConditions waits until all start conditions of the container
+// are met, checking them again with an exponential backoff until the
+// timeout of the conditions expires. The container lock is released while
+// waiting so the container can still be inspected.
+func (c *Container) waitForStartConditions(ctx context.Context) (retErr error) {
+	if len(c.config.StartConditions) == 0 {
+		return nil
+	}
+	conditions := make([]define.StartCondition, 0, len(c.config.StartConditions))
+	for _, s := range c.config.StartConditions {
+		condition, err := define.ParseStartCondition(s)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if !c.batched {
+		if err := c.flushSaves(); err != nil {
+			return err
+		}
+		c.lock.Unlock()
+		defer func() {
+			c.lock.Lock()
+			if err := c.syncContainer(); err != nil {
+				if retErr == nil {
+					retErr = err
+				}
+				return
+			}
+			if retErr == nil && !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateExited) {
+				retErr = fmt.Errorf("container %s changed state while waiting for its start conditions: %w", c.ID(), define.ErrCtrStateInvalid)
+			}
+		}()
+	}
+
+	if c.config.StartConditionsTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.StartConditionsTimeout)
+		defer cancel()
+	}
+
+	backoff := startConditionInitialBackoff
+	for _, condition := range conditions {
+		for {
+			err := checkStartCondition(ctx, condition)
+			if err == nil {
+				logrus.Debugf("Start condition %s of container %s is met", condition, c.ID())
+				break
+			}
+			logrus.Debugf("Start condition %s of container %s is not met, checking again in %s: %v", condition, c.ID(), backoff, err)
+
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return fmt.Errorf("container %s: %s not met after %s: %v: %w", c.ID(), condition, c.config.StartConditionsTimeout, err, define.ErrStartConditionNotMet)
+				}
+				return fmt.Errorf("container %s: waiting for %s: %w", c.ID(), condition, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, startConditionMaxBackoff)
+		}
+	}
+	return nil
+}
+
+// checkStartCondition returns an error if the start condition is not met.
+func checkStartCondition(ctx context.Context, condition define.StartCondition) error {
+	switch condition.Type {
+	case define.StartConditionPathExists:
+		_, err := os.Stat(condition.Value)
+		return err
+	case define.StartConditionTCP:
+		ctx, cancel := context.WithTimeout(ctx, startConditionMaxBackoff)
+		defer cancel()
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", condition.Value)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	default:
+		return fmt.Errorf("unsupported start condition type %q: %w", condition.Type, define.ErrInvalidArg)
+	}
+}
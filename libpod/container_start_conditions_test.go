@@ -0,0 +1,38 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStartCondition(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	assert.NoError(t, checkStartCondition(ctx, define.StartCondition{Type: define.StartConditionTCP, Value: addr}))
+	listener.Close()
+	assert.Error(t, checkStartCondition(ctx, define.StartCondition{Type: define.StartConditionTCP, Value: addr}))
+
+	assert.NoError(t, checkStartCondition(ctx, define.StartCondition{Type: define.StartConditionPathExists, Value: dir}))
+	assert.Error(t, checkStartCondition(ctx, define.StartCondition{Type: define.StartConditionPathExists, Value: filepath.Join(dir, "missing")}))
+
+	for _, invalid := range []string{"", "tcp", "tcp=", "tcp=localhost", "tcp=localhost:0", "tcp=localhost:http", "path-exists=relative", "port=80"} {
+		_, err := define.ParseStartCondition(invalid)
+		assert.ErrorIs(t, err, define.ErrInvalidArg, invalid)
+	}
+	condition, err := define.ParseStartCondition("tcp=[::1]:5432")
+	require.NoError(t, err)
+	assert.Equal(t, define.StartCondition{Type: define.StartConditionTCP, Value: "[::1]:5432"}, condition)
+	assert.Equal(t, "tcp=[::1]:5432", condition.String())
+}
@@ -45,6 +45,12 @@ func (c *Container) validate() error {
 		return fmt.Errorf("cannot both create a network namespace and join another container's network namespace: %w", define.ErrInvalidArg)
 	}
 
+	// Opting out of the pod network namespace requires a pod and a
+	// network namespace of its own.
+	if c.config.PodNetworkOptOut && (c.config.Pod == "" || c.config.NetNsCtr != "") {
+		return fmt.Errorf("only containers in a pod not joining another container's network namespace can opt out of the pod network: %w", define.ErrInvalidArg)
+	}
+
 	if c.config.CgroupsMode == cgroupSplit && c.config.CgroupParent != "" {
 		return fmt.Errorf("cannot specify --cgroup-mode=split with a cgroup-parent: %w", define.ErrInvalidArg)
 	}
@@ -158,6 +164,27 @@ func (c *Container) validate() error {
 		}
 	}
 
+	// Listen sockets are passed as the first file descriptors after 0, 1
+	// and 2, which preserved file descriptors would overwrite.
+	if len(c.config.ListenSockets) > 0 {
+		if c.config.PreserveFDs > 0 || len(c.config.PreserveFD) > 0 {
+			return fmt.Errorf("cannot publish sockets and preserve file descriptors at the same time: %w", define.ErrInvalidArg)
+		}
+		seen := make(map[string]bool, len(c.config.ListenSockets))
+		for _, socket := range c.config.ListenSockets {
+			if socket.Protocol != "tcp" && socket.Protocol != "udp" {
+				return fmt.Errorf("cannot publish socket %s, the protocol must be tcp or udp: %w", socket, define.ErrInvalidArg)
+			}
+			if socket.Port == 0 {
+				return fmt.Errorf("cannot publish socket %s, a port must be given: %w", socket, define.ErrInvalidArg)
+			}
+			if seen[socket.String()] {
+				return fmt.Errorf("socket %s is published twice: %w", socket, define.ErrInvalidArg)
+			}
+			seen[socket.String()] = true
+		}
+	}
+
 	// Cannot set startup HC without a healthcheck
 	if c.config.HealthCheckConfig == nil && c.config.StartupHealthCheckConfig != nil {
 		return fmt.Errorf("cannot set a startup healthcheck when there is no regular healthcheck: %w", define.ErrInvalidArg)
@@ -0,0 +1,39 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+
+	"github.com/containers/podman/v5/pkg/specgenutil"
+	"github.com/sirupsen/logrus"
+)
+
+// startProcessWatcher launches a Podman process in the background which
+// writes process events for the container until it stops, see
+// WatchProcesses.
+func (c *Container) startProcessWatcher() error {
+	args, err := specgenutil.CreateProcessWatcherCommandArgs(c.runtime.storageConfig, c.runtime.config, c.runtime.syslog || logrus.IsLevelEnabled(logrus.DebugLevel))
+	if err != nil {
+		return fmt.Errorf("creating process watcher command: %w", err)
+	}
+	args = append(args, c.ID())
+
+	cmd := exec.Command(args[0], args[1:]...)
+	// Do not let signals to our process group, e.g. on Ctrl-C of an
+	// attached session, take down the watcher.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting process watcher: %w", err)
+	}
+	logrus.Debugf("Started process watcher for container %s with PID %d", c.ID(), cmd.Process.Pid)
+
+	// Reap the watcher if we are still around when it exits, e.g. when
+	// running as a service.
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
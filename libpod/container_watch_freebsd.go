@@ -0,0 +1,16 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// WatchProcesses writes a process event whenever a process starts or exits
+// inside the container.  It is not supported on FreeBSD.
+func (c *Container) WatchProcesses(ctx context.Context) error {
+	return fmt.Errorf("watching container processes: %w", define.ErrNotImplemented)
+}
@@ -0,0 +1,117 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/events"
+)
+
+// processWatchInterval is how often WatchProcesses lists the processes of
+// the container.
+const processWatchInterval = 250 * time.Millisecond
+
+// WatchProcesses writes a process event whenever a process starts or exits
+// inside the container, until the container stops or ctx is canceled.
+// Processes are found by listing the container's cgroup, so processes living
+// shorter than the watch interval may not be reported.
+func (c *Container) WatchProcesses(ctx context.Context) error {
+	cgroupPath, err := c.CgroupPath()
+	if err != nil {
+		return err
+	}
+	cgroupDir := cgroupFullPath(cgroupPath)
+
+	ticker := time.NewTicker(processWatchInterval)
+	defer ticker.Stop()
+
+	// Commands of the known processes by PID.
+	known := make(map[int]string)
+	for {
+		pids, err := cgroupProcesses(cgroupDir)
+		// The cgroup is removed once the container stopped.
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("listing processes of container %s: %w", c.ID(), err)
+		}
+
+		current := make(map[int]bool, len(pids))
+		for _, pid := range pids {
+			current[pid] = true
+			if _, ok := known[pid]; !ok {
+				command := processCommand(pid)
+				known[pid] = command
+				c.newProcessEvent(events.Start, pid, command)
+			}
+		}
+		for pid, command := range known {
+			if !current[pid] {
+				delete(known, pid)
+				c.newProcessEvent(events.Exited, pid, command)
+			}
+		}
+
+		if len(pids) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// cgroupProcesses returns the PIDs of the processes in the cgroup at dir and
+// all of its descendants.
+func cgroupProcesses(dir string) ([]int, error) {
+	var pids []int
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Sub-cgroups may go away while walking them.
+			if path != dir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(filepath.Join(path, "cgroup.procs"))
+		if err != nil {
+			if path != dir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		for _, line := range bytes.Fields(content) {
+			pid, err := strconv.Atoi(string(line))
+			if err != nil {
+				return fmt.Errorf("parsing PID %q in %s: %w", line, path, err)
+			}
+			pids = append(pids, pid)
+		}
+		return nil
+	})
+	return pids, err
+}
+
+// processCommand returns the command name of the process with the given
+// PID, or "" if the process is gone.
+func processCommand(pid int) string {
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
@@ -0,0 +1,30 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCgroupProcesses(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup.procs"), []byte("1\n15\n"), 0o644))
+	sub := filepath.Join(dir, "init.scope")
+	require.NoError(t, os.Mkdir(sub, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, "cgroup.procs"), []byte("42\n"), 0o644))
+	// An empty sub-cgroup.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "empty"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty", "cgroup.procs"), nil, 0o644))
+
+	pids, err := cgroupProcesses(dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 15, 42}, pids)
+
+	_, err = cgroupProcesses(filepath.Join(dir, "gone"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
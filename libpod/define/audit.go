@@ -0,0 +1,51 @@
+package define
+
+import "time"
+
+const (
+	// AuditSourceState marks audit entries of modifications of the
+	// container, pod, volume and network state.
+	AuditSourceState = "state"
+	// AuditSourceAPI marks audit entries of modifying requests to the API
+	// service.
+	AuditSourceAPI = "api"
+	// AuditSourcePolicy marks audit entries of images denied by the image
+	// usage policy.
+	AuditSourcePolicy = "policy"
+)
+
+// AuditEntry is a single entry of the audit log, recording who modified what
+// and when.
+type AuditEntry struct {
+	// Time is the time of the modification.
+	Time time.Time `json:"time"`
+	// Source is AuditSourceState, AuditSourceAPI or AuditSourcePolicy.
+	Source string `json:"source"`
+	// UID is the user ID of the Podman process making the modification,
+	// or of the API client, -1 if the UID of the client is unknown.
+	UID int `json:"uid"`
+	// PID is the process ID of the Podman process making the
+	// modification.
+	PID int `json:"pid"`
+	// Identity identifies the API client, e.g. by its socket peer UID or
+	// TLS client certificate common name.
+	Identity string `json:"identity,omitempty"`
+	// Operation is the state operation, e.g. AddContainer, or the method
+	// of the API request.
+	Operation string `json:"operation"`
+	// Type is the type of the modified object, e.g. container or pod.
+	Type string `json:"type,omitempty"`
+	// ID is the ID of the modified object.
+	ID string `json:"id,omitempty"`
+	// Name is the name of the modified object.
+	Name string `json:"name,omitempty"`
+	// Detail describes the modification, e.g. the network a container was
+	// connected to or the path of an API request.
+	Detail string `json:"detail,omitempty"`
+	// RequestID is the X-Reference-Id of an API request.
+	RequestID string `json:"requestID,omitempty"`
+	// Status is the HTTP status code of an API request.
+	Status int `json:"status,omitempty"`
+	// Error is the error the modification failed with, if any.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,28 @@
+package define
+
+import "time"
+
+// CheckpointRecord records a checkpoint of a container exported to a file,
+// so exported checkpoints can be listed and pruned.
+type CheckpointRecord struct {
+	// ID identifies the record, it is set when the record is added.
+	ID int64 `json:"id"`
+	// ContainerID is the ID of the checkpointed container.
+	ContainerID string `json:"containerID"`
+	// ContainerName is the name of the checkpointed container.
+	ContainerName string `json:"containerName"`
+	// Path is the absolute path of the exported checkpoint archive.
+	Path string `json:"path"`
+	// Created is the time the checkpoint was exported.
+	Created time.Time `json:"created"`
+	// Compression is the compression of the archive, e.g. zstd.
+	Compression string `json:"compression"`
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size"`
+	// PreCheckpoint is set for pre-checkpoints, which dump the memory of
+	// the container while leaving it running.
+	PreCheckpoint bool `json:"preCheckpoint,omitempty"`
+	// ParentID is the ID of the record of the pre-checkpoint a checkpoint
+	// created with --with-previous is based on, 0 if there is none.
+	ParentID int64 `json:"parentID,omitempty"`
+}
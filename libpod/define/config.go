@@ -41,6 +41,25 @@ const VolumeDriverLocal = "local"
 // uses volumes backed by an image.
 const VolumeDriverImage = "image"
 
+const (
+	// VolumeDriverNFS is the "nfs" volume driver. It is managed by Libpod
+	// and mounts an NFS export.
+	VolumeDriverNFS = "nfs"
+	// VolumeDriverCIFS is the "cifs" volume driver. It is managed by
+	// Libpod and mounts a CIFS share.
+	VolumeDriverCIFS = "cifs"
+)
+
+const (
+	// VolumeQuotaProject limits the size and inodes of a local volume
+	// with a project quota of the file system holding the volumes.
+	VolumeQuotaProject = "project"
+	// VolumeQuotaLoopback limits the size and inodes of a local volume
+	// with a file system of its own, mounted from an image file with a
+	// loop device.
+	VolumeQuotaLoopback = "loopback"
+)
+
 const (
 	OCIManifestDir  = "oci-dir"
 	OCIArchive      = "oci-archive"
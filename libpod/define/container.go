@@ -41,6 +41,11 @@ func ValidateRestartPolicy(policy string) error {
 	}
 }
 
+// HostGatewayAllNetworkOption is the network option set on containers
+// created with --network host-gateway-all.  They join the host network
+// namespace and have their ports published to the podman machine host.
+const HostGatewayAllNetworkOption = "gateway-all"
+
 // InitContainerTypes
 const (
 	// AlwaysInitContainer is an init container that runs on each
@@ -0,0 +1,11 @@
+package define
+
+import "time"
+
+// ContainerExit is an exit of a container recorded in the database.
+type ContainerExit struct {
+	// ExitCode is the exit code of the container.
+	ExitCode int32 `json:"ExitCode"`
+	// ExitedAt is the time the exit code was recorded.
+	ExitedAt time.Time `json:"ExitedAt"`
+}
@@ -0,0 +1,20 @@
+package define
+
+import "time"
+
+// ContainerGroupPrefix marks a container group where a container name or ID
+// is accepted, as in @web.
+const ContainerGroupPrefix = "@"
+
+// ContainerGroup is a named set of container filters. The group contains the
+// containers matching all of its filters when it is used, so containers join
+// and leave the group as they are created, removed or changed.
+type ContainerGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+	// Filters are the container filters selecting the members of the
+	// group, as accepted by podman ps.
+	Filters map[string][]string `json:"filters"`
+	// Created is the time the group was created.
+	Created time.Time `json:"created"`
+}
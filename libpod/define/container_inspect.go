@@ -61,6 +61,22 @@ type InspectContainerConfig struct {
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 	// HealthcheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthcheckOnFailureAction string `json:"HealthcheckOnFailureAction,omitempty"`
+	// OnExitNotify are the notifiers notified when the container exits.
+	OnExitNotify []string `json:"OnExitNotify,omitempty"`
+	// StartConditions are the conditions on the host which must be met
+	// before the container is started.
+	StartConditions []string `json:"StartConditions,omitempty"`
+	// ProxyProfile is the name of the proxy profile injected into the
+	// container.
+	ProxyProfile string `json:"ProxyProfile,omitempty"`
+	// SecurityProfile is the security profile enforced on the container.
+	SecurityProfile string `json:"SecurityProfile,omitempty"`
+	// SecurityProfileOverridden is the default security profile of
+	// containers.conf overridden by the container, if any.
+	SecurityProfileOverridden string `json:"SecurityProfileOverridden,omitempty"`
+	// Keyring is the keyring mode of the container: private, inherit or
+	// none.
+	Keyring string `json:"Keyring,omitempty"`
 	// CreateCommand is the full command plus arguments of the process the
 	// container has been created with.
 	CreateCommand []string `json:"CreateCommand,omitempty"`
@@ -301,9 +317,28 @@ type InspectContainerState struct {
 	RestoredAt     time.Time           `json:"RestoredAt,omitempty"`
 	CheckpointLog  string              `json:"CheckpointLog,omitempty"`
 	CheckpointPath string              `json:"CheckpointPath,omitempty"`
-	RestoreLog     string              `json:"RestoreLog,omitempty"`
-	Restored       bool                `json:"Restored,omitempty"`
-	StoppedByUser  bool                `json:"StoppedByUser,omitempty"`
+	// CheckpointChain are the paths of the pre-dumps the checkpoint of
+	// the container is based on, oldest first.
+	CheckpointChain []string `json:"CheckpointChain,omitempty"`
+	RestoreLog      string   `json:"RestoreLog,omitempty"`
+	Restored        bool     `json:"Restored,omitempty"`
+	StoppedByUser   bool     `json:"StoppedByUser,omitempty"`
+	// Provisioned is set once the provision script of the container
+	// succeeded.
+	Provisioned bool `json:"Provisioned,omitempty"`
+	// CgroupFullPath is the location of the container's cgroup in the
+	// cgroup filesystem.
+	CgroupFullPath string `json:"CgroupFullPath,omitempty"`
+	// CgroupUnit and CgroupSlice are the systemd unit and slice the
+	// container's cgroup belongs to.
+	CgroupUnit  string `json:"CgroupUnit,omitempty"`
+	CgroupSlice string `json:"CgroupSlice,omitempty"`
+	// CgroupDelegated is set if systemd delegated the cgroup subtree of
+	// CgroupUnit.
+	CgroupDelegated bool `json:"CgroupDelegated,omitempty"`
+	// KeyringKeys is the number of keys in the session keyring of a
+	// running container with a private keyring, if it is visible.
+	KeyringKeys *int `json:"KeyringKeys,omitempty"`
 }
 
 // Healthcheck returns the HealthCheckResults. This is used for old podman compat
@@ -728,6 +763,9 @@ type InspectNetworkSettings struct {
 	// container has joined.
 	// It is a map of network name to network information.
 	Networks map[string]*InspectAdditionalNetwork `json:"Networks,omitempty"`
+	// Disturbances are the latencies and packet losses currently injected
+	// in the network of the container by `podman network disturb`.
+	Disturbances []NetworkDisturbance `json:"Disturbances,omitempty"`
 }
 
 // InspectContainerData provides a detailed record of a container's configuration
@@ -746,6 +784,7 @@ type InspectContainerData struct {
 	ImageName               string                      `json:"ImageName"`
 	Rootfs                  string                      `json:"Rootfs"`
 	Pod                     string                      `json:"Pod"`
+	PodNetworkOptOut        bool                        `json:"PodNetworkOptOut,omitempty"`
 	ResolvConfPath          string                      `json:"ResolvConfPath"`
 	HostnamePath            string                      `json:"HostnamePath"`
 	HostsPath               string                      `json:"HostsPath"`
@@ -766,6 +805,7 @@ type InspectContainerData struct {
 	GraphDriver             *DriverData                 `json:"GraphDriver"`
 	SizeRw                  *int64                      `json:"SizeRw,omitempty"`
 	SizeRootFs              int64                       `json:"SizeRootFs,omitempty"`
+	SizeComputedAt          *time.Time                  `json:"SizeComputedAt,omitempty"`
 	Mounts                  []InspectMount              `json:"Mounts"`
 	Dependencies            []string                    `json:"Dependencies"`
 	NetworkSettings         *InspectNetworkSettings     `json:"NetworkSettings"`
@@ -773,9 +813,13 @@ type InspectContainerData struct {
 	IsInfra                 bool                        `json:"IsInfra"`
 	IsService               bool                        `json:"IsService"`
 	KubeExitCodePropagation string                      `json:"KubeExitCodePropagation"`
+	ExitHistory             []ContainerExit             `json:"ExitHistory,omitempty"`
 	LockNumber              uint32                      `json:"lockNumber"`
 	Config                  *InspectContainerConfig     `json:"Config"`
 	HostConfig              *InspectContainerHostConfig `json:"HostConfig"`
+	// EffectiveConfig is the snapshot of the containers.conf settings the
+	// container was created with. It is only set if requested.
+	EffectiveConfig map[string]any `json:"EffectiveConfig,omitempty"`
 }
 
 // InspectExecSession contains information about a given exec session.
@@ -0,0 +1,14 @@
+package define
+
+import "time"
+
+// ContainerNote is a free-form note an operator attached to a container,
+// e.g. to record maintenance done on it.
+type ContainerNote struct {
+	// ID identifies the note, it is set when the note is added.
+	ID int64 `json:"id"`
+	// Created is the time the note was added.
+	Created time.Time `json:"created"`
+	// Text is the content of the note.
+	Text string `json:"text"`
+}
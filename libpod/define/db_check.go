@@ -0,0 +1,29 @@
+package define
+
+// DBCheckReport describes the problems found by a consistency check of the
+// database.
+type DBCheckReport struct {
+	// Backend is the database backend which was checked.
+	Backend string `json:"backend"`
+	// Errors are problems with the database itself, e.g. a failed SQLite
+	// integrity check or entries which cannot be resolved.
+	Errors []string `json:"errors,omitempty"`
+	// OrphanExitCodes are the IDs of removed containers whose exit codes
+	// are kept longer than the 5 minutes they are needed for.
+	OrphanExitCodes []string `json:"orphanExitCodes,omitempty"`
+	// OrphanExecSessions are the IDs of exec sessions whose container
+	// does not exist.
+	OrphanExecSessions []string `json:"orphanExecSessions,omitempty"`
+	// OrphanDependencies are dependencies between containers of which at
+	// least one does not exist, as "CONTAINER -> DEPENDENCY".
+	OrphanDependencies []string `json:"orphanDependencies,omitempty"`
+	// MissingStorage are the IDs of containers whose storage is missing
+	// in c/storage.
+	MissingStorage []string `json:"missingStorage,omitempty"`
+}
+
+// HasProblems returns true if the check found any problem.
+func (r *DBCheckReport) HasProblems() bool {
+	return len(r.Errors) > 0 || len(r.OrphanExitCodes) > 0 || len(r.OrphanExecSessions) > 0 ||
+		len(r.OrphanDependencies) > 0 || len(r.MissingStorage) > 0
+}
@@ -0,0 +1,15 @@
+package define
+
+// DBMigrationReport describes a migration of the database to a new backend.
+type DBMigrationReport struct {
+	// Backend is the database backend migrated to.
+	Backend string `json:"backend"`
+	// Path is the path of the migrated database.
+	Path string `json:"path"`
+	// Containers is the number of migrated containers.
+	Containers int `json:"containers"`
+	// Pods is the number of migrated pods.
+	Pods int `json:"pods"`
+	// Volumes is the number of migrated volumes.
+	Volumes int `json:"volumes"`
+}
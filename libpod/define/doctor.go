@@ -0,0 +1,44 @@
+package define
+
+// DoctorStatus is the outcome of a single check of podman doctor.
+type DoctorStatus string
+
+const (
+	// DoctorPass means the check found no problem.
+	DoctorPass DoctorStatus = "pass"
+	// DoctorWarn means the check found something which works, but is
+	// known to cause trouble or poor performance.
+	DoctorWarn DoctorStatus = "warn"
+	// DoctorFail means the check found a problem which breaks containers.
+	DoctorFail DoctorStatus = "fail"
+	// DoctorSkip means the check does not apply to this host.
+	DoctorSkip DoctorStatus = "skip"
+)
+
+// DoctorCheck is the result of a single environment check.
+type DoctorCheck struct {
+	// Name is a short name of the check.
+	Name string `json:"name"`
+	// Status is the outcome of the check.
+	Status DoctorStatus `json:"status"`
+	// Message describes what was found.
+	Message string `json:"message"`
+	// Remediation describes how to fix the problem. It is only set if
+	// the check did not pass.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// DoctorReport holds the results of all checks of podman doctor.
+type DoctorReport struct {
+	Checks []DoctorCheck `json:"checks"`
+}
+
+// Failed returns true if any check failed.
+func (r *DoctorReport) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == DoctorFail {
+			return true
+		}
+	}
+	return false
+}
@@ -29,6 +29,46 @@ var (
 	// does not exist.
 	ErrNoSuchExitCode = errors.New("no such exit code")
 
+	// ErrNoSuchImageDigestPin indicates that no digest has been pinned for
+	// the requested image source.
+	ErrNoSuchImageDigestPin = errors.New("no digest pinned for image")
+
+	// ErrNoSuchVolumeBackup indicates that the requested volume backup or
+	// volume backup policy does not exist.
+	ErrNoSuchVolumeBackup = errors.New("no such volume backup")
+
+	// ErrNoSuchVolumeSnapshot indicates that the requested volume snapshot
+	// does not exist.
+	ErrNoSuchVolumeSnapshot = errors.New("no such volume snapshot")
+
+	// ErrNoSuchNetworkReservation indicates that the requested network
+	// address is not reserved.
+	ErrNoSuchNetworkReservation = errors.New("no such network reservation")
+
+	// ErrNetworkReserved indicates that a network address is reserved for
+	// another container.
+	ErrNetworkReserved = errors.New("network address is reserved")
+
+	// ErrNoSuchContainerGroup indicates that the requested container group
+	// does not exist.
+	ErrNoSuchContainerGroup = errors.New("no such container group")
+
+	// ErrContainerGroupExists indicates that a container group with the
+	// same name already exists.
+	ErrContainerGroupExists = errors.New("container group already exists")
+
+	// ErrPortInUse indicates that a host port published by a container is
+	// already in use by another container or a process on the host.
+	ErrPortInUse = errors.New("port is already in use")
+
+	// ErrNoSuchContainerNote indicates that the requested note of a
+	// container does not exist.
+	ErrNoSuchContainerNote = errors.New("no such container note")
+
+	// ErrNoSuchCheckpoint indicates that the requested checkpoint record
+	// does not exist.
+	ErrNoSuchCheckpoint = errors.New("no such checkpoint")
+
 	// ErrDepExists indicates that the current object has dependencies and
 	// cannot be removed before them.
 	ErrDepExists = errors.New("dependency exists")
@@ -97,6 +137,10 @@ var (
 	// the user.
 	ErrDetach = detach.ErrDetach
 
+	// ErrAttachCanceled indicates that an HTTP attach session was closed
+	// by the server, e.g. as the API service is shutting down.
+	ErrAttachCanceled = errors.New("attach session canceled by the server")
+
 	// ErrWillDeadlock indicates that the requested operation will cause a
 	// deadlock. This is usually caused by upgrade issues, and is resolved
 	// by renumbering the locks.
@@ -167,6 +211,10 @@ var (
 	// could not be found in the configuration
 	ErrOCIRuntimeUnavailable = errors.New("OCI unavailable")
 
+	// ErrPlatformNotSupported indicates that the host can neither run
+	// the platform of an image natively nor through emulation
+	ErrPlatformNotSupported = errors.New("image platform not supported by the host")
+
 	// ErrConmonOutdated indicates the version of conmon found (whether via the configuration or $PATH)
 	// is out of date for the current podman version
 	ErrConmonOutdated = errors.New("outdated conmon version")
@@ -217,4 +265,16 @@ var (
 	// ErrRemovingCtrs indicates that there was an error removing all
 	// containers from a pod.
 	ErrRemovingCtrs = errors.New("removing pod containers")
+
+	// ErrImageDigestChanged indicates that the digest of a pulled image
+	// differs from the digest pinned for its source on first use.
+	ErrImageDigestChanged = errors.New("image digest changed")
+
+	// ErrImageDenied indicates that an image may not be pulled or used to
+	// create a container according to the image usage policy.
+	ErrImageDenied = errors.New("image denied by usage policy")
+
+	// ErrStartConditionNotMet indicates that a start condition of a
+	// container was not met before its timeout expired.
+	ErrStartConditionNotMet = errors.New("start condition not met")
 )
@@ -0,0 +1,86 @@
+package define
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ExitNotifierType is the kind of an on-exit notifier.
+type ExitNotifierType string
+
+const (
+	// ExitNotifyWebhook POSTs the notification to an HTTP(S) URL.
+	ExitNotifyWebhook ExitNotifierType = "webhook"
+	// ExitNotifyScript runs a script on the host with the notification on
+	// its standard input.
+	ExitNotifyScript ExitNotifierType = "script"
+	// ExitNotifyEmail sends the notification to an e-mail address using
+	// the sendmail command of the host.
+	ExitNotifyEmail ExitNotifierType = "email"
+)
+
+// ExitNotifier is a notification target of --on-exit-notify.
+type ExitNotifier struct {
+	Type ExitNotifierType
+	// Target is the URL, absolute script path or e-mail address.
+	Target string
+}
+
+// String returns the notifier in the TYPE=TARGET form it is parsed from.
+func (n ExitNotifier) String() string {
+	return string(n.Type) + "=" + n.Target
+}
+
+// ParseExitNotifier parses a notifier given as TYPE=TARGET.
+func ParseExitNotifier(s string) (ExitNotifier, error) {
+	kind, target, ok := strings.Cut(s, "=")
+	if !ok || target == "" {
+		return ExitNotifier{}, fmt.Errorf("invalid on-exit notifier %q, must be webhook=URL, script=PATH or email=ADDRESS: %w", s, ErrInvalidArg)
+	}
+	n := ExitNotifier{Type: ExitNotifierType(kind), Target: target}
+	switch n.Type {
+	case ExitNotifyWebhook:
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ExitNotifier{}, fmt.Errorf("invalid on-exit webhook %q, must be an http or https URL: %w", target, ErrInvalidArg)
+		}
+	case ExitNotifyScript:
+		if !filepath.IsAbs(target) {
+			return ExitNotifier{}, fmt.Errorf("invalid on-exit script %q, must be an absolute path: %w", target, ErrInvalidArg)
+		}
+	case ExitNotifyEmail:
+		if _, err := mail.ParseAddress(target); err != nil {
+			return ExitNotifier{}, fmt.Errorf("invalid on-exit e-mail address %q: %w", target, ErrInvalidArg)
+		}
+	default:
+		return ExitNotifier{}, fmt.Errorf("invalid on-exit notifier type %q, must be webhook, script or email: %w", kind, ErrInvalidArg)
+	}
+	return n, nil
+}
+
+// ExitNotification is the data sent by the on-exit notifiers, and the data
+// of the --on-exit-notify-template template.
+type ExitNotification struct {
+	ID        string
+	Name      string
+	Image     string
+	ExitCode  int32
+	OOMKilled bool
+	ExitedAt  time.Time
+	// Logs are the last lines logged by the container.
+	Logs []string
+}
+
+// ParseExitNotifyTemplate parses the template of the on-exit notifications.
+func ParseExitNotifyTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("on-exit-notify").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid on-exit notification template: %v: %w", err, ErrInvalidArg)
+	}
+	return t, nil
+}
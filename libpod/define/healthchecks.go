@@ -145,3 +145,11 @@ type StartupHealthCheck struct {
 	// If set to 0, a single success will mark the HC as passed.
 	Successes int `json:",omitempty"`
 }
+
+// HealthCheckHistoryEntry is a healthcheck run of a container recorded in
+// the database.
+type HealthCheckHistoryEntry struct {
+	HealthCheckLog
+	// Status is the health status of the container after the run.
+	Status string `json:"Status"`
+}
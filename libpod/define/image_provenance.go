@@ -0,0 +1,16 @@
+package define
+
+import "time"
+
+// ImageProvenance records where an image was pulled from.
+type ImageProvenance struct {
+	// Source is the reference the image was pulled from. It includes the
+	// transport unless the image was pulled from a registry.
+	Source string `json:"source"`
+	// ImageID is the ID of the pulled image.
+	ImageID string `json:"imageID"`
+	// Digest is the digest of the manifest of the pulled image.
+	Digest string `json:"digest"`
+	// Pulled is the time the image was pulled.
+	Pulled time.Time `json:"pulled"`
+}
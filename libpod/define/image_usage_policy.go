@@ -0,0 +1,20 @@
+package define
+
+// ImageUsagePolicy restricts the images which can be pulled and used to create
+// containers on locked-down hosts. It is read from the system-wide and the
+// per-user image usage policy files, an image has to satisfy both.
+type ImageUsagePolicy struct {
+	// Allow lists patterns of the repositories images may come from. If it
+	// is empty, all repositories which are not denied are allowed.
+	Allow []string `json:"allow,omitempty"`
+	// Deny lists patterns of the repositories images must not come from.
+	// It takes precedence over Allow.
+	Deny []string `json:"deny,omitempty"`
+	// RequireSignatures lists patterns of the repositories whose images
+	// must be verified by a signature when they are pulled, i.e. the
+	// signature policy must not accept them unsigned.
+	RequireSignatures []string `json:"requireSignatures,omitempty"`
+	// MaxAge is the maximum age of an image by its creation time, as a
+	// duration, e.g. "2160h".
+	MaxAge string `json:"maxAge,omitempty"`
+}
@@ -1,6 +1,8 @@
 package define
 
 import (
+	"time"
+
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/storage/pkg/idtools"
 )
@@ -9,11 +11,48 @@ import (
 // running libpod/podman
 // swagger:model LibpodInfo
 type Info struct {
-	Host       *HostInfo              `json:"host"`
-	Store      *StoreInfo             `json:"store"`
-	Registries map[string]interface{} `json:"registries"`
-	Plugins    Plugins                `json:"plugins"`
-	Version    Version                `json:"version"`
+	DatabaseBackend *DatabaseBackendInfo   `json:"databaseBackend,omitempty"`
+	Host            *HostInfo              `json:"host"`
+	Store           *StoreInfo             `json:"store"`
+	Registries      map[string]interface{} `json:"registries"`
+	Plugins         Plugins                `json:"plugins"`
+	Version         Version                `json:"version"`
+}
+
+// Integrity status of the database, as found by the last consistency check.
+const (
+	// DBIntegrityOK means the last check found no problems.
+	DBIntegrityOK = "ok"
+	// DBIntegrityProblems means the last check found problems, see
+	// podman system check --db.
+	DBIntegrityProblems = "problems found"
+	// DBIntegrityUnchecked means the database was never checked.
+	DBIntegrityUnchecked = "unchecked"
+)
+
+// DatabaseBackendInfo describes the database holding the state of libpod
+type DatabaseBackendInfo struct {
+	// Type is the database backend, e.g. sqlite.
+	Type string `json:"type"`
+	// Path is the database file, empty for PostgreSQL.
+	Path string `json:"path,omitempty"`
+	// SchemaVersion is the version of the schema of the database, 0 for
+	// BoltDB.
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+	// Size is the size of the database in bytes.
+	Size int64 `json:"size"`
+	// WALSize is the size of the write-ahead log in bytes.
+	WALSize int64 `json:"walSize"`
+	// LastVacuum is the time the database was last vacuumed.
+	LastVacuum *time.Time `json:"lastVacuum,omitempty"`
+	// LastBackup is the time the database was last backed up.
+	LastBackup *time.Time `json:"lastBackup,omitempty"`
+	// LastCheck is the time the consistency of the database was last
+	// checked.
+	LastCheck *time.Time `json:"lastCheck,omitempty"`
+	// Integrity is the result of the last consistency check, one of the
+	// DBIntegrity constants.
+	Integrity string `json:"integrity"`
 }
 
 // SecurityInfo describes the libpod host
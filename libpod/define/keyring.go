@@ -0,0 +1,32 @@
+package define
+
+import "fmt"
+
+const (
+	// KeyringPrivate gives the container a new session keyring, created
+	// by the OCI runtime.
+	KeyringPrivate = "private"
+	// KeyringInherit makes the container share the session keyring of
+	// the Podman process creating it, e.g. for Kerberos credentials.
+	KeyringInherit = "inherit"
+	// KeyringNone denies the container access to kernel keyrings by
+	// blocking the keyring system calls with seccomp.
+	KeyringNone = "none"
+)
+
+// KeyringModes are the supported keyring modes of containers.
+var KeyringModes = []string{KeyringPrivate, KeyringInherit, KeyringNone}
+
+// KeyringSyscalls are the system calls blocked for containers with the
+// KeyringNone keyring mode.
+var KeyringSyscalls = []string{"add_key", "keyctl", "request_key"}
+
+// ValidateKeyring returns an error if the keyring mode is not supported.
+func ValidateKeyring(mode string) error {
+	switch mode {
+	case KeyringPrivate, KeyringInherit, KeyringNone:
+		return nil
+	default:
+		return fmt.Errorf("unsupported keyring mode %q, must be one of %v: %w", mode, KeyringModes, ErrInvalidArg)
+	}
+}
@@ -0,0 +1,29 @@
+package define
+
+import (
+	"net"
+	"strconv"
+)
+
+// ListenSocket is a socket Podman binds on the host and passes to the
+// container using socket activation, see sd_listen_fds(3). Podman keeps the
+// socket open while the container runs, so it can be handed over to the
+// replacement of the container without refusing connections.
+type ListenSocket struct {
+	// HostIP is the IP the socket is bound to, all IPs if empty.
+	HostIP string `json:"hostIP,omitempty"`
+	// Port is the port the socket is bound to.
+	Port uint16 `json:"port"`
+	// Protocol is tcp or udp.
+	Protocol string `json:"protocol"`
+}
+
+// Address returns the address of the socket in the format of net.Listen.
+func (s ListenSocket) Address() string {
+	return net.JoinHostPort(s.HostIP, strconv.Itoa(int(s.Port)))
+}
+
+// String returns the socket in the format of --publish-socket.
+func (s ListenSocket) String() string {
+	return s.Address() + "/" + s.Protocol
+}
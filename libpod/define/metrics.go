@@ -0,0 +1,50 @@
+package define
+
+// StateLatencyBuckets are the upper bounds, in seconds, of the buckets of the
+// latency histograms of state operations.
+var StateLatencyBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+// StateOperationMetrics is the latency histogram of a state database
+// operation.
+type StateOperationMetrics struct {
+	// Operation is the name of the State method.
+	Operation string
+	// Count is the number of calls of the operation.
+	Count uint64
+	// Sum is the total time spent in the operation, in seconds.
+	Sum float64
+	// Buckets are the numbers of calls which took at most the matching
+	// StateLatencyBuckets. The counts are cumulative.
+	Buckets []uint64
+}
+
+// EventCount is the number of events of a type and status written by a
+// runtime.
+type EventCount struct {
+	Type   string
+	Status string
+	Count  uint64
+}
+
+// RuntimeMetrics are the metrics of a runtime, as exposed by the /metrics
+// endpoint of the system service.
+type RuntimeMetrics struct {
+	// ContainerStates is the number of containers in each state.
+	ContainerStates map[string]int
+	// Containers are the resource usage stats of the running containers.
+	Containers []*ContainerStats
+	// Pods is the number of pods.
+	Pods int
+	// Volumes is the number of volumes.
+	Volumes int
+	// Images is the number of images in the local storage.
+	Images int
+	// LayersSize is the size of all layers in the local storage, in bytes.
+	LayersSize int64
+	// StateOperations are the latencies of the state operations made by
+	// the runtime since metrics were enabled, sorted by operation.
+	StateOperations []StateOperationMetrics
+	// Events are the numbers of events written by the runtime since
+	// metrics were enabled, sorted by type and status.
+	Events []EventCount
+}
@@ -0,0 +1,22 @@
+package define
+
+import "time"
+
+// NetworkDisturbance is an impairment of the outgoing traffic of a container
+// interface, injected with the netem queueing discipline to test how the
+// container copes with a degraded network.
+type NetworkDisturbance struct {
+	// Interface is the interface of the container the disturbance is
+	// applied to.
+	Interface string `json:"Interface"`
+	// Latency is the delay added to every outgoing packet, formatted as a
+	// duration.
+	Latency string `json:"Latency,omitempty"`
+	// Loss is the percentage of outgoing packets dropped.
+	Loss float64 `json:"Loss,omitempty"`
+	// Started is when the disturbance was applied.
+	Started time.Time `json:"Started"`
+	// Expires is when the disturbance is reverted. It is zero if the
+	// disturbance lasts until it is cleared.
+	Expires time.Time `json:"Expires,omitempty"`
+}
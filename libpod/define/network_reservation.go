@@ -0,0 +1,17 @@
+package define
+
+import "time"
+
+// NetworkReservation reserves an IP or MAC address of a network for a
+// container or pod, so the address is never assigned to another container.
+type NetworkReservation struct {
+	// Network is the name of the network the address belongs to.
+	Network string `json:"network"`
+	// Address is the reserved IP or MAC address, in canonical form.
+	Address string `json:"address"`
+	// Container is the name of the container or pod the address is
+	// reserved for. The container does not need to exist yet.
+	Container string `json:"container"`
+	// Created is the time the reservation was made.
+	Created time.Time `json:"created"`
+}
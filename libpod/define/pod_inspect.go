@@ -150,4 +150,7 @@ type InspectPodContainerInfo struct {
 	Name string
 	// State is the current status of the container.
 	State string
+	// PodNetworkOptOut is set if the container does not join the network
+	// namespace shared by the pod.
+	PodNetworkOptOut bool `json:",omitempty"`
 }
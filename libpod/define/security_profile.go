@@ -0,0 +1,30 @@
+package define
+
+import "fmt"
+
+const (
+	// SecurityProfilePrivileged does not restrict the container.
+	SecurityProfilePrivileged = "privileged"
+	// SecurityProfileBaseline prevents known privilege escalations,
+	// mirroring the Kubernetes "baseline" Pod Security Standard.
+	SecurityProfileBaseline = "baseline"
+	// SecurityProfileRestricted additionally enforces hardening best
+	// practices, mirroring the Kubernetes "restricted" Pod Security
+	// Standard.
+	SecurityProfileRestricted = "restricted"
+)
+
+// SecurityProfiles are the supported security profiles, from the least to
+// the most restrictive.
+var SecurityProfiles = []string{SecurityProfilePrivileged, SecurityProfileBaseline, SecurityProfileRestricted}
+
+// ValidateSecurityProfile returns an error if the security profile is not
+// supported.
+func ValidateSecurityProfile(profile string) error {
+	switch profile {
+	case SecurityProfilePrivileged, SecurityProfileBaseline, SecurityProfileRestricted:
+		return nil
+	default:
+		return fmt.Errorf("unsupported security profile %q, must be one of %v: %w", profile, SecurityProfiles, ErrInvalidArg)
+	}
+}
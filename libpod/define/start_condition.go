@@ -0,0 +1,64 @@
+package define
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// StartConditionPathExists is met once the path exists on the host.
+	StartConditionPathExists = "path-exists"
+	// StartConditionTCP is met once a TCP connection to the address, in
+	// the host:port form, can be established from the host.
+	StartConditionTCP = "tcp"
+
+	// DefaultStartConditionsTimeout is how long the start conditions of
+	// a container are waited for, unless set otherwise.
+	DefaultStartConditionsTimeout = time.Minute
+)
+
+// StartConditionTypes are the supported types of start conditions.
+var StartConditionTypes = []string{StartConditionPathExists, StartConditionTCP}
+
+// StartCondition is a condition on the host which must be met before the
+// process of a container is started.
+type StartCondition struct {
+	// Type is the type of the condition, e.g. path-exists or tcp.
+	Type string `json:"type"`
+	// Value is the path or address checked by the condition.
+	Value string `json:"value"`
+}
+
+// String returns the condition in the TYPE=VALUE form it is parsed from.
+func (c StartCondition) String() string {
+	return c.Type + "=" + c.Value
+}
+
+// ParseStartCondition parses a start condition in the TYPE=VALUE form.
+func ParseStartCondition(condition string) (StartCondition, error) {
+	conditionType, value, ok := strings.Cut(condition, "=")
+	if !ok || value == "" {
+		return StartCondition{}, fmt.Errorf("invalid start condition %q, must be in the TYPE=VALUE form: %w", condition, ErrInvalidArg)
+	}
+	switch conditionType {
+	case StartConditionPathExists:
+		if !filepath.IsAbs(value) {
+			return StartCondition{}, fmt.Errorf("invalid start condition %q, the path must be absolute: %w", condition, ErrInvalidArg)
+		}
+	case StartConditionTCP:
+		_, port, err := net.SplitHostPort(value)
+		if err != nil {
+			return StartCondition{}, fmt.Errorf("invalid start condition %q, the address must be in the host:port form: %w", condition, ErrInvalidArg)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return StartCondition{}, fmt.Errorf("invalid start condition %q, invalid port %q: %w", condition, port, ErrInvalidArg)
+		}
+	default:
+		return StartCondition{}, fmt.Errorf("unsupported start condition type %q, must be one of %v: %w", conditionType, StartConditionTypes, ErrInvalidArg)
+	}
+	return StartCondition{Type: conditionType, Value: value}, nil
+}
@@ -0,0 +1,43 @@
+package define
+
+import "time"
+
+// StateChangeType is the kind of object changed by a StateChange.
+type StateChangeType string
+
+const (
+	// StateChangeContainer is a change of a container.
+	StateChangeContainer StateChangeType = "container"
+	// StateChangePod is a change of a pod.
+	StateChangePod StateChangeType = "pod"
+	// StateChangeVolume is a change of a volume.
+	StateChangeVolume StateChangeType = "volume"
+)
+
+// StateChangeOperation is what happened to the changed object.
+type StateChangeOperation string
+
+const (
+	// StateChangeCreate means the object was added to the database.
+	StateChangeCreate StateChangeOperation = "create"
+	// StateChangeUpdate means the config or state of the object changed.
+	StateChangeUpdate StateChangeOperation = "update"
+	// StateChangeRemove means the object was removed from the database.
+	StateChangeRemove StateChangeOperation = "remove"
+)
+
+// StateChange is a change of a container, pod or volume recorded by the
+// database, regardless of the process which made it.
+type StateChange struct {
+	// ID increases with every change.
+	ID int64 `json:"id"`
+	// Time is when the change was committed, with millisecond precision.
+	Time time.Time `json:"time"`
+	// Type is the kind of the changed object.
+	Type StateChangeType `json:"type"`
+	// ObjectID is the ID of the container or pod, or the name of the
+	// volume.
+	ObjectID string `json:"objectID"`
+	// Operation is what happened to the object.
+	Operation StateChangeOperation `json:"operation"`
+}
@@ -0,0 +1,96 @@
+package define
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"time"
+)
+
+// VolumeBackupSchedule is how often a volume is backed up.
+type VolumeBackupSchedule string
+
+const (
+	// VolumeBackupHourly backs up a volume every hour.
+	VolumeBackupHourly VolumeBackupSchedule = "hourly"
+	// VolumeBackupDaily backs up a volume every day.
+	VolumeBackupDaily VolumeBackupSchedule = "daily"
+	// VolumeBackupWeekly backs up a volume every week.
+	VolumeBackupWeekly VolumeBackupSchedule = "weekly"
+)
+
+// VolumeBackupSchedules lists the supported schedules, e.g. for completion.
+var VolumeBackupSchedules = []VolumeBackupSchedule{VolumeBackupHourly, VolumeBackupDaily, VolumeBackupWeekly}
+
+// ParseVolumeBackupSchedule parses the given schedule.
+func ParseVolumeBackupSchedule(s string) (VolumeBackupSchedule, error) {
+	switch schedule := VolumeBackupSchedule(s); schedule {
+	case VolumeBackupHourly, VolumeBackupDaily, VolumeBackupWeekly:
+		return schedule, nil
+	default:
+		return "", fmt.Errorf("invalid volume backup schedule %q, must be hourly, daily or weekly: %w", s, ErrInvalidArg)
+	}
+}
+
+// Interval returns the time between two backups of the schedule.
+func (s VolumeBackupSchedule) Interval() time.Duration {
+	switch s {
+	case VolumeBackupHourly:
+		return time.Hour
+	case VolumeBackupWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// ParseVolumeBackupTarget parses the target a volume is backed up to, either
+// an absolute directory on the host or an ssh://[USER@]HOST[:PORT]/DIR URL.
+// It returns nil for a directory on the host.
+func ParseVolumeBackupTarget(target string) (*url.URL, error) {
+	if filepath.IsAbs(target) {
+		return nil, nil
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "ssh" || u.Host == "" || u.Path == "" {
+		return nil, fmt.Errorf("invalid volume backup target %q, must be an absolute path or an ssh://[USER@]HOST[:PORT]/DIR URL: %w", target, ErrInvalidArg)
+	}
+	return u, nil
+}
+
+// VolumeBackupPolicy configures the scheduled backups of a volume.
+type VolumeBackupPolicy struct {
+	// Volume is the name of the backed up volume.
+	Volume string `json:"volume"`
+	// Schedule is how often the volume is backed up.
+	Schedule VolumeBackupSchedule `json:"schedule"`
+	// Keep is the number of backups kept on the target. Older backups are
+	// removed after a new one was created.
+	Keep int `json:"keep"`
+	// Target is the directory the backups are written to, see
+	// ParseVolumeBackupTarget.
+	Target string `json:"target"`
+	// Identity is the SSH private key used for ssh:// targets.
+	Identity string `json:"identity,omitempty"`
+	// Created is the time the backups were enabled.
+	Created time.Time `json:"created"`
+}
+
+// VolumeBackup is a backup archive of a volume.
+type VolumeBackup struct {
+	// ID identifies the backup in the database.
+	ID int64 `json:"id"`
+	// Volume is the name of the backed up volume.
+	Volume string `json:"volume"`
+	// Target is the target of the policy the backup was created for.
+	Target string `json:"target"`
+	// Archive is the path of the zstd compressed tar archive on the
+	// target.
+	Archive string `json:"archive"`
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size"`
+	// Digest is the sha256 digest of the archive.
+	Digest string `json:"digest"`
+	// Created is the time the backup was created.
+	Created time.Time `json:"created"`
+}
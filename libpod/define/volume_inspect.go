@@ -44,6 +44,13 @@ type InspectVolumeData struct {
 	UID int `json:"UID,omitempty"`
 	// GID is the GID that the volume was created with.
 	GID int `json:"GID,omitempty"`
+	// QuotaMethod is how the size and inodes of the volume are limited,
+	// either "project" or "loopback". Empty if the volume is not limited.
+	QuotaMethod string `json:"QuotaMethod,omitempty"`
+	// EncryptionSecret is the name of the secret holding the password the
+	// contents of the volume are encrypted with. Empty if the volume is not
+	// encrypted.
+	EncryptionSecret string `json:"EncryptionSecret,omitempty"`
 	// Anonymous indicates that the volume was created as an anonymous
 	// volume for a specific container, and will be removed when any
 	// container using it is removed.
@@ -61,6 +68,12 @@ type InspectVolumeData struct {
 	// StorageID is the ID of the container backing the volume in c/storage.
 	// Only used with Image Volumes.
 	StorageID string `json:"StorageID,omitempty"`
+	// Image is the ID of the image backing the volume. Only used with Image
+	// Volumes.
+	Image string `json:"Image,omitempty"`
+	// ImageName is the name of the image backing the volume, as resolved
+	// when the volume was created. Only used with Image Volumes.
+	ImageName string `json:"ImageName,omitempty"`
 	// LockNumber is the number of the volume's Libpod lock.
 	LockNumber uint32
 }
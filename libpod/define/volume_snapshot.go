@@ -0,0 +1,32 @@
+package define
+
+import "time"
+
+const (
+	// VolumeSnapshotReflink snapshots clone the files of the volume, so
+	// they share their blocks until either copy is modified. It is used
+	// on file systems supporting reflinks, e.g. btrfs and XFS.
+	VolumeSnapshotReflink = "reflink"
+	// VolumeSnapshotCopy snapshots copy the contents of the volume.
+	VolumeSnapshotCopy = "copy"
+)
+
+// VolumeSnapshot is a point-in-time copy of the contents of a volume.
+type VolumeSnapshot struct {
+	// ID identifies the snapshot in the database.
+	ID int64 `json:"id"`
+	// Volume is the name of the snapshotted volume.
+	Volume string `json:"volume"`
+	// Name of the snapshot, unique among the snapshots of the volume.
+	Name string `json:"name"`
+	// Path is the directory holding the contents of the snapshot.
+	Path string `json:"path"`
+	// Method is how the snapshot was taken, VolumeSnapshotReflink or
+	// VolumeSnapshotCopy.
+	Method string `json:"method"`
+	// Size is the size of the contents of the snapshot in bytes. Blocks
+	// shared with the volume by reflinks are included.
+	Size int64 `json:"size"`
+	// Created is the time the snapshot was taken.
+	Created time.Time `json:"created"`
+}
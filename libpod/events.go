@@ -6,12 +6,20 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/storage/pkg/lockfile"
 	"github.com/sirupsen/logrus"
 )
 
+// eventWebhookShutdownTimeout is how long DeliverEventsToWebhooks waits for
+// queued events to be delivered to the events webhooks once it is stopped.
+const eventWebhookShutdownTimeout = 5 * time.Second
+
 // newEventer returns an eventer that can be used to read/write events
 func (r *Runtime) newEventer() (events.Eventer, error) {
 	if r.config.Engine.EventsLogFilePath == "" {
@@ -26,29 +34,100 @@ func (r *Runtime) newEventer() (events.Eventer, error) {
 	return events.NewEventer(options)
 }
 
+// eventsWebhooksLockPath returns the path of the lock held by the process
+// delivering the events to the events webhooks.
+func (r *Runtime) eventsWebhooksLockPath() string {
+	return filepath.Join(r.config.Engine.TmpDir, "events-webhooks.lock")
+}
+
+// eventsWebhooksDelivered returns true if a process delivers the events to
+// the events webhooks.
+func (r *Runtime) eventsWebhooksDelivered() bool {
+	lock, err := lockfile.GetLockFile(r.eventsWebhooksLockPath())
+	if err != nil {
+		logrus.Debugf("Checking whether events are delivered to the webhooks: %v", err)
+		return false
+	}
+	if err := lock.TryRLock(); err != nil {
+		return true
+	}
+	lock.Unlock()
+	return false
+}
+
+// DeliverEventsToWebhooks POSTs the events to the events webhooks configured
+// in containers.conf until ctx is done. The events of all Podman processes
+// are read back from the events backend, so only a long-running process such
+// as the system service delivers them and short-lived commands never wait
+// for the webhooks. Events written while no process delivers them are not
+// sent. Only one process delivers the events, others wait until it stops.
+func (r *Runtime) DeliverEventsToWebhooks(ctx context.Context) error {
+	if len(r.podmanConf.Engine.EventsWebhooks) == 0 {
+		return nil
+	}
+	webhooks, err := events.NewWebhooks(r.podmanConf.Engine.EventsWebhooks, r.podmanConf.Engine.EventsWebhookFilters)
+	if err != nil {
+		return err
+	}
+	defer webhooks.Close(eventWebhookShutdownTimeout)
+
+	lock, err := lockfile.GetLockFile(r.eventsWebhooksLockPath())
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	eventChannel := make(chan *events.Event)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- r.eventer.Read(ctx, events.ReadOptions{EventChannel: eventChannel, Stream: true})
+	}()
+	for e := range eventChannel {
+		webhooks.Send(*e)
+	}
+	return <-errChan
+}
+
 // newContainerEvent creates a new event based on a container
 func (c *Container) newContainerEvent(status events.Status) {
-	if err := c.newContainerEventWithInspectData(status, "", false); err != nil {
+	if err := c.newContainerEventWithInspectData(status, false); err != nil {
 		logrus.Errorf("Unable to write container event: %v", err)
 	}
 }
 
-// newContainerHealthCheckEvent creates a new healthcheck event with the given status
-func (c *Container) newContainerHealthCheckEvent(healthStatus string) {
-	if err := c.newContainerEventWithInspectData(events.HealthStatus, healthStatus, false); err != nil {
+// newContainerHealthCheckEvent creates a new healthcheck event with the given
+// status. The status before the healthcheck is recorded in the attributes so
+// consumers can tell status changes apart from repeated checks.
+func (c *Container) newContainerHealthCheckEvent(healthStatus, previousHealthStatus string) {
+	e := events.NewEvent(events.HealthStatus)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.HealthStatus = healthStatus
+
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: c.Labels(),
+	}
+	if previousHealthStatus != "" {
+		e.Attributes[events.PreviousHealthStatusAttribute] = previousHealthStatus
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
 		logrus.Errorf("Unable to write container event: %v", err)
 	}
 }
 
 // newContainerEventWithInspectData creates a new event and sets the
 // ContainerInspectData field if inspectData is set.
-func (c *Container) newContainerEventWithInspectData(status events.Status, healthStatus string, inspectData bool) error {
+func (c *Container) newContainerEventWithInspectData(status events.Status, inspectData bool) error {
 	e := events.NewEvent(status)
 	e.ID = c.ID()
 	e.Name = c.Name()
 	e.Image = c.config.RootfsImageName
 	e.Type = events.Container
-	e.HealthStatus = healthStatus
 
 	e.Details = events.Details{
 		PodID:      c.PodID(),
@@ -104,8 +183,49 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
-// newExecDiedEvent creates a new event for an exec session's death
-func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
+// newProcessEvent creates a new event for a process starting or exiting
+// inside the container
+func (c *Container) newProcessEvent(status events.Status, pid int, command string) {
+	e := events.NewEvent(status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Type = events.Process
+	e.ProcessID = pid
+	e.ProcessCommand = command
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write process event: %q", err)
+	}
+}
+
+// EmitCustomEvent writes a user-defined event of the given type, carrying the
+// given message, for the container.
+func (c *Container) EmitCustomEvent(eventType, message string) error {
+	if !c.valid {
+		return define.ErrCtrRemoved
+	}
+	if !define.NameRegex.MatchString(eventType) {
+		return fmt.Errorf("invalid event type %q: %w", eventType, define.RegexError)
+	}
+	e := events.NewEvent(events.Status(eventType))
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Custom
+	e.Message = message
+	e.Details = events.Details{
+		PodID: c.PodID(),
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		return fmt.Errorf("writing custom event: %w", err)
+	}
+	return nil
+}
+
+// newExecDiedEvent creates a new event for an exec session's death. The
+// duration of the session is only recorded when its start time is known.
+func (c *Container) newExecDiedEvent(sessionID string, exitCode int, startedAt time.Time) {
 	e := events.NewEvent(events.ExecDied)
 	e.ID = c.ID()
 	e.Name = c.Name()
@@ -113,18 +233,41 @@ func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	e.Type = events.Container
 	intExitCode := exitCode
 	e.ContainerExitCode = &intExitCode
-	e.Attributes = make(map[string]string)
-	e.Attributes["execID"] = sessionID
 
 	e.Details = events.Details{
+		PodID:      c.PodID(),
 		Attributes: c.Labels(),
 	}
+	e.Attributes[events.ExecIDAttribute] = sessionID
+	if !startedAt.IsZero() {
+		e.Attributes[events.ExecDurationAttribute] = strconv.FormatInt(int64(e.Time.Sub(startedAt)/time.Second), 10)
+	}
 
 	if err := c.runtime.eventer.Write(e); err != nil {
 		logrus.Errorf("Unable to write exec died event: %q", err)
 	}
 }
 
+// newExecReapedEvent creates a new event for the removal of a stale exec
+// session
+func (c *Container) newExecReapedEvent(sessionID string) {
+	e := events.NewEvent(events.ExecReaped)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: c.Labels(),
+	}
+	e.Attributes[events.ExecIDAttribute] = sessionID
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write exec reaped event: %q", err)
+	}
+}
+
 // newNetworkEvent creates a new event based on a network connect/disconnect
 func (c *Container) newNetworkEvent(status events.Status, netName string) {
 	e := events.NewEvent(status)
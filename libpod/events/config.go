@@ -43,6 +43,12 @@ type Event struct {
 	HealthStatus string `json:"health_status,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:"error,omitempty"`
+	// ProcessID is the PID of the process in a process event
+	ProcessID int `json:"process_id,omitempty"`
+	// ProcessCommand is the command of the process in a process event
+	ProcessCommand string `json:"process_command,omitempty"`
+	// Message is the user-supplied message of a custom event
+	Message string `json:"message,omitempty"`
 
 	Details
 }
@@ -61,6 +67,19 @@ type Details struct {
 	Attributes map[string]string
 }
 
+const (
+	// ExecIDAttribute is the attribute holding the ID of the exec session
+	// of exec_died and exec_reaped events.
+	ExecIDAttribute = "execID"
+	// ExecDurationAttribute is the attribute holding how long, in seconds,
+	// the exec session of an exec_died event ran.
+	ExecDurationAttribute = "execDuration"
+	// PreviousHealthStatusAttribute is the attribute holding the health
+	// status of the container before the healthcheck of a health_status
+	// event.
+	PreviousHealthStatusAttribute = "previousHealthStatus"
+)
+
 // EventerOptions describe options that need to be passed to create
 // an eventer
 type EventerOptions struct {
@@ -110,12 +129,16 @@ type Status string
 const (
 	// Container - event is related to containers
 	Container Type = "container"
+	// Custom - event was emitted by a user for a container
+	Custom Type = "custom"
 	// Image - event is related to images
 	Image Type = "image"
 	// Network - event is related to networks
 	Network Type = "network"
 	// Pod - event is related to pods
 	Pod Type = "pod"
+	// Process - event is related to processes inside of containers
+	Process Type = "process"
 	// System - event is related to Podman whole and not to any specific
 	// container/pod/image/volume
 	System Type = "system"
@@ -144,6 +167,9 @@ const (
 	Exec Status = "exec"
 	// ExecDied indicates that an exec session in a container died.
 	ExecDied Status = "exec_died"
+	// ExecReaped indicates that a stale exec session of a container was
+	// removed.
+	ExecReaped Status = "exec_reaped"
 	// Exited indicates that a container's process died
 	Exited Status = "died"
 	// Export ...
@@ -168,6 +194,9 @@ const (
 	NetworkDisconnect Status = "disconnect"
 	// Pause ...
 	Pause Status = "pause"
+	// PolicyDenied indicates that the image usage policy denied pulling an
+	// image or creating a container from it.
+	PolicyDenied Status = "policy-denied"
 	// Prune ...
 	Prune Status = "prune"
 	// Pull ...
@@ -179,6 +208,9 @@ const (
 	// Refresh indicates that the system refreshed the state after a
 	// reboot.
 	Refresh Status = "refresh"
+	// Reload indicates that the system service reloaded its
+	// configuration.
+	Reload Status = "reload"
 	// Remove ...
 	Remove Status = "remove"
 	// Rename indicates that a container was renamed
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/containers/storage/pkg/stringid"
@@ -88,6 +90,10 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		humanFormat += ")"
 	case Network:
 		humanFormat = fmt.Sprintf("%s %s %s %s (container=%s, name=%s)", e.Time, e.Type, e.Status, id, id, e.Network)
+	case Process:
+		humanFormat = fmt.Sprintf("%s %s %s %s (name=%s, pid=%d, command=%s)", e.Time, e.Type, e.Status, id, e.Name, e.ProcessID, e.ProcessCommand)
+	case Custom:
+		humanFormat = fmt.Sprintf("%s %s %s %s (name=%s, message=%s)", e.Time, e.Type, e.Status, id, e.Name, e.Message)
 	case Image:
 		humanFormat = fmt.Sprintf("%s %s %s %s %s", e.Time, e.Type, e.Status, id, e.Name)
 		if e.Error != "" {
@@ -99,6 +105,14 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		} else {
 			humanFormat = fmt.Sprintf("%s %s %s", e.Time, e.Type, e.Status)
 		}
+		if e.Status == Reload && len(e.Attributes) > 0 {
+			attrs := make([]string, 0, len(e.Attributes))
+			for k, v := range e.Attributes {
+				attrs = append(attrs, fmt.Sprintf("%s=%s", k, v))
+			}
+			sort.Strings(attrs)
+			humanFormat += " (" + strings.Join(attrs, ", ") + ")"
+		}
 	case Volume, Machine:
 		humanFormat = fmt.Sprintf("%s %s %s %s", e.Time, e.Type, e.Status, e.Name)
 	}
@@ -130,6 +144,8 @@ func StringToType(name string) (Type, error) {
 	switch name {
 	case Container.String():
 		return Container, nil
+	case Custom.String():
+		return Custom, nil
 	case Image.String():
 		return Image, nil
 	case Machine.String():
@@ -138,6 +154,8 @@ func StringToType(name string) (Type, error) {
 		return Network, nil
 	case Pod.String():
 		return Pod, nil
+	case Process.String():
+		return Process, nil
 	case System.String():
 		return System, nil
 	case Volume.String():
@@ -169,6 +187,8 @@ func StringToStatus(name string) (Status, error) {
 		return Exec, nil
 	case ExecDied.String():
 		return ExecDied, nil
+	case ExecReaped.String():
+		return ExecReaped, nil
 	case Exited.String():
 		return Exited, nil
 	case Export.String():
@@ -193,6 +213,8 @@ func StringToStatus(name string) (Status, error) {
 		return NetworkDisconnect, nil
 	case Pause.String():
 		return Pause, nil
+	case PolicyDenied.String():
+		return PolicyDenied, nil
 	case Prune.String():
 		return Prune, nil
 	case Pull.String():
@@ -203,6 +225,8 @@ func StringToStatus(name string) (Status, error) {
 		return Push, nil
 	case Refresh.String():
 		return Refresh, nil
+	case Reload.String():
+		return Reload, nil
 	case Remove.String():
 		return Remove, nil
 	case Rename.String():
@@ -12,7 +12,7 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 	switch strings.ToUpper(filter) {
 	case "CONTAINER":
 		return func(e *Event) bool {
-			if e.Type != Container {
+			if e.Type != Container && e.Type != Process && e.Type != Custom {
 				return false
 			}
 			if e.Name == filterValue {
@@ -21,8 +21,17 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 			return strings.HasPrefix(e.ID, filterValue)
 		}, nil
 	case "EVENT", "STATUS":
-		if filterValue == "die" { // Docker compat
-			filterValue = "died"
+		// Docker compat
+		switch filterValue {
+		case "die":
+			filterValue = Exited.String()
+		case "exec_die":
+			filterValue = ExecDied.String()
+		}
+		if healthStatus, ok := strings.CutPrefix(filterValue, HealthStatus.String()+": "); ok {
+			return func(e *Event) bool {
+				return e.Status == HealthStatus && e.HealthStatus == healthStatus
+			}, nil
 		}
 		return func(e *Event) bool {
 			return string(e.Status) == filterValue
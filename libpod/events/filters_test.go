@@ -0,0 +1,38 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEventFilterDockerStatus(t *testing.T) {
+	died := NewEvent(Exited)
+	execDied := NewEvent(ExecDied)
+	healthy := NewEvent(HealthStatus)
+	healthy.HealthStatus = "healthy"
+	unhealthy := NewEvent(HealthStatus)
+	unhealthy.HealthStatus = "unhealthy"
+
+	for _, tt := range []struct {
+		value   string
+		matches []Event
+		misses  []Event
+	}{
+		{value: "die", matches: []Event{died}, misses: []Event{execDied}},
+		{value: "exec_die", matches: []Event{execDied}, misses: []Event{died}},
+		{value: "exec_died", matches: []Event{execDied}, misses: []Event{died}},
+		{value: "health_status", matches: []Event{healthy, unhealthy}, misses: []Event{died}},
+		{value: "health_status: healthy", matches: []Event{healthy}, misses: []Event{unhealthy, died}},
+	} {
+		filter, err := generateEventFilter("event", tt.value)
+		require.NoError(t, err)
+		for _, e := range tt.matches {
+			assert.True(t, filter(&e), "%q should match %s %s", tt.value, e.Status, e.HealthStatus)
+		}
+		for _, e := range tt.misses {
+			assert.False(t, filter(&e), "%q should not match %s %s", tt.value, e.Status, e.HealthStatus)
+		}
+	}
+}
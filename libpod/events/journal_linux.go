@@ -73,8 +73,26 @@ func (e EventJournalD) Write(ee Event) error {
 	case Network:
 		m["PODMAN_ID"] = ee.ID
 		m["PODMAN_NETWORK_NAME"] = ee.Network
+	case Process:
+		m["PODMAN_NAME"] = ee.Name
+		m["PODMAN_ID"] = ee.ID
+		m["PODMAN_PROCESS_ID"] = strconv.Itoa(ee.ProcessID)
+		m["PODMAN_PROCESS_COMMAND"] = ee.ProcessCommand
+	case Custom:
+		m["PODMAN_NAME"] = ee.Name
+		m["PODMAN_ID"] = ee.ID
+		m["PODMAN_MESSAGE"] = ee.Message
 	case Volume:
 		m["PODMAN_NAME"] = ee.Name
+	case System:
+		m["PODMAN_NAME"] = ee.Name
+		if len(ee.Details.Attributes) > 0 {
+			b, err := json.Marshal(ee.Details.Attributes)
+			if err != nil {
+				return err
+			}
+			m["PODMAN_LABELS"] = string(b)
+		}
 	}
 
 	// starting with commit 7e6e267329 we set LogLevel=notice for the systemd healthcheck unit
@@ -189,9 +207,13 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) {
 	if err != nil {
 		return nil, err
 	}
-	eventStatus, err := StringToStatus(entry.Fields["PODMAN_EVENT"])
-	if err != nil {
-		return nil, err
+	// Custom events carry a user-defined status
+	eventStatus := Status(entry.Fields["PODMAN_EVENT"])
+	if eventType != Custom {
+		eventStatus, err = StringToStatus(entry.Fields["PODMAN_EVENT"])
+		if err != nil {
+			return nil, err
+		}
 	}
 	newEvent.Type = eventType
 	newEvent.Time = eventTime
@@ -229,11 +251,31 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) {
 	case Network:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 		newEvent.Network = entry.Fields["PODMAN_NETWORK_NAME"]
+	case Process:
+		newEvent.ID = entry.Fields["PODMAN_ID"]
+		if pid, ok := entry.Fields["PODMAN_PROCESS_ID"]; ok {
+			intPID, err := strconv.Atoi(pid)
+			if err != nil {
+				logrus.Errorf("Parsing event process ID %s", pid)
+			} else {
+				newEvent.ProcessID = intPID
+			}
+		}
+		newEvent.ProcessCommand = entry.Fields["PODMAN_PROCESS_COMMAND"]
+	case Custom:
+		newEvent.ID = entry.Fields["PODMAN_ID"]
+		newEvent.Message = entry.Fields["PODMAN_MESSAGE"]
 	case Image:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 		if val, ok := entry.Fields["ERROR"]; ok {
 			newEvent.Error = val
 		}
+	case System:
+		if stringLabels, ok := entry.Fields["PODMAN_LABELS"]; ok && len(stringLabels) > 0 {
+			if err := json.Unmarshal([]byte(stringLabels), &newEvent.Attributes); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return &newEvent, nil
 }
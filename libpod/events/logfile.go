@@ -170,7 +170,7 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 			return err
 		}
 		switch event.Type {
-		case Image, Volume, Pod, Container, Network:
+		case Image, Volume, Pod, Container, Network, Process, Custom:
 			//	no-op
 		case System:
 			begin, end, err := e.readRotateEvent(event)
@@ -1,7 +1,9 @@
 package events
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -135,3 +137,40 @@ func TestRenameLog(t *testing.T) {
 	require.NoError(t, os.Remove(target.Name()))
 	require.Equal(t, beforeRename, afterRename)
 }
+
+func TestCustomEvent(t *testing.T) {
+	eventer, err := newLogFileEventer(EventerOptions{
+		LogFilePath: filepath.Join(t.TempDir(), "events.log"),
+	})
+	require.NoError(t, err)
+
+	for _, status := range []Status{"backup", Start} {
+		e := NewEvent(status)
+		e.ID = "abcdef"
+		e.Name = "ctr"
+		e.Type = Custom
+		e.Message = "backup completed"
+		require.NoError(t, eventer.Write(e))
+	}
+
+	eventChannel := make(chan *Event)
+	errChannel := make(chan error)
+	go func() {
+		errChannel <- eventer.Read(context.Background(), ReadOptions{
+			EventChannel: eventChannel,
+			Filters:      []string{"container=ctr", "event=backup"},
+			FromStart:    true,
+		})
+	}()
+
+	var read []*Event
+	for e := range eventChannel {
+		read = append(read, e)
+	}
+	require.NoError(t, <-errChannel)
+	require.Len(t, read, 1)
+	require.Equal(t, Custom, read[0].Type)
+	require.Equal(t, Status("backup"), read[0].Status)
+	require.Equal(t, "backup completed", read[0].Message)
+	require.Contains(t, read[0].ToHumanReadable(false), "custom backup abcdef (name=ctr, message=backup completed)")
+}
@@ -0,0 +1,183 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookQueueSize is the number of events waiting for delivery
+	// before further events are dropped.
+	webhookQueueSize = 1024
+	// webhookAttempts is the number of times the delivery of an event to
+	// an endpoint is attempted.
+	webhookAttempts = 5
+	// webhookInitialBackoff is the time waited before the first retry,
+	// it doubles with every further retry.
+	webhookInitialBackoff = time.Second
+	// webhookTimeout is the timeout of a single delivery.
+	webhookTimeout = 10 * time.Second
+)
+
+// errWebhookPermanent marks delivery errors which are not retried, such as
+// an endpoint rejecting the event.
+var errWebhookPermanent = errors.New("permanent webhook error")
+
+// Webhooks POSTs events as JSON to HTTP endpoints. Events are delivered in
+// order by a background goroutine, so sending an event does not wait for the
+// endpoints.
+type Webhooks struct {
+	urls    []string
+	filters map[string][]EventFilter
+	client  *http.Client
+	backoff time.Duration
+
+	lock   sync.Mutex
+	closed bool
+	queue  chan Event
+	done   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewWebhooks returns webhooks POSTing the events matching the filters, in
+// the format of podman events --filter, to the given URLs.
+func NewWebhooks(urls, filters []string) (*Webhooks, error) {
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid events webhook %q: %w", u, err)
+		}
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return nil, fmt.Errorf("invalid events webhook %q: the scheme must be http or https", u)
+		}
+	}
+	filterMap, err := generateEventFilters(filters, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid events webhook filter: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Webhooks{
+		urls:    urls,
+		filters: filterMap,
+		client:  &http.Client{Timeout: webhookTimeout},
+		backoff: webhookInitialBackoff,
+		queue:   make(chan Event, webhookQueueSize),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	go w.run()
+	return w, nil
+}
+
+// Send queues the event for delivery to the webhooks if it matches the
+// filters.
+func (w *Webhooks) Send(event Event) {
+	if !applyFilters(&event, w.filters) {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.queue <- event:
+	default:
+		logrus.Warnf("Events webhook queue is full, dropping %s event of %s", event.Status, event.ID)
+	}
+}
+
+// Close stops accepting events and waits up to the given timeout for the
+// queued events to be delivered. Events which are not delivered by then are
+// dropped.
+func (w *Webhooks) Close(timeout time.Duration) {
+	w.lock.Lock()
+	if w.closed {
+		w.lock.Unlock()
+		return
+	}
+	w.closed = true
+	close(w.queue)
+	w.lock.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+	case <-timer.C:
+		logrus.Warnf("Timed out delivering %d events to webhooks", len(w.queue))
+		w.cancel()
+		<-w.done
+	}
+	w.cancel()
+}
+
+// run delivers the queued events until the queue is closed.
+func (w *Webhooks) run() {
+	defer close(w.done)
+	for event := range w.queue {
+		body, err := event.ToJSONString()
+		if err != nil {
+			logrus.Errorf("Marshalling %s event for webhooks: %v", event.Status, err)
+			continue
+		}
+		for _, u := range w.urls {
+			if err := w.deliver(u, []byte(body)); err != nil {
+				logrus.Warnf("Delivering %s event of %s to webhook %s: %v", event.Status, event.ID, u, err)
+			}
+		}
+	}
+}
+
+// deliver POSTs the event to the endpoint, retrying with an exponential
+// backoff.
+func (w *Webhooks) deliver(u string, body []byte) error {
+	backoff := w.backoff
+	for attempt := 1; ; attempt++ {
+		err := w.post(u, body)
+		if err == nil || errors.Is(err, errWebhookPermanent) || attempt >= webhookAttempts {
+			return err
+		}
+		logrus.Debugf("Delivering event to webhook %s failed, retrying in %s: %v", u, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-w.ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// post POSTs the event to the endpoint once.
+func (w *Webhooks) post(u string, body []byte) error {
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errWebhookPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	default:
+		return fmt.Errorf("%w: endpoint returned %s", errWebhookPermanent, resp.Status)
+	}
+}
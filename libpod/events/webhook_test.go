@@ -0,0 +1,78 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests int
+		received []Event
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests++
+		// The first delivery fails and is retried.
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var event Event
+		assert.NoError(t, json.Unmarshal(body, &event))
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer rejecting.Close()
+
+	_, err := NewWebhooks([]string{"ftp://example.com"}, nil)
+	assert.Error(t, err)
+	_, err = NewWebhooks([]string{server.URL}, []string{"invalid"})
+	assert.Error(t, err)
+
+	w, err := NewWebhooks([]string{rejecting.URL, server.URL}, []string{"type=container"})
+	require.NoError(t, err)
+	w.backoff = time.Millisecond
+
+	start := NewEvent(Start)
+	start.Type = Container
+	start.ID = "ctr1"
+	w.Send(start)
+	remove := NewEvent(Remove)
+	remove.Type = Volume
+	remove.Name = "vol1"
+	w.Send(remove)
+	died := NewEvent(Exited)
+	died.Type = Container
+	died.ID = "ctr1"
+	w.Send(died)
+
+	w.Close(10 * time.Second)
+	// Events sent after closing are not delivered.
+	w.Send(start)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, 3, requests)
+	require.Len(t, received, 2)
+	assert.Equal(t, Start, received[0].Status)
+	assert.Equal(t, "ctr1", received[0].ID)
+	assert.Equal(t, Exited, received[1].Status)
+}
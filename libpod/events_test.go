@@ -0,0 +1,27 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsWebhooksDelivered(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.config.Engine.TmpDir = t.TempDir()
+	assert.False(t, runtime.eventsWebhooksDelivered())
+
+	// The lock is held while the events are delivered.
+	lock, err := lockfile.GetLockFile(runtime.eventsWebhooksLockPath())
+	require.NoError(t, err)
+	lock.Lock()
+	assert.True(t, runtime.eventsWebhooksDelivered())
+	lock.Unlock()
+	assert.False(t, runtime.eventsWebhooksDelivered())
+}
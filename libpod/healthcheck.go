@@ -154,7 +154,7 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	}
 
 	hcl := newHealthCheckLog(timeStart, timeEnd, returnCode, eventLog)
-	logStatus, err := c.updateHealthCheckLog(hcl, inStartPeriod, isStartup)
+	previousStatus, logStatus, err := c.updateHealthCheckLog(hcl, inStartPeriod, isStartup)
 	if err != nil {
 		return hcResult, "", fmt.Errorf("unable to update health check log %s for %s: %w", c.healthCheckLogPath(), c.ID(), err)
 	}
@@ -165,7 +165,7 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 		return hcResult, logStatus, hcErr
 	}
 	if c.runtime.config.Engine.HealthcheckEvents {
-		c.newContainerHealthCheckEvent(logStatus)
+		c.newContainerHealthCheckEvent(logStatus, previousStatus)
 	}
 
 	return hcResult, logStatus, hcErr
@@ -340,7 +340,7 @@ func newHealthCheckLog(start, end time.Time, exitCode int, log string) define.He
 // updateHealthStatus updates the health status of the container
 // in the healthcheck log
 func (c *Container) updateHealthStatus(status string) error {
-	healthCheck, err := c.getHealthCheckLog()
+	healthCheck, err := c.readHealthCheckLogFile()
 	if err != nil {
 		return err
 	}
@@ -357,15 +357,16 @@ func (c *Container) isUnhealthy() (bool, error) {
 	if !c.HasHealthCheck() {
 		return false, nil
 	}
-	healthCheck, err := c.getHealthCheckLog()
+	healthCheck, err := c.readHealthCheckLogFile()
 	if err != nil {
 		return false, err
 	}
 	return healthCheck.Status == define.HealthCheckUnhealthy, nil
 }
 
-// UpdateHealthCheckLog parses the health check results and writes the log
-func (c *Container) updateHealthCheckLog(hcl define.HealthCheckLog, inStartPeriod, isStartup bool) (string, error) {
+// UpdateHealthCheckLog parses the health check results and writes the log.
+// It returns the health status before and after the results.
+func (c *Container) updateHealthCheckLog(hcl define.HealthCheckLog, inStartPeriod, isStartup bool) (string, string, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -373,13 +374,14 @@ func (c *Container) updateHealthCheckLog(hcl define.HealthCheckLog, inStartPerio
 	// both failing and succeeding cases to match kube behavior.
 	// So don't update the health check log till the start period is over
 	if _, ok := c.config.Spec.Annotations[define.KubeHealthCheckAnnotation]; ok && inStartPeriod && !isStartup {
-		return "", nil
+		return "", "", nil
 	}
 
-	healthCheck, err := c.getHealthCheckLog()
+	healthCheck, err := c.readHealthCheckLogFile()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
+	previousStatus := healthCheck.Status
 	if hcl.ExitCode == 0 {
 		//	set status to healthy, reset failing state to 0
 		healthCheck.Status = define.HealthCheckHealthy
@@ -397,15 +399,27 @@ func (c *Container) updateHealthCheckLog(hcl define.HealthCheckLog, inStartPerio
 			}
 		}
 	}
-	healthCheck.Log = append(healthCheck.Log, hcl)
-	if len(healthCheck.Log) > MaxHealthCheckNumberLogs {
-		healthCheck.Log = healthCheck.Log[1:]
+
+	entry := &define.HealthCheckHistoryEntry{HealthCheckLog: hcl, Status: healthCheck.Status}
+	err = c.runtime.state.AddHealthCheckLog(c.ID(), entry, c.runtime.podmanConf.Engine.HealthcheckMaxLogCount)
+	switch {
+	case err == nil:
+		// The runs are kept in the database, the file only holds the
+		// current status.
+		healthCheck.Log = nil
+	case errors.Is(err, define.ErrNotImplemented):
+		healthCheck.Log = append(healthCheck.Log, hcl)
+		if len(healthCheck.Log) > MaxHealthCheckNumberLogs {
+			healthCheck.Log = healthCheck.Log[1:]
+		}
+	default:
+		return "", "", err
 	}
 	newResults, err := json.Marshal(healthCheck)
 	if err != nil {
-		return "", fmt.Errorf("unable to marshall healthchecks for writing: %w", err)
+		return "", "", fmt.Errorf("unable to marshall healthchecks for writing: %w", err)
 	}
-	return healthCheck.Status, os.WriteFile(c.healthCheckLogPath(), newResults, 0700)
+	return previousStatus, healthCheck.Status, os.WriteFile(c.healthCheckLogPath(), newResults, 0700)
 }
 
 // HealthCheckLogPath returns the path for where the health check log is
@@ -414,10 +428,47 @@ func (c *Container) healthCheckLogPath() string {
 }
 
 // getHealthCheckLog returns HealthCheck results by reading the container's
-// health check log file.  If the health check log file does not exist, then
-// an empty healthcheck struct is returned
+// health check log file and the most recent runs since the container was
+// started from the database.  If the health check log file does not exist,
+// then an empty healthcheck struct is returned
 // The caller should lock the container before this function is called.
 func (c *Container) getHealthCheckLog() (define.HealthCheckResults, error) {
+	healthCheck, err := c.readHealthCheckLogFile()
+	if err != nil {
+		return healthCheck, err
+	}
+	history, err := c.runtime.state.GetHealthCheckLog(c.ID())
+	if err != nil {
+		if errors.Is(err, define.ErrNotImplemented) {
+			return healthCheck, nil
+		}
+		return healthCheck, err
+	}
+	// Containers which have not run a healthcheck since the database
+	// started recording them still have their runs in the file.
+	if len(history) == 0 {
+		return healthCheck, nil
+	}
+	healthCheck.Log = []define.HealthCheckLog{}
+	for _, entry := range history {
+		// The log is reset when the container is restarted.
+		start, err := time.Parse(time.RFC3339Nano, entry.Start)
+		if err == nil && start.Before(c.state.StartedTime) {
+			continue
+		}
+		healthCheck.Log = append(healthCheck.Log, entry.HealthCheckLog)
+	}
+	if len(healthCheck.Log) > MaxHealthCheckNumberLogs {
+		healthCheck.Log = healthCheck.Log[len(healthCheck.Log)-MaxHealthCheckNumberLogs:]
+	}
+	return healthCheck, nil
+}
+
+// readHealthCheckLogFile returns HealthCheck results by reading the
+// container's health check log file.  If the health check log file does not
+// exist, then an empty healthcheck struct is returned
+// The caller should lock the container before this function is called.
+func (c *Container) readHealthCheckLogFile() (define.HealthCheckResults, error) {
 	var healthCheck define.HealthCheckResults
 	b, err := os.ReadFile(c.healthCheckLogPath())
 	if err != nil {
@@ -454,10 +505,43 @@ func (c *Container) healthCheckStatus() (string, error) {
 		return "", err
 	}
 
-	results, err := c.getHealthCheckLog()
+	results, err := c.readHealthCheckLogFile()
 	if err != nil {
 		return "", fmt.Errorf("unable to get healthcheck log for %s: %w", c.ID(), err)
 	}
 
 	return results.Status, nil
 }
+
+// HealthCheckHistory returns the recorded healthcheck runs of the container,
+// oldest first. With the BoltDB database backend, only the most recent runs
+// since the container was started are kept and their status is not
+// recorded.
+func (c *Container) HealthCheckHistory() ([]define.HealthCheckHistoryEntry, error) {
+	if !c.HasHealthCheck() {
+		return nil, fmt.Errorf("container %s has no defined healthcheck: %w", c.ID(), define.ErrInvalidArg)
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+	}
+
+	history, err := c.runtime.state.GetHealthCheckLog(c.ID())
+	if err == nil && len(history) > 0 {
+		return history, nil
+	}
+	if err != nil && !errors.Is(err, define.ErrNotImplemented) {
+		return nil, err
+	}
+
+	results, err := c.readHealthCheckLogFile()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get healthcheck log for %s: %w", c.ID(), err)
+	}
+	history = make([]define.HealthCheckHistoryEntry, 0, len(results.Log))
+	for _, hcl := range results.Log {
+		history = append(history, define.HealthCheckHistoryEntry{HealthCheckLog: hcl})
+	}
+	return history, nil
+}
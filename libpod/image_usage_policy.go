@@ -0,0 +1,346 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/shortnames"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/storage/pkg/homedir"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// imageUsagePolicySystemPath is the system-wide image usage policy.
+	imageUsagePolicySystemPath = "/etc/containers/image-usage-policy.json"
+	// imageUsagePolicyUserFile is the per-user image usage policy,
+	// relative to the configuration home of the user.
+	imageUsagePolicyUserFile = "containers/image-usage-policy.json"
+	// imageUsagePolicyEnv overrides the path of the system-wide image
+	// usage policy.
+	imageUsagePolicyEnv = "CONTAINERS_IMAGE_USAGE_POLICY"
+)
+
+// imageUsagePolicyFile is an image usage policy and the file it was read from.
+type imageUsagePolicyFile struct {
+	path   string
+	policy define.ImageUsagePolicy
+	maxAge time.Duration
+}
+
+// loadImageUsagePolicies reads the system-wide and the per-user image usage
+// policy. Missing files are skipped.
+func loadImageUsagePolicies() ([]*imageUsagePolicyFile, error) {
+	paths := []string{imageUsagePolicySystemPath}
+	if env := os.Getenv(imageUsagePolicyEnv); env != "" {
+		paths[0] = env
+	}
+	if configHome, err := homedir.GetConfigHome(); err == nil {
+		if userPath := filepath.Join(configHome, imageUsagePolicyUserFile); userPath != paths[0] {
+			paths = append(paths, userPath)
+		}
+	}
+
+	var policies []*imageUsagePolicyFile
+	for _, p := range paths {
+		policy, err := readImageUsagePolicy(p)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// readImageUsagePolicy reads and validates the image usage policy at path.
+func readImageUsagePolicy(path string) (*imageUsagePolicyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := &imageUsagePolicyFile{path: path}
+	if err := json.Unmarshal(data, &file.policy); err != nil {
+		return nil, fmt.Errorf("parsing image usage policy %s: %w", path, err)
+	}
+	for _, patterns := range [][]string{file.policy.Allow, file.policy.Deny, file.policy.RequireSignatures} {
+		for _, pattern := range patterns {
+			if _, err := matchRepository(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in image usage policy %s: %w", pattern, path, err)
+			}
+		}
+	}
+	if file.policy.MaxAge != "" {
+		if file.maxAge, err = time.ParseDuration(file.policy.MaxAge); err != nil {
+			return nil, fmt.Errorf("invalid maxAge in image usage policy %s: %w", path, err)
+		}
+	}
+	return file, nil
+}
+
+// matchRepository returns true if the pattern matches the repository or one of
+// its parent namespaces, e.g. quay.io/org matches quay.io/org/app. Patterns
+// may contain wildcards as in path.Match, which do not match a slash.
+func matchRepository(pattern, repo string) (bool, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return false, err
+	}
+	for name := repo; name != ""; {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true, nil
+		}
+		i := strings.LastIndex(name, "/")
+		if i == -1 {
+			break
+		}
+		name = name[:i]
+	}
+	return false, nil
+}
+
+// matchAnyRepository returns the first of the repositories matched by one of
+// the patterns.
+func matchAnyRepository(patterns, repos []string) (string, bool) {
+	for _, repo := range repos {
+		for _, pattern := range patterns {
+			if matched, _ := matchRepository(pattern, repo); matched {
+				return repo, true
+			}
+		}
+	}
+	return "", false
+}
+
+// check returns why the policy denies the image with the given repositories
+// and creation time, or "" if it allows it. The creation time is zero if the
+// image has not been pulled yet. signed returns true if the signature policy
+// requires signatures for images of a repository.
+func (f *imageUsagePolicyFile) check(repos []string, created time.Time, signed func(repo string) bool) string {
+	if repo, ok := matchAnyRepository(f.policy.Deny, repos); ok {
+		return fmt.Sprintf("repository %s is denied", repo)
+	}
+	if len(f.policy.Allow) > 0 && len(repos) > 0 {
+		if _, ok := matchAnyRepository(f.policy.Allow, repos); !ok {
+			return fmt.Sprintf("repository %s is not allowed", repos[0])
+		}
+	}
+	for _, repo := range repos {
+		if _, ok := matchAnyRepository(f.policy.RequireSignatures, []string{repo}); ok && !signed(repo) {
+			return fmt.Sprintf("images of repository %s must be verified by a signature, but the signature policy accepts them unsigned", repo)
+		}
+	}
+	if f.maxAge > 0 && !created.IsZero() && time.Since(created) > f.maxAge {
+		return fmt.Sprintf("image was created on %s, more than %s ago", created.Format(time.RFC3339), f.policy.MaxAge)
+	}
+	return ""
+}
+
+// imageUsageRepositories returns the repositories the image referenced by name
+// is checked against. These are the repository of name if it is fully
+// qualified, or else the repositories of the names of the image. img is nil if
+// the image has not been pulled yet.
+func imageUsageRepositories(name string, img *libimage.Image) []string {
+	if ref, err := alltransports.ParseImageName(name); err == nil {
+		name = ""
+		if ref.Transport().Name() == docker.Transport.Name() {
+			name = strings.TrimPrefix(ref.StringWithinTransport(), "//")
+		}
+	}
+	if name != "" && !shortnames.IsShortName(name) {
+		if named, err := reference.ParseNormalizedNamed(name); err == nil {
+			return []string{named.Name()}
+		}
+	}
+	if img == nil {
+		return nil
+	}
+	var repos []string
+	for _, n := range img.Names() {
+		named, err := reference.ParseNormalizedNamed(n)
+		if err != nil {
+			continue
+		}
+		if !slices.Contains(repos, named.Name()) {
+			repos = append(repos, named.Name())
+		}
+	}
+	return repos
+}
+
+// signaturePolicyScopes returns the scopes of the signature policy which may
+// apply to the repository, most specific first.
+func signaturePolicyScopes(repo string) []string {
+	var scopes []string
+	for name := repo; ; {
+		scopes = append(scopes, name)
+		i := strings.LastIndex(name, "/")
+		if i == -1 {
+			break
+		}
+		name = name[:i]
+	}
+	host := scopes[len(scopes)-1]
+	for {
+		i := strings.Index(host, ".")
+		if i == -1 {
+			break
+		}
+		host = host[i+1:]
+		scopes = append(scopes, "*."+host)
+	}
+	return scopes
+}
+
+// signatureRequired returns true if the signature policy requires images of
+// the repository to be verified.
+func signatureRequired(policy *signature.Policy, repo string) bool {
+	requirements := policy.Default
+	if scopes, ok := policy.Transports[docker.Transport.Name()]; ok {
+		if reqs, ok := scopes[""]; ok {
+			requirements = reqs
+		}
+		for _, scope := range signaturePolicyScopes(repo) {
+			if reqs, ok := scopes[scope]; ok {
+				requirements = reqs
+				break
+			}
+		}
+	}
+	for _, req := range requirements {
+		data, err := json.Marshal(req)
+		if err != nil {
+			continue
+		}
+		var common struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(data, &common); err == nil && common.Type != "insecureAcceptAnything" {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckImagePullPolicy checks an image pull against the image usage policy.
+// It is called with the name to pull and no images before pulling, which
+// checks the repository if name is fully qualified, and with the pulled images
+// after pulling, which also checks their age.
+func (r *Runtime) CheckImagePullPolicy(name string, images []*libimage.Image) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	if len(images) == 0 {
+		return r.checkImageUsagePolicy("pull", name, nil)
+	}
+	for _, img := range images {
+		if err := r.checkImageUsagePolicy("pull", name, img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCreateImagePolicy checks the image of a new container against the image
+// usage policy. Infra and service containers use the pause image and are not
+// checked.
+func (r *Runtime) checkCreateImagePolicy(ctr *Container) error {
+	if ctr.config.RootfsImageID == "" || ctr.config.IsInfra || ctr.config.IsService {
+		return nil
+	}
+	img, _, err := r.libimageRuntime.LookupImage(ctr.config.RootfsImageID, nil)
+	if err != nil {
+		return err
+	}
+	return r.checkImageUsagePolicy("create", ctr.config.RootfsImageName, img)
+}
+
+// checkImageUsagePolicy checks the image referenced by name against the image
+// usage policies. Denials are reported as events and in the audit log.
+func (r *Runtime) checkImageUsagePolicy(operation, name string, img *libimage.Image) error {
+	policies, err := loadImageUsagePolicies()
+	if err != nil || len(policies) == 0 {
+		return err
+	}
+	repos := imageUsageRepositories(name, img)
+	var (
+		id      string
+		created time.Time
+	)
+	if img != nil {
+		id = img.ID()
+		created = img.Created()
+	}
+
+	var sigPolicy *signature.Policy
+	signed := func(repo string) bool {
+		if sigPolicy == nil {
+			policy, err := signature.DefaultPolicy(r.SystemContext())
+			if err != nil {
+				logrus.Errorf("Reading signature policy: %v", err)
+				policy = &signature.Policy{}
+			}
+			sigPolicy = policy
+		}
+		return signatureRequired(sigPolicy, repo)
+	}
+
+	for _, policy := range policies {
+		reason := policy.check(repos, created, signed)
+		if reason == "" {
+			continue
+		}
+		if name == "" {
+			name = id
+		}
+		r.reportImageDenied(operation, name, id, policy.path, reason)
+		return fmt.Errorf("image %s: %s by %s: %w", name, reason, policy.path, define.ErrImageDenied)
+	}
+	return nil
+}
+
+// reportImageDenied writes an event and an audit log entry for an image denied
+// by the image usage policy.
+func (r *Runtime) reportImageDenied(operation, name, id, path, reason string) {
+	if r.eventer != nil {
+		e := events.Event{
+			ID:     id,
+			Name:   name,
+			Status: events.PolicyDenied,
+			Time:   time.Now(),
+			Type:   events.Image,
+			Error:  reason,
+		}
+		if err := r.eventer.Write(e); err != nil {
+			logrus.Errorf("Unable to write image event: %q", err)
+		}
+	}
+	if err := r.Audit(&define.AuditEntry{
+		Source:    define.AuditSourcePolicy,
+		UID:       os.Getuid(),
+		Operation: operation,
+		Type:      "image",
+		ID:        id,
+		Name:      name,
+		Detail:    path,
+		Error:     reason,
+	}); err != nil {
+		logrus.Errorf("Writing audit log entry: %v", err)
+	}
+}
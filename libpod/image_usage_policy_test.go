@@ -0,0 +1,135 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRepository(t *testing.T) {
+	tests := []struct {
+		pattern string
+		repo    string
+		want    bool
+	}{
+		{"quay.io", "quay.io/org/app", true},
+		{"quay.io/org", "quay.io/org/app", true},
+		{"quay.io/org/app", "quay.io/org/app", true},
+		{"quay.io/org", "quay.io/organization/app", false},
+		{"quay.io/org/app/sub", "quay.io/org/app", false},
+		{"*.example.com", "registry.example.com/app", true},
+		{"*.example.com", "example.com/app", false},
+		{"quay.io/*/app", "quay.io/org/app", true},
+		{"quay.io/*", "quay.io/org/app", true},
+		{"*", "localhost/app", true},
+	}
+	for _, tt := range tests {
+		matched, err := matchRepository(tt.pattern, tt.repo)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, matched, "pattern %q, repository %q", tt.pattern, tt.repo)
+	}
+
+	_, err := matchRepository("quay.io/[", "quay.io/org")
+	assert.Error(t, err)
+}
+
+func TestImageUsagePolicyCheck(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.json")
+	err := os.WriteFile(path, []byte(`{
+  "allow": ["quay.io/org", "*.example.com"],
+  "deny": ["quay.io/org/legacy"],
+  "requireSignatures": ["registry.example.com"],
+  "maxAge": "720h"
+}`), 0o600)
+	require.NoError(t, err)
+	policy, err := readImageUsagePolicy(path)
+	require.NoError(t, err)
+
+	signed := func(repo string) bool { return repo == "registry.example.com/signed" }
+	now := time.Now()
+	tests := []struct {
+		name    string
+		repos   []string
+		created time.Time
+		want    string
+	}{
+		{"allowed", []string{"quay.io/org/app"}, now, ""},
+		{"not pulled yet", []string{"quay.io/org/app"}, time.Time{}, ""},
+		{"unknown repository", nil, now, ""},
+		{"denied", []string{"quay.io/org/legacy/app"}, now, "repository quay.io/org/legacy/app is denied"},
+		{"not allowed", []string{"docker.io/library/alpine"}, now, "repository docker.io/library/alpine is not allowed"},
+		{"one name allowed", []string{"docker.io/library/alpine", "quay.io/org/app"}, now, ""},
+		{"signed", []string{"registry.example.com/signed"}, now, ""},
+		{"unsigned", []string{"registry.example.com/unsigned"}, now, "images of repository registry.example.com/unsigned must be verified by a signature, but the signature policy accepts them unsigned"},
+		{"too old", []string{"quay.io/org/app"}, now.Add(-1000 * time.Hour), "more than 720h ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := policy.check(tt.repos, tt.created, signed)
+			if tt.want == "" {
+				assert.Empty(t, reason)
+			} else {
+				assert.Contains(t, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadImageUsagePolicyInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"invalid pattern": `{"allow": ["quay.io/["]}`,
+		"invalid maxAge":  `{"maxAge": "30 days"}`,
+		"invalid json":    `{"allow": "quay.io"}`,
+	} {
+		path := filepath.Join(dir, "policy.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := readImageUsagePolicy(path)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestImageUsageRepositories(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"quay.io/org/app:1.0", []string{"quay.io/org/app"}},
+		{"docker://quay.io/org/app@sha256:0000000000000000000000000000000000000000000000000000000000000000", []string{"quay.io/org/app"}},
+		{"localhost:5000/app", []string{"localhost:5000/app"}},
+		// Short names are resolved by the names of the pulled image.
+		{"alpine", nil},
+		{"oci-archive:/tmp/app.tar", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, imageUsageRepositories(tt.name, nil), tt.name)
+	}
+}
+
+func TestSignatureRequired(t *testing.T) {
+	policy, err := signature.NewPolicyFromBytes([]byte(`{
+  "default": [{"type": "reject"}],
+  "transports": {
+    "docker": {
+      "": [{"type": "insecureAcceptAnything"}],
+      "*.example.com": [{"type": "signedBy", "keyType": "GPGKeys", "keyPath": "/key.gpg"}],
+      "registry.example.com/unsigned": [{"type": "insecureAcceptAnything"}]
+    }
+  }
+}`))
+	require.NoError(t, err)
+
+	assert.False(t, signatureRequired(policy, "quay.io/org/app"))
+	assert.True(t, signatureRequired(policy, "registry.example.com/app"))
+	assert.True(t, signatureRequired(policy, "sub.registry.example.com/app"))
+	assert.False(t, signatureRequired(policy, "registry.example.com/unsigned/app"))
+	assert.True(t, signatureRequired(&signature.Policy{Default: policy.Default}, "quay.io/org/app"))
+}
@@ -47,6 +47,13 @@ func (r *Runtime) info() (*define.Info, error) {
 		return nil, fmt.Errorf("getting store info: %w", err)
 	}
 	info.Store = storeInfo
+
+	dbInfo, err := r.state.DatabaseInfo()
+	if err != nil {
+		return nil, fmt.Errorf("getting database info: %w", err)
+	}
+	info.DatabaseBackend = dbInfo
+
 	registries := make(map[string]interface{})
 
 	sys := r.SystemContext()
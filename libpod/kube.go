@@ -539,6 +539,10 @@ func (p *Pod) podWithContainers(ctx context.Context, containers []*Container, po
 				stopTimeout = &ctr.config.StopTimeout
 			}
 
+			if p.SharesNet() && ctr.PodNetworkOptOut() {
+				logrus.Warnf("Container %s does not join the network namespace of pod %s, which Kubernetes YAML cannot express: it will join the pod network", ctr.Name(), p.Name())
+			}
+
 			ctr, volumes, _, annotations, err := containerToV1Container(ctx, ctr, getService)
 			if err != nil {
 				return nil, err
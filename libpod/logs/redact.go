@@ -0,0 +1,89 @@
+package logs
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// RedactMask replaces the redacted parts of a log line.
+	RedactMask = "********"
+
+	// minRedactLength is the minimum length of a value to be redacted, so
+	// short secrets do not mask common words and digits.
+	minRedactLength = 4
+)
+
+// Redactor masks secret values and the matches of regular expressions in log
+// lines.
+type Redactor struct {
+	values   []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor returns a Redactor masking the given values and the matches of
+// the given regular expressions. A value is also masked without its trailing
+// newline, values shorter than four characters are ignored. If a pattern has
+// capturing groups, only the groups are masked.
+func NewRedactor(values, patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, value := range values {
+		for _, v := range []string{value, strings.TrimRight(value, "\r\n")} {
+			if len(v) >= minRedactLength && !slices.Contains(r.values, v) {
+				r.values = append(r.values, v)
+			}
+		}
+	}
+	// Mask the longest values first, so a value containing another one is
+	// masked completely.
+	slices.SortFunc(r.values, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns msg with the secret values and pattern matches masked.
+func (r *Redactor) Redact(msg string) string {
+	for _, value := range r.values {
+		msg = strings.ReplaceAll(msg, value, RedactMask)
+	}
+	for _, re := range r.patterns {
+		msg = redactPattern(re, msg)
+	}
+	return msg
+}
+
+// redactPattern masks the matches of re in msg, or only their capturing
+// groups if re has any.
+func redactPattern(re *regexp.Regexp, msg string) string {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllLiteralString(msg, RedactMask)
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringSubmatchIndex(msg, -1) {
+		for i := 2; i < len(match); i += 2 {
+			start, end := match[i], match[i+1]
+			// Skip groups which did not match, are empty or are
+			// nested in a group already masked.
+			if start < last || start == end {
+				continue
+			}
+			b.WriteString(msg[last:start])
+			b.WriteString(RedactMask)
+			last = end
+		}
+	}
+	b.WriteString(msg[last:])
+	return b.String()
+}
@@ -0,0 +1,80 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryState(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := NewMemoryState(runtime)
+	require.NoError(t, err)
+
+	pod, err := getTestPodN("4", manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddPod(pod))
+
+	// The state is shared by all goroutines of the process.
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		ctr, err := getTestCtrN(strconv.Itoa(i+1), manager)
+		require.NoError(t, err)
+		ctr.config.Pod = pod.ID()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = state.AddContainerToPod(pod, ctr)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	ctrs, err := state.AllContainers(true)
+	require.NoError(t, err)
+	assert.Len(t, ctrs, 3)
+	report, err := state.Verify()
+	require.NoError(t, err)
+	assert.False(t, report.HasProblems())
+	assert.Equal(t, "memory", report.Backend)
+
+	require.NoError(t, state.RemovePodContainers(pod))
+	podCtrs, err := state.PodContainers(pod)
+	require.NoError(t, err)
+	assert.Empty(t, podCtrs)
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+	require.NoError(t, state.Close())
+
+	// Nothing is left behind when the state is closed.
+	state, err = NewMemoryState(runtime)
+	require.NoError(t, err)
+	defer state.Close()
+	ctrs, err = state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+
+	runtime.readOnlyState = true
+	_, err = NewMemoryState(runtime)
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+}
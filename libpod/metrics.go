@@ -0,0 +1,100 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+)
+
+// runtimeMetrics holds the latencies of state operations and the counts of
+// events recorded since metrics were enabled.
+type runtimeMetrics struct {
+	lock       sync.Mutex
+	operations map[string]*define.StateOperationMetrics
+	events     map[[2]string]uint64
+}
+
+func newRuntimeMetrics() *runtimeMetrics {
+	return &runtimeMetrics{
+		operations: make(map[string]*define.StateOperationMetrics),
+		events:     make(map[[2]string]uint64),
+	}
+}
+
+// observe records a call of the state operation started at start. It is meant
+// to be deferred.
+func (m *runtimeMetrics) observe(operation string, start time.Time) {
+	seconds := time.Since(start).Seconds()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	op, ok := m.operations[operation]
+	if !ok {
+		op = &define.StateOperationMetrics{
+			Operation: operation,
+			Buckets:   make([]uint64, len(define.StateLatencyBuckets)),
+		}
+		m.operations[operation] = op
+	}
+	op.Count++
+	op.Sum += seconds
+	for i, bound := range define.StateLatencyBuckets {
+		if seconds <= bound {
+			op.Buckets[i]++
+		}
+	}
+}
+
+// countEvent records an event written by the runtime.
+func (m *runtimeMetrics) countEvent(e events.Event) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events[[2]string{e.Type.String(), e.Status.String()}]++
+}
+
+// snapshot returns copies of the recorded state operations and event counts.
+func (m *runtimeMetrics) snapshot() ([]define.StateOperationMetrics, []define.EventCount) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	operations := make([]define.StateOperationMetrics, 0, len(m.operations))
+	for _, op := range m.operations {
+		c := *op
+		c.Buckets = append([]uint64(nil), op.Buckets...)
+		operations = append(operations, c)
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].Operation < operations[j].Operation
+	})
+
+	counts := make([]define.EventCount, 0, len(m.events))
+	for key, count := range m.events {
+		counts = append(counts, define.EventCount{Type: key[0], Status: key[1], Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Type != counts[j].Type {
+			return counts[i].Type < counts[j].Type
+		}
+		return counts[i].Status < counts[j].Status
+	})
+	return operations, counts
+}
+
+// metricsEventer counts the events written to an eventer.
+type metricsEventer struct {
+	events.Eventer
+	metrics *runtimeMetrics
+}
+
+func (e *metricsEventer) Write(event events.Event) error {
+	if err := e.Eventer.Write(event); err != nil {
+		return err
+	}
+	e.metrics.countEvent(event)
+	return nil
+}
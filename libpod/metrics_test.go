@@ -0,0 +1,72 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsState(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+	require.NoError(t, WithMetrics()(runtime))
+	runtime.eventer = &metricsEventer{Eventer: events.NewMemoryEventer(), metrics: runtime.metrics}
+
+	sqlState, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	runtime.state = runtime.wrapState(sqlState)
+	defer runtime.state.Close()
+	assert.Equal(t, sqlState, runtime.state.(*instrumentedState).State)
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, runtime.state.AddContainer(ctr))
+	_, err = runtime.state.LookupContainer(ctr.Name())
+	require.NoError(t, err)
+	// Failed operations are recorded too.
+	_, err = runtime.state.LookupContainer("missing")
+	require.Error(t, err)
+	// Operations which are not instrumented are passed through.
+	_, err = runtime.state.GetDBConfig()
+	require.NoError(t, err)
+
+	e := events.NewEvent(events.Create)
+	e.Type = events.Container
+	require.NoError(t, runtime.eventer.Write(e))
+	require.NoError(t, runtime.eventer.Write(e))
+	e.Status = events.Start
+	require.NoError(t, runtime.eventer.Write(e))
+
+	operations, counts := runtime.metrics.snapshot()
+	require.Len(t, operations, 2)
+	assert.Equal(t, "AddContainer", operations[0].Operation)
+	assert.Equal(t, uint64(1), operations[0].Count)
+	assert.Equal(t, "LookupContainer", operations[1].Operation)
+	assert.Equal(t, uint64(2), operations[1].Count)
+	for _, op := range operations {
+		require.Len(t, op.Buckets, len(define.StateLatencyBuckets))
+		// The buckets are cumulative.
+		for i := 1; i < len(op.Buckets); i++ {
+			assert.GreaterOrEqual(t, op.Buckets[i], op.Buckets[i-1])
+		}
+		assert.LessOrEqual(t, op.Buckets[len(op.Buckets)-1], op.Count)
+		assert.Greater(t, op.Sum, 0.0)
+	}
+	assert.Equal(t, []define.EventCount{
+		{Type: "container", Status: "create", Count: 2},
+		{Type: "container", Status: "start", Count: 1},
+	}, counts)
+}
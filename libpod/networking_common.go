@@ -63,7 +63,44 @@ func (c *Container) getNetworkOptions(networkOpts map[string]types.PerNetworkOpt
 // setUpNetwork will set up the networks, on error it will also tear down the cni
 // networks. If rootless it will join/create the rootless network namespace.
 func (r *Runtime) setUpNetwork(ns string, opts types.NetworkOptions) (map[string]types.StatusBlock, error) {
-	return r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
+	if _, ok := opts.Networks[r.config.Network.DefaultNetwork]; ok {
+		if err := r.ensureDefaultNetwork(); err != nil {
+			return nil, err
+		}
+	}
+
+	reservations, err := r.state.NetworkReservations("")
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) == 0 {
+		return r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
+	}
+	opts, err = applyNetworkReservations(opts, reservations)
+	if err != nil {
+		return nil, err
+	}
+	// The IPAM of the network backend does not know about reservations.
+	// It hands out the addresses of a subnet in turn, so if it assigned an
+	// address reserved for another container, the network is set up again
+	// until it assigns one that is not reserved.
+	for attempt := 0; ; attempt++ {
+		status, err := r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
+		if err != nil {
+			return nil, err
+		}
+		res := reservedForOthers(status, opts.ContainerName, reservations)
+		if res == nil {
+			return status, nil
+		}
+		logrus.Debugf("Address %s on network %s is reserved for %s, setting up the network of %s again", res.Address, res.Network, res.Container, opts.ContainerName)
+		if err := r.teardownNetworkBackend(ns, opts); err != nil {
+			return nil, err
+		}
+		if attempt >= len(reservations) {
+			return nil, fmt.Errorf("no unreserved address left on network %s: %w", res.Network, define.ErrNetworkReserved)
+		}
+	}
 }
 
 // getNetworkPodName return the pod name (hostname) used by dns backend.
@@ -146,6 +183,13 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 		}
 	}
 
+	return r.setUpNetworkPreservingAddresses(ctr)
+}
+
+// setUpNetworkPreservingAddresses sets the network of the container up again
+// in its existing network namespace, after it was torn down, preserving the
+// MAC and IP addresses.
+func (r *Runtime) setUpNetworkPreservingAddresses(ctr *Container) (map[string]types.StatusBlock, error) {
 	networkOpts, err := ctr.networks()
 	if err != nil {
 		return nil, err
@@ -171,6 +215,65 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 	return r.configureNetNS(ctr, ctr.state.NetNS)
 }
 
+// updatePortMappings replaces the ports forwarded to the network namespace of
+// the container and persists them in its config. If the network of the
+// container is set up, the port forwarding of its bridge networks is
+// re-programmed, preserving MAC and IP addresses.
+// The container must be locked.
+func (c *Container) updatePortMappings(ports []types.PortMapping) error {
+	if c.state.NetNS != "" {
+		if !c.config.NetMode.IsBridge() {
+			return fmt.Errorf("published ports of container %s with the %s network mode can only be changed while it is stopped: %w", c.ID(), c.config.NetMode, define.ErrCtrStateInvalid)
+		}
+		// The rootlessport process forwarding the ports is only
+		// started along with a container publishing ports.
+		if rootless.IsRootless() && len(ports) > 0 && !c.hasRootlessPortForwarder() {
+			return fmt.Errorf("container %s was started without published ports and must be restarted to publish ports as rootless: %w", c.ID(), define.ErrCtrStateInvalid)
+		}
+
+		oldPorts := c.config.PortMappings
+		if err := c.forwardPorts(ports); err != nil {
+			// Restore the previous port forwarding.
+			if restoreErr := c.forwardPorts(oldPorts); restoreErr != nil {
+				logrus.Errorf("Restoring published ports of container %s: %v", c.ID(), restoreErr)
+			}
+			return fmt.Errorf("updating published ports of container %s: %w", c.ID(), err)
+		}
+	}
+
+	c.config.PortMappings = ports
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+		return fmt.Errorf("saving published ports of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// forwardPorts re-programs the port forwarding of the configured bridge
+// networks of the container to forward the given ports.
+func (c *Container) forwardPorts(ports []types.PortMapping) error {
+	if err := c.runtime.teardownNetwork(c); err != nil {
+		logrus.Infof("Tearing down network of container %s: %v", c.ID(), err)
+	}
+	if err := c.runtime.unexposeMachinePorts(c.config.PortMappings); err != nil {
+		logrus.Errorf("Failed to free gvproxy machine ports: %v", err)
+	}
+
+	c.config.PortMappings = ports
+	status, err := c.runtime.setUpNetworkPreservingAddresses(c)
+	if err != nil {
+		return err
+	}
+	c.state.NetworkStatus = status
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if rootless.IsRootless() && c.hasRootlessPortForwarder() {
+		return c.updateRootlessRLKPortMapping()
+	}
+	return nil
+}
+
 // Produce an InspectNetworkSettings containing information on the container
 // network.
 func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, error) {
@@ -194,6 +297,7 @@ func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, e
 
 	settings := new(define.InspectNetworkSettings)
 	settings.Ports = makeInspectPorts(c.config.PortMappings, c.config.ExposedPorts)
+	settings.Disturbances = c.state.NetworkDisturbances
 
 	networks, err := c.networks()
 	if err != nil {
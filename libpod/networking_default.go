@@ -0,0 +1,76 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/common/libnetwork/network"
+	"github.com/containers/common/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultNetworkLabel is set on default networks created on demand.
+const defaultNetworkLabel = "io.podman.network.default"
+
+// setUpDefaultNetworkBackend creates the network backend that creates a
+// custom default network configured with default_network in containers.conf.
+// The network backend of the runtime only keeps the default network in
+// memory, with a fixed ID and bridge name shared by everyone. A custom
+// default network is therefore created like a regular network on first use,
+// and recreated should its config be removed, by a second backend that does
+// not consider it as default.
+func (r *Runtime) setUpDefaultNetworkBackend() error {
+	name := r.config.Network.DefaultNetwork
+	if name == "" || name == types.DefaultNetworkName {
+		return nil
+	}
+	conf := *r.config
+	conf.Network.DefaultNetwork = types.DefaultNetworkName
+	conf.Network.DefaultSubnet = types.DefaultSubnet
+	_, backend, err := network.NetworkBackend(r.store, &conf, r.syslog)
+	if err != nil {
+		return fmt.Errorf("setting up network backend of default network %s: %w", name, err)
+	}
+	r.defaultNetworkBackend = backend
+	return nil
+}
+
+// ensureDefaultNetwork makes sure that a custom default network exists on
+// disk, with the driver, options and subnet configured in containers.conf.
+func (r *Runtime) ensureDefaultNetwork() error {
+	if r.defaultNetworkBackend == nil {
+		return nil
+	}
+	settings := r.podmanConf.Network
+	net := types.Network{
+		Name:    r.config.Network.DefaultNetwork,
+		Driver:  settings.DefaultNetworkDriver,
+		Options: settings.DefaultNetworkOptions,
+		Labels:  map[string]string{defaultNetworkLabel: "true"},
+	}
+	if net.Driver == "" {
+		net.Driver = types.BridgeNetworkDriver
+	}
+	if settings.DefaultNetworkDNS != nil {
+		net.DNSEnabled = *settings.DefaultNetworkDNS
+	} else {
+		net.DNSEnabled = net.Driver == types.BridgeNetworkDriver
+	}
+	// Without a custom subnet pick a free one from the subnet pools, the
+	// stock default subnet is taken by the in-memory podman network.
+	if subnet := r.config.Network.DefaultSubnet; subnet != "" && subnet != types.DefaultSubnet {
+		ipnet, err := types.ParseCIDR(subnet)
+		if err != nil {
+			return fmt.Errorf("parsing default subnet: %w", err)
+		}
+		net.Subnets = []types.Subnet{{Subnet: ipnet}}
+	}
+
+	created, err := r.defaultNetworkBackend.NetworkCreate(net, &types.NetworkCreateOptions{IgnoreIfExists: true})
+	if err != nil {
+		return fmt.Errorf("creating default network %s: %w", net.Name, err)
+	}
+	logrus.Debugf("Using default network %s with ID %s", created.Name, created.ID)
+	return nil
+}
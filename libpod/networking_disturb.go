@@ -0,0 +1,164 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// maxNetworkDisturbanceLatency is the largest latency netem accepts, as it
+// takes the latency in microseconds as a 32-bit integer.
+const maxNetworkDisturbanceLatency = time.Duration(math.MaxUint32) * time.Microsecond
+
+// DisturbNetwork injects latency and packet loss in the outgoing traffic of
+// the given interface of the container, or of all its interfaces but the
+// loopback if iface is empty. It blocks until duration elapsed or, if
+// duration is 0, until ctx is done, and then reverts the disturbance. The
+// container must be running in its own network namespace.
+func (c *Container) DisturbNetwork(ctx context.Context, iface string, latency time.Duration, loss float64, duration time.Duration) error {
+	if latency < 0 || latency > maxNetworkDisturbanceLatency {
+		return fmt.Errorf("latency must be between 0 and %s: %w", maxNetworkDisturbanceLatency, define.ErrInvalidArg)
+	}
+	if loss < 0 || loss > 100 {
+		return fmt.Errorf("packet loss must be between 0%% and 100%%: %w", define.ErrInvalidArg)
+	}
+	if latency == 0 && loss == 0 {
+		return fmt.Errorf("a latency or a packet loss must be given: %w", define.ErrInvalidArg)
+	}
+	if duration < 0 {
+		return fmt.Errorf("duration must not be negative: %w", define.ErrInvalidArg)
+	}
+
+	disturbances, err := c.applyNetworkDisturbances(iface, latency, loss, duration)
+	if err != nil {
+		return err
+	}
+
+	var expired <-chan time.Time
+	if duration > 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-expired:
+	case <-ctx.Done():
+	}
+
+	return c.revertNetworkDisturbances(disturbances)
+}
+
+// ClearNetworkDisturbances reverts all network disturbances of the
+// container, including those whose `podman network disturb` process was
+// killed before it could revert them.
+func (c *Container) ClearNetworkDisturbances() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if len(c.state.NetworkDisturbances) == 0 {
+		return nil
+	}
+
+	if c.state.NetNS != "" {
+		ifaces := make([]string, 0, len(c.state.NetworkDisturbances))
+		for _, d := range c.state.NetworkDisturbances {
+			ifaces = append(ifaces, d.Interface)
+		}
+		if err := removeNetem(c.state.NetNS, ifaces); err != nil {
+			return err
+		}
+	}
+	c.state.NetworkDisturbances = nil
+	return c.save()
+}
+
+// applyNetworkDisturbances injects the disturbance in the network namespace
+// of the container and records it in the state of the container. A
+// disturbance of an interface replaces the previous one.
+func (c *Container) applyNetworkDisturbances(iface string, latency time.Duration, loss float64, duration time.Duration) ([]define.NetworkDisturbance, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return nil, err
+	}
+	if c.state.State != define.ContainerStateRunning {
+		return nil, fmt.Errorf("container %s must be running to disturb its network: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	if c.config.NetNsCtr != "" {
+		return nil, fmt.Errorf("container %s uses the network namespace of container %s, disturb the network of that container instead: %w", c.ID(), c.config.NetNsCtr, define.ErrInvalidArg)
+	}
+	if c.state.NetNS == "" {
+		return nil, fmt.Errorf("container %s has no network namespace of its own: %w", c.ID(), define.ErrInvalidArg)
+	}
+
+	ifaces, err := applyNetem(c.state.NetNS, iface, latency, loss)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	disturbances := make([]define.NetworkDisturbance, 0, len(ifaces))
+	for _, name := range ifaces {
+		d := define.NetworkDisturbance{
+			Interface: name,
+			Loss:      loss,
+			Started:   now,
+		}
+		if latency > 0 {
+			d.Latency = latency.String()
+		}
+		if duration > 0 {
+			d.Expires = now.Add(duration)
+		}
+		disturbances = append(disturbances, d)
+	}
+	c.state.NetworkDisturbances = slices.DeleteFunc(c.state.NetworkDisturbances, func(d define.NetworkDisturbance) bool {
+		return slices.Contains(ifaces, d.Interface)
+	})
+	c.state.NetworkDisturbances = append(c.state.NetworkDisturbances, disturbances...)
+
+	return disturbances, c.save()
+}
+
+// revertNetworkDisturbances removes the given disturbances from the network
+// namespace of the container, unless they were replaced or cleared, or the
+// network namespace was torn down in the meantime.
+func (c *Container) revertNetworkDisturbances(disturbances []define.NetworkDisturbance) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	var ifaces []string
+	c.state.NetworkDisturbances = slices.DeleteFunc(c.state.NetworkDisturbances, func(d define.NetworkDisturbance) bool {
+		for _, reverted := range disturbances {
+			if d.Interface == reverted.Interface && d.Started.Equal(reverted.Started) {
+				ifaces = append(ifaces, d.Interface)
+				return true
+			}
+		}
+		return false
+	})
+	if len(ifaces) == 0 {
+		return nil
+	}
+
+	if c.state.NetNS != "" {
+		if err := removeNetem(c.state.NetNS, ifaces); err != nil {
+			return err
+		}
+	}
+	return c.save()
+}
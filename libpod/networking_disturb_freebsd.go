@@ -0,0 +1,18 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+func applyNetem(netNSPath, iface string, latency time.Duration, loss float64) ([]string, error) {
+	return nil, fmt.Errorf("network disturbances are not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+func removeNetem(netNSPath string, ifaces []string) error {
+	return fmt.Errorf("network disturbances are not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
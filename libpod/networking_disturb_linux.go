@@ -0,0 +1,113 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// applyNetem sets a netem queueing discipline with the given latency and
+// packet loss as root queueing discipline of the given interface in the
+// network namespace, or of all interfaces but the loopback if iface is empty.
+// It returns the names of the interfaces.
+func applyNetem(netNSPath, iface string, latency time.Duration, loss float64) ([]string, error) {
+	var ifaces []string
+	err := ns.WithNetNSPath(netNSPath, func(_ ns.NetNS) error {
+		links, err := disturbedLinks(iface)
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			attrs := netlink.QdiscAttrs{
+				LinkIndex: link.Attrs().Index,
+				Handle:    netlink.MakeHandle(1, 0),
+				Parent:    netlink.HANDLE_ROOT,
+			}
+			qdisc := netlink.NewNetem(attrs, netlink.NetemQdiscAttrs{
+				Latency: uint32(latency.Microseconds()),
+				Loss:    float32(loss),
+			})
+			if err := netlink.QdiscReplace(qdisc); err != nil {
+				return fmt.Errorf("adding netem queueing discipline to interface %s: %w", link.Attrs().Name, err)
+			}
+			ifaces = append(ifaces, link.Attrs().Name)
+		}
+		return nil
+	})
+	if err != nil {
+		// Do not leave the interfaces disturbed so far behind.
+		if len(ifaces) > 0 {
+			if rmErr := removeNetem(netNSPath, ifaces); rmErr != nil {
+				logrus.Errorf("Reverting network disturbance: %v", rmErr)
+			}
+		}
+		return nil, err
+	}
+	return ifaces, nil
+}
+
+// removeNetem removes the netem root queueing discipline of the given
+// interfaces in the network namespace. Interfaces which no longer exist are
+// skipped.
+func removeNetem(netNSPath string, ifaces []string) error {
+	return ns.WithNetNSPath(netNSPath, func(_ ns.NetNS) error {
+		for _, name := range ifaces {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				var notFound netlink.LinkNotFoundError
+				if errors.As(err, &notFound) {
+					continue
+				}
+				return fmt.Errorf("looking up interface %s: %w", name, err)
+			}
+			qdiscs, err := netlink.QdiscList(link)
+			if err != nil {
+				return fmt.Errorf("listing queueing disciplines of interface %s: %w", name, err)
+			}
+			for _, qdisc := range qdiscs {
+				if qdisc.Type() != "netem" || qdisc.Attrs().Parent != netlink.HANDLE_ROOT {
+					continue
+				}
+				if err := netlink.QdiscDel(qdisc); err != nil {
+					return fmt.Errorf("removing netem queueing discipline of interface %s: %w", name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// disturbedLinks returns the interface with the given name, or all
+// interfaces but the loopback if name is empty.
+func disturbedLinks(name string) ([]netlink.Link, error) {
+	if name != "" {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("looking up interface %s: %w", name, err)
+		}
+		return []netlink.Link{link}, nil
+	}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving all network interfaces: %w", err)
+	}
+	var disturbed []netlink.Link
+	for _, link := range links {
+		if link.Attrs().Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		disturbed = append(disturbed, link)
+	}
+	if len(disturbed) == 0 {
+		return nil, errors.New("the network namespace has no interface but the loopback")
+	}
+	return disturbed, nil
+}
@@ -115,6 +115,10 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		return nil, nil
 	}
 
+	if len(ctr.config.InterfaceOptions) > 0 {
+		return nil, fmt.Errorf("network options are not supported on FreeBSD: %w", define.ErrNotImplemented)
+	}
+
 	netOpts := ctr.getNetworkOptions(networks)
 	netStatus, err := r.setUpNetwork(ctrNS, netOpts)
 	if err != nil {
@@ -265,6 +269,14 @@ func (c *Container) reloadRootlessRLKPortMapping() error {
 	return errors.New("unsupported (*Container).reloadRootlessRLKPortMapping")
 }
 
+func (c *Container) updateRootlessRLKPortMapping() error {
+	return errors.New("unsupported (*Container).updateRootlessRLKPortMapping")
+}
+
+func (c *Container) hasRootlessPortForwarder() bool {
+	return false
+}
+
 func (c *Container) setupRootlessNetwork() error {
 	return nil
 }
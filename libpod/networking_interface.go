@@ -0,0 +1,82 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+const (
+	// interfaceOptionMTU is the interface option setting the MTU of the
+	// network interfaces of a container.
+	interfaceOptionMTU = "mtu"
+	// minInterfaceMTU is the smallest MTU accepted for an interface, the
+	// minimum MTU of IPv4.
+	minInterfaceMTU = 68
+	// maxInterfaceMTU is the largest MTU accepted for an interface.
+	maxInterfaceMTU = 65535
+)
+
+// interfaceOffloads are the interface options turning an offload of the
+// network interfaces of a container on or off, by their ethtool names.
+var interfaceOffloads = []string{"gro", "gso", "rx", "tso", "tx"}
+
+// validateInterfaceOptions checks the options of the network interfaces of a
+// container, and returns the MTU they set, or 0.
+func validateInterfaceOptions(options map[string]string) (int, error) {
+	mtu := 0
+	for key, value := range options {
+		switch {
+		case key == interfaceOptionMTU:
+			var err error
+			mtu, err = strconv.Atoi(value)
+			if err != nil || mtu < minInterfaceMTU || mtu > maxInterfaceMTU {
+				return 0, fmt.Errorf("invalid network option mtu=%s, the MTU must be between %d and %d: %w", value, minInterfaceMTU, maxInterfaceMTU, define.ErrInvalidArg)
+			}
+		case slices.Contains(interfaceOffloads, key):
+			if value != "on" && value != "off" {
+				return 0, fmt.Errorf("invalid network option %s=%s, it must be on or off: %w", key, value, define.ErrInvalidArg)
+			}
+		default:
+			return 0, fmt.Errorf("unknown network option %q, supported options are mtu, gro, gso, rx, tso and tx: %w", key, define.ErrInvalidArg)
+		}
+	}
+	return mtu, nil
+}
+
+// validateContainerInterfaceOptions checks that the container may set the
+// options of its network interfaces: it must use bridge networks, whose MTU
+// is not smaller than the MTU the options set.
+func (r *Runtime) validateContainerInterfaceOptions(ctr *Container) error {
+	if len(ctr.config.InterfaceOptions) == 0 {
+		return nil
+	}
+	mtu, err := validateInterfaceOptions(ctr.config.InterfaceOptions)
+	if err != nil {
+		return err
+	}
+	if !ctr.config.CreateNetNS || !ctr.config.NetMode.IsBridge() {
+		return fmt.Errorf("network options can only be set for containers using bridge networks: %w", define.ErrInvalidArg)
+	}
+	if mtu == 0 {
+		return nil
+	}
+	for name := range ctr.config.Networks {
+		network, err := r.network.NetworkInspect(name)
+		if err != nil {
+			return err
+		}
+		networkMTU, ok := network.Options[interfaceOptionMTU]
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(networkMTU); err == nil && mtu > n {
+			return fmt.Errorf("network option mtu=%d exceeds the MTU %d of network %s: %w", mtu, n, name, define.ErrInvalidArg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"unsafe"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/common/libnetwork/types"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// ethtoolSetCommands are the ethtool commands turning the interface offloads
+// on or off.
+var ethtoolSetCommands = map[string]uint32{
+	"gro": unix.ETHTOOL_SGRO,
+	"gso": unix.ETHTOOL_SGSO,
+	"rx":  unix.ETHTOOL_SRXCSUM,
+	"tso": unix.ETHTOOL_STSO,
+	"tx":  unix.ETHTOOL_STXCSUM,
+}
+
+// ethtoolValue is struct ethtool_value of linux/ethtool.h.
+type ethtoolValue struct {
+	cmd  uint32
+	data uint32
+}
+
+// ethtoolIfreq is struct ifreq of linux/if.h holding a pointer to an ethtool
+// request.
+type ethtoolIfreq struct {
+	name [unix.IFNAMSIZ]byte
+	data unsafe.Pointer
+	_    [24]byte
+}
+
+// applyInterfaceOptions sets the MTU and the offloads given by options on the
+// given interfaces in the network namespace. The MTU must not exceed the MTU
+// the interfaces were created with, the MTU of their network.
+func applyInterfaceOptions(netNSPath string, ifaces []string, options map[string]string) error {
+	return ns.WithNetNSPath(netNSPath, func(_ ns.NetNS) error {
+		for _, name := range ifaces {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				return fmt.Errorf("looking up interface %s: %w", name, err)
+			}
+			if value, ok := options[interfaceOptionMTU]; ok {
+				mtu, err := strconv.Atoi(value)
+				if err != nil {
+					return err
+				}
+				if mtu > link.Attrs().MTU {
+					return fmt.Errorf("network option mtu=%d exceeds the MTU %d of interface %s", mtu, link.Attrs().MTU, name)
+				}
+				if err := netlink.LinkSetMTU(link, mtu); err != nil {
+					return fmt.Errorf("setting the MTU of interface %s: %w", name, err)
+				}
+			}
+			for _, offload := range interfaceOffloads {
+				value, ok := options[offload]
+				if !ok {
+					continue
+				}
+				if err := setInterfaceOffload(name, ethtoolSetCommands[offload], value == "on"); err != nil {
+					return fmt.Errorf("turning %s %s on interface %s: %w", offload, value, name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// setInterfaceOffload turns an offload of the interface on or off with the
+// given ethtool command.
+func setInterfaceOffload(name string, cmd uint32, on bool) error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	value := ethtoolValue{cmd: cmd}
+	if on {
+		value.data = 1
+	}
+	ifr := ethtoolIfreq{data: unsafe.Pointer(&value)}
+	copy(ifr.name[:unix.IFNAMSIZ-1], name)
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// containerInterfaces returns the names of the interfaces of the given
+// network status, sorted.
+func containerInterfaces(status map[string]types.StatusBlock) []string {
+	var ifaces []string
+	for _, block := range status {
+		for name := range block.Interfaces {
+			ifaces = append(ifaces, name)
+		}
+	}
+	slices.Sort(ifaces)
+	return ifaces
+}
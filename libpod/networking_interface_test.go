@@ -0,0 +1,30 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInterfaceOptions(t *testing.T) {
+	mtu, err := validateInterfaceOptions(map[string]string{"mtu": "1400", "tso": "off", "gro": "on"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1400, mtu)
+
+	mtu, err = validateInterfaceOptions(map[string]string{"gso": "off"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mtu)
+
+	for _, options := range []map[string]string{
+		{"mtu": "abc"},
+		{"mtu": "67"},
+		{"mtu": "65536"},
+		{"tso": "false"},
+		{"lro": "off"},
+	} {
+		_, err := validateInterfaceOptions(options)
+		assert.Error(t, err, "%v", options)
+	}
+}
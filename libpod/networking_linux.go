@@ -63,6 +63,12 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		}
 	}()
 
+	if len(ctr.config.InterfaceOptions) > 0 {
+		if err := applyInterfaceOptions(ctrNS, containerInterfaces(netStatus), ctr.config.InterfaceOptions); err != nil {
+			return nil, err
+		}
+	}
+
 	// set up rootless port forwarder when rootless with ports and the network status is empty,
 	// if this is called from network reload the network status will not be empty and we should
 	// not set up port because they are still active
@@ -10,12 +10,16 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/containers/common/libnetwork/etchosts"
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/machine"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/sirupsen/logrus"
 )
 
@@ -132,3 +136,59 @@ func (r *Runtime) unexposeMachinePorts(ports []types.PortMapping) error {
 	}
 	return requestMachinePorts(false, ports)
 }
+
+// publishesMachineHostPorts reports whether the container was created with
+// --network host-gateway-all, i.e. it shares the host network namespace of
+// the machine but still wants its ports published to the machine host.
+func (c *Container) publishesMachineHostPorts() bool {
+	return slices.Contains(c.config.NetworkOptions["host"], define.HostGatewayAllNetworkOption)
+}
+
+// exposeMachineHostPorts forwards the ports of a host-gateway-all container
+// through gvproxy.  There is no network namespace for these containers so the
+// forwarding is tracked in the container state instead.
+func (c *Container) exposeMachineHostPorts() error {
+	if !c.publishesMachineHostPorts() || c.state.MachinePortsExposed {
+		return nil
+	}
+	if err := c.runtime.exposeMachinePorts(c.config.PortMappings); err != nil {
+		return err
+	}
+	c.state.MachinePortsExposed = true
+	return nil
+}
+
+// unexposeMachineHostPorts removes the gvproxy forwarding added by
+// exposeMachineHostPorts.
+func (c *Container) unexposeMachineHostPorts() {
+	if !c.state.MachinePortsExposed {
+		return
+	}
+	if err := c.runtime.unexposeMachinePorts(c.config.PortMappings); err != nil {
+		logrus.Errorf("failed to free gvproxy machine ports: %v", err)
+	}
+	c.state.MachinePortsExposed = false
+}
+
+var (
+	machineHostIPOnce sync.Once
+	machineHostIP     string
+)
+
+// machineHostContainersInternalIP returns the address gvproxy resolves
+// host.containers.internal to.  Writing it to /etc/hosts keeps the name
+// working for containers that do not use the machine DNS server, e.g.
+// with --dns or in the host network namespace.
+func machineHostContainersInternalIP() string {
+	machineHostIPOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ips, err := net.DefaultResolver.LookupHost(ctx, etchosts.HostContainersInternal)
+		if err != nil || len(ips) == 0 {
+			logrus.Debugf("Unable to resolve %s via gvproxy: %v", etchosts.HostContainersInternal, err)
+			return
+		}
+		machineHostIP = ips[0]
+	})
+	return machineHostIP
+}
@@ -0,0 +1,133 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// checkPortConflicts checks that the host ports published by the container
+// are neither published by another running container nor in use by a process
+// on the host, so the conflict is reported when the container is created
+// instead of when it is started.
+func (r *Runtime) checkPortConflicts(ctr *Container) error {
+	ports := ctr.config.PortMappings
+	if len(ports) == 0 {
+		return nil
+	}
+
+	// The ports of containers using netavark with root privileges are
+	// forwarded by the firewall and held by conmon, so the owner is best
+	// found in the state.
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	for _, other := range ctrs {
+		if other.ID() == ctr.ID() {
+			continue
+		}
+		for _, port := range ports {
+			idx := slices.IndexFunc(other.config.PortMappings, func(otherPort types.PortMapping) bool {
+				return portMappingsOverlap(port, otherPort)
+			})
+			if idx < 0 {
+				continue
+			}
+			state, err := other.State()
+			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+					break
+				}
+				return err
+			}
+			if state != define.ContainerStateRunning && state != define.ContainerStatePaused && state != define.ContainerStateStopping {
+				break
+			}
+			otherPort := other.config.PortMappings[idx]
+			return fmt.Errorf("host port %s is already published by container %s (%s): %w", formatHostPort(otherPort), other.Name(), other.ID()[:12], define.ErrPortInUse)
+		}
+	}
+
+	for _, port := range ports {
+		if port.HostPort == 0 {
+			continue
+		}
+		isV6 := port.HostIP != "" && net.ParseIP(port.HostIP).To4() == nil
+		sctpWarning := false
+		for _, protocol := range strings.Split(port.Protocol, ",") {
+			for i := uint16(0); i < port.Range; i++ {
+				f, err := bindPort(protocol, port.HostIP, port.HostPort+i, isV6, &sctpWarning)
+				if f != nil {
+					if err := f.Close(); err != nil {
+						logrus.Warnf("Failed to close socket of port %d/%s: %v", port.HostPort+i, protocol, err)
+					}
+				}
+				// Other errors, such as a missing permission to
+				// bind a privileged port, are left to the start
+				// of the container.
+				if err == nil || !errors.Is(err, syscall.EADDRINUSE) {
+					continue
+				}
+				owner := "a process on the host"
+				if name, pid := hostPortOwner(protocol, port.HostPort+i); pid > 0 {
+					owner = fmt.Sprintf("process %s (pid %d) on the host", name, pid)
+				}
+				return fmt.Errorf("host port %d/%s is already in use by %s: %w", port.HostPort+i, protocol, owner, define.ErrPortInUse)
+			}
+		}
+	}
+	return nil
+}
+
+// portMappingsOverlap returns true if the two port mappings publish a common
+// host port with a common protocol on a common host IP.
+func portMappingsOverlap(a, b types.PortMapping) bool {
+	// A random host port is assigned when the container starts.
+	if a.HostPort == 0 || b.HostPort == 0 {
+		return false
+	}
+	if !slices.ContainsFunc(strings.Split(a.Protocol, ","), func(protocol string) bool {
+		return slices.Contains(strings.Split(b.Protocol, ","), protocol)
+	}) {
+		return false
+	}
+	if !hostIPsOverlap(a.HostIP, b.HostIP) {
+		return false
+	}
+	aEnd := uint32(a.HostPort) + uint32(max(a.Range, 1))
+	bEnd := uint32(b.HostPort) + uint32(max(b.Range, 1))
+	return uint32(a.HostPort) < bEnd && uint32(b.HostPort) < aEnd
+}
+
+// hostIPsOverlap returns true if ports bound to the two host IPs conflict. An
+// empty or unspecified IP conflicts with every IP.
+func hostIPsOverlap(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipA.IsUnspecified() || ipB == nil || ipB.IsUnspecified() {
+		return true
+	}
+	return ipA.Equal(ipB)
+}
+
+// formatHostPort formats the host ports of a port mapping, e.g.
+// 127.0.0.1:8080-8081/tcp.
+func formatHostPort(port types.PortMapping) string {
+	s := fmt.Sprintf("%d", port.HostPort)
+	if port.Range > 1 {
+		s = fmt.Sprintf("%d-%d", port.HostPort, port.HostPort+port.Range-1)
+	}
+	if port.HostIP != "" {
+		s = net.JoinHostPort(port.HostIP, s)
+	}
+	return s + "/" + port.Protocol
+}
@@ -0,0 +1,9 @@
+//go:build !remote
+
+package libpod
+
+// hostPortOwner is not supported on FreeBSD and never finds the owner of a
+// port.
+func hostPortOwner(protocol string, port uint16) (string, int) {
+	return "", 0
+}
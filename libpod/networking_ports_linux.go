@@ -0,0 +1,88 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hostPortOwner returns the name and PID of the process on the host holding a
+// socket bound to the given port, or a PID of 0 if it cannot be found, e.g.
+// because the process belongs to another user.
+func hostPortOwner(protocol string, port uint16) (string, int) {
+	// Listening TCP sockets are in the LISTEN state, bound UDP sockets in
+	// the CLOSE state.
+	state := "0A"
+	if protocol == "udp" {
+		state = "07"
+	}
+	inodes := make(map[string]bool)
+	for _, family := range []string{"", "6"} {
+		f, err := os.Open(filepath.Join("/proc/net", protocol+family))
+		if err != nil {
+			continue
+		}
+		for _, inode := range socketInodes(f, port, state) {
+			inodes[inode] = true
+		}
+		f.Close()
+	}
+	if len(inodes) == 0 {
+		return "", 0
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return "", 0
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", proc.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			inode, ok := strings.CutPrefix(link, "socket:[")
+			if !ok || !inodes[strings.TrimSuffix(inode, "]")] {
+				continue
+			}
+			comm, err := os.ReadFile(filepath.Join("/proc", proc.Name(), "comm"))
+			if err != nil {
+				return "", 0
+			}
+			return strings.TrimSpace(string(comm)), pid
+		}
+	}
+	return "", 0
+}
+
+// socketInodes returns the inodes of the sockets in the given state bound to
+// the given local port, read from a /proc/net/{tcp,udp}[6] table.
+func socketInodes(r io.Reader, port uint16, state string) []string {
+	var inodes []string
+	localPort := fmt.Sprintf(":%04X", port)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != state || !strings.HasSuffix(fields[1], localPort) {
+			continue
+		}
+		inodes = append(inodes, fields[9])
+	}
+	return inodes
+}
@@ -0,0 +1,21 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSocketInodes(t *testing.T) {
+	table := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 31337 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:A2C4 01 00000000:00000000 00:00000000 00000000  1000        0 31338 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:1F91 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 31339 1 0000000000000000 100 0 0 10 0
+`
+	assert.Equal(t, []string{"31337"}, socketInodes(strings.NewReader(table), 8080, "0A"))
+	assert.Equal(t, []string{"31339"}, socketInodes(strings.NewReader(table), 8081, "0A"))
+	assert.Empty(t, socketInodes(strings.NewReader(table), 8082, "0A"))
+}
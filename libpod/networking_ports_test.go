@@ -0,0 +1,74 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortMappingsOverlap(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   types.PortMapping
+		expect bool
+	}{
+		{
+			name:   "same port",
+			a:      types.PortMapping{HostPort: 8080, Range: 1, Protocol: "tcp"},
+			b:      types.PortMapping{HostPort: 8080, Range: 1, Protocol: "tcp"},
+			expect: true,
+		},
+		{
+			name: "different ports",
+			a:    types.PortMapping{HostPort: 8080, Range: 1, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 8081, Range: 1, Protocol: "tcp"},
+		},
+		{
+			name:   "overlapping ranges",
+			a:      types.PortMapping{HostPort: 8080, Range: 3, Protocol: "tcp"},
+			b:      types.PortMapping{HostPort: 8082, Range: 5, Protocol: "tcp"},
+			expect: true,
+		},
+		{
+			name: "adjacent ranges",
+			a:    types.PortMapping{HostPort: 8080, Range: 2, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 8082, Range: 2, Protocol: "tcp"},
+		},
+		{
+			name: "different protocols",
+			a:    types.PortMapping{HostPort: 53, Range: 1, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 53, Range: 1, Protocol: "udp"},
+		},
+		{
+			name:   "common protocol",
+			a:      types.PortMapping{HostPort: 53, Range: 1, Protocol: "tcp,udp"},
+			b:      types.PortMapping{HostPort: 53, Range: 1, Protocol: "udp"},
+			expect: true,
+		},
+		{
+			name: "different host IPs",
+			a:    types.PortMapping{HostIP: "127.0.0.1", HostPort: 8080, Range: 1, Protocol: "tcp"},
+			b:    types.PortMapping{HostIP: "127.0.0.2", HostPort: 8080, Range: 1, Protocol: "tcp"},
+		},
+		{
+			name:   "all host IPs",
+			a:      types.PortMapping{HostIP: "127.0.0.1", HostPort: 8080, Range: 1, Protocol: "tcp"},
+			b:      types.PortMapping{HostIP: "0.0.0.0", HostPort: 8080, Range: 1, Protocol: "tcp"},
+			expect: true,
+		},
+		{
+			name: "random host port",
+			a:    types.PortMapping{HostPort: 0, Range: 1, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 0, Range: 1, Protocol: "tcp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, portMappingsOverlap(tt.a, tt.b))
+			assert.Equal(t, tt.expect, portMappingsOverlap(tt.b, tt.a))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/containers/common/libnetwork/slirp4netns"
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/containers/storage/pkg/fileutils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -80,13 +81,58 @@ func (c *Container) reloadRootlessRLKPortMapping() error {
 	childIP := slirp4netns.GetRootlessPortChildIP(nil, c.state.NetworkStatus)
 	logrus.Debugf("reloading rootless ports for container %s, childIP is %s", c.config.ID, childIP)
 
-	conn, err := openUnixSocket(filepath.Join(c.runtime.config.Engine.TmpDir, "rp", c.config.ID))
-	if err != nil {
+	if err := c.sendRootlessPortRequest(childIP); err != nil {
 		return fmt.Errorf("could not reload rootless port mappings, port forwarding may no longer work correctly: %w", err)
 	}
+	return nil
+}
+
+// rootlessPortUpdate is sent to the rootlessport process to replace the
+// forwarded ports. It must match the request decoded by rootlessport.
+type rootlessPortUpdate struct {
+	ChildIP  string              `json:"childIP"`
+	Mappings []types.PortMapping `json:"mappings"`
+}
+
+// updateRootlessRLKPortMapping replaces the ports forwarded by the rootlessport
+// process with the port mappings of the container.
+// This should only be called as rootless.
+func (c *Container) updateRootlessRLKPortMapping() error {
+	childIP := slirp4netns.GetRootlessPortChildIP(nil, c.state.NetworkStatus)
+	logrus.Debugf("updating rootless ports for container %s, childIP is %s", c.config.ID, childIP)
+
+	ports := c.convertPortMappings()
+	if ports == nil {
+		ports = []types.PortMapping{}
+	}
+	if err := c.sendRootlessPortRequest(rootlessPortUpdate{ChildIP: childIP, Mappings: ports}); err != nil {
+		return fmt.Errorf("could not update rootless port mappings: %w", err)
+	}
+	return nil
+}
+
+// hasRootlessPortForwarder returns whether a rootlessport process forwards
+// ports to the container.
+func (c *Container) hasRootlessPortForwarder() bool {
+	return fileutils.Exists(c.rootlessPortSocketPath()) == nil
+}
+
+// rootlessPortSocketPath returns the path of the socket of the rootlessport
+// process of the container.
+func (c *Container) rootlessPortSocketPath() string {
+	return filepath.Join(c.runtime.config.Engine.TmpDir, "rp", c.config.ID)
+}
+
+// sendRootlessPortRequest sends the JSON encoded request to the rootlessport
+// process of the container and waits for its answer.
+func (c *Container) sendRootlessPortRequest(request any) error {
+	conn, err := openUnixSocket(c.rootlessPortSocketPath())
+	if err != nil {
+		return err
+	}
 	defer conn.Close()
 	enc := json.NewEncoder(conn)
-	err = enc.Encode(childIP)
+	err = enc.Encode(request)
 	if err != nil {
 		return fmt.Errorf("port reloading failed: %w", err)
 	}
@@ -63,7 +63,6 @@ type ConmonOCIRuntime struct {
 	supportsJSON      bool
 	supportsKVM       bool
 	supportsNoCgroups bool
-	enableKeyring     bool
 	persistDir        string
 }
 
@@ -102,7 +101,6 @@ func newConmonOCIRuntime(name string, paths []string, conmonPath string, runtime
 	runtime.logSizeMax = runtimeCfg.Containers.LogSizeMax
 	runtime.noPivot = runtimeCfg.Engine.NoPivotRoot
 	runtime.reservePorts = runtimeCfg.Engine.EnablePortReservation
-	runtime.enableKeyring = runtimeCfg.Containers.EnableKeyring
 
 	// TODO: probe OCI runtime for feature and enable automatically if
 	// available.
@@ -751,7 +749,7 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 				logrus.Errorf("Unable to close conn: %v", connErr)
 			}
 		case <-cancel:
-			return nil
+			return define.ErrAttachCanceled
 		}
 	}
 }
@@ -804,6 +802,9 @@ func (r *ConmonOCIRuntime) CheckpointContainer(ctr *Container, options Container
 	imagePath := ctr.CheckpointPath()
 	if options.PreCheckPoint {
 		imagePath = ctr.PreCheckPointPath()
+		if options.preDumpDir != "" {
+			imagePath = filepath.Join(ctr.bundlePath(), options.preDumpDir)
+		}
 	}
 	// workPath will be used to store dump.log and stats-dump
 	workPath := ctr.bundlePath()
@@ -832,11 +833,11 @@ func (r *ConmonOCIRuntime) CheckpointContainer(ctr *Container, options Container
 	if options.PreCheckPoint {
 		args = append(args, "--pre-dump")
 	}
-	if !options.PreCheckPoint && options.WithPrevious {
+	if options.parentDir != "" {
 		args = append(
 			args,
 			"--parent-path",
-			filepath.Join("..", preCheckpointDir),
+			filepath.Join("..", options.parentDir),
 		)
 	}
 
@@ -1105,7 +1106,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		args = append(args, fmt.Sprintf("--timeout=%d", ctr.config.Timeout))
 	}
 
-	if !r.enableKeyring {
+	if ctr.Keyring() == define.KeyringInherit {
 		args = append(args, "--no-new-keyring")
 	}
 	if ctr.config.ConmonPidFile != "" {
@@ -1131,7 +1132,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 
 	// Pass down the LISTEN_* environment (see #10443).
 	if val := os.Getenv("LISTEN_FDS"); val != "" {
-		if preserveFDs > 0 || len(ctr.config.PreserveFD) > 0 {
+		if preserveFDs > 0 || len(ctr.config.PreserveFD) > 0 || len(ctr.config.ListenSockets) > 0 {
 			logrus.Warnf("Ignoring LISTEN_FDS to preserve custom user-specified FDs")
 		} else {
 			fds, err := strconv.Atoi(val)
@@ -1146,6 +1147,18 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	if err != nil {
 		return 0, err
 	}
+
+	// Published sockets are passed to the container as the first FDs.
+	var listenSockets []*os.File
+	if len(ctr.config.ListenSockets) > 0 {
+		listenSockets, err = ctr.listenSocketFiles()
+		if err != nil {
+			return 0, err
+		}
+		filesToClose = append(filesToClose, listenSockets...)
+		extraFiles = listenSockets
+		preserveFDs = uint(len(listenSockets))
+	}
 	if preserveFDs > 0 {
 		args = append(args, formatRuntimeOpts("--preserve-fds", strconv.FormatUint(uint64(preserveFDs), 10))...)
 	}
@@ -1216,7 +1229,13 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	cmd.Env = append(cmd.Env, conmonEnv...)
 	cmd.ExtraFiles = append(cmd.ExtraFiles, childSyncPipe, childStartPipe)
 
-	if r.reservePorts && !rootless.IsRootless() && !ctr.config.NetMode.IsSlirp4netns() {
+	// Conmon keeps the published sockets open while the container runs, so
+	// they can be taken over by the replacement of the container.
+	cmd.ExtraFiles = append(cmd.ExtraFiles, listenSockets...)
+
+	// Containers in the host network namespace publishing their ports to the
+	// machine host listen on the ports themselves, do not reserve them.
+	if r.reservePorts && !rootless.IsRootless() && !ctr.config.NetMode.IsSlirp4netns() && !ctr.publishesMachineHostPorts() {
 		ports, err := bindPorts(ctr.convertPortMappings())
 		if err != nil {
 			return 0, err
@@ -1302,6 +1321,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		logrus.Infof("Got Conmon PID as %d", conmonPID)
 		ctr.state.ConmonPID = conmonPID
 	}
+	ctr.state.ListenSocketInodes = listenSocketInodes(listenSockets)
 
 	runtimeRestoreDuration := func() int64 {
 		if restoreOptions != nil && restoreOptions.PrintStats {
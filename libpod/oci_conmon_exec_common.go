@@ -657,7 +657,7 @@ func attachExecHTTP(c *Container, sessionID string, r *http.Request, w http.Resp
 				logrus.Errorf("Unable to close conn: %v", connErr)
 			}
 		case <-cancel:
-			return nil
+			return define.ErrAttachCanceled
 		}
 	}
 }
@@ -698,6 +698,13 @@ func (c *Container) prepareProcessExec(options *ExecOptions, env []string, sessi
 		}
 		pspec.Env = append(pspec.Env, fmt.Sprintf("%s=%s", name, string(data)))
 	}
+	templateEnv, err := c.resolveEnvTemplate()
+	if err != nil {
+		return nil, err
+	}
+	for name, val := range templateEnv {
+		pspec.Env = append(pspec.Env, fmt.Sprintf("%s=%s", name, val))
+	}
 
 	if options.Cwd != "" {
 		pspec.Cwd = options.Cwd
@@ -18,6 +18,7 @@ import (
 	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
+	"github.com/containers/podman/v5/pkg/env"
 	"github.com/containers/podman/v5/pkg/namespaces"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/util"
@@ -491,6 +492,53 @@ func WithRenumber() RuntimeOption {
 	}
 }
 
+// WithReadOnlyState opens the state database read only, so tools can inspect
+// containers, pods and volumes without writing to the database or holding its
+// write lock. Any operation modifying the state fails. The database must have
+// been created by a read-write runtime of this version before.
+func WithReadOnlyState() RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.readOnlyState = true
+
+		return nil
+	}
+}
+
+// WithEventsWebhookDelivery tells the runtime that the process delivers the
+// events to the events webhooks with DeliverEventsToWebhooks, as the system
+// service does, so it does not warn that no process delivers them.
+func WithEventsWebhookDelivery() RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.deliversEventsWebhooks = true
+
+		return nil
+	}
+}
+
+// WithMetrics records the latencies of state operations and the events
+// written by the runtime, which are reported by Metrics. The system service
+// enables it with its --metrics option, the operations and events of other
+// Podman processes are not recorded.
+func WithMetrics() RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.metrics = newRuntimeMetrics()
+
+		return nil
+	}
+}
+
 // WithEventsLogger sets the events backend to use.
 // Currently supported values are "file" for file backend and "journald" for
 // journald backend.
@@ -929,6 +977,20 @@ func WithNetNSFrom(nsCtr *Container) CtrCreateOption {
 	}
 }
 
+// WithPodNetworkOptOut indicates that the container does not join the network
+// namespace of its pod, even though the pod shares it.
+func WithPodNetworkOptOut() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.PodNetworkOptOut = true
+
+		return nil
+	}
+}
+
 // WithPIDNSFrom indicates that the container should join the PID namespace of
 // the given container.
 // If the container has joined a pod, it can only join the namespaces of
@@ -1086,6 +1148,23 @@ func WithNetNS(portMappings []nettypes.PortMapping, exposedPorts map[uint16][]st
 	}
 }
 
+// WithMachineHostPorts sets the port mappings for a container that joins the
+// host network namespace.  The ports are not mapped by a network backend but
+// published to the podman machine host through gvproxy when the container
+// starts.
+func WithMachineHostPorts(portMappings []nettypes.PortMapping, exposedPorts map[uint16][]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.PortMappings = portMappings
+		ctr.config.ExposedPorts = exposedPorts
+
+		return nil
+	}
+}
+
 // WithNetworkOptions sets additional options for the networks.
 func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1099,6 +1178,23 @@ func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 	}
 }
 
+// WithInterfaceOptions sets the MTU and the offloads of the interfaces of the
+// container in its bridge networks.
+func WithInterfaceOptions(options map[string]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if _, err := validateInterfaceOptions(options); err != nil {
+			return err
+		}
+		ctr.config.InterfaceOptions = options
+
+		return nil
+	}
+}
+
 // WithLogDriver sets the log driver for the container
 func WithLogDriver(driver string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1477,11 +1573,15 @@ func WithImageVolumes(volumes []*ContainerImageVolume) CtrCreateOption {
 		}
 
 		for _, vol := range volumes {
+			if vol.Persist && !vol.ReadWrite {
+				return fmt.Errorf("image volume %q:%q must be read-write to persist its changes: %w", vol.Source, vol.Dest, define.ErrInvalidArg)
+			}
 			ctr.config.ImageVolumes = append(ctr.config.ImageVolumes, &ContainerImageVolume{
 				Dest:      vol.Dest,
 				Source:    vol.Source,
 				ReadWrite: vol.ReadWrite,
 				SubPath:   vol.SubPath,
+				Persist:   vol.Persist,
 			})
 		}
 
@@ -1535,6 +1635,18 @@ func WithPreserveFD(fds []uint) CtrCreateOption {
 	}
 }
 
+// WithListenSockets binds the given sockets on the host and passes them to
+// the container using socket activation.
+func WithListenSockets(sockets []define.ListenSocket) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.ListenSockets = sockets
+		return nil
+	}
+}
+
 // WithCreateCommand adds the full command plus arguments of the current
 // process to the container config.
 func WithCreateCommand(cmd []string) CtrCreateOption {
@@ -1576,6 +1688,122 @@ func WithIsService(ecp define.KubeExitCodePropagation) CtrCreateOption {
 	}
 }
 
+// WithWatchProcesses makes libpod watch the processes inside the container
+// while it runs and write a process event whenever one starts or exits.
+func WithWatchProcesses() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.WatchProcesses = true
+		return nil
+	}
+}
+
+// WithProvisionScript sets a script run inside the container once, on its
+// first start.
+func WithProvisionScript(script string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.ProvisionScript = script
+		return nil
+	}
+}
+
+// WithOnExitNotify sets the notifiers notified by the cleanup of the container
+// after it exited, and the template of the notifications.
+func WithOnExitNotify(notifiers []string, tmpl string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for _, n := range notifiers {
+			if _, err := define.ParseExitNotifier(n); err != nil {
+				return err
+			}
+		}
+		if tmpl != "" {
+			if _, err := define.ParseExitNotifyTemplate(tmpl); err != nil {
+				return err
+			}
+		}
+
+		ctr.config.OnExitNotify = notifiers
+		ctr.config.OnExitNotifyTemplate = tmpl
+		return nil
+	}
+}
+
+// WithStartConditions sets the conditions on the host which must be met
+// before the container is started, and how long they are waited for.
+func WithStartConditions(conditions []string, timeout time.Duration) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for _, c := range conditions {
+			if _, err := define.ParseStartCondition(c); err != nil {
+				return err
+			}
+		}
+		if timeout < 0 {
+			return fmt.Errorf("start conditions timeout must not be negative: %w", define.ErrInvalidArg)
+		}
+
+		ctr.config.StartConditions = conditions
+		ctr.config.StartConditionsTimeout = timeout
+		return nil
+	}
+}
+
+// WithProxyProfile records the name of the proxy profile injected into the
+// container.
+func WithProxyProfile(profile string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.ProxyProfile = profile
+		return nil
+	}
+}
+
+// WithSecurityProfile records the security profile enforced on the container
+// and the containers.conf default it overrides, if any.
+func WithSecurityProfile(profile, overridden string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.SecurityProfile = profile
+		ctr.config.SecurityProfileOverridden = overridden
+		return nil
+	}
+}
+
+// WithKeyring sets the keyring mode of the container.
+func WithKeyring(mode string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if err := define.ValidateKeyring(mode); err != nil {
+			return err
+		}
+
+		ctr.config.Keyring = mode
+		return nil
+	}
+}
+
 // WithCreateWorkingDir tells Podman to create the container's working directory
 // if it does not exist.
 func WithCreateWorkingDir() CtrCreateOption {
@@ -1755,10 +1983,10 @@ func WithVolumeDisableQuota() VolumeCreateOption {
 	}
 }
 
-// withSetAnon sets a bool notifying libpod that this volume is anonymous and
+// WithVolumeAnonymous sets a bool notifying libpod that this volume is anonymous and
 // should be removed when containers using it are removed and volumes are
 // specified for removal.
-func withSetAnon() VolumeCreateOption {
+func WithVolumeAnonymous() VolumeCreateOption {
 	return func(volume *Volume) error {
 		if volume.valid {
 			return define.ErrVolumeFinalized
@@ -1856,6 +2084,21 @@ func WithEnvSecrets(envSecrets map[string]string) CtrCreateOption {
 	}
 }
 
+// WithEnvTemplate sets an env file template whose placeholders are resolved
+// from secrets and the host environment when the container is started.
+func WithEnvTemplate(tmpl string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if err := env.ValidateTemplate(tmpl); err != nil {
+			return err
+		}
+		ctr.config.EnvTemplate = tmpl
+		return nil
+	}
+}
+
 // WithPidFile adds pidFile to the container
 func WithPidFile(pidFile string) CtrCreateOption {
 	return func(ctr *Container) error {
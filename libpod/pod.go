@@ -96,6 +96,31 @@ type PodConfig struct {
 	ResourceLimits specs.LinuxResources
 }
 
+// sharedNamespaces returns the names of the namespaces the containers of the
+// pod share, as shown by pod inspect.
+func (c *PodConfig) sharedNamespaces() []string {
+	namespaces := []struct {
+		name   string
+		shared bool
+	}{
+		{"pid", c.UsePodPID},
+		{"ipc", c.UsePodIPC},
+		{"net", c.UsePodNet},
+		{"mount", c.UsePodMount},
+		{"user", c.UsePodUser},
+		{"uts", c.UsePodUTS},
+		{"cgroup", c.UsePodCgroupNS},
+	}
+
+	shared := []string{}
+	for _, ns := range namespaces {
+		if ns.shared {
+			shared = append(shared, ns.name)
+		}
+	}
+	return shared
+}
+
 // podState represents a pod's state
 type podState struct {
 	// CgroupPath is the path to the pod's Cgroup
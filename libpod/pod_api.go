@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
@@ -72,6 +73,13 @@ func (p *Pod) startInitContainers(ctx context.Context) error {
 // set to ErrPodPartialFail.
 // If both error and the map are nil, all containers were started successfully.
 func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
+	return p.StartParallel(ctx, 0)
+}
+
+// StartParallel starts all containers within a pod like Start, starting at
+// most parallelism containers whose dependencies are running concurrently, or
+// the number of parallel jobs if parallelism is 0.
+func (p *Pod) StartParallel(ctx context.Context, parallelism uint) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -103,14 +111,9 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		return nil, fmt.Errorf("no containers in pod %s have no dependencies, cannot start pod: %w", p.ID(), define.ErrNoSuchCtr)
 	}
 
-	ctrErrors := make(map[string]error)
-	ctrsVisited := make(map[string]bool)
-
-	// Traverse the graph beginning at nodes with no dependencies
-	for _, node := range graph.noDepNodes {
-		startNode(ctx, node, false, ctrErrors, ctrsVisited, false)
-	}
-
+	// Start the containers of the graph, beginning at nodes with no
+	// dependencies
+	ctrErrors := startGraph(ctx, graph, parallelism, false)
 	if len(ctrErrors) > 0 {
 		return ctrErrors, fmt.Errorf("starting some containers: %w", define.ErrPodPartialFail)
 	}
@@ -140,13 +143,20 @@ func (p *Pod) Stop(ctx context.Context, cleanup bool) (map[string]error, error)
 // set to ErrPodPartialFail.
 // If both error and the map are nil, all containers were stopped without error.
 func (p *Pod) StopWithTimeout(ctx context.Context, cleanup bool, timeout int) (map[string]error, error) {
+	return p.StopParallel(ctx, cleanup, timeout, 0)
+}
+
+// StopParallel stops all containers within a pod like StopWithTimeout,
+// stopping at most parallelism containers concurrently, or the number of
+// parallel jobs if parallelism is 0.
+func (p *Pod) StopParallel(ctx context.Context, cleanup bool, timeout int, parallelism uint) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	return p.stopWithTimeout(ctx, cleanup, timeout)
+	return p.stopWithTimeout(ctx, cleanup, timeout, parallelism)
 }
 
-func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int) (map[string]error, error) {
+func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int, parallelism uint) (map[string]error, error) {
 	if !p.valid {
 		return nil, define.ErrPodRemoved
 	}
@@ -156,50 +166,46 @@ func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int) (m
 		return nil, err
 	}
 
-	// Stopping pods is not ordered by dependency. We haven't seen any case
-	// where this would actually matter.
-
-	ctrErrChan := make(map[string]<-chan error)
+	// Containers are stopped before the containers they depend on, such as
+	// the infra container. Stopping must not fail because of dependencies
+	// on containers outside of the pod, which are ignored then.
+	graph, err := BuildContainerGraph(allCtrs)
+	if err != nil {
+		logrus.Debugf("Generating dependency graph for pod %s, stopping its containers in any order: %v", p.ID(), err)
+		graph = &ContainerGraph{nodes: make(map[string]*containerNode, len(allCtrs))}
+		for _, ctr := range allCtrs {
+			graph.nodes[ctr.ID()] = &containerNode{id: ctr.ID(), container: ctr}
+		}
+	}
 
-	// Enqueue a function for each container with the parallel executor.
-	for _, ctr := range allCtrs {
-		c := ctr
-		logrus.Debugf("Adding parallel job to stop container %s", c.ID())
-		retChan := parallel.Enqueue(ctx, func() error {
-			// Can't batch these without forcing Stop() to hold the
-			// lock for the full duration of the timeout.
-			// We probably don't want to do that.
-			if timeout > -1 {
-				if err := c.StopWithTimeout(uint(timeout)); err != nil {
-					return err
-				}
-			} else {
-				if err := c.Stop(); err != nil {
-					return err
-				}
+	ctrErrors := walkGraph(graph, parallelism, true, func(node *containerNode) error {
+		c := node.container
+		logrus.Debugf("Stopping container %s", c.ID())
+		// Can't batch these without forcing Stop() to hold the
+		// lock for the full duration of the timeout.
+		// We probably don't want to do that.
+		if timeout > -1 {
+			if err := c.StopWithTimeout(uint(timeout)); err != nil {
+				return err
 			}
-
-			if cleanup {
-				return c.Cleanup(ctx)
+		} else {
+			if err := c.Stop(); err != nil {
+				return err
 			}
+		}
 
-			return nil
-		})
+		if cleanup {
+			return c.Cleanup(ctx)
+		}
 
-		ctrErrChan[c.ID()] = retChan
-	}
+		return nil
+	}, nil)
 
 	p.newPodEvent(events.Stop)
 
-	ctrErrors := make(map[string]error)
-
-	// Get returned error for every container we worked on
-	for id, channel := range ctrErrChan {
-		if err := <-channel; err != nil {
-			if errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrCtrStopped) {
-				continue
-			}
-			ctrErrors[id] = err
+	for id, err := range ctrErrors {
+		if errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrCtrStopped) {
+			delete(ctrErrors, id)
 		}
 	}
 
@@ -262,7 +268,7 @@ func (p *Pod) stopIfOnlyInfraRemains(ctx context.Context, ignoreID string) error
 		}
 	}
 
-	_, err = p.stopWithTimeout(ctx, true, -1)
+	_, err = p.stopWithTimeout(ctx, true, -1, 0)
 	return err
 }
 
@@ -483,20 +489,15 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 		return nil, fmt.Errorf("generating dependency graph for pod %s: %w", p.ID(), err)
 	}
 
-	ctrErrors := make(map[string]error)
-	ctrsVisited := make(map[string]bool)
-
 	// If there are no containers without dependencies, we can't start
 	// Error out
 	if len(graph.noDepNodes) == 0 {
 		return nil, fmt.Errorf("no containers in pod %s have no dependencies, cannot start pod: %w", p.ID(), define.ErrNoSuchCtr)
 	}
 
-	// Traverse the graph beginning at nodes with no dependencies
-	for _, node := range graph.noDepNodes {
-		startNode(ctx, node, false, ctrErrors, ctrsVisited, true)
-	}
-
+	// Restart the containers of the graph, beginning at nodes with no
+	// dependencies
+	ctrErrors := startGraph(ctx, graph, 0, true)
 	if len(ctrErrors) > 0 {
 		return ctrErrors, fmt.Errorf("stopping some containers: %w", define.ErrPodPartialFail)
 	}
@@ -567,6 +568,42 @@ func (p *Pod) Kill(ctx context.Context, signal uint) (map[string]error, error) {
 	return nil, nil
 }
 
+// UpdatePortMappings replaces the ports published by the pod, which are
+// forwarded to the network namespace of its infra container. If the pod is
+// running, the port forwarding is re-programmed without restarting it.
+func (p *Pod) UpdatePortMappings(ports []types.PortMapping) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return define.ErrPodRemoved
+	}
+
+	infra, err := p.infraContainer()
+	if err != nil {
+		return fmt.Errorf("ports can only be published by pods with an infra container: %w", err)
+	}
+
+	infra.lock.Lock()
+	defer infra.lock.Unlock()
+
+	if err := infra.syncContainer(); err != nil {
+		return err
+	}
+
+	netMode := infra.config.NetMode
+	if len(ports) > 0 && !netMode.IsBridge() && !netMode.IsSlirp4netns() && !netMode.IsPasta() {
+		return fmt.Errorf("cannot publish ports of pod %s with the %s network mode: %w", p.ID(), netMode, define.ErrNetworkModeInvalid)
+	}
+
+	if err := infra.updatePortMappings(ports); err != nil {
+		return err
+	}
+
+	p.newPodEvent(events.Update)
+	return nil
+}
+
 // Status gets the status of all containers in the pod.
 // Returns a map of Container ID to Container Status.
 func (p *Pod) Status() (map[string]define.ContainerStatus, error) {
@@ -628,9 +665,10 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 			containerStatus = containerState.String()
 		}
 		ctrs = append(ctrs, define.InspectPodContainerInfo{
-			ID:    c.ID(),
-			Name:  c.Name(),
-			State: containerStatus,
+			ID:               c.ID(),
+			Name:             c.Name(),
+			State:            containerStatus,
+			PodNetworkOptOut: c.config.PodNetworkOptOut,
 		})
 		// Do not add init containers fdr status
 		if len(c.config.InitContainerType) < 1 {
@@ -642,22 +680,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		return nil, err
 	}
 
-	namespaces := map[string]bool{
-		"pid":    p.config.UsePodPID,
-		"ipc":    p.config.UsePodIPC,
-		"net":    p.config.UsePodNet,
-		"mount":  p.config.UsePodMount,
-		"user":   p.config.UsePodUser,
-		"uts":    p.config.UsePodUTS,
-		"cgroup": p.config.UsePodCgroupNS,
-	}
-
-	sharesNS := []string{}
-	for nsStr, include := range namespaces {
-		if include {
-			sharesNS = append(sharesNS, nsStr)
-		}
-	}
+	sharesNS := p.config.sharedNamespaces()
 
 	// Infra config contains detailed information on the pod's infra
 	// container.
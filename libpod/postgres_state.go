@@ -0,0 +1,92 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// postgresDSN returns the connection string of the database configured in
+// containers.conf. If the runtime uses a read-only state, the server is asked
+// to refuse all writes.
+func postgresDSN(runtime *Runtime) (string, error) {
+	dsn := runtime.podmanConf.Engine.DBConnection
+	if dsn == "" {
+		return "", fmt.Errorf("database_connection must be set to use the %s database backend: %w", dbBackendPostgres, define.ErrInvalidArg)
+	}
+	if !runtime.readOnlyState {
+		return dsn, nil
+	}
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("parsing database_connection: %w", err)
+		}
+		query := u.Query()
+		query.Set("default_transaction_read_only", "on")
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+	return dsn + " default_transaction_read_only=on", nil
+}
+
+// NewPostgresState creates a new state backed by the PostgreSQL database set
+// by database_connection in containers.conf. It is the SQLite state using the
+// PostgreSQL dialect, with the same tables, which allows to keep the state of
+// a fleet of hosts on a central database server. Every host needs a database
+// or schema of its own.
+func NewPostgresState(runtime *Runtime) (State, error) {
+	logrus.Info("Using postgres as database backend")
+	dsn, err := postgresDSN(runtime)
+	if err != nil {
+		return nil, err
+	}
+	state, err := newPostgresState(runtime, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// newPostgresState connects to the database with the given connection string
+// and creates the tables if they do not exist. If the runtime uses a
+// read-only state, the tables must exist with the current schema.
+func newPostgresState(runtime *Runtime, dsn string) (_ *SQLiteState, defErr error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parsing database_connection: %w", err)
+	}
+	conn := sql.OpenDB(connector)
+	defer func() {
+		if defErr != nil {
+			if err := conn.Close(); err != nil {
+				logrus.Errorf("Error closing PostgreSQL DB connection: %v", err)
+			}
+		}
+	}()
+
+	if runtime.readOnlyState {
+		err = checkPostgresSchema(conn)
+	} else {
+		err = initPostgresDB(conn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := new(SQLiteState)
+	state.conn = conn
+	state.valid = true
+	state.runtime = runtime
+	state.dialect = postgresDialect{}
+
+	return state, nil
+}
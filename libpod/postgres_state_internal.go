@@ -0,0 +1,652 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Schema version of the PostgreSQL database. It is counted separately
+	// from the SQLite schema version. The tables are created with their
+	// current schema; changes to it must bump the version and migrate
+	// existing databases in initPostgresDB.
+	postgresSchemaVersion = 1
+
+	// Key of the advisory lock held by every transaction, so transactions
+	// run one at a time like the exclusive transactions of the SQLite
+	// state. It spells "libpod".
+	postgresLockKey = 0x6c6962706f64
+)
+
+// postgresBegin starts a transaction and waits until no other transaction
+// of any host using the database is running.
+func postgresBegin(conn *sql.DB) (*sql.Tx, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1);", postgresLockKey); err != nil {
+		if err := tx.Rollback(); err != nil {
+			logrus.Errorf("Rolling back transaction to lock database: %v", err)
+		}
+		return nil, fmt.Errorf("locking database: %w", err)
+	}
+	return tx, nil
+}
+
+// postgresDialect is the dialect of PostgreSQL databases.
+type postgresDialect struct{}
+
+func (postgresDialect) backend() string {
+	return dbBackendPostgres
+}
+
+func (postgresDialect) bindVars(query string) string {
+	return postgresBindVars(query)
+}
+
+func (postgresDialect) upsert(table string, key []string, columns ...string) string {
+	updates := make([]string, 0, len(columns))
+	for _, column := range columns {
+		updates = append(updates, column+"=EXCLUDED."+column)
+	}
+	return "INSERT INTO " + table + " VALUES (" + sqlPlaceholders(len(key)+len(columns)) + ") ON CONFLICT (" +
+		strings.Join(key, ", ") + ") DO UPDATE SET " + strings.Join(updates, ", ") + ";"
+}
+
+func (postgresDialect) containerStateBlobsColumn() string {
+	return postgresContainerStateBlobsColumn
+}
+
+// begin starts a transaction holding the database lock.
+func (postgresDialect) begin(conn *sql.DB) (*sql.Tx, error) {
+	return postgresBegin(conn)
+}
+
+// retry does not retry, transactions wait for the database lock and queries
+// outside of transactions do not take locks.
+func (postgresDialect) retry(fn func() error) error {
+	return fn()
+}
+
+func (postgresDialect) isRetryableError(error) bool {
+	return false
+}
+
+func (postgresDialect) isForeignKeyError(err error) bool {
+	return isPostgresForeignKeyError(err)
+}
+
+func (postgresDialect) schemaVersion() int {
+	return postgresSchemaVersion
+}
+
+// recordsStateChanges returns false, the StateChange table is filled by
+// triggers of the SQLite database only.
+func (postgresDialect) recordsStateChanges() bool {
+	return false
+}
+
+// checkIntegrity is not supported, the database can be checked on the server
+// with the amcheck extension.
+func (postgresDialect) checkIntegrity(*SQLiteState) ([]string, error) {
+	return nil, fmt.Errorf("checking a %s database, use amcheck on the server instead: %w", dbBackendPostgres, define.ErrNotImplemented)
+}
+
+// vacuum also updates the statistics of the query planner.
+func (postgresDialect) vacuum(s *SQLiteState) error {
+	if _, err := s.conn.Exec("VACUUM ANALYZE;"); err != nil {
+		return fmt.Errorf("vacuuming database: %w", err)
+	}
+	return nil
+}
+
+// checkpointWAL is a no-op as the server manages its write-ahead log.
+func (postgresDialect) checkpointWAL(*SQLiteState) error {
+	return nil
+}
+
+// backup is not supported, the database can be backed up on the server with
+// pg_dump.
+func (postgresDialect) backup(*SQLiteState, string) error {
+	return fmt.Errorf("backing up a %s database, use pg_dump on the server instead: %w", dbBackendPostgres, define.ErrNotImplemented)
+}
+
+// databaseInfo only reports the size of the database, its location and its
+// write-ahead log are managed by the server.
+func (postgresDialect) databaseInfo(s *SQLiteState, info *define.DatabaseBackendInfo) error {
+	if err := s.conn.QueryRow("SELECT pg_database_size(current_database());").Scan(&info.Size); err != nil {
+		return fmt.Errorf("retrieving database size: %w", err)
+	}
+	return nil
+}
+
+// postgresBindVars replaces the ? placeholders of a query, as used by the
+// SQLite state, with the numbered parameters of PostgreSQL. The query must
+// not contain a literal question mark.
+func postgresBindVars(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// postgresSchemaVersionOf returns the schema version of the database, or 0
+// if the database has no DBConfig table or no DBConfig row yet. The second
+// return value is false if the tables have not been created.
+func postgresSchemaVersionOf(q interface {
+	QueryRow(query string, args ...any) *sql.Row
+}) (int, bool, error) {
+	var exists bool
+	if err := q.QueryRow("SELECT to_regclass('dbconfig') IS NOT NULL;").Scan(&exists); err != nil {
+		return 0, false, fmt.Errorf("checking if DB config table exists: %w", err)
+	}
+	if !exists {
+		return 0, false, nil
+	}
+	var schemaVer int
+	if err := q.QueryRow("SELECT SchemaVersion FROM DBConfig;").Scan(&schemaVer); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, true, fmt.Errorf("scanning schema version from DB config: %w", err)
+	}
+	return schemaVer, true, nil
+}
+
+// initPostgresDB creates the tables of the state unless they exist already.
+func initPostgresDB(conn *sql.DB) (defErr error) {
+	// Hosts sharing a database server must not create the tables
+	// concurrently.
+	tx, err := postgresBegin(conn)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to create tables: %v", err)
+			}
+		}
+	}()
+
+	schemaVer, exists, err := postgresSchemaVersionOf(tx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := createPostgresTables(tx); err != nil {
+			return err
+		}
+	} else if schemaVer != 0 && schemaVer != postgresSchemaVersion {
+		return fmt.Errorf("database has schema version %d while this libpod version only supports version %d: %w",
+			schemaVer, postgresSchemaVersion, define.ErrInternal)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// createPostgresExitHistoryTable creates the table holding every exit code
+// recorded for a container, while ContainerExitCode only holds the most recent
+// one. The rows are kept after the container is removed, until they are older
+// than the exit code retention.
+func createPostgresExitHistoryTable(tx *sql.Tx) error {
+	const exitHistory = `
+        CREATE TABLE ContainerExitHistory(
+                ID          BIGSERIAL PRIMARY KEY,
+                ContainerID TEXT      NOT NULL,
+                Timestamp   BIGINT    NOT NULL,
+                ExitCode    INTEGER   NOT NULL
+        );`
+	if _, err := tx.Exec(exitHistory); err != nil {
+		return fmt.Errorf("creating table ContainerExitHistory: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX ContainerExitHistoryID ON ContainerExitHistory(ContainerID);"); err != nil {
+		return fmt.Errorf("creating index ContainerExitHistoryID: %w", err)
+	}
+	return nil
+}
+
+// createPostgresVolumeBackupTables creates the tables holding the backup
+// policies of volumes and the backups created for them.
+func createPostgresVolumeBackupTables(tx *sql.Tx) error {
+	const volumeBackupPolicy = `
+        CREATE TABLE VolumeBackupPolicy(
+                Volume TEXT PRIMARY KEY NOT NULL,
+                JSON   TEXT NOT NULL,
+                FOREIGN KEY (Volume) REFERENCES VolumeConfig(Name) DEFERRABLE INITIALLY DEFERRED
+        );`
+	if _, err := tx.Exec(volumeBackupPolicy); err != nil {
+		return fmt.Errorf("creating table VolumeBackupPolicy: %w", err)
+	}
+	const volumeBackup = `
+        CREATE TABLE VolumeBackup(
+                ID     BIGSERIAL PRIMARY KEY,
+                Volume TEXT      NOT NULL,
+                JSON   TEXT      NOT NULL
+        );`
+	if _, err := tx.Exec(volumeBackup); err != nil {
+		return fmt.Errorf("creating table VolumeBackup: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX VolumeBackupVolume ON VolumeBackup(Volume);"); err != nil {
+		return fmt.Errorf("creating index VolumeBackupVolume: %w", err)
+	}
+	return nil
+}
+
+// createPostgresContainerMetadataTable creates the table holding the metadata
+// entries attached to containers.
+func createPostgresContainerMetadataTable(tx *sql.Tx) error {
+	const containerMetadata = `
+        CREATE TABLE ContainerMetadata(
+                ContainerID TEXT NOT NULL,
+                Key         TEXT NOT NULL,
+                Value       TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, Key),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+	if _, err := tx.Exec(containerMetadata); err != nil {
+		return fmt.Errorf("creating table ContainerMetadata: %w", err)
+	}
+	return nil
+}
+
+// createPostgresContainerNetworkTable creates the table holding the networks
+// containers are connected to.
+func createPostgresContainerNetworkTable(tx *sql.Tx) error {
+	if _, err := tx.Exec(containerNetworkTable); err != nil {
+		return fmt.Errorf("creating table ContainerNetwork: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX ContainerNetworkNetwork ON ContainerNetwork(Network);"); err != nil {
+		return fmt.Errorf("creating index ContainerNetworkNetwork: %w", err)
+	}
+	return nil
+}
+
+// createPostgresHealthCheckLogTable creates the table holding the
+// healthcheck runs of every container.
+func createPostgresHealthCheckLogTable(tx *sql.Tx) error {
+	const healthCheckLog = `
+        CREATE TABLE HealthCheckLog(
+                ID          BIGSERIAL PRIMARY KEY,
+                ContainerID TEXT      NOT NULL,
+                Status      TEXT      NOT NULL,
+                ExitCode    INTEGER   NOT NULL,
+                Output      TEXT      NOT NULL,
+                StartTime   TEXT      NOT NULL,
+                EndTime     TEXT      NOT NULL,
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+	if _, err := tx.Exec(healthCheckLog); err != nil {
+		return fmt.Errorf("creating table HealthCheckLog: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX HealthCheckLogContainerID ON HealthCheckLog(ContainerID);"); err != nil {
+		return fmt.Errorf("creating index HealthCheckLogContainerID: %w", err)
+	}
+	return nil
+}
+
+// createPostgresContainerStatsHistoryTable creates the table holding the
+// resource usage samples of every container.
+func createPostgresContainerStatsHistoryTable(tx *sql.Tx) error {
+	const containerStatsHistory = `
+        CREATE TABLE ContainerStatsHistory(
+                ID          BIGSERIAL PRIMARY KEY,
+                ContainerID TEXT      NOT NULL,
+                Time        BIGINT    NOT NULL,
+                JSON        TEXT      NOT NULL,
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+	if _, err := tx.Exec(containerStatsHistory); err != nil {
+		return fmt.Errorf("creating table ContainerStatsHistory: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX ContainerStatsHistoryContainerID ON ContainerStatsHistory(ContainerID, Time);"); err != nil {
+		return fmt.Errorf("creating index ContainerStatsHistoryContainerID: %w", err)
+	}
+	return nil
+}
+
+// createPostgresPodTables creates the tables holding the containers, infra
+// containers and shared namespaces of pods.
+func createPostgresPodTables(tx *sql.Tx) error {
+	// PodInfraContainer references PodContainer.
+	tables := []struct {
+		name string
+		cmd  string
+	}{
+		{"PodContainer", podContainerTable},
+		{"PodInfraContainer", podInfraContainerTable},
+		{"PodSharedNamespace", podSharedNamespaceTable},
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec(table.cmd); err != nil {
+			return fmt.Errorf("creating table %s: %w", table.name, err)
+		}
+	}
+	return nil
+}
+
+// createPostgresNetworkReservationTable creates the table holding the
+// addresses of networks reserved for containers.
+func createPostgresNetworkReservationTable(tx *sql.Tx) error {
+	const networkReservation = `
+        CREATE TABLE NetworkReservation(
+                Network TEXT NOT NULL,
+                Address TEXT NOT NULL,
+                JSON    TEXT NOT NULL,
+                PRIMARY KEY (Network, Address)
+        );`
+	if _, err := tx.Exec(networkReservation); err != nil {
+		return fmt.Errorf("creating table NetworkReservation: %w", err)
+	}
+	return nil
+}
+
+// createPostgresCheckpointTable creates the table holding the checkpoints
+// exported by containers.
+func createPostgresCheckpointTable(tx *sql.Tx) error {
+	const checkpoint = `
+        CREATE TABLE Checkpoint(
+                ID          BIGSERIAL PRIMARY KEY,
+                ContainerID TEXT      NOT NULL,
+                JSON        TEXT      NOT NULL
+        );`
+	if _, err := tx.Exec(checkpoint); err != nil {
+		return fmt.Errorf("creating table Checkpoint: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX CheckpointContainerID ON Checkpoint(ContainerID);"); err != nil {
+		return fmt.Errorf("creating index CheckpointContainerID: %w", err)
+	}
+	return nil
+}
+
+// createPostgresVolumeSnapshotTable creates the table holding the snapshots
+// of volumes.
+func createPostgresVolumeSnapshotTable(tx *sql.Tx) error {
+	const volumeSnapshot = `
+        CREATE TABLE VolumeSnapshot(
+                ID     BIGSERIAL PRIMARY KEY,
+                Volume TEXT      NOT NULL,
+                JSON   TEXT      NOT NULL
+        );`
+	if _, err := tx.Exec(volumeSnapshot); err != nil {
+		return fmt.Errorf("creating table VolumeSnapshot: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX VolumeSnapshotVolume ON VolumeSnapshot(Volume);"); err != nil {
+		return fmt.Errorf("creating index VolumeSnapshotVolume: %w", err)
+	}
+	return nil
+}
+
+// createPostgresContainerNoteTable creates the table holding the notes
+// operators attached to containers.
+func createPostgresContainerNoteTable(tx *sql.Tx) error {
+	const containerNote = `
+        CREATE TABLE ContainerNote(
+                ID          BIGSERIAL PRIMARY KEY,
+                ContainerID TEXT      NOT NULL,
+                JSON        TEXT      NOT NULL,
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+	if _, err := tx.Exec(containerNote); err != nil {
+		return fmt.Errorf("creating table ContainerNote: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX ContainerNoteContainerID ON ContainerNote(ContainerID);"); err != nil {
+		return fmt.Errorf("creating index ContainerNoteContainerID: %w", err)
+	}
+	return nil
+}
+
+// isPostgresForeignKeyError returns true if err was caused by a foreign key
+// constraint, such as removing a row still referenced by another table.
+func isPostgresForeignKeyError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code.Name() == "foreign_key_violation"
+}
+
+// checkPostgresSchema verifies that a database opened read only has the
+// current schema, as it can neither be created nor migrated.
+func checkPostgresSchema(conn *sql.DB) error {
+	schemaVer, _, err := postgresSchemaVersionOf(conn)
+	if err != nil {
+		return err
+	}
+	if schemaVer == 0 {
+		return fmt.Errorf("database has not been initialized and cannot be opened read only: %w", define.ErrDBBadConfig)
+	}
+	if schemaVer != postgresSchemaVersion {
+		return fmt.Errorf("database has schema version %d while this libpod version requires version %d to open it read only: %w",
+			schemaVer, postgresSchemaVersion, define.ErrDBBadConfig)
+	}
+	return nil
+}
+
+// createPostgresTables creates the tables of the SQLite state. Tables which
+// reference each other are created without the foreign keys closing the
+// cycle, they are added once all tables exist.
+func createPostgresTables(tx *sql.Tx) error {
+	tables := []struct {
+		name string
+		cmd  string
+	}{
+		{"DBConfig", `
+        CREATE TABLE DBConfig(
+                ID            INTEGER PRIMARY KEY NOT NULL,
+                SchemaVersion INTEGER NOT NULL,
+                OS            TEXT    NOT NULL,
+                StaticDir     TEXT    NOT NULL,
+                TmpDir        TEXT    NOT NULL,
+                GraphRoot     TEXT    NOT NULL,
+                RunRoot       TEXT    NOT NULL,
+                GraphDriver   TEXT    NOT NULL,
+                VolumeDir     TEXT    NOT NULL,
+                CHECK (ID IN (1))
+        );`},
+		{"IDNamespace", `
+        CREATE TABLE IDNamespace(
+                ID TEXT PRIMARY KEY NOT NULL
+        );`},
+		{"PodConfig", `
+        CREATE TABLE PodConfig(
+                ID              TEXT    PRIMARY KEY NOT NULL,
+                Name            TEXT    UNIQUE NOT NULL,
+                JSON            TEXT    NOT NULL,
+                Namespace       TEXT    NOT NULL DEFAULT '',
+                FOREIGN KEY (ID) REFERENCES IDNamespace(ID) DEFERRABLE INITIALLY DEFERRED
+        );`},
+		{"PodState", `
+        CREATE TABLE PodState(
+                ID               TEXT PRIMARY KEY NOT NULL,
+                InfraContainerID TEXT,
+                JSON             TEXT NOT NULL,
+                FOREIGN KEY (ID) REFERENCES PodConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`},
+		{"VolumeConfig", `
+        CREATE TABLE VolumeConfig(
+                Name            TEXT    PRIMARY KEY NOT NULL,
+                StorageID       TEXT,
+                JSON            TEXT    NOT NULL
+        );`},
+		{"VolumeState", `
+        CREATE TABLE VolumeState(
+                Name TEXT PRIMARY KEY NOT NULL,
+                JSON TEXT NOT NULL,
+                FOREIGN KEY (Name) REFERENCES VolumeConfig(Name) DEFERRABLE INITIALLY DEFERRED
+        );`},
+		// Names are compared bytewise, so name prefixes can be looked up
+		// as ranges by AllContainersFiltered.
+		{"ContainerConfig", `
+        CREATE TABLE ContainerConfig(
+                ID              TEXT    PRIMARY KEY NOT NULL,
+                Name            TEXT    COLLATE "C" UNIQUE NOT NULL,
+                PodID           TEXT,
+                JSON            TEXT    NOT NULL,
+                RestartPolicy   TEXT    NOT NULL DEFAULT '',
+                CreatedTime     BIGINT  NOT NULL DEFAULT 0,
+                ImageID         TEXT    NOT NULL DEFAULT '',
+                Namespace       TEXT    NOT NULL DEFAULT '',
+                FOREIGN KEY (ID)    REFERENCES IDNamespace(ID)    DEFERRABLE INITIALLY DEFERRED,
+                FOREIGN KEY (PodID) REFERENCES PodConfig(ID)
+        );`},
+		{"ContainerState", `
+        CREATE TABLE ContainerState(
+                ID       TEXT    PRIMARY KEY NOT NULL,
+                State    INTEGER NOT NULL,
+                ExitCode INTEGER,
+                JSON     TEXT    NOT NULL,
+                FOREIGN KEY (ID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED,
+                CHECK (ExitCode BETWEEN -1 AND 255)
+        );`},
+		{"ContainerExecSession", `
+        CREATE TABLE ContainerExecSession(
+                ID          TEXT PRIMARY KEY NOT NULL,
+                ContainerID TEXT NOT NULL,
+                JSON        TEXT NOT NULL DEFAULT '{}',
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID)
+        );`},
+		{"ContainerDependency", `
+        CREATE TABLE ContainerDependency(
+                ID           TEXT NOT NULL,
+                DependencyID TEXT NOT NULL,
+                PRIMARY KEY (ID, DependencyID),
+                FOREIGN KEY (ID)           REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED,
+                FOREIGN KEY (DependencyID) REFERENCES ContainerConfig(ID),
+                CHECK (ID <> DependencyID)
+        );`},
+		{"ContainerVolume", `
+        CREATE TABLE ContainerVolume(
+                ContainerID TEXT NOT NULL,
+                VolumeName  TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, VolumeName),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED,
+                FOREIGN KEY (VolumeName)  REFERENCES VolumeConfig(Name)
+        );`},
+		{"ContainerLabel", `
+        CREATE TABLE ContainerLabel(
+                ContainerID TEXT NOT NULL,
+                Key         TEXT NOT NULL,
+                Value       TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, Key),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`},
+		{"ContainerExitCode", `
+        CREATE TABLE ContainerExitCode(
+                ID        TEXT    PRIMARY KEY NOT NULL,
+                Timestamp BIGINT  NOT NULL,
+                ExitCode  INTEGER NOT NULL,
+                CHECK (ExitCode BETWEEN -1 AND 255)
+        );`},
+		{"ImageProvenance", `
+        CREATE TABLE ImageProvenance(
+                ID        BIGSERIAL PRIMARY KEY,
+                Source    TEXT      NOT NULL,
+                ImageID   TEXT      NOT NULL,
+                Digest    TEXT      NOT NULL,
+                Timestamp BIGINT    NOT NULL
+        );`},
+		{"ImageDigestPin", `
+        CREATE TABLE ImageDigestPin(
+                Source    TEXT   PRIMARY KEY NOT NULL,
+                ImageID   TEXT   NOT NULL,
+                Digest    TEXT   NOT NULL,
+                Timestamp BIGINT NOT NULL
+        );`},
+	}
+
+	foreignKeys := map[string]string{
+		"PodConfig":       "ALTER TABLE PodConfig ADD FOREIGN KEY (ID) REFERENCES PodState(ID) DEFERRABLE INITIALLY DEFERRED;",
+		"PodState":        "ALTER TABLE PodState ADD FOREIGN KEY (InfraContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED;",
+		"VolumeConfig":    "ALTER TABLE VolumeConfig ADD FOREIGN KEY (Name) REFERENCES VolumeState(Name) DEFERRABLE INITIALLY DEFERRED;",
+		"ContainerConfig": "ALTER TABLE ContainerConfig ADD FOREIGN KEY (ID) REFERENCES ContainerState(ID) DEFERRABLE INITIALLY DEFERRED;",
+	}
+
+	indexes := map[string]string{
+		"ContainerConfigPodID":         "CREATE INDEX ContainerConfigPodID ON ContainerConfig(PodID);",
+		"ContainerConfigRestartPolicy": "CREATE INDEX ContainerConfigRestartPolicy ON ContainerConfig(RestartPolicy);",
+		"ContainerConfigCreatedTime":   "CREATE INDEX ContainerConfigCreatedTime ON ContainerConfig(CreatedTime);",
+		"ContainerConfigImageID":       "CREATE INDEX ContainerConfigImageID ON ContainerConfig(ImageID);",
+		"ContainerConfigNamespace":     "CREATE INDEX ContainerConfigNamespace ON ContainerConfig(Namespace);",
+		"PodConfigNamespace":           "CREATE INDEX PodConfigNamespace ON PodConfig(Namespace);",
+		"ContainerLabelKeyValue":       "CREATE INDEX ContainerLabelKeyValue ON ContainerLabel(Key, Value);",
+		"ImageProvenanceSource":        "CREATE INDEX ImageProvenanceSource ON ImageProvenance(Source);",
+		"ImageProvenanceImageID":       "CREATE INDEX ImageProvenanceImageID ON ImageProvenance(ImageID);",
+	}
+
+	for _, table := range tables {
+		if _, err := tx.Exec(table.cmd); err != nil {
+			return fmt.Errorf("creating table %s: %w", table.name, err)
+		}
+	}
+	for tblName, cmd := range foreignKeys {
+		if _, err := tx.Exec(cmd); err != nil {
+			return fmt.Errorf("adding foreign key of table %s: %w", tblName, err)
+		}
+	}
+	for idxName, cmd := range indexes {
+		if _, err := tx.Exec(cmd); err != nil {
+			return fmt.Errorf("creating index %s: %w", idxName, err)
+		}
+	}
+	if err := createPostgresExitHistoryTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresVolumeBackupTables(tx); err != nil {
+		return err
+	}
+	if err := createPostgresContainerMetadataTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresContainerNetworkTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresHealthCheckLogTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresContainerStatsHistoryTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresPodTables(tx); err != nil {
+		return err
+	}
+	if err := createPostgresNetworkReservationTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresCheckpointTable(tx); err != nil {
+		return err
+	}
+	if err := createPostgresContainerNoteTable(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(containerStateBlobTable); err != nil {
+		return fmt.Errorf("creating table ContainerStateBlob: %w", err)
+	}
+	if _, err := tx.Exec(dbMaintenanceTable); err != nil {
+		return fmt.Errorf("creating table DBMaintenance: %w", err)
+	}
+	if _, err := tx.Exec(containerGroupTable); err != nil {
+		return fmt.Errorf("creating table ContainerGroup: %w", err)
+	}
+	return createPostgresVolumeSnapshotTable(tx)
+}
+
+// postgresContainerStateBlobsColumn is the PostgreSQL equivalent of
+// sqliteContainerStateBlobsColumn.
+const postgresContainerStateBlobsColumn = "(SELECT COALESCE(json_object_agg(ContainerStateBlob.Name, ContainerStateBlob.JSON)::text, '{}') FROM ContainerStateBlob WHERE ContainerStateBlob.ContainerID = ContainerState.ID)"
@@ -0,0 +1,213 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		readOnly bool
+		want     string
+		wantErr  string
+	}{
+		{
+			name:    "unset",
+			wantErr: "database_connection must be set",
+		},
+		{
+			name: "url",
+			dsn:  "postgres://podman@db.example.com/podman?sslmode=verify-full",
+			want: "postgres://podman@db.example.com/podman?sslmode=verify-full",
+		},
+		{
+			name:     "url read only",
+			dsn:      "postgresql://podman@db.example.com/podman?sslmode=verify-full",
+			readOnly: true,
+			want:     "postgresql://podman@db.example.com/podman?default_transaction_read_only=on&sslmode=verify-full",
+		},
+		{
+			name:     "key value read only",
+			dsn:      "host=db.example.com dbname=podman",
+			readOnly: true,
+			want:     "host=db.example.com dbname=podman default_transaction_read_only=on",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := new(Runtime)
+			runtime.config = new(config.Config)
+			runtime.podmanConf.Engine.DBConnection = tt.dsn
+			runtime.readOnlyState = tt.readOnly
+			dsn, err := postgresDSN(runtime)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dsn)
+		})
+	}
+}
+
+func TestPostgresBindVars(t *testing.T) {
+	where, args := sqliteContainerFilters(&StateContainerFilters{
+		PodIDs: []string{"a", "b"},
+		Labels: []string{"app=web"},
+	})
+	assert.Equal(t, " WHERE ContainerConfig.PodID IN ($1, $2) AND ContainerConfig.ID IN (SELECT ContainerID FROM ContainerLabel WHERE Key=$3 AND Value=$4)", postgresBindVars(where))
+	assert.Len(t, args, 4)
+	assert.Equal(t, "SELECT JSON FROM ContainerConfig;", postgresBindVars("SELECT JSON FROM ContainerConfig;"))
+}
+
+func TestPostgresUpsert(t *testing.T) {
+	upsert := postgresDialect{}.upsert("ContainerMetadata", []string{"ContainerID", "Key"}, "Value")
+	assert.Equal(t, "INSERT INTO ContainerMetadata VALUES ($1, $2, $3) ON CONFLICT (ContainerID, Key) DO UPDATE SET Value=EXCLUDED.Value;", postgresBindVars(upsert))
+	assert.Equal(t, "INSERT OR REPLACE INTO ContainerMetadata VALUES (?, ?, ?);", sqliteDialect{}.upsert("ContainerMetadata", []string{"ContainerID", "Key"}, "Value"))
+}
+
+// getEmptyPostgresState returns a state using a new schema of the database
+// given by PODMAN_TEST_POSTGRES_CONNECTION, which is dropped when the test
+// ends. The test is skipped if the variable is not set.
+func getEmptyPostgresState(t *testing.T, runtime *Runtime) *SQLiteState {
+	dsn, ok := os.LookupEnv("PODMAN_TEST_POSTGRES_CONNECTION")
+	if !ok {
+		t.Skip("PODMAN_TEST_POSTGRES_CONNECTION is not set")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+	schema := fmt.Sprintf("podman_test_%d", time.Now().UnixNano())
+	_, err = admin.Exec("CREATE SCHEMA " + pq.QuoteIdentifier(schema) + ";")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := admin.Exec("DROP SCHEMA " + pq.QuoteIdentifier(schema) + " CASCADE;")
+		assert.NoError(t, err)
+	})
+
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		require.NoError(t, err)
+		query := u.Query()
+		query.Set("search_path", schema)
+		u.RawQuery = query.Encode()
+		dsn = u.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+	runtime.config = new(config.Config)
+	runtime.podmanConf.Engine.DBConnection = dsn
+
+	state, err := newPostgresState(runtime, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { state.Close() })
+	return state
+}
+
+func TestPostgresAddAndRemoveContainers(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+	state := getEmptyPostgresState(t, runtime)
+
+	pod, err := getTestPodN("4", manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddPod(pod))
+
+	ctr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr2.config.UserNsCtr = ctr1.ID()
+	ctr3, err := getTestCtrN("3", manager)
+	require.NoError(t, err)
+	ctr3.config.Pod = pod.ID()
+	dupCtr, err := getTestContainer(strings.Repeat("5", 32), ctr1.Name(), manager)
+	require.NoError(t, err)
+
+	// A failing container rolls back the whole batch.
+	assert.Error(t, state.AddContainers([]*Container{ctr1, ctr2, dupCtr}))
+	ctrs, err := state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+
+	require.NoError(t, state.AddContainers([]*Container{ctr1, ctr2, ctr3}))
+	retrievedCtr, err := state.LookupContainer(ctr1.ID()[:8])
+	require.NoError(t, err)
+	testContainersEqual(t, retrievedCtr, ctr1, true)
+	deps, err := state.ContainerInUse(ctr1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ctr2.ID()}, deps)
+
+	require.NoError(t, state.RemovePodContainers(pod))
+	podCtrs, err := state.PodContainers(pod)
+	require.NoError(t, err)
+	assert.Empty(t, podCtrs)
+
+	require.NoError(t, state.RemoveContainers([]*Container{ctr2, ctr1}))
+	ctrs, err = state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+}
+
+func TestPostgresExitCodes(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.storageConfig = storage.StoreOptions{}
+	state := getEmptyPostgresState(t, runtime)
+
+	require.NoError(t, state.AddContainerExitCode("ctr", 1))
+	require.NoError(t, state.AddContainerExitCode("ctr", 2))
+	exitCode, err := state.GetContainerExitCode("ctr")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), exitCode)
+
+	_, err = state.GetContainerExitCode("missing")
+	assert.ErrorIs(t, err, define.ErrNoSuchExitCode)
+}
+
+func TestPostgresAllContainersFiltered(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+	state := getEmptyPostgresState(t, runtime)
+
+	testAllContainersFiltered(t, state, manager)
+}
+
+func TestPostgresImageProvenance(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.storageConfig = storage.StoreOptions{}
+	state := getEmptyPostgresState(t, runtime)
+
+	testImageProvenance(t, state, nil)
+}
+
+func TestPostgresImageDigestPin(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.storageConfig = storage.StoreOptions{}
+	state := getEmptyPostgresState(t, runtime)
+
+	testImageDigestPin(t, state, nil)
+}
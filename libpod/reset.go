@@ -95,6 +95,12 @@ func (r *Runtime) removeAllDirs() error {
 // All containers, images, volumes, pods, and networks will be removed.
 // Calls Shutdown(), rendering the runtime unusable after this is run.
 func (r *Runtime) Reset(ctx context.Context) error {
+	// A reset removes the storage of all namespaces, but only the
+	// containers and pods of the current one are visible.
+	if r.config.Engine.Namespace != "" {
+		return fmt.Errorf("cannot reset storage from within namespace %q: %w", r.config.Engine.Namespace, define.ErrInvalidArg)
+	}
+
 	// Acquire the alive lock and hold it.
 	// Ensures that we don't let other Podman commands run while we are
 	// removing everything.
@@ -4,24 +4,27 @@ package libpod
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/libnetwork/network"
 	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/common/pkg/config"
-	"github.com/containers/common/pkg/secrets"
 	systemdCommon "github.com/containers/common/pkg/systemd"
 	"github.com/containers/image/v5/pkg/sysregistriesv2"
 	is "github.com/containers/image/v5/storage"
@@ -31,8 +34,10 @@ import (
 	"github.com/containers/podman/v5/libpod/lock"
 	"github.com/containers/podman/v5/libpod/plugin"
 	"github.com/containers/podman/v5/libpod/shutdown"
+	"github.com/containers/podman/v5/pkg/containersconf"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/secrets"
 	"github.com/containers/podman/v5/pkg/systemd"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage"
@@ -68,15 +73,25 @@ type Runtime struct {
 	config        *config.Config
 	storageConfig storage.StoreOptions
 	storageSet    storageSet
-
-	state                  State
-	store                  storage.Store
-	storageService         *storageService
-	imageContext           *types.SystemContext
-	defaultOCIRuntime      OCIRuntime
-	ociRuntimes            map[string]OCIRuntime
-	runtimeFlags           []string
-	network                nettypes.ContainerNetwork
+	// reloadBase is the configuration as read from the containers.conf
+	// files, without the overrides of command-line options, that Reload
+	// compares the files with to find the changed settings.
+	reloadBase *config.Config
+	// podmanConf are the settings of containers.conf only known to Podman.
+	podmanConf containersconf.Config
+	// podmanReloadBase is the reloadBase of podmanConf.
+	podmanReloadBase *containersconf.Config
+
+	state             State
+	store             storage.Store
+	storageService    *storageService
+	imageContext      *types.SystemContext
+	defaultOCIRuntime OCIRuntime
+	ociRuntimes       map[string]OCIRuntime
+	runtimeFlags      []string
+	network           nettypes.ContainerNetwork
+	// defaultNetworkBackend creates the custom default network, if any.
+	defaultNetworkBackend  nettypes.ContainerNetwork
 	conmonPath             string
 	libimageRuntime        *libimage.Runtime
 	libimageEventsShutdown chan bool
@@ -105,6 +120,16 @@ type Runtime struct {
 	// errors related to lock initialization so a renumber can be performed
 	// if something has gone wrong.
 	doRenumber bool
+	// readOnlyState indicates that the state database is opened read only.
+	// The runtime can inspect containers, pods and volumes, but every
+	// operation writing to the state fails. The database must already
+	// exist with the current schema, and a state refresh after a reboot is
+	// refused.
+	readOnlyState bool
+	// deliversEventsWebhooks indicates that the process delivers the
+	// events to the events webhooks, so the runtime does not warn that no
+	// process does.
+	deliversEventsWebhooks bool
 
 	// valid indicates whether the runtime is ready to use.
 	// valid is set to true when a runtime is returned from GetRuntime(),
@@ -115,8 +140,16 @@ type Runtime struct {
 	// mechanism to read and write even logs
 	eventer events.Eventer
 
+	// audit records modifications of the state, nil if auditing is
+	// disabled
+	audit *auditLog
+
+	// metrics records state operation latencies and event counts, nil
+	// unless enabled with WithMetrics
+	metrics *runtimeMetrics
+
 	// secretsManager manages secrets
-	secretsManager *secrets.SecretsManager
+	secretsManager *secrets.Manager
 }
 
 // SetXdgDirs ensures the XDG_RUNTIME_DIR env and XDG_CONFIG_HOME variables are set.
@@ -193,6 +226,12 @@ func newRuntimeFromConfig(ctx context.Context, conf *config.Config, options ...R
 
 	runtime.config = conf
 
+	podmanConf, err := containersconf.New(conf.LoadedModules())
+	if err != nil {
+		return nil, err
+	}
+	runtime.podmanConf = *podmanConf
+
 	if err := SetXdgDirs(); err != nil {
 		return nil, err
 	}
@@ -241,6 +280,12 @@ func getLockManager(runtime *Runtime) (lock.Manager, error) {
 	var err error
 	var manager lock.Manager
 
+	// The containers of the memory backend only exist in this process, so
+	// their locks need not be shared with other processes.
+	if runtime.config.Engine.DBBackend == dbBackendMemory {
+		return lock.NewInMemoryManager(runtime.config.Engine.NumLocks)
+	}
+
 	switch runtime.config.Engine.LockType {
 	case "file":
 		lockPath := filepath.Join(runtime.config.Engine.TmpDir, "locks")
@@ -294,22 +339,49 @@ func getLockManager(runtime *Runtime) (lock.Manager, error) {
 	return manager, nil
 }
 
+const (
+	// dbBackendPostgres is the PostgreSQL database backend. containers.conf
+	// selects it by setting database_connection without database_backend.
+	dbBackendPostgres = "postgres"
+	// dbBackendMemory is the database backend keeping the state in memory
+	// for the lifetime of the process.
+	dbBackendMemory = "memory"
+)
+
+// defaultBoltDBPath returns the default path of the BoltDB database.
+func defaultBoltDBPath(runtime *Runtime) string {
+	baseDir := runtime.config.Engine.StaticDir
+	if runtime.storageConfig.TransientStore {
+		baseDir = runtime.config.Engine.TmpDir
+	}
+	return filepath.Join(baseDir, "bolt_state.db")
+}
+
 func getDBState(runtime *Runtime) (State, error) {
 	// TODO - if we further break out the state implementation into
 	// libpod/state, the config could take care of the code below.  It
 	// would further allow to move the types and consts into a coherent
 	// package.
+	// The database_backend setting of containers.conf only accepts the
+	// backends known to containers/common, the others are Podman's own.
+	switch runtime.config.Engine.DBBackend {
+	case dbBackendPostgres:
+		return NewPostgresState(runtime)
+	case dbBackendMemory:
+		return NewMemoryState(runtime)
+	case "":
+		if runtime.podmanConf.Engine.DBConnection != "" {
+			runtime.config.Engine.DBBackend = dbBackendPostgres
+			return NewPostgresState(runtime)
+		}
+	}
+
 	backend, err := config.ParseDBBackend(runtime.config.Engine.DBBackend)
 	if err != nil {
 		return nil, err
 	}
 
-	// get default boltdb path
-	baseDir := runtime.config.Engine.StaticDir
-	if runtime.storageConfig.TransientStore {
-		baseDir = runtime.config.Engine.TmpDir
-	}
-	boltDBPath := filepath.Join(baseDir, "bolt_state.db")
+	boltDBPath := defaultBoltDBPath(runtime)
 
 	switch backend {
 	case config.DBBackendDefault:
@@ -369,11 +441,21 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 		return fmt.Errorf("creating runtime temporary files directory: %w", err)
 	}
 
+	if runtime.readOnlyState && (runtime.doReset || runtime.doRenumber) {
+		return fmt.Errorf("a system reset or renumber cannot be performed with a read-only state: %w", define.ErrInvalidArg)
+	}
+
 	// Set up the state.
 	runtime.state, err = getDBState(runtime)
 	if err != nil {
 		return err
 	}
+	if runtime.podmanConf.Engine.AuditLog != "" {
+		if runtime.audit, err = newAuditLog(runtime.podmanConf.Engine.AuditLog); err != nil {
+			return err
+		}
+	}
+	runtime.state = runtime.wrapState(runtime.state)
 
 	// Grab config from the database so we can reset some defaults
 	dbConfig, err := runtime.state.GetDBConfig()
@@ -419,10 +501,6 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 		logrus.Errorf("Runtime paths differ from those stored in database, storage reset may not remove all files")
 	}
 
-	if runtime.config.Engine.Namespace != "" {
-		return fmt.Errorf("namespaces are not supported by this version of Libpod, please unset the `namespace` field in containers.conf: %w", define.ErrNotImplemented)
-	}
-
 	needsUserns := os.Geteuid() != 0
 	if !needsUserns {
 		hasCapSysAdmin, err := unshare.HasCapSysAdmin()
@@ -462,6 +540,12 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 		return err
 	}
 	runtime.eventer = eventer
+	if runtime.metrics != nil {
+		runtime.eventer = &metricsEventer{Eventer: eventer, metrics: runtime.metrics}
+	}
+	if len(runtime.podmanConf.Engine.EventsWebhooks) > 0 && !runtime.deliversEventsWebhooks && !runtime.eventsWebhooksDelivered() {
+		logrus.Warnf("events_webhooks are set in containers.conf but no podman system service is running, events are only delivered to the webhooks by a running service")
+	}
 
 	// Set up containers/image
 	if runtime.imageContext == nil {
@@ -529,6 +613,9 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 		}
 		runtime.config.Network.NetworkBackend = string(netBackend)
 		runtime.network = netInterface
+		if err := runtime.setUpDefaultNetworkBackend(); err != nil {
+			return err
+		}
 	}
 
 	// We now need to see if the system has restarted
@@ -623,6 +710,9 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 
 	// If we need to refresh the state, do it now - things are guaranteed to
 	// be set up by now.
+	if doRefresh && runtime.readOnlyState {
+		return fmt.Errorf("the state must be refreshed after a reboot, which is not possible with a read-only state: %w", define.ErrInvalidArg)
+	}
 	if doRefresh {
 		// Ensure we have a store before refresh occurs
 		if runtime.store == nil {
@@ -636,6 +726,12 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 		}
 	}
 
+	// Restrict the state to the namespace only after the refresh, which
+	// has to reset all containers and pods.
+	if err := runtime.state.SetNamespace(runtime.config.Engine.Namespace); err != nil {
+		return err
+	}
+
 	// Check current boot ID - will be written to the alive file.
 	if err := runtime.checkBootID(runtimeAliveFile); err != nil {
 		return err
@@ -665,6 +761,12 @@ func (r *Runtime) GetConfigNoCopy() (*config.Config, error) {
 	return r.config, nil
 }
 
+// GetPodmanConfigNoCopy returns the settings of containers.conf only known to
+// Podman. The returned configuration must only be read.
+func (r *Runtime) GetPodmanConfigNoCopy() *containersconf.Config {
+	return &r.podmanConf
+}
+
 // GetConfig returns a copy of the configuration used by the runtime.
 // Please use GetConfigNoCopy() in case you only want to read from
 // but not write to the returned config.
@@ -684,6 +786,61 @@ func (r *Runtime) GetConfig() (*config.Config, error) {
 	return config, nil
 }
 
+// effectiveConfigTables are the tables of containers.conf recorded in the
+// effective configuration of the containers.
+var effectiveConfigTables = []string{"containers", "engine", "network"}
+
+// effectiveConfig returns the snapshot of the containers.conf settings of the
+// runtime recorded in the containers it creates, keyed by their
+// containers.conf names. The remote connections are left out, they do not
+// affect containers.
+func (r *Runtime) effectiveConfig() (map[string]any, error) {
+	snapshot := config.Config{
+		Containers: r.config.Containers,
+		Engine:     r.config.Engine,
+		Network:    r.config.Network,
+	}
+	snapshot.Engine.ActiveService = ""
+	snapshot.Engine.RemoteURI = ""
+	snapshot.Engine.RemoteIdentity = ""
+	snapshot.Engine.ServiceDestinations = nil
+
+	tables, err := configTables(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+	podmanTables, err := configTables(&r.podmanConf)
+	if err != nil {
+		return nil, err
+	}
+	effective := make(map[string]any, len(effectiveConfigTables))
+	for _, table := range effectiveConfigTables {
+		values, ok := tables[table].(map[string]any)
+		if !ok {
+			continue
+		}
+		if podmanValues, ok := podmanTables[table].(map[string]any); ok {
+			maps.Copy(values, podmanValues)
+		}
+		effective[table] = values
+	}
+	return effective, nil
+}
+
+// configTables returns the tables of conf keyed by their containers.conf
+// names.
+func configTables(conf any) (map[string]any, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(conf); err != nil {
+		return nil, fmt.Errorf("encoding effective configuration: %w", err)
+	}
+	tables := make(map[string]any)
+	if _, err := toml.Decode(buf.String(), &tables); err != nil {
+		return nil, fmt.Errorf("decoding effective configuration: %w", err)
+	}
+	return tables, nil
+}
+
 // libimageEventsMap translates a libimage event type to a libpod event status.
 var libimageEventsMap = map[libimage.EventType]events.Status{
 	libimage.EventTypeImagePull:      events.Pull,
@@ -733,6 +890,11 @@ func (r *Runtime) libimageEvents() {
 				if err := r.eventer.Write(e); err != nil {
 					logrus.Errorf("Unable to write image event: %q", err)
 				}
+				if libimageEvent.Type == libimage.EventTypeImagePull && libimageEvent.Error == nil {
+					if err := r.recordImageProvenance(libimageEvent); err != nil {
+						logrus.Errorf("Unable to record provenance of image %s: %v", libimageEvent.ID, err)
+					}
+				}
 			}
 
 			if sawShutdown {
@@ -809,6 +971,14 @@ func (r *Runtime) Shutdown(force bool) error {
 		}
 		lastError = err
 	}
+	if r.audit != nil {
+		if err := r.audit.close(); err != nil {
+			if lastError != nil {
+				logrus.Error(lastError)
+			}
+			lastError = err
+		}
+	}
 
 	return lastError
 }
@@ -819,8 +989,18 @@ func (r *Runtime) Shutdown(force bool) error {
 func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 	logrus.Debugf("Podman detected system restart - performing state refresh")
 
+	// Exec sessions do not survive a reboot. Remember the sessions removed
+	// by refreshing the database, to report them as reaped.
+	var staleSessions []*ExecSession
+
 	// Clear state of database if not running in container
 	if !graphRootMounted() {
+		sessions, err := r.state.AllExecSessions()
+		if err != nil {
+			logrus.Errorf("Retrieving exec sessions from state: %v", err)
+		}
+		staleSessions = sessions
+
 		// First clear the state in the database
 		if err := r.state.Refresh(); err != nil {
 			return err
@@ -880,6 +1060,15 @@ func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 			logrus.Errorf("Refreshing volume %s: %v", vol.Name(), err)
 		}
 	}
+	ctrsByID := make(map[string]*Container, len(ctrs))
+	for _, ctr := range ctrs {
+		ctrsByID[ctr.ID()] = ctr
+	}
+	for _, session := range staleSessions {
+		if ctr, ok := ctrsByID[session.ContainerID()]; ok {
+			ctr.newExecReapedEvent(session.ID())
+		}
+	}
 
 	// Create a file indicating the runtime is alive and ready
 	file, err := os.OpenFile(alivePath, os.O_RDONLY|os.O_CREATE, 0644)
@@ -1072,29 +1261,79 @@ func (r *Runtime) EnableLabeling() bool {
 	return r.config.Containers.EnableLabeling
 }
 
-// Reload reloads the configurations files
+// Reload reloads the configurations files. Only the reloadable settings of
+// containers.conf are applied, the others keep their value until Podman is
+// restarted. A system reload event lists the changed settings.
 func (r *Runtime) Reload() error {
-	if err := r.reloadContainersConf(); err != nil {
+	reloaded, restart, err := r.reloadContainersConf()
+	if err != nil {
 		return err
 	}
 	if err := r.reloadStorageConf(); err != nil {
 		return err
 	}
-	// Invalidate the registries.conf cache. The next invocation will
-	// reload all data.
-	sysregistriesv2.InvalidateCache()
+	if r.reloadRegistriesConf() {
+		reloaded = append(reloaded, registriesSetting)
+	}
+	r.newReloadEvent(reloaded, restart)
 	return nil
 }
 
-// reloadContainersConf reloads the containers.conf
-func (r *Runtime) reloadContainersConf() error {
-	config, err := config.Reload()
+// reloadContainersConf reloads the containers.conf and returns the changed
+// settings that were applied and the ones requiring a restart.
+func (r *Runtime) reloadContainersConf() ([]string, []string, error) {
+	updated, err := config.New(&config.Options{SetDefault: true, Modules: r.config.LoadedModules()})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	r.config = config
-	logrus.Infof("Applied new containers configuration: %v", config)
-	return nil
+	base := r.reloadBase
+	if base == nil {
+		base = updated
+	}
+	podmanUpdated, err := containersconf.New(updated.LoadedModules())
+	if err != nil {
+		return nil, nil, err
+	}
+	podmanBase := r.podmanReloadBase
+	if podmanBase == nil {
+		podmanBase = podmanUpdated
+	}
+	conf, reloaded, restart := applyReloadableSettings(r.config, base, updated)
+	podmanConf, podmanReloaded, podmanRestart := applyReloadableSettings(&r.podmanConf, podmanBase, podmanUpdated)
+	reloaded = append(reloaded, podmanReloaded...)
+	restart = append(restart, podmanRestart...)
+	if slices.Contains(reloaded, serviceLogLevelSetting) && podmanConf.Engine.ServiceLogLevel != "" {
+		level, err := logrus.ParseLevel(podmanConf.Engine.ServiceLogLevel)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service_log_level: %w", err)
+		}
+		logrus.SetLevel(level)
+	}
+	r.config = conf
+	r.reloadBase = updated
+	r.podmanConf = *podmanConf
+	r.podmanReloadBase = podmanUpdated
+	for _, setting := range restart {
+		logrus.Warnf("Changed setting %s of containers.conf requires a restart to apply", setting)
+	}
+	logrus.Infof("Applied new containers configuration: %v", conf)
+	return reloaded, restart, nil
+}
+
+// reloadRegistriesConf invalidates the registries.conf cache, the next
+// invocation reloads all data. It returns true if the registries changed.
+func (r *Runtime) reloadRegistriesConf() bool {
+	before, err := sysregistriesv2.TryUpdatingCache(r.imageContext)
+	if err != nil {
+		logrus.Debugf("Reading registries configuration: %v", err)
+	}
+	sysregistriesv2.InvalidateCache()
+	after, err := sysregistriesv2.TryUpdatingCache(r.imageContext)
+	if err != nil {
+		logrus.Errorf("Reloading registries configuration: %v", err)
+		return false
+	}
+	return !reflect.DeepEqual(before, after)
 }
 
 // reloadStorageConf reloads the storage.conf
@@ -1119,7 +1358,8 @@ func (r *Runtime) getVolumePlugin(volConfig *VolumeConfig) (*plugin.VolumePlugin
 
 	pluginPath, ok := r.config.Engine.VolumePlugins[name]
 	if !ok {
-		if name == define.VolumeDriverImage {
+		switch name {
+		case define.VolumeDriverImage, define.VolumeDriverNFS, define.VolumeDriverCIFS:
 			return nil, nil
 		}
 		return nil, fmt.Errorf("no volume plugin with name %s available: %w", name, define.ErrMissingPlugin)
@@ -1134,7 +1374,7 @@ func (r *Runtime) GetSecretsStorageDir() string {
 }
 
 // SecretsManager returns the directory that the secrets manager should take
-func (r *Runtime) SecretsManager() (*secrets.SecretsManager, error) {
+func (r *Runtime) SecretsManager() (*secrets.Manager, error) {
 	if r.secretsManager == nil {
 		manager, err := secrets.NewManager(r.GetSecretsStorageDir())
 		if err != nil {
@@ -1390,3 +1630,69 @@ func (r *Runtime) SystemCheck(ctx context.Context, options entities.SystemCheckO
 
 	return report, err
 }
+
+// CheckDB checks the consistency of the database and that the storage of
+// all containers exists in c/storage.
+func (r *Runtime) CheckDB(ctx context.Context) (*define.DBCheckReport, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	report, err := r.state.Verify()
+	if err != nil {
+		return nil, fmt.Errorf("verifying database: %w", err)
+	}
+
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving containers: %w", err)
+	}
+	for _, ctr := range ctrs {
+		// Containers created from a root filesystem have no storage.
+		if ctr.config.Rootfs != "" {
+			continue
+		}
+		if _, err := r.store.Container(ctr.ID()); err != nil {
+			if !errors.Is(err, storage.ErrContainerUnknown) {
+				return nil, fmt.Errorf("looking up storage of container %s: %w", ctr.ID(), err)
+			}
+			report.MissingStorage = append(report.MissingStorage, ctr.ID())
+		}
+	}
+
+	integrity := define.DBIntegrityOK
+	if report.HasProblems() {
+		integrity = define.DBIntegrityProblems
+	}
+	r.recordDBMaintenance(dbMaintenanceCheck, integrity)
+
+	return report, nil
+}
+
+// VacuumDB rebuilds the database to reclaim the space of removed entries.
+func (r *Runtime) VacuumDB() error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	if err := r.state.Vacuum(); err != nil {
+		return err
+	}
+	r.recordDBMaintenance(dbMaintenanceVacuum, "")
+	return nil
+}
+
+// recordDBMaintenance records a maintenance operation of the database, shown
+// by podman info. Failures are only logged as the operation itself succeeded.
+func (r *Runtime) recordDBMaintenance(operation, result string) {
+	if err := r.state.RecordMaintenance(operation, result); err != nil && !errors.Is(err, define.ErrNotImplemented) {
+		logrus.Warnf("Recording database %s: %v", operation, err)
+	}
+}
+
+// CheckpointDB truncates the write-ahead log of the database, if it uses one.
+func (r *Runtime) CheckpointDB() error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.state.CheckpointWAL()
+}
@@ -0,0 +1,160 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/sirupsen/logrus"
+)
+
+// BackupDB writes a consistent copy of the database to path, which must not
+// exist. Containers may keep running while the backup is taken.
+func (r *Runtime) BackupDB(path string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	if err := fileutils.Exists(path); err == nil {
+		return fmt.Errorf("backup file %s already exists: %w", path, define.ErrInvalidArg)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := r.state.Backup(path); err != nil {
+		return err
+	}
+	r.recordDBMaintenance(dbMaintenanceBackup, "")
+	return nil
+}
+
+// RestoreDB replaces the database with the backup at path, which must have
+// been created by BackupDB for the same database backend. No container may be
+// running, as their state in the backup would not match the system.
+//
+// The backup is copied next to the database and opened to validate it, and
+// only then moved into place. The runtime uses the restored database
+// afterwards.
+func (r *Runtime) RestoreDB(path string) error {
+	aliveLock, err := r.getRuntimeAliveLock()
+	if err != nil {
+		return fmt.Errorf("retrieving alive lock: %w", err)
+	}
+	aliveLock.Lock()
+	defer aliveLock.Unlock()
+
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	ctrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return fmt.Errorf("retrieving containers: %w", err)
+	}
+	for _, ctr := range ctrs {
+		if ctr.ensureState(define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping) {
+			return fmt.Errorf("container %s is %s, stop all containers before restoring the database: %w", ctr.ID(), ctr.state.State, define.ErrCtrStateInvalid)
+		}
+	}
+
+	var (
+		dbPath string
+		openDB func(path string) (State, error)
+	)
+	switch state := r.backingState().(type) {
+	case *SQLiteState:
+		dbPath = filepath.Join(sqliteStateDir(r), sqliteDBName)
+		openDB = func(path string) (State, error) {
+			sqlState, err := newSqliteState(r, path)
+			if err != nil {
+				return nil, err
+			}
+			return sqlState, nil
+		}
+	case *BoltState:
+		dbPath = state.dbPath
+		openDB = func(path string) (State, error) {
+			return NewBoltState(path, r)
+		}
+	default:
+		return fmt.Errorf("restoring state %T is not supported: %w", r.state, define.ErrNotImplemented)
+	}
+
+	tmpPath := dbPath + ".restore"
+	removeTmp := func() {
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Remove(tmpPath + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				logrus.Errorf("Removing temporary database %s: %v", tmpPath+suffix, err)
+			}
+		}
+	}
+	removeTmp()
+
+	if err := copyDBFile(path, tmpPath); err != nil {
+		removeTmp()
+		return err
+	}
+	restored, err := openDB(tmpPath)
+	if err != nil {
+		removeTmp()
+		return fmt.Errorf("opening backup %s: %w", path, err)
+	}
+	if err := restored.Close(); err != nil {
+		removeTmp()
+		return err
+	}
+
+	// Closing the database merges the write-ahead log of SQLite, the
+	// remaining files belong to the old database.
+	if err := r.state.Close(); err != nil {
+		removeTmp()
+		return fmt.Errorf("closing database: %w", err)
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		removeTmp()
+		return fmt.Errorf("moving restored database into place: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing write-ahead log of the old database: %w", err)
+		}
+	}
+
+	state, err := openDB(dbPath)
+	if err != nil {
+		return fmt.Errorf("opening restored database: %w", err)
+	}
+	r.state = r.wrapState(state)
+	return nil
+}
+
+// copyDBFile copies the database file src to dest and syncs it to disk.
+func copyDBFile(src, dest string) (retErr error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("opening backup: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := out.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("copying backup %s: %w", src, err)
+	}
+	return out.Sync()
+}
@@ -0,0 +1,157 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities/reports"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/sirupsen/logrus"
+)
+
+// CheckpointFilter is a function to determine whether a checkpoint record is
+// included in command output. A true return includes the record.
+type CheckpointFilter func(*define.CheckpointRecord) bool
+
+// Checkpoints returns the records of the checkpoints exported by containers
+// which match all given filters, oldest first.
+func (r *Runtime) Checkpoints(filters ...CheckpointFilter) ([]*define.CheckpointRecord, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	records, err := r.state.Checkpoints("")
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]*define.CheckpointRecord, 0, len(records))
+	for _, record := range records {
+		if matchCheckpointFilters(record, filters) {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered, nil
+}
+
+// PruneCheckpoints removes the archives and records of the checkpoints
+// matching all given filters. Records of archives which no longer exist are
+// always removed. A pre-checkpoint is kept as long as a checkpoint based on
+// it is kept, as the checkpoint cannot be restored without it.
+func (r *Runtime) PruneCheckpoints(filters []CheckpointFilter) ([]*reports.PruneReport, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	records, err := r.state.Checkpoints("")
+	if err != nil {
+		return nil, err
+	}
+
+	prune := make(map[int64]bool, len(records))
+	for _, record := range records {
+		if _, err := os.Stat(record.Path); errors.Is(err, os.ErrNotExist) {
+			prune[record.ID] = true
+			continue
+		}
+		prune[record.ID] = matchCheckpointFilters(record, filters)
+	}
+	for _, record := range records {
+		if !prune[record.ID] && record.ParentID != 0 {
+			prune[record.ParentID] = false
+		}
+	}
+
+	preports := make([]*reports.PruneReport, 0)
+	for _, record := range records {
+		if !prune[record.ID] {
+			continue
+		}
+		report := &reports.PruneReport{Id: record.Path}
+		if info, err := os.Stat(record.Path); err == nil {
+			report.Size = uint64(info.Size())
+		}
+		if err := os.Remove(record.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			report.Err = fmt.Errorf("removing checkpoint %s: %w", record.Path, err)
+			report.Size = 0
+		} else if err := r.state.RemoveCheckpoint(record.ID); err != nil {
+			report.Err = err
+		}
+		preports = append(preports, report)
+	}
+	return preports, nil
+}
+
+// matchCheckpointFilters returns true if the record matches all filters.
+func matchCheckpointFilters(record *define.CheckpointRecord, filters []CheckpointFilter) bool {
+	for _, filter := range filters {
+		if !filter(record) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordCheckpoint records the checkpoint the container just exported to
+// options.TargetFile, so it can be listed and pruned later. Failing to record
+// the checkpoint does not fail the checkpoint itself.
+func (c *Container) recordCheckpoint(options ContainerCheckpointOptions) {
+	target, err := filepath.Abs(options.TargetFile)
+	if err != nil {
+		logrus.Warnf("Not recording checkpoint of container %s: %v", c.ID(), err)
+		return
+	}
+	record := &define.CheckpointRecord{
+		ContainerID:   c.ID(),
+		ContainerName: c.Name(),
+		Path:          target,
+		Created:       time.Now(),
+		Compression:   checkpointCompressionName(options.Compression),
+		PreCheckpoint: options.PreCheckPoint,
+	}
+	if info, err := os.Stat(target); err == nil {
+		record.Size = info.Size()
+	}
+
+	if options.WithPrevious {
+		records, err := c.runtime.state.Checkpoints(c.ID())
+		if err != nil {
+			logrus.Warnf("Retrieving pre-checkpoints of container %s: %v", c.ID(), err)
+		}
+		for i := len(records) - 1; i >= 0; i-- {
+			if records[i].PreCheckpoint {
+				record.ParentID = records[i].ID
+				break
+			}
+		}
+	}
+
+	if err := c.runtime.state.AddCheckpoint(record); err != nil {
+		if errors.Is(err, define.ErrNotImplemented) {
+			logrus.Debugf("Not recording checkpoint of container %s: %v", c.ID(), err)
+			return
+		}
+		logrus.Warnf("Recording checkpoint of container %s: %v", c.ID(), err)
+	}
+}
+
+// checkpointCompressionName returns the name of the compression as accepted by
+// podman container checkpoint --compress.
+func checkpointCompressionName(compression archive.Compression) string {
+	switch compression {
+	case archive.Uncompressed:
+		return "none"
+	case archive.Gzip:
+		return "gzip"
+	case archive.Zstd:
+		return "zstd"
+	case archive.Bzip2:
+		return "bzip2"
+	case archive.Xz:
+		return "xz"
+	}
+	return ""
+}
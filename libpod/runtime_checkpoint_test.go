@@ -0,0 +1,62 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneCheckpoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	runtime := &Runtime{config: new(config.Config), storageConfig: storage.StoreOptions{}, valid: true}
+	state, err := newSqliteState(runtime, filepath.Join(tmpDir, sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+	runtime.state = state
+
+	archive := func(name string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte("checkpoint"), 0o600))
+		return path
+	}
+	pre := &define.CheckpointRecord{ContainerID: "ctr1", ContainerName: "web", Path: archive("pre.tar"), PreCheckpoint: true}
+	require.NoError(t, state.AddCheckpoint(pre))
+	full := &define.CheckpointRecord{ContainerID: "ctr1", ContainerName: "web", Path: archive("web.tar"), ParentID: pre.ID}
+	require.NoError(t, state.AddCheckpoint(full))
+	other := &define.CheckpointRecord{ContainerID: "ctr2", ContainerName: "db", Path: archive("db.tar")}
+	require.NoError(t, state.AddCheckpoint(other))
+	// The archive of this one was removed by hand.
+	require.NoError(t, state.AddCheckpoint(&define.CheckpointRecord{ContainerID: "ctr2", ContainerName: "db", Path: filepath.Join(tmpDir, "gone.tar")}))
+
+	// The pre-checkpoint is kept as the checkpoint based on it is kept.
+	preOrDB := func(record *define.CheckpointRecord) bool {
+		return record.PreCheckpoint || record.ContainerID == "ctr2"
+	}
+	reports, err := runtime.PruneCheckpoints([]CheckpointFilter{preOrDB})
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, other.Path, reports[0].Id)
+	assert.Equal(t, uint64(len("checkpoint")), reports[0].Size)
+	assert.NoFileExists(t, other.Path)
+	assert.FileExists(t, pre.Path)
+
+	records, err := runtime.Checkpoints()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	reports, err = runtime.PruneCheckpoints(nil)
+	require.NoError(t, err)
+	assert.Len(t, reports, 2)
+	records, err = runtime.Checkpoints()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.NoFileExists(t, pre.Path)
+}
@@ -0,0 +1,60 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// AddContainerGroup creates a container group with the given name selecting
+// the containers matching all of the given filters. The filters are not
+// validated here, as they are interpreted by the callers listing containers.
+func (r *Runtime) AddContainerGroup(name string, filters map[string][]string) (*define.ContainerGroup, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if !define.NameRegex.MatchString(name) {
+		return nil, fmt.Errorf("container group name %q: %w", name, define.RegexError)
+	}
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("container group %s must have at least one filter: %w", name, define.ErrInvalidArg)
+	}
+
+	group := &define.ContainerGroup{
+		Name:    name,
+		Filters: filters,
+		Created: time.Now(),
+	}
+	if err := r.state.AddContainerGroup(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// RemoveContainerGroup removes the container group with the given name. The
+// containers of the group are not affected.
+func (r *Runtime) RemoveContainerGroup(name string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.state.RemoveContainerGroup(name)
+}
+
+// ContainerGroup returns the container group with the given name.
+func (r *Runtime) ContainerGroup(name string) (*define.ContainerGroup, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.ContainerGroup(name)
+}
+
+// ContainerGroups returns all container groups, ordered by name.
+func (r *Runtime) ContainerGroups() ([]*define.ContainerGroup, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.ContainerGroups()
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/containers/buildah"
@@ -126,37 +127,20 @@ func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName s
 		return nil, define.RegexError
 	}
 
-	// We need to pull an updated config, in case another rename fired and
-	// the config was re-written.
-	newConf, err := r.state.GetContainerConfig(ctr.ID())
-	if err != nil {
-		return nil, fmt.Errorf("retrieving container %s configuration from DB to remove: %w", ctr.ID(), err)
-	}
-	ctr.config = newConf
-
-	logrus.Infof("Going to rename container %s from %q to %q", ctr.ID(), ctr.Name(), newName)
-
-	// Step 1: Alter the config. Save the old name, we need it to rewrite
-	// the config.
-	oldName := ctr.config.Name
-	ctr.config.Name = newName
+	oldName := ctr.Name()
+	logrus.Infof("Going to rename container %s from %q to %q", ctr.ID(), oldName, newName)
 
-	// Step 2: rewrite the old container's config in the DB.
-	if err := r.state.SafeRewriteContainerConfig(ctr, oldName, ctr.config.Name, ctr.config); err != nil {
-		// Assume the rename failed.
-		// Set config back to the old name so reflect what is actually
-		// present in the DB.
-		ctr.config.Name = oldName
+	if err := r.state.RenameContainer(ctr, newName); err != nil {
 		return nil, fmt.Errorf("renaming container %s: %w", ctr.ID(), err)
 	}
 
-	// Step 3: rename the container in c/storage.
-	// This can fail if the name is already in use by a non-Podman
-	// container. This puts us in a bad spot - we've already renamed the
-	// container in Podman. We can swap the order, but then we have the
-	// opposite problem. Atomicity is a real problem here, with no easy
-	// solution.
-	if err := r.store.SetNames(ctr.ID(), []string{ctr.Name()}); err != nil {
+	// Rename the container in c/storage as well. This can fail if the name
+	// is already in use by a non-Podman container, in which case the
+	// rename in the database is reverted.
+	if err := r.store.SetNames(ctr.ID(), []string{newName}); err != nil {
+		if revertErr := r.state.RenameContainer(ctr, oldName); revertErr != nil {
+			logrus.Errorf("Reverting rename of container %s to %q: %v", ctr.ID(), oldName, revertErr)
+		}
 		return nil, err
 	}
 
@@ -211,6 +195,12 @@ func (r *Runtime) initContainerVariables(rSpec *spec.Spec, config *ContainerConf
 	ctr.config.Spec = rSpec
 	ctr.config.CreatedTime = time.Now()
 
+	effectiveConfig, err := r.effectiveConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctr.config.EffectiveConfig = effectiveConfig
+
 	ctr.state.BindMounts = make(map[string]string)
 
 	ctr.config.OCIRuntime = r.defaultOCIRuntime.Name()
@@ -246,6 +236,11 @@ func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ..
 }
 
 func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Container, retErr error) {
+	ctx, endSpan := ctr.startSpan(ctx, "container create")
+	defer func() {
+		endSpan(retErr)
+	}()
+
 	// normalize the networks to names
 	// the db backend only knows about network names so we have to make
 	// sure we do not use ids internally
@@ -297,11 +292,20 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		}
 		ctr.config.Networks = normalizeNetworks
 	}
+	if err := r.validateContainerInterfaceOptions(ctr); err != nil {
+		return nil, err
+	}
 
 	// Validate the container
 	if err := ctr.validate(); err != nil {
 		return nil, err
 	}
+	if err := r.checkPortConflicts(ctr); err != nil {
+		return nil, err
+	}
+	if err := r.checkCreateImagePolicy(ctr); err != nil {
+		return nil, err
+	}
 	if ctr.config.IsInfra {
 		ctr.config.StopTimeout = 10
 	}
@@ -510,7 +514,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 			WithVolumeMountLabel(ctr.MountLabel()),
 		}
 		if isAnonymous {
-			volOptions = append(volOptions, withSetAnon())
+			volOptions = append(volOptions, WithVolumeAnonymous())
 		}
 
 		needsChown := true
@@ -550,6 +554,25 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		}
 	}
 
+	// Persistent image volumes keep their changes in an anonymous volume.
+	for _, vol := range ctr.config.ImageVolumes {
+		if !vol.Persist {
+			continue
+		}
+		vol.UpperVolume = stringid.GenerateRandomID()
+		logrus.Debugf("Creating new volume %s for image volume %q:%q of container", vol.UpperVolume, vol.Source, vol.Dest)
+		volOptions := []VolumeCreateOption{
+			WithVolumeName(vol.UpperVolume),
+			WithVolumeMountLabel(ctr.MountLabel()),
+			WithVolumeUID(ctr.RootUID()),
+			WithVolumeGID(ctr.RootGID()),
+			WithVolumeAnonymous(),
+		}
+		if _, err := r.newVolume(ctx, false, volOptions...); err != nil {
+			return nil, fmt.Errorf("creating volume for image volume %q:%q: %w", vol.Source, vol.Dest, err)
+		}
+	}
+
 	switch ctr.config.LogDriver {
 	case define.NoLogging, define.PassthroughLogging, define.JournaldLogging:
 		break
@@ -585,7 +608,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 	}
 
 	if ctr.runtime.config.Engine.EventsContainerCreateInspectData {
-		if err := ctr.newContainerEventWithInspectData(events.Create, "", true); err != nil {
+		if err := ctr.newContainerEventWithInspectData(events.Create, true); err != nil {
 			return nil, err
 		}
 	} else {
@@ -643,7 +666,8 @@ type ctrRmOpts struct {
 	RemovePod bool
 	// Whether to ignore dependencies of the container when removing
 	// (This is *DANGEROUS* and should not be used outside of non-graph
-	// traversal pod removal code).
+	// traversal pod removal code, or for dependents removed in graph
+	// order with RemoveDeps).
 	IgnoreDeps bool
 	// Remove all the dependencies associated with the container. Can cause
 	// multiple containers, and possibly one or more pods, to be removed.
@@ -878,21 +902,41 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 	// Only used if not removing a pod - pods guarantee that all
 	// deps will be evicted at the same time.
 	if !opts.IgnoreDeps {
-		deps, err := r.state.ContainerInUse(c)
-		if err != nil {
-			retErr = err
-			return
-		}
 		if !opts.RemoveDeps {
+			deps, err := r.state.ContainerInUse(c)
+			if err != nil {
+				retErr = err
+				return
+			}
 			if len(deps) != 0 {
 				depsStr := strings.Join(deps, ", ")
 				retErr = fmt.Errorf("container %s has dependent containers which must be removed before it: %s: %w", c.ID(), depsStr, define.ErrCtrExists)
 				return
 			}
 		}
+
+		// Retrieve the whole graph of dependents at once and remove
+		// them in order, each before the containers it depends on.
+		var deps []string
+		if opts.RemoveDeps {
+			edges, err := r.state.ContainerDependents(c)
+			if err != nil {
+				retErr = err
+				return
+			}
+			deps, err = dependentsRemovalOrder(c.ID(), edges)
+			if err != nil {
+				retErr = err
+				return
+			}
+		}
 		for _, depCtr := range deps {
 			dep, err := r.GetContainer(depCtr)
 			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) {
+					// Removed along with its pod.
+					continue
+				}
 				retErr = err
 				return
 			}
@@ -900,6 +944,7 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 			recursiveOpts := ctrRmOpts{
 				Force:        opts.Force,
 				RemoveVolume: opts.RemoveVolume,
+				IgnoreDeps:   true,
 				RemoveDeps:   true,
 				NoLockPod:    true,
 				Timeout:      opts.Timeout,
@@ -1026,8 +1071,8 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 		return
 	}
 
-	for _, v := range c.config.NamedVolumes {
-		if volume, err := runtime.state.Volume(v.Name); err == nil {
+	for _, name := range c.volumeNames() {
+		if volume, err := runtime.state.Volume(name); err == nil {
 			if !volume.Anonymous() {
 				continue
 			}
@@ -1036,11 +1081,11 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 					// Ignore error, since podman will report original error
 					volumesFrom, _ := c.volumesFrom()
 					if len(volumesFrom) > 0 {
-						logrus.Debugf("Cleaning up volume not possible since volume is in use (%s)", v.Name)
+						logrus.Debugf("Cleaning up volume not possible since volume is in use (%s)", name)
 						continue
 					}
 				}
-				logrus.Errorf("Cleaning up volume (%s): %v", v.Name, err)
+				logrus.Errorf("Cleaning up volume (%s): %v", name, err)
 			}
 		}
 	}
@@ -1190,13 +1235,13 @@ func (r *Runtime) evictContainer(ctx context.Context, idOrName string, removeVol
 		return id, cleanupErr
 	}
 
-	for _, v := range c.config.NamedVolumes {
-		if volume, err := r.state.Volume(v.Name); err == nil {
+	for _, name := range c.volumeNames() {
+		if volume, err := r.state.Volume(name); err == nil {
 			if !volume.Anonymous() {
 				continue
 			}
 			if err := r.removeVolume(ctx, volume, false, timeout, false); err != nil && err != define.ErrNoSuchVolume && err != define.ErrVolumeBeingUsed {
-				logrus.Errorf("Cleaning up volume (%s): %v", v.Name, err)
+				logrus.Errorf("Cleaning up volume (%s): %v", name, err)
 			}
 		}
 	}
@@ -1246,11 +1291,18 @@ func (r *Runtime) LookupContainerID(idOrName string) (string, error) {
 // the output. Multiple filters are handled by ANDing their output, so only
 // containers matching all filters are returned
 func (r *Runtime) GetContainers(loadState bool, filters ...ContainerFilter) ([]*Container, error) {
+	return r.GetContainersFiltered(loadState, nil, filters...)
+}
+
+// GetContainersFiltered is like GetContainers, but only considers the
+// containers matching the given state filters, which are evaluated by the
+// database without loading the other containers.
+func (r *Runtime) GetContainersFiltered(loadState bool, stateFilters *StateContainerFilters, filters ...ContainerFilter) ([]*Container, error) {
 	if !r.valid {
 		return nil, define.ErrRuntimeStopped
 	}
 
-	ctrs, err := r.state.AllContainers(loadState)
+	ctrs, err := r.state.AllContainersFiltered(loadState, stateFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -1276,6 +1328,15 @@ func (r *Runtime) GetAllContainers() ([]*Container, error) {
 	return r.state.AllContainers(false)
 }
 
+// GetNetworkContainers returns the containers connected to the given
+// network, which must be given by name.
+func (r *Runtime) GetNetworkContainers(network string) ([]*Container, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.NetworkContainers(network)
+}
+
 // GetRunningContainers is a helper function for GetContainers
 func (r *Runtime) GetRunningContainers() ([]*Container, error) {
 	running := func(c *Container) bool {
@@ -1299,6 +1360,46 @@ func (r *Runtime) GetContainersByList(containers []string) ([]*Container, error)
 	return ctrs, nil
 }
 
+// StartContainers starts the given containers, each once the containers it
+// depends on among them are running, with at most parallelism containers
+// started concurrently, or the number of parallel jobs if parallelism is 0.
+// Dependencies which are not among ctrs are started along with the containers
+// depending on them. Containers whose dependencies failed to start are not
+// started, while containers which are already running count as started.
+// The errors of the containers are returned, mapped by container ID.
+func (r *Runtime) StartContainers(ctx context.Context, ctrs []*Container, parallelism uint) (map[string]error, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	graph, err := buildContainerGraph(ctrs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Containers which are already running do not prevent the containers
+	// depending on them from starting, so they are only reported once the
+	// graph was walked.
+	var runningLock sync.Mutex
+	running := make(map[string]error)
+	ctrErrors := walkGraph(graph, parallelism, false, func(node *containerNode) error {
+		err := node.container.Start(ctx, true)
+		if errors.Is(err, define.ErrCtrStateRunning) {
+			runningLock.Lock()
+			running[node.id] = err
+			runningLock.Unlock()
+			return nil
+		}
+		return err
+	}, func(node *containerNode) error {
+		return fmt.Errorf("a dependency of container %s failed to start: %w", node.id, define.ErrCtrStateInvalid)
+	})
+	for id, err := range running {
+		ctrErrors[id] = err
+	}
+	return ctrErrors, nil
+}
+
 // GetLatestContainer returns a container object of the latest created container.
 func (r *Runtime) GetLatestContainer() (*Container, error) {
 	lastCreatedIndex := -1
@@ -1335,9 +1436,130 @@ func (r *Runtime) GetExecSessionContainer(id string) (*Container, error) {
 	return r.state.Container(ctrID)
 }
 
+// GetExecSession retrieves the exec session with the given ID, or unique
+// prefix of an ID, as it was last saved, without locking or syncing its
+// container.
+func (r *Runtime) GetExecSession(id string) (*ExecSession, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	session, err := r.state.ExecSession(id)
+	if err == nil || !errors.Is(err, define.ErrNoSuchExecSession) {
+		return session, err
+	}
+
+	sessions, err := r.state.AllExecSessions()
+	if err != nil {
+		return nil, err
+	}
+	var found *ExecSession
+	for _, s := range sessions {
+		if !strings.HasPrefix(s.ID(), id) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one exec session matches ID %s: %w", id, define.ErrInvalidArg)
+		}
+		found = s
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no exec session with ID %s found: %w", id, define.ErrNoSuchExecSession)
+	}
+	return found, nil
+}
+
+// GetAllExecSessions retrieves all exec sessions, including stopped sessions
+// of detached execs which were not removed yet, as they were last saved.
+func (r *Runtime) GetAllExecSessions() ([]*ExecSession, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	return r.state.AllExecSessions()
+}
+
+// ReapExecSessions removes stale exec sessions: sessions whose process
+// exited and whose owner died before removing them, and sessions in the
+// database missing from the state of their container. An event is written
+// for every removed session. Returns the IDs of the removed sessions.
+func (r *Runtime) ReapExecSessions() ([]string, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	sessions, err := r.state.AllExecSessions()
+	if err != nil {
+		return nil, err
+	}
+	ctrSessions := make(map[string][]*ExecSession)
+	for _, session := range sessions {
+		ctrSessions[session.ContainerID()] = append(ctrSessions[session.ContainerID()], session)
+	}
+
+	reaped := []string{}
+	var lastErr error
+	for ctrID, sessions := range ctrSessions {
+		ctr, err := r.state.Container(ctrID)
+		if err == nil {
+			var ids []string
+			ids, err = ctr.reapExecSessions(sessions)
+			reaped = append(reaped, ids...)
+		}
+		if err != nil && !errors.Is(err, define.ErrNoSuchCtr) && !errors.Is(err, define.ErrCtrRemoved) {
+			if lastErr != nil {
+				logrus.Errorf("Reaping exec sessions: %v", lastErr)
+			}
+			lastErr = fmt.Errorf("reaping exec sessions of container %s: %w", ctrID, err)
+		}
+	}
+	return reaped, lastErr
+}
+
+// RestartContainersByPolicy restarts the exited containers whose restart
+// policy requires a restart which did not happen when they exited, for
+// instance because conmon could not run podman container cleanup, or after a
+// reboot on hosts without the podman-restart systemd service. Returns the IDs
+// of the restarted containers.
+func (r *Runtime) RestartContainersByPolicy(ctx context.Context) ([]string, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	ctrs, err := r.GetContainers(false, func(c *Container) bool {
+		return c.config.RestartPolicy != define.RestartPolicyNone && c.config.RestartPolicy != define.RestartPolicyNo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restarted := []string{}
+	var lastErr error
+	for _, ctr := range ctrs {
+		attempted, err := ctr.restartByPolicy(ctx)
+		if err == nil && attempted {
+			restarted = append(restarted, ctr.ID())
+		}
+		if err != nil && !errors.Is(err, define.ErrNoSuchCtr) && !errors.Is(err, define.ErrCtrRemoved) && !errors.Is(err, define.ErrCtrStateRunning) {
+			if lastErr != nil {
+				logrus.Errorf("Restarting containers: %v", lastErr)
+			}
+			lastErr = fmt.Errorf("restarting container %s: %w", ctr.ID(), err)
+		}
+	}
+	return restarted, lastErr
+}
+
 // PruneContainers removes stopped and exited containers from localstorage.  A set of optional filters
 // can be provided to be more granular.
 func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.PruneReport, error) {
+	return r.PruneContainersFiltered(nil, filterFuncs)
+}
+
+// PruneContainersFiltered is like PruneContainers, but only considers the
+// containers matching the given state filters, which are evaluated by the
+// database without loading the other containers.
+func (r *Runtime) PruneContainersFiltered(stateFilters *StateContainerFilters, filterFuncs []ContainerFilter) ([]*reports.PruneReport, error) {
 	preports := make([]*reports.PruneReport, 0)
 	// We add getting the exited and stopped containers via a filter
 	containerStateFilter := func(c *Container) bool {
@@ -1356,7 +1578,7 @@ func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.Pru
 		return false
 	}
 	filterFuncs = append(filterFuncs, containerStateFilter)
-	delContainers, err := r.GetContainers(false, filterFuncs...)
+	delContainers, err := r.GetContainersFiltered(false, stateFilters, filterFuncs...)
 	if err != nil {
 		return nil, err
 	}
@@ -1462,3 +1684,22 @@ func (r *Runtime) StorageContainers() ([]storage.Container, error) {
 func (r *Runtime) IsBuildahContainer(id string) (bool, error) {
 	return buildah.IsContainer(id, r.store)
 }
+
+// ContainerExitHistory returns the exits recorded for the container with the
+// given full ID, oldest first. The exits of removed containers are kept until
+// they are older than the exit code retention.
+func (r *Runtime) ContainerExitHistory(id string) ([]define.ContainerExit, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.ContainerExitHistory(id)
+}
+
+// exitCodeRetention returns how long exit codes are kept in the database.
+// An unset retention keeps them for five minutes.
+func (r *Runtime) exitCodeRetention() time.Duration {
+	if r.podmanConf.Engine.ExitCodeRetention == 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(r.podmanConf.Engine.ExitCodeRetention) * time.Second
+}
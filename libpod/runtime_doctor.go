@@ -0,0 +1,123 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// Doctor runs a series of checks of the environment podman runs in and
+// reports problems together with the steps to fix them.
+func (r *Runtime) Doctor(ctx context.Context) (*define.DoctorReport, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	report := &define.DoctorReport{}
+	report.Checks = append(report.Checks, r.platformDoctorChecks()...)
+	report.Checks = append(report.Checks,
+		r.doctorStorage(),
+		r.doctorDatabase(ctx),
+		r.doctorNetwork(),
+	)
+	report.Checks = append(report.Checks, r.doctorOCIRuntime()...)
+	return report, nil
+}
+
+func (r *Runtime) doctorStorage() define.DoctorCheck {
+	check := define.DoctorCheck{Name: "storage driver"}
+	driver, err := r.store.GraphDriver()
+	if err != nil {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("storage driver %q cannot be used: %v", r.store.GraphDriverName(), err)
+		check.Remediation = "Check the driver and its options in storage.conf. If the driver was changed after images were pulled, run \"podman system reset\" to start over with the new driver."
+		return check
+	}
+
+	switch driver.String() {
+	case "vfs":
+		check.Status = define.DoctorWarn
+		check.Message = "the vfs storage driver copies every layer, which is slow and uses a lot of disk space"
+		check.Remediation = "Set driver = \"overlay\" in storage.conf and run \"podman system reset\". Rootless users need a kernel of version 5.13 or newer, or fuse-overlayfs."
+	default:
+		check.Status = define.DoctorPass
+		check.Message = fmt.Sprintf("using the %s storage driver at %s", driver.String(), r.store.GraphRoot())
+	}
+	return check
+}
+
+func (r *Runtime) doctorDatabase(ctx context.Context) define.DoctorCheck {
+	check := define.DoctorCheck{Name: "database"}
+	dbReport, err := r.CheckDB(ctx)
+	if err != nil {
+		check.Status = define.DoctorFail
+		check.Message = err.Error()
+		check.Remediation = "Back up the database with \"podman system db backup\" if possible, then run \"podman system reset\"."
+		return check
+	}
+
+	if dbReport.HasProblems() {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("problems detected in the %s database", dbReport.Backend)
+		check.Remediation = "Run \"podman system db check\" for details. Orphaned entries are removed with \"podman system db vacuum\"."
+		return check
+	}
+
+	check.Status = define.DoctorPass
+	check.Message = fmt.Sprintf("no problems detected in the %s database", dbReport.Backend)
+	return check
+}
+
+func (r *Runtime) doctorNetwork() define.DoctorCheck {
+	check := define.DoctorCheck{Name: "network backend"}
+	info := r.network.NetworkInfo()
+	if _, err := r.network.NetworkList(); err != nil {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("listing networks of the %s backend: %v", info.Backend, err)
+		check.Remediation = "Check the permissions of the network configuration directory and remove malformed network files from it."
+		return check
+	}
+	if info.Path != "" && info.Version == "" {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("%s at %s cannot be executed", info.Backend, info.Path)
+		check.Remediation = fmt.Sprintf("Install the %s package, or set helper_binaries_dir in containers.conf to the directory containing it.", info.Backend)
+		return check
+	}
+
+	check.Status = define.DoctorPass
+	check.Message = fmt.Sprintf("using the %s backend", info.Backend)
+	if info.Version != "" {
+		check.Message += ", " + firstLine(info.Version)
+	}
+	return check
+}
+
+func (r *Runtime) doctorOCIRuntime() []define.DoctorCheck {
+	conmonCheck := define.DoctorCheck{Name: "conmon"}
+	runtimeCheck := define.DoctorCheck{Name: "OCI runtime"}
+	conmonInfo, runtimeInfo, err := r.defaultOCIRuntime.RuntimeInfo()
+	if err != nil {
+		for _, check := range []*define.DoctorCheck{&conmonCheck, &runtimeCheck} {
+			check.Status = define.DoctorFail
+			check.Message = err.Error()
+			check.Remediation = fmt.Sprintf("Install conmon and %s, or set conmon_path and the [engine.runtimes] table in containers.conf to their locations.", r.defaultOCIRuntime.Name())
+		}
+		return []define.DoctorCheck{conmonCheck, runtimeCheck}
+	}
+
+	conmonCheck.Status = define.DoctorPass
+	conmonCheck.Message = fmt.Sprintf("%s at %s", firstLine(conmonInfo.Version), conmonInfo.Path)
+	runtimeCheck.Status = define.DoctorPass
+	runtimeCheck.Message = fmt.Sprintf("%s at %s", firstLine(runtimeInfo.Version), runtimeInfo.Path)
+	return []define.DoctorCheck{conmonCheck, runtimeCheck}
+}
+
+// firstLine returns the first line of the version output of a program.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
@@ -0,0 +1,9 @@
+//go:build !remote
+
+package libpod
+
+import "github.com/containers/podman/v5/libpod/define"
+
+func (r *Runtime) platformDoctorChecks() []define.DoctorCheck {
+	return nil
+}
@@ -0,0 +1,122 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containers/common/pkg/cgroups"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/rootless"
+)
+
+// doctorMinIDs is the number of subordinate IDs needed by most images, which
+// expect the IDs 0 to 65535 to be usable.
+const doctorMinIDs = 65536
+
+// doctorControllers are the cgroup controllers needed to apply resource
+// limits to rootless containers.
+var doctorControllers = []string{"cpu", "memory", "pids"}
+
+func (r *Runtime) platformDoctorChecks() []define.DoctorCheck {
+	return []define.DoctorCheck{
+		doctorSubIDs(),
+		doctorCgroups(),
+	}
+}
+
+// doctorUserName returns the name of the user running podman, or its UID if
+// the name cannot be resolved.
+func doctorUserName() string {
+	uid := strconv.Itoa(rootless.GetRootlessUID())
+	if u, err := user.LookupId(uid); err == nil {
+		return u.Username
+	}
+	return uid
+}
+
+func doctorSubIDs() define.DoctorCheck {
+	check := define.DoctorCheck{Name: "subordinate IDs"}
+	if !rootless.IsRootless() {
+		check.Status = define.DoctorSkip
+		check.Message = "only needed by rootless podman"
+		return check
+	}
+
+	uids, gids, err := rootless.GetAvailableIDMaps()
+	if err != nil {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("reading ID mappings: %v", err)
+		return check
+	}
+	var uidCount, gidCount int64
+	for _, m := range uids {
+		uidCount += m.Count
+	}
+	for _, m := range gids {
+		gidCount += m.Count
+	}
+
+	name := doctorUserName()
+	remediation := fmt.Sprintf("Run \"usermod --add-subuids 100000-165535 --add-subgids 100000-165535 %s\" as root, then \"podman system migrate\".", name)
+	switch {
+	case uidCount <= 1 || gidCount <= 1:
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("no subordinate UIDs or GIDs are configured for user %s in /etc/subuid and /etc/subgid", name)
+		check.Remediation = remediation
+	case uidCount < doctorMinIDs || gidCount < doctorMinIDs:
+		check.Status = define.DoctorWarn
+		check.Message = fmt.Sprintf("only %d UIDs and %d GIDs are available, images using higher IDs cannot be pulled", uidCount, gidCount)
+		check.Remediation = remediation
+	default:
+		check.Status = define.DoctorPass
+		check.Message = fmt.Sprintf("%d UIDs and %d GIDs are available", uidCount, gidCount)
+	}
+	return check
+}
+
+func doctorCgroups() define.DoctorCheck {
+	check := define.DoctorCheck{Name: "cgroups"}
+	unified, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("reading cgroups mode: %v", err)
+		return check
+	}
+	if !unified {
+		check.Status = define.DoctorWarn
+		check.Message = "cgroups v1 is deprecated"
+		if rootless.IsRootless() {
+			check.Message += ", resource limits of rootless containers are ignored"
+		}
+		check.Remediation = "Boot the kernel with systemd.unified_cgroup_hierarchy=1 to switch to cgroups v2."
+		return check
+	}
+
+	controllers, err := cgroups.AvailableControllers(nil, true)
+	if err != nil {
+		check.Status = define.DoctorFail
+		check.Message = fmt.Sprintf("getting available cgroup controllers: %v", err)
+		return check
+	}
+	var missing []string
+	for _, c := range doctorControllers {
+		if !slices.Contains(controllers, c) {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = define.DoctorWarn
+		check.Message = fmt.Sprintf("the %s controllers are not delegated, the matching resource limits are ignored", strings.Join(missing, ", "))
+		check.Remediation = "Create /etc/systemd/system/user@.service.d/delegate.conf with the lines \"[Service]\" and \"Delegate=cpu cpuset io memory pids\", run \"systemctl daemon-reload\" and log in again."
+		return check
+	}
+
+	check.Status = define.DoctorPass
+	check.Message = "cgroups v2 with the controllers " + strings.Join(controllers, ", ")
+	return check
+}
@@ -8,11 +8,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
 	buildahDefine "github.com/containers/buildah/define"
 	"github.com/containers/buildah/imagebuildah"
 	"github.com/containers/common/libimage"
+	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/shortnames"
+	"github.com/containers/image/v5/transports/alltransports"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/events"
 	"github.com/containers/podman/v5/pkg/util"
@@ -146,3 +151,146 @@ func DownloadFromFile(reader *os.File) (string, error) {
 
 	return outFile.Name(), nil
 }
+
+// ImageProvenance returns the pulls of the image with the given ID, together
+// with all other pulls from the sources the image was pulled from, oldest
+// first.
+func (r *Runtime) ImageProvenance(imageID string) ([]*define.ImageProvenance, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.ImageProvenance(imageID)
+}
+
+// recordImageProvenance records the pull of an image reported by libimage.
+func (r *Runtime) recordImageProvenance(e *libimage.Event) error {
+	img, _, err := r.libimageRuntime.LookupImage(e.ID, nil)
+	if err != nil {
+		return err
+	}
+	return r.state.AddImageProvenance(&define.ImageProvenance{
+		Source:  imageProvenanceSource(e.Name, img.Names()),
+		ImageID: img.ID(),
+		Digest:  img.Digest().String(),
+		Pulled:  e.Time,
+	})
+}
+
+// Modes of trust-on-first-use pinning of image digests, set with
+// image_digest_pinning in containers.conf.
+const (
+	imageDigestPinningOff     = "off"
+	imageDigestPinningWarn    = "warn"
+	imageDigestPinningEnforce = "enforce"
+)
+
+// CheckImageDigestPin implements trust-on-first-use pinning of the digests of
+// images pulled by tag, if enabled in containers.conf. The first pull of a
+// source pins the digest of the pulled image. If a later pull results in a
+// different digest, a warning is logged or, if pinning is enforced, the pinned
+// image is tagged with the source again and an error is returned. With
+// acceptNewDigest the new digest is pinned instead.
+// name is the reference img was pulled by.
+func (r *Runtime) CheckImageDigestPin(name string, img *libimage.Image, acceptNewDigest bool) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	mode := r.podmanConf.Engine.ImageDigestPinning
+	switch mode {
+	case "", imageDigestPinningOff:
+		return nil
+	case imageDigestPinningWarn, imageDigestPinningEnforce:
+	default:
+		return fmt.Errorf("invalid image_digest_pinning %q, must be one of off, warn, enforce: %w", mode, define.ErrInvalidArg)
+	}
+
+	source := imageDigestPinSource(name, img.Names())
+	if source == "" {
+		return nil
+	}
+	pull := &define.ImageProvenance{
+		Source:  source,
+		ImageID: img.ID(),
+		Digest:  img.Digest().String(),
+		Pulled:  time.Now(),
+	}
+
+	pin, err := r.state.ImageDigestPin(source)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchImageDigestPin) {
+			logrus.Debugf("Pinning digest of %s to %s on first use", source, pull.Digest)
+			return r.state.SetImageDigestPin(pull)
+		}
+		return err
+	}
+	if pin.Digest == pull.Digest {
+		return nil
+	}
+	if acceptNewDigest {
+		logrus.Infof("Pinning digest of %s to %s, previously %s", source, pull.Digest, pin.Digest)
+		return r.state.SetImageDigestPin(pull)
+	}
+
+	changedErr := fmt.Errorf("digest of %s changed from %s, pinned on %s, to %s; use podman pull --accept-new-digest to trust the new digest: %w",
+		source, pin.Digest, pin.Pulled.Format(time.RFC3339), pull.Digest, define.ErrImageDigestChanged)
+	if mode == imageDigestPinningWarn {
+		logrus.Warn(changedErr)
+		return nil
+	}
+
+	// The pull already tagged the new image, give the tag back to the
+	// pinned image if it still exists.
+	if err := img.Untag(source); err != nil {
+		return fmt.Errorf("removing tag %s from image %s: %v: %w", source, img.ID(), err, changedErr)
+	}
+	pinned, _, err := r.libimageRuntime.LookupImage(pin.ImageID, nil)
+	if err == nil {
+		err = pinned.Tag(source)
+	}
+	if err != nil {
+		logrus.Warnf("Unable to restore tag %s of pinned image %s: %v", source, pin.ImageID, err)
+	}
+	return changedErr
+}
+
+// imageDigestPinSource returns the source whose digest is pinned when an image
+// is pulled by name, or "" if name does not refer to a tag in a registry.
+func imageDigestPinSource(name string, names []string) string {
+	if ref, err := alltransports.ParseImageName(name); err == nil {
+		if ref.Transport().Name() != docker.Transport.Name() {
+			return ""
+		}
+		name = strings.TrimPrefix(ref.StringWithinTransport(), "//")
+	}
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return ""
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return ""
+	}
+	if shortnames.IsShortName(name) {
+		return imageProvenanceSource(name, names)
+	}
+	return reference.TagNameOnly(named).String()
+}
+
+// imageProvenanceSource returns the reference an image given by name was
+// pulled from. Short names are resolved to the name of the image they match,
+// as that includes the registry.
+func imageProvenanceSource(name string, names []string) string {
+	if !shortnames.IsShortName(name) {
+		return name
+	}
+	tagged := name
+	if !strings.Contains(tagged[strings.LastIndex(tagged, "/")+1:], ":") {
+		tagged += ":latest"
+	}
+	for _, n := range names {
+		if strings.HasSuffix(n, "/"+tagged) {
+			return n
+		}
+	}
+	return name
+}
@@ -0,0 +1,80 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// Metrics returns the number of containers in each state, the resource usage
+// of the running containers, the number of pods, volumes and images, the size
+// of the local storage, and the state operations and events recorded if the
+// runtime was created WithMetrics.
+func (r *Runtime) Metrics() (*define.RuntimeMetrics, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	metrics := &define.RuntimeMetrics{ContainerStates: make(map[string]int)}
+	ctrs, err := r.GetAllContainers()
+	if err != nil {
+		return nil, fmt.Errorf("listing containers: %w", err)
+	}
+	for _, ctr := range ctrs {
+		state, err := ctr.State()
+		if err != nil {
+			if errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return nil, fmt.Errorf("getting state of container %s: %w", ctr.ID(), err)
+		}
+		metrics.ContainerStates[state.String()]++
+		if state != define.ContainerStateRunning {
+			continue
+		}
+		stats, err := ctr.GetContainerStats(nil)
+		if err != nil {
+			// The container stopped or was removed since it was listed,
+			// or it has no cgroup to sample.
+			if errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr) ||
+				errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrCtrStopped) ||
+				errors.Is(err, define.ErrNoCgroups) {
+				continue
+			}
+			return nil, fmt.Errorf("getting stats of container %s: %w", ctr.ID(), err)
+		}
+		metrics.Containers = append(metrics.Containers, stats)
+	}
+
+	pods, err := r.state.AllPods()
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	metrics.Pods = len(pods)
+	volumes, err := r.state.AllVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("listing volumes: %w", err)
+	}
+	metrics.Volumes = len(volumes)
+
+	images, err := r.store.Images()
+	if err != nil {
+		return nil, fmt.Errorf("listing images: %w", err)
+	}
+	metrics.Images = len(images)
+	layers, err := r.store.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("listing layers: %w", err)
+	}
+	for _, layer := range layers {
+		metrics.LayersSize += layer.UncompressedSize
+	}
+
+	if r.metrics != nil {
+		metrics.StateOperations, metrics.Events = r.metrics.snapshot()
+	}
+	return metrics, nil
+}
@@ -0,0 +1,1037 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/sirupsen/logrus"
+)
+
+// containerExitCode is an exit code together with the time it was recorded.
+type containerExitCode struct {
+	ExitCode  int32
+	TimeStamp time.Time
+}
+
+// dbContent is the full content of a state database.
+type dbContent struct {
+	pods         []*Pod
+	containers   []*Container
+	volumes      []*Volume
+	execSessions map[string][]string
+	// Networks of the containers keyed by container ID, as the states
+	// do not store them in the container configs.
+	networks    map[string]map[string]types.PerNetworkOptions
+	exitCodes   map[string]containerExitCode
+	exitHistory map[string][]define.ContainerExit
+	// Volume backups are only supported by the SQLite and PostgreSQL
+	// states, the BoltDB state exports none.
+	backupPolicies []*define.VolumeBackupPolicy
+	backups        []*define.VolumeBackup
+	// Container metadata is only supported by the SQLite and PostgreSQL
+	// states as well. Keyed by container ID.
+	metadata map[string]map[string]string
+	// The healthcheck history as well, keyed by container ID.
+	healthCheckLogs map[string][]define.HealthCheckHistoryEntry
+	// And the notes of containers, keyed by container ID.
+	notes map[string][]*define.ContainerNote
+	// And network reservations, checkpoint records, volume snapshots and
+	// container groups.
+	reservations []*define.NetworkReservation
+	checkpoints  []*define.CheckpointRecord
+	snapshots    []*define.VolumeSnapshot
+	groups       []*define.ContainerGroup
+	// The pulls of images, oldest first, and the digest pins of image
+	// sources.
+	provenance []*define.ImageProvenance
+	digestPins []*define.ImageProvenance
+}
+
+// stateImporter is implemented by the states that can be the destination of
+// a migration.
+type stateImporter interface {
+	importContent(content *dbContent) error
+	verifyContent(content *dbContent) error
+}
+
+// exportTo copies content into dest and verifies the number of imported
+// objects afterwards.
+func exportTo(content *dbContent, dest State) error {
+	importer, ok := dest.(stateImporter)
+	if !ok {
+		return fmt.Errorf("state %T does not support importing: %w", dest, define.ErrNotImplemented)
+	}
+	if err := importer.importContent(content); err != nil {
+		return err
+	}
+	return importer.verifyContent(content)
+}
+
+// ExportTo copies all objects of the database into dest.
+func (s *BoltState) ExportTo(dest State) error {
+	content, err := s.export()
+	if err != nil {
+		return fmt.Errorf("reading BoltDB state: %w", err)
+	}
+	return exportTo(content, dest)
+}
+
+// ExportTo copies all objects of the database into dest.
+func (s *SQLiteState) ExportTo(dest State) error {
+	content, err := s.export()
+	if err != nil {
+		return fmt.Errorf("reading SQLite state: %w", err)
+	}
+	return exportTo(content, dest)
+}
+
+// MigrateDB moves the content of the current state into a new database of
+// the given backend, either from BoltDB to SQLite or back.
+// The data is imported into a temporary database, which is moved into place
+// only after the number of imported objects was verified. The old database
+// is renamed afterwards so it is no longer used. The returned report counts
+// the migrated objects.
+func (r *Runtime) MigrateDB(newDB string) (*define.DBMigrationReport, error) {
+	backend, err := config.ParseDBBackend(newDB)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the containers and pods of the current namespace are visible,
+	// the others would be lost.
+	if r.config.Engine.Namespace != "" {
+		return nil, fmt.Errorf("cannot migrate the database from within namespace %q: %w", r.config.Engine.Namespace, define.ErrInvalidArg)
+	}
+
+	aliveLock, err := r.getRuntimeAliveLock()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving alive lock: %w", err)
+	}
+	aliveLock.Lock()
+	defer aliveLock.Unlock()
+
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	var (
+		oldPath string
+		dbPath  string
+		openDB  func(path string) (State, error)
+	)
+	switch backend {
+	case config.DBBackendSQLite:
+		boltState, ok := r.backingState().(*BoltState)
+		if !ok {
+			return nil, fmt.Errorf("database backend is already %s: %w", r.config.Engine.DBBackend, define.ErrInvalidArg)
+		}
+		oldPath = boltState.dbPath
+		dbPath = filepath.Join(sqliteStateDir(r), sqliteDBName)
+		openDB = func(path string) (State, error) {
+			sqlState, err := newSqliteState(r, path)
+			if err != nil {
+				return nil, err
+			}
+			return sqlState, nil
+		}
+	case config.DBBackendBoltDB:
+		sqlState, ok := r.backingState().(*SQLiteState)
+		if !ok {
+			return nil, fmt.Errorf("database backend is already %s: %w", r.config.Engine.DBBackend, define.ErrInvalidArg)
+		}
+		if backend := sqlState.dialect.backend(); backend != config.DBBackendSQLite.String() {
+			return nil, fmt.Errorf("migrating from database backend %s is not supported: %w", backend, define.ErrInvalidArg)
+		}
+		oldPath = filepath.Join(sqliteStateDir(r), sqliteDBName)
+		dbPath = defaultBoltDBPath(r)
+		openDB = func(path string) (State, error) {
+			return NewBoltState(path, r)
+		}
+	default:
+		return nil, fmt.Errorf("migrating to database backend %q is not supported: %w", newDB, define.ErrInvalidArg)
+	}
+
+	if err := fileutils.Exists(dbPath); err == nil {
+		return nil, fmt.Errorf("database %s already exists, remove it first: %w", dbPath, define.ErrInvalidArg)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	tmpPath := dbPath + ".migrate"
+	removeTmp := func() {
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Remove(tmpPath + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				logrus.Errorf("Removing temporary database %s: %v", tmpPath+suffix, err)
+			}
+		}
+	}
+	removeTmp()
+
+	newState, err := openDB(tmpPath)
+	if err != nil {
+		removeTmp()
+		return nil, err
+	}
+	if err := r.state.ExportTo(newState); err != nil {
+		newState.Close()
+		removeTmp()
+		return nil, err
+	}
+	if err := newState.Close(); err != nil {
+		removeTmp()
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		removeTmp()
+		return nil, fmt.Errorf("moving migrated database into place: %w", err)
+	}
+
+	// SQLite may leave write-ahead log files next to the database.
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		path := oldPath + suffix
+		if err := os.Rename(path, path+".migrated"); err != nil && (suffix == "" || !errors.Is(err, fs.ErrNotExist)) {
+			return nil, fmt.Errorf("renaming database %s after migration: %w", path, err)
+		}
+	}
+
+	// The old state is still open and holds the same objects.
+	ctrs, _ := r.state.AllContainers(true)
+	pods, _ := r.state.AllPods()
+	vols, _ := r.state.AllVolumes()
+	if r.config.Engine.DBBackend != "" && r.config.Engine.DBBackend != backend.String() {
+		logrus.Warnf("containers.conf may set database_backend to %q, change it to %q to use the migrated database", r.config.Engine.DBBackend, backend.String())
+	}
+	return &define.DBMigrationReport{
+		Backend:    backend.String(),
+		Path:       dbPath,
+		Containers: len(ctrs),
+		Pods:       len(pods),
+		Volumes:    len(vols),
+	}, nil
+}
+
+// export reads every object stored in the database.
+func (s *BoltState) export() (*dbContent, error) {
+	var (
+		content dbContent
+		err     error
+	)
+	if content.pods, err = s.AllPods(); err != nil {
+		return nil, err
+	}
+	if content.containers, err = s.AllContainers(true); err != nil {
+		return nil, err
+	}
+	if content.volumes, err = s.AllVolumes(); err != nil {
+		return nil, err
+	}
+	content.execSessions = make(map[string][]string)
+	content.networks = make(map[string]map[string]types.PerNetworkOptions)
+	for _, ctr := range content.containers {
+		sessions, err := s.GetContainerExecSessions(ctr)
+		if err != nil {
+			return nil, err
+		}
+		if len(sessions) > 0 {
+			content.execSessions[ctr.ID()] = sessions
+		}
+		networks, err := s.GetNetworks(ctr)
+		if err != nil {
+			return nil, err
+		}
+		if len(networks) > 0 {
+			content.networks[ctr.ID()] = networks
+		}
+	}
+	if content.exitCodes, err = s.allContainerExitCodes(); err != nil {
+		return nil, err
+	}
+	if content.exitHistory, err = s.allContainerExitHistory(); err != nil {
+		return nil, err
+	}
+	if content.backupPolicies, err = s.VolumeBackupPolicies(); err != nil {
+		return nil, err
+	}
+	if content.backups, err = s.VolumeBackups(""); err != nil {
+		return nil, err
+	}
+	if content.provenance, content.digestPins, err = s.allImageProvenance(); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// importContent writes the given objects into an empty database using a
+// single transaction.
+func (s *SQLiteState) importContent(content *dbContent) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning migration transaction: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back migration transaction: %v", err)
+			}
+		}
+	}()
+
+	// Volumes and pods are referenced by containers, so they go first.
+	for _, vol := range content.volumes {
+		cfgJSON, err := json.Marshal(vol.config)
+		if err != nil {
+			return fmt.Errorf("marshalling volume %s configuration json: %w", vol.Name(), err)
+		}
+		volState := vol.state
+		if volState == nil {
+			volState = new(VolumeState)
+		}
+		stateJSON, err := json.Marshal(volState)
+		if err != nil {
+			return fmt.Errorf("marshalling volume %s state json: %w", vol.Name(), err)
+		}
+		storageID := sql.NullString{}
+		if vol.config.StorageID != "" {
+			storageID.Valid = true
+			storageID.String = vol.config.StorageID
+		}
+		if _, err := tx.Exec("INSERT INTO VolumeConfig VALUES (?, ?, ?);", vol.Name(), storageID, cfgJSON); err != nil {
+			return fmt.Errorf("adding volume %s config to database: %w", vol.Name(), err)
+		}
+		if _, err := tx.Exec("INSERT INTO VolumeState VALUES (?, ?);", vol.Name(), stateJSON); err != nil {
+			return fmt.Errorf("adding volume %s state to database: %w", vol.Name(), err)
+		}
+	}
+
+	for _, pod := range content.pods {
+		configJSON, err := json.Marshal(pod.config)
+		if err != nil {
+			return fmt.Errorf("marshalling pod %s config json: %w", pod.ID(), err)
+		}
+		stateJSON, err := json.Marshal(pod.state)
+		if err != nil {
+			return fmt.Errorf("marshalling pod %s state json: %w", pod.ID(), err)
+		}
+		infraID := sql.NullString{}
+		if pod.state.InfraContainerID != "" {
+			infraID.Valid = true
+			infraID.String = pod.state.InfraContainerID
+		}
+		if _, err := tx.Exec("INSERT INTO IDNamespace VALUES (?);", pod.ID()); err != nil {
+			return fmt.Errorf("adding pod %s id to database: %w", pod.ID(), err)
+		}
+		if _, err := tx.Exec("INSERT INTO PodConfig (ID, Name, JSON, Namespace) VALUES (?, ?, ?, ?);", pod.ID(), pod.Name(), configJSON, pod.config.Namespace); err != nil {
+			return fmt.Errorf("adding pod %s config to database: %w", pod.ID(), err)
+		}
+		if _, err := tx.Exec("INSERT INTO PodState VALUES (?, ?, ?);", pod.ID(), infraID, stateJSON); err != nil {
+			return fmt.Errorf("adding pod %s state to database: %w", pod.ID(), err)
+		}
+		if err := insertPodSharedNamespaces(tx.Tx, pod.config, s.dialect.bindVars); err != nil {
+			return err
+		}
+	}
+
+	for _, ctr := range content.containers {
+		configJSON, err := containerConfigJSON(ctr.config)
+		if err != nil {
+			return fmt.Errorf("marshalling container %s config json: %w", ctr.ID(), err)
+		}
+		stateJSON, err := containerStateJSON(ctr.state)
+		if err != nil {
+			return fmt.Errorf("marshalling container %s state json: %w", ctr.ID(), err)
+		}
+		podID := sql.NullString{}
+		if ctr.config.Pod != "" {
+			podID.Valid = true
+			podID.String = ctr.config.Pod
+		}
+		if _, err := tx.Exec("INSERT INTO IDNamespace VALUES (?);", ctr.ID()); err != nil {
+			return fmt.Errorf("adding container %s id to database: %w", ctr.ID(), err)
+		}
+		args := append([]any{ctr.ID(), ctr.Name(), podID, configJSON}, containerConfigColumnValues(ctr.config)...)
+		args = append(args, ctr.config.Namespace)
+		if _, err := tx.Exec("INSERT INTO ContainerConfig (ID, Name, PodID, JSON, RestartPolicy, CreatedTime, ImageID, Namespace) VALUES (?, ?, ?, ?, ?, ?, ?, ?);", args...); err != nil {
+			return fmt.Errorf("adding container %s config to database: %w", ctr.ID(), err)
+		}
+		if _, err := tx.Exec("INSERT INTO ContainerState VALUES (?, ?, ?, ?);", ctr.ID(), int(ctr.state.State), ctr.state.ExitCode, stateJSON); err != nil {
+			return fmt.Errorf("adding container %s state to database: %w", ctr.ID(), err)
+		}
+		if err := insertContainerLabels(tx, ctr.ID(), ctr.config.Labels); err != nil {
+			return err
+		}
+		if err := saveContainerStateBlobs(tx.Tx, ctr.ID(), ctr.state, s.dialect.bindVars); err != nil {
+			return err
+		}
+		for _, name := range ctr.volumeNames() {
+			if _, err := tx.Exec("INSERT INTO ContainerVolume VALUES (?, ?) ON CONFLICT DO NOTHING;", ctr.ID(), name); err != nil {
+				return fmt.Errorf("adding container %s volume %s to database: %w", ctr.ID(), name, err)
+			}
+		}
+		if err := insertContainerNetworks(tx.Tx, ctr.ID(), content.networks[ctr.ID()], s.dialect.bindVars); err != nil {
+			return err
+		}
+		if ctr.config.Pod != "" {
+			if _, err := tx.Exec("INSERT INTO PodContainer VALUES (?, ?);", ctr.ID(), ctr.config.Pod); err != nil {
+				return fmt.Errorf("adding container %s to pod %s in database: %w", ctr.ID(), ctr.config.Pod, err)
+			}
+		}
+	}
+
+	// Infra containers reference the containers of their pods.
+	for _, pod := range content.pods {
+		if err := savePodInfraContainer(tx.Tx, pod, s.dialect.bindVars); err != nil {
+			return err
+		}
+	}
+
+	// Dependencies and exec sessions reference containers.
+	for _, ctr := range content.containers {
+		for _, dep := range ctr.Dependencies() {
+			if _, err := tx.Exec("INSERT INTO ContainerDependency VALUES (?, ?) ON CONFLICT DO NOTHING;", ctr.ID(), dep); err != nil {
+				return fmt.Errorf("adding container %s dependency %s to database: %w", ctr.ID(), dep, err)
+			}
+		}
+		for _, session := range content.execSessions[ctr.ID()] {
+			sessionJSON, err := json.Marshal(execSessionFromState(ctr, session))
+			if err != nil {
+				return fmt.Errorf("marshalling container %s exec session %s JSON: %w", ctr.ID(), session, err)
+			}
+			if _, err := tx.Exec("INSERT INTO ContainerExecSession VALUES (?, ?, ?);", session, ctr.ID(), sessionJSON); err != nil {
+				return fmt.Errorf("adding container %s exec session %s to database: %w", ctr.ID(), session, err)
+			}
+		}
+	}
+
+	for id, exitCode := range content.exitCodes {
+		if _, err := tx.Exec("INSERT INTO ContainerExitCode VALUES (?, ?, ?);", id, exitCode.TimeStamp.Unix(), exitCode.ExitCode); err != nil {
+			return fmt.Errorf("adding container %s exit code to database: %w", id, err)
+		}
+	}
+	for id, exits := range content.exitHistory {
+		for _, exit := range exits {
+			if _, err := tx.Exec("INSERT INTO ContainerExitHistory (ContainerID, Timestamp, ExitCode) VALUES (?, ?, ?);", id, exit.ExitedAt.Unix(), exit.ExitCode); err != nil {
+				return fmt.Errorf("adding container %s exit history to database: %w", id, err)
+			}
+		}
+	}
+
+	for _, policy := range content.backupPolicies {
+		policyJSON, err := json.Marshal(policy)
+		if err != nil {
+			return fmt.Errorf("marshalling backup policy of volume %s: %w", policy.Volume, err)
+		}
+		if _, err := tx.Exec("INSERT INTO VolumeBackupPolicy VALUES (?, ?);", policy.Volume, policyJSON); err != nil {
+			return fmt.Errorf("adding volume %s backup policy to database: %w", policy.Volume, err)
+		}
+	}
+	// The IDs of the backups are kept, as users refer to backups by ID.
+	for _, backup := range content.backups {
+		backupJSON, err := json.Marshal(backup)
+		if err != nil {
+			return fmt.Errorf("marshalling volume backup %d: %w", backup.ID, err)
+		}
+		if _, err := tx.Exec("INSERT INTO VolumeBackup VALUES (?, ?, ?);", backup.ID, backup.Volume, backupJSON); err != nil {
+			return fmt.Errorf("adding volume backup %d to database: %w", backup.ID, err)
+		}
+	}
+
+	for id, metadata := range content.metadata {
+		for key, value := range metadata {
+			if _, err := tx.Exec("INSERT INTO ContainerMetadata VALUES (?, ?, ?);", id, key, value); err != nil {
+				return fmt.Errorf("adding container %s metadata %s to database: %w", id, key, err)
+			}
+		}
+	}
+
+	for id, entries := range content.healthCheckLogs {
+		for _, entry := range entries {
+			if _, err := tx.Exec("INSERT INTO HealthCheckLog (ContainerID, Status, ExitCode, Output, StartTime, EndTime) VALUES (?, ?, ?, ?, ?, ?);",
+				id, entry.Status, entry.ExitCode, entry.Output, entry.Start, entry.End); err != nil {
+				return fmt.Errorf("adding container %s healthcheck log to database: %w", id, err)
+			}
+		}
+	}
+
+	// The IDs of the notes are kept, as users remove notes by ID.
+	for id, notes := range content.notes {
+		for _, note := range notes {
+			noteJSON, err := json.Marshal(note)
+			if err != nil {
+				return fmt.Errorf("marshalling note %d of container %s: %w", note.ID, id, err)
+			}
+			if _, err := tx.Exec("INSERT INTO ContainerNote VALUES (?, ?, ?);", note.ID, id, noteJSON); err != nil {
+				return fmt.Errorf("adding container %s note %d to database: %w", id, note.ID, err)
+			}
+		}
+	}
+
+	for _, reservation := range content.reservations {
+		reservationJSON, err := json.Marshal(reservation)
+		if err != nil {
+			return fmt.Errorf("marshalling reservation of %s on network %s: %w", reservation.Address, reservation.Network, err)
+		}
+		if _, err := tx.Exec("INSERT INTO NetworkReservation VALUES (?, ?, ?);", reservation.Network, reservation.Address, reservationJSON); err != nil {
+			return fmt.Errorf("adding reservation of %s on network %s to database: %w", reservation.Address, reservation.Network, err)
+		}
+	}
+
+	// The IDs of the checkpoints are kept as well, pre-checkpoints are
+	// referred to by ID.
+	for _, record := range content.checkpoints {
+		recordJSON, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("marshalling checkpoint %d: %w", record.ID, err)
+		}
+		if _, err := tx.Exec("INSERT INTO Checkpoint VALUES (?, ?, ?);", record.ID, record.ContainerID, recordJSON); err != nil {
+			return fmt.Errorf("adding checkpoint %d to database: %w", record.ID, err)
+		}
+	}
+
+	for _, snapshot := range content.snapshots {
+		snapshotJSON, err := json.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("marshalling volume snapshot %d: %w", snapshot.ID, err)
+		}
+		if _, err := tx.Exec("INSERT INTO VolumeSnapshot VALUES (?, ?, ?);", snapshot.ID, snapshot.Volume, snapshotJSON); err != nil {
+			return fmt.Errorf("adding volume snapshot %d to database: %w", snapshot.ID, err)
+		}
+	}
+
+	for _, group := range content.groups {
+		groupJSON, err := json.Marshal(group)
+		if err != nil {
+			return fmt.Errorf("marshalling container group %s: %w", group.Name, err)
+		}
+		if _, err := tx.Exec("INSERT INTO ContainerGroup VALUES (?, ?);", group.Name, groupJSON); err != nil {
+			return fmt.Errorf("adding container group %s to database: %w", group.Name, err)
+		}
+	}
+
+	// The pulls are added in order, the newest pull of a source is the
+	// one with the highest ID.
+	for _, record := range content.provenance {
+		if _, err := tx.Exec("INSERT INTO ImageProvenance(Source, ImageID, Digest, Timestamp) VALUES (?, ?, ?, ?);", record.Source, record.ImageID, record.Digest, record.Pulled.Unix()); err != nil {
+			return fmt.Errorf("adding provenance of image %s to database: %w", record.ImageID, err)
+		}
+	}
+	for _, pin := range content.digestPins {
+		if _, err := tx.Exec("INSERT INTO ImageDigestPin VALUES (?, ?, ?, ?);", pin.Source, pin.ImageID, pin.Digest, pin.Pulled.Unix()); err != nil {
+			return fmt.Errorf("pinning digest of image source %s in database: %w", pin.Source, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing migration transaction: %w", err)
+	}
+	return nil
+}
+
+// verifyContent checks that the database holds as many objects as content.
+func (s *SQLiteState) verifyContent(content *dbContent) error {
+	numSessions := 0
+	for _, sessions := range content.execSessions {
+		numSessions += len(sessions)
+	}
+	numExits := 0
+	for _, exits := range content.exitHistory {
+		numExits += len(exits)
+	}
+	numMetadata := 0
+	for _, metadata := range content.metadata {
+		numMetadata += len(metadata)
+	}
+	numNetworks := 0
+	for _, networks := range content.networks {
+		numNetworks += len(networks)
+	}
+	numHealthChecks := 0
+	for _, entries := range content.healthCheckLogs {
+		numHealthChecks += len(entries)
+	}
+	numNotes := 0
+	for _, notes := range content.notes {
+		numNotes += len(notes)
+	}
+	numLabels := 0
+	for _, ctr := range content.containers {
+		numLabels += len(ctr.config.Labels)
+	}
+	expected := []struct {
+		table string
+		count int
+	}{
+		{"ContainerConfig", len(content.containers)},
+		{"ContainerLabel", numLabels},
+		{"PodConfig", len(content.pods)},
+		{"VolumeConfig", len(content.volumes)},
+		{"ContainerExecSession", numSessions},
+		{"ContainerExitCode", len(content.exitCodes)},
+		{"ContainerExitHistory", numExits},
+		{"VolumeBackupPolicy", len(content.backupPolicies)},
+		{"VolumeBackup", len(content.backups)},
+		{"ContainerMetadata", numMetadata},
+		{"ContainerNetwork", numNetworks},
+		{"HealthCheckLog", numHealthChecks},
+		{"ContainerNote", numNotes},
+		{"NetworkReservation", len(content.reservations)},
+		{"Checkpoint", len(content.checkpoints)},
+		{"VolumeSnapshot", len(content.snapshots)},
+		{"ContainerGroup", len(content.groups)},
+		{"ImageProvenance", len(content.provenance)},
+		{"ImageDigestPin", len(content.digestPins)},
+	}
+	for _, e := range expected {
+		var count int
+		// The table names are constants, not user input.
+		if err := s.queryRow("SELECT COUNT(*) FROM " + e.table + ";").Scan(&count); err != nil {
+			return fmt.Errorf("counting rows of %s: %w", e.table, err)
+		}
+		if count != e.count {
+			return fmt.Errorf("migration verification failed: %s has %d entries, expected %d", e.table, count, e.count)
+		}
+	}
+	return nil
+}
+
+// export reads every object stored in the database.
+func (s *SQLiteState) export() (*dbContent, error) {
+	var (
+		content dbContent
+		err     error
+	)
+	if content.pods, err = s.AllPods(); err != nil {
+		return nil, err
+	}
+	if content.containers, err = s.AllContainers(true); err != nil {
+		return nil, err
+	}
+	if content.volumes, err = s.AllVolumes(); err != nil {
+		return nil, err
+	}
+	content.execSessions = make(map[string][]string)
+	content.networks = make(map[string]map[string]types.PerNetworkOptions)
+	content.healthCheckLogs = make(map[string][]define.HealthCheckHistoryEntry)
+	content.notes = make(map[string][]*define.ContainerNote)
+	for _, ctr := range content.containers {
+		sessions, err := s.GetContainerExecSessions(ctr)
+		if err != nil {
+			return nil, err
+		}
+		if len(sessions) > 0 {
+			content.execSessions[ctr.ID()] = sessions
+		}
+		networks, err := s.GetNetworks(ctr)
+		if err != nil {
+			return nil, err
+		}
+		if len(networks) > 0 {
+			content.networks[ctr.ID()] = networks
+		}
+		healthCheckLog, err := s.GetHealthCheckLog(ctr.ID())
+		if err != nil {
+			return nil, err
+		}
+		if len(healthCheckLog) > 0 {
+			content.healthCheckLogs[ctr.ID()] = healthCheckLog
+		}
+		notes, err := s.GetContainerNotes(ctr.ID())
+		if err != nil {
+			return nil, err
+		}
+		if len(notes) > 0 {
+			content.notes[ctr.ID()] = notes
+		}
+	}
+	if content.exitCodes, err = s.allContainerExitCodes(); err != nil {
+		return nil, err
+	}
+	if content.exitHistory, err = s.allContainerExitHistory(); err != nil {
+		return nil, err
+	}
+	if content.backupPolicies, err = s.VolumeBackupPolicies(); err != nil {
+		return nil, err
+	}
+	if content.backups, err = s.VolumeBackups(""); err != nil {
+		return nil, err
+	}
+	if content.metadata, err = s.allContainerMetadata(); err != nil {
+		return nil, err
+	}
+	if content.reservations, err = s.NetworkReservations(""); err != nil {
+		return nil, err
+	}
+	if content.checkpoints, err = s.Checkpoints(""); err != nil {
+		return nil, err
+	}
+	if content.snapshots, err = s.VolumeSnapshots(""); err != nil {
+		return nil, err
+	}
+	if content.groups, err = s.ContainerGroups(); err != nil {
+		return nil, err
+	}
+	if content.provenance, content.digestPins, err = s.allImageProvenance(); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// allContainerExitCodes returns all exit codes in the database with the time
+// they were added, keyed by container ID.
+func (s *SQLiteState) allContainerExitCodes() (map[string]containerExitCode, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ID, Timestamp, ExitCode FROM ContainerExitCode;")
+	if err != nil {
+		return nil, fmt.Errorf("querying container exit codes: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]containerExitCode)
+	for rows.Next() {
+		var (
+			id        string
+			timeStamp int64
+			exitCode  int32
+		)
+		if err := rows.Scan(&id, &timeStamp, &exitCode); err != nil {
+			return nil, fmt.Errorf("scanning container exit code row: %w", err)
+		}
+		result[id] = containerExitCode{ExitCode: exitCode, TimeStamp: time.Unix(timeStamp, 0)}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// allContainerExitHistory returns the exit histories of all containers in the
+// database, keyed by container ID.
+func (s *SQLiteState) allContainerExitHistory() (map[string][]define.ContainerExit, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ContainerID, Timestamp, ExitCode FROM ContainerExitHistory ORDER BY ID;")
+	if err != nil {
+		return nil, fmt.Errorf("querying container exit history: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]define.ContainerExit)
+	for rows.Next() {
+		var (
+			id        string
+			timeStamp int64
+			exitCode  int32
+		)
+		if err := rows.Scan(&id, &timeStamp, &exitCode); err != nil {
+			return nil, fmt.Errorf("scanning container exit history row: %w", err)
+		}
+		result[id] = append(result[id], define.ContainerExit{ExitCode: exitCode, ExitedAt: time.Unix(timeStamp, 0)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// allContainerMetadata returns the metadata of all containers in the
+// database, keyed by container ID.
+func (s *SQLiteState) allContainerMetadata() (map[string]map[string]string, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ContainerID, Key, Value FROM ContainerMetadata;")
+	if err != nil {
+		return nil, fmt.Errorf("querying container metadata: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]map[string]string)
+	for rows.Next() {
+		var id, key, value string
+		if err := rows.Scan(&id, &key, &value); err != nil {
+			return nil, fmt.Errorf("scanning container metadata row: %w", err)
+		}
+		if result[id] == nil {
+			result[id] = make(map[string]string)
+		}
+		result[id][key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// allImageProvenance returns all pulls of images, oldest first, and all
+// digest pins in the database.
+func (s *SQLiteState) allImageProvenance() ([]*define.ImageProvenance, []*define.ImageProvenance, error) {
+	if !s.valid {
+		return nil, nil, define.ErrDBClosed
+	}
+
+	records, err := s.queryImageProvenance("SELECT Source, ImageID, Digest, Timestamp FROM ImageProvenance ORDER BY ID;")
+	if err != nil {
+		return nil, nil, err
+	}
+	pins, err := s.queryImageProvenance("SELECT Source, ImageID, Digest, Timestamp FROM ImageDigestPin;")
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, pins, nil
+}
+
+// queryImageProvenance returns the pulls of images selected by query.
+func (s *SQLiteState) queryImageProvenance(query string) ([]*define.ImageProvenance, error) {
+	rows, err := s.query(query)
+	if err != nil {
+		return nil, fmt.Errorf("querying image provenance: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*define.ImageProvenance
+	for rows.Next() {
+		record := new(define.ImageProvenance)
+		var timestamp int64
+		if err := rows.Scan(&record.Source, &record.ImageID, &record.Digest, &timestamp); err != nil {
+			return nil, fmt.Errorf("scanning image provenance row: %w", err)
+		}
+		record.Pulled = time.Unix(timestamp, 0)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// importContent writes the given objects into an empty database.
+// Unlike SQLite, BoltDB has no transaction spanning several calls, so a
+// failed import leaves partial data behind and the database must be
+// discarded.
+func (s *BoltState) importContent(content *dbContent) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+	if len(content.backupPolicies) > 0 || len(content.backups) > 0 {
+		return fmt.Errorf("migrating volume backups to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.metadata) > 0 {
+		return fmt.Errorf("migrating container metadata to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.reservations) > 0 {
+		return fmt.Errorf("migrating network reservations to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.notes) > 0 {
+		return fmt.Errorf("migrating container notes to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.checkpoints) > 0 {
+		return fmt.Errorf("migrating checkpoint records to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.snapshots) > 0 {
+		return fmt.Errorf("migrating volume snapshots to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.groups) > 0 {
+		return fmt.Errorf("migrating container groups to the BoltDB backend: %w", define.ErrNotImplemented)
+	}
+	if len(content.healthCheckLogs) > 0 {
+		logrus.Warnf("Not migrating the healthcheck history of %d containers as it is not supported by the BoltDB backend", len(content.healthCheckLogs))
+	}
+
+	for _, vol := range content.volumes {
+		if err := s.AddVolume(vol); err != nil {
+			return fmt.Errorf("adding volume %s to database: %w", vol.Name(), err)
+		}
+	}
+
+	pods := make(map[string]*Pod, len(content.pods))
+	for _, pod := range content.pods {
+		if err := s.AddPod(pod); err != nil {
+			return fmt.Errorf("adding pod %s to database: %w", pod.ID(), err)
+		}
+		pods[pod.ID()] = pod
+	}
+
+	// BoltDB requires the dependencies of a container to exist already.
+	ctrs, err := sortContainersByDependency(content.containers)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		// The BoltDB state takes the networks of new containers from
+		// their configs.
+		if networks, ok := content.networks[ctr.ID()]; ok {
+			ctr.config.Networks = networks
+		}
+		if ctr.config.Pod != "" {
+			pod, ok := pods[ctr.config.Pod]
+			if !ok {
+				return fmt.Errorf("container %s references pod %s which does not exist: %w", ctr.ID(), ctr.config.Pod, define.ErrNoSuchPod)
+			}
+			err = s.AddContainerToPod(pod, ctr)
+		} else {
+			err = s.AddContainer(ctr)
+		}
+		if err != nil {
+			return fmt.Errorf("adding container %s to database: %w", ctr.ID(), err)
+		}
+		for _, session := range content.execSessions[ctr.ID()] {
+			if err := s.AddExecSession(ctr, execSessionFromState(ctr, session)); err != nil {
+				return fmt.Errorf("adding container %s exec session %s to database: %w", ctr.ID(), session, err)
+			}
+		}
+	}
+
+	for id, exitCode := range content.exitCodes {
+		if err := s.addContainerExitCode(id, exitCode.ExitCode, exitCode.TimeStamp); err != nil {
+			return fmt.Errorf("adding container %s exit code to database: %w", id, err)
+		}
+	}
+	// Adding the exit codes also added them to the exit history, which is
+	// replaced by the migrated one.
+	for id := range content.exitCodes {
+		if err := s.setContainerExitHistory(id, content.exitHistory[id]); err != nil {
+			return fmt.Errorf("adding container %s exit history to database: %w", id, err)
+		}
+	}
+	for id, exits := range content.exitHistory {
+		if _, ok := content.exitCodes[id]; ok {
+			continue
+		}
+		if err := s.setContainerExitHistory(id, exits); err != nil {
+			return fmt.Errorf("adding container %s exit history to database: %w", id, err)
+		}
+	}
+
+	for _, record := range content.provenance {
+		if err := s.AddImageProvenance(record); err != nil {
+			return fmt.Errorf("adding provenance of image %s to database: %w", record.ImageID, err)
+		}
+	}
+	for _, pin := range content.digestPins {
+		if err := s.SetImageDigestPin(pin); err != nil {
+			return fmt.Errorf("pinning digest of image source %s in database: %w", pin.Source, err)
+		}
+	}
+	return nil
+}
+
+// verifyContent checks that the database holds as many objects as content.
+func (s *BoltState) verifyContent(content *dbContent) error {
+	ctrs, err := s.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	pods, err := s.AllPods()
+	if err != nil {
+		return err
+	}
+	vols, err := s.AllVolumes()
+	if err != nil {
+		return err
+	}
+	numSessions, expectedSessions := 0, 0
+	for _, ctr := range ctrs {
+		sessions, err := s.GetContainerExecSessions(ctr)
+		if err != nil {
+			return err
+		}
+		numSessions += len(sessions)
+	}
+	for _, sessions := range content.execSessions {
+		expectedSessions += len(sessions)
+	}
+	exitCodes, err := s.allContainerExitCodes()
+	if err != nil {
+		return err
+	}
+	exitHistory, err := s.allContainerExitHistory()
+	if err != nil {
+		return err
+	}
+	numExits, expectedExits := 0, 0
+	for _, exits := range exitHistory {
+		numExits += len(exits)
+	}
+	for _, exits := range content.exitHistory {
+		expectedExits += len(exits)
+	}
+	provenance, digestPins, err := s.allImageProvenance()
+	if err != nil {
+		return err
+	}
+
+	expected := []struct {
+		kind          string
+		count, expect int
+	}{
+		{"containers", len(ctrs), len(content.containers)},
+		{"pods", len(pods), len(content.pods)},
+		{"volumes", len(vols), len(content.volumes)},
+		{"exec sessions", numSessions, expectedSessions},
+		{"exit codes", len(exitCodes), len(content.exitCodes)},
+		{"exit history entries", numExits, expectedExits},
+		{"image pulls", len(provenance), len(content.provenance)},
+		{"image digest pins", len(digestPins), len(content.digestPins)},
+	}
+	for _, e := range expected {
+		if e.count != e.expect {
+			return fmt.Errorf("migration verification failed: database has %d %s, expected %d", e.count, e.kind, e.expect)
+		}
+	}
+	return nil
+}
+
+// sortContainersByDependency orders ctrs so that every container comes after
+// the containers it depends on.
+func sortContainersByDependency(ctrs []*Container) ([]*Container, error) {
+	pending := make(map[string]*Container, len(ctrs))
+	for _, ctr := range ctrs {
+		pending[ctr.ID()] = ctr
+	}
+
+	sorted := make([]*Container, 0, len(ctrs))
+	for len(pending) > 0 {
+		progress := false
+		for _, ctr := range ctrs {
+			if _, ok := pending[ctr.ID()]; !ok {
+				continue
+			}
+			ready := true
+			for _, dep := range ctr.Dependencies() {
+				if _, ok := pending[dep]; ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, ctr)
+				delete(pending, ctr.ID())
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, fmt.Errorf("containers have circular dependencies: %w", define.ErrInternal)
+		}
+	}
+	return sorted, nil
+}
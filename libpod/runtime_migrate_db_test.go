@@ -0,0 +1,304 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateBoltToSqlite(t *testing.T) {
+	state, tmpDir, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	defer state.Close()
+	boltState := state.(*BoltState)
+
+	testPod, err := getTestPodN("3", manager)
+	require.NoError(t, err)
+	require.NoError(t, boltState.AddPod(testPod))
+
+	podCtr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	podCtr.config.Pod = testPod.ID()
+	require.NoError(t, boltState.AddContainerToPod(testPod, podCtr))
+
+	ctr, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr.config.NetMode = "bridge"
+	ctr.config.Networks = map[string]types.PerNetworkOptions{"podman": {InterfaceName: "eth0"}}
+	ctr.config.Labels = map[string]string{"app": "web", "tier": "frontend"}
+	require.NoError(t, boltState.AddContainer(ctr))
+	require.NoError(t, boltState.AddContainerExitCode(ctr.ID(), 1))
+	require.NoError(t, boltState.AddContainerExitCode(ctr.ID(), 42))
+	pulls := addTestImageProvenance(t, boltState)
+
+	content, err := boltState.export()
+	require.NoError(t, err)
+	assert.Len(t, content.containers, 2)
+	assert.Len(t, content.pods, 1)
+	assert.Contains(t, content.exitCodes, ctr.ID())
+
+	sqlState, err := newSqliteState(boltState.runtime, filepath.Join(tmpDir, "migrated.sql"))
+	require.NoError(t, err)
+	defer sqlState.Close()
+
+	require.NoError(t, sqlState.importContent(content))
+	require.NoError(t, sqlState.verifyContent(content))
+
+	retrievedCtr, err := sqlState.Container(ctr.ID())
+	require.NoError(t, err)
+	testContainersEqual(t, retrievedCtr, ctr, true)
+	networks, err := sqlState.GetNetworks(retrievedCtr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]types.PerNetworkOptions{"podman": {InterfaceName: "eth0"}}, networks)
+
+	// Label filters are answered from the ContainerLabel table.
+	labeled, err := sqlState.AllContainersFiltered(false, &StateContainerFilters{Labels: []string{"app=web"}})
+	require.NoError(t, err)
+	require.Len(t, labeled, 1)
+	assert.Equal(t, ctr.ID(), labeled[0].ID())
+
+	retrievedPod, err := sqlState.Pod(testPod.ID())
+	require.NoError(t, err)
+	testPodsEqual(t, retrievedPod, testPod, true)
+
+	podCtrs, err := sqlState.PodContainersByID(testPod)
+	require.NoError(t, err)
+	assert.Equal(t, []string{podCtr.ID()}, podCtrs)
+
+	exitCode, err := sqlState.GetContainerExitCode(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), exitCode)
+	history, err := sqlState.ContainerExitHistory(ctr.ID())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, int32(1), history[0].ExitCode)
+	assert.Equal(t, int32(42), history[1].ExitCode)
+	checkTestImageProvenance(t, sqlState, pulls)
+
+	// Importing twice must fail without leaving partial data behind.
+	assert.Error(t, sqlState.importContent(content))
+	require.NoError(t, sqlState.verifyContent(content))
+}
+
+// addTestImageProvenance records pulls of two images from the same source and
+// pins the digest of the source to the first pull.
+func addTestImageProvenance(t *testing.T, state State) []*define.ImageProvenance {
+	pulls := []*define.ImageProvenance{
+		{Source: "quay.io/libpod/alpine:latest", ImageID: "image1", Digest: "sha256:1111", Pulled: time.Unix(1000, 0)},
+		{Source: "quay.io/libpod/alpine:latest", ImageID: "image2", Digest: "sha256:2222", Pulled: time.Unix(2000, 0)},
+	}
+	for _, pull := range pulls {
+		require.NoError(t, state.AddImageProvenance(pull))
+	}
+	require.NoError(t, state.SetImageDigestPin(pulls[0]))
+	return pulls
+}
+
+// checkTestImageProvenance checks that the pulls and the pin added by
+// addTestImageProvenance were migrated.
+func checkTestImageProvenance(t *testing.T, state State, pulls []*define.ImageProvenance) {
+	assertPull := func(expected, actual *define.ImageProvenance) {
+		assert.Equal(t, expected.Source, actual.Source)
+		assert.Equal(t, expected.ImageID, actual.ImageID)
+		assert.Equal(t, expected.Digest, actual.Digest)
+		assert.True(t, expected.Pulled.Equal(actual.Pulled))
+	}
+	records, err := state.ImageProvenance("image2")
+	require.NoError(t, err)
+	require.Len(t, records, len(pulls))
+	for i, record := range records {
+		assertPull(pulls[i], record)
+	}
+	pin, err := state.ImageDigestPin("quay.io/libpod/alpine:latest")
+	require.NoError(t, err)
+	assertPull(pulls[0], pin)
+}
+
+func TestExportSqliteToBolt(t *testing.T) {
+	state, tmpDir, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	defer state.Close()
+	runtime := state.(*BoltState).runtime
+
+	sqlState, err := newSqliteState(runtime, filepath.Join(tmpDir, "source.sql"))
+	require.NoError(t, err)
+	defer sqlState.Close()
+
+	testPod, err := getTestPodN("3", manager)
+	require.NoError(t, err)
+	require.NoError(t, sqlState.AddPod(testPod))
+
+	podCtr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	podCtr.config.Pod = testPod.ID()
+	require.NoError(t, sqlState.AddContainerToPod(testPod, podCtr))
+
+	depCtr, err := getTestCtrN("4", manager)
+	require.NoError(t, err)
+	require.NoError(t, sqlState.AddContainer(depCtr))
+
+	ctr, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr.config.Dependencies = []string{depCtr.ID()}
+	require.NoError(t, sqlState.AddContainer(ctr))
+	require.NoError(t, sqlState.AddExecSession(ctr, &ExecSession{Id: "session1"}))
+	require.NoError(t, sqlState.AddContainerExitCode(ctr.ID(), 1))
+	require.NoError(t, sqlState.AddContainerExitCode(ctr.ID(), 42))
+	timeStamp, err := sqlState.GetContainerExitCodeTimeStamp(ctr.ID())
+	require.NoError(t, err)
+	pulls := addTestImageProvenance(t, sqlState)
+
+	require.NoError(t, sqlState.ExportTo(state))
+
+	retrievedCtr, err := state.Container(ctr.ID())
+	require.NoError(t, err)
+	testContainersEqual(t, retrievedCtr, ctr, true)
+
+	retrievedPod, err := state.Pod(testPod.ID())
+	require.NoError(t, err)
+	testPodsEqual(t, retrievedPod, testPod, true)
+
+	sessions, err := state.GetContainerExecSessions(ctr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"session1"}, sessions)
+
+	exitCode, err := state.GetContainerExitCode(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), exitCode)
+	retrievedTimeStamp, err := state.(*BoltState).GetContainerExitCodeTimeStamp(ctr.ID())
+	require.NoError(t, err)
+	assert.True(t, timeStamp.Equal(*retrievedTimeStamp))
+	history, err := state.ContainerExitHistory(ctr.ID())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, int32(1), history[0].ExitCode)
+	assert.Equal(t, int32(42), history[1].ExitCode)
+	checkTestImageProvenance(t, state, pulls)
+
+	// The destination is not empty anymore.
+	assert.Error(t, sqlState.ExportTo(state))
+}
+
+func TestExportSqliteVolumeBackupsAndMetadata(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+	tmpDir := t.TempDir()
+
+	source, err := newSqliteState(runtime, filepath.Join(tmpDir, "source.sql"))
+	require.NoError(t, err)
+	defer source.Close()
+
+	vol := &Volume{config: &VolumeConfig{Name: "vol1"}, state: &VolumeState{}, valid: true}
+	require.NoError(t, source.AddVolume(vol))
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, source.AddContainer(ctr))
+	require.NoError(t, source.SetContainerMetadata(ctr.ID(), "owner", "agent"))
+	hcEntry := &define.HealthCheckHistoryEntry{HealthCheckLog: define.HealthCheckLog{Start: "2024-01-01T00:00:00Z", Output: "ok"}, Status: define.HealthCheckHealthy}
+	require.NoError(t, source.AddHealthCheckLog(ctr.ID(), hcEntry, 0))
+	policy := &define.VolumeBackupPolicy{Volume: "vol1", Schedule: define.VolumeBackupWeekly, Keep: 2, Target: "/backups"}
+	require.NoError(t, source.SetVolumeBackupPolicy(policy))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, source.AddVolumeBackup(&define.VolumeBackup{Volume: "vol1", Target: "/backups", Archive: fmt.Sprintf("/backups/vol1-%d.tar.zst", i)}))
+	}
+	backups, err := source.VolumeBackups("")
+	require.NoError(t, err)
+	require.NoError(t, source.RemoveVolumeBackup(backups[0].ID))
+	require.NoError(t, source.AddNetworkReservation(&define.NetworkReservation{Network: "podman", Address: "10.88.0.50", Container: "web"}))
+	note := &define.ContainerNote{Text: "rotated certs"}
+	require.NoError(t, source.AddContainerNote(ctr.ID(), note))
+	preCheckpoint := &define.CheckpointRecord{ContainerID: ctr.ID(), Path: "/checkpoints/pre.tar", PreCheckpoint: true}
+	require.NoError(t, source.AddCheckpoint(preCheckpoint))
+	require.NoError(t, source.AddCheckpoint(&define.CheckpointRecord{ContainerID: ctr.ID(), Path: "/checkpoints/ctr.tar.zst", Compression: "zstd", ParentID: preCheckpoint.ID}))
+	snapshot := &define.VolumeSnapshot{Volume: "vol1", Name: "before-upgrade", Method: define.VolumeSnapshotReflink}
+	require.NoError(t, source.AddVolumeSnapshot(snapshot))
+	group := &define.ContainerGroup{Name: "web", Filters: map[string][]string{"label": {"app=web"}}}
+	require.NoError(t, source.AddContainerGroup(group))
+
+	dest, err := newSqliteState(runtime, filepath.Join(tmpDir, "dest.sql"))
+	require.NoError(t, err)
+	defer dest.Close()
+	require.NoError(t, source.ExportTo(dest))
+
+	policies, err := dest.VolumeBackupPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, define.VolumeBackupWeekly, policies[0].Schedule)
+
+	// The IDs of the backups are kept.
+	retrieved, err := dest.VolumeBackups("vol1")
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, backups[1].ID, retrieved[0].ID)
+	assert.Equal(t, backups[2].Archive, retrieved[1].Archive)
+
+	metadata, err := dest.GetContainerMetadata(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "agent"}, metadata)
+
+	reservations, err := dest.NetworkReservations("")
+	require.NoError(t, err)
+	require.Len(t, reservations, 1)
+	assert.Equal(t, "web", reservations[0].Container)
+
+	notes, err := dest.GetContainerNotes(ctr.ID())
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, note.ID, notes[0].ID)
+	assert.Equal(t, "rotated certs", notes[0].Text)
+
+	checkpoints, err := dest.Checkpoints(ctr.ID())
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, preCheckpoint.ID, checkpoints[1].ParentID)
+	assert.Equal(t, "zstd", checkpoints[1].Compression)
+
+	snapshots, err := dest.VolumeSnapshots("vol1")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, snapshot.ID, snapshots[0].ID)
+	assert.Equal(t, "before-upgrade", snapshots[0].Name)
+
+	groups, err := dest.ContainerGroups()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, group.Filters, groups[0].Filters)
+
+	healthCheckLog, err := dest.GetHealthCheckLog(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []define.HealthCheckHistoryEntry{*hcEntry}, healthCheckLog)
+
+	// Volume backups and container metadata cannot be migrated to BoltDB.
+	boltState, boltDir, _, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(boltDir)
+	defer boltState.Close()
+	assert.ErrorIs(t, source.ExportTo(boltState), define.ErrNotImplemented)
+
+	// Neither can container groups.
+	groupSource, err := newSqliteState(runtime, filepath.Join(tmpDir, "groups.sql"))
+	require.NoError(t, err)
+	defer groupSource.Close()
+	require.NoError(t, groupSource.AddContainerGroup(group))
+	err = groupSource.ExportTo(boltState)
+	assert.ErrorIs(t, err, define.ErrNotImplemented)
+	assert.ErrorContains(t, err, "migrating container groups to the BoltDB backend")
+}
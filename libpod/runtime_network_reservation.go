@@ -0,0 +1,298 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// ReserveNetworkAddress reserves an IP or MAC address of a network for the
+// container or pod with the given name, which does not need to exist yet.
+// The container is then always given the reserved addresses on the network,
+// and no other container is given them. The network of an IP address is
+// inferred from its subnet if none is given, MAC addresses require a network.
+func (r *Runtime) ReserveNetworkAddress(network, address, container string) (*define.NetworkReservation, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if container == "" {
+		return nil, fmt.Errorf("the container to reserve %s for must be given: %w", address, define.ErrInvalidArg)
+	}
+
+	ip := net.ParseIP(address)
+	var mac net.HardwareAddr
+	if ip == nil {
+		var err error
+		if mac, err = net.ParseMAC(address); err != nil {
+			return nil, fmt.Errorf("%q is neither an IP nor a MAC address: %w", address, define.ErrInvalidArg)
+		}
+		if network == "" {
+			return nil, fmt.Errorf("the network of MAC address %s must be given: %w", address, define.ErrInvalidArg)
+		}
+	}
+
+	var netInfo types.Network
+	if network == "" {
+		var err error
+		if netInfo, err = r.networkOfIP(ip); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if netInfo, err = r.network.NetworkInspect(network); err != nil {
+			return nil, err
+		}
+	}
+
+	reservation := &define.NetworkReservation{
+		Network:   netInfo.Name,
+		Container: container,
+		Created:   time.Now(),
+	}
+	if ip != nil {
+		if err := checkReservableIP(&netInfo, ip); err != nil {
+			return nil, err
+		}
+		reservation.Address = ip.String()
+	} else {
+		reservation.Address = mac.String()
+		// A container has a single interface on a network.
+		reservations, err := r.state.NetworkReservations(netInfo.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range reservations {
+			if res.Container == container && net.ParseIP(res.Address) == nil {
+				return nil, fmt.Errorf("MAC address %s on network %s is already reserved for %s: %w", res.Address, netInfo.Name, container, define.ErrNetworkReserved)
+			}
+		}
+	}
+
+	if err := r.checkAddressNotInUse(reservation); err != nil {
+		return nil, err
+	}
+	if err := r.state.AddNetworkReservation(reservation); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// UnreserveNetworkAddress removes the reservation of the given address. The
+// network of an IP address can be omitted if it is only reserved on one
+// network.
+func (r *Runtime) UnreserveNetworkAddress(network, address string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	if ip := net.ParseIP(address); ip != nil {
+		address = ip.String()
+	} else if mac, err := net.ParseMAC(address); err == nil {
+		address = mac.String()
+	}
+
+	if network != "" {
+		netInfo, err := r.network.NetworkInspect(network)
+		if err != nil {
+			// The network may have been removed without
+			// removing its reservations.
+			if !errors.Is(err, define.ErrNoSuchNetwork) {
+				return err
+			}
+		} else {
+			network = netInfo.Name
+		}
+		return r.state.RemoveNetworkReservation(network, address)
+	}
+
+	reservations, err := r.state.NetworkReservations("")
+	if err != nil {
+		return err
+	}
+	var found []*define.NetworkReservation
+	for _, res := range reservations {
+		if res.Address == address {
+			found = append(found, res)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("address %s: %w", address, define.ErrNoSuchNetworkReservation)
+	case 1:
+		return r.state.RemoveNetworkReservation(found[0].Network, address)
+	default:
+		return fmt.Errorf("address %s is reserved on %d networks, the network must be given: %w", address, len(found), define.ErrInvalidArg)
+	}
+}
+
+// NetworkReservations returns the reservations of the given network, or of
+// all networks if the name is empty.
+func (r *Runtime) NetworkReservations(network string) ([]*define.NetworkReservation, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.NetworkReservations(network)
+}
+
+// RemoveNetworkReservations removes all reservations of the network with the
+// given name, e.g. after the network was removed.
+func (r *Runtime) RemoveNetworkReservations(network string) error {
+	reservations, err := r.NetworkReservations(network)
+	if err != nil || len(reservations) == 0 {
+		return err
+	}
+	return r.state.RemoveNetworkReservation(network, "")
+}
+
+// networkOfIP returns the network with a subnet containing the given IP.
+func (r *Runtime) networkOfIP(ip net.IP) (types.Network, error) {
+	networks, err := r.network.NetworkList()
+	if err != nil {
+		return types.Network{}, err
+	}
+	var found []types.Network
+	for _, network := range networks {
+		for _, subnet := range network.Subnets {
+			if subnet.Subnet.Contains(ip) {
+				found = append(found, network)
+				break
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return types.Network{}, fmt.Errorf("no network has a subnet containing %s: %w", ip, define.ErrNoSuchNetwork)
+	case 1:
+		return found[0], nil
+	default:
+		return types.Network{}, fmt.Errorf("%d networks have a subnet containing %s, the network must be given: %w", len(found), ip, define.ErrInvalidArg)
+	}
+}
+
+// checkReservableIP checks that the IP can be assigned to containers by the
+// network.
+func checkReservableIP(network *types.Network, ip net.IP) error {
+	if driver := network.IPAMOptions[types.Driver]; driver != "" && driver != types.HostLocalIPAMDriver {
+		return fmt.Errorf("network %s uses the %s IPAM driver, addresses can only be reserved with the %s driver: %w", network.Name, driver, types.HostLocalIPAMDriver, define.ErrInvalidArg)
+	}
+	for _, subnet := range network.Subnets {
+		if !subnet.Subnet.Contains(ip) {
+			continue
+		}
+		if ip.Equal(subnet.Gateway) {
+			return fmt.Errorf("%s is the gateway of network %s: %w", ip, network.Name, define.ErrInvalidArg)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s is not in a subnet of network %s: %w", ip, network.Name, define.ErrInvalidArg)
+}
+
+// checkAddressNotInUse checks that the reserved address is not assigned to a
+// container other than the one it is reserved for.
+func (r *Runtime) checkAddressNotInUse(reservation *define.NetworkReservation) error {
+	ctrs, err := r.state.NetworkContainers(reservation.Network)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		status, err := ctr.GetNetworkStatus()
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return err
+		}
+		owner := getNetworkPodName(ctr)
+		if owner == reservation.Container {
+			continue
+		}
+		block, ok := status[reservation.Network]
+		if !ok {
+			continue
+		}
+		if statusHasAddress(block, reservation.Address) {
+			return fmt.Errorf("address %s on network %s is in use by %s: %w", reservation.Address, reservation.Network, owner, define.ErrInvalidArg)
+		}
+	}
+	return nil
+}
+
+// statusHasAddress returns true if an interface of the status block has the
+// given IP or MAC address.
+func statusHasAddress(block types.StatusBlock, address string) bool {
+	for _, netInt := range block.Interfaces {
+		if netInt.MacAddress.String() == address {
+			return true
+		}
+		for _, subnet := range netInt.Subnets {
+			if subnet.IPNet.IP.String() == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyNetworkReservations gives the container the addresses reserved for it
+// on each of its networks, unless it requested static addresses. It fails if
+// the container requested an address reserved for another container.
+func applyNetworkReservations(opts types.NetworkOptions, reservations []*define.NetworkReservation) (types.NetworkOptions, error) {
+	networks := make(map[string]types.PerNetworkOptions, len(opts.Networks))
+	for name, netOpts := range opts.Networks {
+		var (
+			ips []net.IP
+			mac net.HardwareAddr
+		)
+		for _, res := range reservations {
+			if res.Network != name {
+				continue
+			}
+			if res.Container == opts.ContainerName {
+				if ip := net.ParseIP(res.Address); ip != nil {
+					ips = append(ips, ip)
+				} else if hw, err := net.ParseMAC(res.Address); err == nil {
+					mac = hw
+				}
+				continue
+			}
+			for _, ip := range netOpts.StaticIPs {
+				if ip.String() == res.Address {
+					return opts, fmt.Errorf("address %s on network %s is reserved for %s: %w", res.Address, name, res.Container, define.ErrNetworkReserved)
+				}
+			}
+			if len(netOpts.StaticMAC) > 0 && netOpts.StaticMAC.String() == res.Address {
+				return opts, fmt.Errorf("address %s on network %s is reserved for %s: %w", res.Address, name, res.Container, define.ErrNetworkReserved)
+			}
+		}
+		if len(netOpts.StaticIPs) == 0 {
+			netOpts.StaticIPs = ips
+		}
+		if len(netOpts.StaticMAC) == 0 {
+			netOpts.StaticMAC = types.HardwareAddr(mac)
+		}
+		networks[name] = netOpts
+	}
+	opts.Networks = networks
+	return opts, nil
+}
+
+// reservedForOthers returns the reservation of an address the network backend
+// assigned to the container although it is reserved for another container, or
+// nil if there is none.
+func reservedForOthers(status map[string]types.StatusBlock, container string, reservations []*define.NetworkReservation) *define.NetworkReservation {
+	for _, res := range reservations {
+		if res.Container == container {
+			continue
+		}
+		if block, ok := status[res.Network]; ok && statusHasAddress(block, res.Address) {
+			return res
+		}
+	}
+	return nil
+}
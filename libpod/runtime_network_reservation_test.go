@@ -0,0 +1,58 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyNetworkReservations(t *testing.T) {
+	reservations := []*define.NetworkReservation{
+		{Network: "net1", Address: "10.89.0.50", Container: "web"},
+		{Network: "net1", Address: "92:d0:c6:0a:29:33", Container: "web"},
+		{Network: "net1", Address: "10.89.0.51", Container: "db"},
+		{Network: "net2", Address: "10.90.0.50", Container: "web"},
+	}
+
+	// The owner is given its reserved addresses on its networks.
+	opts := types.NetworkOptions{
+		ContainerName: "web",
+		Networks:      map[string]types.PerNetworkOptions{"net1": {InterfaceName: "eth0"}},
+	}
+	applied, err := applyNetworkReservations(opts, reservations)
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{net.ParseIP("10.89.0.50")}, applied.Networks["net1"].StaticIPs)
+	assert.Equal(t, "92:d0:c6:0a:29:33", net.HardwareAddr(applied.Networks["net1"].StaticMAC).String())
+	assert.Len(t, applied.Networks, 1)
+	// The options of the caller are not modified.
+	assert.Empty(t, opts.Networks["net1"].StaticIPs)
+
+	// Static addresses requested by the owner are kept.
+	opts.Networks = map[string]types.PerNetworkOptions{"net1": {StaticIPs: []net.IP{net.ParseIP("10.89.0.60")}}}
+	applied, err = applyNetworkReservations(opts, reservations)
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{net.ParseIP("10.89.0.60")}, applied.Networks["net1"].StaticIPs)
+
+	// Other containers cannot request a reserved address.
+	opts.ContainerName = "other"
+	opts.Networks = map[string]types.PerNetworkOptions{"net1": {StaticIPs: []net.IP{net.ParseIP("10.89.0.51")}}}
+	_, err = applyNetworkReservations(opts, reservations)
+	assert.ErrorIs(t, err, define.ErrNetworkReserved)
+
+	// A reserved address assigned by the IPAM is detected.
+	status := map[string]types.StatusBlock{
+		"net1": {Interfaces: map[string]types.NetInterface{"eth0": {
+			Subnets: []types.NetAddress{{IPNet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP("10.89.0.51"), Mask: net.CIDRMask(24, 32)}}}},
+		}}},
+	}
+	res := reservedForOthers(status, "other", reservations)
+	require.NotNil(t, res)
+	assert.Equal(t, "db", res.Container)
+	assert.Nil(t, reservedForOthers(status, "db", reservations))
+}
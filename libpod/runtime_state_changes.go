@@ -0,0 +1,62 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// StateChanges returns the changes of containers, pods and volumes made by
+// any process with an ID greater than afterID, oldest first.
+func (r *Runtime) StateChanges(afterID int64) ([]*define.StateChange, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.StateChanges(afterID)
+}
+
+// WatchStateChanges sends the changes of containers, pods and volumes made by
+// any process with an ID greater than afterID to ch, polling the database
+// every interval, until ctx is done. A negative afterID only sends the changes
+// made after the call.
+// Long-running processes such as the system service use it to learn about the
+// changes made by other podman processes.
+func (r *Runtime) WatchStateChanges(ctx context.Context, afterID int64, interval time.Duration, ch chan<- *define.StateChange) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	if afterID < 0 {
+		latest, err := r.state.LatestStateChange()
+		if err != nil {
+			return err
+		}
+		afterID = latest
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		changes, err := r.state.StateChanges(afterID)
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			select {
+			case ch <- change:
+				afterID = change.ID
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
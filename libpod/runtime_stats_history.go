@@ -0,0 +1,64 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// StatsHistory returns the resource usage samples of the container recorded
+// by the stats collector of the system service after since, oldest first.
+func (c *Container) StatsHistory(since time.Time) ([]*define.ContainerStats, error) {
+	return c.runtime.state.ContainerStatsHistory(c.ID(), since)
+}
+
+// RecordContainerStats samples the resource usage of every running container
+// and records it in the database, keeping the maxSamples most recent samples
+// of each container. previous holds the last sample of every container, which
+// is needed to compute CPU percentages, and is updated in place.
+func (r *Runtime) RecordContainerStats(previous map[string]*define.ContainerStats, maxSamples uint) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	ctrs, err := r.GetRunningContainers()
+	if err != nil {
+		return fmt.Errorf("listing running containers: %w", err)
+	}
+
+	running := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		stats, err := ctr.GetContainerStats(previous[ctr.ID()])
+		if err != nil {
+			// The container stopped or was removed since it was listed,
+			// or it has no cgroup to sample.
+			if errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr) ||
+				errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrCtrStopped) ||
+				errors.Is(err, define.ErrNoCgroups) {
+				continue
+			}
+			return fmt.Errorf("getting stats of container %s: %w", ctr.ID(), err)
+		}
+		if err := r.state.AddContainerStats(ctr.ID(), stats, maxSamples); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return err
+		}
+		previous[ctr.ID()] = stats
+		running[ctr.ID()] = true
+	}
+
+	// Forget the containers that are no longer running, a restarted
+	// container starts over from its start time.
+	for id := range previous {
+		if !running[id] {
+			delete(previous, id)
+		}
+	}
+	return nil
+}
@@ -4,9 +4,13 @@ package libpod
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/pkg/containersconf"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_generateName(t *testing.T) {
@@ -25,3 +29,63 @@ func Test_generateName(t *testing.T) {
 	n2, _ := r.generateName()
 	assert.NotEqual(t, n1, n2)
 }
+
+func TestImageProvenanceSource(t *testing.T) {
+	names := []string{"quay.io/libpod/alpine:latest", "docker.io/library/alpine:3.19"}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"quay.io/libpod/alpine:latest", "quay.io/libpod/alpine:latest"},
+		{"docker-archive:/tmp/alpine.tar", "docker-archive:/tmp/alpine.tar"},
+		{"alpine", "quay.io/libpod/alpine:latest"},
+		{"alpine:3.19", "docker.io/library/alpine:3.19"},
+		{"library/alpine:3.19", "docker.io/library/alpine:3.19"},
+		{"busybox", "busybox"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, imageProvenanceSource(tt.name, names), tt.name)
+	}
+}
+
+func TestImageDigestPinSource(t *testing.T) {
+	names := []string{"quay.io/libpod/alpine:latest"}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"quay.io/libpod/alpine:latest", "quay.io/libpod/alpine:latest"},
+		{"quay.io/libpod/alpine", "quay.io/libpod/alpine:latest"},
+		{"docker://quay.io/libpod/alpine:3.19", "quay.io/libpod/alpine:3.19"},
+		{"alpine", "quay.io/libpod/alpine:latest"},
+		{"quay.io/libpod/alpine@sha256:" + strings.Repeat("a", 64), ""},
+		{"docker-archive:/tmp/alpine.tar", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, imageDigestPinSource(tt.name, names), tt.name)
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	cfg, err := config.New(nil)
+	require.NoError(t, err)
+	cfg.Engine.StopTimeout = 42
+	cfg.Engine.ActiveService = "remote"
+	cfg.Engine.ServiceDestinations = map[string]config.Destination{"remote": {URI: "ssh://host/run/podman/podman.sock"}}
+	r := &Runtime{config: cfg, podmanConf: *containersconf.Default()}
+	r.podmanConf.Engine.ImageDigestPinning = "warn"
+
+	effective, err := r.effectiveConfig()
+	require.NoError(t, err)
+	assert.Len(t, effective, 3)
+	engine := effective["engine"].(map[string]any)
+	assert.Equal(t, int64(42), engine["stop_timeout"])
+	assert.NotContains(t, engine, "active_service")
+	assert.NotContains(t, engine, "service_destinations")
+	assert.Equal(t, "warn", engine["image_digest_pinning"], "the Podman specific settings are recorded")
+	assert.Equal(t, int64(cfg.Containers.PidsLimit), effective["containers"].(map[string]any)["pids_limit"])
+
+	// The runtime configuration is not changed.
+	assert.Equal(t, "remote", cfg.Engine.ActiveService)
+	assert.Len(t, cfg.Engine.ServiceDestinations, 1)
+}
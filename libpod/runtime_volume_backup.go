@@ -0,0 +1,466 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/containers/common/pkg/ssh"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/chrootarchive"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// volumeBackupTimeFormat is used in the names of backup archives, so they
+// sort by creation time.
+const volumeBackupTimeFormat = "20060102T150405.000Z"
+
+// EnableVolumeBackup validates the given policy and stores it, replacing the
+// earlier policy of the volume. The volume is then backed up by
+// RunDueVolumeBackups according to the schedule of the policy.
+func (r *Runtime) EnableVolumeBackup(policy *define.VolumeBackupPolicy) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	if _, err := r.state.Volume(policy.Volume); err != nil {
+		return err
+	}
+	if _, err := define.ParseVolumeBackupSchedule(string(policy.Schedule)); err != nil {
+		return err
+	}
+	if policy.Keep < 1 {
+		return fmt.Errorf("the number of kept backups must be at least 1: %w", define.ErrInvalidArg)
+	}
+	u, err := define.ParseVolumeBackupTarget(policy.Target)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		if err := os.MkdirAll(policy.Target, 0o700); err != nil {
+			return fmt.Errorf("creating volume backup target: %w", err)
+		}
+	}
+
+	policy.Created = time.Now()
+	return r.state.SetVolumeBackupPolicy(policy)
+}
+
+// DisableVolumeBackup removes the backup policy of the given volume. Existing
+// backups are kept.
+func (r *Runtime) DisableVolumeBackup(name string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.state.RemoveVolumeBackupPolicy(name)
+}
+
+// VolumeBackupPolicies returns the backup policies of all volumes.
+func (r *Runtime) VolumeBackupPolicies() ([]*define.VolumeBackupPolicy, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.VolumeBackupPolicies()
+}
+
+// VolumeBackupPolicy returns the backup policy of the given volume.
+func (r *Runtime) VolumeBackupPolicy(name string) (*define.VolumeBackupPolicy, error) {
+	policies, err := r.VolumeBackupPolicies()
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if policy.Volume == name {
+			return policy, nil
+		}
+	}
+	return nil, fmt.Errorf("backup policy of volume %s: %w", name, define.ErrNoSuchVolumeBackup)
+}
+
+// VolumeBackups returns the backups of the given volume, or of all volumes if
+// the name is empty, oldest first.
+func (r *Runtime) VolumeBackups(name string) ([]*define.VolumeBackup, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.VolumeBackups(name)
+}
+
+// RunDueVolumeBackups backs up every volume whose last backup is older than
+// the interval of its schedule. Failed backups are logged and retried the
+// next time.
+func (r *Runtime) RunDueVolumeBackups() {
+	policies, err := r.VolumeBackupPolicies()
+	if err != nil {
+		logrus.Errorf("Retrieving volume backup policies: %v", err)
+		return
+	}
+	for _, policy := range policies {
+		due, err := r.volumeBackupDue(policy)
+		if err != nil {
+			logrus.Errorf("Checking backups of volume %s: %v", policy.Volume, err)
+			continue
+		}
+		if !due {
+			continue
+		}
+		vol, err := r.state.Volume(policy.Volume)
+		if err != nil {
+			logrus.Errorf("Backing up volume %s: %v", policy.Volume, err)
+			continue
+		}
+		if _, err := r.BackupVolume(vol, policy); err != nil {
+			logrus.Errorf("Backing up volume %s: %v", policy.Volume, err)
+		}
+	}
+}
+
+// volumeBackupDue returns whether the volume of the given policy has no
+// backup on the target of the policy younger than the schedule interval.
+func (r *Runtime) volumeBackupDue(policy *define.VolumeBackupPolicy) (bool, error) {
+	backups, err := r.state.VolumeBackups(policy.Volume)
+	if err != nil {
+		return false, err
+	}
+	for i := len(backups) - 1; i >= 0; i-- {
+		if backups[i].Target == policy.Target {
+			return !time.Now().Before(backups[i].Created.Add(policy.Schedule.Interval())), nil
+		}
+	}
+	return true, nil
+}
+
+// BackupVolume writes a zstd compressed tar archive of the given volume to the
+// target of the policy, records it and removes the oldest backups on the
+// target beyond the number of backups kept by the policy.
+func (r *Runtime) BackupVolume(vol *Volume, policy *define.VolumeBackupPolicy) (*define.VolumeBackup, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	u, err := define.ParseVolumeBackupTarget(policy.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	created := time.Now()
+	name := fmt.Sprintf("%s-%s.tar.zst", vol.Name(), created.UTC().Format(volumeBackupTimeFormat))
+	dir := policy.Target
+	if u != nil {
+		dir = util.Tmpdir()
+	}
+	tmpFile, err := os.CreateTemp(dir, "."+name+"-")
+	if err != nil {
+		return nil, fmt.Errorf("creating backup archive of volume %s: %w", vol.Name(), err)
+	}
+	defer func() {
+		tmpFile.Close()
+		if err := os.Remove(tmpFile.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Errorf("Removing temporary backup archive %s: %v", tmpFile.Name(), err)
+		}
+	}()
+
+	backup := &define.VolumeBackup{
+		Volume:  vol.Name(),
+		Target:  policy.Target,
+		Created: created,
+	}
+	backup.Digest, backup.Size, err = writeVolumeArchive(vol, tmpFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, err
+	}
+
+	if u == nil {
+		backup.Archive = filepath.Join(policy.Target, name)
+		if err := os.Rename(tmpFile.Name(), backup.Archive); err != nil {
+			return nil, fmt.Errorf("writing backup archive of volume %s: %w", vol.Name(), err)
+		}
+	} else {
+		backup.Archive = path.Join(u.Path, name)
+		opts, err := volumeBackupScpOptions(u, policy.Identity)
+		if err != nil {
+			return nil, err
+		}
+		opts.Source = tmpFile.Name()
+		opts.Destination = volumeBackupSSHPath(u, backup.Archive)
+		if _, err := ssh.Scp(opts, ssh.GolangMode); err != nil {
+			return nil, fmt.Errorf("copying backup archive of volume %s to %s: %w", vol.Name(), u.Host, err)
+		}
+	}
+
+	if err := r.state.AddVolumeBackup(backup); err != nil {
+		return nil, err
+	}
+	logrus.Debugf("Backed up volume %s to %s", vol.Name(), backup.Archive)
+
+	if err := r.pruneVolumeBackups(policy); err != nil {
+		logrus.Errorf("Removing old backups of volume %s: %v", vol.Name(), err)
+	}
+	return backup, nil
+}
+
+// writeVolumeArchive writes a zstd compressed tar archive of the contents of
+// the given volume and returns its digest and size.
+func writeVolumeArchive(vol *Volume, out io.Writer) (string, int64, error) {
+	mountPoint, err := vol.Mount()
+	if err != nil {
+		return "", 0, fmt.Errorf("mounting volume %s: %w", vol.Name(), err)
+	}
+	defer func() {
+		if err := vol.Unmount(); err != nil {
+			logrus.Errorf("Unmounting volume %s: %v", vol.Name(), err)
+		}
+	}()
+
+	input, err := chrootarchive.Tar(mountPoint, &archive.TarOptions{Compression: archive.Zstd}, mountPoint)
+	if err != nil {
+		return "", 0, fmt.Errorf("reading volume %s: %w", vol.Name(), err)
+	}
+	defer input.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(out, digester.Hash()), input)
+	if err != nil {
+		return "", 0, fmt.Errorf("writing backup archive of volume %s: %w", vol.Name(), err)
+	}
+	return digester.Digest().String(), size, nil
+}
+
+// pruneVolumeBackups removes the oldest backups on the target of the given
+// policy until only the number of backups kept by the policy is left.
+func (r *Runtime) pruneVolumeBackups(policy *define.VolumeBackupPolicy) error {
+	backups, err := r.state.VolumeBackups(policy.Volume)
+	if err != nil {
+		return err
+	}
+	var onTarget []*define.VolumeBackup
+	for _, backup := range backups {
+		if backup.Target == policy.Target {
+			onTarget = append(onTarget, backup)
+		}
+	}
+	if len(onTarget) <= policy.Keep {
+		return nil
+	}
+
+	var errs []error
+	for _, backup := range onTarget[:len(onTarget)-policy.Keep] {
+		if err := removeVolumeBackupArchive(backup, policy.Identity); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := r.state.RemoveVolumeBackup(backup.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeVolumeBackupArchive removes the archive of the given backup from its
+// target. A missing archive is not an error.
+func removeVolumeBackupArchive(backup *define.VolumeBackup, identity string) error {
+	u, err := define.ParseVolumeBackupTarget(backup.Target)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		if err := os.Remove(backup.Archive); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing backup archive %s: %w", backup.Archive, err)
+		}
+		return nil
+	}
+
+	opts, err := volumeBackupExecOptions(u, identity)
+	if err != nil {
+		return err
+	}
+	opts.Args = []string{"rm", "-f", backup.Archive}
+	if _, err := ssh.Exec(opts, ssh.GolangMode); err != nil {
+		return fmt.Errorf("removing backup archive %s from %s: %w", backup.Archive, u.Host, err)
+	}
+	return nil
+}
+
+// RestoreVolumeBackup replaces the contents of the given volume with the
+// backup with the given ID, or with the latest backup of the volume if the ID
+// is 0. The digest of the archive is verified before it is extracted.
+func (r *Runtime) RestoreVolumeBackup(vol *Volume, id int64) (*define.VolumeBackup, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	backups, err := r.state.VolumeBackups(vol.Name())
+	if err != nil {
+		return nil, err
+	}
+	var backup *define.VolumeBackup
+	for _, b := range backups {
+		if id == 0 || b.ID == id {
+			backup = b
+		}
+	}
+	if backup == nil {
+		if id == 0 {
+			return nil, fmt.Errorf("volume %s has no backups: %w", vol.Name(), define.ErrNoSuchVolumeBackup)
+		}
+		return nil, fmt.Errorf("backup %d of volume %s: %w", id, vol.Name(), define.ErrNoSuchVolumeBackup)
+	}
+
+	archivePath, cleanup, err := fetchVolumeBackupArchive(r, backup)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if err := verifyVolumeBackupArchive(backup, archivePath); err != nil {
+		return nil, err
+	}
+
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening backup archive: %w", err)
+	}
+	defer archiveFile.Close()
+
+	mountPoint, err := vol.Mount()
+	if err != nil {
+		return nil, fmt.Errorf("mounting volume %s: %w", vol.Name(), err)
+	}
+	defer func() {
+		if err := vol.Unmount(); err != nil {
+			logrus.Errorf("Unmounting volume %s: %v", vol.Name(), err)
+		}
+	}()
+
+	entries, err := os.ReadDir(mountPoint)
+	if err != nil {
+		return nil, fmt.Errorf("reading volume %s: %w", vol.Name(), err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(mountPoint, entry.Name())); err != nil {
+			return nil, fmt.Errorf("clearing volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	if err := chrootarchive.Untar(archiveFile, mountPoint, nil); err != nil {
+		return nil, fmt.Errorf("restoring backup %d of volume %s: %w", backup.ID, vol.Name(), err)
+	}
+	return backup, nil
+}
+
+// fetchVolumeBackupArchive returns the local path of the archive of the given
+// backup, copying it from an ssh:// target to a temporary file first. The
+// returned function removes the temporary file.
+func fetchVolumeBackupArchive(r *Runtime, backup *define.VolumeBackup) (string, func(), error) {
+	u, err := define.ParseVolumeBackupTarget(backup.Target)
+	if err != nil {
+		return "", nil, err
+	}
+	if u == nil {
+		return backup.Archive, func() {}, nil
+	}
+
+	identity := ""
+	if policy, err := r.VolumeBackupPolicy(backup.Volume); err == nil && policy.Target == backup.Target {
+		identity = policy.Identity
+	}
+
+	tmpFile, err := os.CreateTemp(util.Tmpdir(), "podman-volume-backup-")
+	if err != nil {
+		return "", nil, err
+	}
+	tmpFile.Close()
+	cleanup := func() {
+		if err := os.Remove(tmpFile.Name()); err != nil {
+			logrus.Errorf("Removing temporary backup archive %s: %v", tmpFile.Name(), err)
+		}
+	}
+
+	opts, err := volumeBackupScpOptions(u, identity)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	opts.Source = volumeBackupSSHPath(u, backup.Archive)
+	opts.Destination = tmpFile.Name()
+	if _, err := ssh.Scp(opts, ssh.GolangMode); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("copying backup archive %s from %s: %w", backup.Archive, u.Host, err)
+	}
+	return tmpFile.Name(), cleanup, nil
+}
+
+// verifyVolumeBackupArchive checks the archive at the given path against the
+// digest recorded for the backup.
+func verifyVolumeBackupArchive(backup *define.VolumeBackup, archivePath string) error {
+	expected, err := digest.Parse(backup.Digest)
+	if err != nil {
+		return fmt.Errorf("parsing digest of backup %d: %w", backup.ID, err)
+	}
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("opening backup archive: %w", err)
+	}
+	defer f.Close()
+
+	verifier := expected.Verifier()
+	if _, err := io.Copy(verifier, f); err != nil {
+		return fmt.Errorf("reading backup archive %s: %w", archivePath, err)
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("backup archive %s does not match digest %s", backup.Archive, expected)
+	}
+	return nil
+}
+
+func volumeBackupPort(u *url.URL) (int, error) {
+	if u.Port() == "" {
+		return 0, nil
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		return 0, fmt.Errorf("invalid port of volume backup target %s: %w", u, err)
+	}
+	return port, nil
+}
+
+func volumeBackupScpOptions(u *url.URL, identity string) (*ssh.ConnectionScpOptions, error) {
+	port, err := volumeBackupPort(u)
+	if err != nil {
+		return nil, err
+	}
+	return &ssh.ConnectionScpOptions{User: u.User, Identity: identity, Port: port}, nil
+}
+
+func volumeBackupExecOptions(u *url.URL, identity string) (*ssh.ConnectionExecOptions, error) {
+	port, err := volumeBackupPort(u)
+	if err != nil {
+		return nil, err
+	}
+	return &ssh.ConnectionExecOptions{Host: u.String(), User: u.User, Identity: identity, Port: port}, nil
+}
+
+// volumeBackupSSHPath returns the ssh://[USER@]HOST:PATH form of a file on the
+// host of the given target expected by ssh.Scp, which takes the port
+// separately.
+func volumeBackupSSHPath(u *url.URL, file string) string {
+	host := u.Hostname()
+	if u.User != nil {
+		host = u.User.Username() + "@" + host
+	}
+	return "ssh://" + host + ":" + file
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/containers/storage/pkg/idtools"
 	"github.com/containers/storage/pkg/stringid"
 	pluginapi "github.com/docker/go-plugins-helpers/volume"
+	"github.com/docker/go-units"
 	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/sirupsen/logrus"
 )
@@ -80,6 +82,12 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 
 	if volume.config.Driver == define.VolumeDriverLocal {
 		logrus.Debugf("Validating options for local driver")
+		if err := parseLocalVolumeLimits(volume.config); err != nil {
+			return nil, err
+		}
+		if err := parseLocalVolumeEncryption(volume.config); err != nil {
+			return nil, err
+		}
 		// Validate options
 		for key, val := range volume.config.Options {
 			switch strings.ToLower(key) {
@@ -89,7 +97,7 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 						return nil, fmt.Errorf("invalid volume option %s for driver 'local': %w", key, err)
 					}
 				}
-			case "o", "type", "uid", "gid", "size", "inodes", "noquota", "copy", "nocopy":
+			case "o", "type", "uid", "gid", "size", "inodes", "noquota", "copy", "nocopy", "encrypt":
 				// Do nothing, valid keys
 			default:
 				return nil, fmt.Errorf("invalid mount option %s for driver 'local': %w", key, define.ErrInvalidArg)
@@ -113,7 +121,7 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 		}
 
 		// Look up the image
-		image, _, err := r.libimageRuntime.LookupImage(imgString, nil)
+		image, resolvedName, err := r.libimageRuntime.LookupImage(imgString, nil)
 		if err != nil {
 			return nil, fmt.Errorf("looking up image %s to create volume failed: %w", imgString, err)
 		}
@@ -124,6 +132,11 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 		volume.config.StorageID = stringid.GenerateRandomID()
 		volume.config.StorageName = volume.config.Name + volumeSuffix
 		volume.config.StorageImageID = image.ID()
+		// Images looked up by ID are recorded by their first name.
+		volume.config.StorageImageName = resolvedName
+		if names := image.Names(); len(names) > 0 && (resolvedName == "" || strings.HasPrefix(image.ID(), resolvedName)) {
+			volume.config.StorageImageName = names[0]
+		}
 
 		// Create a backing container in c/storage.
 		storageConfig := storage.ContainerOptions{
@@ -148,6 +161,13 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 		}()
 	}
 
+	if volume.usesShareDriver() {
+		logrus.Debugf("Validating options for %s driver", volume.config.Driver)
+		if err := validateShareVolumeOptions(volume.config); err != nil {
+			return nil, err
+		}
+	}
+
 	// Now we get conditional: we either need to make the volume in the
 	// volume plugin, or on disk if not using a plugin.
 	if volume.plugin != nil && !noCreatePluginVolume {
@@ -178,6 +198,11 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 		if err := LabelVolumePath(fullVolPath, volume.config.MountLabel); err != nil {
 			return nil, err
 		}
+		if volume.config.EncryptionSecret != "" {
+			if err := r.createEncryptedVolume(volPathRoot, volume.config); err != nil {
+				return nil, err
+			}
+		}
 		switch {
 		case volume.config.DisableQuota:
 			if volume.config.Size > 0 || volume.config.Inodes > 0 {
@@ -189,13 +214,19 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 				return nil, errors.New("volume option inodes not supported on tmpfs filesystem")
 			}
 		case volume.config.Inodes > 0 || volume.config.Size > 0:
-			projectQuotaSupported := false
 			q, err := quota.NewControl(r.config.Engine.VolumePath)
-			if err == nil {
-				projectQuotaSupported = true
-			}
-			if !projectQuotaSupported {
-				return nil, errors.New("volume options size and inodes not supported. Filesystem does not support Project Quota")
+			if err != nil {
+				// Without project quotas, the volume gets a
+				// file system of its own, which needs a size.
+				if volume.config.Size == 0 {
+					return nil, errors.New("volume option inodes not supported without size. Filesystem does not support Project Quota")
+				}
+				logrus.Debugf("Project quotas not supported (%v), limiting volume %s with a loopback file system", err, volume.config.Name)
+				if err := createVolumeLoopbackFS(filepath.Join(volPathRoot, volumeLoopbackImage), fullVolPath, volume.config); err != nil {
+					return nil, fmt.Errorf("volume options size and inodes not supported. Filesystem does not support Project Quota and creating a loopback file system failed: %w", err)
+				}
+				volume.config.QuotaMethod = define.VolumeQuotaLoopback
+				break
 			}
 			quota := quota.Quota{
 				Inodes: volume.config.Inodes,
@@ -204,6 +235,7 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 			if err := q.SetQuota(fullVolPath, quota); err != nil {
 				return nil, fmt.Errorf("failed to set size quota size=%d inodes=%d for volume directory %q: %w", volume.config.Size, volume.config.Inodes, fullVolPath, err)
 			}
+			volume.config.QuotaMethod = define.VolumeQuotaProject
 		}
 
 		volume.config.MountPoint = fullVolPath
@@ -234,6 +266,120 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 	return volume, nil
 }
 
+// parseLocalVolumeLimits moves the size and inodes options of a local volume
+// into its config. They can be given as options of their own, e.g. with
+// --opt size=10G, and are then handled as if they were part of the "o"
+// option.
+func parseLocalVolumeLimits(config *VolumeConfig) error {
+	if size, ok := config.Options["size"]; ok {
+		bytes, err := units.FromHumanSize(size)
+		if err != nil {
+			return fmt.Errorf("cannot convert size %s to integer: %w", size, err)
+		}
+		config.Size = uint64(bytes)
+		delete(config.Options, "size")
+		config.Options["SIZE"] = size
+		// tmpfs is limited by its mount options.
+		if config.Options["type"] == define.TypeTmpfs {
+			config.Options["o"] = strings.TrimPrefix(config.Options["o"]+",size="+size, ",")
+		}
+	}
+	if inodes, ok := config.Options["inodes"]; ok {
+		count, err := strconv.ParseUint(inodes, 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot convert inodes %s to integer: %w", inodes, err)
+		}
+		config.Inodes = count
+		delete(config.Options, "inodes")
+		config.Options["INODES"] = inodes
+	}
+	return nil
+}
+
+// parseLocalVolumeEncryption moves the encrypt option of a local volume, the
+// name of the secret holding the password of the volume, into its config.
+func parseLocalVolumeEncryption(config *VolumeConfig) error {
+	secret, ok := config.Options["encrypt"]
+	if !ok {
+		return nil
+	}
+	if secret == "" {
+		return fmt.Errorf("volume option encrypt requires the name of a secret: %w", define.ErrInvalidArg)
+	}
+	// The contents are stored in a directory of the volume, mounting a
+	// file system of another kind or limiting them is not supported.
+	for _, key := range []string{"type", "device", "o", "SIZE", "INODES"} {
+		if _, ok := config.Options[key]; ok {
+			return fmt.Errorf("volume option encrypt cannot be used with option %s: %w", strings.ToLower(key), define.ErrInvalidArg)
+		}
+	}
+	config.EncryptionSecret = secret
+	delete(config.Options, "encrypt")
+	return nil
+}
+
+// createEncryptedVolume initializes the encrypted contents of a new volume,
+// encrypted with the password held by its secret.
+func (r *Runtime) createEncryptedVolume(volPathRoot string, config *VolumeConfig) error {
+	if err := encryptedVolumesSupported(); err != nil {
+		return err
+	}
+	manager, err := r.SecretsManager()
+	if err != nil {
+		return err
+	}
+	_, password, err := manager.LookupSecretData(config.EncryptionSecret)
+	if err != nil {
+		return fmt.Errorf("reading encryption secret %s: %w", config.EncryptionSecret, err)
+	}
+	if len(password) == 0 {
+		return fmt.Errorf("encryption secret %s is empty: %w", config.EncryptionSecret, define.ErrInvalidArg)
+	}
+	// The root directory of the decrypted view has the owner and mode of
+	// the encrypted directory.
+	cipherDir := filepath.Join(volPathRoot, volumeCipherDir)
+	if err := os.Mkdir(cipherDir, 0o755); err != nil {
+		return fmt.Errorf("creating encrypted volume directory %q: %w", cipherDir, err)
+	}
+	if err := idtools.SafeChown(cipherDir, config.UID, config.GID); err != nil {
+		return fmt.Errorf("chowning encrypted volume directory %q to %d:%d: %w", cipherDir, config.UID, config.GID, err)
+	}
+	if err := createVolumeEncryptedFS(cipherDir, password); err != nil {
+		if rmErr := os.RemoveAll(cipherDir); rmErr != nil {
+			logrus.Errorf("Removing encrypted volume directory %q: %v", cipherDir, rmErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateShareVolumeOptions validates the options of a volume of the nfs or
+// cifs driver. The "server" option and the "export" option of NFS or the
+// "share" option of CIFS name the network share, "o" holds its mount options.
+func validateShareVolumeOptions(config *VolumeConfig) error {
+	pathOption := "export"
+	if config.Driver == define.VolumeDriverCIFS {
+		pathOption = "share"
+	}
+	for key := range config.Options {
+		switch key {
+		case "server", pathOption, "o", "UID", "GID":
+			// Do nothing, valid keys
+		default:
+			return fmt.Errorf("invalid mount option %s for driver '%s': %w", key, config.Driver, define.ErrInvalidArg)
+		}
+	}
+	for _, key := range []string{"server", pathOption} {
+		if config.Options[key] == "" {
+			return fmt.Errorf("must provide the %s option when creating a volume with the %s driver: %w", key, config.Driver, define.ErrInvalidArg)
+		}
+	}
+	if config.Size > 0 || config.Inodes > 0 {
+		return fmt.Errorf("volume options size and inodes are not supported by the %s driver: %w", config.Driver, define.ErrInvalidArg)
+	}
+	return nil
+}
+
 // UpdateVolumePlugins reads all volumes from all configured volume plugins and
 // imports them into the libpod db. It also checks if existing libpod volumes
 // are removed in the plugin, in this case we try to remove it from libpod.
@@ -0,0 +1,76 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocalVolumeLimits(t *testing.T) {
+	vol := newVolume(nil)
+	vol.config.Options = map[string]string{"size": "10G", "inodes": "1000"}
+	require.NoError(t, parseLocalVolumeLimits(vol.config))
+	assert.Equal(t, uint64(10*1000*1000*1000), vol.config.Size)
+	assert.Equal(t, uint64(1000), vol.config.Inodes)
+	assert.Equal(t, map[string]string{"SIZE": "10G", "INODES": "1000"}, vol.config.Options)
+	assert.False(t, vol.needsMount(), "limits alone do not need a mount")
+
+	vol.config.QuotaMethod = define.VolumeQuotaLoopback
+	assert.True(t, vol.needsMount(), "loopback file systems are mounted")
+
+	// tmpfs is limited by its mount options.
+	vol = newVolume(nil)
+	vol.config.Options = map[string]string{"type": define.TypeTmpfs, "o": "nodev", "size": "2M"}
+	require.NoError(t, parseLocalVolumeLimits(vol.config))
+	assert.Equal(t, "nodev,size=2M", vol.config.Options["o"])
+	assert.True(t, vol.needsMount())
+
+	vol.config.Options = map[string]string{"size": "huge"}
+	assert.Error(t, parseLocalVolumeLimits(vol.config))
+}
+
+func TestParseLocalVolumeEncryption(t *testing.T) {
+	vol := newVolume(nil)
+	vol.config.Options = map[string]string{"encrypt": "volpass", "nocopy": ""}
+	require.NoError(t, parseLocalVolumeEncryption(vol.config))
+	assert.Equal(t, "volpass", vol.config.EncryptionSecret)
+	assert.Equal(t, map[string]string{"nocopy": ""}, vol.config.Options)
+	assert.True(t, vol.needsMount(), "encrypted volumes are mounted")
+
+	vol = newVolume(nil)
+	vol.config.Options = map[string]string{"encrypt": ""}
+	assert.ErrorIs(t, parseLocalVolumeEncryption(vol.config), define.ErrInvalidArg, "secret is required")
+	vol.config.Options = map[string]string{"encrypt": "volpass", "SIZE": "10G"}
+	assert.ErrorIs(t, parseLocalVolumeEncryption(vol.config), define.ErrInvalidArg, "encrypted volumes cannot be limited")
+	vol.config.Options = map[string]string{"encrypt": "volpass", "type": define.TypeTmpfs}
+	assert.ErrorIs(t, parseLocalVolumeEncryption(vol.config), define.ErrInvalidArg, "encrypted volumes cannot mount other file systems")
+}
+
+func TestValidateShareVolumeOptions(t *testing.T) {
+	vol := newVolume(&Runtime{config: new(config.Config)})
+	vol.config.Driver = define.VolumeDriverNFS
+	vol.config.Options = map[string]string{"server": "nfs.example.com", "export": "/exports/data", "o": "vers=4.2"}
+	require.NoError(t, validateShareVolumeOptions(vol.config))
+	assert.Equal(t, "nfs.example.com:/exports/data", shareSource(vol.config))
+	assert.True(t, vol.usesShareDriver())
+	assert.True(t, vol.needsMount())
+
+	vol.config.Driver = define.VolumeDriverCIFS
+	assert.ErrorIs(t, validateShareVolumeOptions(vol.config), define.ErrInvalidArg, "export is not a cifs option")
+	vol.config.Options = map[string]string{"server": "smb.example.com", "share": "data"}
+	require.NoError(t, validateShareVolumeOptions(vol.config))
+	assert.Equal(t, "//smb.example.com/data", shareSource(vol.config))
+
+	vol.config.Options = map[string]string{"server": "smb.example.com"}
+	assert.ErrorIs(t, validateShareVolumeOptions(vol.config), define.ErrInvalidArg, "share is mandatory")
+
+	// A volume plugin of the same name takes precedence.
+	vol.runtime.config.Engine.VolumePlugins = map[string]string{define.VolumeDriverCIFS: "/run/cifs.sock"}
+	assert.False(t, vol.usesShareDriver())
+	assert.True(t, vol.UsesVolumeDriver())
+}
@@ -0,0 +1,99 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// sqlDialect covers the differences between the databases the SQL state runs
+// on. The queries of the state use ? placeholders and the SQL understood by
+// all databases, anything else goes through the dialect.
+type sqlDialect interface {
+	// backend returns the name of the database backend.
+	backend() string
+
+	// bindVars converts the ? placeholders of a query to the parameters
+	// of the database. The query must not contain a literal question mark.
+	bindVars(query string) string
+
+	// upsert returns a statement inserting a row into table, replacing the
+	// row with the same key if there is one. The values are given for the
+	// key columns first, followed by the other columns.
+	upsert(table string, key []string, columns ...string) string
+
+	// containerStateBlobsColumn selects the rows of ContainerStateBlob of
+	// a container as a JSON object mapping field names to values. It is
+	// used in queries of the ContainerState table.
+	containerStateBlobsColumn() string
+
+	// begin starts a transaction which runs exclusive of the transactions
+	// of all other connections to the database.
+	begin(conn *sql.DB) (*sql.Tx, error)
+
+	// retry calls fn until it succeeds, or fails with an error that is
+	// not retryable, see isRetryableError.
+	retry(fn func() error) error
+
+	// isRetryableError returns true if err was caused by the database
+	// being locked by another connection.
+	isRetryableError(err error) bool
+
+	// isForeignKeyError returns true if err was caused by a foreign key
+	// constraint, such as removing a row still referenced by another
+	// table.
+	isForeignKeyError(err error) bool
+
+	// schemaVersion returns the current schema version of the database.
+	schemaVersion() int
+
+	// recordsStateChanges returns true if the database records changes of
+	// the state in the StateChange table.
+	recordsStateChanges() bool
+
+	// checkIntegrity returns the integrity errors of the database.
+	checkIntegrity(s *SQLiteState) ([]string, error)
+
+	// vacuum reclaims the space of removed entries.
+	vacuum(s *SQLiteState) error
+
+	// checkpointWAL copies the write-ahead log into the database and
+	// truncates it.
+	checkpointWAL(s *SQLiteState) error
+
+	// backup writes a consistent copy of the database to path.
+	backup(s *SQLiteState, path string) error
+
+	// databaseInfo sets the location and the sizes of the database.
+	databaseInfo(s *SQLiteState, info *define.DatabaseBackendInfo) error
+}
+
+// sqlTx is a transaction of the SQL state. The placeholders of its queries
+// are converted to the syntax of the database.
+type sqlTx struct {
+	*sql.Tx
+	dialect sqlDialect
+}
+
+// Exec executes a query that does not return rows, see sql.Tx.Exec.
+func (tx *sqlTx) Exec(query string, args ...any) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.bindVars(query), args...)
+}
+
+// Query executes a query that returns rows, see sql.Tx.Query.
+func (tx *sqlTx) Query(query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.bindVars(query), args...)
+}
+
+// QueryRow executes a query that returns at most one row, see sql.Tx.QueryRow.
+func (tx *sqlTx) QueryRow(query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.bindVars(query), args...)
+}
+
+// sqlPlaceholders returns n comma separated ? placeholders.
+func sqlPlaceholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
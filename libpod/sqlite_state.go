@@ -3,85 +3,239 @@
 package libpod
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	goruntime "runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/mattn/go-sqlite3"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
-
-	// SQLite backend for database/sql
-	_ "github.com/mattn/go-sqlite3"
 )
 
-const schemaVersion = 1
-
-// SQLiteState is a state implementation backed by a SQLite database
+// SQLiteState is a state implementation backed by a SQLite database. With the
+// PostgreSQL dialect it is backed by a PostgreSQL database instead, using the
+// same tables and queries.
 type SQLiteState struct {
 	valid   bool
 	conn    *sql.DB
 	runtime *Runtime
+	// dialect covers the differences between the supported databases.
+	dialect sqlDialect
+	// namespace restricts the visible containers and pods, if set.
+	namespace string
 }
 
 const (
 	// Deal with timezone automatically.
 	sqliteOptionLocation = "_loc=auto"
-	// Force an fsync after each transaction (https://www.sqlite.org/pragma.html#pragma_synchronous).
-	sqliteOptionSynchronous = "&_sync=FULL"
 	// Allow foreign keys (https://www.sqlite.org/pragma.html#pragma_foreign_keys).
 	sqliteOptionForeignKeys = "&_foreign_keys=1"
 	// Make sure that transactions happen exclusively.
 	sqliteOptionTXLock = "&_txlock=exclusive"
 
-	// Assembled sqlite options used when opening the database.
-	sqliteOptions = "db.sql?" +
+	// Force an fsync after each transaction by default (https://www.sqlite.org/pragma.html#pragma_synchronous).
+	sqliteDefaultSynchronous = "FULL"
+	// Keep retrying when the db is locked. Timeout is in ms, so set it to
+	// 100s to have enough time to retry the operations.
+	sqliteDefaultBusyTimeout = 100000
+
+	// Name of the database file.
+	sqliteDBName = "db.sql"
+
+	// Assembled sqlite options used when opening the database, the tunable
+	// ones are added by sqliteDSN.
+	sqliteOptions = "?" +
 		sqliteOptionLocation +
-		sqliteOptionSynchronous +
 		sqliteOptionForeignKeys +
 		sqliteOptionTXLock
+
+	// Assembled sqlite options used when opening the database read only.
+	// The access mode can only be set in URI file names. Transactions are
+	// deferred, so they do not take the write lock of the database.
+	sqliteReadOnlyOptions = "?mode=ro&_query_only=1&" +
+		sqliteOptionLocation +
+		sqliteOptionForeignKeys +
+		"&_txlock=deferred"
 )
 
+// sqliteURIPathEscaper escapes the characters with a special meaning in the
+// path of a SQLite URI file name.
+var sqliteURIPathEscaper = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23")
+
+// sqliteDSN returns the data source name to open the database at dbPath
+// with the pragmas configured in containers.conf.
+func sqliteDSN(runtime *Runtime, dbPath string) (string, error) {
+	engine := runtime.podmanConf.Engine
+
+	synchronous := sqliteDefaultSynchronous
+	if engine.DBSynchronous != "" {
+		synchronous = strings.ToUpper(engine.DBSynchronous)
+		switch synchronous {
+		case "OFF", "NORMAL", "FULL", "EXTRA":
+		default:
+			return "", fmt.Errorf("invalid database_synchronous %q, must be one of off, normal, full, extra: %w", engine.DBSynchronous, define.ErrInvalidArg)
+		}
+	}
+	dsn := dbPath + sqliteOptions + "&_sync=" + synchronous
+	if runtime.readOnlyState {
+		dsn = "file:" + sqliteURIPathEscaper.Replace(dbPath) + sqliteReadOnlyOptions + "&_sync=" + synchronous
+	}
+
+	// Changing the journal mode writes to the database.
+	if engine.DBJournalMode != "" && !runtime.readOnlyState {
+		journalMode := strings.ToUpper(engine.DBJournalMode)
+		switch journalMode {
+		case "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF":
+		default:
+			return "", fmt.Errorf("invalid database_journal_mode %q, must be one of delete, truncate, persist, memory, wal, off: %w", engine.DBJournalMode, define.ErrInvalidArg)
+		}
+		dsn += "&_journal_mode=" + journalMode
+	}
+
+	// Some users might want to experiment with different timeout values (#23236)
+	// DO NOT DOCUMENT or recommend PODMAN_SQLITE_BUSY_TIMEOUT outside of testing.
+	busyTimeout := strconv.FormatUint(sqliteDefaultBusyTimeout, 10)
+	if engine.DBBusyTimeout > 0 {
+		busyTimeout = strconv.FormatUint(uint64(engine.DBBusyTimeout), 10)
+	}
+	if env, ok := os.LookupEnv("PODMAN_SQLITE_BUSY_TIMEOUT"); ok {
+		logrus.Debugf("PODMAN_SQLITE_BUSY_TIMEOUT is set to %s", env)
+		busyTimeout = env
+	}
+	dsn += "&_busy_timeout=" + busyTimeout
+
+	if engine.DBCacheSize != 0 {
+		dsn += "&_cache_size=" + strconv.Itoa(engine.DBCacheSize)
+	}
+	return dsn, nil
+}
+
+// sqliteConnector opens SQLite connections and applies the pragmas that
+// cannot be set in the data source name to each of them.
+type sqliteConnector struct {
+	dsn      string
+	mmapSize int64
+	driver   *sqlite3.SQLiteDriver
+}
+
+func newSqliteConnector(runtime *Runtime, dsn string) *sqliteConnector {
+	return &sqliteConnector{
+		dsn:      dsn,
+		mmapSize: runtime.podmanConf.Engine.DBMmapSize,
+		driver:   &sqlite3.SQLiteDriver{},
+	}
+}
+
+// Connect implements driver.Connector.
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil || c.mmapSize <= 0 {
+		return conn, err
+	}
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("setting database mmap_size: connection does not support statements")
+	}
+	if _, err := execer.ExecContext(ctx, fmt.Sprintf("PRAGMA mmap_size=%d;", c.mmapSize), nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("setting database mmap_size: %w", err)
+	}
+	return conn, nil
+}
+
+// Driver implements driver.Connector.
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
 // NewSqliteState creates a new SQLite-backed state database.
-func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
+func NewSqliteState(runtime *Runtime) (State, error) {
 	logrus.Info("Using sqlite as database backend")
+	state, err := newSqliteState(runtime, filepath.Join(sqliteStateDir(runtime), sqliteDBName))
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// NewMemoryState creates a new state database held in memory. It is lost when
+// the process exits.
+func NewMemoryState(runtime *Runtime) (State, error) {
+	logrus.Info("Using memory as database backend")
+	if runtime.readOnlyState {
+		return nil, fmt.Errorf("the memory database backend cannot be opened read only: %w", define.ErrInvalidArg)
+	}
+
+	conn := sql.OpenDB(newSqliteConnector(runtime, ":memory:"+sqliteOptions))
+	// Every connection to ":memory:" opens a database of its own, so the
+	// one connection must be kept open for the lifetime of the state.
+	conn.SetMaxOpenConns(1)
+	conn.SetMaxIdleConns(1)
+	conn.SetConnMaxLifetime(0)
+	conn.SetConnMaxIdleTime(0)
+	if err := initSQLiteDB(conn); err != nil {
+		if err := conn.Close(); err != nil {
+			logrus.Errorf("Error closing SQLite DB connection: %v", err)
+		}
+		return nil, err
+	}
+
 	state := new(SQLiteState)
+	state.conn = conn
+	state.valid = true
+	state.runtime = runtime
+	state.dialect = sqliteDialect{memory: true}
+	return state, nil
+}
 
+// sqliteStateDir returns the directory containing the SQLite database.
+func sqliteStateDir(runtime *Runtime) string {
 	basePath := runtime.storageConfig.GraphRoot
 	if runtime.storageConfig.TransientStore {
 		basePath = runtime.storageConfig.RunRoot
 	} else if !runtime.storageSet.StaticDirSet {
 		basePath = runtime.config.Engine.StaticDir
 	}
+	return basePath
+}
 
-	// c/storage is set up *after* the DB - so even though we use the c/s
-	// root (or, for transient, runroot) dir, we need to make the dir
-	// ourselves.
-	if err := os.MkdirAll(basePath, 0700); err != nil {
-		return nil, fmt.Errorf("creating root directory: %w", err)
-	}
+// newSqliteState opens the SQLite database at the given path, creating it if
+// it does not exist. If the runtime uses a read-only state, the database must
+// exist with the current schema.
+func newSqliteState(runtime *Runtime, dbPath string) (_ *SQLiteState, defErr error) {
+	state := new(SQLiteState)
 
-	// Make sure busy timeout is set to high value to keep retrying when the db is locked.
-	// Timeout is in ms, so set it to 100s to have enough time to retry the operations.
-	// Some users might want to experiment with different timeout values (#23236)
-	// DO NOT DOCUMENT or recommend PODMAN_SQLITE_BUSY_TIMEOUT outside of testing.
-	busyTimeout := "100000"
-	if env, ok := os.LookupEnv("PODMAN_SQLITE_BUSY_TIMEOUT"); ok {
-		logrus.Debugf("PODMAN_SQLITE_BUSY_TIMEOUT is set to %s", env)
-		busyTimeout = env
+	if runtime.readOnlyState {
+		if err := fileutils.Exists(dbPath); err != nil {
+			return nil, fmt.Errorf("opening database %s read only: %w", dbPath, err)
+		}
+	} else {
+		// c/storage is set up *after* the DB - so even though we use
+		// the c/s root (or, for transient, runroot) dir, we need to
+		// make the dir ourselves.
+		if err := os.MkdirAll(filepath.Dir(dbPath), 0700); err != nil {
+			return nil, fmt.Errorf("creating root directory: %w", err)
+		}
 	}
-	sqliteOptionBusyTimeout := "&_busy_timeout=" + busyTimeout
 
-	conn, err := sql.Open("sqlite3", filepath.Join(basePath, sqliteOptions+sqliteOptionBusyTimeout))
+	dsn, err := sqliteDSN(runtime, dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("initializing sqlite database: %w", err)
+		return nil, err
 	}
+	conn := sql.OpenDB(newSqliteConnector(runtime, dsn))
 	defer func() {
 		if defErr != nil {
 			if err := conn.Close(); err != nil {
@@ -90,13 +244,19 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 		}
 	}()
 
-	if err := initSQLiteDB(conn); err != nil {
+	if runtime.readOnlyState {
+		err = checkSQLiteSchema(conn)
+	} else {
+		err = initSQLiteDB(conn)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	state.conn = conn
 	state.valid = true
 	state.runtime = runtime
+	state.dialect = sqliteDialect{}
 
 	return state, nil
 }
@@ -120,11 +280,11 @@ func (s *SQLiteState) Refresh() (defErr error) {
 	// Retrieve all containers, pods, and volumes.
 	// Maps are indexed by ID (or volume name) so we know which goes where,
 	// and store the marshalled state JSON
-	ctrStates := make(map[string]string)
+	ctrStates := make(map[string]*ContainerState)
 	podStates := make(map[string]string)
 	volumeStates := make(map[string]string)
 
-	ctrRows, err := s.conn.Query("SELECT ID, JSON FROM ContainerState;")
+	ctrRows, err := s.query("SELECT ID, JSON, " + s.dialect.containerStateBlobsColumn() + " FROM ContainerState;")
 	if err != nil {
 		return fmt.Errorf("querying for container states: %w", err)
 	}
@@ -132,33 +292,28 @@ func (s *SQLiteState) Refresh() (defErr error) {
 
 	for ctrRows.Next() {
 		var (
-			id, stateJSON string
+			id, stateJSON, blobsJSON string
 		)
-		if err := ctrRows.Scan(&id, &stateJSON); err != nil {
+		if err := ctrRows.Scan(&id, &stateJSON, &blobsJSON); err != nil {
 			return fmt.Errorf("scanning container state row: %w", err)
 		}
 
 		ctrState := new(ContainerState)
 
-		if err := json.Unmarshal([]byte(stateJSON), ctrState); err != nil {
-			return fmt.Errorf("unmarshalling container state json: %w", err)
+		if err := unmarshalContainerState(id, stateJSON, blobsJSON, ctrState); err != nil {
+			return err
 		}
 
 		// Refresh the state
 		resetContainerState(ctrState)
 
-		newJSON, err := json.Marshal(ctrState)
-		if err != nil {
-			return fmt.Errorf("marshalling container state json: %w", err)
-		}
-
-		ctrStates[id] = string(newJSON)
+		ctrStates[id] = ctrState
 	}
 	if err := ctrRows.Err(); err != nil {
 		return err
 	}
 
-	podRows, err := s.conn.Query("SELECT ID, JSON FROM PodState;")
+	podRows, err := s.query("SELECT ID, JSON FROM PodState;")
 	if err != nil {
 		return fmt.Errorf("querying for pod states: %w", err)
 	}
@@ -192,7 +347,7 @@ func (s *SQLiteState) Refresh() (defErr error) {
 		return err
 	}
 
-	volRows, err := s.conn.Query("SELECT Name, JSON FROM VolumeState;")
+	volRows, err := s.query("SELECT Name, JSON FROM VolumeState;")
 	if err != nil {
 		return fmt.Errorf("querying for volume states: %w", err)
 	}
@@ -230,7 +385,7 @@ func (s *SQLiteState) Refresh() (defErr error) {
 	// Write updated states back to DB, and perform additional maintenance
 	// (Remove exit codes and exec sessions)
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning refresh transaction: %w", err)
 	}
@@ -242,10 +397,17 @@ func (s *SQLiteState) Refresh() (defErr error) {
 		}
 	}()
 
-	for id, json := range ctrStates {
-		if _, err := tx.Exec("UPDATE ContainerState SET JSON=? WHERE ID=?;", json, id); err != nil {
+	for id, state := range ctrStates {
+		stateJSON, err := containerStateJSON(state)
+		if err != nil {
+			return fmt.Errorf("marshalling container state json: %w", err)
+		}
+		if _, err := tx.Exec("UPDATE ContainerState SET JSON=? WHERE ID=?;", stateJSON, id); err != nil {
 			return fmt.Errorf("updating container state: %w", err)
 		}
+		if err := saveContainerStateBlobs(tx.Tx, id, state, s.dialect.bindVars); err != nil {
+			return err
+		}
 	}
 	for id, json := range podStates {
 		if _, err := tx.Exec("UPDATE PodState SET JSON=? WHERE ID=?;", json, id); err != nil {
@@ -283,7 +445,7 @@ func (s *SQLiteState) GetDBConfig() (*DBConfig, error) {
 	cfg := new(DBConfig)
 	var staticDir, tmpDir, graphRoot, runRoot, graphDriver, volumeDir string
 
-	row := s.conn.QueryRow("SELECT StaticDir, TmpDir, GraphRoot, RunRoot, GraphDriver, VolumeDir FROM DBConfig;")
+	row := s.queryRow("SELECT StaticDir, TmpDir, GraphRoot, RunRoot, GraphDriver, VolumeDir FROM DBConfig;")
 
 	if err := row.Scan(&staticDir, &tmpDir, &graphRoot, &runRoot, &graphDriver, &volumeDir); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -351,7 +513,7 @@ func (s *SQLiteState) ValidateDBConfig(runtime *Runtime) (defErr error) {
 	// chance it's a perf hit. If it is, we can move it entirely within the
 	// `errors.Is()` block below, with extra validation to ensure the row
 	// still does not exist (and, if it does, to retry this function).
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning database validation transaction: %w", err)
 	}
@@ -367,7 +529,7 @@ func (s *SQLiteState) ValidateDBConfig(runtime *Runtime) (defErr error) {
 
 	if err := row.Scan(&dbOS, &staticDir, &tmpDir, &graphRoot, &runRoot, &graphDriver, &volumePath); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			if _, err := tx.Exec(createRow, 1, schemaVersion, runtimeOS,
+			if _, err := tx.Exec(createRow, 1, s.dialect.schemaVersion(), runtimeOS,
 				runtimeStaticDir, runtimeTmpDir, runtimeGraphRoot,
 				runtimeRunRoot, runtimeGraphDriver, runtimeVolumePath); err != nil {
 				return fmt.Errorf("adding DB config row: %w", err)
@@ -444,6 +606,18 @@ func (s *SQLiteState) ValidateDBConfig(runtime *Runtime) (defErr error) {
 	return nil
 }
 
+// SetNamespace sets the namespace of the state. Only containers and pods in
+// the namespace are visible afterwards, unless it is empty.
+func (s *SQLiteState) SetNamespace(ns string) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	s.namespace = ns
+
+	return nil
+}
+
 // GetContainerName returns the name of the container associated with a given
 // ID. Returns ErrNoSuchCtr if the ID does not exist.
 func (s *SQLiteState) GetContainerName(id string) (string, error) {
@@ -457,7 +631,7 @@ func (s *SQLiteState) GetContainerName(id string) (string, error) {
 
 	var name string
 
-	row := s.conn.QueryRow("SELECT Name FROM ContainerConfig WHERE ID=?;", id)
+	row := s.queryRow("SELECT Name FROM ContainerConfig WHERE ID=?;", id)
 	if err := row.Scan(&name); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", define.ErrNoSuchCtr
@@ -482,7 +656,7 @@ func (s *SQLiteState) GetPodName(id string) (string, error) {
 
 	var name string
 
-	row := s.conn.QueryRow("SELECT Name FROM PodConfig WHERE ID=?;", id)
+	row := s.queryRow("SELECT Name FROM PodConfig WHERE ID=?;", id)
 	if err := row.Scan(&name); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", define.ErrNoSuchPod
@@ -508,6 +682,9 @@ func (s *SQLiteState) Container(id string) (*Container, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkNamespace("container", id, ctrConfig.Namespace); err != nil {
+		return nil, err
+	}
 
 	ctr := new(Container)
 	ctr.config = ctrConfig
@@ -532,7 +709,8 @@ func (s *SQLiteState) LookupContainerID(idOrName string) (string, error) {
 		return "", define.ErrDBClosed
 	}
 
-	rows, err := s.conn.Query("SELECT ID, Name FROM ContainerConfig WHERE ContainerConfig.Name=? OR (ContainerConfig.ID LIKE ?);", idOrName, idOrName+"%")
+	nsCond, nsArgs := s.namespaceCondition("ContainerConfig")
+	rows, err := s.query("SELECT ID, Name FROM ContainerConfig WHERE (ContainerConfig.Name=? OR (ContainerConfig.ID LIKE ?))"+nsCond+";", append([]any{idOrName, idOrName + "%"}, nsArgs...)...)
 	if err != nil {
 		return "", fmt.Errorf("looking up container %q in database: %w", idOrName, err)
 	}
@@ -574,7 +752,8 @@ func (s *SQLiteState) LookupContainer(idOrName string) (*Container, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	rows, err := s.conn.Query("SELECT JSON, Name FROM ContainerConfig WHERE ContainerConfig.Name=? OR (ContainerConfig.ID LIKE ?);", idOrName, idOrName+"%")
+	nsCond, nsArgs := s.namespaceCondition("ContainerConfig")
+	rows, err := s.query("SELECT JSON, Name FROM ContainerConfig WHERE (ContainerConfig.Name=? OR (ContainerConfig.ID LIKE ?))"+nsCond+";", append([]any{idOrName, idOrName + "%"}, nsArgs...)...)
 	if err != nil {
 		return nil, fmt.Errorf("looking up container %q in database: %w", idOrName, err)
 	}
@@ -632,7 +811,8 @@ func (s *SQLiteState) HasContainer(id string) (bool, error) {
 		return false, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=?;", id)
+	nsCond, nsArgs := s.namespaceCondition("ContainerConfig")
+	row := s.queryRow("SELECT 1 FROM ContainerConfig WHERE ID=?"+nsCond+";", append([]any{id}, nsArgs...)...)
 
 	var check int
 	if err := row.Scan(&check); err != nil {
@@ -662,7 +842,7 @@ func (s *SQLiteState) AddContainer(ctr *Container) error {
 		return fmt.Errorf("cannot add a container that belongs to a pod with AddContainer - use AddContainerToPod: %w", define.ErrInvalidArg)
 	}
 
-	return s.addContainer(ctr)
+	return s.addContainers([]*Container{ctr})
 }
 
 // RemoveContainer removes a container from the state
@@ -680,6 +860,33 @@ func (s *SQLiteState) RemoveContainer(ctr *Container) error {
 	return s.removeContainer(ctr)
 }
 
+// AddContainers adds the given containers to the state in a single
+// transaction. Containers that belong to a pod are added to the pod as well.
+func (s *SQLiteState) AddContainers(ctrs []*Container) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	for _, ctr := range ctrs {
+		if !ctr.valid {
+			return define.ErrCtrRemoved
+		}
+	}
+
+	return s.addContainers(ctrs)
+}
+
+// RemoveContainers removes the given containers from the state in a single
+// transaction. Containers that belong to a pod are removed from the pod as
+// well.
+func (s *SQLiteState) RemoveContainers(ctrs []*Container) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	return s.removeContainers(ctrs)
+}
+
 // UpdateContainer updates a container's state from the database
 func (s *SQLiteState) UpdateContainer(ctr *Container) error {
 	if !s.valid {
@@ -690,10 +897,10 @@ func (s *SQLiteState) UpdateContainer(ctr *Container) error {
 		return define.ErrCtrRemoved
 	}
 
-	row := s.conn.QueryRow("SELECT JSON FROM ContainerState WHERE ID=?;", ctr.ID())
+	row := s.queryRow("SELECT JSON, "+s.dialect.containerStateBlobsColumn()+" FROM ContainerState WHERE ID=?;", ctr.ID())
 
-	var rawJSON string
-	if err := row.Scan(&rawJSON); err != nil {
+	var rawJSON, blobsJSON string
+	if err := row.Scan(&rawJSON, &blobsJSON); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			// Container was removed
 			ctr.valid = false
@@ -702,8 +909,8 @@ func (s *SQLiteState) UpdateContainer(ctr *Container) error {
 	}
 
 	newState := new(ContainerState)
-	if err := json.Unmarshal([]byte(rawJSON), newState); err != nil {
-		return fmt.Errorf("unmarshalling container %s state JSON: %w", ctr.ID(), err)
+	if err := unmarshalContainerState(ctr.ID(), rawJSON, blobsJSON, newState); err != nil {
+		return err
 	}
 
 	ctr.state = newState
@@ -721,12 +928,12 @@ func (s *SQLiteState) SaveContainer(ctr *Container) (defErr error) {
 		return define.ErrCtrRemoved
 	}
 
-	stateJSON, err := json.Marshal(ctr.state)
+	stateJSON, err := containerStateJSON(ctr.state)
 	if err != nil {
 		return fmt.Errorf("marshalling container %s state JSON: %w", ctr.ID(), err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning container %s save transaction: %w", ctr.ID(), err)
 	}
@@ -751,6 +958,14 @@ func (s *SQLiteState) SaveContainer(ctr *Container) (defErr error) {
 		return define.ErrNoSuchCtr
 	}
 
+	if err := saveContainerStateBlobs(tx.Tx, ctr.ID(), ctr.state, s.dialect.bindVars); err != nil {
+		return err
+	}
+
+	if err := saveExecSessions(tx.Tx, ctr, s.dialect.bindVars); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing container %s state: %w", ctr.ID(), err)
 	}
@@ -770,7 +985,7 @@ func (s *SQLiteState) ContainerInUse(ctr *Container) ([]string, error) {
 		return nil, define.ErrCtrRemoved
 	}
 
-	rows, err := s.conn.Query("SELECT ID FROM ContainerDependency WHERE DependencyID=?;", ctr.ID())
+	rows, err := s.query("SELECT ID FROM ContainerDependency WHERE DependencyID=?;", ctr.ID())
 	if err != nil {
 		return nil, fmt.Errorf("retrieving containers that depend on container %s: %w", ctr.ID(), err)
 	}
@@ -791,25 +1006,106 @@ func (s *SQLiteState) ContainerInUse(ctr *Container) ([]string, error) {
 	return deps, nil
 }
 
+// ContainerDependents returns the edges of the dependency graph of all
+// containers depending on the given container, directly or transitively.
+func (s *SQLiteState) ContainerDependents(ctr *Container) ([]StateDependency, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return nil, define.ErrCtrRemoved
+	}
+
+	rows, err := s.query(containerDependentsQuery, ctr.ID())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving containers that depend on container %s: %w", ctr.ID(), err)
+	}
+	defer rows.Close()
+
+	edges := []StateDependency{}
+	for rows.Next() {
+		var edge StateDependency
+		if err := rows.Scan(&edge.ID, &edge.DependencyID); err != nil {
+			return nil, fmt.Errorf("reading containers that depend on container %s: %w", ctr.ID(), err)
+		}
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return edges, nil
+}
+
+// ContainerDependencies returns the edges of the dependency graph of all
+// containers the given container depends on, directly or transitively.
+func (s *SQLiteState) ContainerDependencies(ctr *Container) ([]StateDependency, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return nil, define.ErrCtrRemoved
+	}
+
+	rows, err := s.query(containerDependenciesQuery, ctr.ID())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving containers that are dependencies of container %s: %w", ctr.ID(), err)
+	}
+	defer rows.Close()
+
+	edges := []StateDependency{}
+	for rows.Next() {
+		var edge StateDependency
+		if err := rows.Scan(&edge.ID, &edge.DependencyID); err != nil {
+			return nil, fmt.Errorf("reading containers that are dependencies of container %s: %w", ctr.ID(), err)
+		}
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return edges, nil
+}
+
 // AllContainers retrieves all the containers in the database
 // If `loadState` is set, the containers' state will be loaded as well.
 func (s *SQLiteState) AllContainers(loadState bool) ([]*Container, error) {
+	return s.AllContainersFiltered(loadState, nil)
+}
+
+// AllContainersFiltered retrieves the containers in the database matching the
+// given filters, which are evaluated by the database using its indexes.
+// If `loadState` is set, the containers' state will be loaded as well.
+func (s *SQLiteState) AllContainersFiltered(loadState bool, filters *StateContainerFilters) ([]*Container, error) {
 	if !s.valid {
 		return nil, define.ErrDBClosed
 	}
 
 	ctrs := []*Container{}
 
+	if s.namespace != "" {
+		nsFilters := StateContainerFilters{}
+		if filters != nil {
+			nsFilters = *filters
+		}
+		nsFilters.namespace = s.namespace
+		filters = &nsFilters
+	}
+	where, args := sqliteContainerFilters(filters)
+
 	if loadState {
-		rows, err := s.conn.Query("SELECT ContainerConfig.JSON, ContainerState.JSON AS StateJSON FROM ContainerConfig INNER JOIN ContainerState ON ContainerConfig.ID = ContainerState.ID;")
+		rows, err := s.query("SELECT ContainerConfig.JSON, ContainerState.JSON AS StateJSON, "+s.dialect.containerStateBlobsColumn()+" FROM ContainerConfig INNER JOIN ContainerState ON ContainerConfig.ID = ContainerState.ID"+where+";", args...)
 		if err != nil {
 			return nil, fmt.Errorf("retrieving all containers from database: %w", err)
 		}
 		defer rows.Close()
 
 		for rows.Next() {
-			var configJSON, stateJSON string
-			if err := rows.Scan(&configJSON, &stateJSON); err != nil {
+			var configJSON, stateJSON, blobsJSON string
+			if err := rows.Scan(&configJSON, &stateJSON, &blobsJSON); err != nil {
 				return nil, fmt.Errorf("scanning container from database: %w", err)
 			}
 
@@ -821,8 +1117,8 @@ func (s *SQLiteState) AllContainers(loadState bool) ([]*Container, error) {
 			if err := json.Unmarshal([]byte(configJSON), ctr.config); err != nil {
 				return nil, fmt.Errorf("unmarshalling container config: %w", err)
 			}
-			if err := json.Unmarshal([]byte(stateJSON), ctr.state); err != nil {
-				return nil, fmt.Errorf("unmarshalling container %s state: %w", ctr.ID(), err)
+			if err := unmarshalContainerState(ctr.ID(), stateJSON, blobsJSON, ctr.state); err != nil {
+				return nil, err
 			}
 
 			ctrs = append(ctrs, ctr)
@@ -831,7 +1127,7 @@ func (s *SQLiteState) AllContainers(loadState bool) ([]*Container, error) {
 			return nil, err
 		}
 	} else {
-		rows, err := s.conn.Query("SELECT JSON FROM ContainerConfig;")
+		rows, err := s.query("SELECT JSON FROM ContainerConfig"+where+";", args...)
 		if err != nil {
 			return nil, fmt.Errorf("retrieving all containers from database: %w", err)
 		}
@@ -874,167 +1170,1702 @@ func (s *SQLiteState) GetNetworks(ctr *Container) (map[string]types.PerNetworkOp
 		return nil, define.ErrDBClosed
 	}
 
-	if !ctr.valid {
-		return nil, define.ErrCtrRemoved
+	if !ctr.valid {
+		return nil, define.ErrCtrRemoved
+	}
+
+	// if the network mode is not bridge return no networks
+	if !ctr.config.NetMode.IsBridge() {
+		return nil, nil
+	}
+
+	rows, err := s.query("SELECT Network, OptionsJSON FROM ContainerNetwork WHERE ContainerID=?;", ctr.ID())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving networks of container %s from database: %w", ctr.ID(), err)
+	}
+	defer rows.Close()
+
+	var networks map[string]types.PerNetworkOptions
+	for rows.Next() {
+		var network, optsJSON string
+		if err := rows.Scan(&network, &optsJSON); err != nil {
+			return nil, fmt.Errorf("scanning network of container %s: %w", ctr.ID(), err)
+		}
+		opts := types.PerNetworkOptions{}
+		if err := json.Unmarshal([]byte(optsJSON), &opts); err != nil {
+			return nil, fmt.Errorf("unmarshalling container %s options of network %s: %w", ctr.ID(), network, err)
+		}
+		if networks == nil {
+			networks = make(map[string]types.PerNetworkOptions)
+		}
+		networks[network] = opts
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// A container without networks may have been removed.
+	if networks == nil {
+		if _, err := s.getCtrConfig(ctr.ID()); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) {
+				ctr.valid = false
+			}
+			return nil, err
+		}
+	}
+
+	return networks, nil
+}
+
+// NetworkContainers returns the containers connected to the given network.
+func (s *SQLiteState) NetworkContainers(network string) ([]*Container, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ContainerConfig.JSON FROM ContainerNetwork INNER JOIN ContainerConfig ON ContainerConfig.ID=ContainerNetwork.ContainerID WHERE ContainerNetwork.Network=?;", network)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving containers of network %s from database: %w", network, err)
+	}
+	defer rows.Close()
+
+	var ctrs []*Container
+	for rows.Next() {
+		var rawJSON string
+		if err := rows.Scan(&rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning container from database: %w", err)
+		}
+
+		ctr := new(Container)
+		ctr.config = new(ContainerConfig)
+		ctr.state = new(ContainerState)
+		ctr.runtime = s.runtime
+
+		if err := json.Unmarshal([]byte(rawJSON), ctr.config); err != nil {
+			return nil, fmt.Errorf("unmarshalling container config: %w", err)
+		}
+
+		ctrs = append(ctrs, ctr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, ctr := range ctrs {
+		if err := finalizeCtrSqlite(ctr); err != nil {
+			return nil, err
+		}
+	}
+
+	return ctrs, nil
+}
+
+// NetworkConnect adds the given container to the given network. If aliases are
+// specified, those will be added to the given network.
+func (s *SQLiteState) NetworkConnect(ctr *Container, network string, opts types.PerNetworkOptions) error {
+	return s.networkModify(ctr, network, opts, true, false)
+}
+
+// NetworkModify will allow you to set new options on an existing connected network
+func (s *SQLiteState) NetworkModify(ctr *Container, network string, opts types.PerNetworkOptions) error {
+	return s.networkModify(ctr, network, opts, false, false)
+}
+
+// NetworkDisconnect disconnects the container from the given network, also
+// removing any aliases in the network.
+func (s *SQLiteState) NetworkDisconnect(ctr *Container, network string) error {
+	return s.networkModify(ctr, network, types.PerNetworkOptions{}, false, true)
+}
+
+// GetContainerConfig returns a container config from the database by full ID
+func (s *SQLiteState) GetContainerConfig(id string) (*ContainerConfig, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	return s.getCtrConfig(id)
+}
+
+// SetContainerMetadata sets the metadata entry with the given key of the
+// container with the given ID. An empty value removes the entry.
+func (s *SQLiteState) SetContainerMetadata(id, key, value string) (defErr error) {
+	if len(id) == 0 {
+		return define.ErrEmptyID
+	}
+	if key == "" {
+		return fmt.Errorf("container metadata key must not be empty: %w", define.ErrInvalidArg)
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to set container metadata: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to set container metadata: %v", err)
+			}
+		}
+	}()
+
+	var check int
+	if err := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=?;", id).Scan(&check); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no container with ID %s found in database: %w", id, define.ErrNoSuchCtr)
+		}
+		return fmt.Errorf("checking if container %s exists in the database: %w", id, err)
+	}
+
+	if value == "" {
+		if _, err := tx.Exec("DELETE FROM ContainerMetadata WHERE ContainerID=? AND Key=?;", id, key); err != nil {
+			return fmt.Errorf("removing metadata %s of container %s: %w", key, id, err)
+		}
+	} else if _, err := tx.Exec(s.dialect.upsert("ContainerMetadata", []string{"ContainerID", "Key"}, "Value"), id, key, value); err != nil {
+		return fmt.Errorf("setting metadata %s of container %s: %w", key, id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to set container metadata: %w", err)
+	}
+
+	return nil
+}
+
+// GetContainerMetadata returns the metadata entries of the container with the
+// given ID.
+func (s *SQLiteState) GetContainerMetadata(id string) (map[string]string, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT Key, Value FROM ContainerMetadata WHERE ContainerID=?;", id)
+	if err != nil {
+		return nil, fmt.Errorf("querying metadata of container %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	metadata := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scanning metadata of container %s: %w", id, err)
+		}
+		metadata[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// AddContainerNote adds a note to the container with the given ID and sets
+// the ID of the note.
+func (s *SQLiteState) AddContainerNote(id string, note *define.ContainerNote) (defErr error) {
+	if len(id) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	noteJSON, err := json.Marshal(note)
+	if err != nil {
+		return fmt.Errorf("marshalling note of container %s: %w", id, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add container note: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add container note: %v", err)
+			}
+		}
+	}()
+
+	var check int
+	if err := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=?;", id).Scan(&check); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no container with ID %s found in database: %w", id, define.ErrNoSuchCtr)
+		}
+		return fmt.Errorf("checking if container %s exists in the database: %w", id, err)
+	}
+
+	var noteID int64
+	if err := tx.QueryRow("INSERT INTO ContainerNote (ContainerID, JSON) VALUES (?, ?) RETURNING ID;", id, noteJSON).Scan(&noteID); err != nil {
+		return fmt.Errorf("adding note of container %s: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add container note: %w", err)
+	}
+
+	note.ID = noteID
+	return nil
+}
+
+// GetContainerNotes returns the notes of the container with the given ID,
+// oldest first.
+func (s *SQLiteState) GetContainerNotes(id string) ([]*define.ContainerNote, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ID, JSON FROM ContainerNote WHERE ContainerID=? ORDER BY ID;", id)
+	if err != nil {
+		return nil, fmt.Errorf("querying notes of container %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	notes := []*define.ContainerNote{}
+	for rows.Next() {
+		var (
+			noteID  int64
+			rawJSON string
+		)
+		if err := rows.Scan(&noteID, &rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning note of container %s: %w", id, err)
+		}
+		note := new(define.ContainerNote)
+		if err := json.Unmarshal([]byte(rawJSON), note); err != nil {
+			return nil, fmt.Errorf("unmarshalling note %d of container %s: %w", noteID, id, err)
+		}
+		note.ID = noteID
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// RemoveContainerNote removes the note with the given note ID from the
+// container with the given ID.
+func (s *SQLiteState) RemoveContainerNote(id string, noteID int64) (defErr error) {
+	if len(id) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove container note: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove container note: %v", err)
+			}
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM ContainerNote WHERE ContainerID=? AND ID=?;", id, noteID)
+	if err != nil {
+		return fmt.Errorf("removing note %d of container %s: %w", noteID, id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving number of removed container notes: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("note %d of container %s: %w", noteID, id, define.ErrNoSuchContainerNote)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove container note: %w", err)
+	}
+
+	return nil
+}
+
+// AddHealthCheckLog records a healthcheck run of the container with the given
+// ID, keeping only the maxEntries most recent runs of the container.
+func (s *SQLiteState) AddHealthCheckLog(id string, entry *define.HealthCheckHistoryEntry, maxEntries uint) (defErr error) {
+	if len(id) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add healthcheck log: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add healthcheck log: %v", err)
+			}
+		}
+	}()
+
+	var check int
+	if err := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=?;", id).Scan(&check); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no container with ID %s found in database: %w", id, define.ErrNoSuchCtr)
+		}
+		return fmt.Errorf("checking if container %s exists in the database: %w", id, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO HealthCheckLog (ContainerID, Status, ExitCode, Output, StartTime, EndTime) VALUES (?, ?, ?, ?, ?, ?);",
+		id, entry.Status, entry.ExitCode, entry.Output, entry.Start, entry.End); err != nil {
+		return fmt.Errorf("adding healthcheck log of container %s: %w", id, err)
+	}
+	if maxEntries > 0 {
+		if _, err := tx.Exec("DELETE FROM HealthCheckLog WHERE ContainerID=? AND ID NOT IN (SELECT ID FROM HealthCheckLog WHERE ContainerID=? ORDER BY ID DESC LIMIT ?);", id, id, maxEntries); err != nil {
+			return fmt.Errorf("removing old healthcheck log of container %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add healthcheck log: %w", err)
+	}
+
+	return nil
+}
+
+// GetHealthCheckLog returns the healthcheck runs recorded for the container
+// with the given ID, oldest first.
+func (s *SQLiteState) GetHealthCheckLog(id string) ([]define.HealthCheckHistoryEntry, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT Status, ExitCode, Output, StartTime, EndTime FROM HealthCheckLog WHERE ContainerID=? ORDER BY ID;", id)
+	if err != nil {
+		return nil, fmt.Errorf("querying healthcheck log of container %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	entries := []define.HealthCheckHistoryEntry{}
+	for rows.Next() {
+		var entry define.HealthCheckHistoryEntry
+		if err := rows.Scan(&entry.Status, &entry.ExitCode, &entry.Output, &entry.Start, &entry.End); err != nil {
+			return nil, fmt.Errorf("scanning healthcheck log of container %s: %w", id, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// AddContainerStats records a resource usage sample of the container with the
+// given ID, keeping only the maxSamples most recent samples of the container.
+func (s *SQLiteState) AddContainerStats(id string, stats *define.ContainerStats, maxSamples uint) (defErr error) {
+	if len(id) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("marshalling stats of container %s to JSON: %w", id, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add container stats: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add container stats: %v", err)
+			}
+		}
+	}()
+
+	var check int
+	if err := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=?;", id).Scan(&check); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no container with ID %s found in database: %w", id, define.ErrNoSuchCtr)
+		}
+		return fmt.Errorf("checking if container %s exists in the database: %w", id, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO ContainerStatsHistory (ContainerID, Time, JSON) VALUES (?, ?, ?);", id, int64(stats.SystemNano), string(statsJSON)); err != nil {
+		return fmt.Errorf("adding stats of container %s: %w", id, err)
+	}
+	if maxSamples > 0 {
+		if _, err := tx.Exec("DELETE FROM ContainerStatsHistory WHERE ContainerID=? AND ID NOT IN (SELECT ID FROM ContainerStatsHistory WHERE ContainerID=? ORDER BY ID DESC LIMIT ?);", id, id, maxSamples); err != nil {
+			return fmt.Errorf("removing old stats of container %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add container stats: %w", err)
+	}
+
+	return nil
+}
+
+// ContainerStatsHistory returns the resource usage samples of the container
+// with the given ID taken after since, oldest first.
+func (s *SQLiteState) ContainerStatsHistory(id string, since time.Time) ([]*define.ContainerStats, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT JSON FROM ContainerStatsHistory WHERE ContainerID=? AND Time>? ORDER BY ID;", id, since.UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("querying stats history of container %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	history := []*define.ContainerStats{}
+	for rows.Next() {
+		var statsJSON string
+		if err := rows.Scan(&statsJSON); err != nil {
+			return nil, fmt.Errorf("scanning stats history of container %s: %w", id, err)
+		}
+		stats := new(define.ContainerStats)
+		if err := json.Unmarshal([]byte(statsJSON), stats); err != nil {
+			return nil, fmt.Errorf("unmarshalling stats of container %s: %w", id, err)
+		}
+		history = append(history, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// AddContainerExitCode adds the exit code for the specified container to the database.
+func (s *SQLiteState) AddContainerExitCode(id string, exitCode int32) (defErr error) {
+	if len(id) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add exit code: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add exit code: %v", err)
+			}
+		}
+	}()
+
+	timestamp := time.Now().Unix()
+	if _, err := tx.Exec(s.dialect.upsert("ContainerExitCode", []string{"ID"}, "Timestamp", "ExitCode"), id, timestamp, exitCode); err != nil {
+		return fmt.Errorf("adding container %s exit code %d: %w", id, exitCode, err)
+	}
+	if _, err := tx.Exec("INSERT INTO ContainerExitHistory (ContainerID, Timestamp, ExitCode) VALUES (?, ?, ?);", id, timestamp, exitCode); err != nil {
+		return fmt.Errorf("adding container %s exit code %d to exit history: %w", id, exitCode, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add exit code: %w", err)
+	}
+
+	return nil
+}
+
+// GetContainerExitCode returns the exit code for the specified container.
+func (s *SQLiteState) GetContainerExitCode(id string) (int32, error) {
+	if len(id) == 0 {
+		return -1, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return -1, define.ErrDBClosed
+	}
+
+	row := s.queryRow("SELECT ExitCode FROM ContainerExitCode WHERE ID=?;", id)
+	var exitCode int32 = -1
+	if err := row.Scan(&exitCode); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, fmt.Errorf("getting exit code of container %s from DB: %w", id, define.ErrNoSuchExitCode)
+		}
+		return -1, fmt.Errorf("scanning exit code of container %s: %w", id, err)
+	}
+
+	return exitCode, nil
+}
+
+// GetContainerExitCodeTimeStamp returns the time stamp when the exit code of
+// the specified container was added to the database.
+func (s *SQLiteState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	row := s.queryRow("SELECT Timestamp FROM ContainerExitCode WHERE ID=?;", id)
+
+	var timestamp int64
+	if err := row.Scan(&timestamp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("getting timestamp for exit code of container %s from DB: %w", id, define.ErrNoSuchExitCode)
+		}
+		return nil, fmt.Errorf("scanning exit timestamp of container %s: %w", id, err)
+	}
+
+	result := time.Unix(timestamp, 0)
+
+	return &result, nil
+}
+
+// PruneContainerExitCodes removes exit codes older than the exit code
+// retention unless the associated container still exists, and the exit
+// history older than the retention.
+func (s *SQLiteState) PruneContainerExitCodes() (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	cutoff := time.Now().Add(-s.runtime.exitCodeRetention()).Unix()
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove old timestamps: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove old timestamps: %v", err)
+			}
+		}
+	}()
+
+	if _, err := tx.Exec("DELETE FROM ContainerExitCode WHERE (Timestamp <= ?) AND (ID NOT IN (SELECT ID FROM ContainerConfig))", cutoff); err != nil {
+		return fmt.Errorf("removing exit codes older than the retention: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM ContainerExitHistory WHERE Timestamp <= ?;", cutoff); err != nil {
+		return fmt.Errorf("removing exit history older than the retention: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove old timestamps: %w", err)
+	}
+
+	return nil
+}
+
+// ContainerExitHistory returns the exits recorded for the container with the
+// given full ID, oldest first.
+func (s *SQLiteState) ContainerExitHistory(id string) ([]define.ContainerExit, error) {
+	if len(id) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ExitCode, Timestamp FROM ContainerExitHistory WHERE ContainerID=? ORDER BY ID;", id)
+	if err != nil {
+		return nil, fmt.Errorf("querying exit history of container %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	exits := []define.ContainerExit{}
+	for rows.Next() {
+		var (
+			exit      define.ContainerExit
+			timestamp int64
+		)
+		if err := rows.Scan(&exit.ExitCode, &timestamp); err != nil {
+			return nil, fmt.Errorf("scanning exit history of container %s: %w", id, err)
+		}
+		exit.ExitedAt = time.Unix(timestamp, 0)
+		exits = append(exits, exit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exits, nil
+}
+
+// AddImageProvenance records a pull of an image. Nothing is recorded if the
+// last pull from the same source resulted in the same image and digest.
+func (s *SQLiteState) AddImageProvenance(record *define.ImageProvenance) (defErr error) {
+	if record.ImageID == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add image provenance: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add image provenance: %v", err)
+			}
+		}
+	}()
+
+	const insert = `INSERT INTO ImageProvenance(Source, ImageID, Digest, Timestamp)
+                SELECT CAST(? AS TEXT), CAST(? AS TEXT), CAST(? AS TEXT), CAST(? AS BIGINT)
+                WHERE NOT EXISTS (
+                        SELECT 1 FROM (SELECT ImageID, Digest FROM ImageProvenance WHERE Source=? ORDER BY ID DESC LIMIT 1) AS LastPull
+                        WHERE ImageID=? AND Digest=?
+                );`
+	if _, err := tx.Exec(insert, record.Source, record.ImageID, record.Digest, record.Pulled.Unix(),
+		record.Source, record.ImageID, record.Digest); err != nil {
+		return fmt.Errorf("adding provenance of image %s: %w", record.ImageID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add image provenance: %w", err)
+	}
+
+	return nil
+}
+
+// ImageProvenance returns the pulls of the image with the given ID, together
+// with all other pulls from the sources the image was pulled from, oldest
+// first.
+func (s *SQLiteState) ImageProvenance(imageID string) ([]*define.ImageProvenance, error) {
+	if imageID == "" {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT Source, ImageID, Digest, Timestamp FROM ImageProvenance WHERE ImageID=? OR Source IN (SELECT Source FROM ImageProvenance WHERE ImageID=?) ORDER BY ID;", imageID, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("querying provenance of image %s: %w", imageID, err)
+	}
+	defer rows.Close()
+
+	records := []*define.ImageProvenance{}
+	for rows.Next() {
+		record := new(define.ImageProvenance)
+		var timestamp int64
+		if err := rows.Scan(&record.Source, &record.ImageID, &record.Digest, &timestamp); err != nil {
+			return nil, fmt.Errorf("scanning provenance of image %s: %w", imageID, err)
+		}
+		record.Pulled = time.Unix(timestamp, 0)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// SetImageDigestPin pins the digest of an image source to the given pull,
+// replacing any earlier pin of the source.
+func (s *SQLiteState) SetImageDigestPin(pin *define.ImageProvenance) (defErr error) {
+	if pin.ImageID == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to pin image digest: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to pin image digest: %v", err)
+			}
+		}
+	}()
+
+	if _, err := tx.Exec(s.dialect.upsert("ImageDigestPin", []string{"Source"}, "ImageID", "Digest", "Timestamp"), pin.Source, pin.ImageID, pin.Digest, pin.Pulled.Unix()); err != nil {
+		return fmt.Errorf("pinning digest of image source %s: %w", pin.Source, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to pin image digest: %w", err)
+	}
+
+	return nil
+}
+
+// ImageDigestPin returns the pull the given image source is pinned to.
+func (s *SQLiteState) ImageDigestPin(source string) (*define.ImageProvenance, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	pin := &define.ImageProvenance{Source: source}
+	var timestamp int64
+	row := s.queryRow("SELECT ImageID, Digest, Timestamp FROM ImageDigestPin WHERE Source=?;", source)
+	if err := row.Scan(&pin.ImageID, &pin.Digest, &timestamp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("image source %s: %w", source, define.ErrNoSuchImageDigestPin)
+		}
+		return nil, fmt.Errorf("retrieving digest pin of image source %s: %w", source, err)
+	}
+	pin.Pulled = time.Unix(timestamp, 0)
+
+	return pin, nil
+}
+
+// SetVolumeBackupPolicy sets the backup policy of a volume, replacing any
+// earlier policy of the volume.
+func (s *SQLiteState) SetVolumeBackupPolicy(policy *define.VolumeBackupPolicy) (defErr error) {
+	if policy.Volume == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("marshalling backup policy of volume %s: %w", policy.Volume, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to set volume backup policy: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to set volume backup policy: %v", err)
+			}
+		}
+	}()
+
+	if _, err := tx.Exec(s.dialect.upsert("VolumeBackupPolicy", []string{"Volume"}, "JSON"), policy.Volume, policyJSON); err != nil {
+		return fmt.Errorf("setting backup policy of volume %s: %w", policy.Volume, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to set volume backup policy: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveVolumeBackupPolicy removes the backup policy of the volume with the
+// given name.
+func (s *SQLiteState) RemoveVolumeBackupPolicy(volume string) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove volume backup policy: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove volume backup policy: %v", err)
+			}
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM VolumeBackupPolicy WHERE Volume=?;", volume)
+	if err != nil {
+		return fmt.Errorf("removing backup policy of volume %s: %w", volume, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving number of removed volume backup policies: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("backup policy of volume %s: %w", volume, define.ErrNoSuchVolumeBackup)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove volume backup policy: %w", err)
+	}
+
+	return nil
+}
+
+// VolumeBackupPolicies returns the backup policies of all volumes.
+func (s *SQLiteState) VolumeBackupPolicies() ([]*define.VolumeBackupPolicy, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT JSON FROM VolumeBackupPolicy ORDER BY Volume;")
+	if err != nil {
+		return nil, fmt.Errorf("querying volume backup policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []*define.VolumeBackupPolicy{}
+	for rows.Next() {
+		var rawJSON string
+		if err := rows.Scan(&rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning volume backup policy: %w", err)
+		}
+		policy := new(define.VolumeBackupPolicy)
+		if err := json.Unmarshal([]byte(rawJSON), policy); err != nil {
+			return nil, fmt.Errorf("unmarshalling volume backup policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// AddVolumeBackup records a backup of a volume and sets its ID.
+func (s *SQLiteState) AddVolumeBackup(backup *define.VolumeBackup) (defErr error) {
+	if backup.Volume == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	backupJSON, err := json.Marshal(backup)
+	if err != nil {
+		return fmt.Errorf("marshalling backup of volume %s: %w", backup.Volume, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add volume backup: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add volume backup: %v", err)
+			}
+		}
+	}()
+
+	var id int64
+	if err := tx.QueryRow("INSERT INTO VolumeBackup (Volume, JSON) VALUES (?, ?) RETURNING ID;", backup.Volume, backupJSON).Scan(&id); err != nil {
+		return fmt.Errorf("adding backup of volume %s: %w", backup.Volume, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add volume backup: %w", err)
+	}
+
+	backup.ID = id
+	return nil
+}
+
+// VolumeBackups returns the backups of the volume with the given name, or of
+// all volumes if the name is empty, oldest first.
+func (s *SQLiteState) VolumeBackups(volume string) ([]*define.VolumeBackup, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	query, args := "SELECT ID, JSON FROM VolumeBackup ORDER BY ID;", []any{}
+	if volume != "" {
+		query, args = "SELECT ID, JSON FROM VolumeBackup WHERE Volume=? ORDER BY ID;", []any{volume}
+	}
+	rows, err := s.query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying volume backups: %w", err)
+	}
+	defer rows.Close()
+
+	backups := []*define.VolumeBackup{}
+	for rows.Next() {
+		var (
+			id      int64
+			rawJSON string
+		)
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning volume backup: %w", err)
+		}
+		backup := new(define.VolumeBackup)
+		if err := json.Unmarshal([]byte(rawJSON), backup); err != nil {
+			return nil, fmt.Errorf("unmarshalling volume backup %d: %w", id, err)
+		}
+		backup.ID = id
+		backups = append(backups, backup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+// RemoveVolumeBackup removes the backup with the given ID.
+func (s *SQLiteState) RemoveVolumeBackup(id int64) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove volume backup: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove volume backup: %v", err)
+			}
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM VolumeBackup WHERE ID=?;", id)
+	if err != nil {
+		return fmt.Errorf("removing volume backup %d: %w", id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving number of removed volume backups: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("volume backup %d: %w", id, define.ErrNoSuchVolumeBackup)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove volume backup: %w", err)
+	}
+
+	return nil
+}
+
+// AddVolumeSnapshot records a snapshot of a volume and sets its ID.
+func (s *SQLiteState) AddVolumeSnapshot(snapshot *define.VolumeSnapshot) (defErr error) {
+	if snapshot.Volume == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("marshalling snapshot of volume %s: %w", snapshot.Volume, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add volume snapshot: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add volume snapshot: %v", err)
+			}
+		}
+	}()
+
+	var id int64
+	if err := tx.QueryRow("INSERT INTO VolumeSnapshot (Volume, JSON) VALUES (?, ?) RETURNING ID;", snapshot.Volume, snapshotJSON).Scan(&id); err != nil {
+		return fmt.Errorf("adding snapshot of volume %s: %w", snapshot.Volume, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add volume snapshot: %w", err)
+	}
+
+	snapshot.ID = id
+	return nil
+}
+
+// VolumeSnapshots returns the snapshots of the volume with the given name, or
+// of all volumes if the name is empty, oldest first.
+func (s *SQLiteState) VolumeSnapshots(volume string) ([]*define.VolumeSnapshot, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	query, args := "SELECT ID, JSON FROM VolumeSnapshot ORDER BY ID;", []any{}
+	if volume != "" {
+		query, args = "SELECT ID, JSON FROM VolumeSnapshot WHERE Volume=? ORDER BY ID;", []any{volume}
+	}
+	rows, err := s.query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying volume snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []*define.VolumeSnapshot{}
+	for rows.Next() {
+		var (
+			id      int64
+			rawJSON string
+		)
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning volume snapshot: %w", err)
+		}
+		snapshot := new(define.VolumeSnapshot)
+		if err := json.Unmarshal([]byte(rawJSON), snapshot); err != nil {
+			return nil, fmt.Errorf("unmarshalling volume snapshot %d: %w", id, err)
+		}
+		snapshot.ID = id
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// RemoveVolumeSnapshot removes the snapshot with the given ID.
+func (s *SQLiteState) RemoveVolumeSnapshot(id int64) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove volume snapshot: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove volume snapshot: %v", err)
+			}
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM VolumeSnapshot WHERE ID=?;", id)
+	if err != nil {
+		return fmt.Errorf("removing volume snapshot %d: %w", id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving number of removed volume snapshots: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("volume snapshot %d: %w", id, define.ErrNoSuchVolumeSnapshot)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove volume snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// AddNetworkReservation reserves an address of a network for a container.
+func (s *SQLiteState) AddNetworkReservation(reservation *define.NetworkReservation) (defErr error) {
+	if reservation.Network == "" || reservation.Address == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	reservationJSON, err := json.Marshal(reservation)
+	if err != nil {
+		return fmt.Errorf("marshalling reservation of %s on network %s: %w", reservation.Address, reservation.Network, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add network reservation: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add network reservation: %v", err)
+			}
+		}
+	}()
+
+	var check int
+	row := tx.QueryRow("SELECT 1 FROM NetworkReservation WHERE Network=? AND Address=?;", reservation.Network, reservation.Address)
+	switch err := row.Scan(&check); {
+	case err == nil:
+		return fmt.Errorf("address %s on network %s: %w", reservation.Address, reservation.Network, define.ErrNetworkReserved)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("checking reservation of %s on network %s: %w", reservation.Address, reservation.Network, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO NetworkReservation VALUES (?, ?, ?);", reservation.Network, reservation.Address, reservationJSON); err != nil {
+		return fmt.Errorf("reserving %s on network %s: %w", reservation.Address, reservation.Network, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add network reservation: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveNetworkReservation removes the reservation of the given address of a
+// network, or all reservations of the network if the address is empty.
+func (s *SQLiteState) RemoveNetworkReservation(network, address string) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove network reservation: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove network reservation: %v", err)
+			}
+		}
+	}()
+
+	if address == "" {
+		if _, err := tx.Exec("DELETE FROM NetworkReservation WHERE Network=?;", network); err != nil {
+			return fmt.Errorf("removing reservations of network %s: %w", network, err)
+		}
+	} else {
+		result, err := tx.Exec("DELETE FROM NetworkReservation WHERE Network=? AND Address=?;", network, address)
+		if err != nil {
+			return fmt.Errorf("removing reservation of %s on network %s: %w", address, network, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("retrieving number of removed network reservations: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("address %s on network %s: %w", address, network, define.ErrNoSuchNetworkReservation)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove network reservation: %w", err)
+	}
+
+	return nil
+}
+
+// NetworkReservations returns the reservations of the given network, or of
+// all networks if the name is empty.
+func (s *SQLiteState) NetworkReservations(network string) ([]*define.NetworkReservation, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	query, args := "SELECT JSON FROM NetworkReservation ORDER BY Network, Address;", []any{}
+	if network != "" {
+		query, args = "SELECT JSON FROM NetworkReservation WHERE Network=? ORDER BY Address;", []any{network}
+	}
+	rows, err := s.query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying network reservations: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []*define.NetworkReservation{}
+	for rows.Next() {
+		var rawJSON string
+		if err := rows.Scan(&rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning network reservation: %w", err)
+		}
+		reservation := new(define.NetworkReservation)
+		if err := json.Unmarshal([]byte(rawJSON), reservation); err != nil {
+			return nil, fmt.Errorf("unmarshalling network reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// AddContainerGroup adds a container group.
+func (s *SQLiteState) AddContainerGroup(group *define.ContainerGroup) (defErr error) {
+	if group.Name == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	groupJSON, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("marshalling container group %s: %w", group.Name, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add container group %s: %w", group.Name, err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add container group %s: %v", group.Name, err)
+			}
+		}
+	}()
+
+	var check int
+	row := tx.QueryRow("SELECT 1 FROM ContainerGroup WHERE Name=?;", group.Name)
+	switch err := row.Scan(&check); {
+	case err == nil:
+		return fmt.Errorf("container group %s: %w", group.Name, define.ErrContainerGroupExists)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("checking container group %s: %w", group.Name, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO ContainerGroup VALUES (?, ?);", group.Name, groupJSON); err != nil {
+		return fmt.Errorf("adding container group %s to database: %w", group.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add container group %s: %w", group.Name, err)
+	}
+
+	return nil
+}
+
+// RemoveContainerGroup removes the container group with the given name.
+func (s *SQLiteState) RemoveContainerGroup(name string) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove container group %s: %w", name, err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove container group %s: %v", name, err)
+			}
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM ContainerGroup WHERE Name=?;", name)
+	if err != nil {
+		return fmt.Errorf("removing container group %s: %w", name, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving number of removed container groups: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("container group %s: %w", name, define.ErrNoSuchContainerGroup)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove container group %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ContainerGroup returns the container group with the given name.
+func (s *SQLiteState) ContainerGroup(name string) (*define.ContainerGroup, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	var rawJSON string
+	if err := s.queryRow("SELECT JSON FROM ContainerGroup WHERE Name=?;", name).Scan(&rawJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("container group %s: %w", name, define.ErrNoSuchContainerGroup)
+		}
+		return nil, fmt.Errorf("retrieving container group %s: %w", name, err)
+	}
+	group := new(define.ContainerGroup)
+	if err := json.Unmarshal([]byte(rawJSON), group); err != nil {
+		return nil, fmt.Errorf("unmarshalling container group %s: %w", name, err)
+	}
+	return group, nil
+}
+
+// ContainerGroups returns all container groups, ordered by name.
+func (s *SQLiteState) ContainerGroups() ([]*define.ContainerGroup, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT JSON FROM ContainerGroup ORDER BY Name;")
+	if err != nil {
+		return nil, fmt.Errorf("querying container groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*define.ContainerGroup{}
+	for rows.Next() {
+		var rawJSON string
+		if err := rows.Scan(&rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning container group: %w", err)
+		}
+		group := new(define.ContainerGroup)
+		if err := json.Unmarshal([]byte(rawJSON), group); err != nil {
+			return nil, fmt.Errorf("unmarshalling container group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// AddCheckpoint records a checkpoint exported to a file and sets its ID.
+func (s *SQLiteState) AddCheckpoint(record *define.CheckpointRecord) (defErr error) {
+	if record.ContainerID == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshalling checkpoint of container %s: %w", record.ContainerID, err)
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to add checkpoint: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add checkpoint: %v", err)
+			}
+		}
+	}()
+
+	var id int64
+	if err := tx.QueryRow("INSERT INTO Checkpoint (ContainerID, JSON) VALUES (?, ?) RETURNING ID;", record.ContainerID, recordJSON).Scan(&id); err != nil {
+		return fmt.Errorf("adding checkpoint of container %s: %w", record.ContainerID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to add checkpoint: %w", err)
+	}
+
+	record.ID = id
+	return nil
+}
+
+// Checkpoints returns the checkpoints of the container with the given ID, or
+// of all containers if the ID is empty, oldest first.
+func (s *SQLiteState) Checkpoints(ctrID string) ([]*define.CheckpointRecord, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	query, args := "SELECT ID, JSON FROM Checkpoint ORDER BY ID;", []any{}
+	if ctrID != "" {
+		query, args = "SELECT ID, JSON FROM Checkpoint WHERE ContainerID=? ORDER BY ID;", []any{ctrID}
+	}
+	rows, err := s.query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*define.CheckpointRecord{}
+	for rows.Next() {
+		var (
+			id      int64
+			rawJSON string
+		)
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning checkpoint: %w", err)
+		}
+		record := new(define.CheckpointRecord)
+		if err := json.Unmarshal([]byte(rawJSON), record); err != nil {
+			return nil, fmt.Errorf("unmarshalling checkpoint %d: %w", id, err)
+		}
+		record.ID = id
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// RemoveCheckpoint removes the checkpoint record with the given ID.
+func (s *SQLiteState) RemoveCheckpoint(id int64) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to remove checkpoint: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove checkpoint: %v", err)
+			}
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM Checkpoint WHERE ID=?;", id)
+	if err != nil {
+		return fmt.Errorf("removing checkpoint %d: %w", id, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving number of removed checkpoints: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("checkpoint %d: %w", id, define.ErrNoSuchCheckpoint)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to remove checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Verify checks the consistency of the database and reports orphaned
+// entries.
+func (s *SQLiteState) Verify() (*define.DBCheckReport, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	report := &define.DBCheckReport{Backend: s.dialect.backend()}
+
+	var err error
+	report.Errors, err = s.dialect.checkIntegrity(s)
+	if err != nil {
+		return nil, err
 	}
 
-	// if the network mode is not bridge return no networks
-	if !ctr.config.NetMode.IsBridge() {
-		return nil, nil
+	cutoff := time.Now().Add(-s.runtime.exitCodeRetention()).Unix()
+	report.OrphanExitCodes, err = s.queryStrings("SELECT ID FROM ContainerExitCode WHERE (Timestamp <= ?) AND (ID NOT IN (SELECT ID FROM ContainerConfig));", cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("checking for orphaned exit codes: %w", err)
 	}
 
-	cfg, err := s.getCtrConfig(ctr.ID())
+	report.OrphanExecSessions, err = s.queryStrings("SELECT ID FROM ContainerExecSession WHERE ContainerID NOT IN (SELECT ID FROM ContainerConfig);")
 	if err != nil {
-		if errors.Is(err, define.ErrNoSuchCtr) {
-			ctr.valid = false
-		}
-		return nil, err
+		return nil, fmt.Errorf("checking for orphaned exec sessions: %w", err)
 	}
 
-	return cfg.Networks, nil
-}
+	report.OrphanDependencies, err = s.queryStrings("SELECT ID || ' -> ' || DependencyID FROM ContainerDependency WHERE (ID NOT IN (SELECT ID FROM ContainerConfig)) OR (DependencyID NOT IN (SELECT ID FROM ContainerConfig));")
+	if err != nil {
+		return nil, fmt.Errorf("checking for orphaned container dependencies: %w", err)
+	}
 
-// NetworkConnect adds the given container to the given network. If aliases are
-// specified, those will be added to the given network.
-func (s *SQLiteState) NetworkConnect(ctr *Container, network string, opts types.PerNetworkOptions) error {
-	return s.networkModify(ctr, network, opts, true, false)
+	return report, nil
 }
 
-// NetworkModify will allow you to set new options on an existing connected network
-func (s *SQLiteState) NetworkModify(ctr *Container, network string, opts types.PerNetworkOptions) error {
-	return s.networkModify(ctr, network, opts, false, false)
-}
+// queryStrings returns the first column of all rows returned by the query.
+func (s *SQLiteState) queryStrings(query string, args ...any) ([]string, error) {
+	rows, err := s.query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-// NetworkDisconnect disconnects the container from the given network, also
-// removing any aliases in the network.
-func (s *SQLiteState) NetworkDisconnect(ctr *Container, network string) error {
-	return s.networkModify(ctr, network, types.PerNetworkOptions{}, false, true)
+	var result []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-// GetContainerConfig returns a container config from the database by full ID
-func (s *SQLiteState) GetContainerConfig(id string) (*ContainerConfig, error) {
-	if len(id) == 0 {
-		return nil, define.ErrEmptyID
+// Vacuum reclaims the space of removed entries of the database.
+func (s *SQLiteState) Vacuum() error {
+	if !s.valid {
+		return define.ErrDBClosed
 	}
 
+	return s.dialect.vacuum(s)
+}
+
+// CheckpointWAL copies the content of the write-ahead log into the database
+// and truncates the log. This is a no-op unless the SQLite WAL journal mode
+// is used.
+func (s *SQLiteState) CheckpointWAL() error {
 	if !s.valid {
-		return nil, define.ErrDBClosed
+		return define.ErrDBClosed
 	}
 
-	return s.getCtrConfig(id)
+	return s.dialect.checkpointWAL(s)
 }
 
-// AddContainerExitCode adds the exit code for the specified container to the database.
-func (s *SQLiteState) AddContainerExitCode(id string, exitCode int32) (defErr error) {
-	if len(id) == 0 {
-		return define.ErrEmptyID
+// Backup writes a consistent copy of the database to the given path.
+func (s *SQLiteState) Backup(path string) error {
+	if !s.valid {
+		return define.ErrDBClosed
 	}
 
+	return s.dialect.backup(s, path)
+}
+
+// RecordMaintenance records that the given maintenance operation of the
+// database finished with the given result.
+func (s *SQLiteState) RecordMaintenance(operation, result string) (defErr error) {
 	if !s.valid {
 		return define.ErrDBClosed
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
-		return fmt.Errorf("beginning transaction to add exit code: %w", err)
+		return fmt.Errorf("beginning transaction to record database %s: %w", operation, err)
 	}
 	defer func() {
 		if defErr != nil {
 			if err := tx.Rollback(); err != nil {
-				logrus.Errorf("Rolling back transaction to add exit code: %v", err)
+				logrus.Errorf("Rolling back transaction to record database %s: %v", operation, err)
 			}
 		}
 	}()
 
-	if _, err := tx.Exec("INSERT OR REPLACE INTO ContainerExitCode VALUES (?, ?, ?);", id, time.Now().Unix(), exitCode); err != nil {
-		return fmt.Errorf("adding container %s exit code %d: %w", id, exitCode, err)
+	if err := recordDBMaintenance(tx.Tx, operation, result, s.dialect.bindVars); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing transaction to add exit code: %w", err)
+		return fmt.Errorf("committing transaction to record database %s: %w", operation, err)
 	}
 
 	return nil
 }
 
-// GetContainerExitCode returns the exit code for the specified container.
-func (s *SQLiteState) GetContainerExitCode(id string) (int32, error) {
-	if len(id) == 0 {
-		return -1, define.ErrEmptyID
-	}
-
+// DatabaseInfo describes the database, its write-ahead log and its last
+// recorded maintenance operations.
+func (s *SQLiteState) DatabaseInfo() (*define.DatabaseBackendInfo, error) {
 	if !s.valid {
-		return -1, define.ErrDBClosed
+		return nil, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT ExitCode FROM ContainerExitCode WHERE ID=?;", id)
-	var exitCode int32 = -1
-	if err := row.Scan(&exitCode); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return -1, fmt.Errorf("getting exit code of container %s from DB: %w", id, define.ErrNoSuchExitCode)
-		}
-		return -1, fmt.Errorf("scanning exit code of container %s: %w", id, err)
+	info := &define.DatabaseBackendInfo{Type: s.dialect.backend()}
+	if err := s.dialect.databaseInfo(s, info); err != nil {
+		return nil, err
 	}
 
-	return exitCode, nil
-}
+	// The database config is written when the runtime validates it.
+	if err := s.queryRow("SELECT SchemaVersion FROM DBConfig;").Scan(&info.SchemaVersion); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("retrieving database schema version: %w", err)
+	}
 
-// GetContainerExitCodeTimeStamp returns the time stamp when the exit code of
-// the specified container was added to the database.
-func (s *SQLiteState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error) {
-	if len(id) == 0 {
-		return nil, define.ErrEmptyID
+	if err := readDBMaintenance(s.conn, info); err != nil {
+		return nil, err
 	}
+	return info, nil
+}
 
+// StateChanges returns the changes recorded by the triggers of the database
+// with an ID greater than afterID, oldest first.
+func (s *SQLiteState) StateChanges(afterID int64) ([]*define.StateChange, error) {
 	if !s.valid {
 		return nil, define.ErrDBClosed
 	}
+	if !s.dialect.recordsStateChanges() {
+		return nil, fmt.Errorf("retrieving state changes from the %s database: %w", s.dialect.backend(), define.ErrNotImplemented)
+	}
 
-	row := s.conn.QueryRow("SELECT Timestamp FROM ContainerExitCode WHERE ID=?;", id)
+	rows, err := s.query("SELECT ID, Timestamp, Type, ObjectID, Operation FROM StateChange WHERE ID>? ORDER BY ID;", afterID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving state changes from database: %w", err)
+	}
+	defer rows.Close()
 
-	var timestamp int64
-	if err := row.Scan(&timestamp); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("getting timestamp for exit code of container %s from DB: %w", id, define.ErrNoSuchExitCode)
+	changes := []*define.StateChange{}
+	for rows.Next() {
+		var (
+			change    define.StateChange
+			timestamp int64
+		)
+		if err := rows.Scan(&change.ID, &timestamp, &change.Type, &change.ObjectID, &change.Operation); err != nil {
+			return nil, fmt.Errorf("scanning state change row: %w", err)
 		}
-		return nil, fmt.Errorf("scanning exit timestamp of container %s: %w", id, err)
+		change.Time = time.UnixMilli(timestamp)
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	result := time.Unix(timestamp, 0)
-
-	return &result, nil
+	return changes, nil
 }
 
-// PruneContainerExitCodes removes exit codes older than 5 minutes unless the associated
-// container still exists.
-func (s *SQLiteState) PruneContainerExitCodes() (defErr error) {
+// LatestStateChange returns the ID of the most recent change recorded by the
+// triggers of the database.
+func (s *SQLiteState) LatestStateChange() (int64, error) {
 	if !s.valid {
-		return define.ErrDBClosed
-	}
-
-	fiveMinsAgo := time.Now().Add(-5 * time.Minute).Unix()
-
-	tx, err := s.conn.Begin()
-	if err != nil {
-		return fmt.Errorf("beginning transaction to remove old timestamps: %w", err)
+		return 0, define.ErrDBClosed
 	}
-	defer func() {
-		if defErr != nil {
-			if err := tx.Rollback(); err != nil {
-				logrus.Errorf("Rolling back transaction to remove old timestamps: %v", err)
-			}
-		}
-	}()
-
-	if _, err := tx.Exec("DELETE FROM ContainerExitCode WHERE (Timestamp <= ?) AND (ID NOT IN (SELECT ID FROM ContainerConfig))", fiveMinsAgo); err != nil {
-		return fmt.Errorf("removing exit codes with timestamps older than 5 minutes: %w", err)
+	if !s.dialect.recordsStateChanges() {
+		return 0, fmt.Errorf("retrieving state changes from the %s database: %w", s.dialect.backend(), define.ErrNotImplemented)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing transaction to remove old timestamps: %w", err)
+	var id int64
+	if err := s.queryRow("SELECT COALESCE(MAX(ID), 0) FROM StateChange;").Scan(&id); err != nil {
+		return 0, fmt.Errorf("retrieving latest state change from database: %w", err)
 	}
-
-	return nil
+	return id, nil
 }
 
 // AddExecSession adds an exec session to the state.
@@ -1047,7 +2878,7 @@ func (s *SQLiteState) AddExecSession(ctr *Container, session *ExecSession) (defE
 		return define.ErrCtrRemoved
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning container %s exec session %s add transaction: %w", ctr.ID(), session.Id, err)
 	}
@@ -1059,7 +2890,12 @@ func (s *SQLiteState) AddExecSession(ctr *Container, session *ExecSession) (defE
 		}
 	}()
 
-	if _, err := tx.Exec("INSERT INTO ContainerExecSession VALUES (?, ?);", session.Id, ctr.ID()); err != nil {
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshalling container %s exec session %s JSON: %w", ctr.ID(), session.Id, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO ContainerExecSession VALUES (?, ?, ?);", session.Id, ctr.ID(), sessionJSON); err != nil {
 		return fmt.Errorf("adding container %s exec session %s to database: %w", ctr.ID(), session.Id, err)
 	}
 
@@ -1081,7 +2917,7 @@ func (s *SQLiteState) GetExecSession(id string) (string, error) {
 		return "", define.ErrEmptyID
 	}
 
-	row := s.conn.QueryRow("SELECT ContainerID FROM ContainerExecSession WHERE ID=?;", id)
+	row := s.queryRow("SELECT ContainerID FROM ContainerExecSession WHERE ID=?;", id)
 
 	var ctrID string
 	if err := row.Scan(&ctrID); err != nil {
@@ -1094,6 +2930,60 @@ func (s *SQLiteState) GetExecSession(id string) (string, error) {
 	return ctrID, nil
 }
 
+// ExecSession retrieves the exec session with the given ID as it was last
+// saved with its container.
+func (s *SQLiteState) ExecSession(id string) (*ExecSession, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	if id == "" {
+		return nil, define.ErrEmptyID
+	}
+
+	var ctrID, rawJSON string
+	row := s.queryRow("SELECT ContainerID, JSON FROM ContainerExecSession WHERE ID=?;", id)
+	if err := row.Scan(&ctrID, &rawJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no exec session with ID %s found: %w", id, define.ErrNoSuchExecSession)
+		}
+		return nil, fmt.Errorf("retrieving exec session %s from database: %w", id, err)
+	}
+
+	return unmarshalExecSession(id, ctrID, rawJSON)
+}
+
+// AllExecSessions retrieves all exec sessions in the database.
+func (s *SQLiteState) AllExecSessions() ([]*ExecSession, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.query("SELECT ID, ContainerID, JSON FROM ContainerExecSession;")
+	if err != nil {
+		return nil, fmt.Errorf("querying exec sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*ExecSession{}
+	for rows.Next() {
+		var id, ctrID, rawJSON string
+		if err := rows.Scan(&id, &ctrID, &rawJSON); err != nil {
+			return nil, fmt.Errorf("scanning exec sessions row: %w", err)
+		}
+		session, err := unmarshalExecSession(id, ctrID, rawJSON)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
 // RemoveExecSession removes references to the given exec session in the
 // database.
 func (s *SQLiteState) RemoveExecSession(session *ExecSession) (defErr error) {
@@ -1101,7 +2991,7 @@ func (s *SQLiteState) RemoveExecSession(session *ExecSession) (defErr error) {
 		return define.ErrDBClosed
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning container %s exec session %s remove transaction: %w", session.ContainerId, session.Id, err)
 	}
@@ -1143,7 +3033,7 @@ func (s *SQLiteState) GetContainerExecSessions(ctr *Container) ([]string, error)
 		return nil, define.ErrCtrRemoved
 	}
 
-	rows, err := s.conn.Query("SELECT ID FROM ContainerExecSession WHERE ContainerID=?;", ctr.ID())
+	rows, err := s.query("SELECT ID FROM ContainerExecSession WHERE ContainerID=?;", ctr.ID())
 	if err != nil {
 		return nil, fmt.Errorf("querying container %s exec sessions: %w", ctr.ID(), err)
 	}
@@ -1175,7 +3065,7 @@ func (s *SQLiteState) RemoveContainerExecSessions(ctr *Container) (defErr error)
 		return define.ErrCtrRemoved
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning container %s exec session removal transaction: %w", ctr.ID(), err)
 	}
@@ -1241,6 +3131,76 @@ func (s *SQLiteState) SafeRewriteContainerConfig(ctr *Container, oldName, newNam
 	return s.rewriteContainerConfig(ctr, newCfg)
 }
 
+// RenameContainer renames the given container. Its configuration is read,
+// renamed and written back in a single transaction.
+func (s *SQLiteState) RenameContainer(ctr *Container, newName string) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return define.ErrCtrRemoved
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to rename container %s: %w", ctr.ID(), err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to rename container %s: %v", ctr.ID(), err)
+			}
+		}
+	}()
+
+	if err := renameContainerWithTx(tx.Tx, ctr, newName, s.dialect.bindVars); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to rename container %s: %w", ctr.ID(), err)
+	}
+
+	ctr.config.Name = newName
+	return nil
+}
+
+// UpdateContainerResources sets the resource limits of the given container.
+// Its configuration is read, updated and written back in a single transaction.
+func (s *SQLiteState) UpdateContainerResources(ctr *Container, resources *spec.LinuxResources) (defErr error) {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return define.ErrCtrRemoved
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction to update resources of container %s: %w", ctr.ID(), err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to update resources of container %s: %v", ctr.ID(), err)
+			}
+		}
+	}()
+
+	if err := updateContainerResourcesWithTx(tx.Tx, ctr, resources, s.dialect.bindVars); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction to update resources of container %s: %w", ctr.ID(), err)
+	}
+
+	setConfigResources(ctr.config, resources)
+	return nil
+}
+
 // RewritePodConfig rewrites a pod's configuration.
 // WARNING: This function is DANGEROUS. Do not use without reading the full
 // comment on this function in state.go.
@@ -1258,7 +3218,7 @@ func (s *SQLiteState) RewritePodConfig(pod *Pod, newCfg *PodConfig) (defErr erro
 		return fmt.Errorf("error marshalling pod %s config JSON: %w", pod.ID(), err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction to rewrite pod %s config: %w", pod.ID(), err)
 	}
@@ -1282,6 +3242,12 @@ func (s *SQLiteState) RewritePodConfig(pod *Pod, newCfg *PodConfig) (defErr erro
 		pod.valid = false
 		return fmt.Errorf("no pod with ID %s found in DB: %w", pod.ID(), define.ErrNoSuchPod)
 	}
+	if _, err := tx.Exec("DELETE FROM PodSharedNamespace WHERE PodID=?;", pod.ID()); err != nil {
+		return fmt.Errorf("removing pod %s shared namespaces from database: %w", pod.ID(), err)
+	}
+	if err := insertPodSharedNamespaces(tx.Tx, newCfg, s.dialect.bindVars); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction to rewrite pod %s config: %w", pod.ID(), err)
@@ -1307,7 +3273,7 @@ func (s *SQLiteState) RewriteVolumeConfig(volume *Volume, newCfg *VolumeConfig)
 		return fmt.Errorf("error marshalling volume %s new config JSON: %w", volume.Name(), err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction to rewrite volume %s config: %w", volume.Name(), err)
 	}
@@ -1349,7 +3315,7 @@ func (s *SQLiteState) Pod(id string) (*Pod, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT JSON FROM PodConfig WHERE ID=?;", id)
+	row := s.queryRow("SELECT JSON FROM PodConfig WHERE ID=?;", id)
 	var rawJSON string
 	if err := row.Scan(&rawJSON); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -1358,12 +3324,15 @@ func (s *SQLiteState) Pod(id string) (*Pod, error) {
 		return nil, fmt.Errorf("retrieving pod %s config from DB: %w", id, err)
 	}
 
-	ctrCfg := new(ContainerConfig)
-	if err := json.Unmarshal([]byte(rawJSON), ctrCfg); err != nil {
-		return nil, fmt.Errorf("unmarshalling container %s config: %w", id, err)
+	pod, err := s.createPod(rawJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNamespace("pod", id, pod.config.Namespace); err != nil {
+		return nil, err
 	}
 
-	return s.createPod(rawJSON)
+	return pod, nil
 }
 
 // LookupPod retrieves a pod from a full or unique partial ID, or a name.
@@ -1376,7 +3345,8 @@ func (s *SQLiteState) LookupPod(idOrName string) (*Pod, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	rows, err := s.conn.Query("SELECT JSON, Name FROM PodConfig WHERE PodConfig.Name=? OR (PodConfig.ID LIKE ?);", idOrName, idOrName+"%")
+	nsCond, nsArgs := s.namespaceCondition("PodConfig")
+	rows, err := s.query("SELECT JSON, Name FROM PodConfig WHERE (PodConfig.Name=? OR (PodConfig.ID LIKE ?))"+nsCond+";", append([]any{idOrName, idOrName + "%"}, nsArgs...)...)
 	if err != nil {
 		return nil, fmt.Errorf("looking up pod %q in database: %w", idOrName, err)
 	}
@@ -1421,7 +3391,8 @@ func (s *SQLiteState) HasPod(id string) (bool, error) {
 		return false, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT 1 FROM PodConfig WHERE ID=?;", id)
+	nsCond, nsArgs := s.namespaceCondition("PodConfig")
+	row := s.queryRow("SELECT 1 FROM PodConfig WHERE ID=?"+nsCond+";", append([]any{id}, nsArgs...)...)
 
 	var check int
 	if err := row.Scan(&check); err != nil {
@@ -1451,7 +3422,7 @@ func (s *SQLiteState) PodHasContainer(pod *Pod, id string) (bool, error) {
 	}
 
 	var check int
-	row := s.conn.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=? AND PodID=?;", id, pod.ID())
+	row := s.queryRow("SELECT 1 FROM PodContainer WHERE ContainerID=? AND PodID=?;", id, pod.ID())
 	if err := row.Scan(&check); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -1474,7 +3445,7 @@ func (s *SQLiteState) PodContainersByID(pod *Pod) ([]string, error) {
 		return nil, define.ErrPodRemoved
 	}
 
-	rows, err := s.conn.Query("SELECT ID FROM ContainerConfig WHERE PodID=?;", pod.ID())
+	rows, err := s.query("SELECT ContainerID FROM PodContainer WHERE PodID=?;", pod.ID())
 	if err != nil {
 		return nil, fmt.Errorf("retrieving container IDs of pod %s from database: %w", pod.ID(), err)
 	}
@@ -1506,7 +3477,7 @@ func (s *SQLiteState) PodContainers(pod *Pod) ([]*Container, error) {
 		return nil, define.ErrPodRemoved
 	}
 
-	rows, err := s.conn.Query("SELECT JSON FROM ContainerConfig WHERE PodID=?;", pod.ID())
+	rows, err := s.query("SELECT ContainerConfig.JSON FROM PodContainer INNER JOIN ContainerConfig ON ContainerConfig.ID = PodContainer.ContainerID WHERE PodContainer.PodID=?;", pod.ID())
 	if err != nil {
 		return nil, fmt.Errorf("retrieving containers of pod %s from database: %w", pod.ID(), err)
 	}
@@ -1553,6 +3524,10 @@ func (s *SQLiteState) AddPod(pod *Pod) (defErr error) {
 		return define.ErrPodRemoved
 	}
 
+	if err := s.checkNamespace("pod", pod.ID(), pod.config.Namespace); err != nil {
+		return err
+	}
+
 	infraID := sql.NullString{}
 	if pod.state.InfraContainerID != "" {
 		if err := infraID.Scan(pod.state.InfraContainerID); err != nil {
@@ -1570,7 +3545,7 @@ func (s *SQLiteState) AddPod(pod *Pod) (defErr error) {
 		return fmt.Errorf("marshalling pod state json: %w", err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning pod create transaction: %w", err)
 	}
@@ -1598,12 +3573,15 @@ func (s *SQLiteState) AddPod(pod *Pod) (defErr error) {
 	if _, err := tx.Exec("INSERT INTO IDNamespace VALUES (?);", pod.ID()); err != nil {
 		return fmt.Errorf("adding pod id to database: %w", err)
 	}
-	if _, err := tx.Exec("INSERT INTO PodConfig VALUES (?, ?, ?);", pod.ID(), pod.Name(), configJSON); err != nil {
+	if _, err := tx.Exec("INSERT INTO PodConfig (ID, Name, JSON, Namespace) VALUES (?, ?, ?, ?);", pod.ID(), pod.Name(), configJSON, pod.config.Namespace); err != nil {
 		return fmt.Errorf("adding pod config to database: %w", err)
 	}
 	if _, err := tx.Exec("INSERT INTO PodState VALUES (?, ?, ?);", pod.ID(), infraID, stateJSON); err != nil {
 		return fmt.Errorf("adding pod state to database: %w", err)
 	}
+	if err := insertPodSharedNamespaces(tx.Tx, pod.config, s.dialect.bindVars); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
@@ -1623,7 +3601,11 @@ func (s *SQLiteState) RemovePod(pod *Pod) (defErr error) {
 		return define.ErrPodRemoved
 	}
 
-	tx, err := s.conn.Begin()
+	if err := s.checkNamespace("pod", pod.ID(), pod.config.Namespace); err != nil {
+		return err
+	}
+
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning pod %s removal transaction: %w", pod.ID(), err)
 	}
@@ -1635,16 +3617,6 @@ func (s *SQLiteState) RemovePod(pod *Pod) (defErr error) {
 		}
 	}()
 
-	var check int
-	row := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE PodID=? AND ID!=?;", pod.ID(), pod.state.InfraContainerID)
-	if err := row.Scan(&check); err != nil {
-		if !errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("checking if pod %s has containers in database: %w", pod.ID(), err)
-		}
-	} else if check != 0 {
-		return fmt.Errorf("pod %s is not empty: %w", pod.ID(), define.ErrCtrExists)
-	}
-
 	checkResult := func(result sql.Result) error {
 		rows, err := result.RowsAffected()
 		if err != nil {
@@ -1665,8 +3637,14 @@ func (s *SQLiteState) RemovePod(pod *Pod) (defErr error) {
 		return err
 	}
 
+	// The containers of the pod reference it, so only empty pods can be
+	// deleted. Its infra container and shared namespaces are deleted
+	// along with it.
 	result, err = tx.Exec("DELETE FROM PodConfig WHERE ID=?;", pod.ID())
 	if err != nil {
+		if s.dialect.isForeignKeyError(err) {
+			return fmt.Errorf("pod %s is not empty: %w", pod.ID(), define.ErrCtrExists)
+		}
 		return fmt.Errorf("removing pod %s config from database: %w", pod.ID(), err)
 	}
 	if err := checkResult(result); err != nil {
@@ -1698,7 +3676,7 @@ func (s *SQLiteState) RemovePodContainers(pod *Pod) (defErr error) {
 		return define.ErrPodRemoved
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning removal transaction for containers of pod %s: %w", pod.ID(), err)
 	}
@@ -1710,25 +3688,31 @@ func (s *SQLiteState) RemovePodContainers(pod *Pod) (defErr error) {
 		}
 	}()
 
-	rows, err := tx.Query("SELECT ID FROM ContainerConfig WHERE PodID=?;", pod.ID())
+	rows, err := tx.Query("SELECT ContainerID FROM PodContainer WHERE PodID=?;", pod.ID())
 	if err != nil {
 		return fmt.Errorf("retrieving container IDs of pod %s from database: %w", pod.ID(), err)
 	}
 	defer rows.Close()
 
+	// A PostgreSQL connection runs one statement at a time, so the rows
+	// must be read before the containers are removed.
+	var ids []string
 	for rows.Next() {
 		var id string
 		if err := rows.Scan(&id); err != nil {
 			return fmt.Errorf("scanning container from database: %w", err)
 		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
+	for _, id := range ids {
 		if err := s.removeContainerWithTx(id, tx); err != nil {
 			return err
 		}
 	}
-	if err := rows.Err(); err != nil {
-		return err
-	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing pod containers %s removal transaction: %w", pod.ID(), err)
@@ -1756,7 +3740,7 @@ func (s *SQLiteState) AddContainerToPod(pod *Pod, ctr *Container) error {
 		return fmt.Errorf("container %s is not part of pod %s: %w", ctr.ID(), pod.ID(), define.ErrNoSuchCtr)
 	}
 
-	return s.addContainer(ctr)
+	return s.addContainers([]*Container{ctr})
 }
 
 // RemoveContainerFromPod removes a container from an existing pod
@@ -1791,7 +3775,7 @@ func (s *SQLiteState) UpdatePod(pod *Pod) error {
 		return define.ErrPodRemoved
 	}
 
-	row := s.conn.QueryRow("SELECT JSON FROM PodState WHERE ID=?;", pod.ID())
+	row := s.queryRow("SELECT JSON FROM PodState WHERE ID=?;", pod.ID())
 
 	var rawJSON string
 	if err := row.Scan(&rawJSON); err != nil {
@@ -1827,7 +3811,7 @@ func (s *SQLiteState) SavePod(pod *Pod) (defErr error) {
 		return fmt.Errorf("marshalling pod %s state JSON: %w", pod.ID(), err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning pod %s save transaction: %w", pod.ID(), err)
 	}
@@ -1851,6 +3835,9 @@ func (s *SQLiteState) SavePod(pod *Pod) (defErr error) {
 		pod.valid = false
 		return define.ErrNoSuchPod
 	}
+	if err := savePodInfraContainer(tx.Tx, pod, s.dialect.bindVars); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing pod %s state: %w", pod.ID(), err)
@@ -1866,7 +3853,13 @@ func (s *SQLiteState) AllPods() ([]*Pod, error) {
 	}
 
 	pods := []*Pod{}
-	rows, err := s.conn.Query("SELECT JSON FROM PodConfig;")
+	query := "SELECT JSON FROM PodConfig;"
+	var args []any
+	if s.namespace != "" {
+		query = "SELECT JSON FROM PodConfig WHERE Namespace=?;"
+		args = append(args, s.namespace)
+	}
+	rows, err := s.query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("retrieving all pods from database: %w", err)
 	}
@@ -1924,7 +3917,7 @@ func (s *SQLiteState) AddVolume(volume *Volume) (defErr error) {
 		storageID.String = volume.config.StorageID
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning volume create transaction: %w", err)
 	}
@@ -1968,7 +3961,7 @@ func (s *SQLiteState) RemoveVolume(volume *Volume) (defErr error) {
 		return define.ErrDBClosed
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning volume %s removal transaction: %w", volume.Name(), err)
 	}
@@ -2005,6 +3998,16 @@ func (s *SQLiteState) RemoveVolume(volume *Volume) (defErr error) {
 	// Need to verify that at least 1 row was deleted from VolumeConfig.
 	// Otherwise return ErrNoSuchVolume
 
+	if _, err := tx.Exec("DELETE FROM VolumeBackupPolicy WHERE Volume=?;", volume.Name()); err != nil {
+		return fmt.Errorf("removing volume %s backup policy from DB: %w", volume.Name(), err)
+	}
+
+	// The snapshots are stored in the directory of the volume, so they
+	// are removed with it.
+	if _, err := tx.Exec("DELETE FROM VolumeSnapshot WHERE Volume=?;", volume.Name()); err != nil {
+		return fmt.Errorf("removing volume %s snapshots from DB: %w", volume.Name(), err)
+	}
+
 	if _, err := tx.Exec("DELETE FROM VolumeConfig WHERE Name=?;", volume.Name()); err != nil {
 		return fmt.Errorf("removing volume %s config from DB: %w", volume.Name(), err)
 	}
@@ -2030,7 +4033,7 @@ func (s *SQLiteState) UpdateVolume(volume *Volume) error {
 		return define.ErrVolumeRemoved
 	}
 
-	row := s.conn.QueryRow("SELECT JSON FROM VolumeState WHERE Name=?;", volume.Name())
+	row := s.queryRow("SELECT JSON FROM VolumeState WHERE Name=?;", volume.Name())
 
 	var stateJSON string
 	if err := row.Scan(&stateJSON); err != nil {
@@ -2066,7 +4069,7 @@ func (s *SQLiteState) SaveVolume(volume *Volume) (defErr error) {
 		return fmt.Errorf("marshalling volume %s state JSON: %w", volume.Name(), err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction to rewrite volume %s state: %w", volume.Name(), err)
 	}
@@ -2104,7 +4107,7 @@ func (s *SQLiteState) AllVolumes() ([]*Volume, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	rows, err := s.conn.Query("SELECT JSON FROM VolumeConfig;")
+	rows, err := s.query("SELECT JSON FROM VolumeConfig;")
 	if err != nil {
 		return nil, fmt.Errorf("querying database for all volumes: %w", err)
 	}
@@ -2149,7 +4152,7 @@ func (s *SQLiteState) Volume(name string) (*Volume, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT JSON FROM VolumeConfig WHERE Name=?;", name)
+	row := s.queryRow("SELECT JSON FROM VolumeConfig WHERE Name=?;", name)
 
 	var configJSON string
 
@@ -2185,7 +4188,7 @@ func (s *SQLiteState) LookupVolume(name string) (*Volume, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	rows, err := s.conn.Query("SELECT Name, JSON FROM VolumeConfig WHERE Name LIKE ? ORDER BY LENGTH(Name) ASC;", name+"%")
+	rows, err := s.query("SELECT Name, JSON FROM VolumeConfig WHERE Name LIKE ? ORDER BY LENGTH(Name) ASC;", name+"%")
 	if err != nil {
 		return nil, fmt.Errorf("querying database for volume %s: %w", name, err)
 	}
@@ -2237,7 +4240,7 @@ func (s *SQLiteState) HasVolume(name string) (bool, error) {
 		return false, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT 1 FROM VolumeConfig WHERE Name=?;", name)
+	row := s.queryRow("SELECT 1 FROM VolumeConfig WHERE Name=?;", name)
 
 	var check int
 	if err := row.Scan(&check); err != nil {
@@ -2265,7 +4268,7 @@ func (s *SQLiteState) VolumeInUse(volume *Volume) ([]string, error) {
 		return nil, define.ErrVolumeRemoved
 	}
 
-	rows, err := s.conn.Query("SELECT ContainerID FROM ContainerVolume WHERE VolumeName=?;", volume.Name())
+	rows, err := s.query("SELECT ContainerID FROM ContainerVolume WHERE VolumeName=?;", volume.Name())
 	if err != nil {
 		return nil, fmt.Errorf("querying database for containers using volume %s: %w", volume.Name(), err)
 	}
@@ -2293,7 +4296,7 @@ func (s *SQLiteState) ContainerIDIsVolume(id string) (bool, error) {
 		return false, define.ErrDBClosed
 	}
 
-	row := s.conn.QueryRow("SELECT 1 FROM VolumeConfig WHERE StorageID=?;", id)
+	row := s.queryRow("SELECT 1 FROM VolumeConfig WHERE StorageID=?;", id)
 	var checkDigit int
 	if err := row.Scan(&checkDigit); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
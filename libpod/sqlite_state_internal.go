@@ -7,46 +7,1213 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/mattn/go-sqlite3"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
+)
 
-	// SQLite backend for database/sql
-	_ "github.com/mattn/go-sqlite3"
+const (
+	// Number of attempts of an operation failing with SQLITE_BUSY or
+	// SQLITE_LOCKED.  Each attempt already waits for the busy timeout,
+	// the retries cover the cases where SQLite gives up without calling
+	// the busy handler.
+	sqliteRetryAttempts = 5
+	// Delay before the first retry, doubled after each attempt.
+	sqliteRetryDelay = 50 * time.Millisecond
 )
 
+// isRetryableSQLiteError returns true if err was caused by the database or
+// one of its tables being locked by another connection.
+func isRetryableSQLiteError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+}
+
+// isSQLiteForeignKeyError returns true if err was caused by a foreign key
+// constraint, such as removing a row still referenced by another table.
+// SQLite reports violated ON DELETE RESTRICT actions as failed triggers, the
+// database has no triggers of its own.
+func isSQLiteForeignKeyError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintTrigger
+}
+
+// retrySQLite calls fn until it succeeds, fails with an error that is not
+// retryable or runs out of attempts.
+func retrySQLite(fn func() error) error {
+	delay := sqliteRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt == sqliteRetryAttempts || !isRetryableSQLiteError(err) {
+			return err
+		}
+		logrus.Debugf("Database is locked, retrying in %s: %v", delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// sqliteBegin starts a transaction, retrying while the database is locked.
+// Transactions take an exclusive lock, so once begun their statements do not
+// run into locks held by other connections.
+func sqliteBegin(conn *sql.DB) (tx *sql.Tx, err error) {
+	err = retrySQLite(func() error {
+		tx, err = conn.Begin()
+		return err
+	})
+	return tx, err
+}
+
+// sqliteDialect is the dialect of SQLite databases.
+type sqliteDialect struct {
+	// memory is set if the database is held in memory.
+	memory bool
+}
+
+func (d sqliteDialect) backend() string {
+	if d.memory {
+		return dbBackendMemory
+	}
+	return config.DBBackendSQLite.String()
+}
+
+func (sqliteDialect) bindVars(query string) string {
+	return query
+}
+
+func (sqliteDialect) upsert(table string, key []string, columns ...string) string {
+	return "INSERT OR REPLACE INTO " + table + " VALUES (" + sqlPlaceholders(len(key)+len(columns)) + ");"
+}
+
+func (sqliteDialect) containerStateBlobsColumn() string {
+	return sqliteContainerStateBlobsColumn
+}
+
+func (sqliteDialect) begin(conn *sql.DB) (*sql.Tx, error) {
+	return sqliteBegin(conn)
+}
+
+func (sqliteDialect) retry(fn func() error) error {
+	return retrySQLite(fn)
+}
+
+func (sqliteDialect) isRetryableError(err error) bool {
+	return isRetryableSQLiteError(err)
+}
+
+func (sqliteDialect) isForeignKeyError(err error) bool {
+	return isSQLiteForeignKeyError(err)
+}
+
+func (sqliteDialect) schemaVersion() int {
+	return currentSchemaVersion()
+}
+
+func (sqliteDialect) recordsStateChanges() bool {
+	return true
+}
+
+func (sqliteDialect) checkIntegrity(s *SQLiteState) ([]string, error) {
+	var errs []string
+	integrity, err := s.queryStrings("PRAGMA integrity_check;")
+	if err != nil {
+		return nil, fmt.Errorf("checking database integrity: %w", err)
+	}
+	for _, msg := range integrity {
+		if msg != "ok" {
+			errs = append(errs, "integrity check: "+msg)
+		}
+	}
+
+	rows, err := s.query("PRAGMA foreign_key_check;")
+	if err != nil {
+		return nil, fmt.Errorf("checking database foreign keys: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			table, parent string
+			rowID         sql.NullInt64
+			fkID          int
+		)
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return nil, fmt.Errorf("scanning foreign key check result: %w", err)
+		}
+		errs = append(errs, fmt.Sprintf("foreign key check: row %d of table %s references a missing row of table %s", rowID.Int64, table, parent))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// vacuum rebuilds the database and truncates the write-ahead log afterwards,
+// as vacuuming writes the whole database to it.
+func (d sqliteDialect) vacuum(s *SQLiteState) error {
+	if err := retrySQLite(func() error {
+		_, err := s.conn.Exec("VACUUM;")
+		return err
+	}); err != nil {
+		return fmt.Errorf("vacuuming database: %w", err)
+	}
+	return d.checkpointWAL(s)
+}
+
+// checkpointWAL is a no-op unless the WAL journal mode is used.
+func (sqliteDialect) checkpointWAL(s *SQLiteState) error {
+	var busy, logFrames, checkpointed int
+	if err := s.queryRow("PRAGMA wal_checkpoint(TRUNCATE);").Scan(&busy, &logFrames, &checkpointed); err != nil {
+		return fmt.Errorf("checkpointing write-ahead log: %w", err)
+	}
+	if busy != 0 {
+		return fmt.Errorf("checkpointing write-ahead log: %d of %d frames copied, database is in use", checkpointed, logFrames)
+	}
+	return nil
+}
+
+// backup uses VACUUM INTO, which does not block other connections from
+// reading.
+func (sqliteDialect) backup(s *SQLiteState, path string) error {
+	if err := retrySQLite(func() error {
+		_, err := s.conn.Exec("VACUUM INTO ?;", path)
+		return err
+	}); err != nil {
+		return fmt.Errorf("backing up database to %s: %w", path, err)
+	}
+	return nil
+}
+
+func (sqliteDialect) databaseInfo(s *SQLiteState, info *define.DatabaseBackendInfo) error {
+	// The file of an in-memory database is empty.
+	var (
+		seq        int
+		name, file string
+	)
+	if err := s.queryRow("PRAGMA database_list;").Scan(&seq, &name, &file); err != nil {
+		return fmt.Errorf("retrieving database path: %w", err)
+	}
+	info.Path = file
+
+	var pageCount, pageSize int64
+	if err := s.queryRow("PRAGMA page_count;").Scan(&pageCount); err != nil {
+		return fmt.Errorf("retrieving database page count: %w", err)
+	}
+	if err := s.queryRow("PRAGMA page_size;").Scan(&pageSize); err != nil {
+		return fmt.Errorf("retrieving database page size: %w", err)
+	}
+	info.Size = pageCount * pageSize
+
+	if info.Path != "" {
+		st, err := os.Stat(info.Path + "-wal")
+		if err == nil {
+			info.WALSize = st.Size()
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("retrieving size of write-ahead log: %w", err)
+		}
+	}
+	return nil
+}
+
+// begin starts a transaction, retrying while the database is locked.
+func (s *SQLiteState) begin() (*sqlTx, error) {
+	tx, err := s.dialect.begin(s.conn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{Tx: tx, dialect: s.dialect}, nil
+}
+
+// sqliteRows are the result of a query run with retries.
+type sqliteRows struct {
+	*sql.Rows
+	// pending is set if the rows have been advanced to the first row,
+	// which has not been handed out yet.
+	pending bool
+}
+
+// Next prepares the next result row for reading, see sql.Rows.Next.
+func (r *sqliteRows) Next() bool {
+	if r.pending {
+		r.pending = false
+		return true
+	}
+	return r.Rows.Next()
+}
+
+// query runs a query outside of a transaction, retrying while the database is
+// locked.  SQLite only reports locks when stepping to the first row, so the
+// query is advanced to it before the rows are returned.
+func (s *SQLiteState) query(query string, args ...any) (*sqliteRows, error) {
+	r := new(sqliteRows)
+	query = s.dialect.bindVars(query)
+	err := s.dialect.retry(func() error {
+		rows, err := s.conn.Query(query, args...)
+		if err != nil {
+			return err
+		}
+		r.Rows, r.pending = rows, rows.Next()
+		if !r.pending && s.dialect.isRetryableError(rows.Err()) {
+			return rows.Err()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// sqliteRow is a query returning at most one row which runs, with retries,
+// when the row is scanned.
+type sqliteRow struct {
+	conn    *sql.DB
+	dialect sqlDialect
+	query   string
+	args    []any
+}
+
+// Scan runs the query and copies the columns of the returned row into dest,
+// see sql.Row.Scan.
+func (r *sqliteRow) Scan(dest ...any) error {
+	return r.dialect.retry(func() error {
+		return r.conn.QueryRow(r.query, r.args...).Scan(dest...)
+	})
+}
+
+// queryRow returns a query returning at most one row, run outside of a
+// transaction and retried while the database is locked.
+func (s *SQLiteState) queryRow(query string, args ...any) *sqliteRow {
+	return &sqliteRow{conn: s.conn, dialect: s.dialect, query: s.dialect.bindVars(query), args: args}
+}
+
 func initSQLiteDB(conn *sql.DB) (defErr error) {
 	// Start with a transaction to avoid "database locked" errors.
 	// See https://github.com/mattn/go-sqlite3/issues/274#issuecomment-1429054597
-	tx, err := conn.Begin()
+	tx, err := sqliteBegin(conn)
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
 	}
-	defer func() {
-		if defErr != nil {
-			if err := tx.Rollback(); err != nil {
-				logrus.Errorf("Rolling back transaction to create tables: %v", err)
-			}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to create tables: %v", err)
+			}
+		}
+	}()
+
+	sameSchema, err := migrateSchemaIfNecessary(tx)
+	if err != nil {
+		return err
+	}
+	if !sameSchema {
+		if err := createSQLiteTables(tx); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// checkSQLiteSchema verifies that a database opened read only has the current
+// schema, as it can neither be created nor migrated.
+func checkSQLiteSchema(conn *sql.DB) error {
+	var tables int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='DBConfig';").Scan(&tables); err != nil {
+		return fmt.Errorf("checking if DB config table exists: %w", err)
+	}
+	var schemaVer int
+	if tables == 1 {
+		if err := conn.QueryRow("SELECT SchemaVersion FROM DBConfig;").Scan(&schemaVer); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("scanning schema version from DB config: %w", err)
+		}
+	}
+	if schemaVer == 0 {
+		return fmt.Errorf("database has not been initialized and cannot be opened read only: %w", define.ErrDBBadConfig)
+	}
+	if schemaVersion := currentSchemaVersion(); schemaVer != schemaVersion {
+		return fmt.Errorf("database has schema version %d while this libpod version requires version %d to open it read only: %w",
+			schemaVer, schemaVersion, define.ErrDBBadConfig)
+	}
+	return nil
+}
+
+// schemaMigration upgrades the database schema by one version.
+type schemaMigration struct {
+	// description is logged when the migration is applied.
+	description string
+	// migrate performs the migration. It runs inside the transaction
+	// opening the database, together with all other pending migrations, so
+	// either all of them are applied or none.
+	migrate func(tx *sql.Tx) error
+}
+
+// schemaMigrations lists the schema migrations in order. The migration at
+// index i upgrades schema version i+1 to i+2. Migrations must only be
+// appended, never reordered or removed.
+// Tables added by a new schema version do not need a migration, they are
+// created by createSQLiteTables after all migrations were applied.
+var schemaMigrations = []schemaMigration{
+	{
+		description: "add container label table",
+		migrate: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(containerLabelTable); err != nil {
+				return fmt.Errorf("creating table ContainerLabel: %w", err)
+			}
+			if _, err := tx.Exec("INSERT OR IGNORE INTO ContainerLabel SELECT ContainerConfig.ID, Label.key, Label.value FROM ContainerConfig, json_each(ContainerConfig.JSON, '$.labels') AS Label;"); err != nil {
+				return fmt.Errorf("populating table ContainerLabel: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		// The table itself is created by createSQLiteTables.
+		description: "add image provenance table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table itself is created by createSQLiteTables.
+		description: "add image digest pin table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The indexes are created by createSQLiteTables.
+		description: "add restart policy, created time and image ID columns to container config table",
+		migrate: func(tx *sql.Tx) error {
+			for _, column := range containerConfigColumns {
+				exists, err := sqliteColumnExists(tx, "ContainerConfig", column.name)
+				if err != nil {
+					return err
+				}
+				if exists {
+					continue
+				}
+				if _, err := tx.Exec("ALTER TABLE ContainerConfig ADD COLUMN " + column.name + " " + column.def + ";"); err != nil {
+					return fmt.Errorf("adding column %s to table ContainerConfig: %w", column.name, err)
+				}
+			}
+			return populateContainerConfigColumns(tx, func(query string) string { return query })
+		},
+	},
+	{
+		// The index is created by createSQLiteTables.
+		description: "add container exit history table",
+		migrate: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(containerExitHistoryTable); err != nil {
+				return fmt.Errorf("creating table ContainerExitHistory: %w", err)
+			}
+			if _, err := tx.Exec("INSERT INTO ContainerExitHistory (ContainerID, Timestamp, ExitCode) SELECT ID, Timestamp, ExitCode FROM ContainerExitCode ORDER BY Timestamp;"); err != nil {
+				return fmt.Errorf("populating table ContainerExitHistory: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		// The tables are created by createSQLiteTables.
+		description: "add volume backup tables",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		description: "add JSON column to container exec session table",
+		migrate: func(tx *sql.Tx) error {
+			exists, err := sqliteColumnExists(tx, "ContainerExecSession", "JSON")
+			if err != nil {
+				return err
+			}
+			if !exists {
+				if _, err := tx.Exec("ALTER TABLE ContainerExecSession ADD COLUMN JSON TEXT NOT NULL DEFAULT '{}';"); err != nil {
+					return fmt.Errorf("adding column JSON to table ContainerExecSession: %w", err)
+				}
+			}
+			return populateExecSessionJSON(tx, func(query string) string { return query })
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add container metadata table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The index is created by createSQLiteTables.
+		description: "move container networks to container network table",
+		migrate: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(containerNetworkTable); err != nil {
+				return fmt.Errorf("creating table ContainerNetwork: %w", err)
+			}
+			return populateContainerNetworks(tx, func(query string) string { return query })
+		},
+	},
+	{
+		// The table is created by createSQLiteTables. The healthcheck
+		// logs of existing containers stay in their run directories
+		// until the next healthcheck run of the container.
+		description: "add healthcheck log table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		description: "add pod container, infra container and shared namespace tables",
+		migrate: func(tx *sql.Tx) error {
+			tables := map[string]string{
+				"PodContainer":       podContainerTable,
+				"PodInfraContainer":  podInfraContainerTable,
+				"PodSharedNamespace": podSharedNamespaceTable,
+			}
+			for tblName, cmd := range tables {
+				if _, err := tx.Exec(cmd); err != nil {
+					return fmt.Errorf("creating table %s: %w", tblName, err)
+				}
+			}
+			return populatePodTables(tx, func(query string) string { return query })
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add network reservation table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add checkpoint table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add container note table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add volume snapshot table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The indexes are created by createSQLiteTables.
+		description: "add namespace columns to container and pod config tables",
+		migrate: func(tx *sql.Tx) error {
+			for _, table := range []string{"ContainerConfig", "PodConfig"} {
+				exists, err := sqliteColumnExists(tx, table, "Namespace")
+				if err != nil {
+					return err
+				}
+				if !exists {
+					if _, err := tx.Exec("ALTER TABLE " + table + " ADD COLUMN Namespace TEXT NOT NULL DEFAULT '';"); err != nil {
+						return fmt.Errorf("adding column Namespace to table %s: %w", table, err)
+					}
+				}
+				if _, err := tx.Exec("UPDATE " + table + " SET Namespace=COALESCE(json_extract(JSON, '$.namespace'), '');"); err != nil {
+					return fmt.Errorf("populating column Namespace of table %s: %w", table, err)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		description: "move container network status and bind mounts to container state blob table",
+		migrate: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(containerStateBlobTable); err != nil {
+				return fmt.Errorf("creating table ContainerStateBlob: %w", err)
+			}
+			return populateContainerStateBlobs(tx, func(query string) string { return query })
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add database maintenance table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table and triggers are created by createSQLiteTables.
+		description: "add state change table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add container group table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+	{
+		// The table is created by createSQLiteTables.
+		description: "add container stats history table",
+		migrate: func(*sql.Tx) error {
+			return nil
+		},
+	},
+}
+
+// containerConfigColumns are the columns of ContainerConfig holding fields of
+// the container config, so containers can be filtered and sorted by them
+// without unmarshalling their configs. They are set by
+// containerConfigColumnValues.
+var containerConfigColumns = []struct {
+	name string
+	def  string
+}{
+	{"RestartPolicy", "TEXT NOT NULL DEFAULT ''"},
+	// Nanoseconds since the Unix epoch.
+	{"CreatedTime", "INTEGER NOT NULL DEFAULT 0"},
+	{"ImageID", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// containerConfigColumnValues returns the values of containerConfigColumns for
+// the given config, in the same order.
+func containerConfigColumnValues(config *ContainerConfig) []any {
+	return []any{config.RestartPolicy, config.CreatedTime.UnixNano(), config.RootfsImageID}
+}
+
+// populateContainerConfigColumns sets containerConfigColumns of all existing
+// containers from their configs. bindVars converts the ? placeholders of a
+// query to the syntax of the database.
+func populateContainerConfigColumns(tx *sql.Tx, bindVars func(string) string) error {
+	rows, err := tx.Query("SELECT ID, JSON FROM ContainerConfig;")
+	if err != nil {
+		return fmt.Errorf("retrieving container configs: %w", err)
+	}
+	configs := make(map[string]*ContainerConfig)
+	for rows.Next() {
+		var id, rawJSON string
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning container config: %w", err)
+		}
+		config := new(ContainerConfig)
+		if err := json.Unmarshal([]byte(rawJSON), config); err != nil {
+			rows.Close()
+			return fmt.Errorf("unmarshalling container %s config: %w", id, err)
+		}
+		configs[id] = config
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	query := bindVars("UPDATE ContainerConfig SET RestartPolicy=?, CreatedTime=?, ImageID=? WHERE ID=?;")
+	for id, config := range configs {
+		if _, err := tx.Exec(query, append(containerConfigColumnValues(config), id)...); err != nil {
+			return fmt.Errorf("updating container %s config columns: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// containerConfigJSON marshals the given container config for the
+// ContainerConfig table. The networks of the container are not part of it,
+// they are stored in ContainerNetwork.
+func containerConfigJSON(config *ContainerConfig) ([]byte, error) {
+	cfg := *config
+	cfg.Networks = nil
+	return json.Marshal(&cfg)
+}
+
+// insertContainerNetworks records the networks of the given container in
+// ContainerNetwork. bindVars converts the ? placeholders of a query to the
+// syntax of the database.
+func insertContainerNetworks(tx *sql.Tx, id string, networks map[string]types.PerNetworkOptions, bindVars func(string) string) error {
+	query := bindVars("INSERT INTO ContainerNetwork (ContainerID, Network, OptionsJSON) VALUES (?, ?, ?);")
+	for network, opts := range networks {
+		if network == "" {
+			return fmt.Errorf("network names cannot be an empty string: %w", define.ErrInvalidArg)
+		}
+		optsJSON, err := json.Marshal(opts)
+		if err != nil {
+			return fmt.Errorf("marshalling network options JSON for container %s: %w", id, err)
+		}
+		if _, err := tx.Exec(query, id, network, optsJSON); err != nil {
+			return fmt.Errorf("adding container %s network %s to database: %w", id, network, err)
+		}
+	}
+	return nil
+}
+
+// populateContainerNetworks moves the networks of all existing containers
+// from their configs to ContainerNetwork.
+func populateContainerNetworks(tx *sql.Tx, bindVars func(string) string) error {
+	rows, err := tx.Query("SELECT ID, JSON FROM ContainerConfig;")
+	if err != nil {
+		return fmt.Errorf("retrieving container configs: %w", err)
+	}
+	configs := make(map[string]*ContainerConfig)
+	for rows.Next() {
+		var id, rawJSON string
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning container config: %w", err)
+		}
+		config := new(ContainerConfig)
+		if err := json.Unmarshal([]byte(rawJSON), config); err != nil {
+			rows.Close()
+			return fmt.Errorf("unmarshalling container %s config: %w", id, err)
+		}
+		if len(config.Networks) > 0 {
+			configs[id] = config
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	query := bindVars("UPDATE ContainerConfig SET JSON=? WHERE ID=?;")
+	for id, config := range configs {
+		if err := insertContainerNetworks(tx, id, config.Networks, bindVars); err != nil {
+			return err
+		}
+		configJSON, err := containerConfigJSON(config)
+		if err != nil {
+			return fmt.Errorf("marshalling container %s config JSON: %w", id, err)
+		}
+		if _, err := tx.Exec(query, configJSON, id); err != nil {
+			return fmt.Errorf("updating container %s config: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// saveExecSessions writes the exec sessions in the state of the given
+// container to ContainerExecSession, so they can be inspected without loading
+// the container. Sessions which were not added with AddExecSession are
+// skipped.
+func saveExecSessions(tx *sql.Tx, ctr *Container, bindVars func(string) string) error {
+	query := bindVars("UPDATE ContainerExecSession SET JSON=? WHERE ID=? AND ContainerID=?;")
+	for id, session := range ctr.state.ExecSessions {
+		sessionJSON, err := json.Marshal(session)
+		if err != nil {
+			return fmt.Errorf("marshalling container %s exec session %s JSON: %w", ctr.ID(), id, err)
+		}
+		if _, err := tx.Exec(query, sessionJSON, id, ctr.ID()); err != nil {
+			return fmt.Errorf("writing container %s exec session %s: %w", ctr.ID(), id, err)
+		}
+	}
+	return nil
+}
+
+// populateExecSessionJSON sets the JSON of the exec sessions in
+// ContainerExecSession from the states of their containers.
+func populateExecSessionJSON(tx *sql.Tx, bindVars func(string) string) error {
+	rows, err := tx.Query("SELECT ID, JSON FROM ContainerState;")
+	if err != nil {
+		return fmt.Errorf("retrieving container states: %w", err)
+	}
+	var ctrs []*Container
+	for rows.Next() {
+		var id, rawJSON string
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning container state: %w", err)
+		}
+		ctr := &Container{config: &ContainerConfig{ID: id}, state: new(ContainerState)}
+		if err := json.Unmarshal([]byte(rawJSON), ctr.state); err != nil {
+			rows.Close()
+			return fmt.Errorf("unmarshalling container %s state: %w", id, err)
+		}
+		ctrs = append(ctrs, ctr)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	for _, ctr := range ctrs {
+		if err := saveExecSessions(tx, ctr, bindVars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalExecSession decodes an exec session read from
+// ContainerExecSession. Sessions added before the table held their JSON only
+// have their IDs set.
+func unmarshalExecSession(id, ctrID, rawJSON string) (*ExecSession, error) {
+	session := new(ExecSession)
+	if err := json.Unmarshal([]byte(rawJSON), session); err != nil {
+		return nil, fmt.Errorf("unmarshalling exec session %s: %w", id, err)
+	}
+	session.Id = id
+	session.ContainerId = ctrID
+	return session, nil
+}
+
+// containerStateBlob is a field of ContainerState which is stored in its own
+// row of ContainerStateBlob instead of the JSON of ContainerState. Large fields
+// which change less often than the rest of the state are stored this way, so
+// saving the state does not rewrite them every time.
+type containerStateBlob struct {
+	// name identifies the field in ContainerStateBlob.
+	name string
+	// field returns a pointer to the field of the given state.
+	field func(state *ContainerState) any
+}
+
+// containerStateBlobs are the fields of ContainerState stored in
+// ContainerStateBlob. New fields can be added at any time, fields of existing
+// containers are moved out of their states the next time they are saved.
+var containerStateBlobs = []containerStateBlob{
+	{"networkStatus", func(state *ContainerState) any { return &state.NetworkStatus }},
+	{"bindMounts", func(state *ContainerState) any { return &state.BindMounts }},
+}
+
+// isEmpty returns whether the field of the blob in the given state is unset,
+// in which case no row is stored for it.
+func (b containerStateBlob) isEmpty(state *ContainerState) bool {
+	v := reflect.ValueOf(b.field(state)).Elem()
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// containerStateJSON marshals the given container state for the
+// ContainerState table. The fields in containerStateBlobs are not part of it,
+// they are written by saveContainerStateBlobs.
+func containerStateJSON(state *ContainerState) ([]byte, error) {
+	st := *state
+	for _, blob := range containerStateBlobs {
+		reflect.ValueOf(blob.field(&st)).Elem().SetZero()
+	}
+	return json.Marshal(&st)
+}
+
+// saveContainerStateBlobs writes the fields in containerStateBlobs of the
+// given container state to ContainerStateBlob. Rows are only rewritten if
+// their field changed, and removed if it was unset. bindVars converts the ?
+// placeholders of a query to the syntax of the database.
+func saveContainerStateBlobs(tx *sql.Tx, id string, state *ContainerState, bindVars func(string) string) error {
+	upsert := bindVars("INSERT INTO ContainerStateBlob (ContainerID, Name, JSON) VALUES (?, ?, ?) ON CONFLICT (ContainerID, Name) DO UPDATE SET JSON=excluded.JSON WHERE ContainerStateBlob.JSON <> excluded.JSON;")
+	remove := bindVars("DELETE FROM ContainerStateBlob WHERE ContainerID=? AND Name=?;")
+	for _, blob := range containerStateBlobs {
+		if blob.isEmpty(state) {
+			if _, err := tx.Exec(remove, id, blob.name); err != nil {
+				return fmt.Errorf("removing container %s state %s: %w", id, blob.name, err)
+			}
+			continue
+		}
+		blobJSON, err := json.Marshal(blob.field(state))
+		if err != nil {
+			return fmt.Errorf("marshalling container %s state %s JSON: %w", id, blob.name, err)
+		}
+		if _, err := tx.Exec(upsert, id, blob.name, string(blobJSON)); err != nil {
+			return fmt.Errorf("writing container %s state %s: %w", id, blob.name, err)
+		}
+	}
+	return nil
+}
+
+// unmarshalContainerState decodes a container state read from ContainerState
+// together with its blobs, an object mapping the names of its rows in
+// ContainerStateBlob to their JSON, as selected by
+// sqliteContainerStateBlobsColumn. Rows of unknown fields are ignored.
+func unmarshalContainerState(id, stateJSON, blobsJSON string, state *ContainerState) error {
+	if err := json.Unmarshal([]byte(stateJSON), state); err != nil {
+		return fmt.Errorf("unmarshalling container %s state JSON: %w", id, err)
+	}
+	if blobsJSON == "" {
+		return nil
+	}
+	blobs := make(map[string]string)
+	if err := json.Unmarshal([]byte(blobsJSON), &blobs); err != nil {
+		return fmt.Errorf("unmarshalling container %s state blobs: %w", id, err)
+	}
+	for _, blob := range containerStateBlobs {
+		blobJSON, ok := blobs[blob.name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal([]byte(blobJSON), blob.field(state)); err != nil {
+			return fmt.Errorf("unmarshalling container %s state %s JSON: %w", id, blob.name, err)
+		}
+	}
+	return nil
+}
+
+// populateContainerStateBlobs moves the fields in containerStateBlobs of all
+// existing containers from their states to ContainerStateBlob.
+func populateContainerStateBlobs(tx *sql.Tx, bindVars func(string) string) error {
+	rows, err := tx.Query("SELECT ID, JSON FROM ContainerState;")
+	if err != nil {
+		return fmt.Errorf("retrieving container states: %w", err)
+	}
+	states := make(map[string]*ContainerState)
+	for rows.Next() {
+		var id, rawJSON string
+		if err := rows.Scan(&id, &rawJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning container state: %w", err)
+		}
+		state := new(ContainerState)
+		if err := json.Unmarshal([]byte(rawJSON), state); err != nil {
+			rows.Close()
+			return fmt.Errorf("unmarshalling container %s state: %w", id, err)
+		}
+		for _, blob := range containerStateBlobs {
+			if !blob.isEmpty(state) {
+				states[id] = state
+				break
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	query := bindVars("UPDATE ContainerState SET JSON=? WHERE ID=?;")
+	for id, state := range states {
+		if err := saveContainerStateBlobs(tx, id, state, bindVars); err != nil {
+			return err
+		}
+		stateJSON, err := containerStateJSON(state)
+		if err != nil {
+			return fmt.Errorf("marshalling container %s state JSON: %w", id, err)
+		}
+		if _, err := tx.Exec(query, stateJSON, id); err != nil {
+			return fmt.Errorf("updating container %s state: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// sqliteColumnExists returns whether the given table has the given column.
+func sqliteColumnExists(tx *sql.Tx, table, column string) (bool, error) {
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name=?;", table, column).Scan(&count); err != nil {
+		return false, fmt.Errorf("checking if table %s has column %s: %w", table, column, err)
+	}
+	return count > 0, nil
+}
+
+// containerLabelTable holds the labels of every container, so containers can
+// be filtered by label without unmarshalling their configs.
+const containerLabelTable = `
+        CREATE TABLE IF NOT EXISTS ContainerLabel(
+                ContainerID TEXT NOT NULL,
+                Key         TEXT NOT NULL,
+                Value       TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, Key),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+// containerExitHistoryTable holds every exit code recorded for a container,
+// while ContainerExitCode only holds the most recent one. The rows are kept
+// after the container is removed, until they are older than the exit code
+// retention.
+const containerExitHistoryTable = `
+        CREATE TABLE IF NOT EXISTS ContainerExitHistory(
+                ID          INTEGER PRIMARY KEY AUTOINCREMENT,
+                ContainerID TEXT    NOT NULL,
+                Timestamp   INTEGER NOT NULL,
+                ExitCode    INTEGER NOT NULL
+        );`
+
+// containerNetworkTable holds the networks every container is connected to
+// and the options of the connections, so containers can be connected and
+// disconnected without rewriting their configs and the containers of a
+// network can be listed without unmarshalling all configs.
+const containerNetworkTable = `
+        CREATE TABLE IF NOT EXISTS ContainerNetwork(
+                ContainerID TEXT NOT NULL,
+                Network     TEXT NOT NULL,
+                OptionsJSON TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, Network),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+// containerStateBlobTable holds the fields of container states listed in
+// containerStateBlobs, one row per container and field.
+const containerStateBlobTable = `
+        CREATE TABLE IF NOT EXISTS ContainerStateBlob(
+                ContainerID TEXT NOT NULL,
+                Name        TEXT NOT NULL,
+                JSON        TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, Name),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+// sqliteContainerStateBlobsColumn selects the rows of ContainerStateBlob of
+// the container of the current ContainerState row, as an object mapping their
+// names to their JSON.
+const sqliteContainerStateBlobsColumn = "(SELECT json_group_object(ContainerStateBlob.Name, ContainerStateBlob.JSON) FROM ContainerStateBlob WHERE ContainerStateBlob.ContainerID = ContainerState.ID)"
+
+// Maintenance operations of the database recorded in the DBMaintenance table.
+const (
+	dbMaintenanceVacuum = "vacuum"
+	dbMaintenanceBackup = "backup"
+	// The result of a check is one of the define.DBIntegrity constants.
+	dbMaintenanceCheck = "check"
+)
+
+// dbMaintenanceTable holds the time and result of the last run of each
+// maintenance operation of the database. The timestamp is in nanoseconds
+// since the Unix epoch.
+// containerGroupTable holds the container groups, which are not tied to any
+// container.
+const containerGroupTable = `
+        CREATE TABLE IF NOT EXISTS ContainerGroup(
+                Name TEXT PRIMARY KEY NOT NULL,
+                JSON TEXT NOT NULL
+        );`
+
+const dbMaintenanceTable = `
+        CREATE TABLE IF NOT EXISTS DBMaintenance(
+                Operation TEXT   PRIMARY KEY NOT NULL,
+                Timestamp BIGINT NOT NULL,
+                Result    TEXT   NOT NULL
+        );`
+
+// recordDBMaintenance replaces the record of the given maintenance operation.
+// bindVars converts the ? placeholders of a query to the syntax of the
+// database.
+func recordDBMaintenance(tx *sql.Tx, operation, result string, bindVars func(string) string) error {
+	if _, err := tx.Exec(bindVars("INSERT INTO DBMaintenance VALUES (?, ?, ?) ON CONFLICT (Operation) DO UPDATE SET Timestamp=excluded.Timestamp, Result=excluded.Result;"), operation, time.Now().UnixNano(), result); err != nil {
+		return fmt.Errorf("recording database %s: %w", operation, err)
+	}
+	return nil
+}
+
+// readDBMaintenance sets the maintenance fields of info from the
+// DBMaintenance table.
+func readDBMaintenance(conn *sql.DB, info *define.DatabaseBackendInfo) error {
+	info.Integrity = define.DBIntegrityUnchecked
+
+	rows, err := conn.Query("SELECT Operation, Timestamp, Result FROM DBMaintenance;")
+	if err != nil {
+		return fmt.Errorf("retrieving database maintenance records: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			operation, result string
+			timestamp         int64
+		)
+		if err := rows.Scan(&operation, &timestamp, &result); err != nil {
+			return fmt.Errorf("scanning database maintenance record: %w", err)
+		}
+		t := time.Unix(0, timestamp)
+		switch operation {
+		case dbMaintenanceVacuum:
+			info.LastVacuum = &t
+		case dbMaintenanceBackup:
+			info.LastBackup = &t
+		case dbMaintenanceCheck:
+			info.LastCheck = &t
+			info.Integrity = result
+		}
+	}
+	return rows.Err()
+}
+
+// healthCheckLogTable holds the healthcheck runs of every container, up to
+// the healthcheck log retention of the runtime.
+const healthCheckLogTable = `
+        CREATE TABLE IF NOT EXISTS HealthCheckLog(
+                ID          INTEGER PRIMARY KEY AUTOINCREMENT,
+                ContainerID TEXT    NOT NULL,
+                Status      TEXT    NOT NULL,
+                ExitCode    INTEGER NOT NULL,
+                Output      TEXT    NOT NULL,
+                StartTime   TEXT    NOT NULL,
+                EndTime     TEXT    NOT NULL,
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+// containerStatsHistoryTable holds the resource usage samples of every
+// container taken by the stats collector of the system service, up to the
+// history size it was started with. Time is the sample time in nanoseconds
+// since the epoch.
+const containerStatsHistoryTable = `
+        CREATE TABLE IF NOT EXISTS ContainerStatsHistory(
+                ID          INTEGER PRIMARY KEY AUTOINCREMENT,
+                ContainerID TEXT    NOT NULL,
+                Time        INTEGER NOT NULL,
+                JSON        TEXT    NOT NULL,
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+// podContainerTable holds the containers of every pod. A pod cannot be
+// removed while it has containers. The unique constraint indexes the
+// containers of a pod and is referenced by PodInfraContainer.
+const podContainerTable = `
+        CREATE TABLE IF NOT EXISTS PodContainer(
+                ContainerID TEXT PRIMARY KEY NOT NULL,
+                PodID       TEXT NOT NULL,
+                UNIQUE (PodID, ContainerID),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED,
+                FOREIGN KEY (PodID)       REFERENCES PodConfig(ID)       ON DELETE RESTRICT
+        );`
+
+// podInfraContainerTable holds the infra container of every pod which has
+// one. The infra container must be a container of the pod and cannot be
+// removed while it is the infra container of the pod.
+const podInfraContainerTable = `
+        CREATE TABLE IF NOT EXISTS PodInfraContainer(
+                PodID       TEXT PRIMARY KEY NOT NULL,
+                ContainerID TEXT UNIQUE NOT NULL,
+                FOREIGN KEY (PodID)              REFERENCES PodConfig(ID)                     ON DELETE CASCADE,
+                FOREIGN KEY (PodID, ContainerID) REFERENCES PodContainer(PodID, ContainerID) ON DELETE RESTRICT
+        );`
+
+// podSharedNamespaceTable holds the namespaces shared by the containers of
+// every pod.
+const podSharedNamespaceTable = `
+        CREATE TABLE IF NOT EXISTS PodSharedNamespace(
+                PodID     TEXT NOT NULL,
+                Namespace TEXT NOT NULL,
+                PRIMARY KEY (PodID, Namespace),
+                FOREIGN KEY (PodID) REFERENCES PodConfig(ID) ON DELETE CASCADE
+        );`
+
+// insertPodSharedNamespaces records the namespaces shared by the containers
+// of the pod with the given config in PodSharedNamespace.
+func insertPodSharedNamespaces(tx *sql.Tx, config *PodConfig, bindVars func(string) string) error {
+	query := bindVars("INSERT INTO PodSharedNamespace (PodID, Namespace) VALUES (?, ?);")
+	for _, ns := range config.sharedNamespaces() {
+		if _, err := tx.Exec(query, config.ID, ns); err != nil {
+			return fmt.Errorf("adding pod %s shared namespace %s to database: %w", config.ID, ns, err)
+		}
+	}
+	return nil
+}
+
+// savePodInfraContainer records the infra container in the state of the
+// given pod in PodInfraContainer, if it changed.
+func savePodInfraContainer(tx *sql.Tx, pod *Pod, bindVars func(string) string) error {
+	var current string
+	row := tx.QueryRow(bindVars("SELECT ContainerID FROM PodInfraContainer WHERE PodID=?;"), pod.ID())
+	if err := row.Scan(&current); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("retrieving pod %s infra container from database: %w", pod.ID(), err)
+	}
+	if current == pod.state.InfraContainerID {
+		return nil
+	}
+
+	if current != "" {
+		if _, err := tx.Exec(bindVars("DELETE FROM PodInfraContainer WHERE PodID=?;"), pod.ID()); err != nil {
+			return fmt.Errorf("removing pod %s infra container %s from database: %w", pod.ID(), current, err)
+		}
+	}
+	if pod.state.InfraContainerID != "" {
+		if _, err := tx.Exec(bindVars("INSERT INTO PodInfraContainer (PodID, ContainerID) VALUES (?, ?);"), pod.ID(), pod.state.InfraContainerID); err != nil {
+			return fmt.Errorf("adding pod %s infra container %s to database: %w", pod.ID(), pod.state.InfraContainerID, err)
 		}
-	}()
+	}
+	return nil
+}
 
-	sameSchema, err := migrateSchemaIfNecessary(tx)
+// populatePodTables fills PodContainer, PodInfraContainer and
+// PodSharedNamespace from the containers and pods already in the database.
+// Infra containers which are not part of their pods are skipped.
+func populatePodTables(tx *sql.Tx, bindVars func(string) string) error {
+	if _, err := tx.Exec("INSERT INTO PodContainer (ContainerID, PodID) SELECT ID, PodID FROM ContainerConfig WHERE PodID IS NOT NULL AND ID NOT IN (SELECT ContainerID FROM PodContainer);"); err != nil {
+		return fmt.Errorf("populating table PodContainer: %w", err)
+	}
+
+	rows, err := tx.Query("SELECT PodConfig.JSON, PodState.JSON FROM PodConfig INNER JOIN PodState ON PodConfig.ID = PodState.ID;")
 	if err != nil {
+		return fmt.Errorf("retrieving pods: %w", err)
+	}
+	var pods []*Pod
+	for rows.Next() {
+		var configJSON, stateJSON string
+		if err := rows.Scan(&configJSON, &stateJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning pod: %w", err)
+		}
+		pod := &Pod{config: new(PodConfig), state: new(podState)}
+		if err := json.Unmarshal([]byte(configJSON), pod.config); err != nil {
+			rows.Close()
+			return fmt.Errorf("unmarshalling pod config: %w", err)
+		}
+		if err := json.Unmarshal([]byte(stateJSON), pod.state); err != nil {
+			rows.Close()
+			return fmt.Errorf("unmarshalling pod %s state: %w", pod.ID(), err)
+		}
+		pods = append(pods, pod)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
 		return err
 	}
-	if !sameSchema {
-		if err := createSQLiteTables(tx); err != nil {
+	rows.Close()
+
+	for _, pod := range pods {
+		if _, err := tx.Exec(bindVars("DELETE FROM PodSharedNamespace WHERE PodID=?;"), pod.ID()); err != nil {
+			return fmt.Errorf("removing pod %s shared namespaces from database: %w", pod.ID(), err)
+		}
+		if err := insertPodSharedNamespaces(tx, pod.config, bindVars); err != nil {
+			return err
+		}
+		if pod.state.InfraContainerID == "" {
+			continue
+		}
+		var check int
+		row := tx.QueryRow(bindVars("SELECT 1 FROM PodContainer WHERE ContainerID=? AND PodID=?;"), pod.state.InfraContainerID, pod.ID())
+		if err := row.Scan(&check); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				logrus.Warnf("Infra container %s of pod %s is not part of the pod, not recording it", pod.state.InfraContainerID, pod.ID())
+				continue
+			}
+			return fmt.Errorf("checking if pod %s has container %s in database: %w", pod.ID(), pod.state.InfraContainerID, err)
+		}
+		if err := savePodInfraContainer(tx, pod, bindVars); err != nil {
 			return err
 		}
-	}
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing transaction: %w", err)
 	}
 	return nil
 }
 
+// currentSchemaVersion returns the schema version of newly created databases.
+func currentSchemaVersion() int {
+	return len(schemaMigrations) + 1
+}
+
+// migrateSchemaIfNecessary upgrades the schema of an existing database to the
+// current version. It returns true if the database already had the current
+// schema, and false if the tables still need to be created.
 func migrateSchemaIfNecessary(tx *sql.Tx) (bool, error) {
 	// First, check if the DBConfig table exists
 	checkRow := tx.QueryRow("SELECT 1 FROM sqlite_master WHERE type='table' AND name='DBConfig';")
@@ -78,6 +1245,8 @@ func migrateSchemaIfNecessary(tx *sql.Tx) (bool, error) {
 		return false, fmt.Errorf("database schema version %d is invalid: %w", schemaVer, define.ErrInternal)
 	}
 
+	schemaVersion := currentSchemaVersion()
+
 	// Same schema -> nothing do to.
 	if schemaVer == schemaVersion {
 		return true, nil
@@ -89,7 +1258,17 @@ func migrateSchemaIfNecessary(tx *sql.Tx) (bool, error) {
 			schemaVer, schemaVersion, define.ErrInternal)
 	}
 
-	// Perform schema migration here, one version at a time.
+	// Perform schema migration, one version at a time.
+	for ver := schemaVer; ver < schemaVersion; ver++ {
+		migration := schemaMigrations[ver-1]
+		logrus.Infof("Migrating database schema from version %d to %d: %s", ver, ver+1, migration.description)
+		if err := migration.migrate(tx); err != nil {
+			return false, fmt.Errorf("migrating database schema from version %d to %d: %w", ver, ver+1, err)
+		}
+		if _, err := tx.Exec("UPDATE DBConfig SET SchemaVersion=?;", ver+1); err != nil {
+			return false, fmt.Errorf("updating database schema version to %d: %w", ver+1, err)
+		}
+	}
 
 	return false, nil
 }
@@ -124,6 +1303,10 @@ func createSQLiteTables(tx *sql.Tx) error {
                 Name            TEXT    UNIQUE NOT NULL,
                 PodID           TEXT,
                 JSON            TEXT    NOT NULL,
+                RestartPolicy   TEXT    NOT NULL DEFAULT '',
+                CreatedTime     INTEGER NOT NULL DEFAULT 0,
+                ImageID         TEXT    NOT NULL DEFAULT '',
+                Namespace       TEXT    NOT NULL DEFAULT '',
                 FOREIGN KEY (ID)    REFERENCES IDNamespace(ID)    DEFERRABLE INITIALLY DEFERRED,
                 FOREIGN KEY (ID)    REFERENCES ContainerState(ID) DEFERRABLE INITIALLY DEFERRED,
                 FOREIGN KEY (PodID) REFERENCES PodConfig(ID)
@@ -143,6 +1326,7 @@ func createSQLiteTables(tx *sql.Tx) error {
         CREATE TABLE IF NOT EXISTS ContainerExecSession(
                 ID          TEXT PRIMARY KEY NOT NULL,
                 ContainerID TEXT NOT NULL,
+                JSON        TEXT NOT NULL DEFAULT '{}',
                 FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID)
         );`
 
@@ -165,6 +1349,23 @@ func createSQLiteTables(tx *sql.Tx) error {
                 FOREIGN KEY (VolumeName)  REFERENCES VolumeConfig(Name)
         );`
 
+	const containerMetadata = `
+        CREATE TABLE IF NOT EXISTS ContainerMetadata(
+                ContainerID TEXT NOT NULL,
+                Key         TEXT NOT NULL,
+                Value       TEXT NOT NULL,
+                PRIMARY KEY (ContainerID, Key),
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+	const containerNote = `
+        CREATE TABLE IF NOT EXISTS ContainerNote(
+                ID          INTEGER PRIMARY KEY AUTOINCREMENT,
+                ContainerID TEXT    NOT NULL,
+                JSON        TEXT    NOT NULL,
+                FOREIGN KEY (ContainerID) REFERENCES ContainerConfig(ID) DEFERRABLE INITIALLY DEFERRED
+        );`
+
 	const containerExitCode = `
         CREATE TABLE IF NOT EXISTS ContainerExitCode(
                 ID        TEXT    PRIMARY KEY NOT NULL,
@@ -173,11 +1374,65 @@ func createSQLiteTables(tx *sql.Tx) error {
                 CHECK (ExitCode BETWEEN -1 AND 255)
         );`
 
+	const imageProvenance = `
+        CREATE TABLE IF NOT EXISTS ImageProvenance(
+                ID        INTEGER PRIMARY KEY AUTOINCREMENT,
+                Source    TEXT    NOT NULL,
+                ImageID   TEXT    NOT NULL,
+                Digest    TEXT    NOT NULL,
+                Timestamp INTEGER NOT NULL
+        );`
+
+	const imageDigestPin = `
+        CREATE TABLE IF NOT EXISTS ImageDigestPin(
+                Source    TEXT    PRIMARY KEY NOT NULL,
+                ImageID   TEXT    NOT NULL,
+                Digest    TEXT    NOT NULL,
+                Timestamp INTEGER NOT NULL
+        );`
+
+	const volumeBackupPolicy = `
+        CREATE TABLE IF NOT EXISTS VolumeBackupPolicy(
+                Volume TEXT PRIMARY KEY NOT NULL,
+                JSON   TEXT NOT NULL,
+                FOREIGN KEY (Volume) REFERENCES VolumeConfig(Name) DEFERRABLE INITIALLY DEFERRED
+        );`
+
+	const volumeBackup = `
+        CREATE TABLE IF NOT EXISTS VolumeBackup(
+                ID     INTEGER PRIMARY KEY AUTOINCREMENT,
+                Volume TEXT    NOT NULL,
+                JSON   TEXT    NOT NULL
+        );`
+
+	const networkReservation = `
+        CREATE TABLE IF NOT EXISTS NetworkReservation(
+                Network TEXT NOT NULL,
+                Address TEXT NOT NULL,
+                JSON    TEXT NOT NULL,
+                PRIMARY KEY (Network, Address)
+        );`
+
+	const volumeSnapshot = `
+        CREATE TABLE IF NOT EXISTS VolumeSnapshot(
+                ID     INTEGER PRIMARY KEY AUTOINCREMENT,
+                Volume TEXT    NOT NULL,
+                JSON   TEXT    NOT NULL
+        );`
+
+	const checkpoint = `
+        CREATE TABLE IF NOT EXISTS Checkpoint(
+                ID          INTEGER PRIMARY KEY AUTOINCREMENT,
+                ContainerID TEXT    NOT NULL,
+                JSON        TEXT    NOT NULL
+        );`
+
 	const podConfig = `
         CREATE TABLE IF NOT EXISTS PodConfig(
                 ID              TEXT    PRIMARY KEY NOT NULL,
                 Name            TEXT    UNIQUE NOT NULL,
                 JSON            TEXT    NOT NULL,
+                Namespace       TEXT    NOT NULL DEFAULT '',
                 FOREIGN KEY (ID) REFERENCES IDNamespace(ID) DEFERRABLE INITIALLY DEFERRED,
                 FOREIGN KEY (ID) REFERENCES PodState(ID)    DEFERRABLE INITIALLY DEFERRED
         );`
@@ -207,18 +1462,64 @@ func createSQLiteTables(tx *sql.Tx) error {
         );`
 
 	tables := map[string]string{
-		"DBConfig":             dbConfig,
-		"IDNamespace":          idNamespace,
-		"ContainerConfig":      containerConfig,
-		"ContainerState":       containerState,
-		"ContainerExecSession": containerExecSession,
-		"ContainerDependency":  containerDependency,
-		"ContainerVolume":      containerVolume,
-		"ContainerExitCode":    containerExitCode,
-		"PodConfig":            podConfig,
-		"PodState":             podState,
-		"VolumeConfig":         volumeConfig,
-		"VolumeState":          volumeState,
+		"DBConfig":              dbConfig,
+		"DBMaintenance":         dbMaintenanceTable,
+		"StateChange":           stateChangeTable,
+		"ContainerGroup":        containerGroupTable,
+		"Checkpoint":            checkpoint,
+		"IDNamespace":           idNamespace,
+		"ContainerConfig":       containerConfig,
+		"ContainerState":        containerState,
+		"ContainerExecSession":  containerExecSession,
+		"ContainerDependency":   containerDependency,
+		"ContainerVolume":       containerVolume,
+		"ContainerLabel":        containerLabelTable,
+		"ContainerMetadata":     containerMetadata,
+		"ContainerNote":         containerNote,
+		"ContainerNetwork":      containerNetworkTable,
+		"ContainerStateBlob":    containerStateBlobTable,
+		"ContainerExitCode":     containerExitCode,
+		"ContainerExitHistory":  containerExitHistoryTable,
+		"HealthCheckLog":        healthCheckLogTable,
+		"ContainerStatsHistory": containerStatsHistoryTable,
+		"ImageProvenance":       imageProvenance,
+		"ImageDigestPin":        imageDigestPin,
+		"NetworkReservation":    networkReservation,
+		"PodConfig":             podConfig,
+		"PodState":              podState,
+		"PodContainer":          podContainerTable,
+		"PodInfraContainer":     podInfraContainerTable,
+		"PodSharedNamespace":    podSharedNamespaceTable,
+		"VolumeConfig":          volumeConfig,
+		"VolumeState":           volumeState,
+		"VolumeBackupPolicy":    volumeBackupPolicy,
+		"VolumeBackup":          volumeBackup,
+		"VolumeSnapshot":        volumeSnapshot,
+	}
+
+	// Indexes used to filter and sort containers in AllContainersFiltered
+	// and to look up exit histories, network containers, healthcheck logs,
+	// stats histories, image provenance, volume backups and snapshots, checkpoints and
+	// container notes.
+	// Container names are already indexed as they are unique.
+	indexes := map[string]string{
+		"ContainerConfigPodID":             "CREATE INDEX IF NOT EXISTS ContainerConfigPodID ON ContainerConfig(PodID);",
+		"ContainerConfigRestartPolicy":     "CREATE INDEX IF NOT EXISTS ContainerConfigRestartPolicy ON ContainerConfig(RestartPolicy);",
+		"ContainerConfigCreatedTime":       "CREATE INDEX IF NOT EXISTS ContainerConfigCreatedTime ON ContainerConfig(CreatedTime);",
+		"ContainerConfigImageID":           "CREATE INDEX IF NOT EXISTS ContainerConfigImageID ON ContainerConfig(ImageID);",
+		"ContainerConfigNamespace":         "CREATE INDEX IF NOT EXISTS ContainerConfigNamespace ON ContainerConfig(Namespace);",
+		"PodConfigNamespace":               "CREATE INDEX IF NOT EXISTS PodConfigNamespace ON PodConfig(Namespace);",
+		"ContainerLabelKeyValue":           "CREATE INDEX IF NOT EXISTS ContainerLabelKeyValue ON ContainerLabel(Key, Value);",
+		"ContainerExitHistoryID":           "CREATE INDEX IF NOT EXISTS ContainerExitHistoryID ON ContainerExitHistory(ContainerID);",
+		"ContainerNetworkNetwork":          "CREATE INDEX IF NOT EXISTS ContainerNetworkNetwork ON ContainerNetwork(Network);",
+		"HealthCheckLogContainerID":        "CREATE INDEX IF NOT EXISTS HealthCheckLogContainerID ON HealthCheckLog(ContainerID);",
+		"ContainerStatsHistoryContainerID": "CREATE INDEX IF NOT EXISTS ContainerStatsHistoryContainerID ON ContainerStatsHistory(ContainerID, Time);",
+		"ImageProvenanceSource":            "CREATE INDEX IF NOT EXISTS ImageProvenanceSource ON ImageProvenance(Source);",
+		"ImageProvenanceImageID":           "CREATE INDEX IF NOT EXISTS ImageProvenanceImageID ON ImageProvenance(ImageID);",
+		"VolumeBackupVolume":               "CREATE INDEX IF NOT EXISTS VolumeBackupVolume ON VolumeBackup(Volume);",
+		"VolumeSnapshotVolume":             "CREATE INDEX IF NOT EXISTS VolumeSnapshotVolume ON VolumeSnapshot(Volume);",
+		"CheckpointContainerID":            "CREATE INDEX IF NOT EXISTS CheckpointContainerID ON Checkpoint(ContainerID);",
+		"ContainerNoteContainerID":         "CREATE INDEX IF NOT EXISTS ContainerNoteContainerID ON ContainerNote(ContainerID);",
 	}
 
 	for tblName, cmd := range tables {
@@ -226,12 +1527,174 @@ func createSQLiteTables(tx *sql.Tx) error {
 			return fmt.Errorf("creating table %s: %w", tblName, err)
 		}
 	}
+	for idxName, cmd := range indexes {
+		if _, err := tx.Exec(cmd); err != nil {
+			return fmt.Errorf("creating index %s: %w", idxName, err)
+		}
+	}
+	for trigName, cmd := range sqliteStateChangeTriggers() {
+		if _, err := tx.Exec(cmd); err != nil {
+			return fmt.Errorf("creating trigger %s: %w", trigName, err)
+		}
+	}
+	return nil
+}
+
+// stateChangeTable records the changes of containers, pods and volumes made
+// by any process, so processes such as the system service can learn about
+// the changes made by others. The rows are written by the triggers of
+// sqliteStateChangeTriggers. The timestamp is in milliseconds since the Unix
+// epoch.
+const stateChangeTable = `
+        CREATE TABLE IF NOT EXISTS StateChange(
+                ID        INTEGER PRIMARY KEY AUTOINCREMENT,
+                Timestamp INTEGER NOT NULL,
+                Type      TEXT    NOT NULL,
+                ObjectID  TEXT    NOT NULL,
+                Operation TEXT    NOT NULL
+        );`
+
+// maxStateChanges is the number of changes kept in the StateChange table.
+const maxStateChanges = 10000
+
+// sqliteStateChangeTriggers returns the triggers recording the changes of
+// the config and state tables of containers, pods and volumes in the
+// StateChange table, and the trigger removing old changes.
+func sqliteStateChangeTriggers() map[string]string {
+	const timestamp = "CAST(ROUND((julianday('now') - 2440587.5) * 86400000) AS INTEGER)"
+	triggers := map[string]string{
+		"StateChangeTrim": fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS StateChangeTrim AFTER INSERT ON StateChange BEGIN DELETE FROM StateChange WHERE ID <= NEW.ID - %d; END;", maxStateChanges),
+	}
+	objects := []struct {
+		changeType              define.StateChangeType
+		configTable, stateTable string
+		idColumn                string
+	}{
+		{define.StateChangeContainer, "ContainerConfig", "ContainerState", "ID"},
+		{define.StateChangePod, "PodConfig", "PodState", "ID"},
+		{define.StateChangeVolume, "VolumeConfig", "VolumeState", "Name"},
+	}
+	for _, obj := range objects {
+		record := func(name, event, table, when, row string, op define.StateChangeOperation) {
+			triggers[name] = fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER %s ON %s%s BEGIN INSERT INTO StateChange (Timestamp, Type, ObjectID, Operation) VALUES (%s, '%s', %s.%s, '%s'); END;",
+				name, event, table, when, timestamp, obj.changeType, row, obj.idColumn, op)
+		}
+		// Saving an unchanged config or state is not a change.
+		const changed = " WHEN OLD.JSON <> NEW.JSON"
+		record(obj.configTable+"InsertChange", "INSERT", obj.configTable, "", "NEW", define.StateChangeCreate)
+		record(obj.configTable+"UpdateChange", "UPDATE", obj.configTable, changed, "NEW", define.StateChangeUpdate)
+		record(obj.configTable+"DeleteChange", "DELETE", obj.configTable, "", "OLD", define.StateChangeRemove)
+		record(obj.stateTable+"UpdateChange", "UPDATE", obj.stateTable, changed, "NEW", define.StateChangeUpdate)
+	}
+	return triggers
+}
+
+// sqliteContainerFilters returns the WHERE clause, including a leading space,
+// and its arguments selecting the rows of ContainerConfig matching the given
+// filters. If the filters limit the number of containers, the clause is
+// followed by the ORDER BY and LIMIT clauses selecting the most recently
+// created ones.
+func sqliteContainerFilters(filters *StateContainerFilters) (string, []any) {
+	if filters == nil {
+		return "", nil
+	}
+
+	var conds []string
+	var args []any
+
+	if len(filters.PodIDs) > 0 {
+		conds = append(conds, "ContainerConfig.PodID IN (?"+strings.Repeat(", ?", len(filters.PodIDs)-1)+")")
+		for _, id := range filters.PodIDs {
+			args = append(args, id)
+		}
+	}
+
+	if len(filters.NamePrefixes) > 0 && !slices.Contains(filters.NamePrefixes, "") {
+		// Compare names as ranges so the index on names is used.
+		nameConds := make([]string, 0, len(filters.NamePrefixes))
+		for _, prefix := range filters.NamePrefixes {
+			if upper, ok := prefixUpperBound(prefix); ok {
+				nameConds = append(nameConds, "(ContainerConfig.Name >= ? AND ContainerConfig.Name < ?)")
+				args = append(args, prefix, upper)
+			} else {
+				nameConds = append(nameConds, "ContainerConfig.Name >= ?")
+				args = append(args, prefix)
+			}
+		}
+		conds = append(conds, "("+strings.Join(nameConds, " OR ")+")")
+	}
+
+	for _, label := range filters.Labels {
+		key, value, hasValue := strings.Cut(label, "=")
+		if hasValue {
+			conds = append(conds, "ContainerConfig.ID IN (SELECT ContainerID FROM ContainerLabel WHERE Key=? AND Value=?)")
+			args = append(args, key, value)
+		} else {
+			conds = append(conds, "ContainerConfig.ID IN (SELECT ContainerID FROM ContainerLabel WHERE Key=?)")
+			args = append(args, key)
+		}
+	}
+
+	if len(filters.RestartPolicies) > 0 {
+		conds = append(conds, "ContainerConfig.RestartPolicy IN (?"+strings.Repeat(", ?", len(filters.RestartPolicies)-1)+")")
+		for _, policy := range filters.RestartPolicies {
+			args = append(args, policy)
+		}
+	}
+
+	if !filters.CreatedBefore.IsZero() {
+		conds = append(conds, "ContainerConfig.CreatedTime < ?")
+		args = append(args, filters.CreatedBefore.UnixNano())
+	}
+	if !filters.CreatedAfter.IsZero() {
+		conds = append(conds, "ContainerConfig.CreatedTime > ?")
+		args = append(args, filters.CreatedAfter.UnixNano())
+	}
+
+	if filters.namespace != "" {
+		conds = append(conds, "ContainerConfig.Namespace = ?")
+		args = append(args, filters.namespace)
+	}
+
+	var clause string
+	if len(conds) > 0 {
+		clause = " WHERE " + strings.Join(conds, " AND ")
+	}
+	if filters.Limit > 0 {
+		clause += " ORDER BY ContainerConfig.CreatedTime DESC LIMIT ?"
+		args = append(args, filters.Limit)
+	}
+	return clause, args
+}
+
+// prefixUpperBound returns the smallest string greater than all strings
+// starting with prefix. It returns false if there is none, i.e. if the prefix
+// consists of 0xff bytes only.
+func prefixUpperBound(prefix string) (string, bool) {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1]), true
+		}
+	}
+	return "", false
+}
+
+// insertContainerLabels records the labels of the given container in the
+// ContainerLabel table.
+func insertContainerLabels(tx *sqlTx, id string, labels map[string]string) error {
+	for key, value := range labels {
+		if _, err := tx.Exec("INSERT INTO ContainerLabel VALUES (?, ?, ?);", id, key, value); err != nil {
+			return fmt.Errorf("adding container %s label %s to database: %w", id, key, err)
+		}
+	}
 	return nil
 }
 
 // Get the config of a container with the given ID from the database
 func (s *SQLiteState) getCtrConfig(id string) (*ContainerConfig, error) {
-	row := s.conn.QueryRow("SELECT JSON FROM ContainerConfig WHERE ID=?;", id)
+	row := s.queryRow("SELECT JSON FROM ContainerConfig WHERE ID=?;", id)
 
 	var rawJSON string
 	if err := row.Scan(&rawJSON); err != nil {
@@ -349,12 +1812,12 @@ func finalizeVolumeSqlite(vol *Volume) error {
 }
 
 func (s *SQLiteState) rewriteContainerConfig(ctr *Container, newCfg *ContainerConfig) (defErr error) {
-	json, err := json.Marshal(newCfg)
+	json, err := containerConfigJSON(newCfg)
 	if err != nil {
 		return fmt.Errorf("error marshalling container %s new config JSON: %w", ctr.ID(), err)
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction to rewrite container %s config: %w", ctr.ID(), err)
 	}
@@ -366,7 +1829,8 @@ func (s *SQLiteState) rewriteContainerConfig(ctr *Container, newCfg *ContainerCo
 		}
 	}()
 
-	results, err := tx.Exec("UPDATE ContainerConfig SET Name=?, JSON=? WHERE ID=?;", newCfg.Name, json, ctr.ID())
+	args := append([]any{newCfg.Name, json}, containerConfigColumnValues(newCfg)...)
+	results, err := tx.Exec("UPDATE ContainerConfig SET Name=?, JSON=?, RestartPolicy=?, CreatedTime=?, ImageID=? WHERE ID=?;", append(args, ctr.ID())...)
 	if err != nil {
 		return fmt.Errorf("updating container config table with new configuration for container %s: %w", ctr.ID(), err)
 	}
@@ -379,6 +1843,13 @@ func (s *SQLiteState) rewriteContainerConfig(ctr *Container, newCfg *ContainerCo
 		return define.ErrNoSuchCtr
 	}
 
+	if _, err := tx.Exec("DELETE FROM ContainerLabel WHERE ContainerID=?;", ctr.ID()); err != nil {
+		return fmt.Errorf("removing container %s labels from database: %w", ctr.ID(), err)
+	}
+	if err := insertContainerLabels(tx, ctr.ID(), newCfg.Labels); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction to rewrite container %s config: %w", ctr.ID(), err)
 	}
@@ -386,25 +1857,127 @@ func (s *SQLiteState) rewriteContainerConfig(ctr *Container, newCfg *ContainerCo
 	return nil
 }
 
-func (s *SQLiteState) addContainer(ctr *Container) (defErr error) {
-	configJSON, err := json.Marshal(ctr.config)
+// containerDependentsQuery selects the edges of the dependency graph of all
+// containers depending on a container, directly or transitively. UNION
+// discards duplicate edges, which ends the recursion on cycles.
+const containerDependentsQuery = `
+        WITH RECURSIVE Dependent(ID, DependencyID) AS (
+                SELECT ID, DependencyID FROM ContainerDependency WHERE DependencyID=?
+                UNION
+                SELECT ContainerDependency.ID, ContainerDependency.DependencyID
+                FROM ContainerDependency INNER JOIN Dependent ON ContainerDependency.DependencyID = Dependent.ID
+        )
+        SELECT ID, DependencyID FROM Dependent;`
+
+// containerDependenciesQuery selects the edges of the dependency graph of all
+// containers a container depends on, directly or transitively.
+const containerDependenciesQuery = `
+        WITH RECURSIVE Dependency(ID, DependencyID) AS (
+                SELECT ID, DependencyID FROM ContainerDependency WHERE ID=?
+                UNION
+                SELECT ContainerDependency.ID, ContainerDependency.DependencyID
+                FROM ContainerDependency INNER JOIN Dependency ON ContainerDependency.ID = Dependency.DependencyID
+        )
+        SELECT ID, DependencyID FROM Dependency;`
+
+// renameContainerWithTx renames the given container in its configuration and
+// in the Name column. The configuration is read within the transaction so a
+// concurrent rename or rewrite is not lost. bindVars converts the ?
+// placeholders of a query to the syntax of the database.
+func renameContainerWithTx(tx *sql.Tx, ctr *Container, newName string, bindVars func(string) string) error {
+	var rawJSON string
+	if err := tx.QueryRow(bindVars("SELECT JSON FROM ContainerConfig WHERE ID=?;"), ctr.ID()).Scan(&rawJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctr.valid = false
+			return fmt.Errorf("no container with ID %s found in database: %w", ctr.ID(), define.ErrNoSuchCtr)
+		}
+		return fmt.Errorf("retrieving container %s config from database: %w", ctr.ID(), err)
+	}
+	config := new(ContainerConfig)
+	if err := json.Unmarshal([]byte(rawJSON), config); err != nil {
+		return fmt.Errorf("unmarshalling container %s config: %w", ctr.ID(), err)
+	}
+	if config.Name == newName {
+		return nil
+	}
+
+	var check int
+	err := tx.QueryRow(bindVars("SELECT 1 FROM ContainerConfig WHERE Name=?;"), newName).Scan(&check)
+	if err == nil {
+		return fmt.Errorf("name %q is in use, cannot rename container %s: %w", newName, ctr.ID(), define.ErrCtrExists)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("checking if container name %s exists in database: %w", newName, err)
+	}
+
+	config.Name = newName
+	newJSON, err := containerConfigJSON(config)
 	if err != nil {
-		return fmt.Errorf("marshalling container config json: %w", err)
+		return fmt.Errorf("marshalling container %s config JSON: %w", ctr.ID(), err)
+	}
+	if _, err := tx.Exec(bindVars("UPDATE ContainerConfig SET Name=?, JSON=? WHERE ID=?;"), newName, newJSON, ctr.ID()); err != nil {
+		return fmt.Errorf("renaming container %s in database: %w", ctr.ID(), err)
+	}
+	return nil
+}
+
+// updateContainerResourcesWithTx sets the resource limits in the
+// configuration of the given container, reading and writing the
+// configuration within the given transaction.
+func updateContainerResourcesWithTx(tx *sql.Tx, ctr *Container, resources *spec.LinuxResources, bindVars func(string) string) error {
+	var rawJSON string
+	if err := tx.QueryRow(bindVars("SELECT JSON FROM ContainerConfig WHERE ID=?;"), ctr.ID()).Scan(&rawJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctr.valid = false
+			return fmt.Errorf("no container with ID %s found in database: %w", ctr.ID(), define.ErrNoSuchCtr)
+		}
+		return fmt.Errorf("retrieving container %s config from database: %w", ctr.ID(), err)
+	}
+	config := new(ContainerConfig)
+	if err := json.Unmarshal([]byte(rawJSON), config); err != nil {
+		return fmt.Errorf("unmarshalling container %s config: %w", ctr.ID(), err)
 	}
 
-	stateJSON, err := json.Marshal(ctr.state)
+	setConfigResources(config, resources)
+	newJSON, err := containerConfigJSON(config)
 	if err != nil {
-		return fmt.Errorf("marshalling container state json: %w", err)
+		return fmt.Errorf("marshalling container %s config JSON: %w", ctr.ID(), err)
 	}
-	deps := ctr.Dependencies()
+	if _, err := tx.Exec(bindVars("UPDATE ContainerConfig SET JSON=? WHERE ID=?;"), newJSON, ctr.ID()); err != nil {
+		return fmt.Errorf("updating container %s resources in database: %w", ctr.ID(), err)
+	}
+	return nil
+}
 
-	podID := sql.NullString{}
-	if ctr.config.Pod != "" {
-		podID.Valid = true
-		podID.String = ctr.config.Pod
+// namespaceCondition returns a condition restricting the rows of the given
+// table to the namespace of the state, and its arguments. Both are empty if
+// no namespace is set.
+func (s *SQLiteState) namespaceCondition(table string) (string, []any) {
+	if s.namespace == "" {
+		return "", nil
+	}
+	return " AND " + table + ".Namespace=?", []any{s.namespace}
+}
+
+// checkNamespace returns ErrNSMismatch if a namespace is set and the container
+// or pod with the given ID is in another namespace, ns.
+func (s *SQLiteState) checkNamespace(kind, id, ns string) error {
+	if s.namespace != "" && s.namespace != ns {
+		return fmt.Errorf("%s %s is in namespace %q and we are in namespace %q: %w", kind, id, ns, s.namespace, define.ErrNSMismatch)
+	}
+	return nil
+}
+
+// addContainers adds the given containers to the database in a single
+// transaction.
+func (s *SQLiteState) addContainers(ctrs []*Container) (defErr error) {
+	for _, ctr := range ctrs {
+		if err := s.checkNamespace("container", ctr.ID(), ctr.config.Namespace); err != nil {
+			return err
+		}
 	}
 
-	tx, err := s.conn.Begin()
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning container create transaction: %w", err)
 	}
@@ -416,6 +1989,39 @@ func (s *SQLiteState) addContainer(ctr *Container) (defErr error) {
 		}
 	}()
 
+	for _, ctr := range ctrs {
+		if err := s.addContainerWithTx(ctr, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// addContainerWithTx adds the container with the specified transaction.
+// Callers are responsible for committing.
+func (s *SQLiteState) addContainerWithTx(ctr *Container, tx *sqlTx) error {
+	configJSON, err := containerConfigJSON(ctr.config)
+	if err != nil {
+		return fmt.Errorf("marshalling container config json: %w", err)
+	}
+
+	stateJSON, err := containerStateJSON(ctr.state)
+	if err != nil {
+		return fmt.Errorf("marshalling container state json: %w", err)
+	}
+	deps := ctr.Dependencies()
+
+	podID := sql.NullString{}
+	if ctr.config.Pod != "" {
+		podID.Valid = true
+		podID.String = ctr.config.Pod
+	}
+
 	// TODO: There has to be a better way of doing this
 	var check int
 	row := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE Name=?;", ctr.Name())
@@ -430,22 +2036,49 @@ func (s *SQLiteState) addContainer(ctr *Container) (defErr error) {
 	if _, err := tx.Exec("INSERT INTO IDNamespace VALUES (?);", ctr.ID()); err != nil {
 		return fmt.Errorf("adding container id to database: %w", err)
 	}
-	if _, err := tx.Exec("INSERT INTO ContainerConfig VALUES (?, ?, ?, ?);", ctr.ID(), ctr.Name(), podID, configJSON); err != nil {
+	args := append([]any{ctr.ID(), ctr.Name(), podID, configJSON}, containerConfigColumnValues(ctr.config)...)
+	args = append(args, ctr.config.Namespace)
+	if _, err := tx.Exec("INSERT INTO ContainerConfig (ID, Name, PodID, JSON, RestartPolicy, CreatedTime, ImageID, Namespace) VALUES (?, ?, ?, ?, ?, ?, ?, ?);", args...); err != nil {
 		return fmt.Errorf("adding container config to database: %w", err)
 	}
 	if _, err := tx.Exec("INSERT INTO ContainerState VALUES (?, ?, ?, ?);", ctr.ID(), int(ctr.state.State), ctr.state.ExitCode, stateJSON); err != nil {
 		return fmt.Errorf("adding container state to database: %w", err)
 	}
+	if err := saveContainerStateBlobs(tx.Tx, ctr.ID(), ctr.state, s.dialect.bindVars); err != nil {
+		return err
+	}
+	if err := insertContainerLabels(tx, ctr.ID(), ctr.config.Labels); err != nil {
+		return err
+	}
+	if err := insertContainerNetworks(tx.Tx, ctr.ID(), ctr.config.Networks, s.dialect.bindVars); err != nil {
+		return err
+	}
+	if ctr.config.Pod != "" {
+		var podNamespace string
+		if err := tx.QueryRow("SELECT Namespace FROM PodConfig WHERE ID=?;", ctr.config.Pod).Scan(&podNamespace); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("retrieving pod %s namespace from database: %w", ctr.config.Pod, err)
+		} else if err == nil && podNamespace != ctr.config.Namespace {
+			return fmt.Errorf("container %s is in namespace %q but its pod %s is in namespace %q: %w", ctr.ID(), ctr.config.Namespace, ctr.config.Pod, podNamespace, define.ErrNSMismatch)
+		}
+		if _, err := tx.Exec("INSERT INTO PodContainer VALUES (?, ?);", ctr.ID(), ctr.config.Pod); err != nil {
+			return fmt.Errorf("adding container %s to pod %s in database: %w", ctr.ID(), ctr.config.Pod, err)
+		}
+	}
 	for _, dep := range deps {
 		// Check if the dependency is in the same pod
-		var depPod sql.NullString
-		row := tx.QueryRow("SELECT PodID FROM ContainerConfig WHERE ID=?;", dep)
-		if err := row.Scan(&depPod); err != nil {
+		var (
+			depPod       sql.NullString
+			depNamespace string
+		)
+		row := tx.QueryRow("SELECT PodID, Namespace FROM ContainerConfig WHERE ID=?;", dep)
+		if err := row.Scan(&depPod, &depNamespace); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return fmt.Errorf("container dependency %s does not exist in database: %w", dep, define.ErrNoSuchCtr)
 			}
 		}
 		switch {
+		case depNamespace != ctr.config.Namespace:
+			return fmt.Errorf("container dependency %s is in namespace %q but container is in namespace %q: %w", dep, depNamespace, ctr.config.Namespace, define.ErrNSMismatch)
 		case ctr.config.Pod == "" && depPod.Valid:
 			return fmt.Errorf("container dependency %s is part of a pod, but container is not: %w", dep, define.ErrInvalidArg)
 		case ctr.config.Pod != "" && !depPod.Valid:
@@ -459,25 +2092,25 @@ func (s *SQLiteState) addContainer(ctr *Container) (defErr error) {
 		}
 	}
 	volMap := make(map[string]bool)
-	for _, vol := range ctr.config.NamedVolumes {
-		if _, ok := volMap[vol.Name]; !ok {
-			if _, err := tx.Exec("INSERT INTO ContainerVolume VALUES (?, ?);", ctr.ID(), vol.Name); err != nil {
-				return fmt.Errorf("adding container volume %s to database: %w", vol.Name, err)
+	for _, name := range ctr.volumeNames() {
+		if _, ok := volMap[name]; !ok {
+			if _, err := tx.Exec("INSERT INTO ContainerVolume VALUES (?, ?);", ctr.ID(), name); err != nil {
+				return fmt.Errorf("adding container volume %s to database: %w", name, err)
 			}
-			volMap[vol.Name] = true
+			volMap[name] = true
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing transaction: %w", err)
-	}
-
 	return nil
 }
 
 // removeContainer remove the specified container from the database.
 func (s *SQLiteState) removeContainer(ctr *Container) (defErr error) {
-	tx, err := s.conn.Begin()
+	if err := s.checkNamespace("container", ctr.ID(), ctr.config.Namespace); err != nil {
+		return err
+	}
+
+	tx, err := s.begin()
 	if err != nil {
 		return fmt.Errorf("beginning container %s removal transaction: %w", ctr.ID(), err)
 	}
@@ -501,9 +2134,44 @@ func (s *SQLiteState) removeContainer(ctr *Container) (defErr error) {
 	return nil
 }
 
+// removeContainers removes the given containers from the database in a
+// single transaction.
+func (s *SQLiteState) removeContainers(ctrs []*Container) (defErr error) {
+	for _, ctr := range ctrs {
+		if err := s.checkNamespace("container", ctr.ID(), ctr.config.Namespace); err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning containers removal transaction: %w", err)
+	}
+
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to remove containers: %v", err)
+			}
+		}
+	}()
+
+	for _, ctr := range ctrs {
+		if err := s.removeContainerWithTx(ctr.ID(), tx); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing containers removal transaction: %w", err)
+	}
+
+	return nil
+}
+
 // removeContainerWithTx removes the container with the specified transaction.
 // Callers are responsible for committing.
-func (s *SQLiteState) removeContainerWithTx(id string, tx *sql.Tx) error {
+func (s *SQLiteState) removeContainerWithTx(id string, tx *sqlTx) error {
 	// TODO TODO TODO:
 	// Need to verify that at least 1 row was deleted from ContainerConfig.
 	// Otherwise return ErrNoSuchCtr.
@@ -516,20 +2184,47 @@ func (s *SQLiteState) removeContainerWithTx(id string, tx *sql.Tx) error {
 	if _, err := tx.Exec("DELETE FROM ContainerState WHERE ID=?;", id); err != nil {
 		return fmt.Errorf("removing container %s state from database: %w", id, err)
 	}
+	if _, err := tx.Exec("DELETE FROM ContainerStateBlob WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s state blobs from database: %w", id, err)
+	}
 	if _, err := tx.Exec("DELETE FROM ContainerDependency WHERE ID=?;", id); err != nil {
 		return fmt.Errorf("removing container %s dependencies from database: %w", id, err)
 	}
 	if _, err := tx.Exec("DELETE FROM ContainerVolume WHERE ContainerID=?;", id); err != nil {
 		return fmt.Errorf("removing container %s volumes from database: %w", id, err)
 	}
+	if _, err := tx.Exec("DELETE FROM ContainerLabel WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s labels from database: %w", id, err)
+	}
 	if _, err := tx.Exec("DELETE FROM ContainerExecSession WHERE ContainerID=?;", id); err != nil {
 		return fmt.Errorf("removing container %s exec sessions from database: %w", id, err)
 	}
+	if _, err := tx.Exec("DELETE FROM ContainerMetadata WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s metadata from database: %w", id, err)
+	}
+	if _, err := tx.Exec("DELETE FROM ContainerNote WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s notes from database: %w", id, err)
+	}
+	if _, err := tx.Exec("DELETE FROM HealthCheckLog WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s healthcheck log from database: %w", id, err)
+	}
+	if _, err := tx.Exec("DELETE FROM ContainerStatsHistory WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s stats history from database: %w", id, err)
+	}
+	if _, err := tx.Exec("DELETE FROM ContainerNetwork WHERE ContainerID=?;", id); err != nil {
+		return fmt.Errorf("removing container %s networks from database: %w", id, err)
+	}
+	if _, err := tx.Exec("DELETE FROM PodContainer WHERE ContainerID=?;", id); err != nil {
+		if s.dialect.isForeignKeyError(err) {
+			return fmt.Errorf("container %s is the infra container of its pod and cannot be removed without removing the pod: %w", id, define.ErrCtrExists)
+		}
+		return fmt.Errorf("removing container %s from its pod in database: %w", id, err)
+	}
 	return nil
 }
 
 // networkModify allows you to modify or add a new network, to add a new network use the new bool
-func (s *SQLiteState) networkModify(ctr *Container, network string, opts types.PerNetworkOptions, new, disconnect bool) error {
+func (s *SQLiteState) networkModify(ctr *Container, network string, opts types.PerNetworkOptions, new, disconnect bool) (defErr error) {
 	if !s.valid {
 		return define.ErrDBClosed
 	}
@@ -546,35 +2241,62 @@ func (s *SQLiteState) networkModify(ctr *Container, network string, opts types.P
 		return fmt.Errorf("new and disconnect are mutually exclusive: %w", define.ErrInvalidArg)
 	}
 
-	// Grab a fresh copy of the config, in case anything changed
-	newCfg, err := s.getCtrConfig(ctr.ID())
-	if err != nil && errors.Is(err, define.ErrNoSuchCtr) {
-		ctr.valid = false
-		return define.ErrNoSuchCtr
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return fmt.Errorf("marshalling network options JSON for container %s: %w", ctr.ID(), err)
 	}
 
-	_, ok := newCfg.Networks[network]
-	if new && ok {
-		return fmt.Errorf("container %s is already connected to network %s: %w", ctr.ID(), network, define.ErrNetworkConnected)
-	}
-	if !ok && (!new || disconnect) {
-		return fmt.Errorf("container %s is not connected to network %s: %w", ctr.ID(), network, define.ErrNoSuchNetwork)
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("beginning container %s network transaction: %w", ctr.ID(), err)
 	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to modify container %s network %s: %v", ctr.ID(), network, err)
+			}
+		}
+	}()
 
-	if !disconnect {
-		if newCfg.Networks == nil {
-			newCfg.Networks = make(map[string]types.PerNetworkOptions)
+	var exists int
+	if err := tx.QueryRow("SELECT 1 FROM ContainerConfig WHERE ID=?;", ctr.ID()).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctr.valid = false
+			return define.ErrNoSuchCtr
 		}
-		newCfg.Networks[network] = opts
-	} else {
-		delete(newCfg.Networks, network)
+		return fmt.Errorf("checking if container %s exists in database: %w", ctr.ID(), err)
 	}
 
-	if err := s.rewriteContainerConfig(ctr, newCfg); err != nil {
-		return err
+	var result sql.Result
+	switch {
+	case new:
+		var connected int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM ContainerNetwork WHERE ContainerID=? AND Network=?;", ctr.ID(), network).Scan(&connected); err != nil {
+			return fmt.Errorf("checking if container %s is connected to network %s: %w", ctr.ID(), network, err)
+		}
+		if connected > 0 {
+			return fmt.Errorf("container %s is already connected to network %s: %w", ctr.ID(), network, define.ErrNetworkConnected)
+		}
+		result, err = tx.Exec("INSERT INTO ContainerNetwork (ContainerID, Network, OptionsJSON) VALUES (?, ?, ?);", ctr.ID(), network, optsJSON)
+	case disconnect:
+		result, err = tx.Exec("DELETE FROM ContainerNetwork WHERE ContainerID=? AND Network=?;", ctr.ID(), network)
+	default:
+		result, err = tx.Exec("UPDATE ContainerNetwork SET OptionsJSON=? WHERE ContainerID=? AND Network=?;", optsJSON, ctr.ID(), network)
+	}
+	if err != nil {
+		return fmt.Errorf("updating container %s network %s in database: %w", ctr.ID(), network, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving container %s network %s rows affected: %w", ctr.ID(), network, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("container %s is not connected to network %s: %w", ctr.ID(), network, define.ErrNoSuchNetwork)
 	}
 
-	ctr.config = newCfg
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing container %s network transaction: %w", ctr.ID(), err)
+	}
 
 	return nil
 }
@@ -0,0 +1,368 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getSchemaV1State returns a SQLite state at dbPath populated with one
+// container, whose DBConfig claims schema version 1.
+func getSchemaV1State(t *testing.T, dbPath string) (*Runtime, *Container) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	_, err = state.conn.Exec("INSERT INTO DBConfig VALUES (1, 1, 'linux', '', '', '', '', '', '');")
+	require.NoError(t, err)
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+	return runtime, ctr
+}
+
+// getSchemaVersion returns the schema version recorded in the database.
+func getSchemaVersion(t *testing.T, state *SQLiteState) int {
+	var version int
+	require.NoError(t, state.conn.QueryRow("SELECT SchemaVersion FROM DBConfig;").Scan(&version))
+	return version
+}
+
+// setSchemaMigrations replaces the registered migrations until the test ends.
+func setSchemaMigrations(t *testing.T, migrations []schemaMigration) {
+	old := schemaMigrations
+	schemaMigrations = migrations
+	t.Cleanup(func() {
+		schemaMigrations = old
+	})
+}
+
+var testMigrations = []schemaMigration{
+	{
+		description: "add container Note column",
+		migrate: func(tx *sql.Tx) error {
+			_, err := tx.Exec("ALTER TABLE ContainerConfig ADD COLUMN Note TEXT;")
+			return err
+		},
+	},
+	{
+		description: "fill container Note column",
+		migrate: func(tx *sql.Tx) error {
+			_, err := tx.Exec("UPDATE ContainerConfig SET Note=Name;")
+			return err
+		},
+	},
+}
+
+func TestSchemaMigrationUpgradesV1(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, ctr := getSchemaV1State(t, dbPath)
+
+	setSchemaMigrations(t, testMigrations)
+	assert.Equal(t, 3, currentSchemaVersion())
+
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, 3, getSchemaVersion(t, state))
+
+	var note string
+	require.NoError(t, state.conn.QueryRow("SELECT Note FROM ContainerConfig WHERE ID=?;", ctr.ID()).Scan(&note))
+	assert.Equal(t, ctr.Name(), note)
+
+	retrievedCtr, err := state.Container(ctr.ID())
+	require.NoError(t, err)
+	testContainersEqual(t, retrievedCtr, ctr, true)
+
+	// Opening again must not apply the migrations twice.
+	require.NoError(t, state.Close())
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, getSchemaVersion(t, state))
+	require.NoError(t, state.Close())
+}
+
+func TestSchemaMigrationContainerLabels(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, ctr := getSchemaV1State(t, dbPath)
+
+	// Schema version 1 has no label table.
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("DROP TABLE ContainerLabel;")
+	require.NoError(t, err)
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=1;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	ctrs, err := state.AllContainersFiltered(false, &StateContainerFilters{Labels: []string{"a=b"}})
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, ctr.ID(), ctrs[0].ID())
+}
+
+func TestSchemaMigrationContainerConfigColumns(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, ctr := getSchemaV1State(t, dbPath)
+
+	// Schema version 3 has no restart policy, created time and image ID
+	// columns.
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	for _, column := range containerConfigColumns {
+		_, err = state.conn.Exec("DROP INDEX ContainerConfig" + column.name + ";")
+		require.NoError(t, err)
+		_, err = state.conn.Exec("ALTER TABLE ContainerConfig DROP COLUMN " + column.name + ";")
+		require.NoError(t, err)
+	}
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=3;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	var restartPolicy, imageID string
+	var created int64
+	require.NoError(t, state.conn.QueryRow("SELECT RestartPolicy, CreatedTime, ImageID FROM ContainerConfig WHERE ID=?;", ctr.ID()).Scan(&restartPolicy, &created, &imageID))
+	assert.Equal(t, ctr.config.RestartPolicy, restartPolicy)
+	assert.Equal(t, ctr.config.CreatedTime.UnixNano(), created)
+	assert.Equal(t, ctr.config.RootfsImageID, imageID)
+
+	ctrs, err := state.AllContainersFiltered(false, &StateContainerFilters{CreatedAfter: ctr.config.CreatedTime.Add(-time.Second)})
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, ctr.ID(), ctrs[0].ID())
+}
+
+func TestSchemaMigrationContainerExitHistory(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, _ := getSchemaV1State(t, dbPath)
+
+	// Schema version 4 only has the latest exit code of every container.
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("DROP TABLE ContainerExitHistory;")
+	require.NoError(t, err)
+	timeStamp := time.Now().Unix()
+	_, err = state.conn.Exec("INSERT INTO ContainerExitCode VALUES (?, ?, ?);", "removed", timeStamp, 42)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=4;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	history, err := state.ContainerExitHistory("removed")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, int32(42), history[0].ExitCode)
+	assert.Equal(t, timeStamp, history[0].ExitedAt.Unix())
+}
+
+func TestSchemaMigrationExecSessionJSON(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, ctr := getSchemaV1State(t, dbPath)
+
+	// Before schema version 8, the table only held the IDs of the sessions.
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("DROP TABLE ContainerExecSession;")
+	require.NoError(t, err)
+	_, err = state.conn.Exec("CREATE TABLE ContainerExecSession(ID TEXT PRIMARY KEY NOT NULL, ContainerID TEXT NOT NULL);")
+	require.NoError(t, err)
+	_, err = state.conn.Exec("INSERT INTO ContainerExecSession VALUES (?, ?);", "abcd", ctr.ID())
+	require.NoError(t, err)
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=7;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	session, err := state.ExecSession("abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", session.ID())
+	assert.Equal(t, ctr.ID(), session.ContainerID())
+	assert.Equal(t, 9876, session.PID)
+}
+
+func TestSchemaMigrationContainerNetworks(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, ctr := getSchemaV1State(t, dbPath)
+
+	// Before schema version 10, the networks were part of the config.
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("DROP TABLE ContainerNetwork;")
+	require.NoError(t, err)
+	_, err = state.conn.Exec(`UPDATE ContainerConfig SET JSON=json_set(JSON, '$.networkMode', 'bridge', '$.newNetworks', json('{"podman":{"interface_name":"eth0"}}'));`)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=9;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	var rawJSON string
+	require.NoError(t, state.conn.QueryRow("SELECT JSON FROM ContainerConfig WHERE ID=?;", ctr.ID()).Scan(&rawJSON))
+	assert.NotContains(t, rawJSON, "newNetworks")
+
+	ctrs, err := state.NetworkContainers("podman")
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	networks, err := state.GetNetworks(ctrs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "eth0", networks["podman"].InterfaceName)
+}
+
+func TestSchemaMigrationPodTables(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, _ := getSchemaV1State(t, dbPath)
+
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	pod, err := getTestPodN("4", runtime.lockManager)
+	require.NoError(t, err)
+	pod.config.UsePodNet = true
+	require.NoError(t, state.AddPod(pod))
+	infra, err := getTestCtrN("5", runtime.lockManager)
+	require.NoError(t, err)
+	infra.config.Pod = pod.ID()
+	require.NoError(t, state.AddContainerToPod(pod, infra))
+	pod.state.InfraContainerID = infra.ID()
+	require.NoError(t, state.SavePod(pod))
+
+	// Before schema version 12, pod membership was only recorded in
+	// ContainerConfig and the infra container in the pod state.
+	for _, table := range []string{"PodInfraContainer", "PodSharedNamespace", "PodContainer"} {
+		_, err = state.conn.Exec("DROP TABLE " + table + ";")
+		require.NoError(t, err)
+	}
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=11;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	exists, err := state.PodHasContainer(pod, infra.ID())
+	require.NoError(t, err)
+	assert.True(t, exists)
+	var infraID, namespace string
+	require.NoError(t, state.conn.QueryRow("SELECT ContainerID FROM PodInfraContainer WHERE PodID=?;", pod.ID()).Scan(&infraID))
+	assert.Equal(t, infra.ID(), infraID)
+	require.NoError(t, state.conn.QueryRow("SELECT Namespace FROM PodSharedNamespace WHERE PodID=?;", pod.ID()).Scan(&namespace))
+	assert.Equal(t, "net", namespace)
+}
+
+func TestSchemaMigrationContainerStateBlobs(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, ctr := getSchemaV1State(t, dbPath)
+
+	// Before schema version 18, the network status and bind mounts were
+	// part of the state.
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("DROP TABLE ContainerStateBlob;")
+	require.NoError(t, err)
+	_, err = state.conn.Exec(`UPDATE ContainerState SET JSON=json_set(JSON, '$.networkStatus', json('{"podman":{}}'), '$.bindMounts', json('{"/etc/hosts":"/run/hosts"}'));`)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("UPDATE DBConfig SET SchemaVersion=17;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, currentSchemaVersion(), getSchemaVersion(t, state))
+	var rawJSON string
+	require.NoError(t, state.conn.QueryRow("SELECT JSON FROM ContainerState WHERE ID=?;", ctr.ID()).Scan(&rawJSON))
+	assert.NotContains(t, rawJSON, "networkStatus")
+	assert.NotContains(t, rawJSON, "bindMounts")
+
+	ctr.valid = true
+	require.NoError(t, state.UpdateContainer(ctr))
+	assert.Contains(t, ctr.state.NetworkStatus, "podman")
+	assert.Equal(t, map[string]string{"/etc/hosts": "/run/hosts"}, ctr.state.BindMounts)
+}
+
+func TestSchemaMigrationFailureRollsBack(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, _ := getSchemaV1State(t, dbPath)
+
+	setSchemaMigrations(t, []schemaMigration{
+		testMigrations[0],
+		{
+			description: "fail",
+			migrate: func(tx *sql.Tx) error {
+				return errors.New("migration failed")
+			},
+		},
+	})
+
+	_, err := newSqliteState(runtime, dbPath)
+	require.ErrorContains(t, err, "migrating database schema from version 2 to 3: migration failed")
+
+	// Neither migration may have been applied.
+	setSchemaMigrations(t, nil)
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	assert.Equal(t, 1, getSchemaVersion(t, state))
+	_, err = state.conn.Exec("SELECT Note FROM ContainerConfig;")
+	assert.ErrorContains(t, err, "no such column")
+}
+
+func TestSchemaMigrationNewerVersion(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	runtime, _ := getSchemaV1State(t, dbPath)
+
+	setSchemaMigrations(t, testMigrations)
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	setSchemaMigrations(t, nil)
+	_, err = newSqliteState(runtime, dbPath)
+	assert.ErrorContains(t, err, "database has schema version 3 while this libpod version only supports version 1")
+}
@@ -0,0 +1,1201 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/podman/v5/pkg/containersconf"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteDSN(t *testing.T) {
+	if env, ok := os.LookupEnv("PODMAN_SQLITE_BUSY_TIMEOUT"); ok {
+		t.Cleanup(func() { os.Setenv("PODMAN_SQLITE_BUSY_TIMEOUT", env) })
+		os.Unsetenv("PODMAN_SQLITE_BUSY_TIMEOUT")
+	}
+
+	tests := []struct {
+		name     string
+		engine   containersconf.EngineConfig
+		readOnly bool
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "defaults",
+			want: "db.sql" + sqliteOptions + "&_sync=FULL&_busy_timeout=100000",
+		},
+		{
+			name: "tuned",
+			engine: containersconf.EngineConfig{
+				DBSynchronous: "normal",
+				DBJournalMode: "wal",
+				DBBusyTimeout: 5000,
+				DBCacheSize:   -8000,
+			},
+			want: "db.sql" + sqliteOptions + "&_sync=NORMAL&_journal_mode=WAL&_busy_timeout=5000&_cache_size=-8000",
+		},
+		{
+			name:     "read only",
+			engine:   containersconf.EngineConfig{DBJournalMode: "wal"},
+			readOnly: true,
+			want:     "file:db.sql" + sqliteReadOnlyOptions + "&_sync=FULL&_busy_timeout=100000",
+		},
+		{
+			name:    "invalid synchronous",
+			engine:  containersconf.EngineConfig{DBSynchronous: "always"},
+			wantErr: `invalid database_synchronous "always"`,
+		},
+		{
+			name:    "invalid journal mode",
+			engine:  containersconf.EngineConfig{DBJournalMode: "wal2"},
+			wantErr: `invalid database_journal_mode "wal2"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := new(Runtime)
+			runtime.config = new(config.Config)
+			runtime.podmanConf.Engine = tt.engine
+			runtime.readOnlyState = tt.readOnly
+			dsn, err := sqliteDSN(runtime, "db.sql")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dsn)
+		})
+	}
+}
+
+func TestSqlitePragmas(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.podmanConf.Engine = containersconf.EngineConfig{
+		DBJournalMode: "wal",
+		DBSynchronous: "normal",
+		DBCacheSize:   100,
+		DBMmapSize:    1 << 20,
+	}
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	pragmas := map[string]string{
+		"journal_mode": "wal",
+		// NORMAL
+		"synchronous": "1",
+		"cache_size":  "100",
+		"mmap_size":   "1048576",
+	}
+	for pragma, want := range pragmas {
+		var value string
+		require.NoError(t, state.conn.QueryRow("PRAGMA "+pragma+";").Scan(&value))
+		assert.Equal(t, want, value, pragma)
+	}
+}
+
+func TestSqliteRetryWhileLocked(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.podmanConf.Engine = containersconf.EngineConfig{
+		// Readers are only blocked by writers with a rollback journal.
+		DBJournalMode: "delete",
+		DBBusyTimeout: 1,
+	}
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	dsn, err := sqliteDSN(runtime, dbPath)
+	require.NoError(t, err)
+	locker, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	defer locker.Close()
+	tx, err := locker.Begin()
+	require.NoError(t, err)
+
+	// Without retrying the busy timeout expires right away.
+	var count int
+	err = state.conn.QueryRow("SELECT COUNT(*) FROM ContainerConfig;").Scan(&count)
+	require.Error(t, err)
+	assert.True(t, isRetryableSQLiteError(err), err.Error())
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = tx.Rollback()
+	}()
+	ctrs, err := state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+}
+
+func TestSqliteVerify(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+
+	report, err := state.Verify()
+	require.NoError(t, err)
+	assert.False(t, report.HasProblems(), "%+v", report)
+
+	old := time.Now().Add(-10 * time.Minute).Unix()
+	for id, timeStamp := range map[string]int64{"orphan": old, "recent": time.Now().Unix(), ctr.ID(): old} {
+		_, err := state.conn.Exec("INSERT INTO ContainerExitCode VALUES (?, ?, 1);", id, timeStamp)
+		require.NoError(t, err)
+	}
+
+	report, err = state.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orphan"}, report.OrphanExitCodes)
+	assert.Empty(t, report.Errors)
+}
+
+func TestSqliteDatabaseInfo(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	path := filepath.Join(t.TempDir(), sqliteDBName)
+	state, err := newSqliteState(runtime, path)
+	require.NoError(t, err)
+	defer state.Close()
+
+	_, err = state.conn.Exec("INSERT INTO DBConfig VALUES (1, ?, 'linux', '', '', '', '', '', '');", currentSchemaVersion())
+	require.NoError(t, err)
+
+	info, err := state.DatabaseInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "sqlite", info.Type)
+	assert.Equal(t, path, info.Path)
+	assert.Equal(t, currentSchemaVersion(), info.SchemaVersion)
+	assert.Positive(t, info.Size)
+	assert.Nil(t, info.LastVacuum)
+	assert.Nil(t, info.LastBackup)
+	assert.Nil(t, info.LastCheck)
+	assert.Equal(t, define.DBIntegrityUnchecked, info.Integrity)
+
+	before := time.Now()
+	require.NoError(t, state.RecordMaintenance(dbMaintenanceCheck, define.DBIntegrityProblems))
+	require.NoError(t, state.RecordMaintenance(dbMaintenanceCheck, define.DBIntegrityOK))
+	require.NoError(t, state.RecordMaintenance(dbMaintenanceVacuum, ""))
+
+	info, err = state.DatabaseInfo()
+	require.NoError(t, err)
+	require.NotNil(t, info.LastCheck)
+	assert.False(t, info.LastCheck.Before(before))
+	assert.Equal(t, define.DBIntegrityOK, info.Integrity)
+	assert.NotNil(t, info.LastVacuum)
+	assert.Nil(t, info.LastBackup)
+}
+
+func TestSqliteStateChanges(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	latest, err := state.LatestStateChange()
+	require.NoError(t, err)
+	assert.Zero(t, latest)
+
+	before := time.Now().Truncate(time.Millisecond)
+	pod, err := getTestPod1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddPod(pod))
+	// Saving an unchanged state is not recorded.
+	require.NoError(t, state.SavePod(pod))
+	pod.state.CgroupPath = "/another/path"
+	require.NoError(t, state.SavePod(pod))
+	require.NoError(t, state.RemovePod(pod))
+
+	changes, err := state.StateChanges(0)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	for i, op := range []define.StateChangeOperation{define.StateChangeCreate, define.StateChangeUpdate, define.StateChangeRemove} {
+		assert.Equal(t, define.StateChangePod, changes[i].Type)
+		assert.Equal(t, pod.ID(), changes[i].ObjectID)
+		assert.Equal(t, op, changes[i].Operation)
+		assert.False(t, changes[i].Time.Before(before))
+	}
+
+	latest, err = state.LatestStateChange()
+	require.NoError(t, err)
+	assert.Equal(t, changes[2].ID, latest)
+
+	changes, err = state.StateChanges(changes[0].ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, define.StateChangeUpdate, changes[0].Operation)
+
+	// Only the most recent changes are kept.
+	_, err = state.conn.Exec(fmt.Sprintf(`WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i+1 FROM n WHERE i<%d)
+		INSERT INTO StateChange (Timestamp, Type, ObjectID, Operation) SELECT 0, 'volume', 'vol', 'update' FROM n;`, maxStateChanges))
+	require.NoError(t, err)
+	changes, err = state.StateChanges(0)
+	require.NoError(t, err)
+	assert.Len(t, changes, maxStateChanges)
+	assert.Equal(t, latest+1, changes[0].ID)
+}
+
+func TestSqliteContainerExitHistory(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	testContainerExitHistory(t, state, nil)
+
+	runtime.podmanConf.Engine.ExitCodeRetention = 60
+	old := time.Now().Add(-2 * time.Minute).Unix()
+	for _, id := range []string{"old", "ctr"} {
+		_, err := state.conn.Exec("INSERT INTO ContainerExitHistory (ContainerID, Timestamp, ExitCode) VALUES (?, ?, 3);", id, old)
+		require.NoError(t, err)
+	}
+	require.NoError(t, state.PruneContainerExitCodes())
+
+	history, err := state.ContainerExitHistory("old")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	// Only the exits older than the retention are removed.
+	history, err = state.ContainerExitHistory("ctr")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, int32(2), history[1].ExitCode)
+}
+
+func TestSqliteExecSessions(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.state.ExecSessions = map[string]*ExecSession{}
+	require.NoError(t, state.AddContainer(ctr))
+
+	session := &ExecSession{
+		Id:          strings.Repeat("e", 64),
+		ContainerId: ctr.ID(),
+		State:       define.ExecStateRunning,
+		PID:         42,
+		Config:      &ExecConfig{Command: []string{"sleep", "inf"}},
+	}
+	ctr.state.ExecSessions[session.ID()] = session
+	require.NoError(t, state.AddExecSession(ctr, session))
+
+	fromDB, err := state.ExecSession(session.ID())
+	require.NoError(t, err)
+	assert.Equal(t, define.ExecStateRunning, fromDB.State)
+	assert.Equal(t, []string{"sleep", "inf"}, fromDB.Config.Command)
+
+	// Saving the container updates the sessions in its state.
+	session.State = define.ExecStateStopped
+	session.ExitCode = 3
+	require.NoError(t, state.SaveContainer(ctr))
+
+	sessions, err := state.AllExecSessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, session.ID(), sessions[0].ID())
+	assert.Equal(t, ctr.ID(), sessions[0].ContainerID())
+	assert.Equal(t, define.ExecStateStopped, sessions[0].State)
+	assert.Equal(t, 3, sessions[0].ExitCode)
+
+	require.NoError(t, state.RemoveExecSession(session))
+	_, err = state.ExecSession(session.ID())
+	assert.ErrorIs(t, err, define.ErrNoSuchExecSession)
+	sessions, err = state.AllExecSessions()
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestSqliteContainerMetadata(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+
+	metadata, err := state.GetContainerMetadata(ctr.ID())
+	require.NoError(t, err)
+	assert.Empty(t, metadata)
+
+	require.NoError(t, state.SetContainerMetadata(ctr.ID(), "io.podman.auto-update.digest", "sha256:1"))
+	require.NoError(t, state.SetContainerMetadata(ctr.ID(), "owner", "agent"))
+	require.NoError(t, state.SetContainerMetadata(ctr.ID(), "io.podman.auto-update.digest", "sha256:2"))
+	metadata, err = state.GetContainerMetadata(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"io.podman.auto-update.digest": "sha256:2", "owner": "agent"}, metadata)
+
+	// An empty value removes the entry.
+	require.NoError(t, state.SetContainerMetadata(ctr.ID(), "owner", ""))
+	metadata, err = state.GetContainerMetadata(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"io.podman.auto-update.digest": "sha256:2"}, metadata)
+
+	assert.ErrorIs(t, state.SetContainerMetadata("missing", "owner", "agent"), define.ErrNoSuchCtr)
+	assert.ErrorIs(t, state.SetContainerMetadata(ctr.ID(), "", "agent"), define.ErrInvalidArg)
+
+	// The metadata is removed together with the container.
+	require.NoError(t, state.RemoveContainer(ctr))
+	metadata, err = state.GetContainerMetadata(ctr.ID())
+	require.NoError(t, err)
+	assert.Empty(t, metadata)
+}
+
+func TestSqliteContainerNotes(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+
+	notes, err := state.GetContainerNotes(ctr.ID())
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	created := time.Unix(time.Now().Unix(), 0)
+	first := &define.ContainerNote{Created: created, Text: "rotated certs 2024-05-01"}
+	second := &define.ContainerNote{Created: created.Add(time.Hour), Text: "bumped memory limit"}
+	require.NoError(t, state.AddContainerNote(ctr.ID(), first))
+	require.NoError(t, state.AddContainerNote(ctr.ID(), second))
+	assert.NotZero(t, first.ID)
+	assert.ErrorIs(t, state.AddContainerNote("missing", &define.ContainerNote{Text: "note"}), define.ErrNoSuchCtr)
+
+	notes, err = state.GetContainerNotes(ctr.ID())
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, first.ID, notes[0].ID)
+	assert.Equal(t, "rotated certs 2024-05-01", notes[0].Text)
+	assert.True(t, second.Created.Equal(notes[1].Created))
+
+	require.NoError(t, state.RemoveContainerNote(ctr.ID(), first.ID))
+	assert.ErrorIs(t, state.RemoveContainerNote(ctr.ID(), first.ID), define.ErrNoSuchContainerNote)
+	assert.ErrorIs(t, state.RemoveContainerNote("other", second.ID), define.ErrNoSuchContainerNote)
+
+	// The notes are removed together with the container.
+	require.NoError(t, state.RemoveContainer(ctr))
+	notes, err = state.GetContainerNotes(ctr.ID())
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+}
+
+func TestSqliteContainerNetworks(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.config.NetMode = "bridge"
+	ctr.config.Networks = map[string]types.PerNetworkOptions{"podman": {InterfaceName: "eth0"}}
+	require.NoError(t, state.AddContainer(ctr))
+
+	// The networks are not part of the stored config.
+	var rawJSON string
+	require.NoError(t, state.conn.QueryRow("SELECT JSON FROM ContainerConfig WHERE ID=?;", ctr.ID()).Scan(&rawJSON))
+	assert.NotContains(t, rawJSON, "newNetworks")
+
+	networks, err := state.GetNetworks(ctr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]types.PerNetworkOptions{"podman": {InterfaceName: "eth0"}}, networks)
+
+	require.NoError(t, state.NetworkConnect(ctr, "net1", types.PerNetworkOptions{InterfaceName: "eth1"}))
+	assert.ErrorIs(t, state.NetworkConnect(ctr, "net1", types.PerNetworkOptions{InterfaceName: "eth1"}), define.ErrNetworkConnected)
+	require.NoError(t, state.NetworkModify(ctr, "net1", types.PerNetworkOptions{InterfaceName: "eth1", Aliases: []string{"web"}}))
+	assert.ErrorIs(t, state.NetworkModify(ctr, "net2", types.PerNetworkOptions{InterfaceName: "eth2"}), define.ErrNoSuchNetwork)
+	networks, err = state.GetNetworks(ctr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, networks["net1"].Aliases)
+
+	ctrs, err := state.NetworkContainers("net1")
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, ctr.ID(), ctrs[0].ID())
+
+	require.NoError(t, state.NetworkDisconnect(ctr, "net1"))
+	assert.ErrorIs(t, state.NetworkDisconnect(ctr, "net1"), define.ErrNoSuchNetwork)
+	ctrs, err = state.NetworkContainers("net1")
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+
+	// The networks are removed together with the container.
+	require.NoError(t, state.RemoveContainer(ctr))
+	ctrs, err = state.NetworkContainers("podman")
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+}
+
+func TestSqliteContainerStateBlobs(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.state.BindMounts = map[string]string{"/etc/hosts": "/run/hosts"}
+	require.NoError(t, state.AddContainer(ctr))
+	ctr.state.NetworkStatus = map[string]types.StatusBlock{"podman": {}}
+	require.NoError(t, state.SaveContainer(ctr))
+
+	// The blobs are not part of the stored state.
+	var rawJSON string
+	require.NoError(t, state.conn.QueryRow("SELECT JSON FROM ContainerState WHERE ID=?;", ctr.ID()).Scan(&rawJSON))
+	assert.NotContains(t, rawJSON, "networkStatus")
+	assert.NotContains(t, rawJSON, "bindMounts")
+
+	require.NoError(t, state.UpdateContainer(ctr))
+	assert.Equal(t, map[string]string{"/etc/hosts": "/run/hosts"}, ctr.state.BindMounts)
+	assert.Contains(t, ctr.state.NetworkStatus, "podman")
+
+	ctrs, err := state.AllContainers(true)
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, ctr.state.BindMounts, ctrs[0].state.BindMounts)
+	assert.Equal(t, ctr.state.NetworkStatus, ctrs[0].state.NetworkStatus)
+
+	// Unset fields have no rows.
+	ctr.state.NetworkStatus = nil
+	require.NoError(t, state.SaveContainer(ctr))
+	require.NoError(t, state.UpdateContainer(ctr))
+	assert.Empty(t, ctr.state.NetworkStatus)
+	var count int
+	require.NoError(t, state.conn.QueryRow("SELECT COUNT(*) FROM ContainerStateBlob WHERE ContainerID=?;", ctr.ID()).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// Refreshing the state resets the bind mounts.
+	require.NoError(t, state.Refresh())
+	require.NoError(t, state.UpdateContainer(ctr))
+	assert.Empty(t, ctr.state.BindMounts)
+
+	ctr.state.BindMounts = map[string]string{"/etc/hosts": "/run/hosts"}
+	require.NoError(t, state.SaveContainer(ctr))
+	require.NoError(t, state.RemoveContainer(ctr))
+	require.NoError(t, state.conn.QueryRow("SELECT COUNT(*) FROM ContainerStateBlob;").Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestSqliteHealthCheckLog(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+
+	history, err := state.GetHealthCheckLog(ctr.ID())
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	for i := 0; i < 4; i++ {
+		entry := &define.HealthCheckHistoryEntry{
+			HealthCheckLog: define.HealthCheckLog{
+				Start:    fmt.Sprintf("2024-01-01T00:00:0%dZ", i),
+				End:      fmt.Sprintf("2024-01-01T00:00:0%d.5Z", i),
+				ExitCode: i % 2,
+				Output:   fmt.Sprintf("run %d", i),
+			},
+			Status: define.HealthCheckHealthy,
+		}
+		require.NoError(t, state.AddHealthCheckLog(ctr.ID(), entry, 3))
+	}
+
+	// Only the 3 most recent runs are kept.
+	history, err = state.GetHealthCheckLog(ctr.ID())
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "run 1", history[0].Output)
+	assert.Equal(t, 1, history[0].ExitCode)
+	assert.Equal(t, "2024-01-01T00:00:03Z", history[2].Start)
+	assert.Equal(t, define.HealthCheckHealthy, history[2].Status)
+
+	assert.ErrorIs(t, state.AddHealthCheckLog("missing", &define.HealthCheckHistoryEntry{}, 0), define.ErrNoSuchCtr)
+
+	// The history is removed together with the container.
+	require.NoError(t, state.RemoveContainer(ctr))
+	history, err = state.GetHealthCheckLog(ctr.ID())
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestSqliteContainerStatsHistory(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+
+	history, err := state.ContainerStatsHistory(ctr.ID(), time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 4; i++ {
+		stats := &define.ContainerStats{
+			ContainerID: ctr.ID(),
+			CPU:         float64(i),
+			MemUsage:    uint64(i) * 1024,
+			SystemNano:  uint64(start.Add(time.Duration(i) * time.Minute).UnixNano()),
+		}
+		require.NoError(t, state.AddContainerStats(ctr.ID(), stats, 3))
+	}
+
+	// Only the 3 most recent samples are kept.
+	history, err = state.ContainerStatsHistory(ctr.ID(), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, float64(1), history[0].CPU)
+	assert.Equal(t, uint64(3*1024), history[2].MemUsage)
+
+	history, err = state.ContainerStatsHistory(ctr.ID(), start.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, float64(3), history[0].CPU)
+
+	assert.ErrorIs(t, state.AddContainerStats("missing", &define.ContainerStats{}, 0), define.ErrNoSuchCtr)
+
+	// The history is removed together with the container.
+	require.NoError(t, state.RemoveContainer(ctr))
+	history, err = state.ContainerStatsHistory(ctr.ID(), time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestSqliteVacuum(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.podmanConf.Engine.DBJournalMode = "wal"
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	dbPath := filepath.Join(t.TempDir(), sqliteDBName)
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+	require.NoError(t, state.RemoveContainer(ctr))
+
+	walInfo, err := os.Stat(dbPath + "-wal")
+	require.NoError(t, err)
+	assert.NotZero(t, walInfo.Size())
+
+	require.NoError(t, state.Vacuum())
+
+	walInfo, err = os.Stat(dbPath + "-wal")
+	require.NoError(t, err)
+	assert.Zero(t, walInfo.Size())
+
+	ctrs, err := state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+}
+
+func TestSqliteBackup(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.podmanConf.Engine.DBJournalMode = "wal"
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	require.NoError(t, state.Backup(backupPath))
+	require.NoError(t, state.RemoveContainer(ctr))
+
+	backup, err := newSqliteState(runtime, backupPath)
+	require.NoError(t, err)
+	defer backup.Close()
+
+	ctrs, err := backup.AllContainers(false)
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	testContainersEqual(t, ctrs[0], ctr, true)
+
+	// Backups never overwrite existing files.
+	assert.Error(t, state.Backup(backupPath))
+}
+
+func TestSqliteAddAndRemoveContainers(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	pod, err := getTestPodN("4", manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddPod(pod))
+
+	ctr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr2.config.UserNsCtr = ctr1.ID()
+	ctr3, err := getTestCtrN("3", manager)
+	require.NoError(t, err)
+	ctr3.config.Pod = pod.ID()
+	dupCtr, err := getTestContainer(strings.Repeat("5", 32), ctr1.Name(), manager)
+	require.NoError(t, err)
+
+	// A failing container rolls back the whole batch.
+	assert.Error(t, state.AddContainers([]*Container{ctr1, ctr2, dupCtr}))
+	ctrs, err := state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+
+	require.NoError(t, state.AddContainers([]*Container{ctr1, ctr2, ctr3}))
+	ctrs, err = state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Len(t, ctrs, 3)
+	podCtrs, err := state.PodContainers(pod)
+	require.NoError(t, err)
+	require.Len(t, podCtrs, 1)
+	testContainersEqual(t, podCtrs[0], ctr3, true)
+
+	require.NoError(t, state.RemoveContainers([]*Container{ctr3, ctr2, ctr1}))
+	ctrs, err = state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+	podCtrs, err = state.PodContainers(pod)
+	require.NoError(t, err)
+	assert.Empty(t, podCtrs)
+}
+
+func TestSqlitePodTables(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	pod, err := getTestPodN("4", manager)
+	require.NoError(t, err)
+	pod.config.UsePodIPC = true
+	pod.config.UsePodNet = true
+	require.NoError(t, state.AddPod(pod))
+
+	infra, err := getTestCtrN("5", manager)
+	require.NoError(t, err)
+	infra.config.Pod = pod.ID()
+	ctr, err := getTestCtrN("6", manager)
+	require.NoError(t, err)
+	ctr.config.Pod = pod.ID()
+	require.NoError(t, state.AddContainerToPod(pod, infra))
+	require.NoError(t, state.AddContainerToPod(pod, ctr))
+	pod.state.InfraContainerID = infra.ID()
+	require.NoError(t, state.SavePod(pod))
+
+	ids, err := state.PodContainersByID(pod)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{infra.ID(), ctr.ID()}, ids)
+	exists, err := state.PodHasContainer(pod, ctr.ID())
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	var namespaces []string
+	rows, err := state.conn.Query("SELECT Namespace FROM PodSharedNamespace WHERE PodID=? ORDER BY Namespace;", pod.ID())
+	require.NoError(t, err)
+	for rows.Next() {
+		var ns string
+		require.NoError(t, rows.Scan(&ns))
+		namespaces = append(namespaces, ns)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"ipc", "net"}, namespaces)
+
+	// Pods with containers cannot be removed.
+	err = state.RemovePod(pod)
+	assert.ErrorIs(t, err, define.ErrCtrExists)
+
+	// The infra container cannot be removed while it is recorded as such.
+	err = state.RemoveContainerFromPod(pod, infra)
+	assert.ErrorIs(t, err, define.ErrCtrExists)
+
+	require.NoError(t, state.RemoveContainerFromPod(pod, ctr))
+	exists, err = state.PodHasContainer(pod, ctr.ID())
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	pod.state.InfraContainerID = ""
+	require.NoError(t, state.SavePod(pod))
+	require.NoError(t, state.RemovePodContainers(pod))
+	require.NoError(t, state.RemovePod(pod))
+
+	var count int
+	require.NoError(t, state.conn.QueryRow("SELECT COUNT(*) FROM PodSharedNamespace;").Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestSqliteReadOnlyState(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	dbDir := t.TempDir()
+	dbPath := filepath.Join(dbDir, sqliteDBName)
+	state, err := newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddContainer(ctr))
+	require.NoError(t, state.Close())
+
+	runtime.readOnlyState = true
+
+	// The database has no DBConfig row yet.
+	_, err = newSqliteState(runtime, dbPath)
+	require.ErrorIs(t, err, define.ErrDBBadConfig)
+
+	runtime.readOnlyState = false
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	_, err = state.conn.Exec("INSERT INTO DBConfig VALUES (1, ?, 'linux', '', '', '', '', '', '');", currentSchemaVersion())
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	runtime.readOnlyState = true
+	state, err = newSqliteState(runtime, dbPath)
+	require.NoError(t, err)
+	defer state.Close()
+
+	retrievedCtr, err := state.Container(ctr.ID())
+	require.NoError(t, err)
+	testContainersEqual(t, retrievedCtr, ctr, true)
+
+	err = state.RemoveContainer(ctr)
+	assert.ErrorContains(t, err, "readonly database")
+	_, err = state.Container(ctr.ID())
+	assert.NoError(t, err)
+
+	_, err = newSqliteState(runtime, filepath.Join(dbDir, "missing.sql"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSqliteVolumeBackups(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	vol := &Volume{config: &VolumeConfig{Name: "vol1"}, state: &VolumeState{}, valid: true}
+	require.NoError(t, state.AddVolume(vol))
+
+	policies, err := state.VolumeBackupPolicies()
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+	assert.ErrorIs(t, state.RemoveVolumeBackupPolicy("vol1"), define.ErrNoSuchVolumeBackup)
+
+	// A new policy replaces the previous one.
+	policy := &define.VolumeBackupPolicy{Volume: "vol1", Schedule: define.VolumeBackupDaily, Keep: 7, Target: "/backups"}
+	require.NoError(t, state.SetVolumeBackupPolicy(policy))
+	policy.Keep = 3
+	require.NoError(t, state.SetVolumeBackupPolicy(policy))
+	policies, err = state.VolumeBackupPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, 3, policies[0].Keep)
+
+	created := time.Unix(time.Now().Unix(), 0)
+	first := &define.VolumeBackup{Volume: "vol1", Target: "/backups", Archive: "/backups/vol1-1.tar.zst", Digest: "sha256:1", Created: created}
+	second := &define.VolumeBackup{Volume: "vol1", Target: "/backups", Archive: "/backups/vol1-2.tar.zst", Digest: "sha256:2", Created: created.Add(time.Hour)}
+	other := &define.VolumeBackup{Volume: "vol2", Target: "/backups", Archive: "/backups/vol2-1.tar.zst", Digest: "sha256:3", Created: created}
+	for _, backup := range []*define.VolumeBackup{first, other, second} {
+		require.NoError(t, state.AddVolumeBackup(backup))
+		assert.NotZero(t, backup.ID)
+	}
+
+	backups, err := state.VolumeBackups("vol1")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, first.ID, backups[0].ID)
+	assert.Equal(t, second.Archive, backups[1].Archive)
+	assert.True(t, second.Created.Equal(backups[1].Created))
+
+	backups, err = state.VolumeBackups("")
+	require.NoError(t, err)
+	assert.Len(t, backups, 3)
+
+	require.NoError(t, state.RemoveVolumeBackup(first.ID))
+	assert.ErrorIs(t, state.RemoveVolumeBackup(first.ID), define.ErrNoSuchVolumeBackup)
+
+	// Removing the volume removes its policy but keeps its backups, so
+	// they can be restored into a new volume.
+	require.NoError(t, state.RemoveVolume(vol))
+	policies, err = state.VolumeBackupPolicies()
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+	backups, err = state.VolumeBackups("vol1")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, second.ID, backups[0].ID)
+}
+
+func TestSqliteNetworkReservations(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	reservations, err := state.NetworkReservations("")
+	require.NoError(t, err)
+	assert.Empty(t, reservations)
+	assert.ErrorIs(t, state.RemoveNetworkReservation("net1", "10.89.0.50"), define.ErrNoSuchNetworkReservation)
+
+	web := &define.NetworkReservation{Network: "net1", Address: "10.89.0.50", Container: "web", Created: time.Unix(time.Now().Unix(), 0)}
+	require.NoError(t, state.AddNetworkReservation(web))
+	// An address can only be reserved once per network.
+	assert.ErrorIs(t, state.AddNetworkReservation(&define.NetworkReservation{Network: "net1", Address: "10.89.0.50", Container: "db"}), define.ErrNetworkReserved)
+	require.NoError(t, state.AddNetworkReservation(&define.NetworkReservation{Network: "net1", Address: "92:d0:c6:0a:29:33", Container: "web"}))
+	require.NoError(t, state.AddNetworkReservation(&define.NetworkReservation{Network: "net2", Address: "10.89.0.50", Container: "db"}))
+
+	reservations, err = state.NetworkReservations("net1")
+	require.NoError(t, err)
+	require.Len(t, reservations, 2)
+	assert.Equal(t, "10.89.0.50", reservations[0].Address)
+	assert.Equal(t, "web", reservations[0].Container)
+	assert.True(t, web.Created.Equal(reservations[0].Created))
+
+	reservations, err = state.NetworkReservations("")
+	require.NoError(t, err)
+	assert.Len(t, reservations, 3)
+
+	require.NoError(t, state.RemoveNetworkReservation("net2", "10.89.0.50"))
+	assert.ErrorIs(t, state.RemoveNetworkReservation("net2", "10.89.0.50"), define.ErrNoSuchNetworkReservation)
+
+	// An empty address removes all reservations of the network.
+	require.NoError(t, state.RemoveNetworkReservation("net1", ""))
+	reservations, err = state.NetworkReservations("")
+	require.NoError(t, err)
+	assert.Empty(t, reservations)
+}
+
+func TestSqliteCheckpoints(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	records, err := state.Checkpoints("")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.ErrorIs(t, state.RemoveCheckpoint(1), define.ErrNoSuchCheckpoint)
+
+	created := time.Unix(time.Now().Unix(), 0)
+	pre := &define.CheckpointRecord{ContainerID: "ctr1", ContainerName: "web", Path: "/checkpoints/pre.tar", Created: created, PreCheckpoint: true}
+	require.NoError(t, state.AddCheckpoint(pre))
+	assert.NotZero(t, pre.ID)
+	full := &define.CheckpointRecord{ContainerID: "ctr1", ContainerName: "web", Path: "/checkpoints/web.tar.zst", Created: created.Add(time.Minute), Compression: "zstd", Size: 4096, ParentID: pre.ID}
+	require.NoError(t, state.AddCheckpoint(full))
+	require.NoError(t, state.AddCheckpoint(&define.CheckpointRecord{ContainerID: "ctr2", ContainerName: "db", Path: "/checkpoints/db.tar"}))
+
+	records, err = state.Checkpoints("ctr1")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, pre.ID, records[0].ID)
+	assert.True(t, records[0].PreCheckpoint)
+	assert.Equal(t, pre.ID, records[1].ParentID)
+	assert.Equal(t, int64(4096), records[1].Size)
+	assert.True(t, full.Created.Equal(records[1].Created))
+
+	records, err = state.Checkpoints("")
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	require.NoError(t, state.RemoveCheckpoint(pre.ID))
+	assert.ErrorIs(t, state.RemoveCheckpoint(pre.ID), define.ErrNoSuchCheckpoint)
+	records, err = state.Checkpoints("ctr1")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, full.ID, records[0].ID)
+}
+
+func TestSqliteVolumeSnapshots(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	vol := &Volume{config: &VolumeConfig{Name: "vol1"}, state: &VolumeState{}, valid: true}
+	require.NoError(t, state.AddVolume(vol))
+
+	snapshots, err := state.VolumeSnapshots("")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+	assert.ErrorIs(t, state.RemoveVolumeSnapshot(1), define.ErrNoSuchVolumeSnapshot)
+
+	created := time.Unix(time.Now().Unix(), 0)
+	first := &define.VolumeSnapshot{Volume: "vol1", Name: "first", Path: "/volumes/vol1/snapshots/first", Method: define.VolumeSnapshotReflink, Size: 4096, Created: created}
+	second := &define.VolumeSnapshot{Volume: "vol1", Name: "second", Path: "/volumes/vol1/snapshots/second", Method: define.VolumeSnapshotCopy, Created: created.Add(time.Hour)}
+	other := &define.VolumeSnapshot{Volume: "vol2", Name: "first", Path: "/volumes/vol2/snapshots/first", Method: define.VolumeSnapshotCopy, Created: created}
+	for _, snapshot := range []*define.VolumeSnapshot{first, other, second} {
+		require.NoError(t, state.AddVolumeSnapshot(snapshot))
+		assert.NotZero(t, snapshot.ID)
+	}
+
+	snapshots, err = state.VolumeSnapshots("vol1")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, first.ID, snapshots[0].ID)
+	assert.Equal(t, define.VolumeSnapshotReflink, snapshots[0].Method)
+	assert.Equal(t, int64(4096), snapshots[0].Size)
+	assert.Equal(t, second.Name, snapshots[1].Name)
+	assert.True(t, second.Created.Equal(snapshots[1].Created))
+
+	snapshots, err = state.VolumeSnapshots("")
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 3)
+
+	require.NoError(t, state.RemoveVolumeSnapshot(first.ID))
+	assert.ErrorIs(t, state.RemoveVolumeSnapshot(first.ID), define.ErrNoSuchVolumeSnapshot)
+
+	// The snapshots are stored in the volume directory, so removing the
+	// volume removes them.
+	require.NoError(t, state.RemoveVolume(vol))
+	snapshots, err = state.VolumeSnapshots("")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, other.ID, snapshots[0].ID)
+}
+
+func TestSqliteNamespace(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	ctr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr1.config.Namespace = "ns1"
+	ctr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr2.config.Namespace = "ns2"
+	pod, err := getTestPodN("3", manager)
+	require.NoError(t, err)
+	pod.config.Namespace = "ns2"
+	require.NoError(t, state.AddContainer(ctr1))
+	require.NoError(t, state.AddContainer(ctr2))
+	require.NoError(t, state.AddPod(pod))
+
+	require.NoError(t, state.SetNamespace("ns1"))
+
+	ctrs, err := state.AllContainers(false)
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	testContainersEqual(t, ctrs[0], ctr1, true)
+	ctrs, err = state.AllContainersFiltered(false, &StateContainerFilters{NamePrefixes: []string{ctr2.Name()}})
+	require.NoError(t, err)
+	assert.Empty(t, ctrs)
+
+	_, err = state.Container(ctr2.ID())
+	assert.ErrorIs(t, err, define.ErrNSMismatch)
+	_, err = state.LookupContainer(ctr2.Name())
+	assert.ErrorIs(t, err, define.ErrNoSuchCtr)
+	exists, err := state.HasContainer(ctr2.ID())
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.ErrorIs(t, state.RemoveContainer(ctr2), define.ErrNSMismatch)
+
+	_, err = state.Pod(pod.ID())
+	assert.ErrorIs(t, err, define.ErrNSMismatch)
+	_, err = state.LookupPod(pod.Name())
+	assert.ErrorIs(t, err, define.ErrNoSuchPod)
+	pods, err := state.AllPods()
+	require.NoError(t, err)
+	assert.Empty(t, pods)
+
+	// Containers cannot depend on containers of other namespaces.
+	ctr3, err := getTestCtrN("4", manager)
+	require.NoError(t, err)
+	ctr3.config.Namespace = "ns1"
+	ctr3.config.IPCNsCtr = ctr2.ID()
+	assert.ErrorIs(t, state.AddContainer(ctr3), define.ErrNSMismatch)
+
+	require.NoError(t, state.SetNamespace(""))
+	ctrs, err = state.AllContainers(false)
+	require.NoError(t, err)
+	assert.Len(t, ctrs, 2)
+	pods, err = state.AllPods()
+	require.NoError(t, err)
+	assert.Len(t, pods, 1)
+}
+
+func TestSqliteContainerGroups(t *testing.T) {
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+
+	state, err := newSqliteState(runtime, filepath.Join(t.TempDir(), sqliteDBName))
+	require.NoError(t, err)
+	defer state.Close()
+
+	groups, err := state.ContainerGroups()
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+	_, err = state.ContainerGroup("web")
+	assert.ErrorIs(t, err, define.ErrNoSuchContainerGroup)
+	assert.ErrorIs(t, state.RemoveContainerGroup("web"), define.ErrNoSuchContainerGroup)
+
+	web := &define.ContainerGroup{Name: "web", Filters: map[string][]string{"label": {"tier=web"}}, Created: time.Unix(time.Now().Unix(), 0)}
+	require.NoError(t, state.AddContainerGroup(web))
+	assert.ErrorIs(t, state.AddContainerGroup(&define.ContainerGroup{Name: "web", Filters: map[string][]string{"status": {"exited"}}}), define.ErrContainerGroupExists)
+	require.NoError(t, state.AddContainerGroup(&define.ContainerGroup{Name: "db", Filters: map[string][]string{"label": {"tier=db"}}}))
+
+	group, err := state.ContainerGroup("web")
+	require.NoError(t, err)
+	assert.Equal(t, web.Filters, group.Filters)
+	assert.True(t, web.Created.Equal(group.Created))
+
+	groups, err = state.ContainerGroups()
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "db", groups[0].Name)
+	assert.Equal(t, "web", groups[1].Name)
+
+	require.NoError(t, state.RemoveContainerGroup("web"))
+	_, err = state.ContainerGroup("web")
+	assert.ErrorIs(t, err, define.ErrNoSuchContainerGroup)
+	groups, err = state.ContainerGroups()
+	require.NoError(t, err)
+	assert.Len(t, groups, 1)
+}
@@ -2,7 +2,128 @@
 
 package libpod
 
-import "github.com/containers/common/libnetwork/types"
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// StateContainerFilters restricts the containers retrieved by
+// State.AllContainersFiltered. A container must match all non-empty fields.
+type StateContainerFilters struct {
+	// PodIDs matches containers in any of the given pods, by full ID.
+	PodIDs []string
+	// NamePrefixes matches containers whose name starts with any of the
+	// given prefixes.
+	NamePrefixes []string
+	// Labels matches containers with all of the given labels, each given
+	// as "key" or "key=value".
+	Labels []string
+	// RestartPolicies matches containers with any of the given restart
+	// policies. define.RestartPolicyNone is the empty string.
+	RestartPolicies []string
+	// CreatedBefore matches containers created before the given time.
+	CreatedBefore time.Time
+	// CreatedAfter matches containers created after the given time.
+	CreatedAfter time.Time
+	// Limit, if greater than zero, restricts the result to the given
+	// number of containers, the most recently created ones.
+	Limit int
+
+	// namespace matches containers in the given libpod namespace. It is
+	// set by states supporting namespaces.
+	namespace string
+}
+
+// matchesPod returns whether a container in the given pod, if any, matches
+// the pod filter. Nil filters match all containers.
+func (f *StateContainerFilters) matchesPod(podID string) bool {
+	return f == nil || len(f.PodIDs) == 0 || slices.Contains(f.PodIDs, podID)
+}
+
+// matchesName returns whether a container with the given name matches the name
+// filter. Nil filters match all containers.
+func (f *StateContainerFilters) matchesName(name string) bool {
+	if f == nil || len(f.NamePrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range f.NamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLabels returns whether a container with the given labels matches the
+// label filter. Nil filters match all containers.
+func (f *StateContainerFilters) matchesLabels(labels map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	for _, label := range f.Labels {
+		key, value, hasValue := strings.Cut(label, "=")
+		ctrValue, ok := labels[key]
+		if !ok || (hasValue && ctrValue != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesConfig returns whether a container with the given config matches the
+// restart policy and creation time filters. Nil filters match all containers.
+func (f *StateContainerFilters) matchesConfig(config *ContainerConfig) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.RestartPolicies) > 0 && !slices.Contains(f.RestartPolicies, config.RestartPolicy) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !config.CreatedTime.Before(f.CreatedBefore) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && !config.CreatedTime.After(f.CreatedAfter) {
+		return false
+	}
+	return true
+}
+
+// applyLimit returns the most recently created containers of ctrs if the
+// filters limit the number of containers. Nil filters return all containers.
+func (f *StateContainerFilters) applyLimit(ctrs []*Container) []*Container {
+	if f == nil || f.Limit <= 0 || len(ctrs) <= f.Limit {
+		return ctrs
+	}
+	sort.SliceStable(ctrs, func(i, j int) bool {
+		return ctrs[i].config.CreatedTime.After(ctrs[j].config.CreatedTime)
+	})
+	return ctrs[:f.Limit]
+}
+
+// StateDependency is an edge of the container dependency graph: the container
+// ID depends on the container DependencyID.
+type StateDependency struct {
+	ID           string
+	DependencyID string
+}
+
+// setConfigResources sets the resource limits in the OCI spec of the given
+// container config.
+func setConfigResources(config *ContainerConfig, resources *spec.LinuxResources) {
+	if config.Spec == nil {
+		config.Spec = new(spec.Spec)
+	}
+	if config.Spec.Linux == nil {
+		config.Spec.Linux = new(spec.Linux)
+	}
+	config.Spec.Linux.Resources = resources
+}
 
 // State is a storage backend for libpod's current state.
 // A State is only initialized once per instance of libpod.
@@ -25,6 +146,10 @@ type State interface { //nolint:interfacebloat
 	// Refresh clears container and pod states after a reboot
 	Refresh() error
 
+	// ExportTo copies all containers, pods, volumes, exec sessions and
+	// exit codes into the given state, which must be empty.
+	ExportTo(dest State) error
+
 	// GetDBConfig retrieves several paths configured within the database
 	// when it was created - namely, Libpod root and tmp dirs, c/storage
 	// root and tmp dirs, and c/storage graph driver.
@@ -41,6 +166,13 @@ type State interface { //nolint:interfacebloat
 	// the program.
 	ValidateDBConfig(runtime *Runtime) error
 
+	// SetNamespace sets the namespace of the state. Once set, only
+	// containers and pods in the namespace are visible, and only those can
+	// be added or removed. The empty string, the default, makes all
+	// containers and pods visible. Returns define.ErrNotImplemented if the
+	// backend does not support namespaces.
+	SetNamespace(ns string) error
+
 	// Resolve an ID to a Container Name.
 	GetContainerName(id string) (string, error)
 	// Resolve an ID to a Pod Name.
@@ -76,6 +208,21 @@ type State interface { //nolint:interfacebloat
 	// All dependencies must be removed first.
 	// All exec sessions referencing the container must be removed first.
 	RemoveContainer(ctr *Container) error
+	// AddContainers adds multiple containers to state in a single
+	// transaction: either all of them are added, or none are.
+	// Containers that are part of a pod are added to that pod, which must
+	// already exist in the state.
+	// The same restrictions as AddContainer and AddContainerToPod apply to
+	// every container. Containers may depend on containers that come
+	// before them in the list.
+	AddContainers(ctrs []*Container) error
+	// RemoveContainers removes multiple containers from state in a single
+	// transaction: either all of them are removed, or none are.
+	// Containers that are part of a pod are removed from that pod as well.
+	// The same restrictions as RemoveContainer apply to every container,
+	// except that a container may be a dependency of containers that come
+	// before it in the list.
+	RemoveContainers(ctrs []*Container) error
 	// UpdateContainer updates a container's state from the backing store.
 	// The container must be part of the set namespace.
 	UpdateContainer(ctr *Container) error
@@ -90,14 +237,33 @@ type State interface { //nolint:interfacebloat
 	// A container cannot be removed if other containers depend on it.
 	// The container being checked must be part of the set namespace.
 	ContainerInUse(ctr *Container) ([]string, error)
+	// ContainerDependents returns the dependency graph of all containers
+	// that depend on the given container, directly or through other
+	// containers, as a list of edges. Each edge is returned once, even if
+	// the graph has cycles.
+	// The container being checked must be part of the set namespace.
+	ContainerDependents(ctr *Container) ([]StateDependency, error)
+	// ContainerDependencies returns the dependency graph of all containers
+	// the given container depends on, directly or through other
+	// containers, as a list of edges. Each edge is returned once, even if
+	// the graph has cycles.
+	// The container being checked must be part of the set namespace.
+	ContainerDependencies(ctr *Container) ([]StateDependency, error)
 	// Retrieves all containers presently in state.
 	// If `loadState` is set, the containers' state will be loaded as well.
 	// If a namespace is set, only containers within the namespace will be
 	// returned.
 	AllContainers(loadState bool) ([]*Container, error)
+	// Retrieves the containers presently in state matching the given
+	// filters, without loading the others from the backing store.
+	// If `loadState` is set, the containers' state will be loaded as well.
+	// With nil filters, it is equivalent to AllContainers.
+	AllContainersFiltered(loadState bool, filters *StateContainerFilters) ([]*Container, error)
 
 	// Get networks the container is currently connected to.
 	GetNetworks(ctr *Container) (map[string]types.PerNetworkOptions, error)
+	// Get the containers connected to the given network.
+	NetworkContainers(network string) ([]*Container, error)
 	// Add the container to the given network with the given options
 	NetworkConnect(ctr *Container, network string, opts types.PerNetworkOptions) error
 	// Modify the container network with the given options.
@@ -109,12 +275,178 @@ type State interface { //nolint:interfacebloat
 	// Return a container config from the database by full ID
 	GetContainerConfig(id string) (*ContainerConfig, error)
 
+	// Set the metadata entry with the given key of the container with the
+	// given full ID, replacing any earlier value of the key. An empty
+	// value removes the entry. Metadata is removed together with the
+	// container. Returns define.ErrNotImplemented if the backend does not
+	// support container metadata.
+	SetContainerMetadata(id, key, value string) error
+	// Return the metadata entries of the container with the given full
+	// ID.
+	GetContainerMetadata(id string) (map[string]string, error)
+
+	// Add a note to the container with the given full ID and set the ID
+	// of the note. Notes are removed together with the container. Returns
+	// define.ErrNotImplemented if the backend does not support container
+	// notes.
+	AddContainerNote(id string, note *define.ContainerNote) error
+	// Return the notes of the container with the given full ID, oldest
+	// first.
+	GetContainerNotes(id string) ([]*define.ContainerNote, error)
+	// Remove the note with the given note ID from the container with the
+	// given full ID. Returns ErrNoSuchContainerNote if the container has
+	// no such note.
+	RemoveContainerNote(id string, noteID int64) error
+
+	// Record a healthcheck run of the container with the given full ID,
+	// keeping only the maxEntries most recent runs of the container (0
+	// keeps all of them). Runs are removed together with the container.
+	// Returns define.ErrNotImplemented if the backend does not support
+	// healthcheck history.
+	AddHealthCheckLog(id string, entry *define.HealthCheckHistoryEntry, maxEntries uint) error
+	// Return the healthcheck runs recorded for the container with the given
+	// full ID, oldest first. Returns define.ErrNotImplemented if the
+	// backend does not support healthcheck history.
+	GetHealthCheckLog(id string) ([]define.HealthCheckHistoryEntry, error)
+	// Record a resource usage sample of the container with the given full
+	// ID, keeping only the maxSamples most recent samples of the container
+	// (0 keeps all of them). Samples are removed together with the
+	// container. Returns define.ErrNotImplemented if the backend does not
+	// support stats history.
+	AddContainerStats(id string, stats *define.ContainerStats, maxSamples uint) error
+	// Return the resource usage samples of the container with the given
+	// full ID taken after since, oldest first. Returns
+	// define.ErrNotImplemented if the backend does not support stats
+	// history.
+	ContainerStatsHistory(id string, since time.Time) ([]*define.ContainerStats, error)
+
 	// Add the exit code for the specified container to the database.
 	AddContainerExitCode(id string, exitCode int32) error
 	// Return the exit code for the specified container.
 	GetContainerExitCode(id string) (int32, error)
-	// Remove exit codes older than 5 minutes.
+	// Remove exit codes older than the exit code retention of the runtime,
+	// unless the container still exists. Older entries of the exit history
+	// are removed even if the container still exists.
 	PruneContainerExitCodes() error
+	// Return the exits recorded for the container with the given full ID,
+	// oldest first. The exits are kept after the container is removed,
+	// until they are older than the exit code retention.
+	ContainerExitHistory(id string) ([]define.ContainerExit, error)
+
+	// Record a pull of an image. Nothing is recorded if the last pull from
+	// the same source resulted in the same image and digest.
+	AddImageProvenance(record *define.ImageProvenance) error
+	// Return the pulls of the image with the given full ID, together with
+	// all other pulls from the sources the image was pulled from, oldest
+	// first. Records are kept after the image is removed.
+	ImageProvenance(imageID string) ([]*define.ImageProvenance, error)
+	// Pin the digest of an image source to the given pull, replacing any
+	// earlier pin of the source.
+	SetImageDigestPin(pin *define.ImageProvenance) error
+	// Return the pull the given image source is pinned to. Returns
+	// ErrNoSuchImageDigestPin if the source has not been pinned.
+	ImageDigestPin(source string) (*define.ImageProvenance, error)
+
+	// Set the backup policy of a volume, replacing any earlier policy of
+	// the volume. The policy is removed together with the volume.
+	// Returns define.ErrNotImplemented if the backend does not support
+	// volume backups.
+	SetVolumeBackupPolicy(policy *define.VolumeBackupPolicy) error
+	// Remove the backup policy of the volume with the given name. Returns
+	// ErrNoSuchVolumeBackup if the volume has no policy.
+	RemoveVolumeBackupPolicy(volume string) error
+	// Return the backup policies of all volumes.
+	VolumeBackupPolicies() ([]*define.VolumeBackupPolicy, error)
+	// Record a backup of a volume and set its ID. Backups are kept after
+	// the volume is removed, so it can be restored.
+	AddVolumeBackup(backup *define.VolumeBackup) error
+	// Return the backups of the volume with the given name, or of all
+	// volumes if the name is empty, oldest first.
+	VolumeBackups(volume string) ([]*define.VolumeBackup, error)
+	// Remove the backup with the given ID. Returns ErrNoSuchVolumeBackup
+	// if it does not exist.
+	RemoveVolumeBackup(id int64) error
+
+	// Record a snapshot of a volume and set its ID. Returns
+	// define.ErrNotImplemented if the backend does not support volume
+	// snapshots.
+	AddVolumeSnapshot(snapshot *define.VolumeSnapshot) error
+	// Return the snapshots of the volume with the given name, or of all
+	// volumes if the name is empty, oldest first.
+	VolumeSnapshots(volume string) ([]*define.VolumeSnapshot, error)
+	// Remove the snapshot with the given ID. Returns
+	// ErrNoSuchVolumeSnapshot if it does not exist.
+	RemoveVolumeSnapshot(id int64) error
+
+	// Reserve an address of a network for a container. Returns
+	// ErrNetworkReserved if the address is already reserved.
+	// Returns define.ErrNotImplemented if the backend does not support
+	// network reservations.
+	AddNetworkReservation(reservation *define.NetworkReservation) error
+	// Remove the reservation of the given address of a network, or all
+	// reservations of the network if the address is empty. Returns
+	// ErrNoSuchNetworkReservation if the address is not reserved.
+	RemoveNetworkReservation(network, address string) error
+	// Return the reservations of the given network, or of all networks if
+	// the name is empty, ordered by network and address.
+	NetworkReservations(network string) ([]*define.NetworkReservation, error)
+
+	// Add a container group. Returns ErrContainerGroupExists if a group
+	// with the same name exists.
+	// Returns define.ErrNotImplemented if the backend does not support
+	// container groups.
+	AddContainerGroup(group *define.ContainerGroup) error
+	// Remove the container group with the given name. Returns
+	// ErrNoSuchContainerGroup if it does not exist.
+	RemoveContainerGroup(name string) error
+	// Return the container group with the given name. Returns
+	// ErrNoSuchContainerGroup if it does not exist.
+	ContainerGroup(name string) (*define.ContainerGroup, error)
+	// Return all container groups, ordered by name.
+	ContainerGroups() ([]*define.ContainerGroup, error)
+
+	// Record a checkpoint exported to a file and set its ID. Records are
+	// kept after the container is removed, as the checkpoint can still be
+	// restored. Returns define.ErrNotImplemented if the backend does not
+	// support checkpoint records.
+	AddCheckpoint(record *define.CheckpointRecord) error
+	// Return the checkpoints of the container with the given ID, or of all
+	// containers if the ID is empty, oldest first.
+	Checkpoints(ctrID string) ([]*define.CheckpointRecord, error)
+	// Remove the checkpoint record with the given ID. Returns
+	// ErrNoSuchCheckpoint if it does not exist.
+	RemoveCheckpoint(id int64) error
+
+	// Verify checks the consistency of the database and reports orphaned
+	// entries, i.e. exit codes, exec sessions and dependencies referencing
+	// containers which do not exist.
+	Verify() (*define.DBCheckReport, error)
+	// Vacuum rebuilds the database to reclaim the space of removed entries.
+	// Returns define.ErrNotImplemented if the backend does not support it.
+	Vacuum() error
+	// CheckpointWAL copies the content of the write-ahead log, if the
+	// database uses one, into the database and truncates the log.
+	CheckpointWAL() error
+	// Backup writes a consistent copy of the database to the given path,
+	// which must not exist, while the database remains in use.
+	Backup(path string) error
+	// RecordMaintenance records that the given maintenance operation of
+	// the database finished with the given result, replacing the previous
+	// record of the operation.
+	// Returns define.ErrNotImplemented if the backend does not support it.
+	RecordMaintenance(operation, result string) error
+	// DatabaseInfo describes the database and its last recorded
+	// maintenance operations.
+	DatabaseInfo() (*define.DatabaseBackendInfo, error)
+	// StateChanges returns the recorded changes of containers, pods and
+	// volumes with an ID greater than afterID, oldest first. Only the
+	// most recent changes are kept.
+	// Returns define.ErrNotImplemented if the backend does not support it.
+	StateChanges(afterID int64) ([]*define.StateChange, error)
+	// LatestStateChange returns the ID of the most recent recorded change,
+	// or 0 if none was recorded.
+	// Returns define.ErrNotImplemented if the backend does not support it.
+	LatestStateChange() (int64, error)
 
 	// Add creates a reference to an exec session in the database.
 	// The container the exec session is attached to will be recorded.
@@ -128,6 +460,11 @@ type State interface { //nolint:interfacebloat
 	AddExecSession(ctr *Container, session *ExecSession) error
 	// Get retrieves the container a given exec session is attached to.
 	GetExecSession(id string) (string, error)
+	// ExecSession retrieves the exec session with the given ID as it was
+	// last saved with the state of its container.
+	ExecSession(id string) (*ExecSession, error)
+	// AllExecSessions retrieves all exec sessions added by AddExecSession.
+	AllExecSessions() ([]*ExecSession, error)
 	// Remove a reference to an exec session from the database.
 	// This will not modify container state to remove the exec session there
 	// and instead only removes the session ID -> container ID reference
@@ -180,6 +517,19 @@ type State interface { //nolint:interfacebloat
 	// If newName is not "" the container will be renamed to the new name.
 	// The oldName parameter is only required if newName is given.
 	SafeRewriteContainerConfig(ctr *Container, oldName, newName string, newCfg *ContainerConfig) error
+	// Rename the given container to the given name. The current
+	// configuration of the container is read, renamed and written back in
+	// a single transaction, so a concurrent rename or configuration
+	// rewrite is not lost. The container may be running.
+	// The config of the container is updated with the new name.
+	RenameContainer(ctr *Container, newName string) error
+	// Update the resource limits of the given container. The current
+	// configuration of the container is read, updated and written back
+	// in a single transaction, so the limits survive restarts of the
+	// container and of the system and concurrent changes to the
+	// configuration are not lost. The container may be running.
+	// The config of the container is updated with the new limits.
+	UpdateContainerResources(ctr *Container, resources *spec.LinuxResources) error
 	// PLEASE READ THE DESCRIPTION FOR RewriteContainerConfig BEFORE USING.
 	// This function is identical to RewriteContainerConfig, save for the
 	// fact that it is used with pods instead.
@@ -0,0 +1,163 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"time"
+
+	"github.com/containers/podman/v5/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentedState wraps the state of the runtime, recording the latencies of
+// the lookups and modifications of containers, pods and volumes if metrics are
+// enabled, and tracing them if tracing is enabled. Other operations are passed
+// through unchanged.
+type instrumentedState struct {
+	State
+	runtime *Runtime
+}
+
+// observe starts recording a call of the state operation, made for ctr if it
+// is not nil, and returns the function ending it. It is meant to be deferred.
+// The call is traced as a child of the traced operation running on ctr or of
+// the span of the process, and not traced if there is neither.
+func (s *instrumentedState) observe(operation string, ctr *Container) func() {
+	start := time.Now()
+	var span trace.Span
+	if tracing.Enabled() {
+		if parent := traceParent(ctr); trace.SpanContextFromContext(parent).IsValid() {
+			_, span = tracer.Start(parent, "state "+operation, trace.WithTimestamp(start),
+				trace.WithAttributes(attribute.String("db.system", s.runtime.config.Engine.DBBackend), attribute.String("db.operation", operation)))
+		}
+	}
+	return func() {
+		if s.runtime.metrics != nil {
+			s.runtime.metrics.observe(operation, start)
+		}
+		if span != nil {
+			span.End()
+		}
+	}
+}
+
+func (s *instrumentedState) Container(id string) (*Container, error) {
+	defer s.observe("Container", nil)()
+	return s.State.Container(id)
+}
+
+func (s *instrumentedState) LookupContainerID(idOrName string) (string, error) {
+	defer s.observe("LookupContainerID", nil)()
+	return s.State.LookupContainerID(idOrName)
+}
+
+func (s *instrumentedState) LookupContainer(idOrName string) (*Container, error) {
+	defer s.observe("LookupContainer", nil)()
+	return s.State.LookupContainer(idOrName)
+}
+
+func (s *instrumentedState) AddContainer(ctr *Container) error {
+	defer s.observe("AddContainer", ctr)()
+	return s.State.AddContainer(ctr)
+}
+
+func (s *instrumentedState) RemoveContainer(ctr *Container) error {
+	defer s.observe("RemoveContainer", ctr)()
+	return s.State.RemoveContainer(ctr)
+}
+
+func (s *instrumentedState) UpdateContainer(ctr *Container) error {
+	defer s.observe("UpdateContainer", ctr)()
+	return s.State.UpdateContainer(ctr)
+}
+
+func (s *instrumentedState) SaveContainer(ctr *Container) error {
+	defer s.observe("SaveContainer", ctr)()
+	return s.State.SaveContainer(ctr)
+}
+
+func (s *instrumentedState) AllContainers(loadState bool) ([]*Container, error) {
+	defer s.observe("AllContainers", nil)()
+	return s.State.AllContainers(loadState)
+}
+
+func (s *instrumentedState) AllContainersFiltered(loadState bool, filters *StateContainerFilters) ([]*Container, error) {
+	defer s.observe("AllContainersFiltered", nil)()
+	return s.State.AllContainersFiltered(loadState, filters)
+}
+
+func (s *instrumentedState) Pod(id string) (*Pod, error) {
+	defer s.observe("Pod", nil)()
+	return s.State.Pod(id)
+}
+
+func (s *instrumentedState) LookupPod(idOrName string) (*Pod, error) {
+	defer s.observe("LookupPod", nil)()
+	return s.State.LookupPod(idOrName)
+}
+
+func (s *instrumentedState) AddPod(pod *Pod) error {
+	defer s.observe("AddPod", nil)()
+	return s.State.AddPod(pod)
+}
+
+func (s *instrumentedState) RemovePod(pod *Pod) error {
+	defer s.observe("RemovePod", nil)()
+	return s.State.RemovePod(pod)
+}
+
+func (s *instrumentedState) UpdatePod(pod *Pod) error {
+	defer s.observe("UpdatePod", nil)()
+	return s.State.UpdatePod(pod)
+}
+
+func (s *instrumentedState) SavePod(pod *Pod) error {
+	defer s.observe("SavePod", nil)()
+	return s.State.SavePod(pod)
+}
+
+func (s *instrumentedState) PodContainers(pod *Pod) ([]*Container, error) {
+	defer s.observe("PodContainers", nil)()
+	return s.State.PodContainers(pod)
+}
+
+func (s *instrumentedState) AllPods() ([]*Pod, error) {
+	defer s.observe("AllPods", nil)()
+	return s.State.AllPods()
+}
+
+func (s *instrumentedState) Volume(volName string) (*Volume, error) {
+	defer s.observe("Volume", nil)()
+	return s.State.Volume(volName)
+}
+
+func (s *instrumentedState) LookupVolume(name string) (*Volume, error) {
+	defer s.observe("LookupVolume", nil)()
+	return s.State.LookupVolume(name)
+}
+
+func (s *instrumentedState) AddVolume(volume *Volume) error {
+	defer s.observe("AddVolume", nil)()
+	return s.State.AddVolume(volume)
+}
+
+func (s *instrumentedState) RemoveVolume(volume *Volume) error {
+	defer s.observe("RemoveVolume", nil)()
+	return s.State.RemoveVolume(volume)
+}
+
+func (s *instrumentedState) UpdateVolume(volume *Volume) error {
+	defer s.observe("UpdateVolume", nil)()
+	return s.State.UpdateVolume(volume)
+}
+
+func (s *instrumentedState) SaveVolume(volume *Volume) error {
+	defer s.observe("SaveVolume", nil)()
+	return s.State.SaveVolume(volume)
+}
+
+func (s *instrumentedState) AllVolumes() ([]*Volume, error) {
+	defer s.observe("AllVolumes", nil)()
+	return s.State.AllVolumes()
+}
@@ -14,8 +14,10 @@ import (
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/lock"
 	"github.com/containers/storage"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
 )
 
 // Returns state, tmp directory containing all state files, lock manager, and
@@ -31,6 +33,8 @@ const (
 var (
 	testedStates = map[string]emptyStateFunc{
 		"boltdb": getEmptyBoltState,
+		"sqlite": getEmptySqliteState,
+		"memory": getEmptyMemoryState,
 	}
 )
 
@@ -70,6 +74,57 @@ func getEmptyBoltState() (_ State, _ string, _ lock.Manager, retErr error) {
 	return state, tmpDir, lockManager, nil
 }
 
+// Get an empty SQLite state for use in tests
+func getEmptySqliteState() (_ State, _ string, _ lock.Manager, retErr error) {
+	tmpDir, err := os.MkdirTemp("", tmpDirPrefix)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			os.RemoveAll(tmpDir)
+		}
+	}()
+
+	lockManager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = lockManager
+
+	state, err := newSqliteState(runtime, filepath.Join(tmpDir, sqliteDBName))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return state, tmpDir, lockManager, nil
+}
+
+// Get an empty in-memory state for use in tests. It has no files, the
+// returned directory is empty.
+func getEmptyMemoryState() (State, string, lock.Manager, error) {
+	lockManager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = lockManager
+
+	state, err := NewMemoryState(runtime)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return state, "", lockManager, nil
+}
+
 func runForAllStates(t *testing.T, testFunc func(*testing.T, State, lock.Manager)) {
 	for stateName, stateFunc := range testedStates {
 		state, path, manager, err := stateFunc()
@@ -88,6 +143,20 @@ func runForAllStates(t *testing.T, testFunc func(*testing.T, State, lock.Manager
 	}
 }
 
+// runForBoltState runs testFunc against the BoltDB state only, for checks of
+// behavior the SQL states do not share, such as rejecting objects that are
+// not in the state. The SQL states have their own tests of such behavior.
+func runForBoltState(t *testing.T, testFunc func(*testing.T, State, lock.Manager)) {
+	state, path, manager, err := getEmptyBoltState()
+	if err != nil {
+		t.Fatalf("Error initializing state boltdb: %v", err)
+	}
+	defer os.RemoveAll(path)
+	defer state.Close()
+
+	testFunc(t, state, manager)
+}
+
 func TestAddAndGetContainer(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
@@ -201,7 +270,7 @@ func TestAddCtrPodDupIDFails(t *testing.T) {
 }
 
 func TestAddCtrPodDupNameFails(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 		testCtr, err := getTestContainer(strings.Repeat("2", 32), testPod.Name(), manager)
@@ -540,7 +609,7 @@ func TestRemoveContainer(t *testing.T) {
 }
 
 func TestRemoveNonexistentContainerFails(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
 		assert.NoError(t, err)
 
@@ -550,6 +619,226 @@ func TestRemoveNonexistentContainerFails(t *testing.T) {
 	})
 }
 
+func TestAddAndRemoveContainers(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testPod, err := getTestPodN("4", manager)
+		assert.NoError(t, err)
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestCtr2(manager)
+		assert.NoError(t, err)
+		testCtr3, err := getTestCtrN("3", manager)
+		assert.NoError(t, err)
+
+		testCtr2.config.UserNsCtr = testCtr1.ID()
+		testCtr3.config.Pod = testPod.ID()
+
+		err = state.AddPod(testPod)
+		assert.NoError(t, err)
+
+		err = state.AddContainers([]*Container{testCtr1, testCtr2, testCtr3})
+		assert.NoError(t, err)
+
+		ctrs, err := state.AllContainers(false)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(ctrs))
+
+		podCtrs, err := state.PodContainers(testPod)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(podCtrs))
+
+		// Dependencies must be removed before the containers they depend on
+		err = state.RemoveContainers([]*Container{testCtr1, testCtr2, testCtr3})
+		assert.Error(t, err)
+
+		ctrs, err = state.AllContainers(false)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(ctrs))
+
+		err = state.RemoveContainers([]*Container{testCtr3, testCtr2, testCtr1})
+		assert.NoError(t, err)
+
+		ctrs, err = state.AllContainers(false)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(ctrs))
+
+		podCtrs, err = state.PodContainers(testPod)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(podCtrs))
+	})
+}
+
+func TestAddContainersFailureAddsNone(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestContainer(strings.Repeat("2", 32), testCtr1.Name(), manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainers([]*Container{testCtr1, testCtr2})
+		assert.Error(t, err)
+
+		ctrs, err := state.AllContainers(false)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(ctrs))
+	})
+}
+
+// testAllContainersFiltered checks that AllContainersFiltered only returns the
+// containers matching the given filters.
+func testAllContainersFiltered(t *testing.T, state State, manager lock.Manager) {
+	testPod, err := getTestPodN("4", manager)
+	require.NoError(t, err)
+	require.NoError(t, state.AddPod(testPod))
+
+	webCtr, err := getTestContainer(strings.Repeat("1", 32), "web-1", manager)
+	require.NoError(t, err)
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	webCtr.config.Labels = map[string]string{"app": "web", "tier": "front"}
+	webCtr.config.Pod = testPod.ID()
+	webCtr.config.RestartPolicy = define.RestartPolicyAlways
+	webCtr.config.CreatedTime = created
+	require.NoError(t, state.AddContainerToPod(testPod, webCtr))
+
+	dbCtr, err := getTestContainer(strings.Repeat("2", 32), "db-1", manager)
+	require.NoError(t, err)
+	dbCtr.config.Labels = map[string]string{"app": "db", "tier": "back"}
+	dbCtr.config.RestartPolicy = define.RestartPolicyOnFailure
+	dbCtr.config.CreatedTime = created.Add(time.Hour)
+	require.NoError(t, state.AddContainer(dbCtr))
+
+	otherCtr, err := getTestContainer(strings.Repeat("3", 32), "web", manager)
+	require.NoError(t, err)
+	otherCtr.config.CreatedTime = created.Add(2 * time.Hour)
+	require.NoError(t, state.AddContainer(otherCtr))
+
+	tests := []struct {
+		name    string
+		filters *StateContainerFilters
+		ctrs    []string
+	}{
+		{"nil", nil, []string{"web-1", "db-1", "web"}},
+		{"empty", &StateContainerFilters{}, []string{"web-1", "db-1", "web"}},
+		{"pod", &StateContainerFilters{PodIDs: []string{testPod.ID()}}, []string{"web-1"}},
+		{"name prefix", &StateContainerFilters{NamePrefixes: []string{"web"}}, []string{"web-1", "web"}},
+		{"name prefixes", &StateContainerFilters{NamePrefixes: []string{"web-", "db"}}, []string{"web-1", "db-1"}},
+		{"label key", &StateContainerFilters{Labels: []string{"tier"}}, []string{"web-1", "db-1"}},
+		{"label value", &StateContainerFilters{Labels: []string{"app=db"}}, []string{"db-1"}},
+		{"labels", &StateContainerFilters{Labels: []string{"app=web", "tier=back"}}, nil},
+		{"restart policies", &StateContainerFilters{RestartPolicies: []string{define.RestartPolicyAlways, define.RestartPolicyNone}}, []string{"web-1", "web"}},
+		{"created before", &StateContainerFilters{CreatedBefore: created.Add(time.Hour)}, []string{"web-1"}},
+		{"created after", &StateContainerFilters{CreatedAfter: created}, []string{"db-1", "web"}},
+		{"limit", &StateContainerFilters{Limit: 2}, []string{"db-1", "web"}},
+		{"limit filtered", &StateContainerFilters{NamePrefixes: []string{"web"}, Limit: 1}, []string{"web"}},
+		{"all", &StateContainerFilters{PodIDs: []string{testPod.ID()}, NamePrefixes: []string{"web-1"}, Labels: []string{"app=web"}, RestartPolicies: []string{define.RestartPolicyAlways}, CreatedBefore: created.Add(time.Minute)}, []string{"web-1"}},
+	}
+	for _, tt := range tests {
+		ctrs, err := state.AllContainersFiltered(true, tt.filters)
+		require.NoError(t, err, tt.name)
+		names := make([]string, 0, len(ctrs))
+		for _, ctr := range ctrs {
+			names = append(names, ctr.Name())
+		}
+		assert.ElementsMatch(t, tt.ctrs, names, tt.name)
+	}
+
+	// Rewritten labels and restart policies are used for filtering.
+	newCfg := *dbCtr.config
+	newCfg.Labels = map[string]string{"app": "cache"}
+	newCfg.RestartPolicy = define.RestartPolicyUnlessStopped
+	require.NoError(t, state.RewriteContainerConfig(dbCtr, &newCfg))
+	ctrs, err := state.AllContainersFiltered(false, &StateContainerFilters{Labels: []string{"app=cache"}})
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, dbCtr.ID(), ctrs[0].ID())
+	ctrs, err = state.AllContainersFiltered(false, &StateContainerFilters{RestartPolicies: []string{define.RestartPolicyUnlessStopped}})
+	require.NoError(t, err)
+	require.Len(t, ctrs, 1)
+	assert.Equal(t, dbCtr.ID(), ctrs[0].ID())
+}
+
+func TestAllContainersFiltered(t *testing.T) {
+	runForAllStates(t, testAllContainersFiltered)
+}
+
+// assertImageProvenanceEqual compares provenance records, ignoring the time
+// zone of the pull times.
+func assertImageProvenanceEqual(t *testing.T, expected, actual []*define.ImageProvenance) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].Source, actual[i].Source)
+		assert.Equal(t, expected[i].ImageID, actual[i].ImageID)
+		assert.Equal(t, expected[i].Digest, actual[i].Digest)
+		assert.True(t, expected[i].Pulled.Equal(actual[i].Pulled), "pulled at %s, expected %s", actual[i].Pulled, expected[i].Pulled)
+	}
+}
+
+func testImageProvenance(t *testing.T, state State, _ lock.Manager) {
+	alpine := "docker.io/library/alpine:latest"
+	mirror := "quay.io/mirror/alpine:latest"
+	start := time.Unix(time.Now().Unix(), 0)
+	records := []*define.ImageProvenance{
+		{Source: alpine, ImageID: "aaa", Digest: "sha256:1", Pulled: start},
+		// Pulling the same image again is not recorded.
+		{Source: alpine, ImageID: "aaa", Digest: "sha256:1", Pulled: start.Add(time.Hour)},
+		{Source: mirror, ImageID: "aaa", Digest: "sha256:1", Pulled: start.Add(2 * time.Hour)},
+		{Source: "docker.io/library/busybox:latest", ImageID: "ccc", Digest: "sha256:3", Pulled: start.Add(3 * time.Hour)},
+		{Source: alpine, ImageID: "bbb", Digest: "sha256:2", Pulled: start.Add(4 * time.Hour)},
+		// Going back to a previous image is recorded.
+		{Source: alpine, ImageID: "aaa", Digest: "sha256:1", Pulled: start.Add(5 * time.Hour)},
+	}
+	for _, record := range records {
+		require.NoError(t, state.AddImageProvenance(record))
+	}
+
+	provenance, err := state.ImageProvenance("bbb")
+	require.NoError(t, err)
+	assertImageProvenanceEqual(t, []*define.ImageProvenance{records[0], records[4], records[5]}, provenance)
+
+	provenance, err = state.ImageProvenance("aaa")
+	require.NoError(t, err)
+	assertImageProvenanceEqual(t, []*define.ImageProvenance{records[0], records[2], records[4], records[5]}, provenance)
+
+	provenance, err = state.ImageProvenance("ddd")
+	require.NoError(t, err)
+	assert.Empty(t, provenance)
+
+	assert.ErrorIs(t, state.AddImageProvenance(&define.ImageProvenance{Source: alpine}), define.ErrEmptyID)
+}
+
+func TestImageProvenance(t *testing.T) {
+	runForAllStates(t, testImageProvenance)
+}
+
+func testImageDigestPin(t *testing.T, state State, _ lock.Manager) {
+	alpine := "docker.io/library/alpine:latest"
+	start := time.Unix(time.Now().Unix(), 0)
+
+	_, err := state.ImageDigestPin(alpine)
+	require.ErrorIs(t, err, define.ErrNoSuchImageDigestPin)
+
+	first := &define.ImageProvenance{Source: alpine, ImageID: "aaa", Digest: "sha256:1", Pulled: start}
+	require.NoError(t, state.SetImageDigestPin(first))
+	pin, err := state.ImageDigestPin(alpine)
+	require.NoError(t, err)
+	assertImageProvenanceEqual(t, []*define.ImageProvenance{first}, []*define.ImageProvenance{pin})
+
+	// A new pin replaces the previous one.
+	second := &define.ImageProvenance{Source: alpine, ImageID: "bbb", Digest: "sha256:2", Pulled: start.Add(time.Hour)}
+	require.NoError(t, state.SetImageDigestPin(second))
+	pin, err = state.ImageDigestPin(alpine)
+	require.NoError(t, err)
+	assertImageProvenanceEqual(t, []*define.ImageProvenance{second}, []*define.ImageProvenance{pin})
+
+	_, err = state.ImageDigestPin("docker.io/library/busybox:latest")
+	assert.ErrorIs(t, err, define.ErrNoSuchImageDigestPin)
+}
+
+func TestImageDigestPin(t *testing.T) {
+	runForAllStates(t, testImageDigestPin)
+}
+
 func TestGetAllContainersOnNewStateIsEmpty(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		ctrs, err := state.AllContainers(false)
@@ -593,6 +882,66 @@ func TestGetAllContainersTwoContainers(t *testing.T) {
 	})
 }
 
+func TestContainerDependencyGraph(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestCtr2(manager)
+		assert.NoError(t, err)
+		testCtr3, err := getTestCtrN("3", manager)
+		assert.NoError(t, err)
+		testCtr4, err := getTestCtrN("4", manager)
+		assert.NoError(t, err)
+
+		// 2 and 3 depend on 1, 4 depends on 2 and 3.
+		testCtr2.config.UserNsCtr = testCtr1.config.ID
+		testCtr3.config.IPCNsCtr = testCtr1.config.ID
+		testCtr4.config.NetNsCtr = testCtr2.config.ID
+		testCtr4.config.Dependencies = []string{testCtr3.config.ID}
+
+		for _, ctr := range []*Container{testCtr1, testCtr2, testCtr3, testCtr4} {
+			err = state.AddContainer(ctr)
+			assert.NoError(t, err)
+		}
+
+		edges, err := state.ContainerDependents(testCtr1)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []StateDependency{
+			{ID: testCtr2.ID(), DependencyID: testCtr1.ID()},
+			{ID: testCtr3.ID(), DependencyID: testCtr1.ID()},
+			{ID: testCtr4.ID(), DependencyID: testCtr2.ID()},
+			{ID: testCtr4.ID(), DependencyID: testCtr3.ID()},
+		}, edges)
+
+		edges, err = state.ContainerDependencies(testCtr4)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []StateDependency{
+			{ID: testCtr4.ID(), DependencyID: testCtr2.ID()},
+			{ID: testCtr4.ID(), DependencyID: testCtr3.ID()},
+			{ID: testCtr2.ID(), DependencyID: testCtr1.ID()},
+			{ID: testCtr3.ID(), DependencyID: testCtr1.ID()},
+		}, edges)
+
+		edges, err = state.ContainerDependencies(testCtr1)
+		assert.NoError(t, err)
+		assert.Empty(t, edges)
+		edges, err = state.ContainerDependents(testCtr4)
+		assert.NoError(t, err)
+		assert.Empty(t, edges)
+	})
+}
+
+func TestContainerDependencyGraphCtrNotInState(t *testing.T) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		_, err = state.ContainerDependents(testCtr)
+		assert.Error(t, err)
+		_, err = state.ContainerDependencies(testCtr)
+		assert.Error(t, err)
+	})
+}
+
 func TestContainerInUseInvalidContainer(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		_, err := state.ContainerInUse(&Container{})
@@ -601,7 +950,7 @@ func TestContainerInUseInvalidContainer(t *testing.T) {
 }
 
 func TestContainerInUseCtrNotInState(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
 		assert.NoError(t, err)
 		_, err = state.ContainerInUse(testCtr)
@@ -965,6 +1314,83 @@ func TestRewriteContainerConfigRewritesConfig(t *testing.T) {
 	})
 }
 
+func TestRenameContainer(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestCtr2(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr1)
+		assert.NoError(t, err)
+		err = state.AddContainer(testCtr2)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr1, testCtr2.Name())
+		assert.ErrorIs(t, err, define.ErrCtrExists)
+		assert.Equal(t, "test1", testCtr1.Name())
+
+		err = state.RenameContainer(testCtr1, "renamed")
+		assert.NoError(t, err)
+		assert.Equal(t, "renamed", testCtr1.Name())
+
+		id, err := state.LookupContainerID("renamed")
+		assert.NoError(t, err)
+		assert.Equal(t, testCtr1.ID(), id)
+		_, err = state.LookupContainerID("test1")
+		assert.Error(t, err)
+
+		testCtrFromState, err := state.Container(testCtr1.ID())
+		assert.NoError(t, err)
+		testContainersEqual(t, testCtrFromState, testCtr1, true)
+
+		// The old name is free again.
+		err = state.RenameContainer(testCtr2, "test1")
+		assert.NoError(t, err)
+	})
+}
+
+func TestRenameContainerNotInState(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		err = state.RenameContainer(testCtr, "renamed")
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateContainerResources(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		limit := int64(256 * 1024 * 1024)
+		resources := &spec.LinuxResources{Memory: &spec.LinuxMemory{Limit: &limit}}
+		err = state.UpdateContainerResources(testCtr, resources)
+		assert.NoError(t, err)
+		assert.Equal(t, resources, testCtr.config.Spec.Linux.Resources)
+
+		testCtrFromState, err := state.Container(testCtr.ID())
+		assert.NoError(t, err)
+		require.NotNil(t, testCtrFromState.config.Spec.Linux)
+		require.NotNil(t, testCtrFromState.config.Spec.Linux.Resources)
+		assert.Equal(t, limit, *testCtrFromState.config.Spec.Linux.Resources.Memory.Limit)
+		testContainersEqual(t, testCtrFromState, testCtr, true)
+	})
+}
+
+func TestUpdateContainerResourcesNotInState(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		err = state.UpdateContainerResources(testCtr, &spec.LinuxResources{})
+		assert.Error(t, err)
+	})
+}
+
 func TestRewritePodConfigDoesNotExist(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		err := state.RewritePodConfig(&Pod{}, &PodConfig{})
@@ -1348,7 +1774,7 @@ func TestAddPodCtrIDConflictFails(t *testing.T) {
 }
 
 func TestAddPodCtrNameConflictFails(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
 		assert.NoError(t, err)
 
@@ -1624,7 +2050,7 @@ func TestPodContainersByIDInvalidPod(t *testing.T) {
 }
 
 func TestPodContainerdByIDPodNotInState(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -1726,7 +2152,7 @@ func TestPodContainersInvalidPod(t *testing.T) {
 }
 
 func TestPodContainersPodNotInState(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -1829,7 +2255,7 @@ func TestRemovePodContainersInvalidPod(t *testing.T) {
 }
 
 func TestRemovePodContainersPodNotInState(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -1946,7 +2372,7 @@ func TestRemovePodContainersTwoContainers(t *testing.T) {
 }
 
 func TestRemovePodContainerDependencyInPod(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -2005,7 +2431,7 @@ func TestAddContainerToPodInvalidCtr(t *testing.T) {
 }
 
 func TestAddContainerToPodPodNotInState(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -2200,7 +2626,7 @@ func TestAddContainerToPodPodIDConflict(t *testing.T) {
 }
 
 func TestAddContainerToPodPodNameConflict(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -2345,7 +2771,7 @@ func TestRemoveContainerFromPodBadPodFails(t *testing.T) {
 }
 
 func TestRemoveContainerFromPodPodNotInStateFails(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -2361,7 +2787,7 @@ func TestRemoveContainerFromPodPodNotInStateFails(t *testing.T) {
 }
 
 func TestRemoveContainerFromPodCtrNotInStateFails(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
 		assert.NoError(t, err)
 
@@ -2595,7 +3021,7 @@ func TestGetContainerConfigNonExistentIDFails(t *testing.T) {
 
 // Test that the state will convert the ports to the new format
 func TestConvertPortMapping(t *testing.T) {
-	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
 		assert.NoError(t, err)
 
@@ -2631,3 +3057,135 @@ func TestConvertPortMapping(t *testing.T) {
 		testContainersEqual(t, retrievedCtr, testCtr, true)
 	})
 }
+
+func testContainerExitHistory(t *testing.T, state State, _ lock.Manager) {
+	start := time.Now().Add(-time.Second)
+	require.NoError(t, state.AddContainerExitCode("ctr", 1))
+	require.NoError(t, state.AddContainerExitCode("ctr", 2))
+	require.NoError(t, state.AddContainerExitCode("other", 3))
+
+	exitCode, err := state.GetContainerExitCode("ctr")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), exitCode)
+
+	// Recent exits are kept when pruning, even if the container does not
+	// exist.
+	require.NoError(t, state.PruneContainerExitCodes())
+
+	history, err := state.ContainerExitHistory("ctr")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	for i, expected := range []int32{1, 2} {
+		assert.Equal(t, expected, history[i].ExitCode)
+		assert.False(t, history[i].ExitedAt.Before(start), "exited at %s, before %s", history[i].ExitedAt, start)
+	}
+
+	history, err = state.ContainerExitHistory("missing")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	_, err = state.ContainerExitHistory("")
+	assert.ErrorIs(t, err, define.ErrEmptyID)
+}
+
+func TestContainerExitHistory(t *testing.T) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
+		testContainerExitHistory(t, state, manager)
+
+		boltState, ok := state.(*BoltState)
+		require.True(t, ok)
+		boltState.runtime.podmanConf.Engine.ExitCodeRetention = 60
+		require.NoError(t, boltState.addContainerExitCode("old", 1, time.Now().Add(-2*time.Minute)))
+		require.NoError(t, boltState.addContainerExitCode("ctr", 3, time.Now().Add(-2*time.Minute)))
+		require.NoError(t, state.PruneContainerExitCodes())
+
+		history, err := state.ContainerExitHistory("old")
+		require.NoError(t, err)
+		assert.Empty(t, history)
+		_, err = state.GetContainerExitCode("old")
+		assert.ErrorIs(t, err, define.ErrNoSuchExitCode)
+
+		// Only the exits older than the retention are removed.
+		history, err = state.ContainerExitHistory("ctr")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, int32(2), history[1].ExitCode)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		require.NoError(t, err)
+		require.NoError(t, state.AddContainer(testCtr))
+
+		report, err := state.Verify()
+		require.NoError(t, err)
+		assert.False(t, report.HasProblems(), "%+v", report)
+
+		boltState, ok := state.(*BoltState)
+		require.True(t, ok)
+		require.NoError(t, boltState.addContainerExitCode("orphan", 1, time.Now().Add(-10*time.Minute)))
+		require.NoError(t, boltState.addContainerExitCode("recent", 1, time.Now()))
+		require.NoError(t, boltState.addContainerExitCode(testCtr.ID(), 1, time.Now().Add(-10*time.Minute)))
+
+		report, err = state.Verify()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orphan"}, report.OrphanExitCodes)
+		assert.Empty(t, report.Errors)
+	})
+}
+
+func TestBackup(t *testing.T) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		require.NoError(t, err)
+		require.NoError(t, state.AddContainer(testCtr))
+
+		backupPath := filepath.Join(t.TempDir(), "backup.db")
+		require.NoError(t, state.Backup(backupPath))
+
+		// Changes after the backup are not part of it.
+		require.NoError(t, state.RemoveContainer(testCtr))
+
+		boltState, ok := state.(*BoltState)
+		require.True(t, ok)
+		backup, err := NewBoltState(backupPath, boltState.runtime)
+		require.NoError(t, err)
+		defer backup.Close()
+
+		ctrs, err := backup.AllContainers(false)
+		require.NoError(t, err)
+		require.Len(t, ctrs, 1)
+		testContainersEqual(t, ctrs[0], testCtr, true)
+
+		// Backups never overwrite existing files.
+		assert.Error(t, state.Backup(backupPath))
+	})
+}
+
+func TestReadOnlyState(t *testing.T) {
+	runForBoltState(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		require.NoError(t, err)
+		require.NoError(t, state.AddContainer(testCtr))
+
+		boltState, ok := state.(*BoltState)
+		require.True(t, ok)
+		runtime := new(Runtime)
+		runtime.config = boltState.runtime.config
+		runtime.storageConfig = boltState.runtime.storageConfig
+		runtime.lockManager = manager
+		runtime.readOnlyState = true
+		roState, err := NewBoltState(boltState.dbPath, runtime)
+		require.NoError(t, err)
+		defer roState.Close()
+
+		retrievedCtr, err := roState.Container(testCtr.ID())
+		require.NoError(t, err)
+		testContainersEqual(t, retrievedCtr, testCtr, true)
+
+		assert.ErrorIs(t, roState.RemoveContainer(testCtr), bolt.ErrDatabaseReadOnly)
+		assert.NoError(t, roState.ValidateDBConfig(runtime))
+	})
+}
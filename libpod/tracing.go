@@ -0,0 +1,54 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+
+	"github.com/containers/podman/v5/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/containers/podman/v5/libpod")
+
+// traceParent returns the context carrying the parent span of the operations
+// made for ctr: the span of the traced operation running on it if there is
+// one, or else the span of the process.
+func traceParent(ctr *Container) context.Context {
+	if ctr != nil && ctr.traceCtx != nil {
+		return ctr.traceCtx
+	}
+	return tracing.ProcessContext()
+}
+
+// startSpan starts the span of an operation on the container, a child of the
+// span of ctx or of the span of the process if ctx has none, and makes it the
+// parent of the spans of the state operations made for the container until it
+// ends. It returns the context carrying the span and the function ending it
+// with the result of the operation.
+func (c *Container) startSpan(ctx context.Context, operation string) (context.Context, func(error)) {
+	if !tracing.Enabled() {
+		return ctx, func(error) {}
+	}
+
+	parent := ctx
+	if !trace.SpanContextFromContext(parent).IsValid() {
+		parent = traceParent(c)
+	}
+	_, span := tracer.Start(parent, operation)
+	previous := c.traceCtx
+	c.traceCtx = trace.ContextWithSpan(context.Background(), span)
+
+	return trace.ContextWithSpan(ctx, span), func(err error) {
+		c.traceCtx = previous
+		span.SetAttributes(attribute.String("container.id", c.ID()), attribute.String("container.name", c.Name()))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"syscall"
 
+	"github.com/containers/podman/v5/libpod/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
@@ -23,6 +24,15 @@ func deleteSystemdCgroup(path string, resources *spec.LinuxResources) error {
 	return nil
 }
 
+// fillCgroupInspect is a no-op on FreeBSD which has no cgroups.
+func fillCgroupInspect(state *define.InspectContainerState) {
+}
+
+// keyringKeys returns nil on FreeBSD which has no kernel keyrings.
+func keyringKeys(ctrID string) *int {
+	return nil
+}
+
 // No equivalent on FreeBSD?
 func LabelVolumePath(path, mountLabel string) error {
 	return nil
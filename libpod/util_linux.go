@@ -5,7 +5,9 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -19,15 +21,98 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-func cgroupExist(path string) bool {
+// cgroupFullPath returns the location of the given cgroup in the cgroup
+// filesystem.  On cgroups v1 the memory hierarchy is used.
+func cgroupFullPath(path string) string {
 	cgroupv2, _ := cgroups.IsCgroup2UnifiedMode()
-	var fullPath string
 	if cgroupv2 {
-		fullPath = filepath.Join("/sys/fs/cgroup", path)
-	} else {
-		fullPath = filepath.Join("/sys/fs/cgroup/memory", path)
+		return filepath.Join("/sys/fs/cgroup", path)
+	}
+	return filepath.Join("/sys/fs/cgroup/memory", path)
+}
+
+func cgroupExist(path string) bool {
+	return fileutils.Exists(cgroupFullPath(path)) == nil
+}
+
+// systemdUnitFromCgroup returns the innermost systemd unit and slice the
+// given cgroup belongs to, and the cgroup path of the unit.
+func systemdUnitFromCgroup(path string) (unit, slice, unitPath string) {
+	elements := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(elements) - 1; i >= 0; i-- {
+		switch {
+		case unit == "" && (strings.HasSuffix(elements[i], ".scope") || strings.HasSuffix(elements[i], ".service")):
+			unit = elements[i]
+			unitPath = "/" + strings.Join(elements[:i+1], "/")
+		case unit != "" && strings.HasSuffix(elements[i], ".slice"):
+			return unit, elements[i], unitPath
+		}
+	}
+	return unit, slice, unitPath
+}
+
+// isCgroupDelegated reports whether systemd delegated the given cgroup.
+// systemd marks delegated cgroups with the trusted.delegate extended
+// attribute, or user.delegate when running as a user manager.
+func isCgroupDelegated(fullPath string) bool {
+	buf := make([]byte, 8)
+	for _, attr := range []string{"trusted.delegate", "user.delegate"} {
+		n, err := unix.Getxattr(fullPath, attr, buf)
+		if err == nil && string(buf[:n]) == "1" {
+			return true
+		}
+	}
+	return false
+}
+
+// fillCgroupInspect adds the location and the systemd unit of the container's
+// cgroup to the inspect data.
+func fillCgroupInspect(state *define.InspectContainerState) {
+	if state.CgroupPath == "" {
+		return
+	}
+	state.CgroupFullPath = cgroupFullPath(state.CgroupPath)
+	unit, slice, unitPath := systemdUnitFromCgroup(state.CgroupPath)
+	if unit == "" {
+		return
+	}
+	state.CgroupUnit = unit
+	state.CgroupSlice = slice
+	state.CgroupDelegated = isCgroupDelegated(cgroupFullPath(unitPath))
+}
+
+// keyringKeys returns the number of keys in the session keyring the OCI runtime
+// created for the container, or nil if the keyring is not visible.
+func keyringKeys(ctrID string) *int {
+	data, err := os.ReadFile("/proc/keys")
+	if err != nil {
+		return nil
 	}
-	return fileutils.Exists(fullPath) == nil
+	return parseKeyringKeys(string(data), ctrID)
+}
+
+// parseKeyringKeys returns the number of keys in the keyring with the given
+// description in the content of /proc/keys, which lists keyrings as
+//
+//	<serial> <flags> <usage> <expiry> <perm> <uid> <gid> keyring <description>: <keys>
+//
+// with keys being "empty" or the number of keys.
+func parseKeyringKeys(procKeys, description string) *int {
+	for _, line := range strings.Split(procKeys, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 10 || fields[7] != "keyring" || fields[8] != description+":" {
+			continue
+		}
+		n := 0
+		if fields[9] != "empty" {
+			var err error
+			if n, err = strconv.Atoi(fields[9]); err != nil {
+				return nil
+			}
+		}
+		return &n
+	}
+	return nil
 }
 
 // systemdSliceFromPath makes a new systemd slice under the given parent with
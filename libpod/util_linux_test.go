@@ -39,3 +39,41 @@ func TestLabelVolumePath(t *testing.T) {
 	err := LabelVolumePath("/foo/bar", "")
 	assert.NoError(t, err)
 }
+
+func TestSystemdUnitFromCgroup(t *testing.T) {
+	tests := []struct {
+		path, unit, slice, unitPath string
+	}{
+		{"/machine.slice/libpod-abc.scope", "libpod-abc.scope", "machine.slice", "/machine.slice/libpod-abc.scope"},
+		{"/machine.slice/libpod-abc.scope/container", "libpod-abc.scope", "machine.slice", "/machine.slice/libpod-abc.scope"},
+		{
+			"/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-abc.scope/container",
+			"libpod-abc.scope", "user.slice",
+			"/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-abc.scope",
+		},
+		{"/libpod_parent/libpod-abc", "", "", ""},
+	}
+	for _, tt := range tests {
+		unit, slice, unitPath := systemdUnitFromCgroup(tt.path)
+		assert.Equal(t, tt.unit, unit, tt.path)
+		assert.Equal(t, tt.slice, slice, tt.path)
+		assert.Equal(t, tt.unitPath, unitPath, tt.path)
+	}
+}
+
+func TestParseKeyringKeys(t *testing.T) {
+	procKeys := `0a1b2c3d I--Q---     1 perm 3f030000     0     0 keyring   _ses: 1
+1b2c3d4e I--Q---     2 perm 3f030000     0     0 keyring   abc123: empty
+2c3d4e5f I--Q---     3 perm 3f030000     0     0 keyring   def456: 3
+3d4e5f60 I--Q---     1 perm 3f010000     0     0 user      def456: 12
+`
+	n := parseKeyringKeys(procKeys, "abc123")
+	if assert.NotNil(t, n) {
+		assert.Equal(t, 0, *n)
+	}
+	n = parseKeyringKeys(procKeys, "def456")
+	if assert.NotNil(t, n) {
+		assert.Equal(t, 3, *n)
+	}
+	assert.Nil(t, parseKeyringKeys(procKeys, "missing"))
+}
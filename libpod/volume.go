@@ -8,7 +8,6 @@ import (
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/lock"
 	"github.com/containers/podman/v5/libpod/plugin"
-	"github.com/containers/storage/pkg/directory"
 )
 
 // Volume is a libpod named volume.
@@ -58,6 +57,14 @@ type VolumeConfig struct {
 	// DisableQuota indicates that the volume should completely disable using any
 	// quota tracking.
 	DisableQuota bool `json:"disableQuota,omitempty"`
+	// QuotaMethod is how Size and Inodes are enforced, either
+	// define.VolumeQuotaProject or define.VolumeQuotaLoopback. Only set for
+	// local volumes with a size or inodes limit.
+	QuotaMethod string `json:"quotaMethod,omitempty"`
+	// EncryptionSecret is the name of the secret holding the password
+	// the contents of the volume are encrypted with. Only set for
+	// encrypted local volumes.
+	EncryptionSecret string `json:"encryptionSecret,omitempty"`
 	// Timeout allows users to override the default driver timeout of 5 seconds
 	Timeout *uint `json:"timeout,omitempty"`
 	// StorageName is the name of the volume in c/storage. Only used for
@@ -69,6 +76,10 @@ type VolumeConfig struct {
 	// StorageImageID is the ID of the image the volume was based off of.
 	// Only used for image volumes.
 	StorageImageID string `json:"storageImageID,omitempty"`
+	// StorageImageName is the name the image the volume was based off of
+	// was resolved to when the volume was created. Only used for image
+	// volumes.
+	StorageImageName string `json:"storageImageName,omitempty"`
 	// MountLabel is the SELinux label to assign to mount points
 	MountLabel string `json:"mountlabel,omitempty"`
 }
@@ -106,6 +117,23 @@ type VolumeState struct {
 	UIDChowned int `json:"uidChowned,omitempty"`
 	// GIDChowned is the GID the volume was chowned to.
 	GIDChowned int `json:"gidChowned,omitempty"`
+	// MountedSource is the network share mounted at the mountpoint of a
+	// volume of the nfs or cifs driver, set while it is mounted.
+	MountedSource string `json:"mountedSource,omitempty"`
+	// SizeCache holds the size of the contents of the volume as computed
+	// while no container could write to it. It is cleared, marking the
+	// size dirty, whenever the volume is mounted into a container or
+	// mounted for writing by Podman.
+	SizeCache *VolumeSize `json:"sizeCache,omitempty"`
+}
+
+// VolumeSize holds the size of the contents of a volume.
+type VolumeSize struct {
+	// Size is the size on disk of the contents of the volume.
+	Size int64 `json:"size"`
+	// ComputedAt is when the size was computed. It is older than the
+	// current time if it was read from the size cache.
+	ComputedAt time.Time `json:"computedAt"`
 }
 
 // Name retrieves the volume's name
@@ -115,8 +143,11 @@ func (v *Volume) Name() string {
 
 // Returns the size on disk of volume
 func (v *Volume) Size() (uint64, error) {
-	size, err := directory.Size(v.config.MountPoint)
-	return uint64(size), err
+	size, err := v.DiskUsage()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(size.Size), nil
 }
 
 // Driver retrieves the volume's driver.
@@ -155,6 +186,12 @@ func (v *Volume) MountPoint() (string, error) {
 	return v.mountPoint(), nil
 }
 
+// ImageID returns the ID of the image backing the volume. It is empty unless
+// the volume uses the image driver.
+func (v *Volume) ImageID() string {
+	return v.config.StorageImageID
+}
+
 // MountCount returns the volume's mountcount on the host from state
 // Useful in determining if volume is using plugin or a filesystem mount and its mount
 func (v *Volume) MountCount() (uint, error) {
@@ -269,7 +306,8 @@ func (v *Volume) IsDangling() (bool, error) {
 // drivers are pluggable backends for volumes that will manage the storage and
 // mounting.
 func (v *Volume) UsesVolumeDriver() bool {
-	if v.config.Driver == define.VolumeDriverImage {
+	switch v.config.Driver {
+	case define.VolumeDriverImage, define.VolumeDriverNFS, define.VolumeDriverCIFS:
 		if _, ok := v.runtime.config.Engine.VolumePlugins[v.config.Driver]; ok {
 			return true
 		}
@@ -278,11 +316,23 @@ func (v *Volume) UsesVolumeDriver() bool {
 	return !(v.config.Driver == define.VolumeDriverLocal || v.config.Driver == "")
 }
 
+// usesShareDriver determines whether the volume mounts a network share with
+// one of the built-in nfs and cifs drivers.
+func (v *Volume) usesShareDriver() bool {
+	return (v.config.Driver == define.VolumeDriverNFS || v.config.Driver == define.VolumeDriverCIFS) && !v.UsesVolumeDriver()
+}
+
 func (v *Volume) Mount() (string, error) {
 	v.lock.Lock()
 	defer v.lock.Unlock()
-	err := v.mount()
-	return v.config.MountPoint, err
+	if err := v.mount(); err != nil {
+		return v.config.MountPoint, err
+	}
+	// The volume is mounted for writing, e.g. to restore a backup.
+	if err := v.update(); err != nil {
+		return v.config.MountPoint, err
+	}
+	return v.config.MountPoint, v.invalidateSize()
 }
 
 func (v *Volume) Unmount() error {
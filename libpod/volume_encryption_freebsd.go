@@ -0,0 +1,27 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// createVolumeEncryptedFS initializes the directory holding the encrypted
+// contents of a volume. Not supported on FreeBSD.
+func createVolumeEncryptedFS(_ string, _ []byte) error {
+	return fmt.Errorf("encrypted volumes: %w", define.ErrNotImplemented)
+}
+
+// mountEncrypted mounts the decrypted view of the contents of the volume.
+// Not supported on FreeBSD.
+func (v *Volume) mountEncrypted() error {
+	return fmt.Errorf("encrypted volumes: %w", define.ErrNotImplemented)
+}
+
+// encryptedVolumesSupported returns an error as encrypted volumes are not
+// supported on FreeBSD.
+func encryptedVolumesSupported() error {
+	return fmt.Errorf("encrypted volumes: %w", define.ErrNotImplemented)
+}
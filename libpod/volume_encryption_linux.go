@@ -0,0 +1,80 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/containers/storage/pkg/unshare"
+	"github.com/sirupsen/logrus"
+)
+
+// volumeEncryptionCommand is the FUSE file system encrypting the contents
+// of encrypted volumes.
+const volumeEncryptionCommand = "gocryptfs"
+
+// runVolumeEncryptionCommand runs gocryptfs with the given arguments, passing
+// it the password of the volume on its standard input.
+func runVolumeEncryptionCommand(password []byte, args ...string) error {
+	path, err := exec.LookPath(volumeEncryptionCommand)
+	if err != nil {
+		return fmt.Errorf("encrypted volumes require %s: %w", volumeEncryptionCommand, err)
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = bytes.NewReader(password)
+	logrus.Debugf("Running %s %s", path, strings.Join(args, " "))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("running %s: %w: %s", volumeEncryptionCommand, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// createVolumeEncryptedFS initializes the directory holding the encrypted
+// contents of a volume, encrypted with a key derived from password.
+func createVolumeEncryptedFS(cipherDir string, password []byte) error {
+	if err := runVolumeEncryptionCommand(password, "-init", "-q", cipherDir); err != nil {
+		return fmt.Errorf("initializing encrypted volume: %w", err)
+	}
+	return nil
+}
+
+// mountEncrypted mounts the decrypted view of the contents of the volume at
+// its mount point. The volume lock must be held.
+func (v *Volume) mountEncrypted() error {
+	password, err := v.encryptionPassword()
+	if err != nil {
+		return err
+	}
+	args := []string{"-q"}
+	// FUSE file systems are only accessible by the user mounting them
+	// unless allow_other is given, which only root may use without
+	// user_allow_other in /etc/fuse.conf.
+	if !unshare.IsRootless() {
+		args = append(args, "-allow_other")
+	}
+	if v.config.MountLabel != "" {
+		args = append(args, "-ko", fmt.Sprintf("context=%q", v.config.MountLabel))
+	}
+	args = append(args, v.cipherDirPath(), v.config.MountPoint)
+	if err := runVolumeEncryptionCommand(password, args...); err != nil {
+		return fmt.Errorf("mounting encrypted volume %s: %w", v.Name(), err)
+	}
+	logrus.Debugf("Mounted encrypted volume %s", v.Name())
+	return nil
+}
+
+// encryptedVolumesSupported returns an error if encrypted volumes cannot be
+// created on this host.
+func encryptedVolumesSupported() error {
+	if _, err := exec.LookPath(volumeEncryptionCommand); err != nil {
+		return fmt.Errorf("encrypted volumes require %s: %w", volumeEncryptionCommand, err)
+	}
+	if _, err := os.Stat("/dev/fuse"); err != nil {
+		return fmt.Errorf("encrypted volumes require FUSE: %w", err)
+	}
+	return nil
+}
@@ -62,11 +62,15 @@ func (v *Volume) Inspect() (*define.InspectVolumeData, error) {
 	}
 	data.UID = v.uid()
 	data.GID = v.gid()
+	data.QuotaMethod = v.config.QuotaMethod
+	data.EncryptionSecret = v.config.EncryptionSecret
 	data.Anonymous = v.config.IsAnon
 	data.MountCount = v.state.MountCount
 	data.NeedsCopyUp = v.state.NeedsCopyUp
 	data.NeedsChown = v.state.NeedsChown
 	data.StorageID = v.config.StorageID
+	data.Image = v.config.StorageImageID
+	data.ImageName = v.config.StorageImageName
 	data.LockNumber = v.lock.ID()
 
 	if v.config.Timeout != nil {
@@ -3,11 +3,25 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/mount"
+)
+
+const (
+	// volumeLoopbackImage is the file system image of a volume limited by a
+	// loopback file system, next to its _data directory.
+	volumeLoopbackImage = "quota.img"
+	// volumeLoopbackFSType is the file system type of the image.
+	volumeLoopbackFSType = "ext4"
+	// volumeCipherDir is the directory holding the encrypted contents of
+	// an encrypted volume, next to its _data directory.
+	volumeCipherDir = "encrypted"
 )
 
 // Creates a new volume
@@ -23,12 +37,49 @@ func newVolume(runtime *Runtime) *Volume {
 	return volume
 }
 
+// loopbackImagePath returns the path of the file system image of a volume
+// limited by a loopback file system.
+func (v *Volume) loopbackImagePath() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, v.Name(), volumeLoopbackImage)
+}
+
+// cipherDirPath returns the directory holding the encrypted contents of an
+// encrypted volume.
+func (v *Volume) cipherDirPath() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, v.Name(), volumeCipherDir)
+}
+
+// encryptionPassword returns the password the contents of an encrypted
+// volume are encrypted with, read from its secret.
+func (v *Volume) encryptionPassword() ([]byte, error) {
+	manager, err := v.runtime.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	_, data, err := manager.LookupSecretData(v.config.EncryptionSecret)
+	if err != nil {
+		return nil, fmt.Errorf("reading encryption secret %s of volume %s: %w", v.config.EncryptionSecret, v.Name(), err)
+	}
+	return data, nil
+}
+
 // teardownStorage deletes the volume from volumePath
 func (v *Volume) teardownStorage() error {
 	if v.UsesVolumeDriver() {
 		return nil
 	}
 
+	// Never delete the contents of a network share that is still mounted.
+	if v.usesShareDriver() {
+		mounted, err := mount.Mounted(v.config.MountPoint)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("checking if share %s is mounted: %w", shareSource(v.config), err)
+		}
+		if mounted {
+			return fmt.Errorf("share %s is still mounted at %s, not removing it: %w", shareSource(v.config), v.config.MountPoint, define.ErrVolumeBeingUsed)
+		}
+	}
+
 	// TODO: Should this be converted to use v.config.MountPoint?
 	return os.RemoveAll(filepath.Join(v.runtime.config.Engine.VolumePath, v.Name()))
 }
@@ -46,6 +97,21 @@ func (v *Volume) needsMount() bool {
 		return true
 	}
 
+	// Volumes limited by a file system of their own mount it
+	if v.config.QuotaMethod == define.VolumeQuotaLoopback {
+		return true
+	}
+
+	// Encrypted volumes mount the decrypted view of their contents
+	if v.config.EncryptionSecret != "" {
+		return true
+	}
+
+	// Network shares always need mount
+	if v.usesShareDriver() {
+		return true
+	}
+
 	// Commit 28138dafcc added the UID and GID options to this map
 	// However we should only mount when options other than uid and gid are set.
 	// see https://github.com/containers/podman/issues/10620
@@ -59,6 +125,9 @@ func (v *Volume) needsMount() bool {
 	if _, ok := v.config.Options["SIZE"]; ok {
 		index++
 	}
+	if _, ok := v.config.Options["INODES"]; ok {
+		index++
+	}
 	if _, ok := v.config.Options["NOQUOTA"]; ok {
 		index++
 	}
@@ -111,4 +180,14 @@ func resetVolumeState(state *VolumeState) {
 	state.MountCount = 0
 	state.MountPoint = ""
 	state.CopiedUp = false
+	state.MountedSource = ""
+}
+
+// shareSource returns the network share mounted by a volume of the nfs or
+// cifs driver, in the syntax of mount(8).
+func shareSource(config *VolumeConfig) string {
+	if config.Driver == define.VolumeDriverCIFS {
+		return "//" + config.Options["server"] + "/" + strings.TrimPrefix(config.Options["share"], "/")
+	}
+	return config.Options["server"] + ":" + config.Options["export"]
 }
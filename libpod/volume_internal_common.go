@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/containers/storage/pkg/mount"
 	pluginapi "github.com/docker/go-plugins-helpers/volume"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
@@ -39,7 +41,7 @@ func (v *Volume) mount() error {
 
 	// If the count is non-zero, the volume is already mounted.
 	// Nothing to do.
-	if v.state.MountCount > 0 {
+	if v.state.MountCount > 0 && !v.shareLost() {
 		v.state.MountCount++
 		logrus.Debugf("Volume %s mount count now at %d", v.Name(), v.state.MountCount)
 		return v.save()
@@ -76,10 +78,46 @@ func (v *Volume) mount() error {
 		return v.save()
 	}
 
+	// Encrypted volumes are mounted by a FUSE file system decrypting
+	// their contents.
+	if v.config.EncryptionSecret != "" {
+		if err := v.mountEncrypted(); err != nil {
+			return err
+		}
+		v.state.MountCount++
+		logrus.Debugf("Volume %s mount count now at %d", v.Name(), v.state.MountCount)
+		return v.save()
+	}
+
 	volDevice := v.config.Options["device"]
 	volType := v.config.Options["type"]
 	volOptions := v.config.Options["o"]
 
+	// Network shares are mounted from their server. A share still mounted
+	// after the state was reset by a refresh is used as is instead of
+	// being mounted a second time.
+	if v.usesShareDriver() {
+		volDevice = shareSource(v.config)
+		volType = v.config.Driver
+		if mounted, err := mount.Mounted(v.config.MountPoint); err == nil && mounted {
+			logrus.Debugf("Share %s of volume %s is already mounted", volDevice, v.Name())
+			v.state.MountCount++
+			v.state.MountedSource = volDevice
+			return v.save()
+		}
+	}
+
+	// Volumes limited by a file system of their own mount its image. The
+	// volume must not be used without its limit if the image is gone.
+	if v.config.QuotaMethod == define.VolumeQuotaLoopback {
+		volDevice = v.loopbackImagePath()
+		volType = volumeLoopbackFSType
+		volOptions = "loop"
+		if err := fileutils.Exists(volDevice); err != nil {
+			return fmt.Errorf("volume %s is limited by a loopback file system but its image cannot be used: %w", v.Name(), err)
+		}
+	}
+
 	// Some filesystems (tmpfs) don't have a device, but we still need to
 	// give the kernel something.
 	if volDevice == "" && volType != "" {
@@ -116,6 +154,9 @@ func (v *Volume) mount() error {
 	}
 
 	logrus.Debugf("Mounted volume %s", v.Name())
+	if v.usesShareDriver() {
+		v.state.MountedSource = volDevice
+	}
 
 	// Increment the mount counter
 	v.state.MountCount++
@@ -123,6 +164,24 @@ func (v *Volume) mount() error {
 	return v.save()
 }
 
+// shareLost returns whether the network share of a volume of the nfs or cifs
+// driver is no longer mounted although the volume is in use, e.g. because it
+// was unmounted by hand. The share is then mounted again by the next mount().
+func (v *Volume) shareLost() bool {
+	if !v.usesShareDriver() {
+		return false
+	}
+	mounted, err := mount.Mounted(v.config.MountPoint)
+	if err != nil {
+		logrus.Debugf("Checking if share %s of volume %s is mounted: %v", v.state.MountedSource, v.Name(), err)
+		return false
+	}
+	if !mounted {
+		logrus.Warnf("Share %s of volume %s is no longer mounted, mounting it again", v.state.MountedSource, v.Name())
+	}
+	return !mounted
+}
+
 // unmount unmounts the volume if necessary.
 // Unmounting a volume that is not mounted is a no-op.
 // Unmounting a volume that does not require a mount is a no-op.
@@ -187,6 +246,7 @@ func (v *Volume) unmount(force bool) error {
 			return fmt.Errorf("unmounting volume %s: %w", v.Name(), err)
 		}
 		logrus.Debugf("Unmounted volume %s", v.Name())
+		v.state.MountedSource = ""
 	}
 
 	return v.save()
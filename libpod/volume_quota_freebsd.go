@@ -0,0 +1,15 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// createVolumeLoopbackFS creates the file system image limiting the size and
+// inodes of a volume. Not supported on FreeBSD.
+func createVolumeLoopbackFS(_, _ string, _ *VolumeConfig) error {
+	return fmt.Errorf("loopback file systems for volumes: %w", define.ErrNotImplemented)
+}
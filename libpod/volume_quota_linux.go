@@ -0,0 +1,63 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// createVolumeLoopbackFS creates the file system image limiting the size and
+// inodes of a volume and prepares its root directory, mounting it at
+// mountPoint for the time being.
+func createVolumeLoopbackFS(image, mountPoint string, config *VolumeConfig) (retErr error) {
+	f, err := os.OpenFile(image, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating file system image: %w", err)
+	}
+	defer func() {
+		if retErr != nil {
+			if err := os.Remove(image); err != nil {
+				logrus.Errorf("Removing file system image %s: %v", image, err)
+			}
+		}
+	}()
+	// The image is sparse, its blocks are only allocated once written.
+	err = f.Truncate(int64(config.Size))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("sizing file system image: %w", err)
+	}
+
+	mkfsArgs := []string{"-q", "-F", "-E", fmt.Sprintf("root_owner=%d:%d", config.UID, config.GID)}
+	if config.Inodes > 0 {
+		mkfsArgs = append(mkfsArgs, "-N", strconv.FormatUint(config.Inodes, 10))
+	}
+	mkfsArgs = append(mkfsArgs, image)
+	if output, err := exec.Command("mkfs."+volumeLoopbackFSType, mkfsArgs...).CombinedOutput(); err != nil {
+		return fmt.Errorf("creating %s file system: %w: %s", volumeLoopbackFSType, err, output)
+	}
+
+	if output, err := exec.Command("mount", "-t", volumeLoopbackFSType, "-o", "loop", image, mountPoint).CombinedOutput(); err != nil {
+		return fmt.Errorf("mounting file system image: %w: %s", err, output)
+	}
+	defer func() {
+		if err := detachUnmount(mountPoint); err != nil {
+			logrus.Errorf("Unmounting file system image %s: %v", image, err)
+		}
+	}()
+	// The volume starts out empty, lost+found is only needed by fsck,
+	// which recreates it.
+	if err := os.Remove(filepath.Join(mountPoint, "lost+found")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing lost+found: %w", err)
+	}
+	return LabelVolumePath(mountPoint, config.MountLabel)
+}
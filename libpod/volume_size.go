@@ -0,0 +1,115 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/sirupsen/logrus"
+)
+
+// volumeSizeCacheTimeout is how long a cached volume size is used. Writes by
+// containers and Podman mark the size dirty, but the contents of a volume can
+// also be modified directly on the host, e.g. by podman volume import.
+const volumeSizeCacheTimeout = 10 * time.Minute
+
+// DiskUsage returns the size on disk of the contents of the volume.
+// The size is computed lazily and cached in the state of the volume while no
+// container can write to it, so it is not recomputed on every call.
+func (v *Volume) DiskUsage() (*VolumeSize, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+
+	if v.state.SizeCache != nil && time.Since(v.state.SizeCache.ComputedAt) < volumeSizeCacheTimeout {
+		return v.state.SizeCache, nil
+	}
+
+	mayChange, err := v.sizeMayChange()
+	if err != nil {
+		return nil, err
+	}
+	path := v.mountPoint()
+	// The contents of encrypted volumes are only visible while mounted,
+	// their size on disk is the size of the encrypted contents.
+	if v.config.EncryptionSecret != "" {
+		path = v.cipherDirPath()
+	}
+	size := &VolumeSize{ComputedAt: time.Now()}
+	if size.Size, err = directory.Size(path); err != nil {
+		return nil, err
+	}
+
+	if mayChange {
+		if v.state.SizeCache == nil {
+			return size, nil
+		}
+		// Drop the expired cached size.
+		v.state.SizeCache = nil
+	} else {
+		v.state.SizeCache = size
+	}
+	if err := v.save(); err != nil {
+		logrus.Warnf("Caching size of volume %s: %v", v.Name(), err)
+	}
+	return size, nil
+}
+
+// sizeMayChange returns whether the contents of the volume may change without
+// libpod noticing, in which case its size must not be cached. This is the
+// case while a container using the volume is not stopped, as it has the
+// volume mounted, and for volumes whose contents are not managed by Podman.
+// The volume lock must be held.
+func (v *Volume) sizeMayChange() (bool, error) {
+	if v.UsesVolumeDriver() || v.config.Driver == define.VolumeDriverImage || v.usesShareDriver() {
+		return true, nil
+	}
+
+	ctrs, err := v.runtime.state.VolumeInUse(v)
+	if err != nil {
+		return true, err
+	}
+	for _, id := range ctrs {
+		// Containers lock their volumes while holding their own lock,
+		// so the state of the containers is read without locking them.
+		ctr, err := v.runtime.state.Container(id)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			if errors.Is(err, define.ErrNSMismatch) {
+				return true, nil
+			}
+			return true, err
+		}
+		if err := v.runtime.state.UpdateContainer(ctr); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return true, err
+		}
+		switch ctr.state.State {
+		case define.ContainerStateConfigured, define.ContainerStateStopped, define.ContainerStateExited:
+		default:
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// invalidateSize marks the size of the volume dirty by clearing its size
+// cache, as its contents may be modified from now on. The volume lock must be
+// held and its state must be up to date.
+func (v *Volume) invalidateSize() error {
+	if v.state.SizeCache == nil {
+		return nil
+	}
+	v.state.SizeCache = nil
+	return v.save()
+}
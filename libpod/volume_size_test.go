@@ -0,0 +1,79 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeDiskUsageCache(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = manager
+	tmpDir := t.TempDir()
+
+	state, err := newSqliteState(runtime, filepath.Join(tmpDir, "db.sql"))
+	require.NoError(t, err)
+	defer state.Close()
+	runtime.state = state
+
+	volLock, err := manager.AllocateLock()
+	require.NoError(t, err)
+	dataDir := filepath.Join(tmpDir, "data")
+	require.NoError(t, os.Mkdir(dataDir, 0o755))
+	vol := newVolume(runtime)
+	vol.config.Name = "vol1"
+	vol.config.Driver = define.VolumeDriverLocal
+	vol.config.MountPoint = dataDir
+	vol.config.LockID = volLock.ID()
+	vol.lock = volLock
+	vol.valid = true
+	require.NoError(t, state.AddVolume(vol))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "a"), make([]byte, 1000), 0o644))
+	usage, err := vol.DiskUsage()
+	require.NoError(t, err)
+	assert.Positive(t, usage.Size)
+	require.NotNil(t, vol.state.SizeCache, "size of unused volume is cached")
+
+	// Writes Podman does not know about are not seen until the size is
+	// marked dirty.
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "b"), make([]byte, 100000), 0o644))
+	cached, err := vol.DiskUsage()
+	require.NoError(t, err)
+	assert.Equal(t, usage.Size, cached.Size)
+	assert.True(t, usage.ComputedAt.Equal(cached.ComputedAt))
+	require.NoError(t, vol.invalidateSize())
+	updated, err := vol.DiskUsage()
+	require.NoError(t, err)
+	assert.Greater(t, updated.Size, usage.Size)
+
+	// The size of a volume used by a running container is not cached.
+	require.NoError(t, vol.invalidateSize())
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.config.NamedVolumes = []*ContainerNamedVolume{{Name: vol.Name(), Dest: "/data"}}
+	ctr.state.State = define.ContainerStateRunning
+	require.NoError(t, state.AddContainer(ctr))
+	_, err = vol.DiskUsage()
+	require.NoError(t, err)
+	assert.Nil(t, vol.state.SizeCache, "size of volume used by a running container is not cached")
+
+	ctr.state.State = define.ContainerStateExited
+	require.NoError(t, state.SaveContainer(ctr))
+	_, err = vol.DiskUsage()
+	require.NoError(t, err)
+	assert.NotNil(t, vol.state.SizeCache, "size is cached once the container exited")
+}
@@ -0,0 +1,260 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/storage/drivers/copy"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/sirupsen/logrus"
+)
+
+// volumeSnapshotsDir is the directory holding the snapshots of a local volume,
+// next to its _data directory.
+const volumeSnapshotsDir = "snapshots"
+
+// snapshotsPath returns the directory holding the snapshots of the volume.
+func (v *Volume) snapshotsPath() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, v.Name(), volumeSnapshotsDir)
+}
+
+// supportsSnapshots returns an error if the contents of the volume cannot be
+// snapshotted. Only unencrypted volumes of the local driver are supported,
+// the contents of other drivers are not managed by Podman.
+func (v *Volume) supportsSnapshots() error {
+	if v.UsesVolumeDriver() || v.config.Driver == define.VolumeDriverImage || v.usesShareDriver() {
+		return fmt.Errorf("volume %s uses the %s driver, snapshots are only supported by the local driver: %w", v.Name(), v.config.Driver, define.ErrNotImplemented)
+	}
+	// Snapshots are copies of the decrypted contents.
+	if v.config.EncryptionSecret != "" {
+		return fmt.Errorf("volume %s is encrypted, snapshots would store its contents unencrypted: %w", v.Name(), define.ErrNotImplemented)
+	}
+	return nil
+}
+
+// Snapshot takes a snapshot of the contents of the volume and records it. The
+// snapshot is named after the current time if name is empty. The files of the
+// snapshot are reflinks of the files of the volume if its file system
+// supports them, e.g. btrfs and XFS, so they share their blocks until either
+// copy is modified, and copies otherwise.
+func (v *Volume) Snapshot(name string) (*define.VolumeSnapshot, error) {
+	if err := v.supportsSnapshots(); err != nil {
+		return nil, err
+	}
+
+	created := time.Now()
+	if name == "" {
+		name = created.UTC().Format(volumeBackupTimeFormat)
+	} else if !define.NameRegex.MatchString(name) {
+		return nil, fmt.Errorf("snapshot name %q: %w", name, define.RegexError)
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return nil, define.ErrVolumeRemoved
+	}
+
+	snapshots, err := v.runtime.state.VolumeSnapshots(v.Name())
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snapshots {
+		if s.Name == name {
+			return nil, fmt.Errorf("volume %s already has a snapshot named %s: %w", v.Name(), name, define.ErrInvalidArg)
+		}
+	}
+
+	if err := v.mount(); err != nil {
+		return nil, fmt.Errorf("mounting volume %s: %w", v.Name(), err)
+	}
+	defer func() {
+		if err := v.unmount(false); err != nil {
+			logrus.Errorf("Unmounting volume %s: %v", v.Name(), err)
+		}
+	}()
+
+	if err := os.MkdirAll(v.snapshotsPath(), 0o700); err != nil {
+		return nil, fmt.Errorf("creating snapshot directory of volume %s: %w", v.Name(), err)
+	}
+	snapshot := &define.VolumeSnapshot{
+		Volume:  v.Name(),
+		Name:    name,
+		Path:    filepath.Join(v.snapshotsPath(), name),
+		Method:  define.VolumeSnapshotCopy,
+		Created: created,
+	}
+	// Reflinks only work within a file system, volumes mounted from a
+	// device or tmpfs are always copied.
+	if !v.needsMount() && reflinkSupported(v.snapshotsPath()) {
+		snapshot.Method = define.VolumeSnapshotReflink
+	}
+
+	if err := copy.DirCopy(v.config.MountPoint, snapshot.Path, copy.Content, true); err != nil {
+		if err := os.RemoveAll(snapshot.Path); err != nil {
+			logrus.Errorf("Removing incomplete snapshot %s of volume %s: %v", name, v.Name(), err)
+		}
+		return nil, fmt.Errorf("taking snapshot %s of volume %s: %w", name, v.Name(), err)
+	}
+	if snapshot.Size, err = directory.Size(snapshot.Path); err != nil {
+		logrus.Warnf("Computing size of snapshot %s of volume %s: %v", name, v.Name(), err)
+	}
+
+	if err := v.runtime.state.AddVolumeSnapshot(snapshot); err != nil {
+		if err := os.RemoveAll(snapshot.Path); err != nil {
+			logrus.Errorf("Removing snapshot %s of volume %s: %v", name, v.Name(), err)
+		}
+		return nil, err
+	}
+	logrus.Debugf("Took %s snapshot %s of volume %s", snapshot.Method, name, v.Name())
+	return snapshot, nil
+}
+
+// Snapshots returns the snapshots of the volume, oldest first.
+func (v *Volume) Snapshots() ([]*define.VolumeSnapshot, error) {
+	if !v.valid {
+		return nil, define.ErrVolumeRemoved
+	}
+	return v.runtime.state.VolumeSnapshots(v.Name())
+}
+
+// VolumeSnapshots returns the snapshots of all volumes, oldest first.
+func (r *Runtime) VolumeSnapshots() ([]*define.VolumeSnapshot, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.VolumeSnapshots("")
+}
+
+// lookupSnapshot returns the snapshot of the volume with the given name or ID.
+func (v *Volume) lookupSnapshot(nameOrID string) (*define.VolumeSnapshot, error) {
+	snapshots, err := v.runtime.state.VolumeSnapshots(v.Name())
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snapshots {
+		if s.Name == nameOrID {
+			return s, nil
+		}
+	}
+	if id, err := strconv.ParseInt(nameOrID, 10, 64); err == nil {
+		for _, s := range snapshots {
+			if s.ID == id {
+				return s, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("snapshot %s of volume %s: %w", nameOrID, v.Name(), define.ErrNoSuchVolumeSnapshot)
+}
+
+// RestoreSnapshot replaces the contents of the volume with the snapshot with
+// the given name or ID. The volume must not be used by a running container.
+func (v *Volume) RestoreSnapshot(nameOrID string) (*define.VolumeSnapshot, error) {
+	if err := v.supportsSnapshots(); err != nil {
+		return nil, err
+	}
+	// Containers lock their volumes while holding their own lock, so
+	// they are checked before the volume is locked.
+	if err := v.checkNotRunning(); err != nil {
+		return nil, err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return nil, define.ErrVolumeRemoved
+	}
+
+	snapshot, err := v.lookupSnapshot(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.mount(); err != nil {
+		return nil, fmt.Errorf("mounting volume %s: %w", v.Name(), err)
+	}
+	defer func() {
+		if err := v.unmount(false); err != nil {
+			logrus.Errorf("Unmounting volume %s: %v", v.Name(), err)
+		}
+	}()
+
+	entries, err := os.ReadDir(v.config.MountPoint)
+	if err != nil {
+		return nil, fmt.Errorf("reading volume %s: %w", v.Name(), err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(v.config.MountPoint, entry.Name())); err != nil {
+			return nil, fmt.Errorf("clearing volume %s: %w", v.Name(), err)
+		}
+	}
+
+	if err := copy.DirCopy(snapshot.Path, v.config.MountPoint, copy.Content, true); err != nil {
+		return nil, fmt.Errorf("restoring snapshot %s of volume %s: %w", snapshot.Name, v.Name(), err)
+	}
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+	if err := v.invalidateSize(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// RemoveSnapshot removes the snapshot of the volume with the given name or ID.
+func (v *Volume) RemoveSnapshot(nameOrID string) (*define.VolumeSnapshot, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return nil, define.ErrVolumeRemoved
+	}
+
+	snapshot, err := v.lookupSnapshot(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(snapshot.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("removing snapshot %s of volume %s: %w", snapshot.Name, v.Name(), err)
+	}
+	if err := v.runtime.state.RemoveVolumeSnapshot(snapshot.ID); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// checkNotRunning returns an error if a running container uses the volume.
+func (v *Volume) checkNotRunning() error {
+	ctrs, err := v.VolumeInUse()
+	if err != nil {
+		return err
+	}
+	for _, id := range ctrs {
+		ctr, err := v.runtime.state.Container(id)
+		if err != nil {
+			// Containers of other namespaces cannot be inspected,
+			// assume they are running.
+			if errors.Is(err, define.ErrNSMismatch) {
+				return fmt.Errorf("volume %s is used by container %s of another namespace: %w", v.Name(), id, define.ErrVolumeBeingUsed)
+			}
+			return err
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			return fmt.Errorf("volume %s is used by running container %s: %w", v.Name(), ctr.ID(), define.ErrVolumeBeingUsed)
+		}
+	}
+	return nil
+}
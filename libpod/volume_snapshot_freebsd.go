@@ -0,0 +1,9 @@
+//go:build !remote
+
+package libpod
+
+// reflinkSupported returns false as FreeBSD has no reflinks, snapshots are
+// always copied.
+func reflinkSupported(string) bool {
+	return false
+}
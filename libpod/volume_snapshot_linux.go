@@ -0,0 +1,34 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// reflinkSupported returns whether the file system of dir supports cloning
+// files with the FICLONE ioctl.
+func reflinkSupported(dir string) bool {
+	src, err := os.CreateTemp(dir, ".reflink-")
+	if err != nil {
+		logrus.Debugf("Probing reflink support of %s: %v", dir, err)
+		return false
+	}
+	defer func() {
+		src.Close()
+		os.Remove(src.Name())
+	}()
+	dst, err := os.CreateTemp(dir, ".reflink-")
+	if err != nil {
+		logrus.Debugf("Probing reflink support of %s: %v", dir, err)
+		return false
+	}
+	defer func() {
+		dst.Close()
+		os.Remove(dst.Name())
+	}()
+	return unix.IoctlFileClone(int(dst.Fd()), int(src.Fd())) == nil
+}
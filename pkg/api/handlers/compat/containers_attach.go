@@ -101,7 +101,7 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 	// HTTPAttach will handle everything about the connection from here on
 	// (including closing it and writing errors to it).
 	hijackChan := make(chan bool, 1)
-	err = ctr.HTTPAttach(r, w, streams, detachKeys, nil, query.Stream, query.Logs, hijackChan)
+	err = ctr.HTTPAttach(r, w, streams, detachKeys, utils.GetShutdown(r), query.Stream, query.Logs, hijackChan)
 
 	if <-hijackChan {
 		// If connection was Hijacked, we have to signal it's being closed
@@ -30,6 +30,7 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 		Until      string `schema:"until"`
 		Timestamps bool   `schema:"timestamps"`
 		Tail       string `schema:"tail"`
+		Redact     bool   `schema:"redact"`
 	}{
 		Tail: "all",
 	}
@@ -89,6 +90,14 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 		Timestamps: query.Timestamps,
 	}
 
+	var redactor *logs.Redactor
+	if query.Redact {
+		if redactor, err = ctnr.LogRedactor(); err != nil {
+			utils.InternalServerError(w, fmt.Errorf("failed to obtain logs for Container '%s': %w", name, err))
+			return
+		}
+	}
+
 	var wg sync.WaitGroup
 	options.WaitGroup = &wg
 
@@ -150,6 +159,9 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 			frame.WriteString(" ")
 		}
 
+		if redactor != nil {
+			line.Msg = redactor.Redact(line.Msg)
+		}
 		frame.WriteString(line.Msg)
 		if !line.Partial() {
 			frame.WriteString("\n")
@@ -33,7 +33,8 @@ func PruneContainers(w http.ResponseWriter, r *http.Request) {
 		filterFuncs = append(filterFuncs, generatedFunc)
 	}
 
-	report, err := PruneContainersHelper(r, filterFuncs)
+	stateFilters := filters.GeneratePruneContainerStateFilters(*filtersMap)
+	report, err := PruneContainersHelper(r, stateFilters, filterFuncs)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
@@ -66,10 +67,10 @@ func PruneContainers(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, payload)
 }
 
-func PruneContainersHelper(r *http.Request, filterFuncs []libpod.ContainerFilter) ([]*reports.PruneReport, error) {
+func PruneContainersHelper(r *http.Request, stateFilters *libpod.StateContainerFilters, filterFuncs []libpod.ContainerFilter) ([]*reports.PruneReport, error) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 
-	report, err := runtime.PruneContainers(filterFuncs)
+	report, err := runtime.PruneContainersFiltered(stateFilters, filterFuncs)
 	if err != nil {
 		return nil, err
 	}
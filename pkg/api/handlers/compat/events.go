@@ -1,6 +1,8 @@
 package compat
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,6 +12,7 @@ import (
 	api "github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/util"
+	dockerEvents "github.com/docker/docker/api/types/events"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/sirupsen/logrus"
 )
@@ -48,6 +51,10 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	}
 	eventChannel := make(chan *events.Event)
 	errorChannel := make(chan error)
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	shutdown := utils.GetShutdown(r)
+	draining := false
 
 	// Start reading events.
 	go func() {
@@ -59,7 +66,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 			Since:        query.Since,
 			Until:        query.Until,
 		}
-		errorChannel <- runtime.Events(r.Context(), readOpts)
+		errorChannel <- runtime.Events(ctx, readOpts)
 	}()
 
 	flush := func() {}
@@ -82,7 +89,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	for {
 		select {
 		case err := <-errorChannel:
-			if err != nil {
+			if err != nil && !(draining && errors.Is(err, context.Canceled)) {
 				utils.InternalServerError(w, err)
 				wroteContent = true
 			}
@@ -95,14 +102,8 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 			e := entities.ConvertToEntitiesEvent(*evt)
 			// Some events differ between Libpod and Docker endpoints.
 			// Handle these differences for Docker-compat.
-			if !utils.IsLibpodRequest(r) && e.Type == "image" && e.Status == "remove" {
-				e.Status = "delete"
-				e.Action = "delete"
-			}
-			if !utils.IsLibpodRequest(r) && e.Status == "died" {
-				e.Status = "die"
-				e.Action = "die"
-				e.Actor.Attributes["exitCode"] = e.Actor.Attributes["containerExitCode"]
+			if !utils.IsLibpodRequest(r) && !toDockerEvent(e) {
+				continue
 			}
 
 			if err := coder.Encode(e); err != nil {
@@ -110,8 +111,41 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 			}
 			wroteContent = true
 			flush()
+		case <-shutdown:
+			// Stop reading and send the events already read before
+			// ending the stream.
+			logrus.Debugf("API service shutting down, ending events stream")
+			shutdown = nil
+			draining = true
+			cancel()
 		case <-r.Context().Done():
 			return
 		}
 	}
 }
+
+// toDockerEvent changes e to the event Docker sends for it, and returns false
+// when Docker sends no event for it: Docker only reports changes of the health
+// status of containers, not every healthcheck.
+func toDockerEvent(e *entities.Event) bool {
+	switch {
+	case e.Type == "image" && e.Status == events.Remove.String():
+		e.Status = "delete"
+		e.Action = "delete"
+	case e.Status == events.Exited.String():
+		e.Status = "die"
+		e.Action = "die"
+		e.Actor.Attributes["exitCode"] = e.Actor.Attributes["containerExitCode"]
+	case e.Status == events.ExecDied.String():
+		e.Status = "exec_die"
+		e.Action = "exec_die"
+		e.Actor.Attributes["exitCode"] = e.Actor.Attributes["containerExitCode"]
+	case e.Status == events.HealthStatus.String():
+		if previous, ok := e.Actor.Attributes[events.PreviousHealthStatusAttribute]; ok && previous == e.HealthStatus {
+			return false
+		}
+		e.Status = fmt.Sprintf("%s: %s", events.HealthStatus, e.HealthStatus)
+		e.Action = dockerEvents.Action(e.Status)
+	}
+	return true
+}
@@ -59,6 +59,7 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 	libpodConfig.WorkDir = input.WorkingDir
 	libpodConfig.Privileged = input.Privileged
 	libpodConfig.User = input.User
+	libpodConfig.ToolsImage = input.ToolsImage
 
 	if input.Tty {
 		util.ExecAddTERM(ctr.Env(), libpodConfig.Environment)
@@ -191,7 +192,7 @@ func ExecStartHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	hijackChan := make(chan bool, 1)
-	err = sessionCtr.ExecHTTPStartAndAttach(sessionID, r, w, nil, nil, nil, hijackChan, size)
+	err = sessionCtr.ExecHTTPStartAndAttach(sessionID, r, w, nil, nil, utils.GetShutdown(r), hijackChan, size)
 
 	if <-hijackChan {
 		// If connection was Hijacked, we have to signal it's being closed
@@ -37,13 +37,10 @@ func ListSecrets(w http.ResponseWriter, r *http.Request) {
 		utils.WriteResponse(w, http.StatusOK, reports)
 		return
 	}
-	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReports := make([]entities.SecretInfoReportCompat, 0, len(reports))
 	for _, report := range reports {
 		compatRep := entities.SecretInfoReportCompat{
 			SecretInfoReport: *report,
-			Version:          entities.SecretVersion{Index: 1},
 		}
 		compatReports = append(compatReports, compatRep)
 	}
@@ -86,11 +83,8 @@ func InspectSecret(w http.ResponseWriter, r *http.Request) {
 		utils.WriteResponse(w, http.StatusOK, reports[0])
 		return
 	}
-	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReport := entities.SecretInfoReportCompat{
 		SecretInfoReport: *reports[0],
-		Version:          entities.SecretVersion{Index: 1},
 	}
 	utils.WriteResponse(w, http.StatusOK, compatReport)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
@@ -16,6 +17,7 @@ import (
 	api "github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/infra/abi"
+	"github.com/containers/podman/v5/pkg/inspect"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/gorilla/schema"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -123,7 +125,9 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 func GetContainer(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
-		Size bool `schema:"size"`
+		Size            bool     `schema:"size"`
+		Fields          []string `schema:"fields"`
+		EffectiveConfig bool     `schema:"effectiveConfig"`
 	}{
 		// override any golang type defaults
 	}
@@ -139,16 +143,29 @@ func GetContainer(w http.ResponseWriter, r *http.Request) {
 		utils.ContainerNotFound(w, name, err)
 		return
 	}
-	data, err := container.Inspect(query.Size)
+	fields := inspect.SplitFields(query.Fields)
+	data, err := container.InspectFields(query.Size, fields)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
+	if query.EffectiveConfig {
+		data.EffectiveConfig = container.EffectiveConfig()
+	}
 	// if client request old v4 payload we should return v4 compatible json
-	if _, err := utils.SupportedVersion(r, ">=5.0.0"); err != nil {
+	if _, err := utils.SupportedVersion(r, ">=5.0.0"); err != nil && data.Config != nil {
 		data.Config.V4PodmanCompatMarshal = true
 	}
 
+	if len(fields) > 0 {
+		selected, err := inspect.SelectFields(data, fields)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, selected)
+		return
+	}
 	utils.WriteResponse(w, http.StatusOK, data)
 }
 
@@ -225,6 +242,7 @@ func Checkpoint(w http.ResponseWriter, r *http.Request) {
 		PrintStats     bool   `schema:"printStats"`
 		PreCheckpoint  bool   `schema:"preCheckpoint"`
 		WithPrevious   bool   `schema:"withPrevious"`
+		PreDumpChain   int    `schema:"preDumpChain"`
 		FileLocks      bool   `schema:"fileLocks"`
 		CreateImage    string `schema:"createImage"`
 	}{
@@ -251,6 +269,7 @@ func Checkpoint(w http.ResponseWriter, r *http.Request) {
 		PrintStats:     query.PrintStats,
 		PreCheckPoint:  query.PreCheckpoint,
 		WithPrevious:   query.WithPrevious,
+		PreDumpChain:   query.PreDumpChain,
 		FileLocks:      query.FileLocks,
 		CreateImage:    query.CreateImage,
 	}
@@ -267,6 +286,8 @@ func Checkpoint(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		options.Export = f.Name()
+		// The archive is streamed to the client and removed.
+		options.SkipRecord = true
 	}
 
 	reports, err := containerEngine.ContainerCheckpoint(r.Context(), names, options)
@@ -404,6 +425,78 @@ func InitContainer(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
 
+func EmitContainerEvent(w http.ResponseWriter, r *http.Request) {
+	name := utils.GetName(r)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Type    string `schema:"type"`
+		Message string `schema:"message"`
+	}{
+		Type: "custom",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := ctr.EmitCustomEvent(query.Type, query.Message); err != nil {
+		if errors.Is(err, define.RegexError) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+// StatsHistory returns the resource usage samples of a container recorded by
+// the stats collector of the service.
+func StatsHistory(w http.ResponseWriter, r *http.Request) {
+	name := utils.GetName(r)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Since string `schema:"since"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	var since time.Time
+	if query.Since != "" {
+		var err error
+		since, err = util.ParseInputTime(query.Since, true)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid since %q: %w", query.Since, err))
+			return
+		}
+	}
+
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	history, err := ctr.StatsHistory(since)
+	if err != nil {
+		if errors.Is(err, define.ErrNotImplemented) {
+			utils.Error(w, http.StatusNotImplemented, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, history)
+}
+
 func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
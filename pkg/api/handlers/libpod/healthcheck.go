@@ -1,6 +1,7 @@
 package libpod
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/containers/podman/v5/libpod"
@@ -40,3 +41,24 @@ func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+// HealthCheckHistory returns the recorded healthcheck runs of a container.
+func HealthCheckHistory(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	history, err := ctr.HealthCheckHistory()
+	if err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusConflict, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, history)
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/buildah"
 	"github.com/containers/common/libimage"
@@ -22,6 +23,7 @@ import (
 	"github.com/containers/podman/v5/pkg/api/handlers"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
+	"github.com/containers/podman/v5/pkg/auth"
 	"github.com/containers/podman/v5/pkg/bindings/images"
 	"github.com/containers/podman/v5/pkg/channel"
 	"github.com/containers/podman/v5/pkg/domain/entities"
@@ -95,6 +97,29 @@ func ImageTree(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
+// ImageProvenance returns the recorded pulls of an image and all other pulls
+// from the sources it was pulled from.
+func ImageProvenance(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+
+	img, _, err := runtime.LibimageRuntime().LookupImage(name, nil)
+	if err != nil {
+		if errors.Is(err, storage.ErrImageUnknown) {
+			utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find image %s: %w", name, err))
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	provenance, err := runtime.ImageProvenance(img.ID())
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to get provenance of image %s: %w", name, err))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, provenance)
+}
+
 func GetImage(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	newImage, err := utils.GetImage(r, name)
@@ -755,3 +780,81 @@ func ImageResolve(w http.ResponseWriter, r *http.Request) {
 	report := handlers.LibpodImagesResolveReport{Names: names}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+// ImagesWatch checks images for new digests on their registry and pulls them,
+// streaming a report for each pulled image and each failed check until the
+// client disconnects.
+func ImagesWatch(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		AutoRestart bool     `schema:"autoRestart"`
+		Images      []string `schema:"images"`
+		Interval    string   `schema:"interval"`
+		Rollback    bool     `schema:"rollback"`
+		TLSVerify   bool     `schema:"tlsVerify"`
+	}{
+		Rollback:  true,
+		TLSVerify: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.ImageWatchOptions{
+		AutoRestart: query.AutoRestart,
+		Images:      query.Images,
+		Rollback:    query.Rollback,
+	}
+	if query.Interval != "" {
+		interval, err := time.ParseDuration(query.Interval)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid interval: %w", err))
+			return
+		}
+		options.Interval = interval
+	}
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+
+	_, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+	options.Authfile = authfile
+
+	// Watching stops when the connection is closed.
+	imageEngine := abi.ImageEngine{Libpod: runtime}
+	watchReports, err := imageEngine.Watch(r.Context(), options)
+	if err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush := func() {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+	flush()
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	for report := range watchReports {
+		if err := coder.Encode(report); err != nil {
+			logrus.Errorf("Unable to encode image watch report: %v", err)
+			return
+		}
+		flush()
+	}
+}
@@ -29,14 +29,15 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
-		AllTags    bool   `schema:"allTags"`
-		CompatMode bool   `schema:"compatMode"`
-		PullPolicy string `schema:"policy"`
-		Quiet      bool   `schema:"quiet"`
-		Reference  string `schema:"reference"`
-		Retry      uint   `schema:"retry"`
-		RetryDelay string `schema:"retrydelay"`
-		TLSVerify  bool   `schema:"tlsVerify"`
+		AcceptNewDigest bool   `schema:"acceptNewDigest"`
+		AllTags         bool   `schema:"allTags"`
+		CompatMode      bool   `schema:"compatMode"`
+		PullPolicy      string `schema:"policy"`
+		Quiet           bool   `schema:"quiet"`
+		Reference       string `schema:"reference"`
+		Retry           uint   `schema:"retry"`
+		RetryDelay      string `schema:"retrydelay"`
+		TLSVerify       bool   `schema:"tlsVerify"`
 		// Platform fields below:
 		Arch    string `schema:"Arch"`
 		OS      string `schema:"OS"`
@@ -113,7 +114,10 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 
 	// Let's keep thing simple when running in quiet mode and pull directly.
 	if query.Quiet {
-		images, err := runtime.LibimageRuntime().Pull(r.Context(), query.Reference, pullPolicy, pullOptions)
+		images, err := utils.PullWithPolicy(r.Context(), runtime, query.Reference, pullPolicy, pullOptions)
+		if err == nil {
+			err = utils.CheckImageDigestPins(runtime, query.Reference, images, pullOptions.AllTags, query.AcceptNewDigest)
+		}
 		var report entities.ImagePullReport
 		if err != nil {
 			report.Error = err.Error()
@@ -128,7 +132,7 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if query.CompatMode {
-		utils.CompatPull(r.Context(), w, runtime, query.Reference, pullPolicy, pullOptions)
+		utils.CompatPull(r.Context(), w, runtime, query.Reference, pullPolicy, pullOptions, query.AcceptNewDigest)
 		return
 	}
 
@@ -141,7 +145,10 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 	runCtx, cancel := context.WithCancel(r.Context())
 	go func() {
 		defer cancel()
-		pulledImages, pullError = runtime.LibimageRuntime().Pull(runCtx, query.Reference, pullPolicy, pullOptions)
+		pulledImages, pullError = utils.PullWithPolicy(runCtx, runtime, query.Reference, pullPolicy, pullOptions)
+		if pullError == nil {
+			pullError = utils.CheckImageDigestPins(runtime, query.Reference, pulledImages, pullOptions.AllTags, query.AcceptNewDigest)
+		}
 	}()
 
 	flush := func() {
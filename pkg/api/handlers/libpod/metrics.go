@@ -0,0 +1,181 @@
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v5/pkg/api/types"
+	"github.com/sirupsen/logrus"
+)
+
+// metricsContentType is the content type of the Prometheus text exposition
+// format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricsContainerStates are the container states always reported by
+// podman_containers, so their series exist while no container is in them.
+var metricsContainerStates = []define.ContainerStatus{
+	define.ContainerStateCreated,
+	define.ContainerStateRunning,
+	define.ContainerStatePaused,
+	define.ContainerStateStopping,
+	define.ContainerStateStopped,
+	define.ContainerStateExited,
+	define.ContainerStateRemoving,
+}
+
+// metricsWriter writes metrics in the Prometheus text exposition format.
+type metricsWriter struct {
+	bytes.Buffer
+}
+
+// family writes the help and type of a metric.
+func (w *metricsWriter) family(name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// sample writes a sample of a metric with the given label names and values,
+// alternating.
+func (w *metricsWriter) sample(name string, value float64, labels ...string) {
+	w.WriteString(name)
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1]))
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	w.WriteByte('\n')
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+// writeMetrics writes the runtime metrics in the Prometheus text exposition
+// format.
+func writeMetrics(w *metricsWriter, metrics *define.RuntimeMetrics) {
+	w.family("podman_containers", "gauge", "Number of containers by state.")
+	states := make(map[string]int, len(metrics.ContainerStates))
+	for state, count := range metrics.ContainerStates {
+		states[state] = count
+	}
+	for _, state := range metricsContainerStates {
+		if _, ok := states[state.String()]; !ok {
+			states[state.String()] = 0
+		}
+	}
+	stateNames := make([]string, 0, len(states))
+	for state := range states {
+		stateNames = append(stateNames, state)
+	}
+	sort.Strings(stateNames)
+	for _, state := range stateNames {
+		w.sample("podman_containers", float64(states[state]), "state", state)
+	}
+
+	w.family("podman_pods", "gauge", "Number of pods.")
+	w.sample("podman_pods", float64(metrics.Pods))
+	w.family("podman_volumes", "gauge", "Number of volumes.")
+	w.sample("podman_volumes", float64(metrics.Volumes))
+	w.family("podman_images", "gauge", "Number of images in the local storage.")
+	w.sample("podman_images", float64(metrics.Images))
+	w.family("podman_storage_layers_bytes", "gauge", "Size of all layers in the local storage.")
+	w.sample("podman_storage_layers_bytes", float64(metrics.LayersSize))
+
+	ctrs := append([]*define.ContainerStats(nil), metrics.Containers...)
+	sort.Slice(ctrs, func(i, j int) bool { return ctrs[i].Name < ctrs[j].Name })
+	type containerMetric struct {
+		name, metricType, help string
+		value                  func(*define.ContainerStats) float64
+	}
+	for _, metric := range []containerMetric{
+		{"podman_container_cpu_seconds_total", "counter", "CPU time consumed by the container.",
+			func(s *define.ContainerStats) float64 { return float64(s.CPUNano) / 1e9 }},
+		{"podman_container_memory_usage_bytes", "gauge", "Memory used by the container.",
+			func(s *define.ContainerStats) float64 { return float64(s.MemUsage) }},
+		{"podman_container_memory_limit_bytes", "gauge", "Memory limit of the container.",
+			func(s *define.ContainerStats) float64 { return float64(s.MemLimit) }},
+		{"podman_container_block_read_bytes_total", "counter", "Bytes read from block devices by the container.",
+			func(s *define.ContainerStats) float64 { return float64(s.BlockInput) }},
+		{"podman_container_block_write_bytes_total", "counter", "Bytes written to block devices by the container.",
+			func(s *define.ContainerStats) float64 { return float64(s.BlockOutput) }},
+		{"podman_container_pids", "gauge", "Number of processes of the container.",
+			func(s *define.ContainerStats) float64 { return float64(s.PIDs) }},
+	} {
+		w.family(metric.name, metric.metricType, metric.help)
+		for _, ctr := range ctrs {
+			w.sample(metric.name, metric.value(ctr), "id", ctr.ContainerID, "name", ctr.Name)
+		}
+	}
+	for _, metric := range []struct {
+		name, help string
+		value      func(define.ContainerNetworkStats) uint64
+	}{
+		{"podman_container_network_receive_bytes_total", "Bytes received by the network interface of the container.",
+			func(s define.ContainerNetworkStats) uint64 { return s.RxBytes }},
+		{"podman_container_network_transmit_bytes_total", "Bytes transmitted by the network interface of the container.",
+			func(s define.ContainerNetworkStats) uint64 { return s.TxBytes }},
+	} {
+		w.family(metric.name, "counter", metric.help)
+		for _, ctr := range ctrs {
+			interfaces := make([]string, 0, len(ctr.Network))
+			for iface := range ctr.Network {
+				interfaces = append(interfaces, iface)
+			}
+			sort.Strings(interfaces)
+			for _, iface := range interfaces {
+				w.sample(metric.name, float64(metric.value(ctr.Network[iface])), "id", ctr.ContainerID, "name", ctr.Name, "interface", iface)
+			}
+		}
+	}
+
+	w.family("podman_state_operation_duration_seconds", "histogram", "Latency of state database operations.")
+	for _, op := range metrics.StateOperations {
+		for i, bound := range define.StateLatencyBuckets {
+			w.sample("podman_state_operation_duration_seconds_bucket", float64(op.Buckets[i]), "operation", op.Operation, "le", strconv.FormatFloat(bound, 'g', -1, 64))
+		}
+		w.sample("podman_state_operation_duration_seconds_bucket", float64(op.Count), "operation", op.Operation, "le", "+Inf")
+		w.sample("podman_state_operation_duration_seconds_sum", op.Sum, "operation", op.Operation)
+		w.sample("podman_state_operation_duration_seconds_count", float64(op.Count), "operation", op.Operation)
+	}
+
+	w.family("podman_events_total", "counter", "Number of events written by the service.")
+	for _, count := range metrics.Events {
+		w.sample("podman_events_total", float64(count.Count), "type", count.Type, "status", count.Status)
+	}
+}
+
+// Metrics exposes the metrics of the service in the Prometheus text
+// exposition format.
+func Metrics(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	metrics, err := runtime.Metrics()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	out := new(metricsWriter)
+	writeMetrics(out, metrics)
+
+	w.Header().Set("Content-Type", metricsContentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := out.WriteTo(w); err != nil {
+		logrus.Errorf("Writing metrics: %v", err)
+	}
+}
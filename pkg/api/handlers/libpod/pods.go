@@ -134,7 +134,8 @@ func PodStop(w http.ResponseWriter, r *http.Request) {
 		responses map[string]error
 	)
 	query := struct {
-		Timeout int `schema:"t"`
+		Timeout  int  `schema:"t"`
+		Parallel uint `schema:"parallel"`
 	}{
 		// override any golang type defaults
 	}
@@ -160,11 +161,11 @@ func PodStop(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	timeout := -1
 	if query.Timeout > 0 {
-		responses, stopError = pod.StopWithTimeout(r.Context(), false, query.Timeout)
-	} else {
-		responses, stopError = pod.Stop(r.Context(), false)
+		timeout = query.Timeout
 	}
+	responses, stopError = pod.StopParallel(r.Context(), false, timeout, query.Parallel)
 	if stopError != nil && !errors.Is(stopError, define.ErrPodPartialFail) {
 		utils.Error(w, http.StatusInternalServerError, err)
 		return
@@ -194,6 +195,16 @@ func PodStop(w http.ResponseWriter, r *http.Request) {
 
 func PodStart(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Parallel uint `schema:"parallel"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
 	name := utils.GetName(r)
 	pod, err := runtime.LookupPod(name)
 	if err != nil {
@@ -210,7 +221,7 @@ func PodStart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responses, err := pod.Start(r.Context())
+	responses, err := pod.StartParallel(r.Context(), query.Parallel)
 	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
 		utils.InternalServerError(w, err)
 		return
@@ -383,6 +394,36 @@ func PodUnpause(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, code, &report)
 }
 
+func PodUpdate(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		utils.PodNotFound(w, name, err)
+		return
+	}
+
+	options := entities.PodUpdateOptions{}
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("decode(): %w", err))
+		return
+	}
+	ports, err := generate.ParsePortMapping(options.PortMappings, nil)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := pod.UpdatePortMappings(ports); err != nil {
+		if errors.Is(err, define.ErrCtrStateInvalid) || errors.Is(err, define.ErrNetworkModeInvalid) || errors.Is(err, define.ErrNoSuchCtr) {
+			utils.Error(w, http.StatusConflict, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, pod.ID())
+}
+
 func PodTop(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
@@ -1,19 +1,28 @@
 package libpod
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/infra/abi"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/gorilla/schema"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sirupsen/logrus"
 )
 
+// stateChangesInterval is how often a stream of state changes polls the
+// database.
+const stateChangesInterval = time.Second
+
 // SystemPrune removes unused data
 func SystemPrune(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
@@ -67,6 +76,17 @@ func DiskUsage(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, response)
 }
 
+func SystemGraph(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	response, err := ic.SystemGraph(r.Context())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, response)
+}
+
 func SystemCheck(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
@@ -109,3 +129,85 @@ func SystemCheck(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+// SystemChanges lists the changes of containers, pods and volumes made by any
+// podman process, optionally streaming the changes made afterwards.
+func SystemChanges(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+
+	query := struct {
+		Since  int64 `schema:"since"`
+		Stream bool  `schema:"stream"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	changes, err := runtime.StateChanges(query.Since)
+	if err != nil {
+		if errors.Is(err, define.ErrNotImplemented) {
+			utils.Error(w, http.StatusNotImplemented, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	if !query.Stream {
+		utils.WriteResponse(w, http.StatusOK, changes)
+		return
+	}
+
+	flush := func() {}
+	if flusher, ok := w.(http.Flusher); ok {
+		flush = flusher.Flush
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush()
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	after := query.Since
+	for _, change := range changes {
+		if err := coder.Encode(change); err != nil {
+			logrus.Errorf("Unable to write json: %q", err)
+		}
+		after = change.ID
+	}
+	flush()
+
+	changeChannel := make(chan *define.StateChange)
+	errorChannel := make(chan error, 1)
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		errorChannel <- runtime.WatchStateChanges(ctx, after, stateChangesInterval, changeChannel)
+	}()
+
+	shutdown := utils.GetShutdown(r)
+	for {
+		select {
+		case err := <-errorChannel:
+			// The status was already sent, so the error can only
+			// end the stream.
+			if err != nil {
+				logrus.Errorf("Watching state changes: %v", err)
+			}
+			return
+		case change := <-changeChannel:
+			if err := coder.Encode(change); err != nil {
+				logrus.Errorf("Unable to write json: %q", err)
+			}
+			flush()
+		case <-shutdown:
+			logrus.Debugf("API service shutting down, ending state changes stream")
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
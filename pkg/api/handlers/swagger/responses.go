@@ -29,6 +29,13 @@ type history struct {
 	Body handlers.HistoryResponse
 }
 
+// Image Provenance
+// swagger:response
+type imageProvenance struct {
+	// in:body
+	Body []define.ImageProvenance
+}
+
 // Image Inspect
 // swagger:response
 type imageInspect struct {
@@ -64,6 +71,13 @@ type imagesPullResponseLibpod struct {
 	Body handlers.LibpodImagesPullReport
 }
 
+// Image Watch
+// swagger:response
+type imageWatchResponseLibpod struct {
+	// in:body
+	Body entities.ImageWatchReport
+}
+
 // Image Remove
 // swagger:response
 type imagesRemoveResponseLibpod struct {
@@ -182,6 +196,20 @@ type healthCheck struct {
 	Body define.HealthCheckResults
 }
 
+// Healthcheck History
+// swagger:response
+type healthCheckHistory struct {
+	// in:body
+	Body []define.HealthCheckHistoryEntry
+}
+
+// Container stats history
+// swagger:response
+type containerStatsHistory struct {
+	// in:body
+	Body []define.ContainerStats
+}
+
 // Version
 // swagger:response
 type versionResponse struct {
@@ -196,6 +224,20 @@ type systemCheckResponse struct {
 	Body entities.SystemCheckReport
 }
 
+// Dependency graph
+// swagger:response
+type systemGraphResponse struct {
+	// in:body
+	Body entities.SystemGraphReport
+}
+
+// State changes
+// swagger:response
+type systemChangesResponse struct {
+	// in:body
+	Body []define.StateChange
+}
+
 // Disk usage
 // swagger:response
 type systemDiskUsage struct {
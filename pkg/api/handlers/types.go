@@ -164,6 +164,9 @@ type HistoryResponse struct {
 
 type ExecCreateConfig struct {
 	docker.ExecConfig
+	// ToolsImage is an image mounted into the container for the duration
+	// of the exec session. Podman only.
+	ToolsImage string `json:"ToolsImage,omitempty"`
 }
 
 type ExecStartConfig struct {
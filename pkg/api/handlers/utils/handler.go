@@ -153,3 +153,11 @@ func GetDecoder(r *http.Request) *schema.Decoder {
 	}
 	return r.Context().Value(api.CompatDecoderKey).(*schema.Decoder)
 }
+
+// GetShutdown returns a channel which is closed when the API service starts
+// shutting down. Long-running handlers should finish early once it is closed.
+// The channel is nil if the request was not made to the API service.
+func GetShutdown(r *http.Request) <-chan bool {
+	shutdown, _ := r.Context().Value(api.ShutdownKey).(<-chan bool)
+	return shutdown
+}
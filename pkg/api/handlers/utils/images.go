@@ -114,13 +114,44 @@ type pullResult struct {
 	err    error
 }
 
-func CompatPull(ctx context.Context, w http.ResponseWriter, runtime *libpod.Runtime, reference string, pullPolicy config.PullPolicy, pullOptions *libimage.PullOptions) {
+// CheckImageDigestPins checks the digests of the images pulled by reference
+// against the digests pinned on first use. Digests are pinned per tag, so
+// pulls of all tags are not checked.
+func CheckImageDigestPins(runtime *libpod.Runtime, reference string, images []*libimage.Image, allTags, acceptNewDigest bool) error {
+	if allTags {
+		return nil
+	}
+	for _, img := range images {
+		if err := runtime.CheckImageDigestPin(reference, img, acceptNewDigest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PullWithPolicy pulls the images of reference if the image usage policy allows
+// pulling them.
+func PullWithPolicy(ctx context.Context, runtime *libpod.Runtime, reference string, pullPolicy config.PullPolicy, pullOptions *libimage.PullOptions) ([]*libimage.Image, error) {
+	if err := runtime.CheckImagePullPolicy(reference, nil); err != nil {
+		return nil, err
+	}
+	images, err := runtime.LibimageRuntime().Pull(ctx, reference, pullPolicy, pullOptions)
+	if err != nil {
+		return nil, err
+	}
+	return images, runtime.CheckImagePullPolicy(reference, images)
+}
+
+func CompatPull(ctx context.Context, w http.ResponseWriter, runtime *libpod.Runtime, reference string, pullPolicy config.PullPolicy, pullOptions *libimage.PullOptions, acceptNewDigest bool) {
 	progress := make(chan types.ProgressProperties)
 	pullOptions.Progress = progress
 
 	pullResChan := make(chan pullResult)
 	go func() {
-		pulledImages, err := runtime.LibimageRuntime().Pull(ctx, reference, pullPolicy, pullOptions)
+		pulledImages, err := PullWithPolicy(ctx, runtime, reference, pullPolicy, pullOptions)
+		if err == nil {
+			err = CheckImageDigestPins(runtime, reference, pulledImages, pullOptions.AllTags, acceptNewDigest)
+		}
 		pullResChan <- pullResult{images: pulledImages, err: err}
 	}()
 
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/types"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// auditResponseWriter records the status code of the response.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if wrapped, ok := w.ResponseWriter.(http.Hijacker); ok {
+		if w.status == 0 {
+			w.status = http.StatusSwitchingProtocols
+		}
+		return wrapped.Hijack()
+	}
+	return nil, nil, errors.New("ResponseWriter does not support hijacking")
+}
+
+func (w *auditResponseWriter) Flush() {
+	if wrapped, ok := w.ResponseWriter.(http.Flusher); ok {
+		wrapped.Flush()
+	}
+}
+
+// auditHandler records every modifying request, with the identity of the
+// client and the resulting status code, in the audit log of the runtime.
+func auditHandler(runtime *libpod.Runtime) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if readOnlyRequest(r) {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &auditResponseWriter{ResponseWriter: w}
+			h.ServeHTTP(recorder, r)
+
+			uid, identity := clientIdentity(r)
+			entry := &define.AuditEntry{
+				Source:    define.AuditSourceAPI,
+				UID:       uid,
+				Identity:  identity,
+				Operation: r.Method,
+				Detail:    r.URL.Path,
+				RequestID: r.Header.Get("X-Reference-Id"),
+				Status:    recorder.status,
+			}
+			if err := runtime.Audit(entry); err != nil {
+				logrus.Errorf("Writing %s %s to audit log: %v", r.Method, r.URL.Path, err)
+			}
+		})
+	}
+}
+
+// clientIdentity returns the UID of the client of r, or -1 if unknown, and a
+// description of its identity: the socket peer UID, the TLS client
+// certificate common name or the remote address.
+func clientIdentity(r *http.Request) (int, string) {
+	conn, ok := r.Context().Value(types.ConnKey).(net.Conn)
+	if ok {
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			if certs := tlsConn.ConnectionState().PeerCertificates; len(certs) > 0 {
+				return -1, "cn=" + certs[0].Subject.CommonName
+			}
+		} else if uid, ok := peerUID(conn); ok {
+			return int(uid), "uid=" + strconv.FormatUint(uint64(uid), 10)
+		}
+	}
+	return -1, "addr=" + r.RemoteAddr
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// errShuttingDown is returned to clients sending requests during shutdown.
+var errShuttingDown = errors.New("API service is shutting down")
+
+// drainHandler refuses requests arriving after the server started shutting
+// down, so only requests already in flight are finished.
+func (s *APIServer) drainHandler() mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if s.draining.Load() {
+				logrus.Infof("Rejecting %s %s, API service is shutting down", r.Method, r.URL.Path)
+				w.Header().Set("Connection", "close")
+				utils.Error(w, http.StatusServiceUnavailable, errShuttingDown)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	"github.com/containers/podman/v5/pkg/api/server/idle"
+	"github.com/containers/podman/v5/pkg/api/types"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainHandler(t *testing.T) {
+	s := &APIServer{}
+	h := s.drainHandler()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := serveFrom(h, "192.0.2.1:1234", http.MethodGet, "/_ping")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// New requests are refused once the server is shutting down, and the
+	// connection is not kept alive.
+	s.draining.Store(true)
+	rec = serveFrom(h, "192.0.2.1:1234", http.MethodGet, "/_ping")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "close", rec.Header().Get("Connection"))
+	assert.Contains(t, rec.Body.String(), "API service is shutting down")
+}
+
+func TestShutdownDrain(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	tracker := idle.NewTracker(UnlimitedServiceDuration)
+	s := &APIServer{
+		Server: http.Server{
+			ConnState: tracker.ConnState,
+			Handler:   router,
+		},
+		Listener:     listener,
+		idleTracker:  tracker,
+		drainTimeout: 30 * time.Second,
+		shutdown:     make(chan bool),
+	}
+	s.BaseContext = func(l net.Listener) context.Context {
+		return context.WithValue(context.Background(), types.ShutdownKey, (<-chan bool)(s.shutdown))
+	}
+	router.Use(s.drainHandler())
+
+	// A request in flight when the shutdown starts.
+	slowStarted := make(chan struct{})
+	slowRelease := make(chan struct{})
+	router.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(slowStarted)
+		<-slowRelease
+		_, _ = io.WriteString(w, "done")
+	})
+	// An attach session, which ends when the shutdown starts and closes
+	// its connection once its output is flushed.
+	hijackRelease := make(chan struct{})
+	router.HandleFunc("/hijack", func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		_, _ = buf.WriteString("HTTP/1.1 101 UPGRADED\r\n\r\n")
+		_ = buf.Flush()
+		<-utils.GetShutdown(r)
+		<-hijackRelease
+		_, _ = io.WriteString(conn, "bye")
+		conn.Close()
+		tracker.Close()
+	})
+
+	go func() {
+		_ = s.Server.Serve(listener)
+	}()
+	addr := "http://" + listener.Addr().String()
+
+	hijackConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer hijackConn.Close()
+	_, err = io.WriteString(hijackConn, "POST /hijack HTTP/1.1\r\nHost: podman\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+	require.NoError(t, err)
+	hijackReader := bufio.NewReader(hijackConn)
+	status, err := hijackReader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1 101 UPGRADED\r\n", status)
+	require.Equal(t, 1, tracker.HijackedConnections())
+
+	type response struct {
+		body string
+		err  error
+	}
+	slowDone := make(chan response, 1)
+	go func() {
+		resp, err := http.Get(addr + "/slow")
+		if err != nil {
+			slowDone <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		slowDone <- response{body: string(body), err: err}
+	}()
+	<-slowStarted
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		assert.NoError(t, s.Shutdown(true))
+	}()
+	require.Eventually(t, s.draining.Load, 5*time.Second, 10*time.Millisecond)
+
+	// The listener is closed, new connections are refused.
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err == nil {
+			conn.Close()
+		}
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The request in flight is finished.
+	close(slowRelease)
+	resp := <-slowDone
+	require.NoError(t, resp.err)
+	assert.Equal(t, "done", resp.body)
+
+	// The shutdown waits for the hijacked connection to be closed.
+	select {
+	case <-shutdownDone:
+		t.Fatal("shutdown finished before the hijacked connection was closed")
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(hijackRelease)
+	select {
+	case <-shutdownDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not finish after the hijacked connection was closed")
+	}
+	rest, err := io.ReadAll(hijackReader)
+	require.NoError(t, err)
+	assert.Equal(t, "\r\nbye", string(rest))
+	assert.Equal(t, 0, tracker.HijackedConnections())
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	"github.com/containers/podman/v5/pkg/api/types"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// Endpoint classes which can be rate limited or capped.
+const (
+	endpointClassBuild = "build"
+	endpointClassPull  = "pull"
+	endpointClassExec  = "exec"
+)
+
+// endpointClasses lists the classes accepted by the service options.
+var endpointClasses = []string{endpointClassBuild, endpointClassPull, endpointClassExec}
+
+// maxIdleClients is the number of clients whose limits are kept before the
+// limits of idle clients are dropped.
+const maxIdleClients = 1024
+
+// endpointLimit holds the limits of one endpoint class and the state of each
+// client.
+type endpointLimit struct {
+	perMinute   int
+	maxInFlight int
+
+	lock    sync.Mutex
+	clients map[string]*clientLimit
+}
+
+// clientLimit holds the state of the limits of one client.
+type clientLimit struct {
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+// client returns the state of the limits of the given client.
+func (l *endpointLimit) client(key string) *clientLimit {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if c, ok := l.clients[key]; ok {
+		return c
+	}
+	if len(l.clients) >= maxIdleClients {
+		maps.DeleteFunc(l.clients, func(_ string, c *clientLimit) bool {
+			return c.idle(l.perMinute)
+		})
+	}
+	c := new(clientLimit)
+	if l.perMinute > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(float64(l.perMinute)/60), l.perMinute)
+	}
+	if l.maxInFlight > 0 {
+		c.inFlight = make(chan struct{}, l.maxInFlight)
+	}
+	l.clients[key] = c
+	return c
+}
+
+// idle reports whether the client has no requests in flight and the full
+// burst of requests available, so that its state can be dropped.
+func (c *clientLimit) idle(perMinute int) bool {
+	return len(c.inFlight) == 0 && (c.limiter == nil || c.limiter.Tokens() >= float64(perMinute))
+}
+
+// limitHandler rejects requests to the build, pull and exec endpoints with
+// 429 Too Many Requests once a client exceeds the rate limit of their class,
+// given in requests per minute, or its number of requests in flight. Clients
+// are identified by their socket peer UID, or by their remote address for TCP
+// connections.
+func limitHandler(rateLimits, maxInFlight map[string]int) (mux.MiddlewareFunc, error) {
+	limits := make(map[string]*endpointLimit)
+	get := func(class string) (*endpointLimit, error) {
+		if !slices.Contains(endpointClasses, class) {
+			return nil, fmt.Errorf("invalid endpoint class %q, must be one of %s", class, strings.Join(endpointClasses, ", "))
+		}
+		l, ok := limits[class]
+		if !ok {
+			l = &endpointLimit{clients: make(map[string]*clientLimit)}
+			limits[class] = l
+		}
+		return l, nil
+	}
+
+	for class, perMinute := range rateLimits {
+		if perMinute <= 0 {
+			return nil, fmt.Errorf("rate limit of %s must be greater than 0", class)
+		}
+		l, err := get(class)
+		if err != nil {
+			return nil, err
+		}
+		l.perMinute = perMinute
+	}
+	for class, limit := range maxInFlight {
+		if limit <= 0 {
+			return nil, fmt.Errorf("maximum requests in flight of %s must be greater than 0", class)
+		}
+		l, err := get(class)
+		if err != nil {
+			return nil, err
+		}
+		l.maxInFlight = limit
+	}
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			class := endpointClass(r)
+			l, ok := limits[class]
+			if !ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			c := l.client(clientKey(r))
+			if c.limiter != nil {
+				res := c.limiter.Reserve()
+				if delay := res.Delay(); delay > 0 {
+					res.Cancel()
+					tooManyRequests(w, r, class, delay, "rate limit")
+					return
+				}
+			}
+			if c.inFlight != nil {
+				select {
+				case c.inFlight <- struct{}{}:
+					defer func() { <-c.inFlight }()
+				default:
+					tooManyRequests(w, r, class, time.Second, "maximum number of requests in flight")
+					return
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// tooManyRequests answers r with 429 and the number of seconds after which
+// the client may retry.
+func tooManyRequests(w http.ResponseWriter, r *http.Request, class string, retryAfter time.Duration, reason string) {
+	logrus.Infof("Rejecting %s %s: %s of %s endpoints reached", r.Method, r.URL.Path, reason, class)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	utils.Error(w, http.StatusTooManyRequests, fmt.Errorf("%s of %s endpoints reached, retry later", reason, class))
+}
+
+// clientKey identifies the client of r by the UID of the peer of a unix
+// socket, or by the host of the remote address.
+func clientKey(r *http.Request) string {
+	if conn, ok := r.Context().Value(types.ConnKey).(net.Conn); ok {
+		if uid, ok := peerUID(conn); ok {
+			return "uid:" + strconv.FormatUint(uint64(uid), 10)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
+
+// endpointClass returns the class of the endpoint r is routed to, or "" if
+// the endpoint is not subject to limits.
+func endpointClass(r *http.Request) string {
+	if r.Method != http.MethodPost {
+		return ""
+	}
+	path := r.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/build"):
+		return endpointClassBuild
+	case strings.HasSuffix(path, "/images/create"), strings.HasSuffix(path, "/images/pull"):
+		return endpointClassPull
+	case strings.HasSuffix(path, "/exec"), strings.Contains(path, "/exec/") && strings.HasSuffix(path, "/start"):
+		return endpointClassExec
+	}
+	return ""
+}
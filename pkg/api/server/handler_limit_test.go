@@ -0,0 +1,138 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointClass(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodPost, "/v1.41/build", endpointClassBuild},
+		{http.MethodPost, "/v5.0.0/libpod/build", endpointClassBuild},
+		{http.MethodPost, "/v1.41/images/create", endpointClassPull},
+		{http.MethodPost, "/v5.0.0/libpod/images/pull", endpointClassPull},
+		{http.MethodPost, "/v1.41/containers/ctr/exec", endpointClassExec},
+		{http.MethodPost, "/v5.0.0/libpod/containers/ctr/exec", endpointClassExec},
+		{http.MethodPost, "/v1.41/exec/123/start", endpointClassExec},
+		{http.MethodPost, "/v5.0.0/libpod/exec/123/start", endpointClassExec},
+		{http.MethodGet, "/v5.0.0/libpod/exec/123/json", ""},
+		{http.MethodPost, "/v5.0.0/libpod/exec/123/resize", ""},
+		{http.MethodPost, "/v5.0.0/libpod/containers/ctr/start", ""},
+		{http.MethodGet, "/v1.41/build", ""},
+		{http.MethodGet, "/v5.0.0/libpod/images/json", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		assert.Equal(t, tt.want, endpointClass(r), "%s %s", tt.method, tt.path)
+	}
+}
+
+func TestLimitHandlerInvalid(t *testing.T) {
+	_, err := limitHandler(map[string]int{"push": 1}, nil)
+	assert.ErrorContains(t, err, `invalid endpoint class "push", must be one of build, pull, exec`)
+	_, err = limitHandler(map[string]int{endpointClassBuild: 0}, nil)
+	assert.ErrorContains(t, err, "rate limit of build must be greater than 0")
+	_, err = limitHandler(nil, map[string]int{endpointClassExec: -1})
+	assert.ErrorContains(t, err, "maximum requests in flight of exec must be greater than 0")
+}
+
+func TestClientKey(t *testing.T) {
+	for remoteAddr, want := range map[string]string{
+		"192.0.2.1:1234":   "addr:192.0.2.1",
+		"[2001:db8::1]:80": "addr:2001:db8::1",
+		"@":                "addr:@",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/_ping", nil)
+		req.RemoteAddr = remoteAddr
+		assert.Equal(t, want, clientKey(req), remoteAddr)
+	}
+}
+
+func serveFrom(h http.Handler, remoteAddr, method, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLimitHandlerRateLimit(t *testing.T) {
+	mw, err := limitHandler(map[string]int{endpointClassPull: 2}, nil)
+	require.NoError(t, err)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	// The burst is accepted, the next request is rejected.
+	for i := 0; i < 2; i++ {
+		rec := serveFrom(h, "192.0.2.1:1234", http.MethodPost, "/v5.0.0/libpod/images/pull")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+	rec := serveFrom(h, "192.0.2.1:1235", http.MethodPost, "/v5.0.0/libpod/images/pull")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	// Two requests per minute refill one request every 30 seconds.
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "rate limit of pull endpoints reached, retry later")
+
+	// Other clients and other endpoints are not limited.
+	rec = serveFrom(h, "192.0.2.2:1234", http.MethodPost, "/v5.0.0/libpod/images/pull")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = serveFrom(h, "192.0.2.1:1234", http.MethodPost, "/v5.0.0/libpod/build")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = serveFrom(h, "192.0.2.1:1234", http.MethodGet, "/v5.0.0/libpod/images/json")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestLimitHandlerMaxInFlight(t *testing.T) {
+	mw, err := limitHandler(nil, map[string]int{endpointClassBuild: 1})
+	require.NoError(t, err)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("block") != "" {
+			started <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	done := make(chan int)
+	go func() {
+		done <- serveFrom(h, "192.0.2.1:1234", http.MethodPost, "/v5.0.0/libpod/build?block=1").Code
+	}()
+	<-started
+
+	rec := serveFrom(h, "192.0.2.1:1235", http.MethodPost, "/v5.0.0/libpod/build")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "maximum number of requests in flight of build endpoints reached")
+
+	rec = serveFrom(h, "192.0.2.2:1234", http.MethodPost, "/v5.0.0/libpod/build")
+	assert.Equal(t, http.StatusNoContent, rec.Code, "other client")
+
+	close(release)
+	assert.Equal(t, http.StatusNoContent, <-done)
+	rec = serveFrom(h, "192.0.2.1:1235", http.MethodPost, "/v5.0.0/libpod/build")
+	assert.Equal(t, http.StatusNoContent, rec.Code, "request finished")
+}
+
+func TestEndpointLimitDropsIdleClients(t *testing.T) {
+	l := &endpointLimit{perMinute: 1, maxInFlight: 1, clients: make(map[string]*clientLimit)}
+	busy := l.client("busy")
+	busy.inFlight <- struct{}{}
+	for i := 0; len(l.clients) < maxIdleClients; i++ {
+		l.client(fmt.Sprintf("client%d", i))
+	}
+	l.client("new")
+	assert.Len(t, l.clients, 2)
+	assert.Same(t, busy, l.client("busy"))
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/utils"
+	"github.com/containers/podman/v5/pkg/api/types"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// errViewerReadOnly is returned to viewer clients attempting a modification.
+var errViewerReadOnly = errors.New("client is restricted to read-only endpoints")
+
+// versionPrefix matches the version prefix of the path templates of the
+// versioned routes, see VersionedPath.
+var versionPrefix = regexp.MustCompile(`^/v\{version:[^}]*\}`)
+
+// viewerRoutes are the path templates, without their version prefix, of the
+// endpoints viewers may use. They inspect, list, or report the stats and the
+// events of objects. Endpoints streaming the content of containers and images,
+// such as archive and export, are not included.
+var viewerRoutes = []string{
+	"/_ping",
+	"/version",
+	"/info",
+	"/events",
+	"/system/df",
+	"/containers/json",
+	"/containers/{name}/json",
+	"/containers/{name}/stats",
+	"/exec/{id}/json",
+	"/images/json",
+	"/images/{name:.*}/json",
+	"/networks",
+	"/networks/{name}",
+	"/secrets",
+	"/secrets/{name}",
+	"/volumes",
+	"/volumes/{name}",
+	"/libpod/_ping",
+	"/libpod/version",
+	"/libpod/info",
+	"/libpod/events",
+	"/libpod/system/df",
+	"/libpod/containers/json",
+	"/libpod/containers/stats",
+	"/libpod/containers/{name}/exists",
+	"/libpod/containers/{name}/json",
+	"/libpod/containers/{name}/stats",
+	"/libpod/exec/{id}/json",
+	"/libpod/images/json",
+	"/libpod/images/{name:.*}/exists",
+	"/libpod/images/{name:.*}/json",
+	"/libpod/manifests/{name:.*}/exists",
+	"/libpod/manifests/{name:.*}/json",
+	"/libpod/networks/json",
+	"/libpod/networks/{name}",
+	"/libpod/networks/{name}/exists",
+	"/libpod/networks/{name}/json",
+	"/libpod/pods/json",
+	"/libpod/pods/stats",
+	"/libpod/pods/{name}/exists",
+	"/libpod/pods/{name}/json",
+	"/libpod/secrets/json",
+	"/libpod/secrets/{name}/exists",
+	"/libpod/secrets/{name}/json",
+	"/libpod/volumes/json",
+	"/libpod/volumes/{name}/exists",
+	"/libpod/volumes/{name}/json",
+}
+
+// viewerHandler restricts clients identified by their socket peer UID or by
+// the common name of their TLS client certificate to read-only endpoints.
+func viewerHandler(uids []uint32, cns []string) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !readOnlyRequest(r) && isViewer(r, uids, cns) {
+				logrus.Infof("Rejecting %s %s from viewer client", r.Method, r.URL.Path)
+				utils.Error(w, http.StatusForbidden, errViewerReadOnly)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isViewer reports whether the client of r has the viewer role. Clients are
+// identified as in the audit log, see clientIdentity.
+func isViewer(r *http.Request, uids []uint32, cns []string) bool {
+	conn, ok := r.Context().Value(types.ConnKey).(net.Conn)
+	if !ok {
+		return false
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		certs := tlsConn.ConnectionState().PeerCertificates
+		return len(certs) > 0 && slices.Contains(cns, certs[0].Subject.CommonName)
+	}
+	uid, ok := peerUID(conn)
+	return ok && slices.Contains(uids, uid)
+}
+
+// readOnlyRequest reports whether r is a GET or HEAD request to one of the
+// viewerRoutes. Revealing secret data with showsecret is excluded.
+func readOnlyRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	template = versionPrefix.ReplaceAllString(template, "")
+	if !slices.Contains(viewerRoutes, template) {
+		return false
+	}
+	return r.URL.Query().Get("showsecret") == ""
+}
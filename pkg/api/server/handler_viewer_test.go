@@ -0,0 +1,182 @@
+//go:build linux
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/api/types"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRouter returns a router with the API routes of most objects. The
+// handlers are replaced by a handler answering 204 once the given middlewares
+// passed.
+func newTestRouter(t *testing.T, mwf ...mux.MiddlewareFunc) *mux.Router {
+	s := &APIServer{}
+	router := mux.NewRouter().UseEncodedPath()
+	for _, fn := range []func(*mux.Router) error{
+		s.registerArchiveHandlers,
+		s.registerContainersHandlers,
+		s.registerEventsHandlers,
+		s.registerExecHandlers,
+		s.registerImagesHandlers,
+		s.registerInfoHandlers,
+		s.registerManifestHandlers,
+		s.registerPingHandlers,
+		s.registerPodsHandlers,
+		s.registerSecretHandlers,
+		s.registerVolumeHandlers,
+	} {
+		require.NoError(t, fn(router))
+	}
+	router.Use(mwf...)
+	router.Use(func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+	return router
+}
+
+// unixPeerConn returns the server end of a unix socket connection made by
+// this process.
+func unixPeerConn(t *testing.T) net.Conn {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "api.sock"))
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	client, err := net.Dial("unix", listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// tlsPeerConn returns the server end of a TLS connection made by a client
+// authenticating with a certificate with the common name cn.
+func tlsPeerConn(t *testing.T, cn string) net.Conn {
+	newCert := func(cn string) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			DNSNames:     []string{"localhost"},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() { serverConn.Close(); clientConn.Close() })
+	server := tls.Server(serverConn, &tls.Config{
+		Certificates: []tls.Certificate{newCert("localhost")},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+	client := tls.Client(clientConn, &tls.Config{
+		Certificates:       []tls.Certificate{newCert(cn)},
+		InsecureSkipVerify: true, //nolint:gosec // the server certificate is not under test
+	})
+	errCh := make(chan error, 1)
+	go func() { errCh <- client.Handshake() }()
+	require.NoError(t, server.Handshake())
+	require.NoError(t, <-errCh)
+	return server
+}
+
+func serveAs(router http.Handler, conn net.Conn, method, target string) int {
+	req := httptest.NewRequest(method, target, nil)
+	req = req.WithContext(context.WithValue(req.Context(), types.ConnKey, conn))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestViewerHandler(t *testing.T) {
+	conn := unixPeerConn(t)
+	router := newTestRouter(t, viewerHandler([]uint32{uint32(os.Getuid())}, nil))
+
+	allowed := []string{
+		"/_ping",
+		"/v1.41/containers/json",
+		"/v1.41/containers/ctr/json",
+		"/v1.41/containers/ctr/stats",
+		"/v1.41/events",
+		"/v1.41/images/docker.io/library/alpine/json",
+		"/v5.0.0/libpod/containers/stats",
+		"/v5.0.0/libpod/containers/ctr/exists",
+		"/v5.0.0/libpod/events",
+		"/v5.0.0/libpod/images/json",
+		"/v5.0.0/libpod/manifests/list/json",
+		"/v5.0.0/libpod/pods/json",
+		"/v5.0.0/libpod/secrets/secr/json",
+		"/v5.0.0/libpod/volumes/vol/json",
+	}
+	for _, target := range allowed {
+		assert.Equal(t, http.StatusNoContent, serveAs(router, conn, http.MethodGet, target), "GET %s", target)
+	}
+
+	rejected := []struct {
+		method string
+		target string
+	}{
+		{http.MethodGet, "/v1.41/containers/ctr/archive?path=/etc"},
+		{http.MethodHead, "/v1.41/containers/ctr/archive?path=/etc"},
+		{http.MethodGet, "/v1.41/containers/ctr/export"},
+		{http.MethodGet, "/v1.41/containers/ctr/logs"},
+		{http.MethodGet, "/v1.41/images/get?names=alpine"},
+		{http.MethodGet, "/v1.41/images/alpine/get"},
+		{http.MethodGet, "/v5.0.0/libpod/containers/ctr/archive?path=/etc"},
+		{http.MethodGet, "/v5.0.0/libpod/containers/ctr/export"},
+		{http.MethodGet, "/v5.0.0/libpod/images/export?references=alpine"},
+		{http.MethodGet, "/v5.0.0/libpod/images/alpine/get"},
+		{http.MethodGet, "/v5.0.0/libpod/secrets/secr/json?showsecret=true"},
+		{http.MethodPost, "/v5.0.0/libpod/containers/ctr/start"},
+		{http.MethodDelete, "/v5.0.0/libpod/containers/ctr"},
+	}
+	for _, req := range rejected {
+		assert.Equal(t, http.StatusForbidden, serveAs(router, conn, req.method, req.target), "%s %s", req.method, req.target)
+	}
+}
+
+func TestViewerHandlerOtherClients(t *testing.T) {
+	conn := unixPeerConn(t)
+	router := newTestRouter(t, viewerHandler([]uint32{uint32(os.Getuid()) + 1}, nil))
+
+	assert.Equal(t, http.StatusNoContent, serveAs(router, conn, http.MethodPost, "/v5.0.0/libpod/containers/ctr/start"))
+	assert.Equal(t, http.StatusNoContent, serveAs(router, conn, http.MethodGet, "/v5.0.0/libpod/containers/ctr/export"))
+	// Clients without socket peer credentials are never viewers.
+	assert.Equal(t, http.StatusNoContent, serveAs(router, nil, http.MethodPost, "/v5.0.0/libpod/containers/ctr/start"))
+}
+
+func TestViewerHandlerClientCN(t *testing.T) {
+	router := newTestRouter(t, viewerHandler(nil, []string{"dashboard"}))
+
+	viewer := tlsPeerConn(t, "dashboard")
+	assert.Equal(t, http.StatusNoContent, serveAs(router, viewer, http.MethodGet, "/v5.0.0/libpod/containers/json"))
+	assert.Equal(t, http.StatusForbidden, serveAs(router, viewer, http.MethodPost, "/v5.0.0/libpod/containers/ctr/start"))
+
+	other := tlsPeerConn(t, "ci")
+	assert.Equal(t, http.StatusNoContent, serveAs(router, other, http.MethodPost, "/v5.0.0/libpod/containers/ctr/start"))
+}
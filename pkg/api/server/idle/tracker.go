@@ -96,6 +96,14 @@ func (t *Tracker) ActiveConnections() int {
 	return len(t.managed) + t.hijacked
 }
 
+// HijackedConnections returns the number of StateHijacked connections not yet
+// closed by their handler
+func (t *Tracker) HijackedConnections() int {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.hijacked
+}
+
 // TotalConnections returns total number of connections made to this instance of the service
 func (t *Tracker) TotalConnections() int {
 	return t.total
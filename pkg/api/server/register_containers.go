@@ -906,11 +906,25 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    name: size
 	//    type: boolean
 	//    description: display filesystem usage
+	//  - in: query
+	//    name: fields
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: |
+	//      only compute and return the given fields, as dot-separated paths of keys like State.Status.
+	//      Expensive fields like the sizes and the graph driver data are skipped unless requested.
+	//  - in: query
+	//    name: effectiveConfig
+	//    type: boolean
+	//    description: include the containers.conf settings the container was created with
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/containerInspectResponseLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//     $ref: "#/responses/containerNotFound"
 	//   500:
@@ -1034,6 +1048,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: Add timestamps to every log line
 	//  - in: query
+	//    name: redact
+	//    type: boolean
+	//    default: false
+	//    description: Mask the values of the container's secrets and the matches of the log_redact_patterns of containers.conf. The stored logs are not modified.
+	//  - in: query
 	//    name: tail
 	//    type: string
 	//    description: Only return this number of log lines from the end of the logs
@@ -1153,6 +1172,38 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stats"), s.APIHandler(compat.StatsContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/stats/history libpod ContainerStatsHistoryLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Stats history of a container
+	// description: |
+	//   Return the resource usage samples of a container recorded by the stats collector of the service, oldest first.
+	//   The collector is enabled with the --stats-interval option of podman system service.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: since
+	//    type: string
+	//    description: only return the samples taken after this timestamp, or this duration before now (e.g. 1h)
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerStatsHistory"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	//   501:
+	//     description: the database backend does not support stats history
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stats/history"), s.APIHandler(libpod.StatsHistory)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/containers/stats libpod ContainersStatsAllLibpod
 	// ---
 	// tags:
@@ -1594,6 +1645,10 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    description: check out the container with previous criu image files in pre-dump. only works on runc 1.0-rc or higher
 	//  - in: query
+	//    name: preDumpChain
+	//    type: integer
+	//    description: number of iterative pre-dumps of the container's memory to take before checkpointing it, which are tracked and used automatically on restore
+	//  - in: query
 	//    name: fileLocks
 	//    type: boolean
 	//    description: checkpoint a container with filelocks
@@ -1743,6 +1798,39 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/init"), s.APIHandler(libpod.InitContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/events libpod ContainerEmitEventLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Emit a custom event
+	// description: Write a user-defined event for the container to the event stream.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: type
+	//    type: string
+	//    default: custom
+	//    description: user-defined type of the event, reported as its status
+	//  - in: query
+	//    name: message
+	//    type: string
+	//    description: message carried by the event
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/events"), s.APIHandler(libpod.EmitContainerEvent)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/rename libpod ContainerRenameLibpod
 	// ---
 	// tags:
@@ -237,6 +237,10 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//        WorkingDir:
 	//          type: string
 	//          description: The working directory for the exec process inside the container.
+	//        ToolsImage:
+	//          type: string
+	//          description: |
+	//           Image whose root filesystem is mounted read-only in the container for the duration of the exec session, with its binary directories appended to PATH. The mount point is given by the PODMAN_TOOLS environment variable of the exec process.
 	// produces:
 	// - application/json
 	// responses:
@@ -31,6 +31,32 @@ func (s *APIServer) registerHealthCheckHandlers(r *mux.Router) error {
 	//     description: container has no healthcheck or is not running
 	//   500:
 	//     $ref: '#/responses/internalError'
+	// swagger:operation GET /libpod/containers/{name}/healthcheck/history libpod ContainerHealthcheckHistoryLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Healthcheck history of a container
+	// description: |
+	//   Return the recorded healthcheck runs of a container, oldest first.
+	//   The number of runs kept is set by healthcheck_max_log_count in containers.conf.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/healthCheckHistory"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     description: container has no healthcheck
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/containers/{name:.*}/healthcheck/history"), s.APIHandler(libpod.HealthCheckHistory)).Methods(http.MethodGet)
 	r.Handle(VersionedPath("/libpod/containers/{name:.*}/healthcheck"), s.APIHandler(libpod.RunHealthCheck)).Methods(http.MethodGet)
 	return nil
 }
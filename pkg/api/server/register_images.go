@@ -865,6 +865,30 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/images/{name:.*}/history"), s.APIHandler(compat.HistoryImage)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/images/{name}/provenance libpod ImageProvenanceLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Image provenance
+	// description: |
+	//   Return the recorded pulls of an image, together with all other pulls from the sources the image was pulled from, oldest first.
+	//   This shows where an image came from and how the images behind its sources changed over time.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageProvenance"
+	//   404:
+	//     $ref: '#/responses/imageNotFound'
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/provenance"), s.APIHandler(libpod.ImageProvenance)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/json libpod ImageListLibpod
 	// ---
 	// tags:
@@ -1062,6 +1086,11 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     type: boolean
 	//     default: false
 	//   - in: query
+	//     name: acceptNewDigest
+	//     description: "Pin the digest of the pulled image even if it differs from the digest pinned for the tag on first use."
+	//     type: boolean
+	//     default: false
+	//   - in: query
 	//     name: Arch
 	//     description: Pull image for the specified architecture.
 	//     type: string
@@ -1179,6 +1208,58 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//      $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/images/search"), s.APIHandler(compat.SearchImages)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/images/watch libpod ImageWatchLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Watch images
+	// description: |
+	//   Check images for new digests on their registry at regular intervals and pull them. A report is streamed for
+	//   each pulled image and each failed check until the client disconnects.
+	// parameters:
+	//  - in: query
+	//    name: images
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: |
+	//      Images to watch, each optionally followed by `=` and its own interval between two checks
+	//      (e.g., `quay.io/image/name:tag=10m`). Defaults to the images of the containers with the registry
+	//      auto-update policy.
+	//  - in: query
+	//    name: interval
+	//    type: string
+	//    default: 5m
+	//    description: Interval between two checks of the images without their own interval, as a Go duration.
+	//  - in: query
+	//    name: autoRestart
+	//    type: boolean
+	//    default: false
+	//    description: Restart the systemd units of the containers with an auto-update policy using a pulled image.
+	//  - in: query
+	//    name: rollback
+	//    type: boolean
+	//    default: true
+	//    description: Restart the systemd units with the previous image if restarting them with the new image failed.
+	//  - in: query
+	//    name: tlsVerify
+	//    type: boolean
+	//    default: true
+	//    description: Require HTTPS and verify signatures when contacting registries.
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
+	//    type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageWatchResponseLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/watch"), s.APIHandler(libpod.ImagesWatch)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/{name}/get libpod ImageGetLibpod
 	// ---
 	// tags:
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v5/pkg/api/handlers/libpod"
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerMetricsHandlers(r *mux.Router) error {
+	// swagger:operation GET /libpod/metrics libpod SystemMetricsLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Prometheus metrics
+	// description: |
+	//   Return the metrics of the service in the Prometheus text exposition format: the number of containers by
+	//   state, the resource usage of running containers, the number of pods, volumes and images, the size of the
+	//   local storage, the latencies of state database operations and the number of events written by the service.
+	//   The endpoint is also available without version at /metrics, the default path of Prometheus scrapers.
+	// produces:
+	// - text/plain
+	// responses:
+	//   200:
+	//     description: the metrics
+	//     schema:
+	//       type: string
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/metrics"), s.APIHandler(libpod.Metrics)).Methods(http.MethodGet)
+	r.Handle("/metrics", s.APIHandler(libpod.Metrics)).Methods(http.MethodGet)
+	return nil
+}
@@ -223,6 +223,10 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: parallel
+	//    type: integer
+	//    description: maximum number of containers started concurrently, the number of parallel jobs of the service if 0
 	// responses:
 	//   200:
 	//     $ref: '#/responses/podStartResponse'
@@ -250,6 +254,10 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//    name: t
 	//    type: integer
 	//    description: timeout
+	//  - in: query
+	//    name: parallel
+	//    type: integer
+	//    description: maximum number of containers stopped concurrently, the number of parallel jobs of the service if 0
 	// responses:
 	//   200:
 	//     $ref: '#/responses/podStopResponse'
@@ -285,6 +293,37 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/unpause"), s.APIHandler(libpod.PodUnpause)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/update pods PodUpdateLibpod
+	// ---
+	// summary: Update published ports of a pod
+	// description: Replace the ports published by the infra container of a pod. The port forwarding of a running pod is updated without restarting it.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: body
+	//    name: config
+	//    description: the new port mappings of the pod
+	//    schema:
+	//      $ref: "#/definitions/PodUpdateOptions"
+	// responses:
+	//   201:
+	//     description: ID of the updated pod
+	//     schema:
+	//       type: string
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/update"), s.APIHandler(libpod.PodUpdate)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/pods/{name}/top pods PodTopLibpod
 	// ---
 	// summary: List processes
@@ -115,6 +115,47 @@ func (s *APIServer) registerSecretHandlers(r *mux.Router) error {
 	//   '500':
 	//     "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/secrets/{name}/exists"), s.APIHandler(libpod.SecretExists)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/secrets/{name}/update libpod SecretUpdateLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Update a secret
+	// description: |
+	//   Replace the data of a secret and increment its version. The copies of the secret mounted
+	//   into containers are updated. Environment variable secrets are only read when a container starts.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	//  - in: query
+	//    name: notify
+	//    type: string
+	//    enum: ["signal", "restart"]
+	//    description: Send a signal to, or restart, the running containers using the secret
+	//  - in: query
+	//    name: signal
+	//    type: string
+	//    description: Signal sent to the running containers using the secret when notify is signal
+	//    default: SIGHUP
+	//  - in: body
+	//    name: request
+	//    description: Secret data
+	//    schema:
+	//      type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     "$ref": "#/responses/SecretUpdateResponse"
+	//   '400':
+	//     "$ref": "#/responses/badParamError"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/update"), s.APIHandler(libpod.UpdateSecret)).Methods(http.MethodPost)
 	// swagger:operation DELETE /libpod/secrets/{name} libpod SecretDeleteLibpod
 	// ---
 	// tags:
@@ -87,5 +87,51 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/system/df"), s.APIHandler(libpod.DiskUsage)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/graph libpod SystemGraphLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Export dependency graph
+	// description: Return the dependency graph of all containers, pods, volumes, and networks
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemGraphResponse'
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/graph"), s.APIHandler(libpod.SystemGraph)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/changes libpod SystemChangesLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: List state changes
+	// description: |
+	//   Return the changes of containers, pods and volumes recorded by the database, oldest first,
+	//   regardless of the podman process which made them. Only the most recent changes are kept.
+	//   Only supported by the SQLite database backend.
+	// parameters:
+	//   - in: query
+	//     name: since
+	//     type: integer
+	//     description: Only return the changes with an ID greater than this one.
+	//     default: 0
+	//   - in: query
+	//     name: stream
+	//     type: boolean
+	//     description: Keep the connection open and send each new change as a JSON object.
+	//     default: false
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemChangesResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	//   501:
+	//     description: The database backend does not record state changes.
+	r.Handle(VersionedPath("/libpod/system/changes"), s.APIHandler(libpod.SystemChanges)).Methods(http.MethodGet)
 	return nil
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -11,15 +12,18 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/libpod/shutdown"
 	"github.com/containers/podman/v5/pkg/api/handlers"
 	"github.com/containers/podman/v5/pkg/api/server/idle"
 	"github.com/containers/podman/v5/pkg/api/types"
 	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/tracing"
 	"github.com/coreos/go-systemd/v22/daemon"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
@@ -36,6 +40,14 @@ type APIServer struct {
 	CorsHeaders        string        // Inject Cross-Origin Resource Sharing (CORS) headers
 	PProfAddr          string        // Binding network address for pprof profiles
 	idleTracker        *idle.Tracker // Track connections to support idle shutdown
+	drainTimeout       time.Duration // Wait for in-flight requests on shutdown
+	dbCheckpoint       time.Duration // Interval to checkpoint the database
+	statsInterval      time.Duration // Interval to record the stats of running containers
+	statsHistorySize   uint          // Stats samples kept per container
+	restartInterval    time.Duration // Interval to restart the containers whose restart policy requires it
+	shutdown           chan bool     // Closed when the server starts shutting down
+	draining           atomic.Bool   // Refuse new requests while shutting down
+	shutdownOnce       sync.Once     // Shutdown() may safely be called from several go routines
 }
 
 // Number of seconds to wait for next request, if exceeded shutdown server
@@ -45,8 +57,13 @@ const (
 	UnlimitedServiceDuration = 0 * time.Second
 )
 
-// shutdownOnce ensures Shutdown() may safely be called from several go routines
-var shutdownOnce sync.Once
+// volumeBackupCheckInterval is how often the service looks for volumes whose
+// scheduled backup is due.
+const volumeBackupCheckInterval = time.Minute
+
+// execSessionReapInterval is how often the service removes stale exec
+// sessions.
+const execSessionReapInterval = 5 * time.Minute
 
 // NewServer will create and configure a new API server with all defaults
 func NewServer(runtime *libpod.Runtime) (*APIServer, error) {
@@ -82,10 +99,17 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 			Handler:     router,
 			IdleTimeout: opts.Timeout * 2,
 		},
-		CorsHeaders: opts.CorsHeaders,
-		Listener:    listener,
-		PProfAddr:   opts.PProfAddr,
-		idleTracker: tracker,
+		Runtime:          runtime,
+		CorsHeaders:      opts.CorsHeaders,
+		Listener:         listener,
+		PProfAddr:        opts.PProfAddr,
+		idleTracker:      tracker,
+		drainTimeout:     opts.DrainTimeout,
+		dbCheckpoint:     opts.DBCheckpointInterval,
+		statsInterval:    opts.StatsInterval,
+		statsHistorySize: opts.StatsHistorySize,
+		restartInterval:  opts.RestartInterval,
+		shutdown:         make(chan bool),
 	}
 
 	server.BaseContext = func(l net.Listener) context.Context {
@@ -93,12 +117,29 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		ctx = context.WithValue(ctx, types.CompatDecoderKey, handlers.NewCompatAPIDecoder())
 		ctx = context.WithValue(ctx, types.RuntimeKey, runtime)
 		ctx = context.WithValue(ctx, types.IdleTrackerKey, tracker)
+		ctx = context.WithValue(ctx, types.ShutdownKey, (<-chan bool)(server.shutdown))
 		return ctx
 	}
 
 	// Capture panics and print stack traces for diagnostics,
 	// additionally process X-Reference-Id Header to support event correlation
-	router.Use(panicHandler(), referenceIDHandler())
+	router.Use(panicHandler(), referenceIDHandler(), server.drainHandler())
+	if tracing.Enabled() {
+		router.Use(tracingHandler())
+	}
+	if runtime.AuditEnabled() {
+		router.Use(auditHandler(runtime))
+	}
+	if len(opts.ViewerUIDs) > 0 || len(opts.ViewerCNs) > 0 {
+		router.Use(viewerHandler(opts.ViewerUIDs, opts.ViewerCNs))
+	}
+	if len(opts.RateLimits) > 0 || len(opts.MaxInFlight) > 0 {
+		limiter, err := limitHandler(opts.RateLimits, opts.MaxInFlight)
+		if err != nil {
+			return nil, err
+		}
+		router.Use(limiter)
+	}
 	router.NotFoundHandler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// We can track user errors...
@@ -127,6 +168,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		server.registerImagesHandlers,
 		server.registerInfoHandlers,
 		server.registerManifestHandlers,
+		server.registerMetricsHandlers,
 		server.registerMonitorHandlers,
 		server.registerNetworkHandlers,
 		server.registerPingHandlers,
@@ -210,6 +252,19 @@ func (s *APIServer) Serve() error {
 		_ = s.Shutdown(false)
 	}()
 
+	if s.dbCheckpoint > 0 {
+		go s.checkpointDB()
+	}
+	if s.statsInterval > 0 {
+		go s.collectStats()
+	}
+	if s.restartInterval > 0 {
+		go s.restartContainersByPolicy()
+	}
+	go s.runVolumeBackups()
+	go s.reapExecSessions()
+	go s.deliverEventWebhooks()
+
 	// Before we start serving, ensure umask is properly set for container creation.
 	_ = syscall.Umask(0o022)
 
@@ -227,6 +282,116 @@ func (s *APIServer) Serve() error {
 	return <-errChan
 }
 
+// checkpointDB periodically truncates the write-ahead log of the database,
+// which otherwise keeps growing while the service runs, until shutdown.
+func (s *APIServer) checkpointDB() {
+	ticker := time.NewTicker(s.dbCheckpoint)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Runtime.CheckpointDB(); err != nil {
+				logrus.Warnf("Checkpointing database: %v", err)
+			}
+		case <-s.shutdown:
+			return
+		}
+	}
+}
+
+// collectStats periodically records the resource usage of the running
+// containers in the database, until shutdown.
+func (s *APIServer) collectStats() {
+	previous := make(map[string]*define.ContainerStats)
+	ticker := time.NewTicker(s.statsInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Runtime.RecordContainerStats(previous, s.statsHistorySize); err != nil {
+			if errors.Is(err, define.ErrNotImplemented) {
+				logrus.Warnf("Not collecting container stats: %v", err)
+				return
+			}
+			logrus.Warnf("Collecting container stats: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-s.shutdown:
+			return
+		}
+	}
+}
+
+// restartContainersByPolicy periodically restarts the exited containers whose
+// restart policy requires it, until shutdown, so restart policies also work
+// when conmon fails to restart a container or after a reboot without systemd.
+func (s *APIServer) restartContainersByPolicy() {
+	ticker := time.NewTicker(s.restartInterval)
+	defer ticker.Stop()
+	for {
+		restarted, err := s.Runtime.RestartContainersByPolicy(context.Background())
+		if err != nil {
+			logrus.Errorf("Restarting containers by restart policy: %v", err)
+		}
+		if len(restarted) > 0 {
+			logrus.Infof("Restarted %d containers by restart policy", len(restarted))
+		}
+		select {
+		case <-ticker.C:
+		case <-s.shutdown:
+			return
+		}
+	}
+}
+
+// deliverEventWebhooks POSTs the events of all Podman processes to the events
+// webhooks, until shutdown.
+func (s *APIServer) deliverEventWebhooks() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-s.shutdown
+		cancel()
+	}()
+	if err := s.Runtime.DeliverEventsToWebhooks(ctx); err != nil {
+		logrus.Errorf("Delivering events to webhooks: %v", err)
+	}
+}
+
+// runVolumeBackups periodically backs up the volumes whose scheduled backup
+// is due, until shutdown.
+func (s *APIServer) runVolumeBackups() {
+	ticker := time.NewTicker(volumeBackupCheckInterval)
+	defer ticker.Stop()
+	for {
+		s.Runtime.RunDueVolumeBackups()
+		select {
+		case <-ticker.C:
+		case <-s.shutdown:
+			return
+		}
+	}
+}
+
+// reapExecSessions periodically removes exec sessions left behind by exec
+// sessions whose owner died.
+func (s *APIServer) reapExecSessions() {
+	ticker := time.NewTicker(execSessionReapInterval)
+	defer ticker.Stop()
+	for {
+		reaped, err := s.Runtime.ReapExecSessions()
+		if err != nil {
+			logrus.Errorf("Reaping exec sessions: %v", err)
+		}
+		if len(reaped) > 0 {
+			logrus.Infof("Reaped %d stale exec sessions", len(reaped))
+		}
+		select {
+		case <-ticker.C:
+		case <-s.shutdown:
+			return
+		}
+	}
+}
+
 // setupPprof enables pprof default endpoints
 // Note: These endpoints and the podman flag --cpu-profile are mutually exclusive
 //
@@ -267,29 +432,62 @@ func (s *APIServer) Shutdown(halt bool) error {
 		return nil
 	}
 
-	shutdownOnce.Do(func() {
+	s.shutdownOnce.Do(func() {
 		logrus.Debugf("API service shutdown, %d/%d connection(s)",
 			s.idleTracker.ActiveConnections(), s.idleTracker.TotalConnections())
 
 		// Gracefully shutdown server(s), duration of wait same as idle window
 		deadline := 1 * time.Second
-		if s.idleTracker.Duration > 0 {
+		switch {
+		case s.drainTimeout > 0:
+			deadline = s.drainTimeout
+		case s.idleTracker.Duration > 0:
 			deadline = s.idleTracker.Duration
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), deadline)
+		defer cancel()
+
+		// Refuse new requests, end event streams and close attach and
+		// exec sessions, which http.Server.Shutdown does not track.
+		s.draining.Store(true)
+		close(s.shutdown)
+
+		done := make(chan struct{})
 		go func() {
-			defer cancel()
+			defer close(done)
 
 			err := s.Server.Shutdown(ctx)
-			if err != nil && err != context.Canceled && err != http.ErrServerClosed {
+			if err != nil && err != context.Canceled && err != context.DeadlineExceeded && err != http.ErrServerClosed {
 				logrus.Error("Failed to cleanly shutdown API service: " + err.Error())
 			}
+			s.waitHijacked(ctx)
 		}()
-		<-ctx.Done()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+		if n := s.idleTracker.ActiveConnections(); n > 0 {
+			logrus.Warnf("API service shutdown after %s with %d connection(s) still active", deadline, n)
+		}
 	})
 	return nil
 }
 
+// waitHijacked waits until the handlers of all hijacked connections have
+// closed them, or ctx is done.
+func (s *APIServer) waitHijacked(ctx context.Context) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for s.idleTracker.HijackedConnections() > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // Close immediately stops responding to clients and exits
 func (s *APIServer) Close() error {
 	return s.Server.Close()
@@ -46,6 +46,7 @@ var supportedPolicies = map[string]Policy{
 // updater includes shared state for auto-updating one or more containers.
 type updater struct {
 	conn             *dbus.Conn                  // DBUS connection
+	imageName        string                      // Only update containers using this normalized image name, if set
 	options          *entities.AutoUpdateOptions // User-specified options
 	unitToTasks      map[string][]*task          // Keeps track of tasks per unit
 	updatedRawImages map[string]bool             // Keeps track of updated images
@@ -123,27 +124,33 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 	// Find auto-update tasks and assemble them by unit.
 	allErrors := auto.assembleTasks(ctx)
 
+	allReports, updateErrors := auto.updateUnits(ctx)
+	return allReports, append(allErrors, updateErrors...)
+}
+
+// updateUnits auto updates the tasks of all systemd units.
+func (u *updater) updateUnits(ctx context.Context) ([]*entities.AutoUpdateReport, []error) {
 	// Nothing to do.
-	if len(auto.unitToTasks) == 0 {
-		return nil, allErrors
+	if len(u.unitToTasks) == 0 {
+		return nil, nil
 	}
 
 	// Connect to DBUS.
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		logrus.Errorf(err.Error())
-		allErrors = append(allErrors, err)
-		return nil, allErrors
+		return nil, []error{err}
 	}
 	defer conn.Close()
-	auto.conn = conn
+	u.conn = conn
 
-	runtime.NewSystemEvent(events.AutoUpdate)
+	u.runtime.NewSystemEvent(events.AutoUpdate)
 
 	// Update all images/container according to their auto-update policy.
 	var allReports []*entities.AutoUpdateReport
-	for unit, tasks := range auto.unitToTasks {
-		unitErrors := auto.updateUnit(ctx, unit, tasks)
+	var allErrors []error
+	for unit, tasks := range u.unitToTasks {
+		unitErrors := u.updateUnit(ctx, unit, tasks)
 		allErrors = append(allErrors, unitErrors...)
 		for _, task := range tasks {
 			allReports = append(allReports, task.report())
@@ -303,7 +310,7 @@ func (t *task) registryUpdate(ctx context.Context) error {
 	pullOptions.AuthFilePath = t.authfile
 	pullOptions.Writer = os.Stderr
 	pullOptions.InsecureSkipTLSVerify = t.auto.options.InsecureSkipTLSVerify
-	if _, err := t.auto.runtime.LibimageRuntime().Pull(ctx, t.rawImageName, config.PullPolicyAlways, pullOptions); err != nil {
+	if _, err := pullImage(ctx, t.auto.runtime, t.rawImageName, pullOptions); err != nil {
 		return err
 	}
 
@@ -311,6 +318,28 @@ func (t *task) registryUpdate(ctx context.Context) error {
 	return nil
 }
 
+// pullImage pulls the newest image of name from its registry. As for podman
+// pull, the pull is checked against the image usage policy and the digest pin
+// of name, so a changed digest fails the update if pinning is enforced.
+func pullImage(ctx context.Context, runtime *libpod.Runtime, name string, options *libimage.PullOptions) ([]*libimage.Image, error) {
+	if err := runtime.CheckImagePullPolicy(name, nil); err != nil {
+		return nil, err
+	}
+	pulled, err := runtime.LibimageRuntime().Pull(ctx, name, config.PullPolicyAlways, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.CheckImagePullPolicy(name, pulled); err != nil {
+		return nil, err
+	}
+	for _, img := range pulled {
+		if err := runtime.CheckImageDigestPin(name, img, false); err != nil {
+			return nil, err
+		}
+	}
+	return pulled, nil
+}
+
 // localUpdateAvailable returns whether a new image in the local storage is available.
 func (t *task) localUpdateAvailable() (bool, error) {
 	localImg, _, err := t.auto.runtime.LibimageRuntime().LookupImage(t.rawImageName, nil)
@@ -399,6 +428,11 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 		if policy == PolicyDefault {
 			continue
 		}
+		if u.imageName != "" {
+			if name, err := normalizeImageName(ctr.RawImageName()); err != nil || name != u.imageName {
+				continue
+			}
+		}
 
 		// Make sure the container runs in a systemd unit which is
 		// stored as a label at container creation.
@@ -0,0 +1,265 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/storage"
+)
+
+// DefaultWatchInterval is the interval between two checks of a watched image
+// without an interval.
+const DefaultWatchInterval = 5 * time.Minute
+
+// watcher includes shared state for watching images.
+type watcher struct {
+	lock    sync.Mutex                  // Serializes pulls and restarts
+	options *entities.ImageWatchOptions // User-specified options
+	reports chan entities.ImageWatchReport
+	runtime *libpod.Runtime // The libpod runtime
+}
+
+// watchTarget is an image checked for updates by the watcher.
+type watchTarget struct {
+	name     string        // Normalized name of the image
+	interval time.Duration // Interval between two checks
+}
+
+// Watch checks the images of options for new digests on their registry, each
+// at its own interval, until ctx is done. A new image is pulled as soon as it
+// is found and, if options.AutoRestart is set, the systemd units of the
+// containers with an auto-update policy using the image are restarted.
+//
+// A report is sent on the returned channel for each pulled image and each
+// failed check. The channel is closed once ctx is done.
+func Watch(ctx context.Context, runtime *libpod.Runtime, options entities.ImageWatchOptions) (chan entities.ImageWatchReport, error) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	var targets []*watchTarget
+	if len(options.Images) > 0 {
+		for _, arg := range options.Images {
+			target, err := parseWatchTarget(arg, interval)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, target)
+		}
+	} else {
+		names, err := registryPolicyImages(runtime)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			targets = append(targets, &watchTarget{name: name, interval: interval})
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no images to watch: no container uses the %q auto-update policy: %w", PolicyRegistryImage, define.ErrInvalidArg)
+	}
+
+	w := &watcher{
+		options: &options,
+		reports: make(chan entities.ImageWatchReport),
+		runtime: runtime,
+	}
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target *watchTarget) {
+			defer wg.Done()
+			w.watch(ctx, target)
+		}(target)
+	}
+	go func() {
+		wg.Wait()
+		close(w.reports)
+	}()
+
+	return w.reports, nil
+}
+
+// parseWatchTarget parses an image to watch, optionally followed by "=" and
+// the interval between two checks. The default interval is used otherwise.
+func parseWatchTarget(arg string, defaultInterval time.Duration) (*watchTarget, error) {
+	rawName, rawInterval, hasInterval := strings.Cut(arg, "=")
+	name, err := normalizeImageName(rawName)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultInterval
+	if hasInterval {
+		interval, err = time.ParseDuration(rawInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval for image %q: %w", rawName, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid interval for image %q: must be positive: %w", rawName, define.ErrInvalidArg)
+		}
+	}
+
+	return &watchTarget{name: name, interval: interval}, nil
+}
+
+// normalizeImageName returns the fully-qualified and tagged name of an image
+// on a registry, so that different spellings of a name match.
+func normalizeImageName(name string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", fmt.Errorf("parsing image name %q: %w", name, err)
+	}
+	if _, isDigested := named.(reference.Digested); isDigested {
+		return "", fmt.Errorf("cannot watch image %q referenced by digest: %w", name, define.ErrInvalidArg)
+	}
+	return reference.TagNameOnly(named).String(), nil
+}
+
+// registryPolicyImages returns the normalized image names of the containers
+// with the registry auto-update policy.
+func registryPolicyImages(runtime *libpod.Runtime) ([]string, error) {
+	allContainers, err := runtime.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, ctr := range allContainers {
+		policy, err := LookupPolicy(ctr.Labels()[define.AutoUpdateLabel])
+		if err != nil || policy != PolicyRegistryImage {
+			continue
+		}
+		name, err := normalizeImageName(ctr.RawImageName())
+		if err != nil {
+			return nil, fmt.Errorf("watching image of container %s: %w", ctr.ID(), err)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// watch checks the image of target at its interval until ctx is done.
+func (w *watcher) watch(ctx context.Context, target *watchTarget) {
+	ticker := time.NewTicker(target.interval)
+	defer ticker.Stop()
+	for {
+		if report := w.check(ctx, target.name); report != nil {
+			select {
+			case w.reports <- *report:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check pulls the image if its digest on the registry differs from the local
+// one, and restarts the containers using it if requested. It returns nil if
+// the image did not change.
+func (w *watcher) check(ctx context.Context, name string) *entities.ImageWatchReport {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	report := &entities.ImageWatchReport{Time: time.Now(), Image: name}
+
+	changed, err := w.changed(ctx, name)
+	if err != nil {
+		report.Error = fmt.Sprintf("checking for updates: %v", err)
+		return report
+	}
+	if !changed {
+		return nil
+	}
+
+	pullOptions := &libimage.PullOptions{}
+	pullOptions.AuthFilePath = w.options.Authfile
+	pullOptions.InsecureSkipTLSVerify = w.options.SkipTLSVerify
+	pulled, err := pullImage(ctx, w.runtime, name, pullOptions)
+	if err != nil {
+		report.Error = fmt.Sprintf("pulling: %v", err)
+		return report
+	}
+	report.ID = pulled[0].ID()
+	report.Digest = pulled[0].Digest().String()
+
+	if w.options.AutoRestart {
+		updates, errs := w.restart(ctx, name)
+		report.Updates = updates
+		if len(errs) > 0 {
+			report.Error = errors.Join(errs...).Error()
+		}
+	}
+	return report
+}
+
+// changed returns whether the digest of the image on the registry differs
+// from the local image, or whether there is no local image yet.
+func (w *watcher) changed(ctx context.Context, name string) (bool, error) {
+	image, _, err := w.runtime.LibimageRuntime().LookupImage(name, nil)
+	if err != nil {
+		if errors.Is(err, storage.ErrImageUnknown) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	remoteRef, err := docker.ParseReference("//" + name)
+	if err != nil {
+		return false, err
+	}
+	options := &libimage.HasDifferentDigestOptions{
+		AuthFilePath:          w.options.Authfile,
+		InsecureSkipTLSVerify: w.options.SkipTLSVerify,
+	}
+	return image.HasDifferentDigest(ctx, remoteRef, options)
+}
+
+// restart restarts the systemd units of the containers with an auto-update
+// policy using the image which has just been pulled.
+func (w *watcher) restart(ctx context.Context, name string) ([]*entities.AutoUpdateReport, []error) {
+	auto := updater{
+		options: &entities.AutoUpdateOptions{
+			Authfile:              w.options.Authfile,
+			Rollback:              w.options.Rollback,
+			InsecureSkipTLSVerify: w.options.SkipTLSVerify,
+		},
+		imageName:        name,
+		runtime:          w.runtime,
+		updatedRawImages: make(map[string]bool),
+	}
+	allErrors := auto.assembleTasks(ctx)
+
+	// The image is known to be updated as it has just been pulled.
+	for _, tasks := range auto.unitToTasks {
+		for _, t := range tasks {
+			auto.updatedRawImages[t.rawImageName] = true
+		}
+	}
+
+	allReports, updateErrors := auto.updateUnits(ctx)
+	return allReports, append(allErrors, updateErrors...)
+}
@@ -0,0 +1,41 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWatchTarget(t *testing.T) {
+	tests := []struct {
+		arg      string
+		name     string
+		interval time.Duration
+		err      string
+	}{
+		{arg: "quay.io/team/web:1.0", name: "quay.io/team/web:1.0", interval: time.Minute},
+		{arg: "quay.io/team/web", name: "quay.io/team/web:latest", interval: time.Minute},
+		{arg: "alpine=10m", name: "docker.io/library/alpine:latest", interval: 10 * time.Minute},
+		{arg: "localhost:5000/web:1.0=1h30m", name: "localhost:5000/web:1.0", interval: 90 * time.Minute},
+		{arg: "alpine=0s", err: "must be positive"},
+		{arg: "alpine=often", err: "invalid interval"},
+		{arg: "alpine@sha256:0000000000000000000000000000000000000000000000000000000000000000", err: "referenced by digest"},
+		{arg: "UPPERCASE", err: "parsing image name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			target, err := parseWatchTarget(tt.arg, time.Minute)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, target.name)
+			assert.Equal(t, tt.interval, target.interval)
+		})
+	}
+}
@@ -278,6 +278,30 @@ func Stats(ctx context.Context, containers []string, options *StatsOptions) (cha
 	return statsChan, nil
 }
 
+// StatsHistory returns the resource usage samples of the container recorded
+// by the stats collector of the server, oldest first.
+func StatsHistory(ctx context.Context, nameOrID string, options *StatsHistoryOptions) ([]define.ContainerStats, error) {
+	if options == nil {
+		options = new(StatsHistoryOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	var history []define.ContainerStats
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/stats/history", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return history, response.Process(&history)
+}
+
 // Top gathers statistics about the running processes in a container. The nameOrID can be a container name
 // or a partial/full ID.  The descriptors allow for specifying which data to collect from the process.
 func Top(ctx context.Context, nameOrID string, options *TopOptions) ([]string, error) {
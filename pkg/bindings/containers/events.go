@@ -0,0 +1,30 @@
+package containers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containers/podman/v5/pkg/bindings"
+)
+
+// EmitEvent writes a custom event for an existing container.
+func EmitEvent(ctx context.Context, nameOrID string, options *EmitEventOptions) error {
+	if options == nil {
+		options = new(EmitEventOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/events", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
@@ -30,3 +30,24 @@ func RunHealthCheck(ctx context.Context, nameOrID string, options *HealthCheckOp
 
 	return &status, response.Process(&status)
 }
+
+// HealthCheckHistory returns the healthcheck runs of the container recorded
+// by the server, oldest first.
+func HealthCheckHistory(ctx context.Context, nameOrID string, options *HealthCheckHistoryOptions) ([]define.HealthCheckHistoryEntry, error) {
+	if options == nil {
+		options = new(HealthCheckHistoryOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var history []define.HealthCheckHistoryEntry
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/healthcheck/history", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return history, response.Process(&history)
+}
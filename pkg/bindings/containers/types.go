@@ -19,6 +19,7 @@ type LogOptions struct {
 	Tail       *string
 	Timestamps *bool
 	Until      *string
+	Redact     *bool
 }
 
 // CommitOptions describe details about the resulting committed
@@ -61,6 +62,7 @@ type CheckpointOptions struct {
 	PrintStats     *bool
 	PreCheckpoint  *bool
 	WithPrevious   *bool
+	PreDumpChain   *int
 	FileLocks      *bool
 }
 
@@ -123,6 +125,12 @@ type ExecStartOptions struct {
 //go:generate go run ../generator/generator.go HealthCheckOptions
 type HealthCheckOptions struct{}
 
+// HealthCheckHistoryOptions are optional options for the healthcheck history
+// of a container
+//
+//go:generate go run ../generator/generator.go HealthCheckHistoryOptions
+type HealthCheckHistoryOptions struct{}
+
 // MountOptions are optional options for mounting
 // containers
 //
@@ -176,7 +184,9 @@ type RemoveOptions struct {
 //
 //go:generate go run ../generator/generator.go InspectOptions
 type InspectOptions struct {
-	Size *bool
+	Size            *bool
+	Fields          []string
+	EffectiveConfig *bool
 }
 
 // KillOptions are optional options for killing containers
@@ -215,6 +225,16 @@ type StatsOptions struct {
 	Interval *int
 }
 
+// StatsHistoryOptions are optional options for getting the stats history
+// of a container
+//
+//go:generate go run ../generator/generator.go StatsHistoryOptions
+type StatsHistoryOptions struct {
+	// Since only returns the samples taken after the given time,
+	// as a timestamp or a duration before now
+	Since *string
+}
+
 // TopOptions are optional options for getting running
 // processes in containers
 //
@@ -273,6 +293,15 @@ type RenameOptions struct {
 	Name *string
 }
 
+// EmitEventOptions are options for emitting a custom container event.
+// The Type field is required.
+//
+//go:generate go run ../generator/generator.go EmitEventOptions
+type EmitEventOptions struct {
+	Type    *string
+	Message *string
+}
+
 // ResizeTTYOptions are optional options for resizing
 // container TTYs
 //
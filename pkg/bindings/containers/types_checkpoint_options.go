@@ -152,6 +152,21 @@ func (o *CheckpointOptions) GetWithPrevious() bool {
 	return *o.WithPrevious
 }
 
+// WithPreDumpChain set field PreDumpChain to given value
+func (o *CheckpointOptions) WithPreDumpChain(value int) *CheckpointOptions {
+	o.PreDumpChain = &value
+	return o
+}
+
+// GetPreDumpChain returns value of field PreDumpChain
+func (o *CheckpointOptions) GetPreDumpChain() int {
+	if o.PreDumpChain == nil {
+		var z int
+		return z
+	}
+	return *o.PreDumpChain
+}
+
 // WithFileLocks set field FileLocks to given value
 func (o *CheckpointOptions) WithFileLocks(value bool) *CheckpointOptions {
 	o.FileLocks = &value
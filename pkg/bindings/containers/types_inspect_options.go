@@ -31,3 +31,33 @@ func (o *InspectOptions) GetSize() bool {
 	}
 	return *o.Size
 }
+
+// WithFields set field Fields to given value
+func (o *InspectOptions) WithFields(value []string) *InspectOptions {
+	o.Fields = value
+	return o
+}
+
+// GetFields returns value of field Fields
+func (o *InspectOptions) GetFields() []string {
+	if o.Fields == nil {
+		var z []string
+		return z
+	}
+	return o.Fields
+}
+
+// WithEffectiveConfig set field EffectiveConfig to given value
+func (o *InspectOptions) WithEffectiveConfig(value bool) *InspectOptions {
+	o.EffectiveConfig = &value
+	return o
+}
+
+// GetEffectiveConfig returns value of field EffectiveConfig
+func (o *InspectOptions) GetEffectiveConfig() bool {
+	if o.EffectiveConfig == nil {
+		var z bool
+		return z
+	}
+	return *o.EffectiveConfig
+}
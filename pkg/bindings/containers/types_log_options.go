@@ -121,3 +121,18 @@ func (o *LogOptions) GetUntil() string {
 	}
 	return *o.Until
 }
+
+// WithRedact set field Redact to given value
+func (o *LogOptions) WithRedact(value bool) *LogOptions {
+	o.Redact = &value
+	return o
+}
+
+// GetRedact returns value of field Redact
+func (o *LogOptions) GetRedact() bool {
+	if o.Redact == nil {
+		var z bool
+		return z
+	}
+	return *o.Redact
+}
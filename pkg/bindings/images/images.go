@@ -2,14 +2,17 @@ package images
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	imageTypes "github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/libpod/define"
 	handlersTypes "github.com/containers/podman/v5/pkg/api/handlers/types"
 	"github.com/containers/podman/v5/pkg/auth"
 	"github.com/containers/podman/v5/pkg/bindings"
@@ -124,6 +127,27 @@ func History(ctx context.Context, nameOrID string, options *HistoryOptions) ([]*
 	return history, response.Process(&history)
 }
 
+// Provenance returns the pulls of an image recorded by the server, together
+// with all other pulls from the sources the image was pulled from.
+func Provenance(ctx context.Context, nameOrID string, options *ProvenanceOptions) ([]*define.ImageProvenance, error) {
+	if options == nil {
+		options = new(ProvenanceOptions)
+	}
+	_ = options
+	var provenance []*define.ImageProvenance
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/images/%s/provenance", nil, nil, nameOrID)
+	if err != nil {
+		return provenance, err
+	}
+	defer response.Body.Close()
+
+	return provenance, response.Process(&provenance)
+}
+
 func Load(ctx context.Context, r io.Reader) (*types.ImageLoadReport, error) {
 	var report types.ImageLoadReport
 	conn, err := bindings.GetClient(ctx)
@@ -326,3 +350,66 @@ func Scp(ctx context.Context, source, destination *string, options ScpOptions) (
 
 	return rep, response.Process(&rep)
 }
+
+// Watch checks the given images, or the images of the containers with the
+// registry auto-update policy if none is given, for new digests on their
+// registry and pulls them. Each image may be followed by "=" and its own
+// interval between two checks. A report is sent on the returned channel for
+// each pulled image and each failed check, until the connection is closed.
+func Watch(ctx context.Context, images []string, options *WatchOptions) (chan types.ImageWatchReport, error) {
+	if options == nil {
+		options = new(WatchOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		params.Add("images", image)
+	}
+
+	// SkipTLSVerify is special.  It's not being serialized by ToParams()
+	// because we need to flip the boolean.
+	if options.SkipTLSVerify != nil {
+		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
+	}
+
+	header, err := auth.MakeXRegistryAuthHeader(&imageTypes.SystemContext{AuthFilePath: options.GetAuthfile()}, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/images/watch", params, header)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsSuccess() {
+		defer response.Body.Close()
+		return nil, response.Process(nil)
+	}
+
+	reports := make(chan types.ImageWatchReport)
+	go func() {
+		defer close(reports)
+		defer response.Body.Close()
+
+		dec := json.NewDecoder(response.Body)
+		for {
+			var report types.ImageWatchReport
+			if err := dec.Decode(&report); err != nil {
+				if !errors.Is(err, io.EOF) {
+					report = types.ImageWatchReport{Time: time.Now(), Error: fmt.Sprintf("decoding image watch report: %v", err)}
+					reports <- report
+				}
+				return
+			}
+			reports <- report
+		}
+	}()
+
+	return reports, nil
+}
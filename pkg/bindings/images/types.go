@@ -65,6 +65,12 @@ type TreeOptions struct {
 type HistoryOptions struct {
 }
 
+// ProvenanceOptions are optional options for image provenance
+//
+//go:generate go run ../generator/generator.go ProvenanceOptions
+type ProvenanceOptions struct {
+}
+
 // LoadOptions are optional options for loading an image
 //
 //go:generate go run ../generator/generator.go LoadOptions
@@ -200,6 +206,9 @@ type SearchOptions struct {
 //
 //go:generate go run ../generator/generator.go PullOptions
 type PullOptions struct {
+	// AcceptNewDigest pins the digest of the pulled image if it differs
+	// from the digest pinned on first use.
+	AcceptNewDigest *bool
 	// AllTags can be specified to pull all tags of an image. Note
 	// that this only works if the image does not include a tag.
 	AllTags *bool
@@ -233,6 +242,27 @@ type PullOptions struct {
 	Variant *string
 }
 
+// WatchOptions are optional options for watching images
+//
+//go:generate go run ../generator/generator.go WatchOptions
+type WatchOptions struct {
+	// Authfile is the path to the authentication file. Ignored for remote
+	// calls.
+	Authfile *string
+	// AutoRestart restarts the systemd units of the containers with an
+	// auto-update policy using a watched image when a new image was
+	// pulled.
+	AutoRestart *bool
+	// Interval between two checks of the images without their own
+	// interval, as a duration such as "5m".
+	Interval *string
+	// Rollback restarts the systemd units another time with the previous
+	// image if restarting them with the new image failed.
+	Rollback *bool
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify *bool `schema:"-"`
+}
+
 // BuildOptions are optional options for building images
 type BuildOptions = types.BuildOptions
 
@@ -18,6 +18,21 @@ func (o *PullOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
 
+// WithAcceptNewDigest set field AcceptNewDigest to given value
+func (o *PullOptions) WithAcceptNewDigest(value bool) *PullOptions {
+	o.AcceptNewDigest = &value
+	return o
+}
+
+// GetAcceptNewDigest returns value of field AcceptNewDigest
+func (o *PullOptions) GetAcceptNewDigest() bool {
+	if o.AcceptNewDigest == nil {
+		var z bool
+		return z
+	}
+	return *o.AcceptNewDigest
+}
+
 // WithAllTags set field AllTags to given value
 func (o *PullOptions) WithAllTags(value bool) *PullOptions {
 	o.AllTags = &value
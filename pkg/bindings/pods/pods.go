@@ -210,12 +210,15 @@ func Start(ctx context.Context, nameOrID string, options *StartOptions) (*entiti
 	if options == nil {
 		options = new(StartOptions)
 	}
-	_ = options
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/pods/%s/start", nil, nil, nameOrID)
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/pods/%s/start", params, nil, nameOrID)
 	if err != nil {
 		return nil, err
 	}
@@ -340,3 +343,24 @@ func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*
 
 	return reports, response.Process(&reports)
 }
+
+// Update replaces the published ports of a pod. The ports of a running pod
+// are reprogrammed without restarting it.
+func Update(ctx context.Context, options *entitiesTypes.PodUpdateOptions) (string, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	body, err := jsoniter.MarshalToString(options)
+	if err != nil {
+		return "", err
+	}
+	response, err := conn.DoRequest(ctx, strings.NewReader(body), http.MethodPost, "/pods/%s/update", nil, nil, options.NameOrID)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	var id string
+	return id, response.Process(&id)
+}
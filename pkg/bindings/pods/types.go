@@ -48,13 +48,15 @@ type RestartOptions struct {
 //
 //go:generate go run ../generator/generator.go StartOptions
 type StartOptions struct {
+	Parallel *uint
 }
 
 // StopOptions are optional options for stopping pods
 //
 //go:generate go run ../generator/generator.go StopOptions
 type StopOptions struct {
-	Timeout *int
+	Timeout  *int
+	Parallel *uint
 }
 
 // TopOptions are optional options for getting top on pods
@@ -16,3 +16,18 @@ func (o *StartOptions) Changed(fieldName string) bool {
 func (o *StartOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
+
+// WithParallel set field Parallel to given value
+func (o *StartOptions) WithParallel(value uint) *StartOptions {
+	o.Parallel = &value
+	return o
+}
+
+// GetParallel returns value of field Parallel
+func (o *StartOptions) GetParallel() uint {
+	if o.Parallel == nil {
+		var z uint
+		return z
+	}
+	return *o.Parallel
+}
@@ -31,3 +31,18 @@ func (o *StopOptions) GetTimeout() int {
 	}
 	return *o.Timeout
 }
+
+// WithParallel set field Parallel to given value
+func (o *StopOptions) WithParallel(value uint) *StopOptions {
+	o.Parallel = &value
+	return o
+}
+
+// GetParallel returns value of field Parallel
+func (o *StopOptions) GetParallel() uint {
+	if o.Parallel == nil {
+		var z uint
+		return z
+	}
+	return *o.Parallel
+}
@@ -107,6 +107,27 @@ func Check(ctx context.Context, options *CheckOptions) (*types.SystemCheckReport
 	return &report, response.Process(&report)
 }
 
+// Graph returns the dependency graph of all containers, pods, volumes and
+// networks.
+func Graph(ctx context.Context, options *GraphOptions) (*types.SystemGraphReport, error) {
+	var report types.SystemGraphReport
+	if options == nil {
+		options = new(GraphOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/system/graph", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
 func Version(ctx context.Context, options *VersionOptions) (*types.SystemVersionReport, error) {
 	var (
 		component types.SystemComponentVersion
@@ -39,6 +39,12 @@ type DiskOptions struct {
 type InfoOptions struct {
 }
 
+// GraphOptions are optional options for exporting the dependency graph
+//
+//go:generate go run ../generator/generator.go GraphOptions
+type GraphOptions struct {
+}
+
 // CheckOptions are optional options for storage consistency check/repair
 //
 //go:generate go run ../generator/generator.go CheckOptions
@@ -0,0 +1,244 @@
+// Package containersconf reads the settings of containers.conf that only
+// Podman uses and that containers/common does not know about. They are read
+// from the files containers/common reads its own settings from, in the same
+// order; containers/common ignores them.
+package containersconf
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	_ "unsafe" // for go:linkname
+
+	"github.com/BurntSushi/toml"
+	_ "github.com/containers/common/pkg/config" // for systemConfigs
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// containersConfOverrideEnv names a config file read after all others,
+	// as containers/common does.
+	containersConfOverrideEnv = "CONTAINERS_CONF_OVERRIDE"
+
+	// DefaultHealthcheckMaxLogCount is the default number of healthcheck
+	// runs kept in the healthcheck history of a container.
+	DefaultHealthcheckMaxLogCount = 100
+	// DefaultExitCodeRetention is the default number of seconds the exit
+	// codes of containers are kept in the database.
+	DefaultExitCodeRetention = 5 * 60
+)
+
+// Config contains the Podman specific settings of containers.conf.
+type Config struct {
+	// Containers are the settings of the [containers] table.
+	Containers ContainersConfig `toml:"containers"`
+	// Engine are the settings of the [engine] table.
+	Engine EngineConfig `toml:"engine"`
+	// Network are the settings of the [network] table.
+	Network NetworkConfig `toml:"network"`
+}
+
+// ContainersConfig contains the Podman specific settings of the
+// [containers] table.
+type ContainersConfig struct {
+	// LogRedactPatterns are regular expressions whose matches are masked
+	// when the logs of a container are read with redaction enabled. If a
+	// pattern has capturing groups, only the groups are masked.
+	LogRedactPatterns []string `toml:"log_redact_patterns,omitempty"`
+
+	// ProxyProfiles are named sets of proxy settings and certificate
+	// authority mounts which can be injected into containers with
+	// --proxy-profile.
+	ProxyProfiles map[string]ProxyProfile `toml:"proxy_profiles,omitempty"`
+
+	// SecurityProfile is the security profile enforced on containers by
+	// default: privileged, baseline or restricted. Containers overriding
+	// it with --security-profile are logged and recorded.
+	SecurityProfile string `toml:"security_profile,omitempty"`
+}
+
+// EngineConfig contains the Podman specific settings of the [engine] table.
+type EngineConfig struct {
+	// AuditLog is the path of a file every modification of the container,
+	// pod, volume and network state is appended to. Auditing is disabled
+	// if empty.
+	AuditLog string `toml:"audit_log,omitempty"`
+
+	// DBBusyTimeout is the time in milliseconds the SQLite database
+	// backend retries operations on a locked database.
+	DBBusyTimeout uint `toml:"database_busy_timeout,omitempty"`
+
+	// DBCacheSize is the SQLite page cache size. Positive values are
+	// pages, negative values are KiB.
+	DBCacheSize int `toml:"database_cache_size,omitempty"`
+
+	// DBConnection is the connection string of the PostgreSQL database
+	// backend, either a URL or a list of key=value settings.
+	DBConnection string `toml:"database_connection,omitempty"`
+
+	// DBJournalMode is the SQLite journal mode, one of "delete",
+	// "truncate", "persist", "memory", "wal" or "off".
+	DBJournalMode string `toml:"database_journal_mode,omitempty"`
+
+	// DBMmapSize is the maximum number of bytes of the SQLite database
+	// that are memory mapped.
+	DBMmapSize int64 `toml:"database_mmap_size,omitempty"`
+
+	// DBSynchronous is the SQLite synchronous level, one of "off",
+	// "normal", "full" or "extra".
+	DBSynchronous string `toml:"database_synchronous,omitempty"`
+
+	// EventsWebhooks are HTTP endpoints every event is POSTed to as JSON.
+	EventsWebhooks []string `toml:"events_webhooks,omitempty"`
+
+	// EventsWebhookFilters limits the events POSTed to EventsWebhooks,
+	// using the filters of podman events.
+	EventsWebhookFilters []string `toml:"events_webhook_filters,omitempty"`
+
+	// ExitCodeRetention is the number of seconds the exit codes of
+	// containers are kept in the database. The most recent exit code of a
+	// container is kept as long as the container exists.
+	ExitCodeRetention uint `toml:"exit_code_retention,omitempty,omitzero"`
+
+	// HealthcheckMaxLogCount is the number of healthcheck runs kept in the
+	// healthcheck history of a container. 0 keeps all runs.
+	HealthcheckMaxLogCount uint `toml:"healthcheck_max_log_count,omitempty"`
+
+	// ImageDigestPinning enables trust-on-first-use pinning of the digests
+	// of images pulled by tag, one of "off", "warn" or "enforce".
+	ImageDigestPinning string `toml:"image_digest_pinning,omitempty"`
+
+	// PlatformPreflight determines how the platform of the image of a
+	// container is checked before the container is started: "auto"
+	// enables an installed qemu-user emulation for a foreign
+	// architecture, "error" only fails if the host cannot run the image,
+	// and "off" skips the check.
+	PlatformPreflight string `toml:"platform_preflight,omitempty"`
+
+	// ServiceLogLevel is the log level of `podman system service` when
+	// --log-level is not set. It is applied again when the service
+	// reloads its configuration.
+	ServiceLogLevel string `toml:"service_log_level,omitempty"`
+
+	// TracingExporter enables OpenTelemetry tracing of Podman operations
+	// and selects where spans are exported: "otlp" to POST them to the
+	// OTLP/HTTP collector at TracingEndpoint, or "file" to append them to
+	// the file at TracingEndpoint. Tracing is disabled if empty.
+	TracingExporter string `toml:"tracing_exporter,omitempty"`
+
+	// TracingEndpoint is the URL of the OTLP/HTTP collector or the path of
+	// the file spans are exported to.
+	TracingEndpoint string `toml:"tracing_endpoint,omitempty"`
+}
+
+// NetworkConfig contains the Podman specific settings of the [network]
+// table.
+type NetworkConfig struct {
+	// DefaultNetworkDriver is the driver of a custom default network,
+	// which Podman creates on first use. Defaults to bridge.
+	DefaultNetworkDriver string `toml:"default_network_driver,omitempty"`
+
+	// DefaultNetworkOptions are the driver options of a custom default
+	// network.
+	DefaultNetworkOptions map[string]string `toml:"default_network_options,omitempty"`
+
+	// DefaultNetworkDNS enables the DNS server of a custom default bridge
+	// network. Defaults to true for the bridge driver.
+	DefaultNetworkDNS *bool `toml:"default_network_dns,omitempty"`
+}
+
+// ProxyProfile represents the proxy settings injected into a container.
+type ProxyProfile struct {
+	// HTTPProxy is the value of the HTTP_PROXY and http_proxy environment
+	// variables.
+	HTTPProxy string `json:",omitempty" toml:"http_proxy,omitempty"`
+
+	// HTTPSProxy is the value of the HTTPS_PROXY and https_proxy
+	// environment variables.
+	HTTPSProxy string `json:",omitempty" toml:"https_proxy,omitempty"`
+
+	// NoProxy is the value of the NO_PROXY and no_proxy environment
+	// variables.
+	NoProxy string `json:",omitempty" toml:"no_proxy,omitempty"`
+
+	// CAMounts are certificate authority bundles mounted read-only into
+	// the container, specified as "<file-on-host>:<file-in-container>".
+	CAMounts []string `json:",omitempty" toml:"ca_mounts,omitempty"`
+}
+
+// Default returns the built-in defaults of the Podman specific settings.
+func Default() *Config {
+	return &Config{
+		Engine: EngineConfig{
+			ExitCodeRetention:      DefaultExitCodeRetention,
+			HealthcheckMaxLogCount: DefaultHealthcheckMaxLogCount,
+		},
+	}
+}
+
+// New reads the Podman specific settings from the containers.conf files and
+// the given modules, as loaded by containers/common, on top of the built-in
+// defaults.
+func New(modules []string) (*Config, error) {
+	conf := Default()
+
+	configs, err := systemConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("finding config on system: %w", err)
+	}
+	for _, path := range configs {
+		if err := readConfigFromFile(path, conf, true); err != nil {
+			return nil, fmt.Errorf("reading system config %q: %w", path, err)
+		}
+	}
+
+	additional := modules
+	if path := os.Getenv(containersConfOverrideEnv); path != "" {
+		additional = append(additional, path)
+	}
+	for _, path := range additional {
+		if err := readConfigFromFile(path, conf, false); err != nil {
+			return nil, fmt.Errorf("reading additional config %q: %w", path, err)
+		}
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// Validate returns an error if a setting is invalid.
+func (c *Config) Validate() error {
+	for _, pattern := range c.Containers.LogRedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid log redact pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// systemConfigs returns the containers.conf files read by containers/common,
+// in the order they are read. It honors CONTAINERS_CONF and the drop-in
+// directories exactly as containers/common does, which does not export it.
+//
+//go:linkname systemConfigs github.com/containers/common/pkg/config.systemConfigs
+func systemConfigs() ([]string, error)
+
+// readConfigFromFile merges the settings of the file at path into conf. The
+// settings of containers/common are ignored.
+func readConfigFromFile(path string, conf *Config, ignoreErrNotExist bool) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := toml.DecodeFile(path, conf); err != nil {
+		if ignoreErrNotExist && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("decode configuration %v: %w", path, err)
+	}
+	logrus.Debugf("Merged Podman settings of config %q", path)
+	return nil
+}
@@ -0,0 +1,88 @@
+//go:build !windows
+
+package containersconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConf(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	conf := writeConf(t, dir, "containers.conf", `
+[containers]
+log_driver = "k8s-file"
+security_profile = "baseline"
+
+[containers.proxy_profiles.corp]
+http_proxy = "http://proxy.example.com:3128"
+ca_mounts = ["/etc/pki/corp.pem:/etc/pki/ca-trust/source/anchors/corp.pem"]
+
+[engine]
+image_digest_pinning = "warn"
+database_journal_mode = "wal"
+`)
+	module := writeConf(t, dir, "module.conf", `
+[engine]
+image_digest_pinning = "enforce"
+`)
+	override := writeConf(t, dir, "override.conf", `
+[engine]
+healthcheck_max_log_count = 10
+`)
+	t.Setenv("CONTAINERS_CONF", conf)
+	t.Setenv(containersConfOverrideEnv, override)
+
+	c, err := New([]string{module})
+	require.NoError(t, err)
+	assert.Equal(t, "baseline", c.Containers.SecurityProfile)
+	assert.Equal(t, ProxyProfile{
+		HTTPProxy: "http://proxy.example.com:3128",
+		CAMounts:  []string{"/etc/pki/corp.pem:/etc/pki/ca-trust/source/anchors/corp.pem"},
+	}, c.Containers.ProxyProfiles["corp"])
+	assert.Equal(t, "wal", c.Engine.DBJournalMode)
+	assert.Equal(t, "enforce", c.Engine.ImageDigestPinning, "modules override the system configs")
+	assert.Equal(t, uint(10), c.Engine.HealthcheckMaxLogCount, "the override config wins")
+	assert.Equal(t, uint(DefaultExitCodeRetention), c.Engine.ExitCodeRetention, "unset settings keep their default")
+}
+
+func TestNewInvalidRedactPattern(t *testing.T) {
+	conf := writeConf(t, t.TempDir(), "containers.conf", `
+[containers]
+log_redact_patterns = ["password=(\\S+"]
+`)
+	t.Setenv("CONTAINERS_CONF", conf)
+	t.Setenv(containersConfOverrideEnv, "")
+
+	_, err := New(nil)
+	assert.ErrorContains(t, err, `invalid log redact pattern "password=(\\S+"`)
+}
+
+func TestSystemConfigs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("CONTAINERS_CONF", "")
+	t.Setenv("XDG_CONFIG_HOME", home)
+	dropIn := filepath.Join(home, "containers", "containers.conf.d")
+	require.NoError(t, os.MkdirAll(dropIn, 0o700))
+	for _, name := range []string{"20-b.conf", "10-a.conf", "ignored.txt"} {
+		writeConf(t, dropIn, name, "")
+	}
+
+	configs, err := systemConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(home, "containers", "containers.conf"),
+		filepath.Join(dropIn, "10-a.conf"),
+		filepath.Join(dropIn, "20-b.conf"),
+	}, configs[len(configs)-3:])
+}
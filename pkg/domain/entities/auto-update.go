@@ -1,6 +1,9 @@
 package entities
 
-import "github.com/containers/image/v5/types"
+import (
+	"github.com/containers/image/v5/types"
+	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+)
 
 // AutoUpdateOptions are the options for running auto-update.
 type AutoUpdateOptions struct {
@@ -19,18 +22,4 @@ type AutoUpdateOptions struct {
 }
 
 // AutoUpdateReport contains the results from running auto-update.
-type AutoUpdateReport struct {
-	// ID of the container *before* an update.
-	ContainerID string
-	// Name of the container *before* an update.
-	ContainerName string
-	// Name of the image.
-	ImageName string
-	// The configured auto-update policy.
-	Policy string
-	// SystemdUnit running a container configured for auto updates.
-	SystemdUnit string
-	// Indicates the update status: true, false, failed, pending (see
-	// DryRun).
-	Updated string
-}
+type AutoUpdateReport = entitiesTypes.AutoUpdateReport
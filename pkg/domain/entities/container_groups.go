@@ -0,0 +1,17 @@
+package entities
+
+import "github.com/containers/podman/v5/libpod/define"
+
+// ContainerGroupCreateOptions describes the options for creating a container
+// group.
+type ContainerGroupCreateOptions struct {
+	// Filters select the containers of the group, as for podman ps.
+	Filters map[string][]string
+}
+
+// ContainerGroupReport describes a container group and its current members.
+type ContainerGroupReport struct {
+	*define.ContainerGroup
+	// Containers are the IDs of the containers currently in the group.
+	Containers []string `json:"containers"`
+}
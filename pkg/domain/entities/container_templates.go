@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"github.com/containers/image/v5/types"
+)
+
+// ContainerTemplateRegistryOptions describes how to access the registry
+// holding a container template.
+type ContainerTemplateRegistryOptions struct {
+	// Authfile is the path to the authentication file.
+	Authfile string
+	// CertDir is the path to certificate directories.
+	CertDir string
+	// Username for authenticating against the registry.
+	Username string
+	// Password for authenticating against the registry.
+	Password string
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify types.OptionalBool
+	// SignaturePolicy to use when pulling.
+	SignaturePolicy string
+}
+
+// SystemContext returns a copy of sys updated with the options.
+func (o *ContainerTemplateRegistryOptions) SystemContext(sys *types.SystemContext) *types.SystemContext {
+	ctx := new(types.SystemContext)
+	if sys != nil {
+		*ctx = *sys
+	}
+	if o.Authfile != "" {
+		ctx.AuthFilePath = o.Authfile
+	}
+	if o.CertDir != "" {
+		ctx.DockerCertPath = o.CertDir
+	}
+	if o.Username != "" || o.Password != "" {
+		ctx.DockerAuthConfig = &types.DockerAuthConfig{Username: o.Username, Password: o.Password}
+	}
+	if o.SkipTLSVerify != types.OptionalBoolUndefined {
+		ctx.DockerInsecureSkipTLSVerify = o.SkipTLSVerify
+		ctx.OCIInsecureSkipTLSVerify = o.SkipTLSVerify == types.OptionalBoolTrue
+	}
+	if o.SignaturePolicy != "" {
+		ctx.SignaturePolicyPath = o.SignaturePolicy
+	}
+	return ctx
+}
+
+// ContainerTemplatePushOptions describes the options for pushing a container
+// template.
+type ContainerTemplatePushOptions struct {
+	ContainerTemplateRegistryOptions
+	// Spec is the specgen JSON of the template.
+	Spec []byte
+	// Annotations to set on the manifest of the template.
+	Annotations map[string]string
+}
+
+// ContainerTemplatePushReport describes a pushed container template.
+type ContainerTemplatePushReport struct {
+	// Digest of the manifest of the template.
+	Digest string
+}
+
+// ContainerTemplatePullOptions describes the options for pulling a container
+// template.
+type ContainerTemplatePullOptions struct {
+	ContainerTemplateRegistryOptions
+}
+
+// ContainerTemplatePullReport describes a pulled container template.
+type ContainerTemplatePullReport struct {
+	// Digest of the manifest of the template.
+	Digest string
+	// Annotations of the manifest of the template.
+	Annotations map[string]string
+	// Spec is the specgen JSON of the template.
+	Spec []byte
+}
@@ -194,6 +194,23 @@ type ContainerExportOptions struct {
 	Output io.Writer
 }
 
+// ContainerAnnotateOptions describes the changes to the operator annotations
+// of a container
+type ContainerAnnotateOptions struct {
+	// Annotations to set, replacing earlier values of the keys
+	Set map[string]string
+	// Keys of the annotations to remove
+	Remove []string
+}
+
+// ContainerNotesOptions describes the changes to the notes of a container
+type ContainerNotesOptions struct {
+	// Text of a note to add
+	Add string
+	// IDs of the notes to remove
+	Remove []int64
+}
+
 type CheckpointOptions struct {
 	All            bool
 	Export         string
@@ -206,13 +223,27 @@ type CheckpointOptions struct {
 	TCPEstablished bool
 	PreCheckPoint  bool
 	WithPrevious   bool
+	PreDumpChain   int
 	Compression    archive.Compression
 	PrintStats     bool
 	FileLocks      bool
+	// SkipRecord does not record the exported checkpoint, so it is not
+	// listed by podman container checkpoint ls.
+	SkipRecord bool
 }
 
 type CheckpointReport = types.CheckpointReport
 
+// CheckpointListOptions describes options for listing exported checkpoints
+type CheckpointListOptions struct {
+	Filters map[string][]string
+}
+
+// CheckpointPruneOptions describes options for pruning exported checkpoints
+type CheckpointPruneOptions struct {
+	Filters map[string][]string
+}
+
 type RestoreOptions struct {
 	All             bool
 	IgnoreRootFS    bool
@@ -270,6 +301,9 @@ type ContainerLogsOptions struct {
 	Timestamps bool
 	// Show different colors in the logs.
 	Colors bool
+	// Mask the values of the secrets of the containers and the matches
+	// of the log_redact_patterns of containers.conf.
+	Redact bool
 	// Write the stdout to this Writer.
 	StdoutWriter io.Writer
 	// Write the stderr to this Writer.
@@ -287,16 +321,35 @@ type ExecOptions struct {
 	PreserveFDs uint
 	PreserveFD  []uint
 	Privileged  bool
+	ToolsImage  string
 	Tty         bool
 	User        string
 	WorkDir     string
 }
 
+// ContainerExecListReport describes an exec session of a container
+type ContainerExecListReport struct {
+	ID          string
+	ContainerID string
+	Command     []string
+	State       string
+	PID         int
+	ExitCode    int
+}
+
 // ContainerExistsOptions describes the cli values to check if a container exists
 type ContainerExistsOptions struct {
 	External bool
 }
 
+// ContainerEmitEventOptions describes the custom event to emit for a container
+type ContainerEmitEventOptions struct {
+	// Type is the user-defined type of the event.
+	Type string
+	// Message is the message carried by the event.
+	Message string
+}
+
 // ContainerStartOptions describes the val from the
 // CLI needed to start a container
 type ContainerStartOptions struct {
@@ -475,12 +528,53 @@ type ContainerStatsOptions struct {
 
 type ContainerStatsReport = types.ContainerStatsReport
 
+// ContainerStatsHistoryOptions describes input options for getting the stats
+// recorded by the stats collector of the system service.
+type ContainerStatsHistoryOptions struct {
+	// Only return the samples taken after Since.
+	Since time.Time
+}
+
 // ContainerRenameOptions describes input options for renaming a container.
 type ContainerRenameOptions struct {
 	// NewName is the new name that will be given to the container.
 	NewName string
 }
 
+// ContainerRefreshOptions describes input options for rebuilding a
+// container from a newer image.
+type ContainerRefreshOptions struct {
+	// Image is the image to rebuild the container from. Defaults to the
+	// image the container was created from.
+	Image string
+	// PullPolicy is the policy for pulling the image.
+	PullPolicy string
+	// Quiet suppresses the output of pulling the image.
+	Quiet bool
+	// TakeoverPorts hands the sockets published by the running container
+	// to its replacement without closing them.
+	TakeoverPorts bool
+}
+
+// ContainerRefreshReport describes the result of rebuilding a container.
+type ContainerRefreshReport struct {
+	Id string //nolint:revive,stylecheck
+	// ImageID is the ID of the image the container uses.
+	ImageID string
+	// Updated is set if the container was rebuilt from a different image.
+	Updated bool
+}
+
+// Valid values for ContainerCloneOptions.Volumes.
+const (
+	// CloneVolumesShare mounts the named volumes of the original container.
+	CloneVolumesShare = "share"
+	// CloneVolumesCopy duplicates the named volumes including their data.
+	CloneVolumesCopy = "copy"
+	// CloneVolumesNew recreates the named volumes empty.
+	CloneVolumesNew = "new"
+)
+
 // ContainerCloneOptions contains options for cloning an existing container
 type ContainerCloneOptions struct {
 	ID           string
@@ -490,6 +584,12 @@ type ContainerCloneOptions struct {
 	RawImageName string
 	Run          bool
 	Force        bool
+	// Volumes controls how named volumes are cloned, one of share, copy
+	// or new. Defaults to share.
+	Volumes string
+	// VolumeCopyProgress receives progress output while copying volume
+	// data. Optional.
+	VolumeCopyProgress io.Writer
 }
 
 // ContainerUpdateOptions containers options for updating an existing containers cgroup configuration
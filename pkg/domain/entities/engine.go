@@ -38,6 +38,8 @@ type PodmanConfig struct {
 	Identity                 string   // ssh identity for connecting to server
 	IsRenumber               bool     // Is this a system renumber command? If so, a number of checks will be relaxed
 	IsReset                  bool     // Is this a system reset command? If so, a number of checks will be skipped/omitted
+	IsService                bool     // Is this a system service command? If so, it delivers the events to the events webhooks
+	ServiceMetrics           bool     // Is this a system service recording metrics? Set by its --metrics flag
 	MaxWorks                 int      // maximum number of parallel threads
 	MemoryProfile            string   // Hidden: Should memory profile be taken
 	RegistriesConf           string   // allows for specifying a custom registries.conf
@@ -57,6 +59,7 @@ type PodmanConfig struct {
 	SSHMode        string
 	MachineMode    bool
 	TransientStore bool
+	ReadOnlyState  bool
 	GraphRoot      string
 	PullOptions    []string
 }
@@ -17,16 +17,22 @@ type ContainerCopyFunc = types.ContainerCopyFunc
 type ContainerEngine interface { //nolint:interfacebloat
 	AutoUpdate(ctx context.Context, options AutoUpdateOptions) ([]*AutoUpdateReport, []error)
 	Config(ctx context.Context) (*config.Config, error)
+	ContainerAnnotate(ctx context.Context, nameOrID string, options ContainerAnnotateOptions) (map[string]string, error)
 	ContainerAttach(ctx context.Context, nameOrID string, options AttachOptions) error
 	ContainerCheckpoint(ctx context.Context, namesOrIds []string, options CheckpointOptions) ([]*CheckpointReport, error)
+	ContainerCheckpointList(ctx context.Context, options CheckpointListOptions) ([]*define.CheckpointRecord, error)
+	ContainerCheckpointPrune(ctx context.Context, options CheckpointPruneOptions) ([]*reports.PruneReport, error)
 	ContainerCleanup(ctx context.Context, namesOrIds []string, options ContainerCleanupOptions) ([]*ContainerCleanupReport, error)
 	ContainerClone(ctx context.Context, ctrClone ContainerCloneOptions) (*ContainerCreateReport, error)
 	ContainerCommit(ctx context.Context, nameOrID string, options CommitOptions) (*CommitReport, error)
 	ContainerCopyFromArchive(ctx context.Context, nameOrID, path string, reader io.Reader, options CopyOptions) (ContainerCopyFunc, error)
 	ContainerCopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (ContainerCopyFunc, error)
 	ContainerCreate(ctx context.Context, s *specgen.SpecGenerator) (*ContainerCreateReport, error)
+	ContainerEmitEvent(ctx context.Context, nameOrID string, options ContainerEmitEventOptions) error
 	ContainerExec(ctx context.Context, nameOrID string, options ExecOptions, streams define.AttachStreams) (int, error)
 	ContainerExecDetached(ctx context.Context, nameOrID string, options ExecOptions) (string, error)
+	ContainerExecInspect(ctx context.Context, ids []string) ([]*define.InspectExecSession, error)
+	ContainerExecList(ctx context.Context, namesOrIds []string) ([]*ContainerExecListReport, error)
 	ContainerExists(ctx context.Context, nameOrID string, options ContainerExistsOptions) (*BoolReport, error)
 	ContainerExport(ctx context.Context, nameOrID string, options ContainerExportOptions) error
 	ContainerInit(ctx context.Context, namesOrIds []string, options ContainerInitOptions) ([]*ContainerInitReport, error)
@@ -36,9 +42,11 @@ type ContainerEngine interface { //nolint:interfacebloat
 	ContainerListExternal(ctx context.Context) ([]ListContainer, error)
 	ContainerLogs(ctx context.Context, containers []string, options ContainerLogsOptions) error
 	ContainerMount(ctx context.Context, nameOrIDs []string, options ContainerMountOptions) ([]*ContainerMountReport, error)
+	ContainerNotes(ctx context.Context, nameOrID string, options ContainerNotesOptions) ([]*define.ContainerNote, error)
 	ContainerPause(ctx context.Context, namesOrIds []string, options PauseUnPauseOptions) ([]*PauseUnpauseReport, error)
 	ContainerPort(ctx context.Context, nameOrID string, options ContainerPortOptions) ([]*ContainerPortReport, error)
 	ContainerPrune(ctx context.Context, options ContainerPruneOptions) ([]*reports.PruneReport, error)
+	ContainerRefresh(ctx context.Context, nameOrID string, options ContainerRefreshOptions) (*ContainerRefreshReport, error)
 	ContainerRename(ctr context.Context, nameOrID string, options ContainerRenameOptions) error
 	ContainerRestart(ctx context.Context, namesOrIds []string, options RestartOptions) ([]*RestartReport, error)
 	ContainerRestore(ctx context.Context, namesOrIds []string, options RestoreOptions) ([]*RestoreReport, error)
@@ -48,33 +56,45 @@ type ContainerEngine interface { //nolint:interfacebloat
 	ContainerStart(ctx context.Context, namesOrIds []string, options ContainerStartOptions) ([]*ContainerStartReport, error)
 	ContainerStat(ctx context.Context, nameOrDir string, path string) (*ContainerStatReport, error)
 	ContainerStats(ctx context.Context, namesOrIds []string, options ContainerStatsOptions) (chan ContainerStatsReport, error)
+	ContainerStatsHistory(ctx context.Context, nameOrID string, options ContainerStatsHistoryOptions) ([]define.ContainerStats, error)
 	ContainerStop(ctx context.Context, namesOrIds []string, options StopOptions) ([]*StopReport, error)
+	ContainerTemplatePull(ctx context.Context, source string, options ContainerTemplatePullOptions) (*ContainerTemplatePullReport, error)
+	ContainerTemplatePush(ctx context.Context, destination string, options ContainerTemplatePushOptions) (*ContainerTemplatePushReport, error)
 	ContainerTop(ctx context.Context, options TopOptions) (*StringSliceReport, error)
 	ContainerUnmount(ctx context.Context, nameOrIDs []string, options ContainerUnmountOptions) ([]*ContainerUnmountReport, error)
 	ContainerUnpause(ctx context.Context, namesOrIds []string, options PauseUnPauseOptions) ([]*PauseUnpauseReport, error)
 	ContainerUpdate(ctx context.Context, options *ContainerUpdateOptions) (string, error)
 	ContainerWait(ctx context.Context, namesOrIds []string, options WaitOptions) ([]WaitReport, error)
+	ContainerWatchProcesses(ctx context.Context, nameOrID string) error
 	Diff(ctx context.Context, namesOrIds []string, options DiffOptions) (*DiffReport, error)
 	Events(ctx context.Context, opts EventsOptions) error
 	GenerateSpec(ctx context.Context, opts *GenerateSpecOptions) (*GenerateSpecReport, error)
 	GenerateSystemd(ctx context.Context, nameOrID string, opts GenerateSystemdOptions) (*GenerateSystemdReport, error)
 	GenerateKube(ctx context.Context, nameOrIDs []string, opts GenerateKubeOptions) (*GenerateKubeReport, error)
 	SystemPrune(ctx context.Context, options SystemPruneOptions) (*SystemPruneReport, error)
+	HealthCheckHistory(ctx context.Context, nameOrID string, options HealthCheckHistoryOptions) ([]define.HealthCheckHistoryEntry, error)
 	HealthCheckRun(ctx context.Context, nameOrID string, options HealthCheckOptions) (*define.HealthCheckResults, error)
 	Info(ctx context.Context) (*define.Info, error)
 	KubeApply(ctx context.Context, body io.Reader, opts ApplyOptions) error
 	Locks(ctx context.Context) (*LocksReport, error)
-	Migrate(ctx context.Context, options SystemMigrateOptions) error
+	Migrate(ctx context.Context, options SystemMigrateOptions) (*define.DBMigrationReport, error)
 	NetworkConnect(ctx context.Context, networkname string, options NetworkConnectOptions) error
 	NetworkCreate(ctx context.Context, network netTypes.Network, createOptions *netTypes.NetworkCreateOptions) (*netTypes.Network, error)
 	NetworkUpdate(ctx context.Context, networkname string, options NetworkUpdateOptions) error
 	NetworkDisconnect(ctx context.Context, networkname string, options NetworkDisconnectOptions) error
+	NetworkDisturb(ctx context.Context, nameOrID string, options NetworkDisturbOptions) error
 	NetworkExists(ctx context.Context, networkname string) (*BoolReport, error)
 	NetworkInspect(ctx context.Context, namesOrIds []string, options InspectOptions) ([]NetworkInspectReport, []error, error)
 	NetworkList(ctx context.Context, options NetworkListOptions) ([]netTypes.Network, error)
 	NetworkPrune(ctx context.Context, options NetworkPruneOptions) ([]*NetworkPruneReport, error)
 	NetworkReload(ctx context.Context, names []string, options NetworkReloadOptions) ([]*NetworkReloadReport, error)
+	NetworkReservations(ctx context.Context, network string) ([]*define.NetworkReservation, error)
+	ContainerGroupCreate(ctx context.Context, name string, options ContainerGroupCreateOptions) (*define.ContainerGroup, error)
+	ContainerGroupList(ctx context.Context) ([]*ContainerGroupReport, error)
+	ContainerGroupRm(ctx context.Context, name string) error
+	NetworkReserve(ctx context.Context, address string, options NetworkReserveOptions) (*define.NetworkReservation, error)
 	NetworkRm(ctx context.Context, namesOrIds []string, options NetworkRmOptions) ([]*NetworkRmReport, error)
+	NetworkUnreserve(ctx context.Context, network, address string) error
 	PlayKube(ctx context.Context, body io.Reader, opts PlayKubeOptions) (*PlayKubeReport, error)
 	PlayKubeDown(ctx context.Context, body io.Reader, opts PlayKubeDownOptions) (*PlayKubeReport, error)
 	PodCreate(ctx context.Context, specg PodSpec) (*PodCreateReport, error)
@@ -93,6 +113,7 @@ type ContainerEngine interface { //nolint:interfacebloat
 	PodStop(ctx context.Context, namesOrIds []string, options PodStopOptions) ([]*PodStopReport, error)
 	PodTop(ctx context.Context, options PodTopOptions) (*StringSliceReport, error)
 	PodUnpause(ctx context.Context, namesOrIds []string, options PodunpauseOptions) ([]*PodUnpauseReport, error)
+	PodUpdate(ctx context.Context, options *PodUpdateOptions) (string, error)
 	Renumber(ctx context.Context) error
 	Reset(ctx context.Context) error
 	SetupRootless(ctx context.Context, noMoveProcess bool, cgroupMode string) error
@@ -100,12 +121,28 @@ type ContainerEngine interface { //nolint:interfacebloat
 	SecretInspect(ctx context.Context, nameOrIDs []string, options SecretInspectOptions) ([]*SecretInfoReport, []error, error)
 	SecretList(ctx context.Context, opts SecretListRequest) ([]*SecretInfoReport, error)
 	SecretRm(ctx context.Context, nameOrID []string, opts SecretRmOptions) ([]*SecretRmReport, error)
+	SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader, options SecretUpdateOptions) (*SecretUpdateReport, error)
 	SecretExists(ctx context.Context, nameOrID string) (*BoolReport, error)
 	Shutdown(ctx context.Context)
 	SystemDf(ctx context.Context, options SystemDfOptions) (*SystemDfReport, error)
 	SystemCheck(ctx context.Context, options SystemCheckOptions) (*SystemCheckReport, error)
+	SystemDBCheck(ctx context.Context) (*define.DBCheckReport, error)
+	SystemDoctor(ctx context.Context) (*define.DoctorReport, error)
+	SystemEmulationDisable(ctx context.Context, archs []string) error
+	SystemEmulationEnable(ctx context.Context, archs []string, options SystemEmulationEnableOptions) error
+	SystemEmulationStatus(ctx context.Context) (*SystemEmulationReport, error)
+	SystemGraph(ctx context.Context) (*SystemGraphReport, error)
+	SystemDBVacuum(ctx context.Context) error
+	SystemDBBackup(ctx context.Context, path string) error
+	SystemDBRestore(ctx context.Context, path string) error
 	Unshare(ctx context.Context, args []string, options SystemUnshareOptions) error
 	Version(ctx context.Context) (*SystemVersionReport, error)
+	VolumeBackupDisable(ctx context.Context, name string) error
+	VolumeBackupEnable(ctx context.Context, name string, opts VolumeBackupEnableOptions) error
+	VolumeBackupInspect(ctx context.Context, names []string) ([]*VolumeBackupInspectReport, error)
+	VolumeBackupList(ctx context.Context, name string) ([]*define.VolumeBackup, error)
+	VolumeBackupRestore(ctx context.Context, name string, id int64) (*define.VolumeBackup, error)
+	VolumeBackupRun(ctx context.Context, names []string) ([]*define.VolumeBackup, error)
 	VolumeCreate(ctx context.Context, opts VolumeCreateOptions) (*IDOrNameResponse, error)
 	VolumeExists(ctx context.Context, namesOrID string) (*BoolReport, error)
 	VolumeMounted(ctx context.Context, namesOrID string) (*BoolReport, error)
@@ -114,6 +151,10 @@ type ContainerEngine interface { //nolint:interfacebloat
 	VolumeMount(ctx context.Context, namesOrIds []string) ([]*VolumeMountReport, error)
 	VolumePrune(ctx context.Context, options VolumePruneOptions) ([]*reports.PruneReport, error)
 	VolumeRm(ctx context.Context, namesOrIds []string, opts VolumeRmOptions) ([]*VolumeRmReport, error)
+	VolumeSnapshotCreate(ctx context.Context, name, snapshot string) (*define.VolumeSnapshot, error)
+	VolumeSnapshotList(ctx context.Context, name string) ([]*define.VolumeSnapshot, error)
+	VolumeSnapshotRemove(ctx context.Context, name string, snapshots []string) ([]*define.VolumeSnapshot, error)
+	VolumeSnapshotRestore(ctx context.Context, name, snapshot string) (*define.VolumeSnapshot, error)
 	VolumeUnmount(ctx context.Context, namesOrIds []string) ([]*VolumeUnmountReport, error)
 	VolumeReload(ctx context.Context) (*VolumeReloadReport, error)
 }
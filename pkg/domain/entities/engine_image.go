@@ -27,11 +27,15 @@ type ImageEngine interface { //nolint:interfacebloat
 	Search(ctx context.Context, term string, opts ImageSearchOptions) ([]ImageSearchReport, error)
 	SetTrust(ctx context.Context, args []string, options SetTrustOptions) error
 	ShowTrust(ctx context.Context, args []string, options ShowTrustOptions) (*ShowTrustReport, error)
+	TrustPolicyAdd(ctx context.Context, scope string, options TrustPolicyAddOptions) error
+	TrustPolicyRemove(ctx context.Context, scope string, options TrustPolicyRemoveOptions) error
+	TrustPolicyTest(ctx context.Context, image string, options TrustPolicyTestOptions) (*TrustPolicyTestReport, error)
 	Shutdown(ctx context.Context)
 	Tag(ctx context.Context, nameOrID string, tags []string, options ImageTagOptions) error
 	Tree(ctx context.Context, nameOrID string, options ImageTreeOptions) (*ImageTreeReport, error)
 	Unmount(ctx context.Context, images []string, options ImageUnmountOptions) ([]*ImageUnmountReport, error)
 	Untag(ctx context.Context, nameOrID string, tags []string, options ImageUntagOptions) error
+	Watch(ctx context.Context, options ImageWatchOptions) (chan ImageWatchReport, error)
 	ManifestCreate(ctx context.Context, name string, images []string, opts ManifestCreateOptions) (string, error)
 	ManifestExists(ctx context.Context, name string) (*BoolReport, error)
 	ManifestInspect(ctx context.Context, name string, opts ManifestInspectOptions) ([]byte, error)
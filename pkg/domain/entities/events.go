@@ -21,19 +21,33 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 			return nil
 		}
 	}
-	status, err := libpodEvents.StringToStatus(string(e.Action))
-	if err != nil {
-		return nil
-	}
 	t, err := libpodEvents.StringToType(string(e.Type))
 	if err != nil {
 		return nil
 	}
+	// Custom events carry a user-defined status
+	status := libpodEvents.Status(e.Action)
+	if t != libpodEvents.Custom {
+		status, err = libpodEvents.StringToStatus(string(e.Action))
+		if err != nil {
+			return nil
+		}
+	}
 	image := e.Actor.Attributes["image"]
 	name := e.Actor.Attributes["name"]
 	network := e.Actor.Attributes["network"]
 	podID := e.Actor.Attributes["podId"]
 	errorString := e.Actor.Attributes["error"]
+	var processID int
+	if pid, ok := e.Actor.Attributes["pid"]; ok {
+		var err error
+		processID, err = strconv.Atoi(pid)
+		if err != nil {
+			return nil
+		}
+	}
+	processCommand := e.Actor.Attributes["command"]
+	message := e.Actor.Attributes["message"]
 	details := e.Actor.Attributes
 	delete(details, "image")
 	delete(details, "name")
@@ -41,6 +55,9 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 	delete(details, "podId")
 	delete(details, "error")
 	delete(details, "containerExitCode")
+	delete(details, "pid")
+	delete(details, "command")
+	delete(details, "message")
 	return &libpodEvents.Event{
 		ContainerExitCode: &exitCode,
 		ID:                e.Actor.ID,
@@ -52,6 +69,9 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Type:              t,
 		HealthStatus:      e.HealthStatus,
 		Error:             errorString,
+		ProcessID:         processID,
+		ProcessCommand:    processCommand,
+		Message:           message,
 		Details: libpodEvents.Details{
 			PodID:      podID,
 			Attributes: details,
@@ -77,6 +97,13 @@ func ConvertToEntitiesEvent(e libpodEvents.Event) *types.Event {
 	if e.Error != "" {
 		attributes["error"] = e.Error
 	}
+	if e.Type == libpodEvents.Process {
+		attributes["pid"] = strconv.Itoa(e.ProcessID)
+		attributes["command"] = e.ProcessCommand
+	}
+	if e.Type == libpodEvents.Custom {
+		attributes["message"] = e.Message
+	}
 	message := dockerEvents.Message{
 		// Compatibility with clients that still look for deprecated API elements
 		Status: e.Status.String(),
@@ -1,3 +1,5 @@
 package entities
 
 type HealthCheckOptions struct{}
+
+type HealthCheckHistoryOptions struct{}
@@ -3,6 +3,7 @@ package entities
 import (
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/manifest"
@@ -71,13 +72,19 @@ type ImageRemoveOptions struct {
 // and images what was untagged vs actually removed.
 type ImageRemoveReport = entitiesTypes.ImageRemoveReport
 
-type ImageHistoryOptions struct{}
+type ImageHistoryOptions struct {
+	// Provenance also returns the recorded pulls of the image.
+	Provenance bool
+}
 
 type ImageHistoryLayer = entitiesTypes.ImageHistoryLayer
 type ImageHistoryReport = entitiesTypes.ImageHistoryReport
 
 // ImagePullOptions are the arguments for pulling images.
 type ImagePullOptions struct {
+	// AcceptNewDigest pins the digest of the pulled image if it differs
+	// from the digest pinned on first use.
+	AcceptNewDigest bool
 	// AllTags can be specified to pull all tags of an image. Note
 	// that this only works if the image does not include a tag.
 	AllTags bool
@@ -121,6 +128,32 @@ type ImagePullOptions struct {
 // ImagePullReport is the response from pulling one or more images.
 type ImagePullReport = entitiesTypes.ImagePullReport
 
+// ImageWatchOptions are the arguments for watching images.
+type ImageWatchOptions struct {
+	// Authfile is the path to the authentication file.
+	Authfile string
+	// AutoRestart restarts the systemd units of the containers with an
+	// auto-update policy using a watched image when a new image was
+	// pulled.
+	AutoRestart bool
+	// Images to watch, each optionally followed by "=" and the interval
+	// between two checks of the image, e.g. "quay.io/foo/bar:latest=10m".
+	// If empty, the images of the containers with the registry
+	// auto-update policy are watched.
+	Images []string
+	// Interval between two checks of the images without their own
+	// interval.
+	Interval time.Duration
+	// Rollback restarts the systemd units another time with the previous
+	// image if restarting them with the new image failed.
+	Rollback bool
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify types.OptionalBool
+}
+
+// ImageWatchReport is sent when a watched image changed.
+type ImageWatchReport = entitiesTypes.ImageWatchReport
+
 // ImagePushOptions are the arguments for pushing images.
 type ImagePushOptions struct {
 	// All indicates that all images referenced in a manifest list should be pushed
@@ -356,6 +389,34 @@ type SetTrustOptions struct {
 	Type        string
 }
 
+// TrustPolicyAddOptions describes the CLI options for adding a trust policy
+type TrustPolicyAddOptions struct {
+	Lookaside    string
+	PolicyPath   string
+	PubKeysFile  []string
+	RegistryPath string
+	Replace      bool
+	Transport    string
+	Type         string
+}
+
+// TrustPolicyRemoveOptions describes the CLI options for removing a trust policy
+type TrustPolicyRemoveOptions struct {
+	PolicyPath   string
+	RegistryPath string
+	Transport    string
+}
+
+// TrustPolicyTestOptions describes the CLI options for testing which trust
+// policy applies to an image
+type TrustPolicyTestOptions struct {
+	PolicyPath   string
+	RegistryPath string
+}
+
+// TrustPolicyTestReport describes the trust policy applying to an image
+type TrustPolicyTestReport = entitiesTypes.TrustPolicyTestReport
+
 // SignOptions describes input options for the CLI signing
 type SignOptions struct {
 	Directory string
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"net"
+	"time"
 
 	entitiesTypes "github.com/containers/podman/v5/pkg/domain/entities/types"
 )
@@ -18,6 +19,11 @@ type NetworkListOptions struct {
 type NetworkReloadOptions struct {
 	All    bool
 	Latest bool
+	// Pods reloads the networks of all containers in the given pods.
+	Pods []string
+	// Projects reloads the networks of all containers of the given
+	// compose projects.
+	Projects []string
 }
 
 // NetworkReloadReport describes the results of reloading a container network.
@@ -59,6 +65,33 @@ type NetworkUpdateOptions struct {
 	RemoveDNSServers []string `json:"removednsservers"`
 }
 
+// NetworkDisturbOptions describes options to disturb the network of a
+// container
+type NetworkDisturbOptions struct {
+	// Interface is the interface to disturb, all interfaces but the
+	// loopback if empty.
+	Interface string
+	// Latency is added to the outgoing packets.
+	Latency time.Duration
+	// Loss is the percentage of outgoing packets dropped.
+	Loss float64
+	// Duration after which the disturbance is reverted, until the
+	// context is done if 0.
+	Duration time.Duration
+	// Clear reverts all disturbances of the container instead.
+	Clear bool
+}
+
+// NetworkReserveOptions describes options for reserving an address of a
+// network
+type NetworkReserveOptions struct {
+	// Network the address belongs to. It is inferred from the subnet of
+	// an IP address if empty.
+	Network string
+	// Container or pod the address is reserved for.
+	Container string
+}
+
 // NetworkCreateReport describes a created network for the cli
 type NetworkCreateReport = entitiesTypes.NetworkCreateReport
 
@@ -84,3 +117,4 @@ type NetworkPruneOptions struct {
 
 type NetworkInspectReport = entitiesTypes.NetworkInspectReport
 type NetworkContainerInfo = entitiesTypes.NetworkContainerInfo
+type NetworkReservationInfo = entitiesTypes.NetworkReservationInfo
@@ -37,11 +37,17 @@ type PodunpauseOptions struct {
 
 type PodUnpauseReport = types.PodUnpauseReport
 
+// PodUpdateOptions are the options to update an existing pod
+type PodUpdateOptions = types.PodUpdateOptions
+
 type PodStopOptions struct {
 	All     bool
 	Ignore  bool
 	Latest  bool
 	Timeout int
+	// Parallel is the maximum number of containers stopped concurrently,
+	// the number of parallel jobs if 0.
+	Parallel uint
 }
 
 type PodStopReport = types.PodStopReport
@@ -55,6 +61,9 @@ type PodRestartReport = types.PodRestartReport
 type PodStartOptions struct {
 	All    bool
 	Latest bool
+	// Parallel is the maximum number of containers started concurrently,
+	// the number of parallel jobs if 0.
+	Parallel uint
 }
 
 type PodStartReport = types.PodStartReport
@@ -164,6 +173,7 @@ type ContainerCreateOptions struct {
 	Env                []string
 	EnvHost            bool
 	EnvFile            []string
+	EnvFileTemplate    string
 	Expose             []string
 	GIDMap             []string
 	GPUs               []string
@@ -176,6 +186,7 @@ type ContainerCreateOptions struct {
 	HealthOnFailure    string
 	Hostname           string `json:"hostname,omitempty"`
 	HTTPProxy          bool
+	ProxyProfile       string
 	HostUsers          []string
 	ImageVolume        string
 	Init               bool
@@ -209,6 +220,7 @@ type ContainerCreateOptions struct {
 	PreserveFD         []uint
 	Privileged         bool
 	PublishAll         bool
+	PublishSocket      []string
 	Pull               string
 	Quiet              bool
 	ReadOnly           bool
@@ -222,6 +234,8 @@ type ContainerCreateOptions struct {
 	RootFS             bool
 	Secrets            []string
 	SecurityOpt        []string `json:"security_opt,omitempty"`
+	SecurityProfile    string
+	Keyring            string
 	SdNotifyMode       string
 	ShmSize            string
 	ShmSizeSystemd     string
@@ -256,6 +270,12 @@ type ContainerCreateOptions struct {
 	Volume             []string `json:"volume,omitempty"`
 	VolumesFrom        []string `json:"volumes_from,omitempty"`
 	Workdir            string
+	WatchProcesses     bool
+	ProvisionScript    string
+	OnExitNotify       []string
+	Conditions         []string
+	ConditionTimeout   string
+	OnExitTemplate     string
 	SeccompPolicy      string
 	PidFile            string
 	ChrootDirs         []string
@@ -357,6 +377,7 @@ func ToPodSpecGen(s specgen.PodSpecGenerator, p *PodCreateOptions) (*specgen.Pod
 		s.PortMappings = p.Net.PublishPorts
 		s.Networks = p.Net.Networks
 		s.NetworkOptions = p.Net.NetworkOptions
+		s.InterfaceOptions = p.Net.InterfaceOptions
 		if p.Net.UseImageResolvConf {
 			s.NoManageResolvConf = true
 		}
@@ -477,6 +498,7 @@ func PodLogsOptionsToContainerLogsOptions(options PodLogsOptions) ContainerLogsO
 		Tail:         options.Tail,
 		Timestamps:   options.Timestamps,
 		Colors:       options.Colors,
+		Redact:       options.Redact,
 		StdoutWriter: options.StdoutWriter,
 		StderrWriter: options.StderrWriter,
 	}
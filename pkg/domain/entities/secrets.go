@@ -14,6 +14,26 @@ type SecretCreateOptions struct {
 	Replace    bool
 }
 
+type SecretUpdateReport = types.SecretUpdateReport
+
+const (
+	// SecretNotifySignal sends a signal to the running containers using
+	// an updated secret.
+	SecretNotifySignal = "signal"
+	// SecretNotifyRestart restarts the running containers using an
+	// updated secret.
+	SecretNotifyRestart = "restart"
+)
+
+type SecretUpdateOptions struct {
+	// Notify is how the running containers using the secret are told
+	// about the update: SecretNotifySignal, SecretNotifyRestart or empty
+	// to only update the secret files mounted into them.
+	Notify string
+	// Signal is sent with SecretNotifySignal, SIGHUP if empty.
+	Signal string
+}
+
 type SecretInspectOptions struct {
 	ShowSecret bool
 }
@@ -62,6 +82,15 @@ type SwagSecretCreateResponse struct {
 	}
 }
 
+// Secret update response
+// swagger:response SecretUpdateResponse
+type SwagSecretUpdateResponse struct {
+	// in:body
+	Body struct {
+		SecretUpdateReport
+	}
+}
+
 // Secret list response
 // swagger:response SecretListResponse
 type SwagSecretListResponse struct {
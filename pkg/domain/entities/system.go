@@ -11,6 +11,12 @@ type SystemPruneReport = types.SystemPruneReport
 type SystemMigrateOptions = types.SystemMigrateOptions
 type SystemCheckOptions = types.SystemCheckOptions
 type SystemCheckReport = types.SystemCheckReport
+type SystemGraphReport = types.SystemGraphReport
+type SystemGraphNode = types.SystemGraphNode
+type SystemGraphEdge = types.SystemGraphEdge
+type SystemEmulationEnableOptions = types.SystemEmulationEnableOptions
+type SystemEmulationReport = types.SystemEmulationReport
+type SystemEmulationPlatform = types.SystemEmulationPlatform
 type SystemDfOptions = types.SystemDfOptions
 type SystemDfReport = types.SystemDfReport
 type SystemDfImageReport = types.SystemDfImageReport
@@ -60,6 +60,9 @@ type NetOptions struct {
 	PublishPorts       []types.PortMapping                `json:"portmappings,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// InterfaceOptions are the MTU and the offloads of the interfaces in
+	// bridge networks
+	InterfaceOptions map[string]string `json:"interface_options,omitempty"`
 }
 
 // InspectOptions all CLI inspect commands and inspect sub-commands use the same options
@@ -74,6 +77,12 @@ type InspectOptions struct {
 	Type string `json:",omitempty"`
 	// All -- inspect all
 	All bool `json:",omitempty"`
+	// Fields (containers only) - compute and return only the given
+	// dot-separated fields, e.g. State.Status.
+	Fields []string `json:",omitempty"`
+	// EffectiveConfig (containers only) - include the containers.conf
+	// settings the container was created with.
+	EffectiveConfig bool `json:",omitempty"`
 }
 
 // DiffOptions all API and CLI diff commands and diff sub-commands use the same options
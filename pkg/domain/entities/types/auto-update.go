@@ -0,0 +1,18 @@
+package types
+
+// AutoUpdateReport contains the results from running auto-update.
+type AutoUpdateReport struct {
+	// ID of the container *before* an update.
+	ContainerID string
+	// Name of the container *before* an update.
+	ContainerName string
+	// Name of the image.
+	ImageName string
+	// The configured auto-update policy.
+	Policy string
+	// SystemdUnit running a container configured for auto updates.
+	SystemdUnit string
+	// Indicates the update status: true, false, failed, pending (see
+	// DryRun).
+	Updated string
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/inspect"
 	"github.com/containers/podman/v5/pkg/trust"
 )
@@ -88,6 +89,9 @@ type ShowTrustReport struct {
 	Policies                []*trust.Policy
 }
 
+// TrustPolicyTestReport describes the trust policy applying to an image
+type TrustPolicyTestReport = trust.PolicyMatch
+
 // ImageMountReport describes the response from image mount
 type ImageMountReport struct {
 	Id           string //nolint:revive,stylecheck
@@ -134,6 +138,9 @@ type ImageHistoryLayer struct {
 
 type ImageHistoryReport struct {
 	Layers []ImageHistoryLayer
+	// Provenance holds the pulls of the image and all other pulls from
+	// the sources it was pulled from, oldest first.
+	Provenance []*define.ImageProvenance `json:",omitempty"`
 }
 
 type ImagePullReport struct {
@@ -147,6 +154,23 @@ type ImagePullReport struct {
 	ID string `json:"id,omitempty"`
 }
 
+// ImageWatchReport is sent by podman image watch when a new image of a watched
+// name was pulled, or when checking or pulling it failed.
+type ImageWatchReport struct {
+	// Time the image was checked.
+	Time time.Time
+	// Image is the name of the watched image.
+	Image string
+	// ID of the pulled image.
+	ID string `json:",omitempty"`
+	// Digest of the pulled image.
+	Digest string `json:",omitempty"`
+	// Updates of the containers restarted with the pulled image.
+	Updates []*AutoUpdateReport `json:",omitempty"`
+	// Error of the check, the pull or the restarts.
+	Error string `json:",omitempty"`
+}
+
 type ImagePushStream struct {
 	// ManifestDigest is the digest of the manifest of the pushed image.
 	ManifestDigest string `json:"manifestdigest,omitempty"`
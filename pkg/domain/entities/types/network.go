@@ -17,6 +17,8 @@ type NetworkReloadReport struct {
 	//nolint:stylecheck,revive
 	Id  string
 	Err error
+	// Ports lists the configured port mappings of the container.
+	Ports []string
 }
 
 // NetworkConnectOptions describes options for connecting
@@ -40,6 +42,9 @@ type NetworkInspectReport struct {
 	commonTypes.Network
 
 	Containers map[string]NetworkContainerInfo `json:"containers"`
+
+	// Addresses of the network reserved for containers
+	Reservations []NetworkReservationInfo `json:"reservations,omitempty"`
 }
 
 type NetworkContainerInfo struct {
@@ -49,3 +54,14 @@ type NetworkContainerInfo struct {
 	// Interfaces configured for this container with their addresses
 	Interfaces map[string]commonTypes.NetInterface `json:"interfaces,omitempty"`
 }
+
+type NetworkReservationInfo struct {
+	// Reserved IP or MAC address
+	Address string `json:"address"`
+
+	// Name of the container or pod the address is reserved for
+	Container string `json:"container"`
+
+	// InUse is true if the address is assigned to the container
+	InUse bool `json:"inUse"`
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/specgen"
 )
@@ -48,6 +49,13 @@ type PodCreateReport struct {
 	Id string //nolint:revive,stylecheck
 }
 
+// PodUpdateOptions are the options to update an existing pod.
+type PodUpdateOptions struct {
+	NameOrID string `json:"-"`
+	// PortMappings replace the ports published by the pod.
+	PortMappings []nettypes.PortMapping `json:"portMappings"`
+}
+
 type PodCloneReport struct {
 	Id string //nolint:revive,stylecheck
 }
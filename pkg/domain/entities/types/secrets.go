@@ -11,6 +11,7 @@ type SecretSpec struct {
 }
 
 type SecretVersion struct {
+	// Index is incremented every time the secret data is updated.
 	Index int
 }
 
@@ -23,6 +24,14 @@ type SecretCreateReport struct {
 	ID string
 }
 
+type SecretUpdateReport struct {
+	ID string
+	// Version is the new version of the secret.
+	Version int
+	// Containers are the IDs of the containers using the secret.
+	Containers []string
+}
+
 type SecretListReport struct {
 	ID        string
 	Name      string
@@ -40,11 +49,11 @@ type SecretInfoReport struct {
 	ID         string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	Version    SecretVersion
 	Spec       SecretSpec
 	SecretData string `json:"SecretData,omitempty"`
 }
 
 type SecretInfoReportCompat struct {
 	SecretInfoReport
-	Version SecretVersion
 }
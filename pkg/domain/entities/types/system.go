@@ -9,10 +9,19 @@ import (
 
 // ServiceOptions provides the input for starting an API and sidecar pprof services
 type ServiceOptions struct {
-	CorsHeaders string        // Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr   string        // Network address to bind pprof profiles service
-	Timeout     time.Duration // Duration of inactivity the service should wait before shutting down
-	URI         string        // Path to unix domain socket service should listen on
+	CorsHeaders          string         // Cross-Origin Resource Sharing (CORS) headers
+	PProfAddr            string         // Network address to bind pprof profiles service
+	Timeout              time.Duration  // Duration of inactivity the service should wait before shutting down
+	DrainTimeout         time.Duration  // Duration to wait for in-flight requests when shutting down
+	URI                  string         // Path to unix domain socket service should listen on
+	ViewerUIDs           []uint32       // Socket peer UIDs restricted to read-only endpoints
+	ViewerCNs            []string       // Common names of TLS client certificates restricted to read-only endpoints
+	RateLimits           map[string]int // Requests per minute allowed per endpoint class (build, pull, exec)
+	MaxInFlight          map[string]int // Concurrent requests allowed per endpoint class (build, pull, exec)
+	DBCheckpointInterval time.Duration  // Interval to truncate the write-ahead log of the database, 0 to disable
+	StatsInterval        time.Duration  // Interval to record the stats of running containers, 0 to disable
+	StatsHistorySize     uint           // Stats samples kept per container, 0 keeps all of them
+	RestartInterval      time.Duration  // Interval to restart the containers whose restart policy requires it, 0 to disable
 }
 
 // SystemCheckOptions provides options for checking storage consistency.
@@ -37,6 +46,55 @@ type SystemCheckReport struct {
 	RemovedContainers map[string]string   // container ID → name
 }
 
+// SystemGraphNode is a container, pod, volume or network in the dependency
+// graph.
+type SystemGraphNode struct {
+	Type string // "container", "pod", "volume" or "network"
+	ID   string // ID of containers and pods, name of volumes and networks
+	Name string
+}
+
+// SystemGraphEdge is a dependency of a container on another node of the
+// dependency graph.
+type SystemGraphEdge struct {
+	Type string // "container", "pod", "volume" or "network", the type of the node depended on
+	From string // ID of the container
+	To   string // ID or name of the node depended on
+}
+
+// SystemGraphReport is the dependency graph of all containers, pods, volumes
+// and networks.
+type SystemGraphReport struct {
+	Nodes []SystemGraphNode
+	Edges []SystemGraphEdge
+}
+
+// SystemEmulationEnableOptions provides options for enabling the emulation of
+// foreign architectures.
+type SystemEmulationEnableOptions struct {
+	// Image is a helper image registering the qemu-user handlers, used
+	// instead of the handlers installed on the host.
+	Image string
+}
+
+// SystemEmulationReport lists the platforms which can be emulated and whether
+// images built for them can be run.
+type SystemEmulationReport struct {
+	Native    string
+	Platforms []SystemEmulationPlatform
+}
+
+// SystemEmulationPlatform is the emulation state of a foreign platform.
+type SystemEmulationPlatform struct {
+	Platform string
+	// Installed is set if a qemu-user handler for the platform is
+	// installed on the host.
+	Installed bool
+	// Registered is set if a qemu-user handler for the platform is
+	// registered with binfmt_misc, so images of the platform can be run.
+	Registered bool
+}
+
 // SystemPruneOptions provides options to prune system.
 type SystemPruneOptions struct {
 	All      bool
@@ -59,6 +117,8 @@ type SystemPruneReport struct {
 // cli to migrate runtimes of containers
 type SystemMigrateOptions struct {
 	NewRuntime string
+	// NewDB is the database backend to migrate the state to.
+	NewDB string
 }
 
 // SystemDfOptions describes the options for getting df information
@@ -3,6 +3,7 @@ package entities
 import (
 	"net/url"
 
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 )
 
@@ -46,3 +47,18 @@ type VolumeMountReport = types.VolumeMountReport
 
 // VolumeUnmountReport describes the response from umounting a volume
 type VolumeUnmountReport = types.VolumeUnmountReport
+
+// VolumeBackupEnableOptions describes the scheduled backups of a volume
+type VolumeBackupEnableOptions struct {
+	Schedule string
+	Keep     int
+	Target   string
+	Identity string
+}
+
+// VolumeBackupInspectReport describes the backup policy and the backups of
+// a volume
+type VolumeBackupInspectReport struct {
+	Policy  *define.VolumeBackupPolicy `json:"policy,omitempty"`
+	Backups []*define.VolumeBackup     `json:"backups"`
+}
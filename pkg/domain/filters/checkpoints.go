@@ -0,0 +1,54 @@
+//go:build !remote
+
+package filters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/common/pkg/filters"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+)
+
+// GenerateCheckpointFilters returns a function filtering the records of
+// exported checkpoints. The container filter matches the name, ID or ID
+// prefix the container had when it was checkpointed, as it may have been
+// removed since.
+func GenerateCheckpointFilters(filter string, filterValues []string) (libpod.CheckpointFilter, error) {
+	switch filter {
+	case "container":
+		return func(record *define.CheckpointRecord) bool {
+			for _, val := range filterValues {
+				if record.ContainerName == val || strings.HasPrefix(record.ContainerID, val) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "pre-checkpoint":
+		for _, val := range filterValues {
+			if _, err := strconv.ParseBool(val); err != nil {
+				return nil, fmt.Errorf("%q is not a valid value for the \"pre-checkpoint\" filter - must be true or false", val)
+			}
+		}
+		return func(record *define.CheckpointRecord) bool {
+			for _, val := range filterValues {
+				if pre, _ := strconv.ParseBool(val); pre == record.PreCheckpoint {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "until":
+		until, err := filters.ComputeUntilTimestamp(filterValues)
+		if err != nil {
+			return nil, err
+		}
+		return func(record *define.CheckpointRecord) bool {
+			return !until.IsZero() && record.Created.Before(until)
+		}, nil
+	}
+	return nil, fmt.Errorf("%q is an invalid checkpoint filter", filter)
+}
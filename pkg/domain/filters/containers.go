@@ -5,6 +5,7 @@ package filters
 import (
 	"errors"
 	"fmt"
+	"regexp/syntax"
 	"slices"
 	"strconv"
 	"strings"
@@ -256,6 +257,33 @@ func GenerateContainerFilterFuncs(filter string, filterValues []string, r *libpo
 			}
 			return false
 		}, nil
+	case "annotation":
+		// the operator annotations set with podman container annotate,
+		// matched like labels
+		return func(c *libpod.Container) bool {
+			annotations, err := c.OperatorAnnotations()
+			if err != nil {
+				return false
+			}
+			return filters.MatchLabelFilters(filterValues, annotations)
+		}, nil
+	case "note":
+		// we only have to match one of the notes, case insensitive
+		return func(c *libpod.Container) bool {
+			notes, err := c.Notes()
+			if err != nil {
+				return false
+			}
+			for _, note := range notes {
+				text := strings.ToLower(note.Text)
+				for _, val := range filterValues {
+					if strings.Contains(text, strings.ToLower(val)) {
+						return true
+					}
+				}
+			}
+			return false
+		}, nil
 	case "restart-policy":
 		invalidPolicyNames := []string{}
 		for _, policy := range filterValues {
@@ -282,10 +310,161 @@ func GenerateContainerFilterFuncs(filter string, filterValues []string, r *libpo
 			}
 			return false
 		}, filterValueError
+	case "group":
+		// A container has to match all filters of one of the groups.
+		groupFuncs := make([][]libpod.ContainerFilter, 0, len(filterValues))
+		for _, name := range filterValues {
+			group, err := r.ContainerGroup(name)
+			if err != nil {
+				return nil, err
+			}
+			funcs, err := GenerateContainerGroupFilterFuncs(group.Filters, r)
+			if err != nil {
+				return nil, fmt.Errorf("container group %s: %w", name, err)
+			}
+			groupFuncs = append(groupFuncs, funcs)
+		}
+		return func(c *libpod.Container) bool {
+			for _, funcs := range groupFuncs {
+				if slices.IndexFunc(funcs, func(f libpod.ContainerFilter) bool { return !f(c) }) < 0 {
+					return true
+				}
+			}
+			return false
+		}, nil
 	}
 	return nil, fmt.Errorf("%s is an invalid filter", filter)
 }
 
+// GenerateContainerGroupFilterFuncs returns the filter functions of the
+// filters of a container group. Groups cannot refer to other groups.
+func GenerateContainerGroupFilterFuncs(filters map[string][]string, r *libpod.Runtime) ([]libpod.ContainerFilter, error) {
+	funcs := make([]libpod.ContainerFilter, 0, len(filters))
+	for k, v := range filters {
+		if k == "group" {
+			return nil, fmt.Errorf("container groups cannot be nested: %w", define.ErrInvalidArg)
+		}
+		f, err := GenerateContainerFilterFuncs(k, v, r)
+		if err != nil {
+			return nil, err
+		}
+		funcs = append(funcs, f)
+	}
+	return funcs, nil
+}
+
+// GenerateContainerStateFilters returns the part of the given filters which
+// the database can evaluate itself, so that containers not matching them are
+// not even loaded. The filter functions from GenerateContainerFilterFuncs must
+// still be applied to the returned containers, as only filters that can be
+// expressed exactly are pushed down.
+func GenerateContainerStateFilters(filters map[string][]string, r *libpod.Runtime) *libpod.StateContainerFilters {
+	stateFilters := &libpod.StateContainerFilters{
+		Labels: labelStateFilters(filters["label"]),
+	}
+
+	// Names are matched by regular expressions, only those anchored to a
+	// literal prefix can be pushed down.
+	for _, filterValue := range filters["name"] {
+		prefix := anchoredLiteralPrefix(strings.ReplaceAll(filterValue, "/", ""))
+		if prefix == "" {
+			stateFilters.NamePrefixes = nil
+			break
+		}
+		stateFilters.NamePrefixes = append(stateFilters.NamePrefixes, prefix)
+	}
+
+	for _, podNameOrID := range filters["pod"] {
+		p, err := r.LookupPod(podNameOrID)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchPod) {
+				continue
+			}
+			// Let the pod filter function report the error.
+			stateFilters.PodIDs = nil
+			break
+		}
+		stateFilters.PodIDs = append(stateFilters.PodIDs, p.ID())
+	}
+
+	for _, policy := range filters["restart-policy"] {
+		stateFilters.RestartPolicies = append(stateFilters.RestartPolicies, policy)
+		if policy == "none" {
+			stateFilters.RestartPolicies = append(stateFilters.RestartPolicies, define.RestartPolicyNone)
+		}
+	}
+
+	// Both are relative to the earliest of the given containers, see
+	// GenerateContainerFilterFuncs.
+	stateFilters.CreatedBefore = earliestCreatedTime(filters["before"], r)
+	stateFilters.CreatedAfter = earliestCreatedTime(filters["since"], r)
+
+	return stateFilters
+}
+
+// earliestCreatedTime returns the creation time of the earliest created of the
+// given containers. It returns the zero time if one of them cannot be looked
+// up, the filter function reports the error.
+func earliestCreatedTime(nameOrIDs []string, r *libpod.Runtime) time.Time {
+	var createTime time.Time
+	for _, nameOrID := range nameOrIDs {
+		ctr, err := r.LookupContainer(nameOrID)
+		if err != nil {
+			return time.Time{}
+		}
+		if createTime.IsZero() || createTime.After(ctr.CreatedTime()) {
+			createTime = ctr.CreatedTime()
+		}
+	}
+	return createTime
+}
+
+// GeneratePruneContainerStateFilters is the GenerateContainerStateFilters
+// counterpart of GeneratePruneContainerFilterFuncs.
+func GeneratePruneContainerStateFilters(filters map[string][]string) *libpod.StateContainerFilters {
+	return &libpod.StateContainerFilters{
+		Labels: labelStateFilters(filters["label"]),
+	}
+}
+
+// labelStateFilters returns the label filters which the database can match
+// exactly, in the form expected by libpod.StateContainerFilters.
+func labelStateFilters(filterValues []string) []string {
+	var labels []string
+	for _, filterValue := range filterValues {
+		key, value, _ := strings.Cut(filterValue, "=")
+		// Keys with wildcards are matched as patterns.
+		if strings.Contains(key, "*") {
+			continue
+		}
+		// An empty value matches any value.
+		if value == "" {
+			labels = append(labels, key)
+		} else {
+			labels = append(labels, filterValue)
+		}
+	}
+	return labels
+}
+
+// anchoredLiteralPrefix returns the literal prefix all strings matching the
+// regular expression must start with, or "" if there is none.
+func anchoredLiteralPrefix(expr string) string {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	re = re.Simplify()
+	if re.Op != syntax.OpConcat || len(re.Sub) < 2 {
+		return ""
+	}
+	anchor, literal := re.Sub[0], re.Sub[1]
+	if anchor.Op != syntax.OpBeginText || literal.Op != syntax.OpLiteral || literal.Flags&syntax.FoldCase != 0 {
+		return ""
+	}
+	return string(literal.Rune)
+}
+
 // GeneratePruneContainerFilterFuncs return ContainerFilter functions based of filter for prune operation
 func GeneratePruneContainerFilterFuncs(filter string, filterValues []string, r *libpod.Runtime) (func(container *libpod.Container) bool, error) {
 	switch filter {
@@ -0,0 +1,61 @@
+//go:build !remote
+
+package filters
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchoredLiteralPrefix(t *testing.T) {
+	tests := []struct {
+		expr   string
+		prefix string
+	}{
+		{"^web", "web"},
+		{"^web-.*", "web-"},
+		{"^web[0-9]+$", "web"},
+		{"web", ""},
+		{"^", ""},
+		{"^web|db", ""},
+		{"^(web|db)", ""},
+		{"^(?i)web", ""},
+		{"^[", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.prefix, anchoredLiteralPrefix(tt.expr), tt.expr)
+	}
+}
+
+func TestGenerateContainerStateFilters(t *testing.T) {
+	stateFilters := GenerateContainerStateFilters(map[string][]string{
+		"label":          {"app=web", "tier=", "com.example.*=x"},
+		"name":           {"^/web", "^db"},
+		"status":         {"running"},
+		"restart-policy": {"always", "none"},
+	}, nil)
+	assert.Equal(t, &libpod.StateContainerFilters{
+		Labels:          []string{"app=web", "tier"},
+		NamePrefixes:    []string{"web", "db"},
+		RestartPolicies: []string{"always", "none", ""},
+	}, stateFilters)
+
+	// A single name filter without literal prefix matches any name.
+	stateFilters = GenerateContainerStateFilters(map[string][]string{
+		"name": {"^web", "db"},
+	}, nil)
+	assert.Empty(t, stateFilters.NamePrefixes)
+}
+
+func TestGeneratePruneContainerStateFilters(t *testing.T) {
+	stateFilters := GeneratePruneContainerStateFilters(map[string][]string{
+		"label":  {"app=web", "tier=", "com.example.*=x"},
+		"label!": {"keep"},
+		"until":  {"1h"},
+	})
+	assert.Equal(t, &libpod.StateContainerFilters{
+		Labels: []string{"app=web", "tier"},
+	}, stateFilters)
+}
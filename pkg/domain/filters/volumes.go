@@ -9,6 +9,7 @@ import (
 
 	"github.com/containers/common/pkg/filters"
 	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/util"
 )
 
@@ -29,6 +30,8 @@ func GenerateVolumeFilters(filter string, filterValues []string, runtime *libpod
 			}
 			return false
 		}, nil
+	case "image":
+		return createImageFilterVolumeFunction(filterValues, runtime)
 	case "scope":
 		return func(v *libpod.Volume) bool {
 			for _, val := range filterValues {
@@ -108,12 +111,30 @@ func GeneratePruneVolumeFilters(filter string, filterValues []string, runtime *l
 		return func(v *libpod.Volume) bool {
 			return !filters.MatchLabelFilters(filterValues, v.Labels())
 		}, nil
+	case "image":
+		return createImageFilterVolumeFunction(filterValues, runtime)
 	case "until":
 		return createUntilFilterVolumeFunction(filterValues)
 	}
 	return nil, fmt.Errorf("%q is an invalid volume filter", filter)
 }
 
+// createImageFilterVolumeFunction matches the volumes of the image driver
+// backed by one of the given images.
+func createImageFilterVolumeFunction(filterValues []string, runtime *libpod.Runtime) (libpod.VolumeFilter, error) {
+	imageIDs := make(map[string]bool, len(filterValues))
+	for _, filterValue := range filterValues {
+		img, _, err := runtime.LibimageRuntime().LookupImage(filterValue, nil)
+		if err != nil {
+			return nil, err
+		}
+		imageIDs[img.ID()] = true
+	}
+	return func(v *libpod.Volume) bool {
+		return v.Driver() == define.VolumeDriverImage && imageIDs[v.ImageID()]
+	}, nil
+}
+
 func createUntilFilterVolumeFunction(filterValues []string) (libpod.VolumeFilter, error) {
 	until, err := filters.ComputeUntilTimestamp(filterValues)
 	if err != nil {
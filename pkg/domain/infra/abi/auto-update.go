@@ -10,3 +10,7 @@ import (
 func (ic *ContainerEngine) AutoUpdate(ctx context.Context, options entities.AutoUpdateOptions) ([]*entities.AutoUpdateReport, []error) {
 	return autoupdate.AutoUpdate(ctx, ic.Libpod, options)
 }
+
+func (ir *ImageEngine) Watch(ctx context.Context, options entities.ImageWatchOptions) (chan entities.ImageWatchReport, error) {
+	return autoupdate.Watch(ctx, ir.Libpod, options)
+}
@@ -0,0 +1,38 @@
+package abi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	dfilters "github.com/containers/podman/v5/pkg/domain/filters"
+)
+
+func (ic *ContainerEngine) ContainerGroupCreate(ctx context.Context, name string, options entities.ContainerGroupCreateOptions) (*define.ContainerGroup, error) {
+	// Reject invalid filters now rather than whenever the group is used.
+	if _, err := dfilters.GenerateContainerGroupFilterFuncs(options.Filters, ic.Libpod); err != nil {
+		return nil, fmt.Errorf("container group %s: %w", name, err)
+	}
+	return ic.Libpod.AddContainerGroup(name, options.Filters)
+}
+
+func (ic *ContainerEngine) ContainerGroupList(ctx context.Context) ([]*entities.ContainerGroupReport, error) {
+	groups, err := ic.Libpod.ContainerGroups()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ContainerGroupReport, 0, len(groups))
+	for _, group := range groups {
+		members, err := expandContainerGroups(ic.Libpod, []string{define.ContainerGroupPrefix + group.Name})
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, &entities.ContainerGroupReport{ContainerGroup: group, Containers: members})
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) ContainerGroupRm(ctx context.Context, name string) error {
+	return ic.Libpod.RemoveContainerGroup(name)
+}
@@ -0,0 +1,30 @@
+package abi
+
+import (
+	"context"
+
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/templates"
+)
+
+func (ic *ContainerEngine) ContainerTemplatePush(ctx context.Context, destination string, options entities.ContainerTemplatePushOptions) (*entities.ContainerTemplatePushReport, error) {
+	sys := options.SystemContext(ic.Libpod.SystemContext())
+	d, err := templates.Push(ctx, sys, destination, options.Spec, options.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerTemplatePushReport{Digest: d.String()}, nil
+}
+
+func (ic *ContainerEngine) ContainerTemplatePull(ctx context.Context, source string, options entities.ContainerTemplatePullOptions) (*entities.ContainerTemplatePullReport, error) {
+	sys := options.SystemContext(ic.Libpod.SystemContext())
+	template, err := templates.Pull(ctx, sys, source)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerTemplatePullReport{
+		Digest:      template.Digest.String(),
+		Annotations: template.Annotations,
+		Spec:        template.Spec,
+	}, nil
+}
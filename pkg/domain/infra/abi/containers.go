@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/containers/buildah"
+	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/cgroups"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/manifest"
@@ -56,6 +59,19 @@ type containerWrapper struct {
 func getContainers(runtime *libpod.Runtime, options getContainersOptions) ([]containerWrapper, error) {
 	var libpodContainers []*libpod.Container
 
+	if len(options.names) > 0 {
+		names, err := expandContainerGroups(runtime, options.names)
+		if err != nil {
+			return nil, err
+		}
+		// Only empty groups were given, which must not be mistaken
+		// for no names at all.
+		if len(names) == 0 {
+			return []containerWrapper{}, nil
+		}
+		options.names = names
+	}
+
 	switch {
 	case len(options.filters) > 0:
 		filterFuncs := make([]libpod.ContainerFilter, 0, len(options.filters))
@@ -157,6 +173,41 @@ func getContainers(runtime *libpod.Runtime, options getContainersOptions) ([]con
 	return containers, nil
 }
 
+// expandContainerGroups replaces the container groups, given as @NAME, among
+// the given container names or IDs with the IDs of their current members.
+func expandContainerGroups(runtime *libpod.Runtime, namesOrIDs []string) ([]string, error) {
+	if !slices.ContainsFunc(namesOrIDs, func(n string) bool { return strings.HasPrefix(n, define.ContainerGroupPrefix) }) {
+		return namesOrIDs, nil
+	}
+
+	expanded := make([]string, 0, len(namesOrIDs))
+	for _, n := range namesOrIDs {
+		name, isGroup := strings.CutPrefix(n, define.ContainerGroupPrefix)
+		if !isGroup {
+			expanded = append(expanded, n)
+			continue
+		}
+		group, err := runtime.ContainerGroup(name)
+		if err != nil {
+			return nil, err
+		}
+		filterFuncs, err := dfilters.GenerateContainerGroupFilterFuncs(group.Filters, runtime)
+		if err != nil {
+			return nil, fmt.Errorf("container group %s: %w", name, err)
+		}
+		ctrs, err := runtime.GetContainers(false, filterFuncs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, ctr := range ctrs {
+			if !slices.Contains(expanded, ctr.ID()) {
+				expanded = append(expanded, ctr.ID())
+			}
+		}
+	}
+	return expanded, nil
+}
+
 // ContainerExists returns whether the container exists in container storage
 func (ic *ContainerEngine) ContainerExists(ctx context.Context, nameOrID string, options entities.ContainerExistsOptions) (*entities.BoolReport, error) {
 	_, err := ic.Libpod.LookupContainer(nameOrID)
@@ -174,6 +225,25 @@ func (ic *ContainerEngine) ContainerExists(ctx context.Context, nameOrID string,
 	return &entities.BoolReport{Value: err == nil}, nil
 }
 
+// ContainerEmitEvent writes a custom event for the container.
+func (ic *ContainerEngine) ContainerEmitEvent(ctx context.Context, nameOrID string, options entities.ContainerEmitEventOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.EmitCustomEvent(options.Type, options.Message)
+}
+
+// ContainerWatchProcesses writes process events for the container until it
+// stops.
+func (ic *ContainerEngine) ContainerWatchProcesses(ctx context.Context, nameOrID string) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.WatchProcesses(ctx)
+}
+
 func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []string, options entities.WaitOptions) ([]entities.WaitReport, error) {
 	responses := make([]entities.WaitReport, 0, len(namesOrIds))
 	containers, err := getContainers(ic.Libpod, getContainersOptions{latest: options.Latest, ignore: options.Ignore, names: namesOrIds})
@@ -325,7 +395,8 @@ func (ic *ContainerEngine) ContainerPrune(ctx context.Context, options entities.
 
 		filterFuncs = append(filterFuncs, generatedFunc)
 	}
-	return ic.Libpod.PruneContainers(filterFuncs)
+	stateFilters := dfilters.GeneratePruneContainerStateFilters(options.Filters)
+	return ic.Libpod.PruneContainersFiltered(stateFilters, filterFuncs)
 }
 
 func (ic *ContainerEngine) ContainerKill(ctx context.Context, namesOrIds []string, options entities.KillOptions) ([]*entities.KillReport, error) {
@@ -493,10 +564,13 @@ func (ic *ContainerEngine) ContainerInspect(ctx context.Context, namesOrIds []st
 			return nil, nil, err
 		}
 
-		inspect, err := ctr.Inspect(options.Size)
+		inspect, err := ctr.InspectFields(options.Size, options.Fields)
 		if err != nil {
 			return nil, nil, err
 		}
+		if options.EffectiveConfig {
+			inspect.EffectiveConfig = ctr.EffectiveConfig()
+		}
 
 		return []*entities.ContainerInspectReport{
 			{
@@ -520,7 +594,7 @@ func (ic *ContainerEngine) ContainerInspect(ctx context.Context, namesOrIds []st
 			return nil, nil, err
 		}
 
-		inspect, err := ctr.Inspect(options.Size)
+		inspect, err := ctr.InspectFields(options.Size, options.Fields)
 		if err != nil {
 			// ErrNoSuchCtr is non-fatal, other errors will be
 			// treated as fatal.
@@ -530,6 +604,9 @@ func (ic *ContainerEngine) ContainerInspect(ctx context.Context, namesOrIds []st
 			}
 			return nil, nil, err
 		}
+		if options.EffectiveConfig {
+			inspect.EffectiveConfig = ctr.EffectiveConfig()
+		}
 
 		reports = append(reports, &entities.ContainerInspectReport{InspectContainerData: inspect})
 	}
@@ -634,10 +711,12 @@ func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds [
 		KeepRunning:    options.LeaveRunning,
 		PreCheckPoint:  options.PreCheckPoint,
 		WithPrevious:   options.WithPrevious,
+		PreDumpChain:   options.PreDumpChain,
 		Compression:    options.Compression,
 		PrintStats:     options.PrintStats,
 		FileLocks:      options.FileLocks,
 		CreateImage:    options.CreateImage,
+		SkipRecord:     options.SkipRecord,
 	}
 	// NOTE: all maps to running
 	containers, err := getContainers(ic.Libpod, getContainersOptions{running: options.All, latest: options.Latest, names: namesOrIds})
@@ -659,6 +738,75 @@ func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds [
 	return reports, nil
 }
 
+// ContainerAnnotate sets and removes the operator annotations of a container
+// and returns its annotations.
+func (ic *ContainerEngine) ContainerAnnotate(ctx context.Context, nameOrID string, options entities.ContainerAnnotateOptions) (map[string]string, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range options.Remove {
+		if err := ctr.SetOperatorAnnotation(key, ""); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range options.Set {
+		if err := ctr.SetOperatorAnnotation(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return ctr.OperatorAnnotations()
+}
+
+// ContainerNotes adds and removes notes of a container and returns its notes.
+func (ic *ContainerEngine) ContainerNotes(ctx context.Context, nameOrID string, options entities.ContainerNotesOptions) ([]*define.ContainerNote, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range options.Remove {
+		if err := ctr.RemoveNote(id); err != nil {
+			return nil, err
+		}
+	}
+	if options.Add != "" {
+		if _, err := ctr.AddNote(options.Add); err != nil {
+			return nil, err
+		}
+	}
+	return ctr.Notes()
+}
+
+// ContainerCheckpointList lists the checkpoints exported by containers.
+func (ic *ContainerEngine) ContainerCheckpointList(ctx context.Context, options entities.CheckpointListOptions) ([]*define.CheckpointRecord, error) {
+	filterFuncs, err := checkpointFilters(options.Filters)
+	if err != nil {
+		return nil, err
+	}
+	return ic.Libpod.Checkpoints(filterFuncs...)
+}
+
+// ContainerCheckpointPrune removes the checkpoints exported by containers.
+func (ic *ContainerEngine) ContainerCheckpointPrune(ctx context.Context, options entities.CheckpointPruneOptions) ([]*reports.PruneReport, error) {
+	filterFuncs, err := checkpointFilters(options.Filters)
+	if err != nil {
+		return nil, err
+	}
+	return ic.Libpod.PruneCheckpoints(filterFuncs)
+}
+
+func checkpointFilters(filterMap map[string][]string) ([]libpod.CheckpointFilter, error) {
+	filterFuncs := make([]libpod.CheckpointFilter, 0, len(filterMap))
+	for filter, filterValues := range filterMap {
+		filterFunc, err := dfilters.GenerateCheckpointFilters(filter, filterValues)
+		if err != nil {
+			return nil, err
+		}
+		filterFuncs = append(filterFuncs, filterFunc)
+	}
+	return filterFuncs, nil
+}
+
 func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []string, options entities.RestoreOptions) ([]*entities.RestoreReport, error) {
 	var (
 		ctrs                        []*libpod.Container
@@ -817,6 +965,7 @@ func makeExecConfig(options entities.ExecOptions, rt *libpod.Runtime) (*libpod.E
 	execConfig.PreserveFDs = options.PreserveFDs
 	execConfig.PreserveFD = options.PreserveFD
 	execConfig.AttachStdin = options.Interactive
+	execConfig.ToolsImage = options.ToolsImage
 
 	// Make an exit command
 	storageConfig := rt.StorageConfig()
@@ -922,6 +1071,56 @@ func (ic *ContainerEngine) ContainerExecDetached(ctx context.Context, nameOrID s
 	return id, nil
 }
 
+func (ic *ContainerEngine) ContainerExecInspect(ctx context.Context, ids []string) ([]*define.InspectExecSession, error) {
+	inspects := make([]*define.InspectExecSession, 0, len(ids))
+	for _, id := range ids {
+		session, err := ic.Libpod.GetExecSession(id)
+		if err != nil {
+			return nil, err
+		}
+		inspect, err := session.Inspect()
+		if err != nil {
+			return nil, fmt.Errorf("inspecting exec session %s: %w", id, err)
+		}
+		inspects = append(inspects, inspect)
+	}
+	return inspects, nil
+}
+
+func (ic *ContainerEngine) ContainerExecList(ctx context.Context, namesOrIds []string) ([]*entities.ContainerExecListReport, error) {
+	ctrIDs := make(map[string]bool, len(namesOrIds))
+	for _, nameOrID := range namesOrIds {
+		ctr, err := ic.Libpod.LookupContainer(nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		ctrIDs[ctr.ID()] = true
+	}
+
+	sessions, err := ic.Libpod.GetAllExecSessions()
+	if err != nil {
+		return nil, err
+	}
+	execReports := make([]*entities.ContainerExecListReport, 0, len(sessions))
+	for _, session := range sessions {
+		if len(ctrIDs) > 0 && !ctrIDs[session.ContainerID()] {
+			continue
+		}
+		execReport := &entities.ContainerExecListReport{
+			ID:          session.ID(),
+			ContainerID: session.ContainerID(),
+			State:       session.State.String(),
+			PID:         session.PID,
+			ExitCode:    session.ExitCode,
+		}
+		if session.Config != nil {
+			execReport.Command = session.Config.Command
+		}
+		execReports = append(execReports, execReport)
+	}
+	return execReports, nil
+}
+
 func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []string, options entities.ContainerStartOptions) ([]*entities.ContainerStartReport, error) {
 	reports := []*entities.ContainerStartReport{}
 	var exitCode = define.ExecErrorCodeGeneric
@@ -929,6 +1128,19 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 	if err != nil {
 		return nil, err
 	}
+	var ctrErrors map[string]error
+	if !options.Attach {
+		// Start the containers in the order of their dependencies,
+		// concurrently where possible.
+		ctrs := make([]*libpod.Container, 0, len(containers))
+		for _, ctr := range containers {
+			ctrs = append(ctrs, ctr.Container)
+		}
+		ctrErrors, err = ic.Libpod.StartContainers(ctx, ctrs, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// There can only be one container if attach was used
 	for i := range containers {
 		ctr := containers[i]
@@ -986,14 +1198,13 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 			return reports, nil
 		} // end attach
 
-		// Handle non-attach start
-		// If the container is in a pod, also set to recursively start dependencies
+		// Handle non-attach start, the containers were started above
 		report := &entities.ContainerStartReport{
 			Id:       ctr.ID(),
 			RawInput: ctr.rawInput,
 			ExitCode: 125,
 		}
-		if err := ctr.Start(ctx, true); err != nil {
+		if err := ctrErrors[ctr.ID()]; err != nil {
 			// Already running is no error for the start command as it is idempotent.
 			if errors.Is(err, define.ErrCtrStateRunning) {
 				// If all is set we only want to output the actual started containers
@@ -1243,8 +1454,16 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 	logChannel := make(chan *logs.LogLine, chSize)
 
 	libpodContainers := make([]*libpod.Container, len(containers))
+	redactors := make(map[string]*logs.Redactor)
 	for i := range containers {
 		libpodContainers[i] = containers[i].Container
+		if options.Redact {
+			redactor, err := containers[i].LogRedactor()
+			if err != nil {
+				return err
+			}
+			redactors[containers[i].ID()] = redactor
+		}
 	}
 
 	if err := ic.Libpod.Log(ctx, libpodContainers, logOpts, logChannel); err != nil {
@@ -1257,6 +1476,9 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 	}()
 
 	for line := range logChannel {
+		if redactor, ok := redactors[line.CID]; ok {
+			line.Msg = redactor.Redact(line.Msg)
+		}
 		line.Write(options.StdoutWriter, options.StderrWriter, logOpts)
 	}
 
@@ -1615,6 +1837,22 @@ func (ic *ContainerEngine) ContainerStats(ctx context.Context, namesOrIds []stri
 	return statsChan, nil
 }
 
+func (ic *ContainerEngine) ContainerStatsHistory(ctx context.Context, nameOrID string, options entities.ContainerStatsHistoryOptions) ([]define.ContainerStats, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := ctr.StatsHistory(options.Since)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]define.ContainerStats, 0, len(history))
+	for _, stats := range history {
+		reports = append(reports, *stats)
+	}
+	return reports, nil
+}
+
 // ShouldRestart returns whether the container should be restarted
 func (ic *ContainerEngine) ShouldRestart(ctx context.Context, nameOrID string) (*entities.BoolReport, error) {
 	ctr, err := ic.Libpod.LookupContainer(nameOrID)
@@ -1625,6 +1863,51 @@ func (ic *ContainerEngine) ShouldRestart(ctx context.Context, nameOrID string) (
 	return &entities.BoolReport{Value: ctr.ShouldRestart(ctx)}, nil
 }
 
+// ContainerRefresh rebuilds the given container from the latest version of
+// its image, or from the given image, keeping its identity.
+func (ic *ContainerEngine) ContainerRefresh(ctx context.Context, nameOrID string, options entities.ContainerRefreshOptions) (*entities.ContainerRefreshReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	oldImageID, _ := ctr.Image()
+	if oldImageID == "" {
+		return nil, fmt.Errorf("container %s was not created from an image and cannot be refreshed", ctr.ID())
+	}
+
+	imageName := options.Image
+	if imageName == "" {
+		imageName = ctr.RawImageName()
+	}
+	if imageName == "" {
+		_, imageName = ctr.Image()
+	}
+	pullPolicy, err := config.ParsePullPolicy(options.PullPolicy)
+	if err != nil {
+		return nil, err
+	}
+	pullOptions := &libimage.PullOptions{}
+	if !options.Quiet {
+		pullOptions.Writer = os.Stderr
+	}
+	if _, err := ic.Libpod.LibimageRuntime().Pull(ctx, imageName, pullPolicy, pullOptions); err != nil {
+		return nil, err
+	}
+	img, resolvedName, err := ic.Libpod.LibimageRuntime().LookupImage(imageName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctr.Rebuild(ctx, img, resolvedName, options.TakeoverPorts); err != nil {
+		return nil, err
+	}
+	return &entities.ContainerRefreshReport{
+		Id:      ctr.ID(),
+		ImageID: img.ID(),
+		Updated: img.ID() != oldImageID,
+	}, nil
+}
+
 // ContainerRename renames the given container.
 func (ic *ContainerEngine) ContainerRename(ctx context.Context, nameOrID string, opts entities.ContainerRenameOptions) error {
 	ctr, err := ic.Libpod.LookupContainer(nameOrID)
@@ -1714,12 +1997,35 @@ func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts enti
 		spec.Name = generate.CheckName(ic.Libpod, n, true)
 	}
 
+	var clonedVolumes []*libpod.Volume
+	removeClonedVolumes := func() {
+		for _, vol := range clonedVolumes {
+			if err := ic.Libpod.RemoveVolume(ctx, vol, false, nil); err != nil {
+				logrus.Errorf("Removing volume %s after failed clone: %v", vol.Name(), err)
+			}
+		}
+	}
+	switch ctrCloneOpts.Volumes {
+	case "", entities.CloneVolumesShare:
+	case entities.CloneVolumesCopy, entities.CloneVolumesNew:
+		clonedVolumes, err = ic.cloneVolumes(ctx, spec, ctrCloneOpts.Volumes, ctrCloneOpts.VolumeCopyProgress)
+		if err != nil {
+			removeClonedVolumes()
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid volumes mode %q, must be one of %s, %s or %s: %w", ctrCloneOpts.Volumes,
+			entities.CloneVolumesShare, entities.CloneVolumesCopy, entities.CloneVolumesNew, define.ErrInvalidArg)
+	}
+
 	rtSpec, spec, opts, err := generate.MakeContainer(context.Background(), ic.Libpod, spec, true, c)
 	if err != nil {
+		removeClonedVolumes()
 		return nil, err
 	}
 	ctr, err := generate.ExecuteCreate(ctx, ic.Libpod, rtSpec, spec, false, opts...)
 	if err != nil {
+		removeClonedVolumes()
 		return nil, err
 	}
 
@@ -0,0 +1,137 @@
+package abi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+)
+
+// cloneVolumes replaces the named volumes in spec by new volumes, which are
+// either empty or a copy of the original ones depending on mode. The created
+// volumes are returned so they can be removed if the clone fails.
+func (ic *ContainerEngine) cloneVolumes(ctx context.Context, spec *specgen.SpecGenerator, mode string, progress io.Writer) ([]*libpod.Volume, error) {
+	var created []*libpod.Volume
+	for _, namedVol := range spec.Volumes {
+		src, err := ic.Libpod.LookupVolume(namedVol.Name)
+		if err != nil {
+			return created, err
+		}
+
+		opts := []libpod.VolumeCreateOption{
+			libpod.WithVolumeDriver(src.Driver()),
+			libpod.WithVolumeOptions(src.Options()),
+			libpod.WithVolumeLabels(src.Labels()),
+		}
+		// Anonymous volumes get a new random name and stay anonymous, so
+		// they are removed with the clone.
+		if src.Anonymous() {
+			opts = append(opts, libpod.WithVolumeAnonymous())
+		} else {
+			opts = append(opts, libpod.WithVolumeName(fmt.Sprintf("%s-%s", src.Name(), spec.Name)))
+		}
+		dst, err := ic.Libpod.NewVolume(ctx, opts...)
+		if err != nil {
+			return created, fmt.Errorf("cloning volume %s: %w", src.Name(), err)
+		}
+		created = append(created, dst)
+
+		if mode == entities.CloneVolumesCopy {
+			if err := copyVolumeData(src, dst, progress); err != nil {
+				return created, fmt.Errorf("copying volume %s to %s: %w", src.Name(), dst.Name(), err)
+			}
+		}
+		namedVol.Name = dst.Name()
+	}
+	return created, nil
+}
+
+// copyVolumeData copies the content of src into dst, reporting the progress
+// of the copy to progress if set.
+func copyVolumeData(src, dst *libpod.Volume, progress io.Writer) error {
+	srcPath, err := src.Mount()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := src.Unmount(); err != nil {
+			logrus.Errorf("Unmounting volume %s: %v", src.Name(), err)
+		}
+	}()
+	dstPath, err := dst.Mount()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := dst.Unmount(); err != nil {
+			logrus.Errorf("Unmounting volume %s: %v", dst.Name(), err)
+		}
+	}()
+
+	tarStream, err := archive.TarWithOptions(srcPath, &archive.TarOptions{})
+	if err != nil {
+		return err
+	}
+	defer tarStream.Close()
+
+	if progress == nil {
+		return archive.Untar(tarStream, dstPath, &archive.TarOptions{})
+	}
+
+	total, err := directory.Size(srcPath)
+	if err != nil {
+		return err
+	}
+	counter := &countingReader{reader: tarStream}
+	done := make(chan bool)
+	finished := make(chan struct{})
+	go func() {
+		reportCopyProgress(progress, src.Name(), counter, total, done)
+		close(finished)
+	}()
+	err = archive.Untar(counter, dstPath, &archive.TarOptions{})
+	done <- err == nil
+	<-finished
+	return err
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	count  atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// reportCopyProgress writes the progress of a volume copy every second until
+// the result of the copy is received on done. The byte count includes tar
+// headers, so it is capped at the total size.
+func reportCopyProgress(w io.Writer, name string, counter *countingReader, total int64, done <-chan bool) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case ok := <-done:
+			if ok {
+				fmt.Fprintf(w, "Copied volume %s (%s)\n", name, units.HumanSize(float64(total)))
+			}
+			return
+		case <-ticker.C:
+			copied := min(counter.count.Load(), total)
+			fmt.Fprintf(w, "Copying volume %s: %s / %s\n", name, units.HumanSize(float64(copied)), units.HumanSize(float64(total)))
+		}
+	}
+}
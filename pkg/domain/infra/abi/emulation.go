@@ -0,0 +1,150 @@
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/containers/common/libimage"
+	"github.com/containers/common/libimage/platform"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/emulation"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/specgen"
+)
+
+// SystemEmulationEnable registers the qemu-user handlers of the given
+// architectures with binfmt_misc, either those installed on the host or
+// those of a helper image. Without architectures, all installed handlers
+// are registered.
+func (ic *ContainerEngine) SystemEmulationEnable(ctx context.Context, archs []string, options entities.SystemEmulationEnableOptions) error {
+	if rootless.IsRootless() {
+		return errors.New("emulation can only be enabled as root")
+	}
+	for _, arch := range archs {
+		if err := checkForeignArch(arch); err != nil {
+			return err
+		}
+	}
+	if options.Image != "" {
+		return ic.runEmulationHelper(ctx, options.Image, archs)
+	}
+
+	if len(archs) == 0 {
+		for _, p := range emulation.Installed() {
+			archs = append(archs, strings.TrimPrefix(p, "linux/"))
+		}
+		archs = slices.DeleteFunc(archs, func(arch string) bool { return checkForeignArch(arch) != nil })
+		if len(archs) == 0 {
+			return fmt.Errorf("install qemu-user-static or use --image to enable emulation: %w", emulation.ErrNotInstalled)
+		}
+	}
+
+	registered := emulation.Registered()
+	for _, arch := range archs {
+		_, arch, _ = platform.Normalize("linux", arch, "")
+		if slices.Contains(registered, "linux/"+arch) {
+			continue
+		}
+		if err := emulation.Enable(arch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SystemEmulationDisable unregisters the qemu-user handlers of the given
+// architectures from binfmt_misc. Without architectures, all qemu-user
+// handlers are unregistered.
+func (ic *ContainerEngine) SystemEmulationDisable(ctx context.Context, archs []string) error {
+	if rootless.IsRootless() {
+		return errors.New("emulation can only be disabled as root")
+	}
+	if len(archs) == 0 {
+		archs = emulation.Architectures()
+	}
+	for _, arch := range archs {
+		if err := emulation.Disable(arch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SystemEmulationStatus reports which foreign platforms can be emulated and
+// whether images built for them can be run.
+func (ic *ContainerEngine) SystemEmulationStatus(ctx context.Context) (*entities.SystemEmulationReport, error) {
+	nativeOS, nativeArch, _ := platform.Normalize(runtime.GOOS, runtime.GOARCH, "")
+	native := nativeOS + "/" + nativeArch
+	report := &entities.SystemEmulationReport{
+		Native:    native,
+		Platforms: []entities.SystemEmulationPlatform{},
+	}
+	installed := emulation.Installed()
+	registered := emulation.Registered()
+	platforms := append(slices.Clone(installed), registered...)
+	for _, arch := range emulation.Architectures() {
+		platforms = append(platforms, "linux/"+arch)
+	}
+	slices.Sort(platforms)
+	for _, p := range slices.Compact(platforms) {
+		if p == native {
+			continue
+		}
+		report.Platforms = append(report.Platforms, entities.SystemEmulationPlatform{
+			Platform:   p,
+			Installed:  slices.Contains(installed, p),
+			Registered: slices.Contains(registered, p),
+		})
+	}
+	return report, nil
+}
+
+// checkForeignArch returns an error if arch is the architecture of the host.
+func checkForeignArch(arch string) error {
+	_, nativeArch, _ := platform.Normalize(runtime.GOOS, runtime.GOARCH, "")
+	if _, arch, _ = platform.Normalize("linux", arch, ""); arch == nativeArch {
+		return fmt.Errorf("%s is the native architecture of the host and does not need emulation", arch)
+	}
+	return nil
+}
+
+// runEmulationHelper runs a privileged helper image registering qemu-user
+// handlers with binfmt_misc. The image is passed "--install" followed by the
+// architectures, as by docker.io/tonistiigi/binfmt.
+func (ic *ContainerEngine) runEmulationHelper(ctx context.Context, image string, archs []string) error {
+	pullOptions := &libimage.PullOptions{}
+	pullOptions.Writer = os.Stderr
+	if _, err := ic.Libpod.LibimageRuntime().Pull(ctx, image, config.PullPolicyMissing, pullOptions); err != nil {
+		return err
+	}
+
+	install := "all"
+	if len(archs) > 0 {
+		install = strings.Join(archs, ",")
+	}
+	s := specgen.NewSpecGenerator(image, false)
+	privileged, remove := true, true
+	s.Privileged = &privileged
+	s.Remove = &remove
+	s.Command = []string{"--install", install}
+
+	report, err := ic.ContainerRun(ctx, entities.ContainerRunOptions{
+		Spec:         s,
+		Rm:           true,
+		OutputStream: os.Stdout,
+		ErrorStream:  os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+	if report.ExitCode != 0 {
+		return fmt.Errorf("helper image %s exited with code %d", image, report.ExitCode)
+	}
+	return nil
+}
@@ -23,3 +23,11 @@ func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string,
 	}
 	return &report, nil
 }
+
+func (ic *ContainerEngine) HealthCheckHistory(ctx context.Context, nameOrID string, options entities.HealthCheckHistoryOptions) ([]define.HealthCheckHistoryEntry, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return ctr.HealthCheckHistory()
+}
@@ -11,6 +11,7 @@ import (
 	"os/user"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"syscall"
@@ -38,10 +39,16 @@ import (
 	"github.com/opencontainers/go-digest"
 	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const UnknownDigestSuffix = docker.UnknownDigestSuffix
 
+var tracer = otel.Tracer("github.com/containers/podman/v5/pkg/domain/infra/abi")
+
 func (ir *ImageEngine) Exists(_ context.Context, nameOrID string) (*entities.BoolReport, error) {
 	exists, err := ir.Libpod.LibimageRuntime().Exists(nameOrID)
 	if err != nil {
@@ -139,6 +146,13 @@ func (ir *ImageEngine) History(ctx context.Context, nameOrID string, opts entiti
 	for i := range results {
 		history.Layers[i] = toDomainHistoryLayer(&results[i])
 	}
+
+	if opts.Provenance {
+		history.Provenance, err = ir.Libpod.ImageProvenance(image.ID())
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &history, nil
 }
 
@@ -268,10 +282,48 @@ func (ir *ImageEngine) Pull(ctx context.Context, rawImage string, options entiti
 		pullOptions.Writer = os.Stderr
 	}
 
-	pulledImages, err := ir.Libpod.LibimageRuntime().Pull(ctx, rawImage, options.PullPolicy, pullOptions)
+	if err := ir.Libpod.CheckImagePullPolicy(rawImage, nil); err != nil {
+		return nil, err
+	}
+	pullCtx, span := tracer.Start(ctx, "image pull", trace.WithAttributes(attribute.String("image.reference", rawImage), attribute.String("image.pull_policy", options.PullPolicy.String())))
+	pulledImages, err := ir.Libpod.LibimageRuntime().Pull(pullCtx, rawImage, options.PullPolicy, pullOptions)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
 		return nil, err
 	}
+	span.End()
+	if err := ir.Libpod.CheckImagePullPolicy(rawImage, pulledImages); err != nil {
+		return nil, err
+	}
+
+	// Digests are pinned per tag, which is unknown when pulling all tags.
+	if !options.AllTags {
+		for _, img := range pulledImages {
+			if err := ir.Libpod.CheckImageDigestPin(rawImage, img, options.AcceptNewDigest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Images pulled for a foreign platform can only be run with
+	// emulation, which is enabled here rather than failing later on.
+	if options.OS != "" || options.Arch != "" {
+		osName, arch := options.OS, options.Arch
+		if osName == "" {
+			osName = runtime.GOOS
+		}
+		if arch == "" {
+			arch = runtime.GOARCH
+		}
+		if err := ir.Libpod.CheckPlatformEmulation(rawImage, osName, arch); err != nil {
+			if !errors.Is(err, define.ErrPlatformNotSupported) {
+				return nil, err
+			}
+			logrus.Warn(err)
+		}
+	}
 
 	pulledIDs := make([]string, len(pulledImages))
 	for i := range pulledImages {
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/containers/common/libnetwork/pasta"
 	"github.com/containers/common/libnetwork/slirp4netns"
 	"github.com/containers/common/libnetwork/types"
 	netutil "github.com/containers/common/libnetwork/util"
+	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 )
@@ -26,6 +28,19 @@ func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, op
 	return nil
 }
 
+// NetworkDisturb injects latency and packet loss in the network of a container
+// until the duration elapsed or ctx is done, or reverts all its disturbances.
+func (ic *ContainerEngine) NetworkDisturb(ctx context.Context, nameOrID string, options entities.NetworkDisturbOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	if options.Clear {
+		return ctr.ClearNetworkDisturbances()
+	}
+	return ctr.DisturbNetwork(ctx, options.Interface, options.Latency, options.Loss, options.Duration)
+}
+
 func (ic *ContainerEngine) NetworkList(ctx context.Context, options entities.NetworkListOptions) ([]types.Network, error) {
 	// dangling filter is not provided by netutil
 	var wantDangling bool
@@ -66,10 +81,6 @@ func (ic *ContainerEngine) NetworkList(ctx context.Context, options entities.Net
 
 func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []string, options entities.InspectOptions) ([]entities.NetworkInspectReport, []error, error) {
 	var errs []error
-	statuses, err := ic.GetContainerNetStatuses()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get network status for containers: %w", err)
-	}
 	networks := make([]entities.NetworkInspectReport, 0, len(namesOrIds))
 	for _, name := range namesOrIds {
 		net, err := ic.Libpod.Network().NetworkInspect(name)
@@ -81,28 +92,91 @@ func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []stri
 				return nil, nil, fmt.Errorf("inspecting network %s: %w", name, err)
 			}
 		}
+		ctrs, err := ic.Libpod.GetNetworkContainers(net.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get containers of network %s: %w", net.Name, err)
+		}
 		containerMap := make(map[string]entities.NetworkContainerInfo)
-		for _, st := range statuses {
+		for _, ctr := range ctrs {
+			status, err := ctr.GetNetworkStatus()
+			if err != nil {
+				if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+					continue
+				}
+				return nil, nil, fmt.Errorf("failed to get network status for container %s: %w", ctr.ID(), err)
+			}
 			// Make sure to only show the info for the correct network
-			if sb, ok := st.Status[net.Name]; ok {
-				containerMap[st.ID] = entities.NetworkContainerInfo{
-					Name:       st.Name,
+			if sb, ok := status[net.Name]; ok {
+				containerMap[ctr.ID()] = entities.NetworkContainerInfo{
+					Name:       ctr.Name(),
 					Interfaces: sb.Interfaces,
 				}
 			}
 		}
 
+		reservations, err := ic.Libpod.NetworkReservations(net.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get reservations of network %s: %w", net.Name, err)
+		}
+		reservationInfos := make([]entities.NetworkReservationInfo, 0, len(reservations))
+		for _, res := range reservations {
+			reservationInfos = append(reservationInfos, entities.NetworkReservationInfo{
+				Address:   res.Address,
+				Container: res.Container,
+				InUse:     addressInUse(containerMap, res.Address),
+			})
+		}
+
 		netReport := entities.NetworkInspectReport{
-			Network:    net,
-			Containers: containerMap,
+			Network:      net,
+			Containers:   containerMap,
+			Reservations: reservationInfos,
 		}
 		networks = append(networks, netReport)
 	}
 	return networks, errs, nil
 }
 
+// addressInUse returns true if one of the containers has an interface with
+// the given IP or MAC address.
+func addressInUse(containers map[string]entities.NetworkContainerInfo, address string) bool {
+	for _, ctr := range containers {
+		for _, netInt := range ctr.Interfaces {
+			if netInt.MacAddress.String() == address {
+				return true
+			}
+			for _, subnet := range netInt.Subnets {
+				if subnet.IPNet.IP.String() == address {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// NetworkReserve reserves an IP or MAC address of a network for a container.
+func (ic *ContainerEngine) NetworkReserve(ctx context.Context, address string, options entities.NetworkReserveOptions) (*define.NetworkReservation, error) {
+	return ic.Libpod.ReserveNetworkAddress(options.Network, address, options.Container)
+}
+
+// NetworkUnreserve removes the reservation of an address of a network.
+func (ic *ContainerEngine) NetworkUnreserve(ctx context.Context, network, address string) error {
+	return ic.Libpod.UnreserveNetworkAddress(network, address)
+}
+
+// NetworkReservations lists the reserved addresses of a network, or of all
+// networks if the name is empty.
+func (ic *ContainerEngine) NetworkReservations(ctx context.Context, network string) ([]*define.NetworkReservation, error) {
+	return ic.Libpod.NetworkReservations(network)
+}
+
+// composeProjectLabels are the labels set by compose implementations to
+// group the containers of a project.
+var composeProjectLabels = []string{"com.docker.compose.project", "io.podman.compose.project"}
+
 func (ic *ContainerEngine) NetworkReload(ctx context.Context, names []string, options entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
-	containers, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: names})
+	containers, named, err := ic.networkReloadContainers(names, options)
 	if err != nil {
 		return nil, err
 	}
@@ -112,17 +186,106 @@ func (ic *ContainerEngine) NetworkReload(ctx context.Context, names []string, op
 		report := new(entities.NetworkReloadReport)
 		report.Id = ctr.ID()
 		report.Err = ctr.ReloadNetwork()
-		// ignore errors for invalid ctr state and network mode when --all is used
-		if options.All && (errors.Is(report.Err, define.ErrCtrStateInvalid) ||
+		// ignore errors for invalid ctr state and network mode of containers
+		// selected by --all, --pod or --project, such as pod members sharing
+		// the network namespace of the infra container
+		if !named[ctr.ID()] && (errors.Is(report.Err, define.ErrCtrStateInvalid) ||
 			errors.Is(report.Err, define.ErrNetworkModeInvalid)) {
 			continue
 		}
+		if report.Err == nil {
+			report.Ports, report.Err = networkReloadPorts(ctr)
+		}
 		reports = append(reports, report)
 	}
 
 	return reports, nil
 }
 
+// networkReloadContainers returns the containers selected by names, pods and
+// compose projects, without duplicates, and the IDs of the containers that
+// were selected by name or with --latest.
+func (ic *ContainerEngine) networkReloadContainers(names []string, options entities.NetworkReloadOptions) ([]*libpod.Container, map[string]bool, error) {
+	var containers []*libpod.Container
+	named := make(map[string]bool)
+	if len(names) > 0 || options.All || options.Latest || (len(options.Pods) == 0 && len(options.Projects) == 0) {
+		ctrs, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: names})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ctr := range ctrs {
+			containers = append(containers, ctr.Container)
+			if !options.All {
+				named[ctr.ID()] = true
+			}
+		}
+	}
+	for _, nameOrID := range options.Pods {
+		pod, err := ic.Libpod.LookupPod(nameOrID)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctrs, err := pod.AllContainers()
+		if err != nil {
+			return nil, nil, err
+		}
+		containers = append(containers, ctrs...)
+	}
+	for _, project := range options.Projects {
+		ctrs, err := ic.Libpod.GetContainers(false, func(c *libpod.Container) bool {
+			labels := c.Labels()
+			for _, label := range composeProjectLabels {
+				if labels[label] == project {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(ctrs) == 0 {
+			return nil, nil, fmt.Errorf("no containers found for project %q: %w", project, define.ErrNoSuchCtr)
+		}
+		containers = append(containers, ctrs...)
+	}
+
+	seen := make(map[string]bool, len(containers))
+	return slices.DeleteFunc(containers, func(c *libpod.Container) bool {
+		if seen[c.ID()] {
+			return true
+		}
+		seen[c.ID()] = true
+		return false
+	}), named, nil
+}
+
+// networkReloadPorts returns the port mappings configured for the container,
+// which the network backend forwards again after the reload.
+func networkReloadPorts(ctr *libpod.Container) ([]string, error) {
+	ports, err := ctr.PortMappings()
+	if err != nil {
+		return nil, err
+	}
+	var mappings []string
+	for _, port := range ports {
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		hostPort := strconv.Itoa(int(port.HostPort))
+		ctrPort := strconv.Itoa(int(port.ContainerPort))
+		if port.Range > 1 {
+			hostPort += "-" + strconv.Itoa(int(port.HostPort+port.Range-1))
+			ctrPort += "-" + strconv.Itoa(int(port.ContainerPort+port.Range-1))
+		}
+		for _, protocol := range strings.Split(port.Protocol, ",") {
+			mappings = append(mappings, fmt.Sprintf("%s:%s->%s/%s", hostIP, hostPort, ctrPort, protocol))
+		}
+	}
+	return mappings, nil
+}
+
 func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, options entities.NetworkRmOptions) ([]*entities.NetworkRmReport, error) {
 	reports := make([]*entities.NetworkRmReport, 0, len(namesOrIds))
 
@@ -162,8 +325,15 @@ func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, o
 				}
 			}
 		}
+		// Reservations are kept by network name.
+		netName := name
+		if net, err := ic.Libpod.Network().NetworkInspect(name); err == nil {
+			netName = net.Name
+		}
 		if err := ic.Libpod.Network().NetworkRemove(name); err != nil {
 			report.Err = err
+		} else if err := ic.Libpod.RemoveNetworkReservations(netName); err != nil {
+			report.Err = fmt.Errorf("removing reservations of network %s: %w", netName, err)
 		}
 		reports = append(reports, &report)
 	}
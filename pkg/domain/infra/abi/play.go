@@ -1187,10 +1187,16 @@ func (ic *ContainerEngine) getImageAndLabelInfo(ctx context.Context, cwd string,
 		pullOptions.Password = options.Password
 		pullOptions.InsecureSkipTLSVerify = options.SkipTLSVerify
 
+		if err := ic.Libpod.CheckImagePullPolicy(container.Image, nil); err != nil {
+			return nil, nil, err
+		}
 		pulledImages, err := ic.Libpod.LibimageRuntime().Pull(ctx, container.Image, pullPolicy, pullOptions)
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := ic.Libpod.CheckImagePullPolicy(container.Image, pulledImages); err != nil {
+			return nil, nil, err
+		}
 		pulledImage = pulledImages[0]
 	}
 
@@ -187,6 +187,23 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+// PodUpdate replaces the published ports of the pod, reprogramming the port
+// forwarding of its infra container if the pod is running.
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, options *entities.PodUpdateOptions) (string, error) {
+	pod, err := ic.Libpod.LookupPod(options.NameOrID)
+	if err != nil {
+		return "", err
+	}
+	ports, err := generate.ParsePortMapping(options.PortMappings, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := pod.UpdatePortMappings(ports); err != nil {
+		return "", err
+	}
+	return pod.ID(), nil
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, options entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	reports := []*entities.PodStopReport{}
 	pods, err := getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
@@ -198,7 +215,7 @@ func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opt
 			Id:       p.ID(),
 			RawInput: p.Name(),
 		}
-		errs, err := p.StopWithTimeout(ctx, true, options.Timeout)
+		errs, err := p.StopParallel(ctx, true, options.Timeout, options.Parallel)
 		if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
 			report.Errs = []error{err}
 			reports = append(reports, &report)
@@ -254,7 +271,7 @@ func (ic *ContainerEngine) PodStart(ctx context.Context, namesOrIds []string, op
 			Id:       p.ID(),
 			RawInput: p.Name(),
 		}
-		errs, err := p.Start(ctx)
+		errs, err := p.StartParallel(ctx, options.Parallel)
 		if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
 			report.Errs = []error{err}
 			reports = append(reports, &report)
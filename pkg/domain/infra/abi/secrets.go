@@ -9,8 +9,11 @@ import (
 	"strings"
 
 	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/utils"
+	"github.com/containers/podman/v5/pkg/errorhandling"
+	"github.com/containers/podman/v5/pkg/signal"
 )
 
 func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader io.Reader, options entities.SecretCreateOptions) (*entities.SecretCreateReport, error) {
@@ -37,9 +40,9 @@ func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader
 		options.DriverOpts = make(map[string]string)
 	}
 
-	if options.Driver == "file" {
+	if options.Driver == "file" || options.Driver == "vault" {
 		if _, ok := options.DriverOpts["path"]; !ok {
-			options.DriverOpts["path"] = filepath.Join(secretsPath, "filedriver")
+			options.DriverOpts["path"] = filepath.Join(secretsPath, options.Driver+"driver")
 		}
 	}
 
@@ -59,6 +62,82 @@ func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader
 	}, nil
 }
 
+// SecretUpdate replaces the data of a secret and refreshes the copies of the
+// secret mounted into containers. Depending on options.Notify, the running
+// containers using the secret are then signaled or restarted. The secret is
+// updated even if notifying some containers fails.
+func (ic *ContainerEngine) SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader, options entities.SecretUpdateOptions) (*entities.SecretUpdateReport, error) {
+	var sig uint
+	switch options.Notify {
+	case "", entities.SecretNotifyRestart:
+	case entities.SecretNotifySignal:
+		if options.Signal == "" {
+			options.Signal = "SIGHUP"
+		}
+		s, err := signal.ParseSignalNameOrNumber(options.Signal)
+		if err != nil {
+			return nil, err
+		}
+		sig = uint(s)
+	default:
+		return nil, fmt.Errorf("invalid notify option %q, must be %q or %q: %w", options.Notify, entities.SecretNotifySignal, entities.SecretNotifyRestart, define.ErrInvalidArg)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.Update(nameOrID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := manager.Version(secret.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.SecretUpdateReport{
+		ID:         secret.ID,
+		Version:    version,
+		Containers: []string{},
+	}
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return report, err
+	}
+	var errs []error
+	for _, ctr := range ctrs {
+		if !ctr.UsesSecret(secret.ID) {
+			continue
+		}
+		report.Containers = append(report.Containers, ctr.ID())
+		if err := ctr.RefreshSecret(secret.ID, sig); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if options.Notify != entities.SecretNotifyRestart {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if state != define.ContainerStateRunning {
+			continue
+		}
+		if err := ctr.RestartWithTimeout(ctx, ctr.StopTimeout()); err != nil {
+			errs = append(errs, fmt.Errorf("restarting container %s: %w", ctr.ID(), err))
+		}
+	}
+	return report, errorhandling.JoinErrors(errs)
+}
+
 func (ic *ContainerEngine) SecretInspect(ctx context.Context, nameOrIDs []string, options entities.SecretInspectOptions) ([]*entities.SecretInfoReport, []error, error) {
 	var (
 		secret *secrets.Secret
@@ -90,7 +169,11 @@ func (ic *ContainerEngine) SecretInspect(ctx context.Context, nameOrIDs []string
 		if secret.UpdatedAt.IsZero() {
 			secret.UpdatedAt = secret.CreatedAt
 		}
-		reports = append(reports, secretToReportWithData(*secret, string(data)))
+		version, err := manager.Version(secret.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("inspecting secret %s: %w", nameOrID, err)
+		}
+		reports = append(reports, secretToReportWithData(*secret, version, string(data)))
 	}
 
 	return reports, errs, nil
@@ -112,7 +195,11 @@ func (ic *ContainerEngine) SecretList(ctx context.Context, opts entities.SecretL
 			return nil, err
 		}
 		if result {
-			report = append(report, secretToReport(secret))
+			version, err := manager.Version(secret.ID)
+			if err != nil {
+				return nil, err
+			}
+			report = append(report, secretToReport(secret, version))
 		}
 	}
 	return report, nil
@@ -163,15 +250,16 @@ func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*
 	return &entities.BoolReport{Value: secret != nil}, nil
 }
 
-func secretToReport(secret secrets.Secret) *entities.SecretInfoReport {
-	return secretToReportWithData(secret, "")
+func secretToReport(secret secrets.Secret, version int) *entities.SecretInfoReport {
+	return secretToReportWithData(secret, version, "")
 }
 
-func secretToReportWithData(secret secrets.Secret, data string) *entities.SecretInfoReport {
+func secretToReportWithData(secret secrets.Secret, version int, data string) *entities.SecretInfoReport {
 	return &entities.SecretInfoReport{
 		ID:        secret.ID,
 		CreatedAt: secret.CreatedAt,
 		UpdatedAt: secret.UpdatedAt,
+		Version:   entities.SecretVersion{Index: version},
 		Spec: entities.SecretSpec{
 			Name: secret.Name,
 			Driver: entities.SecretDriverSpec{
@@ -41,6 +41,7 @@ func Test_secretToReport(t *testing.T) {
 				ID:        "test-id",
 				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+				Version:   entities.SecretVersion{Index: 1},
 				Spec: entities.SecretSpec{
 					Name: "test-name",
 					Driver: entities.SecretDriverSpec{
@@ -55,7 +56,7 @@ func Test_secretToReport(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, secretToReportWithData(tt.args.secret, tt.args.secretData), "secretToReport(%v)", tt.args.secret)
+			assert.Equalf(t, tt.want, secretToReportWithData(tt.args.secret, 1, tt.args.secretData), "secretToReport(%v)", tt.args.secret)
 		})
 	}
 }
@@ -14,7 +14,6 @@ import (
 	"github.com/containers/podman/v5/pkg/domain/entities/reports"
 	"github.com/containers/podman/v5/pkg/util"
 	"github.com/containers/storage"
-	"github.com/containers/storage/pkg/directory"
 	"github.com/containers/storage/pkg/fileutils"
 	"github.com/sirupsen/logrus"
 )
@@ -160,6 +159,11 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		}
 	}
 
+	// Reclaim the space of the removed entries in the database.
+	if err := ic.Libpod.VacuumDB(); err != nil && !errors.Is(err, define.ErrNotImplemented) {
+		logrus.Warnf("Vacuuming database: %v", err)
+	}
+
 	systemPruneReport.ReclaimedSpace = reclaimedSpace
 	return systemPruneReport, nil
 }
@@ -253,10 +257,16 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 			// TODO: fix this.
 			continue
 		}
-		volSize, err := directory.Size(mountPoint)
+		// The size is cached while no container can write to the
+		// volume, instead of walking it on every invocation.
+		usage, err := v.DiskUsage()
 		if err != nil {
+			if errors.Is(err, define.ErrNoSuchVolume) {
+				continue
+			}
 			return nil, err
 		}
+		volSize := usage.Size
 		inUse, err := v.VolumeInUse()
 		if err != nil {
 			if errors.Is(err, define.ErrNoSuchVolume) {
@@ -292,8 +302,14 @@ func (ic *ContainerEngine) Renumber(ctx context.Context) error {
 	return ic.Libpod.RenumberLocks()
 }
 
-func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) error {
-	return ic.Libpod.Migrate(options.NewRuntime)
+func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) (*define.DBMigrationReport, error) {
+	if err := ic.Libpod.Migrate(options.NewRuntime); err != nil {
+		return nil, err
+	}
+	if options.NewDB != "" {
+		return ic.Libpod.MigrateDB(options.NewDB)
+	}
+	return nil, nil
 }
 
 func (se SystemEngine) Shutdown(ctx context.Context) {
@@ -352,3 +368,86 @@ func (ic ContainerEngine) SystemCheck(ctx context.Context, options entities.Syst
 	}
 	return &report, nil
 }
+
+func (ic ContainerEngine) SystemDBCheck(ctx context.Context) (*define.DBCheckReport, error) {
+	return ic.Libpod.CheckDB(ctx)
+}
+
+func (ic ContainerEngine) SystemDoctor(ctx context.Context) (*define.DoctorReport, error) {
+	return ic.Libpod.Doctor(ctx)
+}
+
+// SystemGraph returns the dependency graph of all containers, pods, volumes
+// and networks.
+func (ic ContainerEngine) SystemGraph(ctx context.Context) (*entities.SystemGraphReport, error) {
+	report := &entities.SystemGraphReport{
+		Nodes: []entities.SystemGraphNode{},
+		Edges: []entities.SystemGraphEdge{},
+	}
+
+	pods, err := ic.Libpod.GetAllPods()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pods {
+		report.Nodes = append(report.Nodes, entities.SystemGraphNode{Type: "pod", ID: p.ID(), Name: p.Name()})
+	}
+
+	vols, err := ic.Libpod.GetAllVolumes()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vols {
+		report.Nodes = append(report.Nodes, entities.SystemGraphNode{Type: "volume", ID: v.Name(), Name: v.Name()})
+	}
+
+	nets, err := ic.Libpod.Network().NetworkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nets {
+		report.Nodes = append(report.Nodes, entities.SystemGraphNode{Type: "network", ID: n.Name, Name: n.Name})
+	}
+
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range ctrs {
+		ctrNets, err := c.Networks()
+		if err != nil {
+			// The container may have been removed in the meantime.
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return nil, err
+		}
+		report.Nodes = append(report.Nodes, entities.SystemGraphNode{Type: "container", ID: c.ID(), Name: c.Name()})
+
+		if podID := c.PodID(); podID != "" {
+			report.Edges = append(report.Edges, entities.SystemGraphEdge{Type: "pod", From: c.ID(), To: podID})
+		}
+		for _, dep := range c.Dependencies() {
+			report.Edges = append(report.Edges, entities.SystemGraphEdge{Type: "container", From: c.ID(), To: dep})
+		}
+		for _, vol := range c.NamedVolumes() {
+			report.Edges = append(report.Edges, entities.SystemGraphEdge{Type: "volume", From: c.ID(), To: vol.Name})
+		}
+		for _, net := range ctrNets {
+			report.Edges = append(report.Edges, entities.SystemGraphEdge{Type: "network", From: c.ID(), To: net})
+		}
+	}
+	return report, nil
+}
+
+func (ic ContainerEngine) SystemDBVacuum(ctx context.Context) error {
+	return ic.Libpod.VacuumDB()
+}
+
+func (ic ContainerEngine) SystemDBBackup(ctx context.Context, path string) error {
+	return ic.Libpod.BackupDB(path)
+}
+
+func (ic ContainerEngine) SystemDBRestore(ctx context.Context, path string) error {
+	return ic.Libpod.RestoreDB(path)
+}
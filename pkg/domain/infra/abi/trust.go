@@ -53,3 +53,37 @@ func (ir *ImageEngine) SetTrust(ctx context.Context, args []string, options enti
 		PubKeyFiles: options.PubKeysFile,
 	})
 }
+
+// trustPaths returns the policy.json and registries.d paths to use, the
+// system defaults unless overridden by the options.
+func (ir *ImageEngine) trustPaths(policyPath, registryPath string) (string, string) {
+	if len(policyPath) == 0 {
+		policyPath = trust.DefaultPolicyPath(ir.Libpod.SystemContext())
+	}
+	if len(registryPath) == 0 {
+		registryPath = trust.RegistriesDirPath(ir.Libpod.SystemContext())
+	}
+	return policyPath, registryPath
+}
+
+func (ir *ImageEngine) TrustPolicyAdd(ctx context.Context, scope string, options entities.TrustPolicyAddOptions) error {
+	policyPath, registryPath := ir.trustPaths(options.PolicyPath, options.RegistryPath)
+	return trust.AddPolicyScope(policyPath, registryPath, trust.AddPolicyScopeInput{
+		Transport:   options.Transport,
+		Scope:       scope,
+		Type:        options.Type,
+		PubKeyFiles: options.PubKeysFile,
+		Lookaside:   options.Lookaside,
+		Replace:     options.Replace,
+	})
+}
+
+func (ir *ImageEngine) TrustPolicyRemove(ctx context.Context, scope string, options entities.TrustPolicyRemoveOptions) error {
+	policyPath, registryPath := ir.trustPaths(options.PolicyPath, options.RegistryPath)
+	return trust.RemovePolicyScope(policyPath, registryPath, options.Transport, scope)
+}
+
+func (ir *ImageEngine) TrustPolicyTest(ctx context.Context, image string, options entities.TrustPolicyTestOptions) (*entities.TrustPolicyTestReport, error) {
+	policyPath, registryPath := ir.trustPaths(options.PolicyPath, options.RegistryPath)
+	return trust.MatchPolicy(policyPath, registryPath, image)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
@@ -234,3 +235,152 @@ func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeRe
 	report := ic.Libpod.UpdateVolumePlugins(ctx)
 	return &entities.VolumeReloadReport{VolumeReload: *report}, nil
 }
+
+func (ic *ContainerEngine) VolumeBackupEnable(ctx context.Context, name string, opts entities.VolumeBackupEnableOptions) error {
+	vol, err := ic.Libpod.LookupVolume(name)
+	if err != nil {
+		return err
+	}
+	schedule, err := define.ParseVolumeBackupSchedule(opts.Schedule)
+	if err != nil {
+		return err
+	}
+	identity := opts.Identity
+	if identity != "" {
+		if identity, err = filepath.Abs(identity); err != nil {
+			return err
+		}
+	}
+	return ic.Libpod.EnableVolumeBackup(&define.VolumeBackupPolicy{
+		Volume:   vol.Name(),
+		Schedule: schedule,
+		Keep:     opts.Keep,
+		Target:   opts.Target,
+		Identity: identity,
+	})
+}
+
+func (ic *ContainerEngine) VolumeBackupDisable(ctx context.Context, name string) error {
+	vol, err := ic.Libpod.LookupVolume(name)
+	if err != nil {
+		return err
+	}
+	return ic.Libpod.DisableVolumeBackup(vol.Name())
+}
+
+func (ic *ContainerEngine) VolumeBackupInspect(ctx context.Context, names []string) ([]*entities.VolumeBackupInspectReport, error) {
+	backupReports := make([]*entities.VolumeBackupInspectReport, 0, len(names))
+	for _, name := range names {
+		vol, err := ic.Libpod.LookupVolume(name)
+		if err != nil {
+			return nil, err
+		}
+		report := new(entities.VolumeBackupInspectReport)
+		report.Policy, err = ic.Libpod.VolumeBackupPolicy(vol.Name())
+		if err != nil && !errors.Is(err, define.ErrNoSuchVolumeBackup) {
+			return nil, err
+		}
+		if report.Backups, err = ic.Libpod.VolumeBackups(vol.Name()); err != nil {
+			return nil, err
+		}
+		backupReports = append(backupReports, report)
+	}
+	return backupReports, nil
+}
+
+func (ic *ContainerEngine) VolumeBackupList(ctx context.Context, name string) ([]*define.VolumeBackup, error) {
+	if name != "" {
+		vol, err := ic.Libpod.LookupVolume(name)
+		if err != nil {
+			return nil, err
+		}
+		name = vol.Name()
+	}
+	return ic.Libpod.VolumeBackups(name)
+}
+
+func (ic *ContainerEngine) VolumeBackupRun(ctx context.Context, names []string) ([]*define.VolumeBackup, error) {
+	var policies []*define.VolumeBackupPolicy
+	if len(names) == 0 {
+		var err error
+		if policies, err = ic.Libpod.VolumeBackupPolicies(); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		vol, err := ic.Libpod.LookupVolume(name)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := ic.Libpod.VolumeBackupPolicy(vol.Name())
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	backups := make([]*define.VolumeBackup, 0, len(policies))
+	for _, policy := range policies {
+		vol, err := ic.Libpod.LookupVolume(policy.Volume)
+		if err != nil {
+			return backups, err
+		}
+		backup, err := ic.Libpod.BackupVolume(vol, policy)
+		if err != nil {
+			return backups, fmt.Errorf("backing up volume %s: %w", vol.Name(), err)
+		}
+		backups = append(backups, backup)
+	}
+	return backups, nil
+}
+
+func (ic *ContainerEngine) VolumeBackupRestore(ctx context.Context, name string, id int64) (*define.VolumeBackup, error) {
+	vol, err := ic.Libpod.LookupVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	return ic.Libpod.RestoreVolumeBackup(vol, id)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotCreate(ctx context.Context, name, snapshot string) (*define.VolumeSnapshot, error) {
+	vol, err := ic.Libpod.LookupVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	return vol.Snapshot(snapshot)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotList(ctx context.Context, name string) ([]*define.VolumeSnapshot, error) {
+	if name != "" {
+		vol, err := ic.Libpod.LookupVolume(name)
+		if err != nil {
+			return nil, err
+		}
+		return vol.Snapshots()
+	}
+	return ic.Libpod.VolumeSnapshots()
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRemove(ctx context.Context, name string, snapshots []string) ([]*define.VolumeSnapshot, error) {
+	vol, err := ic.Libpod.LookupVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	removed := make([]*define.VolumeSnapshot, 0, len(snapshots))
+	for _, nameOrID := range snapshots {
+		snapshot, err := vol.RemoveSnapshot(nameOrID)
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, snapshot)
+	}
+	return removed, nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRestore(ctx context.Context, name, snapshot string) (*define.VolumeSnapshot, error) {
+	vol, err := ic.Libpod.LookupVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	return vol.RestoreSnapshot(snapshot)
+}
@@ -9,18 +9,24 @@ import (
 	"io/fs"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/containers/common/pkg/cgroups"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/namespaces"
 	"github.com/containers/podman/v5/pkg/rootless"
 	"github.com/containers/podman/v5/pkg/util"
+	"github.com/containers/storage/pkg/homedir"
 	"github.com/containers/storage/pkg/idtools"
 	"github.com/containers/storage/types"
+	"github.com/fsnotify/fsnotify"
 	"github.com/sirupsen/logrus"
 	flag "github.com/spf13/pflag"
 )
@@ -128,6 +134,9 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 		options = append(options, libpod.WithTransientStore(cfg.TransientStore))
 	}
 
+	if cfg.ReadOnlyState {
+		options = append(options, libpod.WithReadOnlyState())
+	}
 	if opts.reset {
 		options = append(options, libpod.WithReset())
 	}
@@ -216,6 +225,14 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 		options = append(options, libpod.WithSyslog())
 	}
 
+	if cfg.IsService {
+		options = append(options, libpod.WithEventsWebhookDelivery())
+	}
+
+	if cfg.ServiceMetrics {
+		options = append(options, libpod.WithMetrics())
+	}
+
 	if opts.config.ContainersConfDefaultsRO.Engine.StaticDir != "" {
 		options = append(options, libpod.WithStaticDir(opts.config.ContainersConfDefaultsRO.Engine.StaticDir))
 	}
@@ -305,17 +322,36 @@ func ParseIDMapping(mode namespaces.UsernsMode, uidMapSlice, gidMapSlice []strin
 	return &options, nil
 }
 
-// StartWatcher starts a new SIGHUP go routine for the current config.
+// configReloadDelay is the time to wait after a change of a configuration
+// file before reloading, editors write files in several steps.
+const configReloadDelay = time.Second
+
+// StartWatcher starts a new go routine reloading the current config on SIGHUP
+// and when a containers.conf or registries.conf file changes.
 func StartWatcher(rt *libpod.Runtime) {
+	if err := rt.PrepareReload(); err != nil {
+		logrus.Errorf("Unable to read configuration for reloading: %v", err)
+	}
+
 	// Set up the signal notifier
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGHUP)
+	changes := watchConfigFiles()
 
 	go func() {
+		var delay <-chan time.Time
 		for {
-			// Block until the signal is received
+			// Block until the signal is received or the files
+			// settled after a change
 			logrus.Debugf("waiting for SIGHUP to reload configuration")
-			<-ch
+			select {
+			case <-ch:
+			case <-changes:
+				delay = time.After(configReloadDelay)
+				continue
+			case <-delay:
+				delay = nil
+			}
 			if err := rt.Reload(); err != nil {
 				logrus.Errorf("Unable to reload configuration: %v", err)
 				continue
@@ -325,3 +361,76 @@ func StartWatcher(rt *libpod.Runtime) {
 
 	logrus.Debugf("registered SIGHUP watcher for config")
 }
+
+// configDirs returns the directories holding the containers.conf and
+// registries.conf files.
+func configDirs() []string {
+	var dirs []string
+	if path := os.Getenv("CONTAINERS_CONF"); path != "" {
+		dirs = append(dirs, filepath.Dir(path))
+	} else {
+		dirs = append(dirs, filepath.Dir(config.DefaultContainersConfig), filepath.Dir(config.OverrideContainersConfig), config.OverrideContainersConfig+".d")
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(homedir.Get(), ".config")
+		}
+		userConfig := filepath.Join(configHome, "containers", "containers.conf")
+		dirs = append(dirs, filepath.Dir(userConfig), userConfig+".d")
+	}
+	if path := os.Getenv("CONTAINERS_CONF_OVERRIDE"); path != "" {
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	dirs = append(dirs, filepath.Dir(sysregistriesv2.ConfigPath(nil)), sysregistriesv2.ConfigDirPath(nil))
+	return dirs
+}
+
+// watchConfigFiles watches the directories of the configuration files and
+// returns a channel receiving a value when a .conf file changes, or nil if
+// they cannot be watched.
+func watchConfigFiles() <-chan struct{} {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logrus.Warnf("Unable to watch configuration files: %v", err)
+		return nil
+	}
+	watched := 0
+	for _, dir := range configDirs() {
+		if err := watcher.Add(dir); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				logrus.Warnf("Unable to watch configuration directory %s: %v", dir, err)
+			}
+			continue
+		}
+		watched++
+	}
+	if watched == 0 {
+		watcher.Close()
+		return nil
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !strings.HasSuffix(event.Name, ".conf") || event.Op == fsnotify.Chmod {
+					continue
+				}
+				logrus.Debugf("Configuration file %s changed", event.Name)
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logrus.Warnf("Watching configuration files: %v", err)
+			}
+		}
+	}()
+	return changes
+}
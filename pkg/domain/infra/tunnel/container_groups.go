@@ -0,0 +1,21 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+)
+
+func (ic *ContainerEngine) ContainerGroupCreate(ctx context.Context, name string, options entities.ContainerGroupCreateOptions) (*define.ContainerGroup, error) {
+	return nil, errors.New("managing container groups is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) ContainerGroupList(ctx context.Context) ([]*entities.ContainerGroupReport, error) {
+	return nil, errors.New("managing container groups is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) ContainerGroupRm(ctx context.Context, name string) error {
+	return errors.New("managing container groups is not supported on remote clients")
+}
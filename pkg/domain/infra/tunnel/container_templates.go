@@ -0,0 +1,31 @@
+package tunnel
+
+import (
+	"context"
+
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/templates"
+)
+
+// Container templates are pushed and pulled by the client, the service does
+// not take part.
+
+func (ic *ContainerEngine) ContainerTemplatePush(ctx context.Context, destination string, options entities.ContainerTemplatePushOptions) (*entities.ContainerTemplatePushReport, error) {
+	d, err := templates.Push(ctx, options.SystemContext(nil), destination, options.Spec, options.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerTemplatePushReport{Digest: d.String()}, nil
+}
+
+func (ic *ContainerEngine) ContainerTemplatePull(ctx context.Context, source string, options entities.ContainerTemplatePullOptions) (*entities.ContainerTemplatePullReport, error) {
+	template, err := templates.Pull(ctx, options.SystemContext(nil), source)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerTemplatePullReport{
+		Digest:      template.Digest.String(),
+		Annotations: template.Annotations,
+		Spec:        template.Spec,
+	}, nil
+}
@@ -294,7 +294,10 @@ func (ic *ContainerEngine) ContainerInspect(ctx context.Context, namesOrIds []st
 		reports = make([]*entities.ContainerInspectReport, 0, len(namesOrIds))
 		errs    = []error{}
 	)
-	options := new(containers.InspectOptions).WithSize(opts.Size)
+	options := new(containers.InspectOptions).WithSize(opts.Size).WithEffectiveConfig(opts.EffectiveConfig)
+	if len(opts.Fields) > 0 {
+		options.WithFields(opts.Fields)
+	}
 	for _, name := range namesOrIds {
 		inspect, err := containers.Inspect(ic.ClientCtx, name, options)
 		if err != nil {
@@ -369,6 +372,22 @@ func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string,
 	return containers.Export(ic.ClientCtx, nameOrID, options.Output, nil)
 }
 
+func (ic *ContainerEngine) ContainerAnnotate(ctx context.Context, nameOrID string, options entities.ContainerAnnotateOptions) (map[string]string, error) {
+	return nil, errors.New("annotating containers is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) ContainerNotes(ctx context.Context, nameOrID string, options entities.ContainerNotesOptions) ([]*define.ContainerNote, error) {
+	return nil, errors.New("container notes are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) ContainerCheckpointList(ctx context.Context, options entities.CheckpointListOptions) ([]*define.CheckpointRecord, error) {
+	return nil, errors.New("listing exported checkpoints is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) ContainerCheckpointPrune(ctx context.Context, options entities.CheckpointPruneOptions) ([]*reports.PruneReport, error) {
+	return nil, errors.New("pruning exported checkpoints is not supported on remote clients")
+}
+
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, opts entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
 	var (
 		err          error
@@ -387,6 +406,9 @@ func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds [
 	options.WithPreCheckpoint(opts.PreCheckPoint)
 	options.WithLeaveRunning(opts.LeaveRunning)
 	options.WithWithPrevious(opts.WithPrevious)
+	if opts.PreDumpChain > 0 {
+		options.WithPreDumpChain(opts.PreDumpChain)
+	}
 
 	if opts.All {
 		allCtrs, err := getContainersByContext(ic.ClientCtx, true, false, []string{})
@@ -521,6 +543,9 @@ func (ic *ContainerEngine) ContainerLogs(_ context.Context, nameOrIDs []string,
 	stderr := opts.StderrWriter != nil
 	options := new(containers.LogOptions).WithFollow(opts.Follow).WithSince(since).WithUntil(until).WithStderr(stderr)
 	options.WithStdout(stdout).WithTail(tail).WithTimestamps(opts.Timestamps)
+	if opts.Redact {
+		options.WithRedact(true)
+	}
 
 	var err error
 	stdoutCh := make(chan string)
@@ -585,6 +610,7 @@ func makeExecConfig(options entities.ExecOptions) *handlers.ExecCreateConfig {
 	createConfig.Env = env
 	createConfig.WorkingDir = options.WorkDir
 	createConfig.Cmd = options.Cmd
+	createConfig.ToolsImage = options.ToolsImage
 
 	return createConfig
 }
@@ -708,6 +734,22 @@ func logIfRmError(id string, err error, reports []*reports.RmReport) {
 	}
 }
 
+func (ic *ContainerEngine) ContainerExecInspect(ctx context.Context, ids []string) ([]*define.InspectExecSession, error) {
+	inspects := make([]*define.InspectExecSession, 0, len(ids))
+	for _, id := range ids {
+		inspect, err := containers.ExecInspect(ic.ClientCtx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+		inspects = append(inspects, inspect)
+	}
+	return inspects, nil
+}
+
+func (ic *ContainerEngine) ContainerExecList(ctx context.Context, namesOrIds []string) ([]*entities.ContainerExecListReport, error) {
+	return nil, errors.New("listing exec sessions is not supported on remote clients")
+}
+
 func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []string, options entities.ContainerStartOptions) ([]*entities.ContainerStartReport, error) {
 	reports := []*entities.ContainerStartReport{}
 	var exitCode = define.ExecErrorCodeGeneric
@@ -1032,6 +1074,15 @@ func (ic *ContainerEngine) ContainerMount(ctx context.Context, nameOrIDs []strin
 	return nil, errors.New("mounting containers is not supported for remote clients")
 }
 
+func (ic *ContainerEngine) ContainerEmitEvent(ctx context.Context, nameOrID string, options entities.ContainerEmitEventOptions) error {
+	opts := new(containers.EmitEventOptions).WithType(options.Type).WithMessage(options.Message)
+	return containers.EmitEvent(ic.ClientCtx, nameOrID, opts)
+}
+
+func (ic *ContainerEngine) ContainerWatchProcesses(ctx context.Context, nameOrID string) error {
+	return errors.New("watching container processes is not supported for remote clients")
+}
+
 func (ic *ContainerEngine) ContainerUnmount(ctx context.Context, nameOrIDs []string, options entities.ContainerUnmountOptions) ([]*entities.ContainerUnmountReport, error) {
 	return nil, errors.New("unmounting containers is not supported for remote clients")
 }
@@ -1090,11 +1141,23 @@ func (ic *ContainerEngine) ContainerStats(ctx context.Context, namesOrIds []stri
 	return containers.Stats(ic.ClientCtx, namesOrIds, new(containers.StatsOptions).WithStream(options.Stream).WithInterval(options.Interval).WithAll(options.All))
 }
 
+func (ic *ContainerEngine) ContainerStatsHistory(ctx context.Context, nameOrID string, options entities.ContainerStatsHistoryOptions) ([]define.ContainerStats, error) {
+	opts := new(containers.StatsHistoryOptions)
+	if !options.Since.IsZero() {
+		opts.WithSince(options.Since.Format(time.RFC3339Nano))
+	}
+	return containers.StatsHistory(ic.ClientCtx, nameOrID, opts)
+}
+
 // ShouldRestart reports back whether the container will restart.
 func (ic *ContainerEngine) ShouldRestart(_ context.Context, id string) (bool, error) {
 	return containers.ShouldRestart(ic.ClientCtx, id, nil)
 }
 
+func (ic *ContainerEngine) ContainerRefresh(ctx context.Context, nameOrID string, options entities.ContainerRefreshOptions) (*entities.ContainerRefreshReport, error) {
+	return nil, errors.New("refreshing a container is not supported on remote clients")
+}
+
 // ContainerRename renames the given container.
 func (ic *ContainerEngine) ContainerRename(ctx context.Context, nameOrID string, opts entities.ContainerRenameOptions) error {
 	return containers.Rename(ic.ClientCtx, nameOrID, new(containers.RenameOptions).WithName(opts.NewName))
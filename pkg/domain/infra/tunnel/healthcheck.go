@@ -11,3 +11,7 @@ import (
 func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
 	return containers.RunHealthCheck(ic.ClientCtx, nameOrID, nil)
 }
+
+func (ic *ContainerEngine) HealthCheckHistory(ctx context.Context, nameOrID string, options entities.HealthCheckHistoryOptions) ([]define.HealthCheckHistoryEntry, error) {
+	return containers.HealthCheckHistory(ic.ClientCtx, nameOrID, nil)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
@@ -50,6 +52,24 @@ func getContainersAndInputByContext(contextWithConnection context.Context, all,
 	// be awesome to have.
 	filtered := []entities.ListContainer{}
 	for _, nameOrID := range namesOrIDs {
+		// Container groups are resolved by the service.
+		if group, isGroup := strings.CutPrefix(nameOrID, define.ContainerGroupPrefix); isGroup {
+			groupOptions := new(containers.ListOptions).WithAll(true).WithFilters(map[string][]string{"group": {group}})
+			members, err := containers.List(contextWithConnection, groupOptions)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, member := range members {
+				i := slices.IndexFunc(allContainers, func(ctr entities.ListContainer) bool { return ctr.ID == member.ID })
+				if i < 0 || slices.Contains(rawInputs, member.ID) {
+					continue
+				}
+				filtered = append(filtered, allContainers[i])
+				rawInputs = append(rawInputs, member.ID)
+			}
+			continue
+		}
+
 		// First determine if the container exists by doing an inspect.
 		// Inspect takes supports names and IDs and let's us determine
 		// a container's full ID.
@@ -93,6 +93,13 @@ func (ir *ImageEngine) History(ctx context.Context, nameOrID string, opts entiti
 		}
 		history.Layers[i] = hold
 	}
+
+	if opts.Provenance {
+		history.Provenance, err = images.Provenance(ir.ClientCtx, nameOrID, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &history, nil
 }
 
@@ -117,6 +124,7 @@ func (ir *ImageEngine) Pull(ctx context.Context, rawImage string, opts entities.
 
 	options := new(images.PullOptions)
 	options.WithAllTags(opts.AllTags).WithAuthfile(opts.Authfile).WithArch(opts.Arch).WithOS(opts.OS)
+	options.WithAcceptNewDigest(opts.AcceptNewDigest)
 	options.WithVariant(opts.Variant).WithPassword(opts.Password)
 	options.WithQuiet(opts.Quiet).WithUsername(opts.Username).WithPolicy(opts.PullPolicy.String())
 	options.WithProgressWriter(opts.Writer)
@@ -140,6 +148,18 @@ func (ir *ImageEngine) Pull(ctx context.Context, rawImage string, opts entities.
 	return &entities.ImagePullReport{Images: pulledImages}, nil
 }
 
+func (ir *ImageEngine) Watch(ctx context.Context, opts entities.ImageWatchOptions) (chan entities.ImageWatchReport, error) {
+	options := new(images.WatchOptions)
+	options.WithAuthfile(opts.Authfile).WithAutoRestart(opts.AutoRestart).WithRollback(opts.Rollback)
+	if opts.Interval > 0 {
+		options.WithInterval(opts.Interval.String())
+	}
+	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
+		options.WithSkipTLSVerify(s == types.OptionalBoolTrue)
+	}
+	return images.Watch(ir.ClientCtx, opts.Images, options)
+}
+
 func (ir *ImageEngine) Tag(ctx context.Context, nameOrID string, tags []string, opt entities.ImageTagOptions) error {
 	options := new(images.TagOptions)
 	for _, newTag := range tags {
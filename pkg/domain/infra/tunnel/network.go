@@ -17,6 +17,22 @@ func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, op
 	return network.Update(ic.ClientCtx, netName, options)
 }
 
+func (ic *ContainerEngine) NetworkDisturb(ctx context.Context, nameOrID string, opts entities.NetworkDisturbOptions) error {
+	return errors.New("disturbing the network of a container is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) NetworkReserve(ctx context.Context, address string, options entities.NetworkReserveOptions) (*define.NetworkReservation, error) {
+	return nil, errors.New("reserving network addresses is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) NetworkUnreserve(ctx context.Context, network, address string) error {
+	return errors.New("reserving network addresses is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) NetworkReservations(ctx context.Context, network string) ([]*define.NetworkReservation, error) {
+	return nil, errors.New("reserving network addresses is not supported on remote clients")
+}
+
 func (ic *ContainerEngine) NetworkList(ctx context.Context, opts entities.NetworkListOptions) ([]types.Network, error) {
 	options := new(network.ListOptions).WithFilters(opts.Filters)
 	return network.List(ic.ClientCtx, options)
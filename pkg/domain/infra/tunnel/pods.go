@@ -100,6 +100,10 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, options *entities.PodUpdateOptions) (string, error) {
+	return pods.Update(ic.ClientCtx, options)
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opts entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	timeout := -1
 	foundPods, err := getPodsByContext(ic.ClientCtx, opts.All, opts.Ignore, namesOrIds)
@@ -111,6 +115,9 @@ func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opt
 	}
 	reports := make([]*entities.PodStopReport, 0, len(foundPods))
 	options := new(pods.StopOptions).WithTimeout(timeout)
+	if opts.Parallel > 0 {
+		options.WithParallel(opts.Parallel)
+	}
 	for _, p := range foundPods {
 		response, err := pods.Stop(ic.ClientCtx, p.Id, options)
 		if err != nil {
@@ -154,8 +161,12 @@ func (ic *ContainerEngine) PodStart(ctx context.Context, namesOrIds []string, op
 		return nil, err
 	}
 	reports := make([]*entities.PodStartReport, 0, len(foundPods))
+	startOptions := new(pods.StartOptions)
+	if options.Parallel > 0 {
+		startOptions.WithParallel(options.Parallel)
+	}
 	for _, p := range foundPods {
-		response, err := pods.Start(ic.ClientCtx, p.Id, nil)
+		response, err := pods.Start(ic.ClientCtx, p.Id, startOptions)
 		if err != nil {
 			report := entities.PodStartReport{
 				Errs:     []error{err},
@@ -32,8 +32,32 @@ func (ic *ContainerEngine) SystemCheck(ctx context.Context, opts entities.System
 	return system.Check(ic.ClientCtx, options)
 }
 
-func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) error {
-	return errors.New("runtime migration is not supported on remote clients")
+func (ic *ContainerEngine) SystemGraph(ctx context.Context) (*entities.SystemGraphReport, error) {
+	return system.Graph(ic.ClientCtx, nil)
+}
+
+func (ic *ContainerEngine) SystemDBCheck(ctx context.Context) (*define.DBCheckReport, error) {
+	return nil, errors.New("checking the database is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemDoctor(ctx context.Context) (*define.DoctorReport, error) {
+	return nil, errors.New("podman doctor is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemDBVacuum(ctx context.Context) error {
+	return errors.New("vacuuming the database is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemDBBackup(ctx context.Context, path string) error {
+	return errors.New("backing up the database is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemDBRestore(ctx context.Context, path string) error {
+	return errors.New("restoring the database is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) (*define.DBMigrationReport, error) {
+	return nil, errors.New("runtime migration is not supported on remote clients")
 }
 
 func (ic *ContainerEngine) Renumber(ctx context.Context) error {
@@ -59,3 +83,15 @@ func (ic ContainerEngine) Version(ctx context.Context) (*entities.SystemVersionR
 func (ic ContainerEngine) Locks(ctx context.Context) (*entities.LocksReport, error) {
 	return nil, errors.New("locks is not supported on remote clients")
 }
+
+func (ic *ContainerEngine) SystemEmulationDisable(ctx context.Context, archs []string) error {
+	return errors.New("configuring emulation is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemEmulationEnable(ctx context.Context, archs []string, options entities.SystemEmulationEnableOptions) error {
+	return errors.New("configuring emulation is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemEmulationStatus(ctx context.Context) (*entities.SystemEmulationReport, error) {
+	return nil, errors.New("showing the emulation status is not supported on remote clients")
+}
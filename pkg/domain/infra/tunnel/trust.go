@@ -14,3 +14,15 @@ func (ir *ImageEngine) ShowTrust(ctx context.Context, args []string, options ent
 func (ir *ImageEngine) SetTrust(ctx context.Context, args []string, options entities.SetTrustOptions) error {
 	return errors.New("not implemented")
 }
+
+func (ir *ImageEngine) TrustPolicyAdd(ctx context.Context, scope string, options entities.TrustPolicyAddOptions) error {
+	return errors.New("not implemented")
+}
+
+func (ir *ImageEngine) TrustPolicyRemove(ctx context.Context, scope string, options entities.TrustPolicyRemoveOptions) error {
+	return errors.New("not implemented")
+}
+
+func (ir *ImageEngine) TrustPolicyTest(ctx context.Context, image string, options entities.TrustPolicyTestOptions) (*entities.TrustPolicyTestReport, error) {
+	return nil, errors.New("not implemented")
+}
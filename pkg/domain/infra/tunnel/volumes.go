@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/bindings/volumes"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/domain/entities/reports"
@@ -113,3 +114,43 @@ func (ic *ContainerEngine) VolumeUnmount(ctx context.Context, nameOrIDs []string
 func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeReloadReport, error) {
 	return nil, errors.New("volume reload is not supported for remote clients")
 }
+
+func (ic *ContainerEngine) VolumeBackupEnable(ctx context.Context, name string, opts entities.VolumeBackupEnableOptions) error {
+	return errors.New("volume backups are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeBackupDisable(ctx context.Context, name string) error {
+	return errors.New("volume backups are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeBackupInspect(ctx context.Context, names []string) ([]*entities.VolumeBackupInspectReport, error) {
+	return nil, errors.New("volume backups are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeBackupList(ctx context.Context, name string) ([]*define.VolumeBackup, error) {
+	return nil, errors.New("volume backups are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeBackupRun(ctx context.Context, names []string) ([]*define.VolumeBackup, error) {
+	return nil, errors.New("volume backups are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeBackupRestore(ctx context.Context, name string, id int64) (*define.VolumeBackup, error) {
+	return nil, errors.New("volume backups are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeSnapshotCreate(ctx context.Context, name, snapshot string) (*define.VolumeSnapshot, error) {
+	return nil, errors.New("volume snapshots are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeSnapshotList(ctx context.Context, name string) ([]*define.VolumeSnapshot, error) {
+	return nil, errors.New("volume snapshots are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRemove(ctx context.Context, name string, snapshots []string) ([]*define.VolumeSnapshot, error) {
+	return nil, errors.New("volume snapshots are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRestore(ctx context.Context, name, snapshot string) (*define.VolumeSnapshot, error) {
+	return nil, errors.New("volume snapshots are not supported on remote clients")
+}
@@ -0,0 +1,135 @@
+//go:build !remote
+
+package emulation
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const binfmtMiscDir = "/proc/sys/fs/binfmt_misc"
+
+// binfmtConfDirs are the directories holding the binfmt.d(5) configurations
+// installed by the qemu-user packages, in order of precedence.
+var binfmtConfDirs = []string{"/etc/binfmt.d", "/run/binfmt.d", "/usr/lib/binfmt.d"}
+
+// handlerNames returns the names qemu-user handlers of the given qemu
+// architecture are registered under.
+func handlerNames(qemuArch string) []string {
+	return []string{"qemu-" + qemuArch, "qemu-" + qemuArch + "-static"}
+}
+
+// Enable registers the installed qemu-user handler of the given architecture
+// with binfmt_misc, using the binfmt.d(5) configuration of the qemu-user
+// package. It returns ErrNotInstalled if there is none.
+func Enable(arch string) error {
+	qemuArch, err := QemuArch(arch)
+	if err != nil {
+		return err
+	}
+	rule, err := findRule(qemuArch)
+	if err != nil {
+		return err
+	}
+	register := filepath.Join(binfmtMiscDir, "register")
+	if err := os.WriteFile(register, []byte(rule), 0); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("binfmt_misc is not mounted at %s: %w", binfmtMiscDir, err)
+		}
+		if errors.Is(err, fs.ErrExist) {
+			// A handler with the same name is registered without
+			// the fix-binary flag, so Registered ignores it.
+			return fmt.Errorf("a qemu-user handler for %s which cannot be used by containers is already registered, disable it first: %w", arch, err)
+		}
+		return fmt.Errorf("registering qemu-user handler for %s: %w", arch, err)
+	}
+	return nil
+}
+
+// Disable unregisters the qemu-user handlers of the given architecture from
+// binfmt_misc. Architectures without registered handlers are ignored.
+func Disable(arch string) error {
+	qemuArch, err := QemuArch(arch)
+	if err != nil {
+		return err
+	}
+	for _, name := range handlerNames(qemuArch) {
+		// Writing -1 to the entry of a handler removes it.
+		if err := os.WriteFile(filepath.Join(binfmtMiscDir, name), []byte("-1"), 0); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("unregistering qemu-user handler %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Installed returns the platforms for which a qemu-user handler is installed
+// on the host, whether or not it is registered with binfmt_misc.
+func Installed() []string {
+	var installed []string
+	for arch, qemuArch := range qemuArchitectures {
+		if _, err := findRule(qemuArch); err == nil {
+			installed = append(installed, "linux/"+arch)
+		}
+	}
+	sort.Strings(installed)
+	return installed
+}
+
+// findRule returns the binfmt_misc rule of the installed qemu-user handler
+// of the given qemu architecture.
+func findRule(qemuArch string) (string, error) {
+	names := handlerNames(qemuArch)
+	for _, dir := range binfmtConfDirs {
+		for _, name := range names {
+			rule, err := readRule(filepath.Join(dir, name+".conf"), names)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return "", err
+			}
+			if rule != "" {
+				return rule, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no binfmt.d configuration for qemu-%s found in %s: %w", qemuArch, strings.Join(binfmtConfDirs, ", "), ErrNotInstalled)
+}
+
+// readRule returns the first rule of the binfmt.d configuration file which
+// registers a handler with one of the given names.
+func readRule(path string, names []string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		// A rule is ":name:type:offset:magic:mask:interpreter:flags",
+		// where ":" may be any delimiter.
+		fields := strings.Split(line[1:], line[:1])
+		if slices.Contains(names, fields[0]) {
+			return line, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return "", nil
+}
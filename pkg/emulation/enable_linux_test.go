@@ -0,0 +1,47 @@
+//go:build !remote
+
+package emulation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRule(t *testing.T) {
+	conf := `# /usr/lib/binfmt.d/qemu-aarch64-static.conf
+;comment
+:qemu-arm:M::\x7fELF\x01\x01\x01:\xff\xff\xff:/usr/bin/qemu-arm-static:F
+
+|qemu-aarch64-static|M||\x7fELF\x02\x01\x01|\xff\xff\xff|/usr/bin/qemu-aarch64-static|F
+`
+	path := filepath.Join(t.TempDir(), "qemu-aarch64-static.conf")
+	require.NoError(t, os.WriteFile(path, []byte(conf), 0o644))
+
+	rule, err := readRule(path, handlerNames("aarch64"))
+	require.NoError(t, err)
+	assert.Equal(t, `|qemu-aarch64-static|M||\x7fELF\x02\x01\x01|\xff\xff\xff|/usr/bin/qemu-aarch64-static|F`, rule)
+
+	rule, err = readRule(path, handlerNames("s390x"))
+	require.NoError(t, err)
+	assert.Empty(t, rule)
+
+	_, err = readRule(filepath.Join(t.TempDir(), "missing.conf"), handlerNames("aarch64"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestQemuArch(t *testing.T) {
+	arch, err := QemuArch("aarch64")
+	require.NoError(t, err)
+	assert.Equal(t, "aarch64", arch)
+
+	arch, err = QemuArch("amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "x86_64", arch)
+
+	_, err = QemuArch("wasm")
+	assert.ErrorIs(t, err, ErrNotInstalled)
+}
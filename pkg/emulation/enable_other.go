@@ -0,0 +1,26 @@
+//go:build !linux && !remote
+
+package emulation
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Enable registers the installed qemu-user handler of the given architecture
+// with binfmt_misc.
+func Enable(arch string) error {
+	return fmt.Errorf("qemu-user emulation is not supported on %s: %w", runtime.GOOS, ErrNotInstalled)
+}
+
+// Disable unregisters the qemu-user handlers of the given architecture from
+// binfmt_misc.
+func Disable(arch string) error {
+	return fmt.Errorf("qemu-user emulation is not supported on %s: %w", runtime.GOOS, ErrNotInstalled)
+}
+
+// Installed returns the platforms for which a qemu-user handler is installed
+// on the host.
+func Installed() []string {
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build !remote
+
+package emulation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/containers/common/libimage/platform"
+)
+
+// ErrNotInstalled indicates that no qemu-user handler is installed for an
+// architecture.
+var ErrNotInstalled = errors.New("qemu-user emulation is not installed")
+
+// qemuArchitectures maps OCI architectures to the architecture names used by
+// qemu-user.
+var qemuArchitectures = map[string]string{
+	"386":      "i386",
+	"amd64":    "x86_64",
+	"arm":      "arm",
+	"arm64":    "aarch64",
+	"loong64":  "loongarch64",
+	"mips":     "mips",
+	"mipsle":   "mipsel",
+	"mips64":   "mips64",
+	"mips64le": "mips64el",
+	"ppc64":    "ppc64",
+	"ppc64le":  "ppc64le",
+	"riscv64":  "riscv64",
+	"s390x":    "s390x",
+}
+
+// Architectures returns the architectures which can be emulated with
+// qemu-user.
+func Architectures() []string {
+	archs := make([]string, 0, len(qemuArchitectures))
+	for arch := range qemuArchitectures {
+		archs = append(archs, arch)
+	}
+	sort.Strings(archs)
+	return archs
+}
+
+// QemuArch returns the qemu-user name of the given OCI architecture.
+func QemuArch(arch string) (string, error) {
+	_, arch, _ = platform.Normalize("", arch, "")
+	qemuArch, ok := qemuArchitectures[arch]
+	if !ok {
+		return "", fmt.Errorf("architecture %q cannot be emulated: %w", arch, ErrNotInstalled)
+	}
+	return qemuArch, nil
+}
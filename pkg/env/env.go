@@ -6,6 +6,7 @@ package env
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -77,7 +78,12 @@ func ParseFile(path string) (_ map[string]string, err error) {
 	}
 	defer fh.Close()
 
-	scanner := bufio.NewScanner(fh)
+	return parseReader(env, fh)
+}
+
+// parseReader parses line-delimited environment variables from r into env.
+func parseReader(env map[string]string, r io.Reader) (map[string]string, error) {
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		// trim the line from all leading whitespace first
 		line := strings.TrimLeft(scanner.Text(), whiteSpaces)
@@ -0,0 +1,68 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// SecretLookupFunc returns the data of the secret with the given name.
+type SecretLookupFunc func(name string) ([]byte, error)
+
+// newTemplate parses an env file template. The secret lookup is bound at
+// execution time so that the same parsing is used for validation.
+func newTemplate(text string, lookupSecret SecretLookupFunc) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"secret": func(name string) (string, error) {
+			if lookupSecret == nil {
+				return "", fmt.Errorf("secret %q: no secret store available", name)
+			}
+			data, err := lookupSecret(name)
+			if err != nil {
+				return "", fmt.Errorf("secret %q: %w", name, err)
+			}
+			return strings.TrimRight(string(data), "\n"), nil
+		},
+		"env": func(name string, def ...string) (string, error) {
+			if val, ok := os.LookupEnv(name); ok {
+				return val, nil
+			}
+			if len(def) > 0 {
+				return def[0], nil
+			}
+			return "", fmt.Errorf("environment variable %q is not set", name)
+		},
+	}
+	return template.New("env").Option("missingkey=error").Funcs(funcs).Parse(text)
+}
+
+// ValidateTemplate checks that text is a syntactically valid env file
+// template without resolving any of its placeholders.
+func ValidateTemplate(text string) error {
+	if _, err := newTemplate(text, nil); err != nil {
+		return fmt.Errorf("parsing env file template: %w", err)
+	}
+	return nil
+}
+
+// ResolveTemplate renders an env file template and parses the result like an
+// env file. Placeholders of the form {{ secret "name" }} are replaced by the
+// secret data and {{ env "NAME" }} (optionally with a default as second
+// argument) by the value of the environment variable in the calling process.
+// The rendered text is only kept in memory.
+func ResolveTemplate(text string, lookupSecret SecretLookupFunc) (map[string]string, error) {
+	tmpl, err := newTemplate(text, lookupSecret)
+	if err != nil {
+		return nil, fmt.Errorf("parsing env file template: %w", err)
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, nil); err != nil {
+		return nil, fmt.Errorf("resolving env file template: %w", err)
+	}
+	env, err := parseReader(make(map[string]string), strings.NewReader(rendered.String()))
+	if err != nil {
+		return nil, fmt.Errorf("resolving env file template: %w", err)
+	}
+	return env, nil
+}
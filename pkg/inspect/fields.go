@@ -0,0 +1,72 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SplitFields splits comma-separated lists of fields, dropping empty entries.
+func SplitFields(lists []string) []string {
+	var fields []string
+	for _, list := range lists {
+		for _, field := range strings.Split(list, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// TopLevelField returns the first element of the dot-separated field path.
+func TopLevelField(field string) string {
+	top, _, _ := strings.Cut(field, ".")
+	return top
+}
+
+// SelectFields returns the JSON representation of data reduced to the given
+// fields. Fields are dot-separated paths of JSON keys, e.g. State.Status,
+// and must exist in data. The objects containing a field are kept so the
+// result has the same layout as data.
+func SelectFields(data any, fields []string) (map[string]any, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	// Keep numbers as they are, sizes may exceed the precision of float64.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var full map[string]any
+	if err := dec.Decode(&full); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]any)
+	for _, field := range fields {
+		keys := strings.Split(field, ".")
+		src, dest := full, selected
+		for i, key := range keys {
+			value, ok := src[key]
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q", field)
+			}
+			if i == len(keys)-1 || value == nil {
+				dest[key] = value
+				break
+			}
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid field %q: %s is not an object", field, strings.Join(keys[:i+1], "."))
+			}
+			next, ok := dest[key].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				dest[key] = next
+			}
+			src, dest = obj, next
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,40 @@
+package inspect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFields(t *testing.T) {
+	assert.Equal(t, []string{"State.Status", "Name", "Id"}, SplitFields([]string{"State.Status, Name", "", "Id,"}))
+	assert.Nil(t, SplitFields(nil))
+}
+
+func TestSelectFields(t *testing.T) {
+	data := map[string]any{
+		"Id":   "abc",
+		"Size": int64(1) << 60,
+		"State": map[string]any{
+			"Status":  "running",
+			"Pid":     42,
+			"Health":  nil,
+			"Running": true,
+		},
+		"Mounts": []string{"/data"},
+	}
+
+	selected, err := SelectFields(data, []string{"State.Status", "State.Pid", "Size", "State.Health.Status"})
+	require.NoError(t, err)
+	b, err := json.Marshal(selected)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Size": 1152921504606846976, "State": {"Status": "running", "Pid": 42, "Health": null}}`, string(b))
+
+	_, err = SelectFields(data, []string{"State.Unknown"})
+	assert.EqualError(t, err, `unknown field "State.Unknown"`)
+
+	_, err = SelectFields(data, []string{"Mounts.Source"})
+	assert.EqualError(t, err, `invalid field "Mounts.Source": Mounts is not an object`)
+}
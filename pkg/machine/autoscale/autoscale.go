@@ -0,0 +1,165 @@
+//go:build amd64 || arm64
+
+// Package autoscale adjusts the CPUs and memory of a running machine to the
+// load of the containers inside of it.
+package autoscale
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/strongunits"
+	"github.com/containers/podman/v5/pkg/bindings"
+	"github.com/containers/podman/v5/pkg/bindings/containers"
+	"github.com/containers/podman/v5/pkg/machine"
+	"github.com/containers/podman/v5/pkg/machine/define"
+	"github.com/containers/podman/v5/pkg/machine/vmconfigs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultInterval is the time between two load samples
+	DefaultInterval = 30 * time.Second
+	// memoryStep is the granularity in which memory is changed, smaller
+	// changes are ignored to avoid constant ballooning.
+	memoryStep strongunits.MiB = 256
+)
+
+// Load is the resource usage of the containers in the machine as reported by
+// the podman service running inside of it.
+type Load struct {
+	// CPUs is the number of CPUs kept busy by containers
+	CPUs float64
+	// Memory is the memory used by containers
+	Memory strongunits.MiB
+}
+
+// Target computes the CPUs and memory the machine should have for the given
+// load.  CPUs get 25% headroom over the busy CPUs.  MinMemory is treated as
+// the base required by the guest itself, container memory is added on top
+// of it with the same headroom.  Memory changes smaller than 256 MiB are
+// ignored.
+func Target(cfg define.AutoScaleConfig, current strongunits.MiB, load Load) (uint64, strongunits.MiB) {
+	cpus := uint64(math.Ceil(load.CPUs * 1.25))
+	cpus = max(cfg.MinCPUs, min(cfg.MaxCPUs, cpus))
+
+	memory := cfg.MinMemory + load.Memory*5/4
+	if rem := memory % memoryStep; rem != 0 {
+		memory += memoryStep - rem
+	}
+	memory = max(cfg.MinMemory, min(cfg.MaxMemory, memory))
+	if diff := int64(memory) - int64(current); diff > -int64(memoryStep) && diff < int64(memoryStep) {
+		memory = current
+	}
+	return cpus, memory
+}
+
+// Sample collects the load of all running containers through the API
+// connection in ctx.
+func Sample(ctx context.Context) (Load, error) {
+	load := Load{}
+	reports, err := containers.Stats(ctx, nil, new(containers.StatsOptions).WithStream(false))
+	if err != nil {
+		return load, err
+	}
+	var memory uint64
+	for report := range reports {
+		if report.Error != nil {
+			return load, report.Error
+		}
+		for _, stat := range report.Stats {
+			// CPU is a percentage of a single CPU
+			load.CPUs += stat.CPU / 100
+			memory += stat.MemUsage
+		}
+	}
+	load.Memory = strongunits.ToMib(strongunits.B(memory))
+	return load, nil
+}
+
+// setOnlineCPUs onlines the first cpus CPUs of the guest and offlines the
+// rest.  CPU 0 cannot be taken offline.
+func setOnlineCPUs(mc *vmconfigs.MachineConfig, maxCPUs, cpus uint64) error {
+	script := make([]string, 0, maxCPUs)
+	for i := uint64(1); i < maxCPUs; i++ {
+		online := 0
+		if i < cpus {
+			online = 1
+		}
+		script = append(script, fmt.Sprintf("echo %d > /sys/devices/system/cpu/cpu%d/online", online, i))
+	}
+	if len(script) == 0 {
+		return nil
+	}
+	args := []string{"sudo", "sh", "-c", "'" + strings.Join(script, "; ") + "'"}
+	return machine.CommonSSHSilent(mc.SSH.RemoteUsername, mc.SSH.IdentityPath, mc.Name, mc.SSH.Port, args)
+}
+
+// Run samples the load of the machine every interval and adjusts its online
+// CPUs and, if the provider supports ballooning, its memory.  It returns
+// once the machine is no longer running or ctx is canceled.
+func Run(ctx context.Context, mc *vmconfigs.MachineConfig, mp vmconfigs.VMProvider, interval time.Duration) error {
+	if mc.Resources.AutoScale == nil {
+		return fmt.Errorf("auto-scaling is not enabled for machine %q", mc.Name)
+	}
+	cfg := *mc.Resources.AutoScale
+	balloon, canBalloon := mp.(vmconfigs.MemoryBalloonProvider)
+	if !canBalloon {
+		logrus.Infof("Provider %s does not support memory ballooning, only CPUs are scaled", mp.VMType())
+	}
+
+	socket, _, err := mc.ConnectionInfo(mp.VMType())
+	if err != nil {
+		return err
+	}
+	connCtx, err := bindings.NewConnection(ctx, "unix://"+socket.GetPath())
+	if err != nil {
+		return err
+	}
+
+	cpus, memory := cfg.MaxCPUs, cfg.MaxMemory
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		state, err := mp.State(mc, false)
+		if err != nil {
+			return err
+		}
+		if state != define.Running {
+			logrus.Debugf("Machine %q is %s, stopping auto-scaling", mc.Name, state)
+			return nil
+		}
+
+		load, err := Sample(connCtx)
+		if err != nil {
+			logrus.Warnf("Unable to sample load of machine %q: %v", mc.Name, err)
+			continue
+		}
+		newCPUs, newMemory := Target(cfg, memory, load)
+		if newCPUs != cpus {
+			logrus.Debugf("Scaling machine %q from %d to %d CPUs", mc.Name, cpus, newCPUs)
+			if err := setOnlineCPUs(mc, cfg.MaxCPUs, newCPUs); err != nil {
+				logrus.Warnf("Unable to change CPUs of machine %q: %v", mc.Name, err)
+			} else {
+				cpus = newCPUs
+			}
+		}
+		if canBalloon && newMemory != memory {
+			logrus.Debugf("Scaling machine %q from %d to %d MiB", mc.Name, memory, newMemory)
+			if err := balloon.SetBalloon(mc, newMemory); err != nil {
+				logrus.Warnf("Unable to change memory of machine %q: %v", mc.Name, err)
+			} else {
+				memory = newMemory
+			}
+		}
+	}
+}
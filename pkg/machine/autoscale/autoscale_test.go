@@ -0,0 +1,34 @@
+//go:build amd64 || arm64
+
+package autoscale
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/strongunits"
+	"github.com/containers/podman/v5/pkg/machine/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTarget(t *testing.T) {
+	cfg := define.AutoScaleConfig{MinCPUs: 2, MaxCPUs: 8, MinMemory: 2048, MaxMemory: 8192}
+	tests := []struct {
+		name       string
+		current    strongunits.MiB
+		load       Load
+		wantCPUs   uint64
+		wantMemory strongunits.MiB
+	}{
+		{name: "idle", current: 8192, load: Load{}, wantCPUs: 2, wantMemory: 2048},
+		{name: "busy", current: 2048, load: Load{CPUs: 4, Memory: 2000}, wantCPUs: 5, wantMemory: 4608},
+		{name: "above max", current: 2048, load: Load{CPUs: 20, Memory: 20000}, wantCPUs: 8, wantMemory: 8192},
+		{name: "small memory change ignored", current: 4608, load: Load{CPUs: 1, Memory: 1900}, wantCPUs: 2, wantMemory: 4608},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpus, memory := Target(cfg, tt.current, tt.load)
+			assert.Equal(t, tt.wantCPUs, cpus)
+			assert.Equal(t, tt.wantMemory, memory)
+		})
+	}
+}
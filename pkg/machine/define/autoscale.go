@@ -0,0 +1,78 @@
+package define
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/common/pkg/strongunits"
+)
+
+// AutoScaleConfig describes the bounds within which the resources of a
+// running machine are adjusted to the container load.
+type AutoScaleConfig struct {
+	// MinCPUs is the lowest number of online CPUs
+	MinCPUs uint64
+	// MaxCPUs is the number of CPUs the VM is started with
+	MaxCPUs uint64
+	// MinMemory is the lowest amount of memory left to the guest
+	MinMemory strongunits.MiB
+	// MaxMemory is the amount of memory the VM is started with
+	MaxMemory strongunits.MiB
+}
+
+// IsZero reports whether auto-scaling is disabled.
+func (a AutoScaleConfig) IsZero() bool {
+	return a == AutoScaleConfig{}
+}
+
+func parseRange(val string) (uint64, uint64, error) {
+	minStr, maxStr, ok := strings.Cut(val, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q must be a range in the form min-max", val)
+	}
+	lower, err := strconv.ParseUint(minStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid lower bound %q: %w", minStr, err)
+	}
+	upper, err := strconv.ParseUint(maxStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid upper bound %q: %w", maxStr, err)
+	}
+	if lower == 0 || lower > upper {
+		return 0, 0, fmt.Errorf("invalid range %q: lower bound must be greater than zero and not exceed the upper bound", val)
+	}
+	return lower, upper, nil
+}
+
+// ParseAutoScale parses the value of --auto-scale, which is either "off" or
+// a comma separated list of cpus=MIN-MAX and memory=MIN-MAX (in MiB).
+// A zero AutoScaleConfig is returned for "off".
+func ParseAutoScale(val string) (AutoScaleConfig, error) {
+	config := AutoScaleConfig{}
+	if val == "off" || val == "" {
+		return config, nil
+	}
+	for _, opt := range strings.Split(val, ",") {
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			return config, fmt.Errorf("auto-scale: invalid option %q", opt)
+		}
+		lower, upper, err := parseRange(value)
+		if err != nil {
+			return config, fmt.Errorf("auto-scale: %s: %w", key, err)
+		}
+		switch key {
+		case "cpus":
+			config.MinCPUs, config.MaxCPUs = lower, upper
+		case "memory":
+			config.MinMemory, config.MaxMemory = strongunits.MiB(lower), strongunits.MiB(upper)
+		default:
+			return config, fmt.Errorf("auto-scale: unknown option %q", key)
+		}
+	}
+	if config.MaxCPUs == 0 || config.MaxMemory == 0 {
+		return config, fmt.Errorf("auto-scale: both cpus and memory ranges must be set")
+	}
+	return config, nil
+}
@@ -0,0 +1,34 @@
+package define
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAutoScale(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    AutoScaleConfig
+		wantErr bool
+	}{
+		{input: "off", want: AutoScaleConfig{}},
+		{input: "cpus=2-8,memory=2048-8192", want: AutoScaleConfig{MinCPUs: 2, MaxCPUs: 8, MinMemory: 2048, MaxMemory: 8192}},
+		{input: "cpus=2-8", wantErr: true},
+		{input: "cpus=8-2,memory=1-2", wantErr: true},
+		{input: "cpus=0-2,memory=1-2", wantErr: true},
+		{input: "cpus=2,memory=1-2", wantErr: true},
+		{input: "disk=1-2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAutoScale(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,11 +2,19 @@ package define
 
 import "net/url"
 
+// WSLDistroScheme prefixes the name of an existing WSL distribution used
+// as image for a new machine.
+const WSLDistroScheme = "wsl://"
+
 type InitOptions struct {
-	CPUS               uint64
-	DiskSize           uint64
-	IgnitionPath       string
-	Image              string
+	CPUS          uint64
+	DiskSize      uint64
+	IgnitionPath  string
+	Image         string
+	ImageChecksum string
+	// VerifyImage downloads an http(s) Image and checks it against
+	// ImageChecksum, or the sum published next to it, before use
+	VerifyImage        bool
 	Volumes            []string
 	IsDefault          bool
 	Memory             uint64
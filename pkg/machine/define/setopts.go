@@ -3,6 +3,7 @@ package define
 import "github.com/containers/common/pkg/strongunits"
 
 type SetOptions struct {
+	AutoScale          *AutoScaleConfig
 	CPUs               *uint64
 	DiskSize           *strongunits.GiB
 	Memory             *strongunits.MiB
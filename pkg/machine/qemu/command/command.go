@@ -46,6 +46,12 @@ func (q *QemuCmd) SetCPUs(c uint64) {
 	*q = append(*q, "-smp", strconv.FormatUint(c, 10))
 }
 
+// SetBalloon adds a virtio balloon device so the memory of the running
+// machine can be reduced
+func (q *QemuCmd) SetBalloon() {
+	*q = append(*q, "-device", "virtio-balloon")
+}
+
 // SetIgnitionFile specifies the machine's ignition file
 func (q *QemuCmd) SetIgnitionFile(file define.VMFile) {
 	*q = append(*q, "-fw_cfg", "name=opt/com.coreos/config,file="+file.GetPath())
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/strongunits"
 	"github.com/containers/podman/v5/pkg/errorhandling"
 	"github.com/containers/podman/v5/pkg/machine/define"
 	"github.com/containers/podman/v5/pkg/machine/vmconfigs"
@@ -337,3 +338,37 @@ func getDiskSize(path string) (uint64, error) { //nolint:unused
 	}
 	return tmpInfo.VirtualSize, nil
 }
+
+// SetBalloon changes the memory available to the running guest through the
+// virtio balloon device.  The machine must have been started with
+// auto-scaling enabled for the device to exist.
+func (q *QEMUStubber) SetBalloon(mc *vmconfigs.MachineConfig, memory strongunits.MiB) error {
+	monitor, err := qmp.NewSocketMonitor(mc.QEMUHypervisor.QMPMonitor.Network, mc.QEMUHypervisor.QMPMonitor.Address.GetPath(), mc.QEMUHypervisor.QMPMonitor.Timeout)
+	if err != nil {
+		return err
+	}
+	if err := monitor.Connect(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := monitor.Disconnect(); err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	balloonCommand := struct {
+		Execute   string `json:"execute"`
+		Arguments struct {
+			Value uint64 `json:"value"`
+		} `json:"arguments"`
+	}{
+		Execute: "balloon",
+	}
+	balloonCommand.Arguments.Value = uint64(memory.ToBytes())
+	input, err := json.Marshal(balloonCommand)
+	if err != nil {
+		return err
+	}
+	_, err = monitor.Run(input)
+	return err
+}
@@ -88,6 +88,10 @@ func (q *QEMUStubber) setQEMUCommandLine(mc *vmconfigs.MachineConfig) error {
 
 	q.Command.SetUSBHostPassthrough(mc.Resources.USBs)
 
+	if mc.Resources.AutoScale != nil {
+		q.Command.SetBalloon()
+	}
+
 	return nil
 }
 
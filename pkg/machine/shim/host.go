@@ -17,6 +17,7 @@ import (
 	"github.com/containers/podman/v5/pkg/machine/ignition"
 	"github.com/containers/podman/v5/pkg/machine/lock"
 	"github.com/containers/podman/v5/pkg/machine/proxyenv"
+	"github.com/containers/podman/v5/pkg/machine/stdpull"
 	"github.com/containers/podman/v5/pkg/machine/vmconfigs"
 	"github.com/containers/podman/v5/utils"
 	"github.com/hashicorp/go-multierror"
@@ -151,7 +152,22 @@ func Init(opts machineDefine.InitOptions, mp vmconfigs.VMProvider) error {
 	// "/path
 	// "docker://quay.io/something/someManifest
 
-	if err := mp.GetDisk(opts.Image, dirs, mc); err != nil {
+	image := opts.Image
+	if opts.VerifyImage {
+		var verified *machineDefine.VMFile
+		verified, err = stdpull.FetchVerified(opts.Image, opts.ImageChecksum, dirs.ImageCacheDir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := verified.Delete(); err != nil {
+				logrus.Warnf("Removing downloaded image: %v", err)
+			}
+		}()
+		image = verified.GetPath()
+	}
+
+	if err := mp.GetDisk(image, dirs, mc); err != nil {
 		return err
 	}
 
@@ -539,6 +555,18 @@ func Set(mc *vmconfigs.MachineConfig, mp vmconfigs.VMProvider, opts machineDefin
 		return fmt.Errorf("reload config: %w", err)
 	}
 
+	if opts.AutoScale != nil {
+		if opts.AutoScale.IsZero() {
+			mc.Resources.AutoScale = nil
+		} else {
+			// The machine boots with the upper bounds and is
+			// scaled down from there.
+			mc.Resources.AutoScale = opts.AutoScale
+			opts.CPUs = &opts.AutoScale.MaxCPUs
+			opts.Memory = &opts.AutoScale.MaxMemory
+		}
+	}
+
 	if opts.CPUs != nil {
 		mc.Resources.CPUs = *opts.CPUs
 	}
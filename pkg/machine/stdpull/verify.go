@@ -0,0 +1,107 @@
+package stdpull
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/containers/podman/v5/pkg/machine/define"
+	"github.com/containers/podman/v5/utils"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// ParseChecksum parses a user given checksum.  Both the algorithm:hex form
+// and a plain hex encoded sha256 sum are accepted.
+func ParseChecksum(checksum string) (digest.Digest, error) {
+	if !strings.Contains(checksum, ":") {
+		checksum = digest.SHA256.String() + ":" + checksum
+	}
+	d, err := digest.Parse(strings.ToLower(checksum))
+	if err != nil {
+		return "", fmt.Errorf("invalid image checksum %q: %w", checksum, err)
+	}
+	return d, nil
+}
+
+// sidecarChecksum reads the sha256 sum published next to the image at
+// inputPath + ".sha256".  Both a plain sum and the sha256sum output format
+// are accepted.
+func sidecarChecksum(inputPath string) (digest.Digest, error) {
+	resp, err := http.Get(inputPath + ".sha256")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logrus.Error(err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("no checksum given and none published at %s.sha256: %s", inputPath, resp.Status)
+	}
+	line, err := bufio.NewReader(io.LimitReader(resp.Body, 4096)).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file at %s.sha256", inputPath)
+	}
+	return ParseChecksum(fields[0])
+}
+
+// FetchVerified downloads the image at inputPath into dir and verifies it
+// against checksum.  If checksum is empty, the sum published at
+// inputPath + ".sha256" is used.  The path of the verified download is
+// returned, the caller is responsible for removing it.
+func FetchVerified(inputPath, checksum string, dir *define.VMFile) (*define.VMFile, error) {
+	var (
+		expected digest.Digest
+		err      error
+	)
+	if checksum != "" {
+		expected, err = ParseChecksum(checksum)
+	} else {
+		expected, err = sidecarChecksum(inputPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := NewDiskFromURL(inputPath, nil, dir, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.pull(); err != nil {
+		return nil, err
+	}
+
+	if err := verifyFile(d.tempLocation.GetPath(), expected); err != nil {
+		if rmErr := utils.GuardedRemoveAll(d.tempLocation.GetPath()); rmErr != nil {
+			logrus.Warnf("Removing unverified image %s: %v", d.tempLocation.GetPath(), rmErr)
+		}
+		return nil, err
+	}
+	return d.tempLocation, nil
+}
+
+func verifyFile(path string, expected digest.Digest) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	verifier := expected.Verifier()
+	if _, err := io.Copy(verifier, f); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("checksum mismatch for downloaded image: expected %s", expected)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package stdpull
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChecksum(t *testing.T) {
+	hex := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	d, err := ParseChecksum(hex)
+	assert.NoError(t, err)
+	assert.Equal(t, digest.Digest("sha256:"+hex), d)
+
+	d, err = ParseChecksum("SHA256:" + hex)
+	assert.NoError(t, err)
+	assert.Equal(t, digest.Digest("sha256:"+hex), d)
+
+	_, err = ParseChecksum("sha256:abc")
+	assert.Error(t, err)
+}
+
+func TestVerifyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.qcow2")
+	assert.NoError(t, os.WriteFile(path, []byte("image"), 0o600))
+
+	assert.NoError(t, verifyFile(path, digest.FromString("image")))
+	assert.Error(t, verifyFile(path, digest.FromString("other")))
+}
@@ -104,6 +104,12 @@ type VMProvider interface { //nolint:interfacebloat
 	GetRosetta(mc *MachineConfig) (bool, error)
 }
 
+// MemoryBalloonProvider is implemented by providers that can change the
+// memory available to a running VM.
+type MemoryBalloonProvider interface {
+	SetBalloon(mc *MachineConfig, memory strongunits.MiB) error
+}
+
 // HostUser describes the host user
 type HostUser struct {
 	// Whether this machine should run in a rootful or rootless manner
@@ -134,6 +140,9 @@ type ResourceConfig struct {
 	Memory strongunits.MiB
 	// Usbs
 	USBs []define.USBConfig
+	// AutoScale bounds the CPUs and memory while the machine is running,
+	// nil if auto-scaling is disabled
+	AutoScale *define.AutoScaleConfig `json:",omitempty"`
 }
 
 // SSHConfig contains remote access information for SSH
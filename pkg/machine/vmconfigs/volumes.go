@@ -1,7 +1,9 @@
 package vmconfigs
 
 import (
+	"errors"
 	"fmt"
+	"path"
 	"strings"
 )
 
@@ -58,3 +60,48 @@ func SplitVolume(idx int, volume string) (string, string, string, bool, string)
 	readonly, securityModel := extractMountOptions(paths)
 	return tag, source, target, readonly, securityModel
 }
+
+// ErrPathNotShared is returned when a host path is not part of any volume
+// mounted into the machine.
+var ErrPathNotShared = errors.New("path is not shared with the machine")
+
+// normalizeHostPath converts a host path to a slash separated form so
+// Windows and unix style paths can be compared the same way.
+func normalizeHostPath(p string) string {
+	p = strings.TrimPrefix(p, `\\?\`)
+	p = strings.ReplaceAll(p, `\`, "/")
+	if len(p) > 1 && strings.HasSuffix(p, "/") {
+		p = strings.TrimRight(p, "/")
+	}
+	return p
+}
+
+// HostPathToGuest translates a path on the host into the matching path inside
+// the machine by looking it up in the machine mount table.  The longest
+// matching mount source wins.  Comparisons are case insensitive because the
+// mount table is only consulted for Windows hosts, where paths are.
+func HostPathToGuest(mounts []*Mount, hostPath string) (string, error) {
+	norm := normalizeHostPath(hostPath)
+	var best *Mount
+	bestLen := -1
+	for _, m := range mounts {
+		src := normalizeHostPath(m.Source)
+		if len(src) <= bestLen || len(norm) < len(src) || !strings.EqualFold(norm[:len(src)], src) {
+			continue
+		}
+		// Make sure we matched a whole path component, C:/foo must not match C:/foobar.
+		if len(norm) > len(src) && norm[len(src)] != '/' && !strings.HasSuffix(src, "/") {
+			continue
+		}
+		best = m
+		bestLen = len(src)
+	}
+	if best == nil {
+		return "", fmt.Errorf("%q: %w", hostPath, ErrPathNotShared)
+	}
+	rel := strings.TrimPrefix(norm[bestLen:], "/")
+	if rel == "" {
+		return best.Target, nil
+	}
+	return path.Join(best.Target, rel), nil
+}
@@ -0,0 +1,44 @@
+package vmconfigs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHostPathToGuest(t *testing.T) {
+	mounts := []*Mount{
+		{Source: `C:\Users`, Target: "/c/Users"},
+		{Source: `C:\Users\bob\src`, Target: "/src"},
+		{Source: "/Users", Target: "/Users"},
+	}
+	var tests = []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: `C:\Users`, want: "/c/Users"},
+		{path: `c:\users\alice\data`, want: "/c/Users/alice/data"},
+		{path: `C:\Users\bob\src\app`, want: "/src/app"},
+		{path: `\\?\C:\Users\bob`, want: "/c/Users/bob"},
+		{path: "/Users/bob/", want: "/Users/bob"},
+		{path: `C:\UsersX\foo`, wantErr: true},
+		{path: `D:\data`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := HostPathToGuest(mounts, tt.path)
+			if tt.wantErr {
+				if !errors.Is(err, ErrPathNotShared) {
+					t.Errorf("HostPathToGuest() error = %v, want ErrPathNotShared", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("HostPathToGuest() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HostPathToGuest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -833,3 +833,17 @@ func getResources(mc *vmconfigs.MachineConfig) (resources vmconfigs.ResourceConf
 	resources.DiskSize = getDiskSize(mc.Name)
 	return
 }
+
+// exportDistro writes the root filesystem of an existing WSL distribution
+// to a tarball that can be imported as a new machine.
+func exportDistro(dist string, target string) error {
+	exists, err := isWSLExist(dist)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("WSL distribution %q does not exist", dist)
+	}
+	fmt.Printf("Exporting WSL distribution %q, this may take a while...\n", dist)
+	return runCmdPassThrough(wutil.FindWSL(), "--export", dist, target)
+}
@@ -295,6 +295,10 @@ func (w WSLStubber) GetDisk(userInputPath string, dirs *define.MachineDirs, mc *
 		myDisk ocipull.Disker
 	)
 
+	if dist, ok := strings.CutPrefix(userInputPath, define.WSLDistroScheme); ok {
+		return exportDistro(dist, mc.ImagePath.GetPath())
+	}
+
 	if userInputPath != "" {
 		return diskpull.GetDisk(userInputPath, dirs, mc.ImagePath, w.VMType(), mc.Name)
 	}
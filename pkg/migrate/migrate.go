@@ -0,0 +1,227 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/domain/infra"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/sirupsen/logrus"
+)
+
+// Engines are the engines of a host a container is migrated from or to.
+type Engines struct {
+	Containers entities.ContainerEngine
+	Images     entities.ImageEngine
+}
+
+// Options describes how a container is migrated.
+type Options struct {
+	// Compression of the checkpoint archive sent to the destination.
+	Compression archive.Compression
+	// FileLocks checkpoints and restores the file locks of the container.
+	FileLocks bool
+	// IgnoreStaticIP does not restore the static IP address of the
+	// container on the destination.
+	IgnoreStaticIP bool
+	// IgnoreStaticMAC does not restore the static MAC address of the
+	// container on the destination.
+	IgnoreStaticMAC bool
+	// IgnoreVolumes does not send the volumes of the container.
+	IgnoreVolumes bool
+	// Keep keeps the checkpointed container on the source instead of
+	// removing it once it was restored on the destination.
+	Keep bool
+	// Name of the container on the destination, defaults to its name on
+	// the source.
+	Name string
+	// PublishPorts replaces the published ports of the container on the
+	// destination.
+	PublishPorts []string
+	// TCPEstablished migrates a container with established TCP
+	// connections.
+	TCPEstablished bool
+}
+
+// NewDestination connects to the engines of the given system connection.
+func NewDestination(connection string) (*Engines, error) {
+	cfg, err := config.Default()
+	if err != nil {
+		return nil, err
+	}
+	con, err := cfg.GetConnection(connection, false)
+	if err != nil {
+		return nil, err
+	}
+	podmanConfig := &entities.PodmanConfig{
+		EngineMode:  entities.TunnelMode,
+		URI:         con.URI,
+		Identity:    con.Identity,
+		MachineMode: con.IsMachine,
+	}
+	containers, err := infra.NewContainerEngine(podmanConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing container engine at %q: %w", con.URI, err)
+	}
+	images, err := infra.NewImageEngine(podmanConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing image engine at %q: %w", con.URI, err)
+	}
+	return &Engines{Containers: containers, Images: images}, nil
+}
+
+// Migrate moves a running container from the source to the destination. The
+// container is checkpointed, its image is sent to the destination if it is
+// missing there, and the checkpoint including the changes to the root file
+// system is restored on the destination. The container is then removed from
+// the source unless Keep is set. If the container cannot be restored on the
+// destination, it is restored on the source again. Migrate returns the ID of
+// the container on the destination.
+func Migrate(ctx context.Context, source, destination *Engines, nameOrID string, options Options) (string, error) {
+	inspect, errs, err := source.Containers.ContainerInspect(ctx, []string{nameOrID}, entities.InspectOptions{})
+	if err != nil {
+		return "", err
+	}
+	if len(errs) > 0 {
+		return "", errs[0]
+	}
+	ctr := inspect[0]
+	if !ctr.State.Running {
+		return "", fmt.Errorf("container %s is %s, only running containers can be migrated", ctr.Name, ctr.State.Status)
+	}
+	if ctr.Pod != "" {
+		return "", fmt.Errorf("container %s is part of a pod, containers in pods cannot be migrated", ctr.Name)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "podman-migrate")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	image := ctr.ImageName
+	if image == "" {
+		image = ctr.Image
+	}
+	if err := sendImage(ctx, source, destination, ctr.Image, ctr.ImageName, tmpDir); err != nil {
+		return "", fmt.Errorf("sending image %s to the destination: %w", image, err)
+	}
+
+	checkpoint := filepath.Join(tmpDir, "checkpoint.tar")
+	checkpointReports, err := source.Containers.ContainerCheckpoint(ctx, []string{ctr.ID}, entities.CheckpointOptions{
+		Export:         checkpoint,
+		Compression:    options.Compression,
+		FileLocks:      options.FileLocks,
+		IgnoreVolumes:  options.IgnoreVolumes,
+		Keep:           true,
+		TCPEstablished: options.TCPEstablished,
+		SkipRecord:     true,
+	})
+	if err == nil && len(checkpointReports) > 0 {
+		err = checkpointReports[0].Err
+	}
+	if err != nil {
+		return "", fmt.Errorf("checkpointing container %s: %w", ctr.Name, err)
+	}
+
+	restoreReports, err := destination.Containers.ContainerRestore(ctx, nil, entities.RestoreOptions{
+		Import:          checkpoint,
+		FileLocks:       options.FileLocks,
+		IgnoreStaticIP:  options.IgnoreStaticIP,
+		IgnoreStaticMAC: options.IgnoreStaticMAC,
+		IgnoreVolumes:   options.IgnoreVolumes,
+		Name:            options.Name,
+		PublishPorts:    options.PublishPorts,
+		TCPEstablished:  options.TCPEstablished,
+	})
+	if err == nil && len(restoreReports) == 0 {
+		err = errors.New("no container was restored")
+	}
+	if err == nil {
+		err = restoreReports[0].Err
+	}
+	if err != nil {
+		err = fmt.Errorf("restoring container %s on the destination: %w", ctr.Name, err)
+		if restoreErr := restoreSource(ctx, source, ctr.ID, options); restoreErr != nil {
+			logrus.Errorf("Restoring container %s on the source: %v", ctr.Name, restoreErr)
+		}
+		return "", err
+	}
+	id := restoreReports[0].Id
+
+	if !options.Keep {
+		rmReports, err := source.Containers.ContainerRm(ctx, []string{ctr.ID}, entities.RmOptions{})
+		if err == nil && len(rmReports) > 0 {
+			err = rmReports[0].Err
+		}
+		if err != nil {
+			return id, fmt.Errorf("container %s was migrated, but removing it from the source: %w", ctr.Name, err)
+		}
+	}
+	return id, nil
+}
+
+// sendImage loads the image of the container, given its ID, into the
+// destination if the destination does not have an image with that ID. The
+// restore on the destination looks the image up by name, so the name is
+// tagged on the image when it refers to another image on the destination.
+func sendImage(ctx context.Context, source, destination *Engines, id, name, tmpDir string) error {
+	if id == "" {
+		// The container was created from a rootfs.
+		return nil
+	}
+	destID, err := imageID(ctx, destination, id)
+	if err != nil {
+		return err
+	}
+	if destID != id {
+		imageArchive := filepath.Join(tmpDir, "image.tar")
+		if err := source.Images.Save(ctx, id, nil, entities.ImageSaveOptions{Format: "docker-archive", Output: imageArchive}); err != nil {
+			return err
+		}
+		if _, err := destination.Images.Load(ctx, entities.ImageLoadOptions{Input: imageArchive, Quiet: true}); err != nil {
+			return err
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	if destID, err = imageID(ctx, destination, name); err != nil {
+		return err
+	}
+	if destID == id {
+		return nil
+	}
+	return destination.Images.Tag(ctx, id, []string{name}, entities.ImageTagOptions{})
+}
+
+// imageID returns the ID of the image with the given name or ID, or an empty
+// string if no such image exists.
+func imageID(ctx context.Context, engines *Engines, nameOrID string) (string, error) {
+	reports, _, err := engines.Images.Inspect(ctx, []string{nameOrID}, entities.InspectOptions{})
+	if err != nil {
+		return "", err
+	}
+	if len(reports) == 0 {
+		return "", nil
+	}
+	return reports[0].ID, nil
+}
+
+// restoreSource restores the container on the source from the checkpoint kept
+// by Migrate.
+func restoreSource(ctx context.Context, source *Engines, id string, options Options) error {
+	reports, err := source.Containers.ContainerRestore(ctx, []string{id}, entities.RestoreOptions{
+		FileLocks:      options.FileLocks,
+		TCPEstablished: options.TCPEstablished,
+	})
+	if err == nil && len(reports) > 0 {
+		err = reports[0].Err
+	}
+	return err
+}
@@ -0,0 +1,207 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/domain/entities"
+	"github.com/containers/podman/v5/pkg/domain/entities/reports"
+	"github.com/containers/podman/v5/pkg/inspect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContainerEngine records the calls made by Migrate.
+type fakeContainerEngine struct {
+	entities.ContainerEngine
+
+	running    bool
+	restoreErr error
+	calls      []string
+}
+
+func (f *fakeContainerEngine) ContainerInspect(_ context.Context, namesOrIds []string, _ entities.InspectOptions) ([]*entities.ContainerInspectReport, []error, error) {
+	f.calls = append(f.calls, "inspect")
+	status := "exited"
+	if f.running {
+		status = "running"
+	}
+	return []*entities.ContainerInspectReport{{InspectContainerData: &define.InspectContainerData{
+		ID:        "abc",
+		Name:      namesOrIds[0],
+		Image:     testImageID,
+		ImageName: "quay.io/libpod/testimage:latest",
+		State:     &define.InspectContainerState{Running: f.running, Status: status},
+	}}}, nil, nil
+}
+
+func (f *fakeContainerEngine) ContainerCheckpoint(_ context.Context, _ []string, options entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
+	f.calls = append(f.calls, "checkpoint")
+	if options.Export == "" || !options.Keep || !options.SkipRecord {
+		return nil, errors.New("unexpected checkpoint options")
+	}
+	return []*entities.CheckpointReport{{Id: "abc"}}, nil
+}
+
+func (f *fakeContainerEngine) ContainerRestore(_ context.Context, namesOrIds []string, options entities.RestoreOptions) ([]*entities.RestoreReport, error) {
+	if options.Import != "" {
+		f.calls = append(f.calls, "restore import")
+	} else {
+		f.calls = append(f.calls, "restore "+namesOrIds[0])
+	}
+	if f.restoreErr != nil {
+		return []*entities.RestoreReport{{Err: f.restoreErr}}, nil
+	}
+	return []*entities.RestoreReport{{Id: "def"}}, nil
+}
+
+func (f *fakeContainerEngine) ContainerRm(_ context.Context, namesOrIds []string, _ entities.RmOptions) ([]*reports.RmReport, error) {
+	f.calls = append(f.calls, "rm "+namesOrIds[0])
+	return []*reports.RmReport{{Id: namesOrIds[0]}}, nil
+}
+
+const testImageID = "8f9b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b"
+
+// fakeImageEngine records the calls made by Migrate.
+type fakeImageEngine struct {
+	entities.ImageEngine
+
+	// images maps the names and IDs of the images to their IDs.
+	images map[string]string
+	calls  []string
+}
+
+func (f *fakeImageEngine) Inspect(_ context.Context, namesOrIDs []string, _ entities.InspectOptions) ([]*entities.ImageInspectReport, []error, error) {
+	f.calls = append(f.calls, "inspect "+namesOrIDs[0])
+	id, ok := f.images[namesOrIDs[0]]
+	if !ok {
+		return nil, []error{errors.New("no such image")}, nil
+	}
+	return []*entities.ImageInspectReport{{ImageData: &inspect.ImageData{ID: id}}}, nil, nil
+}
+
+func (f *fakeImageEngine) Save(_ context.Context, nameOrID string, _ []string, _ entities.ImageSaveOptions) error {
+	f.calls = append(f.calls, "save "+nameOrID)
+	return nil
+}
+
+func (f *fakeImageEngine) Load(_ context.Context, _ entities.ImageLoadOptions) (*entities.ImageLoadReport, error) {
+	f.calls = append(f.calls, "load")
+	f.images[testImageID] = testImageID
+	return &entities.ImageLoadReport{}, nil
+}
+
+func (f *fakeImageEngine) Tag(_ context.Context, nameOrID string, tags []string, _ entities.ImageTagOptions) error {
+	f.calls = append(f.calls, "tag "+tags[0])
+	f.images[tags[0]] = f.images[nameOrID]
+	return nil
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name            string
+		running         bool
+		images          map[string]string
+		keep            bool
+		restoreErr      error
+		wantErr         string
+		wantID          string
+		wantSource      []string
+		wantDestination []string
+		wantImages      []string
+	}{
+		{
+			name:       "not running",
+			wantErr:    "only running containers can be migrated",
+			wantSource: []string{"inspect"},
+		},
+		{
+			name:    "image on destination",
+			running: true,
+			images: map[string]string{
+				testImageID:                       testImageID,
+				"quay.io/libpod/testimage:latest": testImageID,
+			},
+			wantID:          "def",
+			wantSource:      []string{"inspect", "checkpoint", "rm abc"},
+			wantDestination: []string{"restore import"},
+			wantImages:      []string{"inspect " + testImageID, "inspect quay.io/libpod/testimage:latest"},
+		},
+		{
+			name:            "image sent and source kept",
+			running:         true,
+			keep:            true,
+			images:          map[string]string{},
+			wantID:          "def",
+			wantSource:      []string{"inspect", "checkpoint"},
+			wantDestination: []string{"restore import"},
+			wantImages: []string{
+				"inspect " + testImageID, "save " + testImageID, "load",
+				"inspect quay.io/libpod/testimage:latest", "tag quay.io/libpod/testimage:latest",
+			},
+		},
+		{
+			name:    "other image with the same name on destination",
+			running: true,
+			images: map[string]string{
+				"quay.io/libpod/testimage:latest": "0123456789abcdef",
+			},
+			wantID:          "def",
+			wantSource:      []string{"inspect", "checkpoint", "rm abc"},
+			wantDestination: []string{"restore import"},
+			wantImages: []string{
+				"inspect " + testImageID, "save " + testImageID, "load",
+				"inspect quay.io/libpod/testimage:latest", "tag quay.io/libpod/testimage:latest",
+			},
+		},
+		{
+			name:    "image on destination without the name",
+			running: true,
+			images: map[string]string{
+				testImageID: testImageID,
+			},
+			wantID:          "def",
+			wantSource:      []string{"inspect", "checkpoint", "rm abc"},
+			wantDestination: []string{"restore import"},
+			wantImages: []string{
+				"inspect " + testImageID,
+				"inspect quay.io/libpod/testimage:latest", "tag quay.io/libpod/testimage:latest",
+			},
+		},
+		{
+			name:    "restore fails",
+			running: true,
+			images: map[string]string{
+				testImageID:                       testImageID,
+				"quay.io/libpod/testimage:latest": testImageID,
+			},
+			restoreErr:      errors.New("name in use"),
+			wantErr:         "restoring container web on the destination: name in use",
+			wantSource:      []string{"inspect", "checkpoint", "restore abc"},
+			wantDestination: []string{"restore import"},
+			wantImages:      []string{"inspect " + testImageID, "inspect quay.io/libpod/testimage:latest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceCtrs := &fakeContainerEngine{running: tt.running}
+			destCtrs := &fakeContainerEngine{restoreErr: tt.restoreErr}
+			images := &fakeImageEngine{images: tt.images}
+			source := &Engines{Containers: sourceCtrs, Images: images}
+			destination := &Engines{Containers: destCtrs, Images: images}
+
+			id, err := Migrate(context.Background(), source, destination, "web", Options{Keep: tt.keep})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantSource, sourceCtrs.calls)
+			assert.Equal(t, tt.wantDestination, destCtrs.calls)
+			assert.Equal(t, tt.wantImages, images.calls)
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package define
 
+import "time"
+
 // ContainerSize holds the size of the container's root filesystem and top
 // read-write layer.
 type ContainerSize struct {
 	RootFsSize int64 `json:"rootFsSize"`
 	RwSize     int64 `json:"rwSize"`
+	// ComputedAt is when the sizes were computed, they may have been
+	// cached since.
+	ComputedAt time.Time `json:"computedAt"`
 }
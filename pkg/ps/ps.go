@@ -60,7 +60,13 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	// This may return slightly outdated states but that's acceptable for
 	// listing containers; any state is outdated the point a container lock
 	// gets released.
-	cons, err := runtime.GetContainers(true, filterFuncs...)
+	stateFilters := filters.GenerateContainerStateFilters(options.Filters, runtime)
+	if options.Last > 0 && len(filterFuncs) == 0 {
+		// Without filters evaluated on the loaded containers, the
+		// database can select the last created containers itself.
+		stateFilters.Limit = options.Last
+	}
+	cons, err := runtime.GetContainersFiltered(true, stateFilters, filterFuncs...)
 	if err != nil {
 		return nil, err
 	}
@@ -216,18 +222,15 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		if opts.Size {
 			size = new(psdefine.ContainerSize)
 
-			rootFsSize, err := c.RootFsSize()
+			ctrSize, err := c.Size()
 			if err != nil {
-				logrus.Errorf("Getting root fs size for %q: %v", c.ID(), err)
+				logrus.Errorf("Getting size for %q: %v", c.ID(), err)
 			}
-
-			rwSize, err := c.RWSize()
-			if err != nil {
-				logrus.Errorf("Getting rw size for %q: %v", c.ID(), err)
+			if ctrSize != nil {
+				size.RootFsSize = ctrSize.RootFsSize
+				size.RwSize = ctrSize.RWSize
+				size.ComputedAt = ctrSize.ComputedAt
 			}
-
-			size.RootFsSize = rootFsSize
-			size.RwSize = rwSize
 		}
 
 		if opts.Pod && len(conConfig.Pod) > 0 {
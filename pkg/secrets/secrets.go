@@ -0,0 +1,337 @@
+// Package secrets extends the secrets manager of containers/common with the
+// secret drivers and the secret versions implemented by Podman.
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/common/pkg/secrets/define"
+	"github.com/containers/common/pkg/secrets/filedriver"
+	"github.com/containers/common/pkg/secrets/passdriver"
+	"github.com/containers/common/pkg/secrets/shelldriver"
+	"github.com/containers/podman/v5/pkg/secrets/vault"
+	"github.com/containers/storage/pkg/lockfile"
+)
+
+const (
+	// VaultDriver is the name of the driver reading the payloads of secrets
+	// from a vault.
+	VaultDriver = "vault"
+
+	// fileDriver is the name of the file driver of containers/common.
+	fileDriver = "file"
+
+	// driverMetadata is the metadata key recording the Podman driver of a
+	// secret stored with a driver of containers/common.
+	driverMetadata = "podman.driver"
+
+	// recordsFile is the file where the versions of the secrets are stored.
+	recordsFile = "podman-secrets.json"
+)
+
+// errDataSize matches the limit of the secret data in containers/common.
+var errDataSize = errors.New("secret data must be larger than 0 and less than 512000 bytes")
+
+// maxSecretSize is the maximum size of the secret data.
+const maxSecretSize = 512000
+
+// Manager wraps the secrets manager of containers/common.
+// Secrets of the vault driver are stored with the file driver, which holds
+// their location in the vault, and are marked in the metadata of the secret.
+// Versions are recorded next to the secrets database.
+type Manager struct {
+	*secrets.SecretsManager
+	// recordsPath is the path to the file where versions are stored
+	recordsPath string
+	// lockfile is the locker for the records file
+	lockfile *lockfile.LockFile
+}
+
+// record is the Podman specific state of a secret.
+type record struct {
+	// Version is incremented every time the secret data is replaced.
+	Version int `json:"version"`
+	// UpdatedAt is when the secret data was last updated in place.
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewManager creates a new secrets manager.
+// rootPath is the directory where the secrets data file resides.
+func NewManager(rootPath string) (*Manager, error) {
+	manager, err := secrets.NewManager(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := lockfile.GetLockFile(filepath.Join(rootPath, "podman-secrets.lock"))
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{
+		SecretsManager: manager,
+		recordsPath:    filepath.Join(rootPath, recordsFile),
+		lockfile:       lock,
+	}, nil
+}
+
+// Store takes a name, creates a secret and stores the secret metadata and the
+// secret payload. It returns a generated ID that is associated with the secret.
+// A secret replacing another one continues its versions.
+func (m *Manager) Store(name string, data []byte, driverType string, options secrets.StoreOptions) (string, error) {
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	records, err := m.readRecords()
+	if err != nil {
+		return "", err
+	}
+	version := 1
+	if options.Replace {
+		if old, err := m.SecretsManager.Lookup(name); err == nil && old.Name == name {
+			version = records[old.ID].version() + 1
+			delete(records, old.ID)
+		}
+	}
+
+	if driverType == VaultDriver {
+		if _, err := vault.NewDriver(options.DriverOpts); err != nil {
+			return "", err
+		}
+		if data, err = vault.ParseReference(data); err != nil {
+			return "", err
+		}
+		options.Metadata = maps.Clone(options.Metadata)
+		if options.Metadata == nil {
+			options.Metadata = make(map[string]string)
+		}
+		options.Metadata[driverMetadata] = VaultDriver
+		driverType = fileDriver
+	}
+
+	id, err := m.SecretsManager.Store(name, data, driverType, options)
+	if err != nil {
+		return "", err
+	}
+	records[id] = record{Version: version}
+	return id, m.writeRecords(records)
+}
+
+// Update replaces the data of an existing secret, given its name, ID, or
+// partial ID, and increments its version. Unlike Store with the Replace
+// option, the ID, metadata and driver of the secret are kept, so containers
+// using the secret keep referring to it.
+func (m *Manager) Update(nameOrID string, data []byte) (*secrets.Secret, error) {
+	if !(len(data) > 0 && len(data) < maxSecretSize) {
+		return nil, errDataSize
+	}
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	secr, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if secr.Metadata[driverMetadata] == VaultDriver {
+		if data, err = vault.ParseReference(data); err != nil {
+			return nil, err
+		}
+		defer vault.Forget(secr.ID)
+	}
+	driver, err := getDriver(secr.Driver, secr.DriverOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the current data to restore it if the new data cannot be stored.
+	oldData, err := driver.Lookup(secr.ID)
+	if err != nil && !errors.Is(err, define.ErrNoSuchSecret) {
+		return nil, fmt.Errorf("looking up data of secret %s: %w", secr.Name, err)
+	}
+	if err := driver.Delete(secr.ID); err != nil && !errors.Is(err, define.ErrNoSuchSecret) {
+		return nil, fmt.Errorf("deleting driver secret %s: %w", secr.ID, err)
+	}
+	if err := driver.Store(secr.ID, data); err != nil {
+		if oldData != nil {
+			if restoreErr := driver.Store(secr.ID, oldData); restoreErr != nil {
+				err = fmt.Errorf("%w (restoring the previous data: %v)", err, restoreErr)
+			}
+		}
+		return nil, fmt.Errorf("updating secret %s: %w", secr.Name, err)
+	}
+
+	records, err := m.readRecords()
+	if err != nil {
+		return nil, err
+	}
+	records[secr.ID] = record{Version: records[secr.ID].version() + 1, UpdatedAt: time.Now()}
+	if err := m.writeRecords(records); err != nil {
+		return nil, fmt.Errorf("updating secret %s: %w", secr.Name, err)
+	}
+	decorate(secr, records)
+	return secr, nil
+}
+
+// Delete removes all secret metadata and secret data associated with the
+// specified secret. Delete takes a name, ID, or partial ID.
+func (m *Manager) Delete(nameOrID string) (string, error) {
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	id, err := m.SecretsManager.Delete(nameOrID)
+	if err != nil {
+		return id, err
+	}
+	vault.Forget(id)
+	records, err := m.readRecords()
+	if err != nil {
+		return id, err
+	}
+	if _, ok := records[id]; !ok {
+		return id, nil
+	}
+	delete(records, id)
+	return id, m.writeRecords(records)
+}
+
+// Lookup gives a secret's metadata given its name, ID, or partial ID.
+func (m *Manager) Lookup(nameOrID string) (*secrets.Secret, error) {
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	secr, err := m.SecretsManager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := m.readRecords()
+	if err != nil {
+		return nil, err
+	}
+	decorate(secr, records)
+	return secr, nil
+}
+
+// List lists all secrets.
+func (m *Manager) List() ([]secrets.Secret, error) {
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	list, err := m.SecretsManager.List()
+	if err != nil {
+		return nil, err
+	}
+	records, err := m.readRecords()
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		decorate(&list[i], records)
+	}
+	return list, nil
+}
+
+// LookupSecretData returns secret metadata as well as secret data in bytes.
+// The secret data can be looked up using its name, ID, or partial ID. The
+// payload of a vault secret is read from the vault.
+func (m *Manager) LookupSecretData(nameOrID string) (*secrets.Secret, []byte, error) {
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	secr, data, err := m.SecretsManager.LookupSecretData(nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := m.readRecords()
+	if err != nil {
+		return nil, nil, err
+	}
+	decorate(secr, records)
+	if secr.Driver == VaultDriver {
+		driver, err := vault.NewDriver(secr.DriverOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data, err = driver.Lookup(secr.ID, data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return secr, data, nil
+}
+
+// Version returns the version of the secret data, starting at 1.
+func (m *Manager) Version(id string) (int, error) {
+	m.lockfile.Lock()
+	defer m.lockfile.Unlock()
+
+	records, err := m.readRecords()
+	if err != nil {
+		return 0, err
+	}
+	return records[id].version(), nil
+}
+
+// version returns the version of the secret data, starting at 1 for secrets
+// without a record.
+func (r record) version() int {
+	if r.Version < 1 {
+		return 1
+	}
+	return r.Version
+}
+
+// decorate sets the Podman driver and update time of a secret.
+func decorate(secr *secrets.Secret, records map[string]record) {
+	if driver, ok := secr.Metadata[driverMetadata]; ok {
+		secr.Driver = driver
+	}
+	if updated := records[secr.ID].UpdatedAt; updated.After(secr.UpdatedAt) {
+		secr.UpdatedAt = updated
+	}
+}
+
+// getDriver creates the containers/common driver of a secret, as the secrets
+// manager does.
+func getDriver(name string, opts map[string]string) (secrets.SecretsDriver, error) {
+	switch name {
+	case fileDriver:
+		if path, ok := opts["path"]; ok {
+			return filedriver.NewDriver(path)
+		}
+		return nil, errors.New("need path for filedriver")
+	case "pass":
+		return passdriver.NewDriver(opts)
+	case "shell":
+		return shelldriver.NewDriver(opts)
+	}
+	return nil, fmt.Errorf("unsupported secret driver %q", name)
+}
+
+// readRecords reads the records of all secrets.
+func (m *Manager) readRecords() (map[string]record, error) {
+	records := make(map[string]record)
+	content, err := os.ReadFile(m.recordsPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return records, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", m.recordsPath, err)
+	}
+	return records, nil
+}
+
+// writeRecords replaces the records of all secrets.
+func (m *Manager) writeRecords(records map[string]record) error {
+	content, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.recordsPath, content, 0o600)
+}
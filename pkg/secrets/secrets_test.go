@@ -0,0 +1,139 @@
+package secrets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/containers/common/pkg/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultSecretDriver(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch {
+		case r.URL.Path == "/v1/secret/data/db" && r.Header.Get("X-Vault-Token") == "s.token":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"hunter2","port":5432},"metadata":{"version":1}}}`))
+		case r.URL.Path == "/api/db" && r.Header.Get("Authorization") == "Bearer s.token":
+			_, _ = w.Write([]byte(`{"password":"hunter3"}`))
+		case r.URL.Path == "/v1/secret/data/db" || r.URL.Path == "/api/db":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s.token\n"), 0o600))
+	manager, err := NewManager(filepath.Join(dir, "secrets"))
+	require.NoError(t, err)
+	driverPath := filepath.Join(dir, "vaultdriver")
+
+	opts := map[string]string{"path": driverPath, "url": srv.URL, "tokenfile": tokenFile, "ttl": "1m"}
+	_, err = manager.Store("password", []byte("secret/data/db#password\n"), VaultDriver, secrets.StoreOptions{DriverOpts: opts})
+	require.NoError(t, err)
+	_, data, err := manager.LookupSecretData("password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", string(data))
+
+	// The payload is cached for the TTL and never written to disk.
+	_, data, err = manager.LookupSecretData("password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", string(data))
+	assert.Equal(t, int32(1), requests.Load())
+	references, err := os.ReadFile(filepath.Join(driverPath, "secretsdata.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(references), "hunter2")
+	secret, err := manager.Lookup("password")
+	require.NoError(t, err)
+	assert.Equal(t, VaultDriver, secret.Driver)
+
+	// Fields which are not strings are returned as JSON.
+	_, err = manager.Store("port", []byte("secret/data/db#port"), VaultDriver, secrets.StoreOptions{DriverOpts: opts})
+	require.NoError(t, err)
+	_, data, err = manager.LookupSecretData("port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", string(data))
+
+	_, err = manager.Store("missing", []byte("secret/data/other"), VaultDriver, secrets.StoreOptions{DriverOpts: opts})
+	require.NoError(t, err)
+	_, _, err = manager.LookupSecretData("missing")
+	assert.ErrorContains(t, err, "no such secret")
+
+	httpsOpts := map[string]string{"path": driverPath, "type": "https", "url": srv.URL + "/api", "tokenfile": tokenFile}
+	_, err = manager.Store("https", []byte("db#password"), VaultDriver, secrets.StoreOptions{DriverOpts: httpsOpts})
+	require.NoError(t, err)
+	_, data, err = manager.LookupSecretData("https")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter3", string(data))
+
+	_, err = manager.Delete("password")
+	require.NoError(t, err)
+
+	// Payloads must not be sent in plain text to other hosts.
+	_, err = manager.Store("remote", []byte("secret/data/db"), VaultDriver, secrets.StoreOptions{DriverOpts: map[string]string{"path": driverPath, "url": "http://vault.example.com:8200"}})
+	assert.ErrorContains(t, err, "must use https")
+	_, err = manager.Store("empty", []byte("\n"), VaultDriver, secrets.StoreOptions{DriverOpts: opts})
+	assert.Error(t, err)
+}
+
+func TestSecretUpdate(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := NewManager(filepath.Join(dir, "secrets"))
+	require.NoError(t, err)
+	opts := secrets.StoreOptions{DriverOpts: map[string]string{"path": filepath.Join(dir, "filedriver")}}
+
+	id, err := manager.Store("db", []byte("v1"), "file", opts)
+	require.NoError(t, err)
+	secret, err := manager.Lookup("db")
+	require.NoError(t, err)
+	version, err := manager.Version(id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	// The ID is kept, so containers using the secret still find it.
+	updated, err := manager.Update("db", []byte("v2"))
+	require.NoError(t, err)
+	assert.Equal(t, id, updated.ID)
+	assert.True(t, updated.UpdatedAt.After(secret.UpdatedAt))
+	secret, data, err := manager.LookupSecretData(id)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(data))
+	assert.True(t, updated.UpdatedAt.Equal(secret.UpdatedAt))
+	version, err = manager.Version(id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	_, err = manager.Update("db", nil)
+	assert.Error(t, err)
+	_, data, err = manager.LookupSecretData(id)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(data))
+
+	_, err = manager.Update("missing", []byte("v1"))
+	assert.ErrorIs(t, err, secrets.ErrNoSuchSecret)
+
+	// Replacing a secret creates a new one but continues its versions.
+	_, err = manager.Store("db", []byte("v3"), "file", secrets.StoreOptions{DriverOpts: opts.DriverOpts, Replace: true})
+	require.NoError(t, err)
+	secret, err = manager.Lookup("db")
+	require.NoError(t, err)
+	assert.NotEqual(t, id, secret.ID)
+	version, err = manager.Version(secret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+
+	_, err = manager.Delete("db")
+	require.NoError(t, err)
+	version, err = manager.Version(secret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
@@ -0,0 +1,334 @@
+// Package vault implements the "vault" secret driver, which reads the payloads
+// of secrets from a HashiCorp Vault server or a generic HTTPS endpoint whenever
+// they are looked up. Only the location of a secret in the vault is stored on
+// disk, by the file driver of containers/common.
+package vault
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/common/pkg/secrets/define"
+)
+
+const (
+	// TypeHashiCorp reads secrets from the KV secrets engine of a
+	// HashiCorp Vault server.
+	TypeHashiCorp = "hashicorp"
+	// TypeHTTPS reads secrets from a generic HTTPS endpoint.
+	TypeHTTPS = "https"
+)
+
+// maxPayloadSize is the maximum size of a payload read from the vault, the
+// maximum size of a secret.
+const maxPayloadSize = 512000
+
+// requestTimeout is the timeout of a request to the vault.
+const requestTimeout = 30 * time.Second
+
+// defaultField is the field of a HashiCorp Vault secret used if the reference
+// does not name one.
+const defaultField = "value"
+
+type driverConfig struct {
+	// Type is the type of the vault, TypeHashiCorp or TypeHTTPS
+	Type string
+	// URL is the address of the vault
+	URL *url.URL
+	// TokenFile is a file containing the token used to authenticate
+	TokenFile string
+	// CACert is a file containing additional CA certificates to trust
+	CACert string
+	// Namespace is the HashiCorp Vault namespace
+	Namespace string
+	// TTL is how long payloads are cached in memory, 0 disables caching
+	TTL time.Duration
+}
+
+// parseOpts parses the driver options:
+//   - type: hashicorp (default) or https
+//   - url: address of the vault, defaults to $VAULT_ADDR for hashicorp
+//   - tokenfile: file containing the token, defaults to $VAULT_TOKEN for
+//     hashicorp
+//   - cacert: file containing additional CA certificates
+//   - namespace: HashiCorp Vault namespace
+//   - ttl: duration to cache payloads in memory, e.g. 5m
+func (cfg *driverConfig) parseOpts(opts map[string]string) error {
+	cfg.Type = TypeHashiCorp
+	if val, ok := opts["type"]; ok {
+		cfg.Type = val
+	}
+	if cfg.Type != TypeHashiCorp && cfg.Type != TypeHTTPS {
+		return fmt.Errorf("unknown vault type %q, must be %s or %s", cfg.Type, TypeHashiCorp, TypeHTTPS)
+	}
+
+	address := opts["url"]
+	if address == "" && cfg.Type == TypeHashiCorp {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return errors.New("need url of the vault")
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("parsing vault url: %w", err)
+	}
+	// Payloads must not be sent in plain text, except to a vault on the
+	// same host, e.g. a development server.
+	if u.Scheme != "https" && (u.Scheme != "http" || !isLoopback(u.Hostname())) {
+		return fmt.Errorf("vault url %s must use https", address)
+	}
+	cfg.URL = u
+
+	cfg.TokenFile = opts["tokenfile"]
+	cfg.CACert = opts["cacert"]
+	cfg.Namespace = opts["namespace"]
+	if val, ok := opts["ttl"]; ok {
+		ttl, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("parsing ttl: %w", err)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("ttl %s must not be negative", val)
+		}
+		cfg.TTL = ttl
+	}
+	return nil
+}
+
+// isLoopback returns true if host is localhost or a loopback address.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// cacheEntry is a payload cached in memory.
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+var (
+	cacheLock sync.Mutex
+	// cache holds the payloads read from vaults by secret ID. It is shared
+	// by all drivers as a new driver is created for every operation.
+	cache = make(map[string]cacheEntry)
+)
+
+// Driver reads the payloads of secrets from a vault.
+type Driver struct {
+	driverConfig
+}
+
+// NewDriver creates a new vault driver from the driver options.
+func NewDriver(opts map[string]string) (*Driver, error) {
+	driver := new(Driver)
+	if err := driver.parseOpts(opts); err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+// ParseReference returns the location of a secret in the vault, given as
+// PATH[#FIELD], which is stored in place of the payload of the secret.
+func ParseReference(data []byte) ([]byte, error) {
+	reference := strings.TrimSpace(string(data))
+	path, _ := splitReference(reference)
+	if path == "" {
+		return nil, fmt.Errorf("secret data must be the location of the secret in the vault as PATH[#FIELD]: %w", define.ErrInvalidKey)
+	}
+	return []byte(reference), nil
+}
+
+// Lookup reads the payload of the secret ID at the given reference from the
+// vault, or from the cache if it was read less than the TTL ago.
+func (d *Driver) Lookup(id string, reference []byte) ([]byte, error) {
+	if d.TTL > 0 {
+		cacheLock.Lock()
+		entry, ok := cache[id]
+		cacheLock.Unlock()
+		if ok && time.Now().Before(entry.expires) {
+			return entry.data, nil
+		}
+	}
+
+	data, err := d.fetch(string(reference))
+	if err != nil {
+		return nil, fmt.Errorf("reading secret %s from vault: %w", id, err)
+	}
+
+	if d.TTL > 0 {
+		cacheLock.Lock()
+		cache[id] = cacheEntry{data: data, expires: time.Now().Add(d.TTL)}
+		cacheLock.Unlock()
+	}
+	return data, nil
+}
+
+// Forget drops the cached payload of the secret ID, e.g. when the secret is
+// removed or its reference changes.
+func Forget(id string) {
+	cacheLock.Lock()
+	delete(cache, id)
+	cacheLock.Unlock()
+}
+
+// splitReference splits a reference into the path and the field of the
+// secret in the vault.
+func splitReference(reference string) (string, string) {
+	path, field, _ := strings.Cut(reference, "#")
+	return strings.Trim(path, "/"), field
+}
+
+// fetch reads the payload at the given reference from the vault.
+func (d *Driver) fetch(reference string) ([]byte, error) {
+	path, field := splitReference(reference)
+	u := *d.URL
+	if d.Type == TypeHashiCorp {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/" + path
+		if field == "" {
+			field = defaultField
+		}
+	} else {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + path
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	token, err := d.token()
+	if err != nil {
+		return nil, err
+	}
+	if d.Type == TypeHashiCorp {
+		if token != "" {
+			req.Header.Set("X-Vault-Token", token)
+		}
+		if d.Namespace != "" {
+			req.Header.Set("X-Vault-Namespace", d.Namespace)
+		}
+	} else if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client, err := d.client()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPayloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", path, define.ErrNoSuchSecret)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s: unexpected status %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	case len(body) > maxPayloadSize:
+		return nil, fmt.Errorf("%s: payload is larger than %d bytes", path, maxPayloadSize)
+	}
+
+	if d.Type == TypeHashiCorp {
+		return hashiCorpField(body, field)
+	}
+	if field == "" {
+		return body, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, fmt.Errorf("%s: parsing response to read field %s: %w", path, field, err)
+	}
+	return jsonField(object, field)
+}
+
+// hashiCorpField returns a field of a secret read from version 1 or 2 of the
+// KV secrets engine. Version 2 nests the fields in data.data next to
+// data.metadata.
+func hashiCorpField(body []byte, field string) ([]byte, error) {
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing vault response: %w", err)
+	}
+	data := resp.Data
+	nested, hasData := data["data"]
+	_, hasMetadata := data["metadata"]
+	if hasData && hasMetadata {
+		data = nil
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return nil, fmt.Errorf("parsing vault response: %w", err)
+		}
+	}
+	return jsonField(data, field)
+}
+
+// jsonField returns the value of a field of a JSON object, strings without
+// their quotes.
+func jsonField(object map[string]json.RawMessage, field string) ([]byte, error) {
+	raw, ok := object[field]
+	if !ok {
+		return nil, fmt.Errorf("field %s: %w", field, define.ErrNoSuchSecret)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []byte(s), nil
+	}
+	return raw, nil
+}
+
+// token returns the token used to authenticate to the vault, if any.
+func (d *Driver) token() (string, error) {
+	if d.TokenFile != "" {
+		token, err := os.ReadFile(d.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading vault token: %w", err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	if d.Type == TypeHashiCorp {
+		return os.Getenv("VAULT_TOKEN"), nil
+	}
+	return "", nil
+}
+
+// client returns the HTTP client used to connect to the vault.
+func (d *Driver) client() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if d.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(d.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("reading vault CA certificates: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificates found in %s", d.CACert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &http.Client{Transport: transport, Timeout: requestTimeout}, nil
+}
@@ -91,6 +91,11 @@ func (s *SpecGenerator) Validate() error {
 	if s.UserNS.IsPrivate() && s.IDMappings == nil {
 		return fmt.Errorf("IDMappings are required when not creating a User namespace: %w", ErrInvalidSpecConfig)
 	}
+	if s.Keyring != "" {
+		if err := define.ValidateKeyring(s.Keyring); err != nil {
+			return err
+		}
+	}
 
 	//
 	// ContainerCgroupConfig
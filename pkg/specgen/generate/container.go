@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -17,9 +18,11 @@ import (
 	"github.com/containers/podman/v5/libpod"
 	"github.com/containers/podman/v5/libpod/define"
 	ann "github.com/containers/podman/v5/pkg/annotations"
+	"github.com/containers/podman/v5/pkg/containersconf"
 	envLib "github.com/containers/podman/v5/pkg/env"
 	"github.com/containers/podman/v5/pkg/signal"
 	"github.com/containers/podman/v5/pkg/specgen"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/openshift/imagebuilder"
 	"github.com/sirupsen/logrus"
 )
@@ -180,6 +183,12 @@ func CompleteSpec(ctx context.Context, r *libpod.Runtime, s *specgen.SpecGenerat
 		}
 	}
 
+	if s.ProxyProfile != "" {
+		if err := applyProxyProfile(r.GetPodmanConfigNoCopy().Containers.ProxyProfiles, s, defaultEnvs); err != nil {
+			return nil, err
+		}
+	}
+
 	s.Env = envLib.Join(defaultEnvs, s.Env)
 
 	// Labels and Annotations
@@ -305,7 +314,7 @@ func CompleteSpec(ctx context.Context, r *libpod.Runtime, s *specgen.SpecGenerat
 	}
 
 	// Warn on net=host/container/pod/none and port mappings.
-	if (s.NetNS.NSMode == specgen.Host || s.NetNS.NSMode == specgen.FromContainer ||
+	if ((s.NetNS.NSMode == specgen.Host && !isHostGatewayAll(s)) || s.NetNS.NSMode == specgen.FromContainer ||
 		s.NetNS.NSMode == specgen.FromPod || s.NetNS.NSMode == specgen.NoNetwork) &&
 		len(s.PortMappings) > 0 {
 		warnings = append(warnings, "Port mappings have been discarded as one of the Host, Container, Pod, and None network modes are in use")
@@ -470,6 +479,8 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 				Source:      v.Source,
 				Destination: v.Dest,
 				ReadWrite:   v.ReadWrite,
+				SubPath:     v.SubPath,
+				Persist:     v.Persist,
 			})
 		}
 	}
@@ -553,3 +564,47 @@ func CheckName(rt *libpod.Runtime, n string, kind bool) string {
 	}
 	return n
 }
+
+// applyProxyProfile adds the proxy environment variables of the proxy profile
+// of the spec to envs and its certificate authority bundles to the mounts of
+// the spec. Mounts given explicitly for the same destination take precedence.
+func applyProxyProfile(profiles map[string]containersconf.ProxyProfile, s *specgen.SpecGenerator, envs map[string]string) error {
+	profile, ok := profiles[s.ProxyProfile]
+	if !ok {
+		return fmt.Errorf("proxy profile %q is not defined in containers.conf: %w", s.ProxyProfile, define.ErrInvalidArg)
+	}
+
+	for _, env := range []struct {
+		name, value string
+	}{
+		{"HTTP_PROXY", profile.HTTPProxy},
+		{"HTTPS_PROXY", profile.HTTPSProxy},
+		{"NO_PROXY", profile.NoProxy},
+	} {
+		if env.value == "" {
+			continue
+		}
+		envs[env.name] = env.value
+		envs[strings.ToLower(env.name)] = env.value
+	}
+
+	for _, caMount := range profile.CAMounts {
+		src, dest, ok := strings.Cut(caMount, ":")
+		if !ok || src == "" || dest == "" {
+			return fmt.Errorf("invalid CA mount %q of proxy profile %q, must be <file-on-host>:<file-in-container>: %w", caMount, s.ProxyProfile, define.ErrInvalidArg)
+		}
+		if _, err := os.Stat(src); err != nil {
+			return fmt.Errorf("CA bundle of proxy profile %q: %w", s.ProxyProfile, err)
+		}
+		if slices.ContainsFunc(s.Mounts, func(m spec.Mount) bool { return m.Destination == dest }) {
+			continue
+		}
+		s.Mounts = append(s.Mounts, spec.Mount{
+			Type:        define.TypeBind,
+			Source:      src,
+			Destination: dest,
+			Options:     []string{"ro"},
+		})
+	}
+	return nil
+}
@@ -132,6 +132,15 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		}
 		s.IDMappings = mappings
 	}
+	// A network mode given explicitly for a container in a pod sharing its
+	// network namespace opts the container out of it.
+	podNetworkOptOut := pod != nil && pod.SharesNet() && !s.NetNS.IsDefault() && !s.NetNS.IsPod()
+	if podNetworkOptOut {
+		if err := checkPodPortConflicts(infra, s.PortMappings); err != nil {
+			return nil, nil, nil, err
+		}
+		options = append(options, libpod.WithPodNetworkOptOut())
+	}
 	if s.NetNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("net", rtc, pod)
 		if err != nil {
@@ -147,6 +156,16 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		s.CgroupNS = defaultNS
 	}
 
+	profileOpts, err := applySecurityProfile(s, rt.GetPodmanConfigNoCopy().Containers.SecurityProfile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	options = append(options, profileOpts...)
+
+	if s.Keyring != "" {
+		options = append(options, libpod.WithKeyring(s.Keyring))
+	}
+
 	if s.ContainerCreateCommand != nil {
 		options = append(options, libpod.WithCreateCommand(s.ContainerCreateCommand))
 	}
@@ -362,6 +381,10 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithPreserveFD(s.PreserveFD))
 	}
 
+	if len(s.ListenSockets) > 0 {
+		options = append(options, libpod.WithListenSockets(s.ListenSockets))
+	}
+
 	if s.Stdin != nil && *s.Stdin {
 		options = append(options, libpod.WithStdin())
 	}
@@ -502,6 +525,7 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 				Source:    v.Source,
 				ReadWrite: v.ReadWrite,
 				SubPath:   v.SubPath,
+				Persist:   v.Persist,
 			})
 		}
 		options = append(options, libpod.WithImageVolumes(vols))
@@ -531,6 +555,25 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.StopSignal != nil {
 		options = append(options, libpod.WithStopSignal(*s.StopSignal))
 	}
+	if s.WatchProcesses != nil && *s.WatchProcesses {
+		options = append(options, libpod.WithWatchProcesses())
+	}
+	if s.ProvisionScript != "" {
+		options = append(options, libpod.WithProvisionScript(s.ProvisionScript))
+	}
+	if len(s.StartConditions) > 0 {
+		timeout := define.DefaultStartConditionsTimeout
+		if s.StartConditionsTimeout != nil {
+			timeout = *s.StartConditionsTimeout
+		}
+		options = append(options, libpod.WithStartConditions(s.StartConditions, timeout))
+	}
+	if len(s.OnExitNotify) > 0 {
+		options = append(options, libpod.WithOnExitNotify(s.OnExitNotify, s.OnExitNotifyTemplate))
+	}
+	if s.ProxyProfile != "" {
+		options = append(options, libpod.WithProxyProfile(s.ProxyProfile))
+	}
 	if s.StopTimeout != nil {
 		options = append(options, libpod.WithStopTimeout(*s.StopTimeout))
 	}
@@ -672,6 +715,10 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithEnvSecrets(s.EnvSecrets))
 	}
 
+	if s.EnvTemplate != "" {
+		options = append(options, libpod.WithEnvTemplate(s.EnvTemplate))
+	}
+
 	if len(s.DependencyContainers) > 0 {
 		deps := make([]*libpod.Container, 0, len(s.DependencyContainers))
 		for _, ctr := range s.DependencyContainers {
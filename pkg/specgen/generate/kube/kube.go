@@ -21,7 +21,6 @@ import (
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/common/pkg/parse"
-	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/image/v5/manifest"
 	itypes "github.com/containers/image/v5/types"
 	"github.com/containers/podman/v5/libpod/define"
@@ -30,6 +29,7 @@ import (
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/api/resource"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/containers/podman/v5/pkg/secrets"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgen/generate"
 	systemdDefine "github.com/containers/podman/v5/pkg/systemd/define"
@@ -167,7 +167,7 @@ type CtrSpecGenOptions struct {
 	// UtsNSIsHost tells the container to use the host utsns
 	UtsNSIsHost bool
 	// SecretManager to access the secrets
-	SecretsManager *secrets.SecretsManager
+	SecretsManager *secrets.Manager
 	// LogDriver which should be used for the container
 	LogDriver string
 	// LogOptions log options which should be used for the container
@@ -919,7 +919,7 @@ func quantityToInt64(quantity *resource.Quantity) (int64, error) {
 
 // read a k8s secret in JSON/YAML format from the secret manager
 // k8s secret is stored as YAML, we have to read data as JSON for backward compatibility
-func k8sSecretFromSecretManager(name string, secretsManager *secrets.SecretsManager) (map[string][]byte, error) {
+func k8sSecretFromSecretManager(name string, secretsManager *secrets.Manager) (map[string][]byte, error) {
 	_, inputSecret, err := secretsManager.LookupSecretData(name)
 	if err != nil {
 		return nil, err
@@ -14,14 +14,15 @@ import (
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/api/resource"
 	v12 "github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/containers/podman/v5/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	podmanSecrets "github.com/containers/podman/v5/pkg/secrets"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/docker/docker/pkg/meminfo"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/yaml"
 )
 
-func createSecrets(t *testing.T, d string) *secrets.SecretsManager {
-	secretsManager, err := secrets.NewManager(d)
+func createSecrets(t *testing.T, d string) *podmanSecrets.Manager {
+	secretsManager, err := podmanSecrets.NewManager(d)
 	assert.NoError(t, err)
 
 	driver := "file"
@@ -12,6 +12,7 @@ import (
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v5/libpod"
 	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	podmanSecrets "github.com/containers/podman/v5/pkg/secrets"
 	"github.com/containers/storage/pkg/fileutils"
 
 	"github.com/sirupsen/logrus"
@@ -138,7 +139,7 @@ func VolumeFromHostPath(hostPath *v1.HostPathVolumeSource, mountLabel string) (*
 }
 
 // VolumeFromSecret creates a new kube volume from a kube secret.
-func VolumeFromSecret(secretSource *v1.SecretVolumeSource, secretsManager *secrets.SecretsManager) (*KubeVolume, error) {
+func VolumeFromSecret(secretSource *v1.SecretVolumeSource, secretsManager *podmanSecrets.Manager) (*KubeVolume, error) {
 	kv := &KubeVolume{
 		Type:        KubeVolumeTypeSecret,
 		Source:      secretSource.SecretName,
@@ -280,7 +281,7 @@ func VolumeFromEmptyDir(emptyDirVolumeSource *v1.EmptyDirVolumeSource, name stri
 }
 
 // Create a KubeVolume from one of the supported VolumeSource
-func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, volName, mountLabel string) (*KubeVolume, error) {
+func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *podmanSecrets.Manager, volName, mountLabel string) (*KubeVolume, error) {
 	switch {
 	case volumeSource.HostPath != nil:
 		return VolumeFromHostPath(volumeSource.HostPath, mountLabel)
@@ -298,7 +299,7 @@ func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, s
 }
 
 // Create a map of volume name to KubeVolume
-func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, mountLabel string) (map[string]*KubeVolume, error) {
+func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *podmanSecrets.Manager, mountLabel string) (map[string]*KubeVolume, error) {
 	volumes := make(map[string]*KubeVolume)
 
 	for _, specVolume := range specVolumes {
@@ -4,6 +4,7 @@ package generate
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/containers/common/libimage"
@@ -315,6 +316,19 @@ func namespaceOptions(s *specgen.SpecGenerator, rt *libpod.Runtime, pod *libpod.
 		} else {
 			toReturn = append(toReturn, libpod.WithNetNSFrom(netCtr))
 		}
+	case specgen.Host:
+		if isHostGatewayAll(s) {
+			// Publish all exposed ports as well, there is no other
+			// way to reach them from the machine host.
+			publishAll := true
+			publishSpec := *s
+			publishSpec.PublishExposedPorts = &publishAll
+			portMappings, expose, err := createPortMappings(&publishSpec, imageData)
+			if err != nil {
+				return nil, err
+			}
+			toReturn = append(toReturn, libpod.WithMachineHostPorts(portMappings, expose))
+		}
 	case specgen.Slirp:
 		portMappings, expose, err := createPortMappings(s, imageData)
 		if err != nil {
@@ -390,6 +404,9 @@ func namespaceOptions(s *specgen.SpecGenerator, rt *libpod.Runtime, pod *libpod.
 	if s.NetworkOptions != nil {
 		toReturn = append(toReturn, libpod.WithNetworkOptions(s.NetworkOptions))
 	}
+	if len(s.InterfaceOptions) > 0 {
+		toReturn = append(toReturn, libpod.WithInterfaceOptions(s.InterfaceOptions))
+	}
 
 	return toReturn, nil
 }
@@ -429,3 +446,8 @@ func GetNamespaceOptions(ns []string, netnsIsHost bool) ([]libpod.PodCreateOptio
 	}
 	return options, nil
 }
+
+// isHostGatewayAll reports whether the container uses --network host-gateway-all.
+func isHostGatewayAll(s *specgen.SpecGenerator) bool {
+	return slices.Contains(s.NetworkOptions[string(specgen.Host)], define.HostGatewayAllNetworkOption)
+}
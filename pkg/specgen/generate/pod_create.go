@@ -256,6 +256,9 @@ func MapSpec(p *specgen.PodSpecGenerator) (*specgen.SpecGenerator, error) {
 	if len(p.Networks) > 0 {
 		spec.Networks = p.Networks
 	}
+	if len(p.InterfaceOptions) > 0 {
+		spec.InterfaceOptions = p.InterfaceOptions
+	}
 	// deprecated cni networks for api users
 	if len(p.CNINetworks) > 0 {
 		spec.CNINetworks = p.CNINetworks
@@ -11,6 +11,8 @@ import (
 
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/specgenutil"
 	"github.com/containers/podman/v5/utils"
@@ -340,6 +342,57 @@ func appendProtocolsNoDuplicates(slice []string, protocols []string) []string {
 }
 
 // Make final port mappings for the container
+// checkPodPortConflicts returns an error if one of ports binds a host port
+// already published by the infra container of the pod.
+func checkPodPortConflicts(infra *libpod.Container, ports []types.PortMapping) error {
+	if infra == nil || len(ports) == 0 {
+		return nil
+	}
+	podPorts, err := infra.PortMappings()
+	if err != nil {
+		return err
+	}
+	for _, port := range ports {
+		for _, podPort := range podPorts {
+			if portMappingsOverlap(port, podPort) {
+				return fmt.Errorf("host port %d/%s is already published by the pod: %w", port.HostPort, port.Protocol, define.ErrInvalidArg)
+			}
+		}
+	}
+	return nil
+}
+
+// portMappingsOverlap reports whether a and b bind a common host port.
+// Random host ports never conflict.
+func portMappingsOverlap(a, b types.PortMapping) bool {
+	if a.HostPort == 0 || b.HostPort == 0 {
+		return false
+	}
+	anyIP := func(ip string) bool {
+		return ip == "" || ip == "0.0.0.0" || ip == "::"
+	}
+	if a.HostIP != b.HostIP && !anyIP(a.HostIP) && !anyIP(b.HostIP) {
+		return false
+	}
+	if uint32(a.HostPort)+uint32(max(a.Range, 1)) <= uint32(b.HostPort) ||
+		uint32(b.HostPort)+uint32(max(b.Range, 1)) <= uint32(a.HostPort) {
+		return false
+	}
+	protocols := func(p string) []string {
+		if p == "" {
+			return []string{"tcp"}
+		}
+		return strings.Split(p, ",")
+	}
+	bProtocols := protocols(b.Protocol)
+	for _, proto := range protocols(a.Protocol) {
+		if slices.Contains(bProtocols, proto) {
+			return true
+		}
+	}
+	return false
+}
+
 func createPortMappings(s *specgen.SpecGenerator, imageData *libimage.ImageData) ([]types.PortMapping, map[uint16][]string, error) {
 	expose := make(map[uint16]string)
 	var err error
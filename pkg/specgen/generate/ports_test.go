@@ -989,3 +989,66 @@ func TestParsePortMappingError(t *testing.T) {
 		})
 	}
 }
+
+func TestPortMappingsOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b types.PortMapping
+		want bool
+	}{
+		{
+			name: "same port",
+			a:    types.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 8080, ContainerPort: 8080, Protocol: "tcp"},
+			want: true,
+		},
+		{
+			name: "different protocol",
+			a:    types.PortMapping{HostPort: 53, ContainerPort: 53, Protocol: "udp"},
+			b:    types.PortMapping{HostPort: 53, ContainerPort: 53, Protocol: "tcp"},
+			want: false,
+		},
+		{
+			name: "protocol list",
+			a:    types.PortMapping{HostPort: 53, ContainerPort: 53, Protocol: "tcp,udp"},
+			b:    types.PortMapping{HostPort: 53, ContainerPort: 53, Protocol: "udp"},
+			want: true,
+		},
+		{
+			name: "inside range",
+			a:    types.PortMapping{HostPort: 8085, ContainerPort: 80, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 10},
+			want: true,
+		},
+		{
+			name: "after range",
+			a:    types.PortMapping{HostPort: 8090, ContainerPort: 80, Protocol: "tcp"},
+			b:    types.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 10},
+			want: false,
+		},
+		{
+			name: "different host ips",
+			a:    types.PortMapping{HostIP: "127.0.0.1", HostPort: 8080, ContainerPort: 80},
+			b:    types.PortMapping{HostIP: "192.168.1.1", HostPort: 8080, ContainerPort: 80},
+			want: false,
+		},
+		{
+			name: "all host ips",
+			a:    types.PortMapping{HostIP: "127.0.0.1", HostPort: 8080, ContainerPort: 80},
+			b:    types.PortMapping{HostPort: 8080, ContainerPort: 80},
+			want: true,
+		},
+		{
+			name: "random host port",
+			a:    types.PortMapping{ContainerPort: 80},
+			b:    types.PortMapping{HostPort: 8080, ContainerPort: 80},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, portMappingsOverlap(tt.a, tt.b))
+			assert.Equal(t, tt.want, portMappingsOverlap(tt.b, tt.a))
+		})
+	}
+}
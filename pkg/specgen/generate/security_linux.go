@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"syscall"
 
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/apparmor"
@@ -15,6 +16,7 @@ import (
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/containers/podman/v5/pkg/util"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/sirupsen/logrus"
@@ -215,6 +217,13 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 		configSpec.Linux.Seccomp = nil
 	}
 
+	if s.Keyring == define.KeyringNone {
+		if configSpec.Linux.Seccomp == nil {
+			return fmt.Errorf("--keyring=none requires a seccomp profile to block the keyring system calls: %w", define.ErrInvalidArg)
+		}
+		denyKeyringSyscalls(configSpec.Linux.Seccomp)
+	}
+
 	if s.ReadOnlyFilesystem != nil {
 		g.SetRootReadonly(*s.ReadOnlyFilesystem)
 	}
@@ -271,3 +280,23 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 
 	return nil
 }
+
+// denyKeyringSyscalls makes the keyring system calls fail with EPERM,
+// overriding any rule of the seccomp profile allowing them.
+func denyKeyringSyscalls(seccomp *spec.LinuxSeccomp) {
+	syscalls := make([]spec.LinuxSyscall, 0, len(seccomp.Syscalls)+1)
+	for _, rule := range seccomp.Syscalls {
+		rule.Names = slices.DeleteFunc(slices.Clone(rule.Names), func(name string) bool {
+			return slices.Contains(define.KeyringSyscalls, name)
+		})
+		if len(rule.Names) > 0 {
+			syscalls = append(syscalls, rule)
+		}
+	}
+	errno := uint(syscall.EPERM)
+	seccomp.Syscalls = append(syscalls, spec.LinuxSyscall{
+		Names:    slices.Clone(define.KeyringSyscalls),
+		Action:   spec.ActErrno,
+		ErrnoRet: &errno,
+	})
+}
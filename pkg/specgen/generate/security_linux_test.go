@@ -0,0 +1,32 @@
+//go:build !remote
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDenyKeyringSyscalls(t *testing.T) {
+	seccomp := &spec.LinuxSeccomp{
+		DefaultAction: spec.ActErrno,
+		Syscalls: []spec.LinuxSyscall{
+			{Names: []string{"read", "keyctl", "write"}, Action: spec.ActAllow},
+			{Names: []string{"add_key", "request_key"}, Action: spec.ActAllow},
+		},
+	}
+	denyKeyringSyscalls(seccomp)
+
+	if assert.Len(t, seccomp.Syscalls, 2) {
+		assert.Equal(t, []string{"read", "write"}, seccomp.Syscalls[0].Names)
+		deny := seccomp.Syscalls[1]
+		assert.Equal(t, define.KeyringSyscalls, deny.Names)
+		assert.Equal(t, spec.ActErrno, deny.Action)
+		if assert.NotNil(t, deny.ErrnoRet) {
+			assert.Equal(t, uint(1), *deny.ErrnoRet)
+		}
+	}
+}
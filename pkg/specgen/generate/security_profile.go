@@ -0,0 +1,183 @@
+//go:build !remote
+
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containers/common/pkg/capabilities"
+	"github.com/containers/podman/v5/libpod"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/rootless"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/sirupsen/logrus"
+)
+
+// baselineCapabilities are the capabilities the baseline security profile
+// allows to be added to a container.
+var baselineCapabilities = []string{
+	"CAP_AUDIT_WRITE",
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_FOWNER",
+	"CAP_FSETID",
+	"CAP_KILL",
+	"CAP_MKNOD",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_SETFCAP",
+	"CAP_SETGID",
+	"CAP_SETPCAP",
+	"CAP_SETUID",
+	"CAP_SYS_CHROOT",
+}
+
+// baselineSELinuxTypes are the SELinux process types the baseline security
+// profile allows.
+var baselineSELinuxTypes = []string{"container_t", "container_init_t", "container_kvm_t", "container_engine_t"}
+
+// applySecurityProfile enforces the security profile of the container, which
+// defaults to defaultProfile, the security profile of containers.conf. The
+// returned options record the profile in the container configuration,
+// including the default it overrides if any.
+func applySecurityProfile(s *specgen.SpecGenerator, defaultProfile string) ([]libpod.CtrCreateOption, error) {
+	if defaultProfile != "" {
+		if err := define.ValidateSecurityProfile(defaultProfile); err != nil {
+			return nil, fmt.Errorf("containers.conf: %w", err)
+		}
+	}
+	profile := s.SecurityProfile
+	if profile == "" {
+		profile = defaultProfile
+	}
+	if profile == "" {
+		return nil, nil
+	}
+	if err := define.ValidateSecurityProfile(profile); err != nil {
+		return nil, err
+	}
+
+	overridden := ""
+	if defaultProfile != "" && profile != defaultProfile {
+		overridden = defaultProfile
+		logrus.Warnf("Security profile %q of containers.conf overridden with %q for container %q", defaultProfile, profile, s.Name)
+	}
+
+	switch profile {
+	case define.SecurityProfileBaseline:
+		if err := checkBaselineProfile(s, profile); err != nil {
+			return nil, err
+		}
+	case define.SecurityProfileRestricted:
+		if err := checkBaselineProfile(s, profile); err != nil {
+			return nil, err
+		}
+		if err := applyRestrictedProfile(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return []libpod.CtrCreateOption{libpod.WithSecurityProfile(profile, overridden)}, nil
+}
+
+// checkBaselineProfile rejects the known privilege escalations forbidden by
+// the baseline security profile.
+func checkBaselineProfile(s *specgen.SpecGenerator, profile string) error {
+	violation := func(format string, a ...any) error {
+		return securityProfileViolation(profile, format, a...)
+	}
+
+	if s.IsPrivileged() {
+		return violation("--privileged")
+	}
+	if s.PidNS.IsHost() {
+		return violation("the host PID namespace")
+	}
+	if s.IpcNS.IsHost() {
+		return violation("the host IPC namespace")
+	}
+	if s.NetNS.IsHost() {
+		return violation("the host network namespace")
+	}
+
+	capAdd, err := capabilities.NormalizeCapabilities(s.CapAdd)
+	if err != nil {
+		return err
+	}
+	for _, c := range capAdd {
+		if !slices.Contains(baselineCapabilities, c) {
+			return violation("adding capability %s", c)
+		}
+	}
+
+	if s.SeccompProfilePath == "unconfined" {
+		return violation("an unconfined seccomp profile")
+	}
+	if s.ApparmorProfile == "unconfined" {
+		return violation("an unconfined AppArmor profile")
+	}
+	for _, opt := range s.SelinuxOpts {
+		key, val, _ := strings.Cut(opt, ":")
+		switch key {
+		case "disable":
+			return violation("disabling SELinux separation")
+		case "user", "role":
+			return violation("setting the SELinux %s", key)
+		case "type":
+			if !slices.Contains(baselineSELinuxTypes, val) {
+				return violation("the SELinux type %s", val)
+			}
+		}
+	}
+	if len(s.Unmask) > 0 {
+		return violation("unmasking paths")
+	}
+	return nil
+}
+
+// applyRestrictedProfile hardens the container according to the restricted
+// security profile: all capabilities but NET_BIND_SERVICE are dropped,
+// privilege escalation is disabled and the container must not run as root
+// on the host.
+func applyRestrictedProfile(s *specgen.SpecGenerator) error {
+	violation := func(format string, a ...any) error {
+		return securityProfileViolation(define.SecurityProfileRestricted, format, a...)
+	}
+
+	capAdd, err := capabilities.NormalizeCapabilities(s.CapAdd)
+	if err != nil {
+		return err
+	}
+	for _, c := range capAdd {
+		if c != "CAP_NET_BIND_SERVICE" {
+			return violation("adding capability %s", c)
+		}
+	}
+	s.CapAdd = capAdd
+	s.CapDrop = []string{"ALL"}
+
+	if s.NoNewPrivileges != nil && !*s.NoNewPrivileges {
+		return violation("disabling no-new-privileges")
+	}
+	noNewPrivileges := true
+	s.NoNewPrivileges = &noNewPrivileges
+
+	if !rootless.IsRootless() && (s.UserNS.IsHost() || s.UserNS.IsDefault()) && !isNonRootUser(s.User) {
+		return violation("running as root without a user namespace")
+	}
+	return nil
+}
+
+// isNonRootUser returns true if the user of the container is set and is not
+// root.
+func isNonRootUser(user string) bool {
+	uid, _, _ := strings.Cut(user, ":")
+	return uid != "" && uid != "0" && uid != "root"
+}
+
+// securityProfileViolation returns the error of a setting forbidden by the
+// security profile.
+func securityProfileViolation(profile, format string, a ...any) error {
+	return fmt.Errorf("%s is not allowed by the %q security profile: %w", fmt.Sprintf(format, a...), profile, define.ErrInvalidArg)
+}
@@ -0,0 +1,69 @@
+//go:build !remote
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBaselineProfile(t *testing.T) {
+	privileged := true
+	tests := []struct {
+		name string
+		spec specgen.SpecGenerator
+		err  string
+	}{
+		{"default", specgen.SpecGenerator{}, ""},
+		{"allowed capability", specgen.SpecGenerator{ContainerSecurityConfig: specgen.ContainerSecurityConfig{CapAdd: []string{"net_bind_service"}}}, ""},
+		{"privileged", specgen.SpecGenerator{ContainerSecurityConfig: specgen.ContainerSecurityConfig{Privileged: &privileged}}, "--privileged"},
+		{"host pid", specgen.SpecGenerator{ContainerBasicConfig: specgen.ContainerBasicConfig{PidNS: specgen.Namespace{NSMode: specgen.Host}}}, "the host PID namespace"},
+		{"capability", specgen.SpecGenerator{ContainerSecurityConfig: specgen.ContainerSecurityConfig{CapAdd: []string{"SYS_ADMIN"}}}, "adding capability CAP_SYS_ADMIN"},
+		{"seccomp", specgen.SpecGenerator{ContainerSecurityConfig: specgen.ContainerSecurityConfig{SeccompProfilePath: "unconfined"}}, "an unconfined seccomp profile"},
+		{"selinux disable", specgen.SpecGenerator{ContainerSecurityConfig: specgen.ContainerSecurityConfig{SelinuxOpts: []string{"disable"}}}, "disabling SELinux separation"},
+		{"selinux type", specgen.SpecGenerator{ContainerSecurityConfig: specgen.ContainerSecurityConfig{SelinuxOpts: []string{"type:spc_t"}}}, "the SELinux type spc_t"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkBaselineProfile(&test.spec, define.SecurityProfileBaseline)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, define.ErrInvalidArg)
+			assert.ErrorContains(t, err, test.err+` is not allowed by the "baseline" security profile`)
+		})
+	}
+}
+
+func TestApplySecurityProfile(t *testing.T) {
+	s := specgen.SpecGenerator{}
+	s.User = "1000"
+	s.SecurityProfile = define.SecurityProfileRestricted
+	opts, err := applySecurityProfile(&s, define.SecurityProfileBaseline)
+	assert.NoError(t, err)
+	assert.Len(t, opts, 1)
+	assert.Equal(t, []string{"ALL"}, s.CapDrop)
+	if assert.NotNil(t, s.NoNewPrivileges) {
+		assert.True(t, *s.NoNewPrivileges)
+	}
+
+	s = specgen.SpecGenerator{}
+	s.User = "1000"
+	s.CapAdd = []string{"CHOWN"}
+	s.SecurityProfile = define.SecurityProfileRestricted
+	_, err = applySecurityProfile(&s, define.SecurityProfileBaseline)
+	assert.ErrorContains(t, err, `adding capability CAP_CHOWN is not allowed by the "restricted" security profile`)
+
+	s = specgen.SpecGenerator{}
+	s.SecurityProfile = "strict"
+	_, err = applySecurityProfile(&s, define.SecurityProfileBaseline)
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	opts, err = applySecurityProfile(&specgen.SpecGenerator{}, "")
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+}
@@ -55,6 +55,11 @@ const (
 	// Pasta indicates that a pasta network stack should be used.
 	// Only used with the network namespace, invalid otherwise.
 	Pasta NamespaceMode = "pasta"
+	// HostGatewayAll joins the host network namespace like Host and
+	// publishes all mapped and exposed ports to the podman machine host.
+	// Only valid as --network value, it is stored as Host with the
+	// define.HostGatewayAllNetworkOption network option.
+	HostGatewayAll NamespaceMode = "host-gateway-all"
 	// KeepId indicates a user namespace to keep the owner uid inside
 	// of the namespace itself.
 	// Only used with the user namespace, invalid otherwise.
@@ -368,6 +373,9 @@ func ParseNetworkFlag(networks []string) (Namespace, map[string]types.PerNetwork
 		toReturn.NSMode = NoNetwork
 	case ns == string(Host):
 		toReturn.NSMode = Host
+	case ns == string(HostGatewayAll):
+		toReturn.NSMode = Host
+		networkOptions = map[string][]string{string(Host): {define.HostGatewayAllNetworkOption}}
 	case strings.HasPrefix(ns, "ns:"):
 		_, value, _ := strings.Cut(ns, ":")
 		toReturn.NSMode = Path
@@ -63,6 +63,15 @@ func TestParseNetworkFlag(t *testing.T) {
 			nsmode:   Namespace{NSMode: Slirp},
 			networks: map[string]types.PerNetworkOptions{},
 		},
+		{
+			name:     "host-gateway-all mode",
+			args:     []string{"host-gateway-all"},
+			nsmode:   Namespace{NSMode: Host},
+			networks: map[string]types.PerNetworkOptions{},
+			options: map[string][]string{
+				"host": {"gateway-all"},
+			},
+		},
 		{
 			name:     "from pod mode",
 			args:     []string{"pod"},
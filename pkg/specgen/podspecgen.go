@@ -173,6 +173,10 @@ type PodNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// InterfaceOptions are the MTU and the offloads set on the interfaces
+	// of the infra container in its bridge networks.
+	// Optional.
+	InterfaceOptions map[string]string `json:"interface_options,omitempty"`
 }
 
 // PodStorageConfig contains all of the storage related options for the pod and its infra container.
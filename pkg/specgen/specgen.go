@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 	"syscall"
+	"time"
 
 	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/image/v5/manifest"
@@ -57,6 +58,11 @@ type ContainerBasicConfig struct {
 	// should be added to container
 	// Optional.
 	HTTPProxy *bool `json:"httpproxy,omitempty"`
+	// ProxyProfile is the name of a proxy profile of containers.conf whose
+	// proxy environment variables and certificate authority mounts are
+	// added to the container.
+	// Optional.
+	ProxyProfile string `json:"proxy_profile,omitempty"`
 	// Env is a set of environment variables that will be set in the
 	// container.
 	// Optional.
@@ -159,6 +165,31 @@ type ContainerBasicConfig struct {
 	// and exits.
 	// Optional.
 	Remove *bool `json:"remove,omitempty"`
+	// WatchProcesses indicates that an event should be written whenever a
+	// process starts or exits inside the container.
+	// Optional.
+	WatchProcesses *bool `json:"watch_processes,omitempty"`
+	// ProvisionScript is the content of a script run inside the container
+	// once, on its first start. The container is stopped if it fails.
+	// Optional.
+	ProvisionScript string `json:"provision_script,omitempty"`
+	// OnExitNotify are notifiers, given as webhook=URL, script=PATH or
+	// email=ADDRESS, notified when the container exits.
+	// Optional.
+	OnExitNotify []string `json:"on_exit_notify,omitempty"`
+	// OnExitNotifyTemplate is the Go template of the on-exit
+	// notifications.
+	// Optional.
+	OnExitNotifyTemplate string `json:"on_exit_notify_template,omitempty"`
+	// StartConditions are conditions on the host, given as
+	// path-exists=PATH or tcp=HOST:PORT, which must be met before the
+	// container is started.
+	// Optional.
+	StartConditions []string `json:"start_conditions,omitempty"`
+	// StartConditionsTimeout is how long the start conditions are waited
+	// for. Zero waits indefinitely. If unset, one minute is used.
+	// Optional.
+	StartConditionsTimeout *time.Duration `json:"start_conditions_timeout,omitempty"`
 	// ContainerCreateCommand is the command that was used to create this
 	// container.
 	// This will be shown in the output of Inspect() on the container, and
@@ -193,6 +224,11 @@ type ContainerBasicConfig struct {
 	// EnvSecrets are secrets that will be set as environment variables
 	// Optional.
 	EnvSecrets map[string]string `json:"secret_env,omitempty"`
+	// EnvTemplate is the content of an env file template. Its placeholders
+	// are resolved from secrets and the host environment every time the
+	// container is started.
+	// Optional.
+	EnvTemplate string `json:"env_template,omitempty"`
 	// InitContainerType describes if this container is an init container
 	// and if so, what type: always or once.
 	// Optional.
@@ -395,6 +431,17 @@ type ContainerSecurityConfig struct {
 	// SeccompPolicy determines which seccomp profile gets applied
 	// the container. valid values: empty,default,image
 	SeccompPolicy string `json:"seccomp_policy,omitempty"`
+	// SecurityProfile is the security profile enforced on the container:
+	// privileged, baseline or restricted. Defaults to the security profile
+	// of containers.conf.
+	// Optional.
+	SecurityProfile string `json:"security_profile,omitempty"`
+	// Keyring is the keyring mode of the container: private for a new
+	// session keyring, inherit to share the session keyring of Podman, or
+	// none to block the keyring system calls. Defaults to private or
+	// inherit depending on the keyring option of containers.conf.
+	// Optional.
+	Keyring string `json:"keyring,omitempty"`
 	// SeccompProfilePath is the path to a JSON file containing the
 	// container's Seccomp profile.
 	// If not specified, no Seccomp profile will be used.
@@ -472,6 +519,12 @@ type ContainerNetworkConfig struct {
 	// Only available if NetNS is set to bridge, slirp, or pasta.
 	// Optional.
 	PortMappings []nettypes.PortMapping `json:"portmappings,omitempty"`
+	// ListenSockets are sockets bound on the host and passed to the
+	// container using socket activation. Unlike PortMappings, the
+	// container accepts connections on the host sockets itself, and the
+	// sockets can be handed to a replacement of the container.
+	// Optional.
+	ListenSockets []define.ListenSocket `json:"listen_sockets,omitempty"`
 	// PublishExposedPorts will publish ports specified in the image to
 	// random unused ports (guaranteed to be above 1024) on the host.
 	// This is based on ports set in Expose below, and any ports specified
@@ -548,6 +601,10 @@ type ContainerNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// InterfaceOptions are the MTU and the offloads set on the interfaces
+	// of the container in its bridge networks, e.g. mtu=1400 or tso=off.
+	// Optional.
+	InterfaceOptions map[string]string `json:"interface_options,omitempty"`
 }
 
 // ContainerResourceConfig contains information on container resource limits.
@@ -56,6 +56,9 @@ type ImageVolume struct {
 	// SubPath mounts a particular path within the image.
 	// If empty, the whole image is mounted.
 	SubPath string `json:"subPath,omitempty"`
+	// Persist keeps the changes written to a read-write image volume in an
+	// anonymous volume, so they survive restarts of the container.
+	Persist bool `json:"persist,omitempty"`
 }
 
 // GenVolumeMounts parses user input into mounts, volumes and overlay volumes
@@ -3,11 +3,44 @@ package specgen
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"unicode"
 )
 
+// NoWinPathTranslationEnv is the environment variable that, when set to a true
+// value, disables the automatic translation of Windows host paths used as
+// volume and mount sources.  The path is then passed to the server verbatim.
+const NoWinPathTranslationEnv = "PODMAN_NO_WINPATH_TRANSLATION"
+
+// WinPathTranslationEnabled reports whether Windows host paths should be
+// translated, i.e. the NoWinPathTranslationEnv escape hatch is not set.
+func WinPathTranslationEnabled() bool {
+	val, ok := os.LookupEnv(NoWinPathTranslationEnv)
+	if !ok {
+		return true
+	}
+	disabled, err := strconv.ParseBool(val)
+	return err != nil || !disabled
+}
+
+// WinPathResolver translates an absolute Windows host path into the matching
+// path inside the machine the client is connected to.
+type WinPathResolver func(path string) (string, error)
+
+var winPathResolver WinPathResolver
+
+// SetWinPathResolver installs a resolver that replaces the default WSL style
+// translation done by ConvertWinMountPath.  Passing nil restores the default.
+func SetWinPathResolver(resolver WinPathResolver) {
+	winPathResolver = resolver
+}
+
 func isHostWinPath(path string) bool {
+	if !WinPathTranslationEnabled() {
+		return false
+	}
 	return shouldResolveWinPaths() && strings.HasPrefix(path, `\\`) || hasWinDriveScheme(path, 0) || winPathExists(path)
 }
 
@@ -22,11 +55,15 @@ func hasWinDriveScheme(path string, start int) bool {
 
 // Converts a Windows path to a WSL guest path if local env is a WSL linux guest or this is a Windows client.
 func ConvertWinMountPath(path string) (string, error) {
-	if !shouldResolveWinPaths() {
+	if !shouldResolveWinPaths() || !WinPathTranslationEnabled() {
 		return path, nil
 	}
 
 	if strings.HasPrefix(path, "/") {
+		// A custom resolver only deals with native Windows paths
+		if winPathResolver != nil {
+			return path, nil
+		}
 		// Handle /[driveletter]/windows/path form (e.g. c:\Users\bar == /c/Users/bar)
 		if len(path) > 2 && path[2] == '/' && shouldResolveUnixWinVariant(path) {
 			drive := unicode.ToLower(rune(path[1]))
@@ -42,6 +79,15 @@ func ConvertWinMountPath(path string) (string, error) {
 	// Convert remote win client relative paths to absolute
 	path = resolveRelativeOnWindows(path)
 
+	if winPathResolver != nil {
+		return winPathResolver(path)
+	}
+	return WSLMountPath(path)
+}
+
+// WSLMountPath converts an absolute Windows path to the location where WSL
+// mounts it, e.g. C:\Users becomes /mnt/c/Users.
+func WSLMountPath(path string) (string, error) {
 	// Strip extended marker prefix if present
 	path = strings.TrimPrefix(path, `\\?\`)
 
@@ -463,6 +463,10 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.HTTPProxy = &c.HTTPProxy
 	}
 
+	if s.ProxyProfile == "" {
+		s.ProxyProfile = c.ProxyProfile
+	}
+
 	// env-file overrides any previous variables
 	for _, f := range c.EnvFile {
 		fileEnv, err := envLib.ParseFile(f)
@@ -482,6 +486,19 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.Env = envLib.Join(env, parsedEnv)
 	}
 
+	// The env file template is read on the client side, but only resolved
+	// by the server when the container is started.
+	if c.EnvFileTemplate != "" {
+		content, err := os.ReadFile(c.EnvFileTemplate)
+		if err != nil {
+			return fmt.Errorf("reading env file template: %w", err)
+		}
+		if err := envLib.ValidateTemplate(string(content)); err != nil {
+			return fmt.Errorf("%s: %w", c.EnvFileTemplate, err)
+		}
+		s.EnvTemplate = string(content)
+	}
+
 	// LABEL VARIABLES
 	labels, err := parse.GetAllLabels(c.LabelFile, c.Label)
 	if err != nil {
@@ -582,6 +599,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.DNSSearch = c.Net.DNSSearch
 		s.DNSOptions = c.Net.DNSOptions
 		s.NetworkOptions = c.Net.NetworkOptions
+		s.InterfaceOptions = c.Net.InterfaceOptions
 		s.UseImageHosts = &c.Net.NoHosts
 	}
 	if len(s.HostUsers) == 0 || len(c.HostUsers) != 0 {
@@ -750,6 +768,14 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.SeccompPolicy = c.SeccompPolicy
 	}
 
+	if s.SecurityProfile == "" {
+		s.SecurityProfile = c.SecurityProfile
+	}
+
+	if s.Keyring == "" {
+		s.Keyring = c.Keyring
+	}
+
 	if len(s.VolumesFrom) == 0 || len(c.VolumesFrom) != 0 {
 		s.VolumesFrom = c.VolumesFrom
 	}
@@ -859,6 +885,13 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.PreserveFD = c.PreserveFD
 	}
 
+	if len(s.ListenSockets) == 0 || len(c.PublishSocket) != 0 {
+		s.ListenSockets, err = CreateListenSockets(c.PublishSocket)
+		if err != nil {
+			return err
+		}
+	}
+
 	if s.OOMScoreAdj == nil || c.OOMScoreAdj != nil {
 		s.OOMScoreAdj = c.OOMScoreAdj
 	}
@@ -886,6 +919,32 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if s.Remove == nil {
 		s.Remove = &c.Rm
 	}
+	if s.WatchProcesses == nil {
+		s.WatchProcesses = &c.WatchProcesses
+	}
+	if s.ProvisionScript == "" && c.ProvisionScript != "" {
+		script, err := os.ReadFile(c.ProvisionScript)
+		if err != nil {
+			return fmt.Errorf("reading provision script: %w", err)
+		}
+		s.ProvisionScript = string(script)
+	}
+	if len(s.OnExitNotify) == 0 {
+		s.OnExitNotify = c.OnExitNotify
+	}
+	if len(s.StartConditions) == 0 {
+		s.StartConditions = c.Conditions
+	}
+	if s.StartConditionsTimeout == nil && c.ConditionTimeout != "" {
+		timeout, err := time.ParseDuration(c.ConditionTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid condition timeout: %w", err)
+		}
+		s.StartConditionsTimeout = &timeout
+	}
+	if s.OnExitNotifyTemplate == "" {
+		s.OnExitNotifyTemplate = c.OnExitTemplate
+	}
 	if s.StopTimeout == nil || c.StopTimeout != 0 {
 		s.StopTimeout = &c.StopTimeout
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v5/libpod/define"
 	storageTypes "github.com/containers/storage/types"
 	"github.com/sirupsen/logrus"
 )
@@ -155,6 +156,45 @@ func CreatePortBindings(ports []string) ([]types.PortMapping, error) {
 	return toReturn, nil
 }
 
+// CreateListenSockets parses --publish-socket values, formatted as
+// [hostip:]port[/protocol], into sockets to bind on the host.
+func CreateListenSockets(sockets []string) ([]define.ListenSocket, error) {
+	toReturn := make([]define.ListenSocket, 0, len(sockets))
+	for _, s := range sockets {
+		socket := define.ListenSocket{Protocol: "tcp"}
+		address, proto, hasProto := strings.Cut(s, "/")
+		if hasProto {
+			if proto != "tcp" && proto != "udp" {
+				return nil, fmt.Errorf("invalid socket %q - protocol must be tcp or udp", s)
+			}
+			socket.Protocol = proto
+		}
+
+		port := address
+		if strings.Contains(address, ":") {
+			host, p, err := net.SplitHostPort(address)
+			if err != nil {
+				return nil, fmt.Errorf("invalid socket %q - format is [hostIP:]port[/protocol]: %w", s, err)
+			}
+			if host != "" && host != "0.0.0.0" {
+				ip := net.ParseIP(host)
+				if ip == nil {
+					return nil, fmt.Errorf("invalid socket %q - cannot parse %q as an IP address", s, host)
+				}
+				socket.HostIP = ip.String()
+			}
+			port = p
+		}
+		num, err := parseAndValidatePort(port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket %q: %w", s, err)
+		}
+		socket.Port = num
+		toReturn = append(toReturn, socket)
+	}
+	return toReturn, nil
+}
+
 // parseSplitPort parses individual components of the --publish flag to produce
 // a single port mapping in SpecGen format.
 func parseSplitPort(hostIP, hostPort *string, ctrPort string, protocol *string) (types.PortMapping, error) {
@@ -267,7 +307,39 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 	// user of the API.
 	// As such, provide a way to specify a path to Podman, so we can
 	// still invoke a cleanup process.
+	command, err := podmanCommandArgs(storageConfig, config, syslog)
+	if err != nil {
+		return nil, err
+	}
+
+	command = append(command, []string{"container", "cleanup"}...)
+
+	if rm {
+		command = append(command, "--rm")
+	}
+
+	// This has to be absolutely last, to ensure that the exec session ID
+	// will be added after it by Libpod.
+	if exec {
+		command = append(command, "--exec")
+	}
+
+	return command, nil
+}
+
+// CreateProcessWatcherCommandArgs returns the command watching the processes
+// of a container, the container ID has to be appended by the caller.
+func CreateProcessWatcherCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog bool) ([]string, error) {
+	command, err := podmanCommandArgs(storageConfig, config, syslog)
+	if err != nil {
+		return nil, err
+	}
+	return append(command, "container", "watch-processes"), nil
+}
 
+// podmanCommandArgs returns the path to Podman and the global options
+// needed for it to act on the same storage and configuration as the caller.
+func podmanCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog bool) ([]string, error) {
 	podmanPath, err := os.Executable()
 	if err != nil {
 		return nil, err
@@ -311,17 +383,5 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 		command = append(command, "--module", module)
 	}
 
-	command = append(command, []string{"container", "cleanup"}...)
-
-	if rm {
-		command = append(command, "--rm")
-	}
-
-	// This has to be absolutely last, to ensure that the exec session ID
-	// will be added after it by Libpod.
-	if exec {
-		command = append(command, "--exec")
-	}
-
 	return command, nil
 }
@@ -3,6 +3,8 @@ package specgenutil
 import (
 	"reflect"
 	"testing"
+
+	"github.com/containers/podman/v5/libpod/define"
 )
 
 func TestCreateExpose(t *testing.T) {
@@ -144,3 +146,58 @@ func Test_parseAndValidatePort(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateListenSockets(t *testing.T) {
+	tests := []struct {
+		name    string
+		sockets []string
+		want    []define.ListenSocket
+		wantErr bool
+	}{
+		{
+			name:    "port",
+			sockets: []string{"8080"},
+			want:    []define.ListenSocket{{Port: 8080, Protocol: "tcp"}},
+		},
+		{
+			name:    "ip, port and protocol",
+			sockets: []string{"127.0.0.1:53/udp", "[::1]:443"},
+			want: []define.ListenSocket{
+				{HostIP: "127.0.0.1", Port: 53, Protocol: "udp"},
+				{HostIP: "::1", Port: 443, Protocol: "tcp"},
+			},
+		},
+		{
+			name:    "all IPs",
+			sockets: []string{"0.0.0.0:80"},
+			want:    []define.ListenSocket{{Port: 80, Protocol: "tcp"}},
+		},
+		{
+			name:    "invalid protocol should fail",
+			sockets: []string{"80/sctp"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid IP should fail",
+			sockets: []string{"localhost:80"},
+			wantErr: true,
+		},
+		{
+			name:    "port range should fail",
+			sockets: []string{"80-81"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateListenSockets(tt.sockets)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateListenSockets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateListenSockets() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -619,6 +619,15 @@ func getImageVolume(args []string) (*specgen.ImageVolume, error) {
 				return nil, fmt.Errorf("volume subpath %q must be an absolute path", value)
 			}
 			newVolume.SubPath = value
+		case "persist":
+			switch value {
+			case "true":
+				newVolume.Persist = true
+			case "false":
+				// Nothing to do. Changes are discarded by default.
+			default:
+				return nil, fmt.Errorf("invalid persist value %q: %w", value, util.ErrBadMntOption)
+			}
 		case "consistency":
 			// Often used on MACs and mistakenly on Linux platforms.
 			// Since Docker ignores this option so shall we.
@@ -631,6 +640,9 @@ func getImageVolume(args []string) (*specgen.ImageVolume, error) {
 	if len(newVolume.Source)*len(newVolume.Destination) == 0 {
 		return nil, errors.New("must set source and destination for image volume")
 	}
+	if newVolume.Persist && !newVolume.ReadWrite {
+		return nil, errors.New("persist requires a read-write image volume, set rw=true")
+	}
 
 	return newVolume, nil
 }
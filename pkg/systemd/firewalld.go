@@ -0,0 +1,78 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+
+	godbus "github.com/godbus/dbus/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const firewalldInterface = "org.fedoraproject.FirewallD1"
+
+// WatchFirewalld returns a channel that receives a value whenever firewalld
+// reloads or (re)starts, as both flush the firewall rules created for
+// containers. The channel is closed once ctx is done.
+func WatchFirewalld(ctx context.Context) (<-chan struct{}, error) {
+	conn, err := godbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("connecting to the system bus: %w", err)
+	}
+	if err := conn.AddMatchSignalContext(ctx,
+		godbus.WithMatchInterface(firewalldInterface),
+		godbus.WithMatchMember("Reloaded"),
+	); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.AddMatchSignalContext(ctx,
+		godbus.WithMatchInterface("org.freedesktop.DBus"),
+		godbus.WithMatchMember("NameOwnerChanged"),
+		godbus.WithMatchArg(0, firewalldInterface),
+	); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	signals := make(chan *godbus.Signal, 16)
+	conn.Signal(signals)
+
+	return firewalldEvents(ctx, signals, func() { conn.Close() }), nil
+}
+
+// firewalldEvents turns the firewalld signals into events until ctx is done
+// or signals is closed, then calls done.
+func firewalldEvents(ctx context.Context, signals <-chan *godbus.Signal, done func()) <-chan struct{} {
+	events := make(chan struct{}, 1)
+	go func() {
+		defer close(events)
+		defer done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig, ok := <-signals:
+				if !ok {
+					return
+				}
+				if sig.Name == "org.freedesktop.DBus.NameOwnerChanged" {
+					// Only react when firewalld gained a new owner,
+					// it losing one is followed by a restart.
+					if len(sig.Body) < 3 {
+						continue
+					}
+					if newOwner, _ := sig.Body[2].(string); newOwner == "" {
+						continue
+					}
+				}
+				logrus.Debugf("Received firewalld signal %s", sig.Name)
+				// Coalesce bursts of signals into a single event.
+				select {
+				case events <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return events
+}
@@ -0,0 +1,78 @@
+package systemd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godbus "github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reloadedSignal() *godbus.Signal {
+	return &godbus.Signal{Name: firewalldInterface + ".Reloaded"}
+}
+
+func nameOwnerChangedSignal(newOwner string) *godbus.Signal {
+	return &godbus.Signal{
+		Name: "org.freedesktop.DBus.NameOwnerChanged",
+		Body: []interface{}{firewalldInterface, ":1.4", newOwner},
+	}
+}
+
+// ignoredSignal is never turned into an event. Sending it on an unbuffered
+// channel returns once the previous signal was handled.
+func ignoredSignal() *godbus.Signal {
+	return &godbus.Signal{Name: "org.freedesktop.DBus.NameOwnerChanged"}
+}
+
+func TestFirewalldEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan *godbus.Signal)
+	closed := make(chan struct{})
+	events := firewalldEvents(ctx, signals, func() { close(closed) })
+
+	// A reload is an event.
+	signals <- reloadedSignal()
+	signals <- ignoredSignal()
+	assert.Len(t, events, 1, "reload")
+	<-events
+
+	// firewalld stopping is not, it starting again is.
+	signals <- nameOwnerChangedSignal("")
+	signals <- ignoredSignal()
+	assert.Len(t, events, 0, "firewalld stopped")
+	signals <- nameOwnerChangedSignal(":1.5")
+	signals <- ignoredSignal()
+	assert.Len(t, events, 1, "firewalld started")
+	<-events
+
+	// Bursts are coalesced until the event is received.
+	signals <- reloadedSignal()
+	signals <- reloadedSignal()
+	signals <- ignoredSignal()
+	assert.Len(t, events, 1, "burst of reloads")
+	<-events
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "no event after the context is done")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "events not closed after the context is done")
+	}
+	<-closed
+}
+
+func TestFirewalldEventsSignalsClosed(t *testing.T) {
+	signals := make(chan *godbus.Signal)
+	closed := make(chan struct{})
+	events := firewalldEvents(context.Background(), signals, func() { close(closed) })
+
+	close(signals)
+	_, ok := <-events
+	assert.False(t, ok)
+	<-closed
+}
@@ -0,0 +1,216 @@
+// Package templates stores container templates, the specgen JSON of a
+// container as generated by podman generate spec, in registries as OCI
+// artifacts.
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/image"
+	"github.com/containers/image/v5/pkg/blobinfocache/none"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ArtifactType is the artifact type of the manifest of a container
+	// template, and the media type of its only layer holding the spec.
+	ArtifactType = "application/vnd.podman.container.template.v1+json"
+	// fileName is the title of the layer holding the spec.
+	fileName = "template.json"
+	// maxSpecSize is the largest spec accepted when pulling a template.
+	maxSpecSize = 1 << 20
+)
+
+// ErrNotTemplate is returned when pulling an artifact or image which is not a
+// container template.
+var ErrNotTemplate = errors.New("not a container template")
+
+// Template is a container template pulled from a registry.
+type Template struct {
+	// Digest is the digest of the manifest of the template.
+	Digest digest.Digest
+	// Annotations are the annotations of the manifest of the template.
+	Annotations map[string]string
+	// Spec is the specgen JSON of the template.
+	Spec []byte
+}
+
+// ParseReference parses the name of a template as an image reference. Names
+// without a transport use the docker transport.
+func ParseReference(name string) (types.ImageReference, error) {
+	if ref, err := alltransports.ParseImageName(name); err == nil {
+		return ref, nil
+	}
+	ref, err := docker.ParseReference("//" + name)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template reference %q: %w", name, err)
+	}
+	return ref, nil
+}
+
+// NormalizeSpec checks that spec is the specgen JSON of a container and
+// returns it without the name and the create command of the container, which
+// only describe the container the template was taken from.
+func NormalizeSpec(spec []byte) ([]byte, error) {
+	s := new(specgen.SpecGenerator)
+	if err := json.Unmarshal(spec, s); err != nil {
+		return nil, fmt.Errorf("parsing container spec: %w", err)
+	}
+	if s.Image == "" && s.Rootfs == "" {
+		return nil, errors.New("the spec is not a container spec, it has no image or rootfs")
+	}
+	s.Name = ""
+	s.ContainerCreateCommand = nil
+	return json.MarshalIndent(s, "", " ")
+}
+
+// Push stores spec at destination as a container template with the given
+// annotations, and returns the digest of its manifest. The creation time of
+// the template is recorded unless set in annotations.
+func Push(ctx context.Context, sys *types.SystemContext, destination string, spec []byte, annotations map[string]string) (digest.Digest, error) {
+	spec, err := NormalizeSpec(spec)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := ParseReference(destination)
+	if err != nil {
+		return "", err
+	}
+	dest, err := ref.NewImageDestination(ctx, sys)
+	if err != nil {
+		return "", fmt.Errorf("opening %s: %w", destination, err)
+	}
+	defer dest.Close()
+
+	putBlob := func(data []byte, mediaType string, isConfig bool) (specV1.Descriptor, error) {
+		info := types.BlobInfo{Digest: digest.FromBytes(data), Size: int64(len(data)), MediaType: mediaType}
+		if _, err := dest.PutBlob(ctx, bytes.NewReader(data), info, none.NoCache, isConfig); err != nil {
+			return specV1.Descriptor{}, fmt.Errorf("writing blob to %s: %w", destination, err)
+		}
+		return specV1.Descriptor{MediaType: mediaType, Digest: info.Digest, Size: info.Size}, nil
+	}
+	config, err := putBlob(specV1.DescriptorEmptyJSON.Data, specV1.MediaTypeEmptyJSON, true)
+	if err != nil {
+		return "", err
+	}
+	layer, err := putBlob(spec, ArtifactType, false)
+	if err != nil {
+		return "", err
+	}
+	layer.Annotations = map[string]string{specV1.AnnotationTitle: fileName}
+
+	manifestAnnotations := map[string]string{specV1.AnnotationCreated: time.Now().UTC().Format(time.RFC3339)}
+	for key, value := range annotations {
+		manifestAnnotations[key] = value
+	}
+	manifest, err := json.Marshal(specV1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    specV1.MediaTypeImageManifest,
+		ArtifactType: ArtifactType,
+		Config:       config,
+		Layers:       []specV1.Descriptor{layer},
+		Annotations:  manifestAnnotations,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := dest.PutManifest(ctx, manifest, nil); err != nil {
+		return "", fmt.Errorf("writing manifest to %s: %w", destination, err)
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return "", fmt.Errorf("committing %s: %w", destination, err)
+	}
+	return digest.FromBytes(manifest), nil
+}
+
+// Pull reads the container template at source, if the signature policy of sys
+// allows it.
+func Pull(ctx context.Context, sys *types.SystemContext, source string) (_ *Template, retErr error) {
+	ref, err := ParseReference(source)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := signature.DefaultPolicy(sys)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining default signature policy: %w", err)
+	}
+	policyContext, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return nil, fmt.Errorf("creating new signature policy context: %w", err)
+	}
+	defer func() {
+		if err := policyContext.Destroy(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", source, err)
+	}
+	defer src.Close()
+
+	unparsed := image.UnparsedInstance(src, nil)
+	if _, err := policyContext.IsRunningImageAllowed(ctx, unparsed); err != nil {
+		return nil, fmt.Errorf("template %s rejected by the signature policy: %w", source, err)
+	}
+
+	raw, mimeType, err := unparsed.Manifest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest of %s: %w", source, err)
+	}
+	if mimeType != specV1.MediaTypeImageManifest {
+		return nil, fmt.Errorf("%s: %w", source, ErrNotTemplate)
+	}
+	var manifest specV1.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest of %s: %w", source, err)
+	}
+	if manifest.ArtifactType != ArtifactType || len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != ArtifactType {
+		return nil, fmt.Errorf("%s: %w", source, ErrNotTemplate)
+	}
+	layer := manifest.Layers[0]
+	if layer.Size > maxSpecSize {
+		return nil, fmt.Errorf("spec of template %s is larger than %d bytes", source, maxSpecSize)
+	}
+
+	blob, _, err := src.GetBlob(ctx, types.BlobInfo{Digest: layer.Digest, Size: layer.Size, MediaType: layer.MediaType}, none.NoCache)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec of template %s: %w", source, err)
+	}
+	defer blob.Close()
+	spec, err := io.ReadAll(io.LimitReader(blob, maxSpecSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading spec of template %s: %w", source, err)
+	}
+	if err := layer.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("spec of template %s: %w", source, err)
+	}
+	if layer.Digest.Algorithm().FromBytes(spec) != layer.Digest {
+		return nil, fmt.Errorf("spec of template %s does not match its digest %s", source, layer.Digest)
+	}
+	if _, err := NormalizeSpec(spec); err != nil {
+		return nil, fmt.Errorf("template %s: %w", source, err)
+	}
+
+	return &Template{
+		Digest:      digest.FromBytes(raw),
+		Annotations: manifest.Annotations,
+		Spec:        spec,
+	}, nil
+}
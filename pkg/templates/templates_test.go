@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v5/pkg/specgen"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSystemContext(t *testing.T) *types.SystemContext {
+	policy := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(policy, []byte(`{"default": [{"type": "insecureAcceptAnything"}]}`), 0o600))
+	return &types.SystemContext{SignaturePolicyPath: policy}
+}
+
+func TestNormalizeSpec(t *testing.T) {
+	spec, err := NormalizeSpec([]byte(`{"name": "web", "image": "quay.io/libpod/alpine:latest", "command": ["top"], "containerCreateCommand": ["podman", "create"]}`))
+	require.NoError(t, err)
+	s := new(specgen.SpecGenerator)
+	require.NoError(t, json.Unmarshal(spec, s))
+	assert.Empty(t, s.Name)
+	assert.Empty(t, s.ContainerCreateCommand)
+	assert.Equal(t, "quay.io/libpod/alpine:latest", s.Image)
+	assert.Equal(t, []string{"top"}, s.Command)
+
+	_, err = NormalizeSpec([]byte(`{"name": "pod1", "infra_image": "k8s.gcr.io/pause"}`))
+	assert.ErrorContains(t, err, "not a container spec")
+	_, err = NormalizeSpec([]byte(`not json`))
+	assert.ErrorContains(t, err, "parsing container spec")
+}
+
+func TestPushPull(t *testing.T) {
+	ctx := context.Background()
+	sys := testSystemContext(t)
+	dest := "oci:" + filepath.Join(t.TempDir(), "layout") + ":1.0"
+
+	d, err := Push(ctx, sys, dest, []byte(`{"name": "web", "image": "quay.io/libpod/alpine:latest"}`), map[string]string{specV1.AnnotationVersion: "1.0"})
+	require.NoError(t, err)
+
+	template, err := Pull(ctx, sys, dest)
+	require.NoError(t, err)
+	assert.Equal(t, d, template.Digest)
+	assert.Equal(t, "1.0", template.Annotations[specV1.AnnotationVersion])
+	assert.NotEmpty(t, template.Annotations[specV1.AnnotationCreated])
+	s := new(specgen.SpecGenerator)
+	require.NoError(t, json.Unmarshal(template.Spec, s))
+	assert.Equal(t, "quay.io/libpod/alpine:latest", s.Image)
+	assert.Empty(t, s.Name)
+
+	_, err = Pull(ctx, sys, "oci:"+filepath.Join(t.TempDir(), "missing")+":1.0")
+	assert.Error(t, err)
+
+	_, err = Push(ctx, sys, dest, []byte(`{"name": "pod1"}`), nil)
+	assert.ErrorContains(t, err, "not a container spec")
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("quay.io/team/web-template:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "docker", ref.Transport().Name())
+	assert.Equal(t, "//quay.io/team/web-template:1.0", ref.StringWithinTransport())
+
+	ref, err = ParseReference("localhost:5000/web-template:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "docker", ref.Transport().Name())
+
+	ref, err = ParseReference("oci:/tmp/layout:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "oci", ref.Transport().Name())
+
+	_, err = ParseReference("UPPERCASE")
+	assert.Error(t, err)
+}
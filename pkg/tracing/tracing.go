@@ -0,0 +1,127 @@
+// Package tracing sets up the OpenTelemetry SDK to record the spans of Podman
+// operations and export them either to an OTLP/HTTP collector or to a file.
+// Podman code creates spans with the OpenTelemetry API, which are only
+// recorded once Setup installed the tracer provider.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterOTLP exports spans to an OTLP/HTTP collector.
+	ExporterOTLP = "otlp"
+	// ExporterFile appends spans to a file, one JSON object per span.
+	ExporterFile = "file"
+)
+
+var (
+	// enabled is set once Setup installed a provider.
+	enabled atomic.Bool
+	// processSpan is the trace.SpanContext of the span set with
+	// SetProcessSpan.
+	processSpan atomic.Value
+)
+
+// Enabled returns true if spans are recorded and exported.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// SetProcessSpan makes the span of ctx the parent of the spans of operations
+// which have no context to take it from, such as state database operations.
+// Commands set the span of the command, long-running processes serving many
+// requests leave it unset.
+func SetProcessSpan(ctx context.Context) {
+	processSpan.Store(trace.SpanContextFromContext(ctx))
+}
+
+// ProcessContext returns a context carrying the span set with SetProcessSpan,
+// or no span if none is set.
+func ProcessContext() context.Context {
+	ctx := context.Background()
+	if sc, ok := processSpan.Load().(trace.SpanContext); ok {
+		ctx = trace.ContextWithSpanContext(ctx, sc)
+	}
+	return ctx
+}
+
+// Setup installs a provider exporting spans with the given exporter, ExporterOTLP
+// or ExporterFile, to endpoint, the URL of the collector or the path of the
+// file, as the global OpenTelemetry tracer provider. The spans are exported in
+// batches, the ones not exported yet are exported on Shutdown.
+func Setup(exporterName, endpoint string) (*sdktrace.TracerProvider, error) {
+	exp, err := newExporter(exporterName, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p := newProvider(exp)
+	otel.SetTracerProvider(p)
+	enabled.Store(true)
+	return p, nil
+}
+
+func newProvider(exp sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "podman"))),
+	)
+}
+
+func newExporter(exporterName, endpoint string) (sdktrace.SpanExporter, error) {
+	if endpoint == "" {
+		return nil, errors.New("tracing_endpoint must be set when tracing_exporter is set")
+	}
+	switch exporterName {
+	case ExporterOTLP:
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracing endpoint %q: %w", endpoint, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("invalid tracing endpoint %q: the scheme must be http or https", endpoint)
+		}
+		if u.Path == "" || u.Path == "/" {
+			u.Path = "/v1/traces"
+		}
+		// The exporter does not connect until spans are exported.
+		return otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(u.String()))
+	case ExporterFile:
+		f, err := os.OpenFile(endpoint, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("opening tracing file: %w", err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &fileExporter{Exporter: exp, file: f}, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, must be %q or %q", exporterName, ExporterOTLP, ExporterFile)
+	}
+}
+
+// fileExporter is the stdout exporter writing to a file, which it closes on
+// shutdown.
+type fileExporter struct {
+	*stdouttrace.Exporter
+	file *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.Exporter.Shutdown(ctx), e.file.Close())
+}
@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// fileSpan is the part of a span written by the file exporter checked by the
+// tests.
+type fileSpan struct {
+	Name        string
+	SpanContext struct{ TraceID, SpanID string }
+	Parent      struct{ TraceID, SpanID string }
+	Status      struct{ Code, Description string }
+	Events      []struct{ Name string }
+	Attributes  []struct {
+		Key   string
+		Value struct{ Value any }
+	}
+	Resource []struct {
+		Key   string
+		Value struct{ Value any }
+	}
+	InstrumentationLibrary struct{ Name string }
+}
+
+func readFileSpans(t *testing.T, path string) []fileSpan {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var spans []fileSpan
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var span fileSpan
+		require.NoError(t, json.Unmarshal([]byte(line), &span))
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+func TestNewExporter(t *testing.T) {
+	_, err := newExporter(ExporterOTLP, "")
+	assert.ErrorContains(t, err, "tracing_endpoint must be set")
+	_, err = newExporter(ExporterOTLP, "localhost:4318")
+	assert.ErrorContains(t, err, "the scheme must be http or https")
+	_, err = newExporter("jaeger", "http://localhost:4318")
+	assert.ErrorContains(t, err, `unknown tracing exporter "jaeger"`)
+	_, err = newExporter(ExporterFile, filepath.Join(t.TempDir(), "missing", "spans.json"))
+	assert.ErrorContains(t, err, "opening tracing file")
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	exp, err := newExporter(ExporterFile, path)
+	require.NoError(t, err)
+	p := newProvider(exp)
+
+	tracer := p.Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "container start", trace.WithAttributes(attribute.String("container.name", "web")))
+	_, child := tracer.Start(ctx, "state SaveContainer")
+	child.End()
+	parent.RecordError(errors.New("no such image"))
+	parent.SetStatus(codes.Error, "no such image")
+	parent.End()
+	// Ended spans are not changed.
+	parent.SetName("renamed")
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	spans := readFileSpans(t, path)
+	require.Len(t, spans, 2)
+	assert.Equal(t, "state SaveContainer", spans[0].Name)
+	assert.Equal(t, "container start", spans[1].Name)
+	assert.Equal(t, "test", spans[1].InstrumentationLibrary.Name)
+	assert.Equal(t, spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].Parent.SpanID)
+	assert.Equal(t, "0000000000000000", spans[1].Parent.SpanID)
+	assert.Equal(t, "Error", spans[1].Status.Code)
+	assert.Equal(t, "no such image", spans[1].Status.Description)
+	require.Len(t, spans[1].Events, 1)
+	assert.Equal(t, "exception", spans[1].Events[0].Name)
+	require.Len(t, spans[1].Attributes, 1)
+	assert.Equal(t, "web", spans[1].Attributes[0].Value.Value)
+	require.Len(t, spans[1].Resource, 1)
+	assert.Equal(t, "service.name", spans[1].Resource[0].Key)
+	assert.Equal(t, "podman", spans[1].Resource[0].Value.Value)
+
+	// Spans ended after the shutdown are dropped.
+	_, late := tracer.Start(context.Background(), "late")
+	late.End()
+	assert.Len(t, readFileSpans(t, path), 2)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request := &coltracepb.ExportTraceServiceRequest{}
+		assert.NoError(t, proto.Unmarshal(body, request))
+		received <- request
+	}))
+	defer server.Close()
+
+	exp, err := newExporter(ExporterOTLP, server.URL)
+	require.NoError(t, err)
+	p := newProvider(exp)
+	_, span := p.Tracer("test").Start(context.Background(), "image pull", trace.WithSpanKind(trace.SpanKindClient))
+	span.SetStatus(codes.Ok, "ignored")
+	span.End()
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	request := <-received
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	assert.Equal(t, "image pull", spans[0].Name)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, spans[0].Kind)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_OK, spans[0].Status.Code)
+	assert.Empty(t, spans[0].Status.Message)
+}
+
+func TestProcessContext(t *testing.T) {
+	assert.False(t, trace.SpanContextFromContext(ProcessContext()).IsValid())
+
+	exp, err := newExporter(ExporterFile, filepath.Join(t.TempDir(), "spans.json"))
+	require.NoError(t, err)
+	p := newProvider(exp)
+	ctx, span := p.Tracer("test").Start(context.Background(), "podman create")
+	SetProcessSpan(ctx)
+	defer SetProcessSpan(context.Background())
+	assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(ProcessContext()))
+	span.End()
+	require.NoError(t, p.Shutdown(context.Background()))
+}
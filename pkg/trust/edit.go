@@ -0,0 +1,388 @@
+package trust
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/transports"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/containers/storage/pkg/ioutils"
+	"sigs.k8s.io/yaml"
+)
+
+// managedRegistriesFile is the file in registries.d holding the lookaside
+// locations added by Podman. Files written by other tools are never modified.
+const managedRegistriesFile = "podman-trust.yaml"
+
+// dockerTransport is the name of the transport of images in registries.
+const dockerTransport = "docker"
+
+// AddPolicyScopeInput collects the parameters of AddPolicyScope.
+type AddPolicyScopeInput struct {
+	Transport   string // The transport of Scope, "docker" if empty
+	Scope       string // "default", a scope name of Transport or "" for the default of Transport
+	Type        string
+	PubKeyFiles []string // Paths to public key files, see AddPolicyEntriesInput
+	Lookaside   string   // Location of the signatures of a docker scope, written to registries.d
+	Replace     bool     // Replace the requirements of an existing scope
+}
+
+// PolicyMatch describes the policy requirements applying to an image.
+type PolicyMatch struct {
+	// Image is the image reference, including its transport.
+	Image string `json:"image"`
+	// Checked are the scopes looked up in the policy, most specific first,
+	// before falling back to the default of the transport and the default
+	// of the policy.
+	Checked []string `json:"checked"`
+	// Policies are the requirements of the matching scope.
+	Policies []*Policy `json:"policies"`
+}
+
+// readPolicyForEdit parses the policy.json at policyPath, keeping unknown
+// requirements untouched. A missing file is an empty policy.
+func readPolicyForEdit(policyPath string) (*genericPolicyContent, error) {
+	policy := &genericPolicyContent{}
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return policy, nil
+		}
+		return nil, fmt.Errorf("unable to read policy file: %w", err)
+	}
+	if err := json.Unmarshal(content, policy); err != nil {
+		return nil, fmt.Errorf("could not parse trust policies from %s: %w", policyPath, err)
+	}
+	return policy, nil
+}
+
+// atomicWriteFile replaces the file at path with data, keeping its
+// permissions. A reader sees either the old or the new content.
+func atomicWriteFile(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if st, err := os.Stat(path); err == nil {
+		mode = st.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(path, data, mode)
+}
+
+// validateScope returns an error if scope is not a valid scope of the named
+// transport in policy.json.
+func validateScope(transportName, scope string) error {
+	transport := transports.Get(transportName)
+	if transport == nil {
+		return fmt.Errorf("unknown transport %q", transportName)
+	}
+	if scope == "" {
+		return nil
+	}
+	if err := transport.ValidatePolicyConfigurationScope(scope); err != nil {
+		return fmt.Errorf("invalid scope %q of transport %s: %w", scope, transportName, err)
+	}
+	if transportName == dockerTransport {
+		return validateDockerScope(scope)
+	}
+	return nil
+}
+
+// validateDockerScope returns an error if scope is not a fully-qualified
+// image, repository, namespace, registry or *.domain wildcard. The docker
+// transport accepts any scope but never matches images with the others.
+func validateDockerScope(scope string) error {
+	if _, err := reference.ParseNamed(scope); err == nil {
+		return nil
+	}
+	// Registries, namespaces and wildcards are not valid image names, check
+	// them as the namespace of one.
+	name := strings.TrimPrefix(scope, "*.") + "/placeholder"
+	if _, err := reference.ParseNamed(name); err != nil {
+		return fmt.Errorf("invalid scope %q of transport %s, it must be a fully-qualified image, repository, namespace or registry: %w", scope, dockerTransport, err)
+	}
+	return nil
+}
+
+// scopeDescription returns the user-facing name of a scope of a transport.
+func scopeDescription(transport, scope string) string {
+	if scope == "" {
+		return fmt.Sprintf("the default of transport %s", transport)
+	}
+	return scope
+}
+
+// readManagedRegistries parses the registries.d file managed by Podman.
+func readManagedRegistries(registriesDirPath string) (*registryConfiguration, error) {
+	config := &registryConfiguration{}
+	content, err := os.ReadFile(filepath.Join(registriesDirPath, managedRegistriesFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return config, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Join(registriesDirPath, managedRegistriesFile), err)
+	}
+	return config, nil
+}
+
+// writeManagedRegistries writes the registries.d file managed by Podman, or
+// removes it if it is empty.
+func writeManagedRegistries(registriesDirPath string, config *registryConfiguration) error {
+	path := filepath.Join(registriesDirPath, managedRegistriesFile)
+	if config.DefaultDocker == nil && len(config.Docker) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(path, data)
+}
+
+// AddPolicyScope adds the requirements of a scope to the policy.json at
+// policyPath, and the lookaside location of its signatures to registries.d.
+// Unlike AddPolicyEntries, the key files must exist, an existing scope is
+// only replaced on request, and the resulting policy is validated before both
+// files are replaced atomically.
+func AddPolicyScope(policyPath, registriesDirPath string, input AddPolicyScopeInput) error {
+	transport := input.Transport
+	if transport == "" {
+		transport = dockerTransport
+	}
+	if input.Scope != "default" {
+		if err := validateScope(transport, input.Scope); err != nil {
+			return err
+		}
+	}
+
+	pubKeyFiles := make([]string, 0, len(input.PubKeyFiles))
+	for _, path := range input.PubKeyFiles {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if err := fileutils.Exists(path); err != nil {
+			return fmt.Errorf("public key file: %w", err)
+		}
+		pubKeyFiles = append(pubKeyFiles, path)
+	}
+	requirements, err := newPolicyRequirements(input.Type, pubKeyFiles)
+	if err != nil {
+		return err
+	}
+
+	policy, err := readPolicyForEdit(policyPath)
+	if err != nil {
+		return err
+	}
+	if input.Scope == "default" {
+		if len(policy.Default) > 0 && !input.Replace {
+			return errors.New("default trust policy already exists, use --replace to replace it")
+		}
+		policy.Default = requirements
+	} else {
+		if len(policy.Default) == 0 {
+			return errors.New("default trust policy must be set")
+		}
+		if _, ok := policy.Transports[transport][input.Scope]; ok && !input.Replace {
+			return fmt.Errorf("trust policy for %s already exists, use --replace to replace it", scopeDescription(transport, input.Scope))
+		}
+		if policy.Transports == nil {
+			policy.Transports = make(genericTransportsContent)
+		}
+		if policy.Transports[transport] == nil {
+			policy.Transports[transport] = make(genericRepoMap)
+		}
+		policy.Transports[transport][input.Scope] = requirements
+	}
+
+	data, err := json.MarshalIndent(policy, "", "    ")
+	if err != nil {
+		return fmt.Errorf("setting trust policy: %w", err)
+	}
+	if _, err := signature.NewPolicyFromBytes(data); err != nil {
+		return fmt.Errorf("resulting trust policy is invalid: %w", err)
+	}
+
+	if input.Lookaside != "" {
+		if err := addLookaside(registriesDirPath, transport, input.Scope, input.Lookaside); err != nil {
+			return err
+		}
+	}
+	return atomicWriteFile(policyPath, data)
+}
+
+// addLookaside records the lookaside location of the signatures of a docker
+// scope in the registries.d file managed by Podman.
+func addLookaside(registriesDirPath, transport, scope, lookaside string) error {
+	if transport != dockerTransport || scope == "" {
+		return fmt.Errorf("lookaside locations can only be set for scopes of the %s transport", dockerTransport)
+	}
+	u, err := url.Parse(lookaside)
+	if err != nil {
+		return fmt.Errorf("invalid lookaside location %q: %w", lookaside, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "file":
+	default:
+		return fmt.Errorf("invalid lookaside location %q: the scheme must be http, https or file", lookaside)
+	}
+
+	// A scope defined in more than one file is an error for all readers of
+	// registries.d, do not touch scopes defined by other files.
+	merged, err := loadAndMergeConfig(registriesDirPath)
+	if err != nil {
+		return err
+	}
+	managed, err := readManagedRegistries(registriesDirPath)
+	if err != nil {
+		return err
+	}
+	if scope == "default" {
+		if merged.DefaultDocker != nil && managed.DefaultDocker == nil {
+			return fmt.Errorf("default lookaside location is already set by another file in %s", registriesDirPath)
+		}
+		managed.DefaultDocker = &registryNamespace{Lookaside: lookaside}
+	} else {
+		_, inMerged := merged.Docker[scope]
+		if _, inManaged := managed.Docker[scope]; inMerged && !inManaged {
+			return fmt.Errorf("lookaside location of %s is already set by another file in %s", scope, registriesDirPath)
+		}
+		if managed.Docker == nil {
+			managed.Docker = make(map[string]registryNamespace)
+		}
+		managed.Docker[scope] = registryNamespace{Lookaside: lookaside}
+	}
+	return writeManagedRegistries(registriesDirPath, managed)
+}
+
+// RemovePolicyScope removes the requirements of a scope of the transport from
+// the policy.json at policyPath, and the lookaside location Podman recorded
+// for it in registries.d. The default policy cannot be removed.
+func RemovePolicyScope(policyPath, registriesDirPath, transport, scope string) error {
+	if transport == "" {
+		transport = dockerTransport
+	}
+	if scope == "default" {
+		return errors.New("the default trust policy cannot be removed")
+	}
+	policy, err := readPolicyForEdit(policyPath)
+	if err != nil {
+		return err
+	}
+	if _, ok := policy.Transports[transport][scope]; !ok {
+		return fmt.Errorf("no trust policy for %s in %s", scopeDescription(transport, scope), policyPath)
+	}
+	delete(policy.Transports[transport], scope)
+	if len(policy.Transports[transport]) == 0 {
+		delete(policy.Transports, transport)
+	}
+
+	data, err := json.MarshalIndent(policy, "", "    ")
+	if err != nil {
+		return fmt.Errorf("setting trust policy: %w", err)
+	}
+	if err := atomicWriteFile(policyPath, data); err != nil {
+		return err
+	}
+
+	if transport != dockerTransport {
+		return nil
+	}
+	managed, err := readManagedRegistries(registriesDirPath)
+	if err != nil {
+		return err
+	}
+	if _, ok := managed.Docker[scope]; !ok {
+		return nil
+	}
+	delete(managed.Docker, scope)
+	return writeManagedRegistries(registriesDirPath, managed)
+}
+
+// MatchPolicy returns the requirements of the policy.json at policyPath
+// applying to the image, and the scopes looked up to find them. Images
+// without a transport are looked up in registries.
+func MatchPolicy(policyPath, registriesDirPath, image string) (*PolicyMatch, error) {
+	return matchPolicyWithGPGIDReader(policyPath, registriesDirPath, image, getGPGIdFromKeyPath)
+}
+
+// matchPolicyWithGPGIDReader is MatchPolicy with a gpgIDReader parameter. It exists only to make testing easier.
+func matchPolicyWithGPGIDReader(policyPath, registriesDirPath, image string, idReader gpgIDReader) (*PolicyMatch, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := getPolicy(policyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read trust policies: %w", err)
+	}
+	registryConfigs, err := loadAndMergeConfig(registriesDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := ref.Transport().Name()
+	displayTransport := transport
+	if transport == dockerTransport {
+		displayTransport = "repository"
+	}
+	identity := ref.PolicyConfigurationIdentity()
+	match := &PolicyMatch{
+		Image:   transport + ":" + ref.StringWithinTransport(),
+		Checked: append([]string{identity}, ref.PolicyConfigurationNamespaces()...),
+	}
+	// The lookaside location depends on the image, not on the matching scope.
+	lookasideScope := ""
+	if transport == dockerTransport {
+		lookasideScope = identity
+	}
+
+	scopes := policy.Transports[transport]
+	for _, scope := range match.Checked {
+		if reqs, ok := scopes[scope]; ok {
+			template := Policy{Transport: displayTransport, Name: scope, RepoName: scope}
+			match.Policies = descriptionsOfPolicyRequirements(reqs, template, registryConfigs, lookasideScope, idReader)
+			return match, nil
+		}
+	}
+	if reqs, ok := scopes[""]; ok {
+		template := Policy{Transport: displayTransport}
+		match.Policies = descriptionsOfPolicyRequirements(reqs, template, registryConfigs, lookasideScope, idReader)
+		return match, nil
+	}
+	template := Policy{Transport: "all", Name: "* (default)", RepoName: "default"}
+	match.Policies = descriptionsOfPolicyRequirements(policy.Default, template, registryConfigs, lookasideScope, idReader)
+	return match, nil
+}
+
+// parseImageReference parses an image with a transport, or an image in a
+// registry.
+func parseImageReference(image string) (types.ImageReference, error) {
+	ref, err := alltransports.ParseImageName(image)
+	if err == nil {
+		return ref, nil
+	}
+	if !strings.Contains(image, "://") {
+		if dockerRef, dockerErr := alltransports.ParseImageName(dockerTransport + "://" + image); dockerErr == nil {
+			return dockerRef, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid image %q: %w", image, err)
+}
@@ -0,0 +1,119 @@
+package trust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/image/v5/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddRemovePolicyScope(t *testing.T) {
+	tempDir := t.TempDir()
+	policyPath := filepath.Join(tempDir, "policy.json")
+	registriesDir := filepath.Join(tempDir, "registries.d")
+	keyPath := filepath.Join(tempDir, "key.pub")
+	require.NoError(t, os.WriteFile(keyPath, []byte("key"), 0o600))
+
+	// Scopes need a default policy.
+	err := AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{Scope: "quay.io", Type: "accept"})
+	assert.ErrorContains(t, err, "default trust policy must be set")
+	require.NoError(t, AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{Scope: "default", Type: "reject"}))
+
+	for _, invalid := range []AddPolicyScopeInput{
+		{Scope: "busybox", Type: "accept"},                     // Not fully-qualified
+		{Scope: "quay.io", Type: "accept", Transport: "bogus"}, // Unknown transport
+		{Scope: "quay.io", Type: "signedBy", PubKeyFiles: []string{filepath.Join(tempDir, "missing.pub")}},
+		{Scope: "quay.io", Type: "accept", Lookaside: "ftp://example.com/sigstore"},
+		{Scope: "default", Type: "accept"}, // Exists
+	} {
+		assert.Error(t, AddPolicyScope(policyPath, registriesDir, invalid), "%#v", invalid)
+	}
+
+	require.NoError(t, AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{
+		Scope:       "quay.io/podman",
+		Type:        "sigstoreSigned",
+		PubKeyFiles: []string{keyPath},
+		Lookaside:   "https://example.com/sigstore",
+	}))
+	require.NoError(t, AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{Scope: "quay.io", Type: "accept"}))
+	assert.ErrorContains(t, AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{Scope: "quay.io", Type: "reject"}), "already exists")
+	require.NoError(t, AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{Scope: "quay.io", Type: "reject", Replace: true}))
+
+	parsedPolicy, err := signature.NewPolicyFromFile(policyPath)
+	require.NoError(t, err)
+	assert.Equal(t, &signature.Policy{
+		Default: signature.PolicyRequirements{
+			signature.NewPRReject(),
+		},
+		Transports: map[string]signature.PolicyTransportScopes{
+			"docker": {
+				"quay.io": {
+					signature.NewPRReject(),
+				},
+				"quay.io/podman": {
+					xNewPRSigstoreSignedKeyPath(t, keyPath, signature.NewPRMMatchRepoDigestOrExact()),
+				},
+			},
+		},
+	}, parsedPolicy)
+	registries, err := loadAndMergeConfig(registriesDir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]registryNamespace{"quay.io/podman": {Lookaside: "https://example.com/sigstore"}}, registries.Docker)
+
+	// Lookaside locations set by other files are not modified.
+	require.NoError(t, os.WriteFile(filepath.Join(registriesDir, "other.yaml"), []byte("docker:\n  registry.example.com:\n    lookaside: https://example.com/other\n"), 0o644))
+	err = AddPolicyScope(policyPath, registriesDir, AddPolicyScopeInput{Scope: "registry.example.com", Type: "accept", Lookaside: "https://example.com/mine"})
+	assert.ErrorContains(t, err, "another file")
+
+	assert.ErrorContains(t, RemovePolicyScope(policyPath, registriesDir, "", "default"), "cannot be removed")
+	assert.ErrorContains(t, RemovePolicyScope(policyPath, registriesDir, "", "registry.example.com"), "no trust policy")
+	require.NoError(t, RemovePolicyScope(policyPath, registriesDir, "", "quay.io/podman"))
+	assert.NoFileExists(t, filepath.Join(registriesDir, managedRegistriesFile))
+	assert.FileExists(t, filepath.Join(registriesDir, "other.yaml"))
+	parsedPolicy, err = signature.NewPolicyFromFile(policyPath)
+	require.NoError(t, err)
+	assert.Equal(t, signature.PolicyTransportScopes{"quay.io": {signature.NewPRReject()}}, parsedPolicy.Transports["docker"])
+}
+
+func TestMatchPolicy(t *testing.T) {
+	tempDir := t.TempDir()
+	policyPath := filepath.Join(tempDir, "policy.json")
+	registriesDir := filepath.Join(tempDir, "registries.d")
+	require.NoError(t, os.WriteFile(policyPath, []byte(`{
+    "default": [{"type": "reject"}],
+    "transports": {
+        "docker": {
+            "quay.io": [{"type": "insecureAcceptAnything"}],
+            "quay.io/podman/stable": [{"type": "signedBy", "keyType": "GPGKeys", "keyPath": "/key.gpg"}]
+        },
+        "docker-daemon": {
+            "": [{"type": "insecureAcceptAnything"}]
+        }
+    }
+}`), 0o600))
+	idReader := func(string) []string { return []string{"podman@example.com"} }
+
+	match, err := matchPolicyWithGPGIDReader(policyPath, registriesDir, "quay.io/podman/stable", idReader)
+	require.NoError(t, err)
+	assert.Equal(t, "docker://quay.io/podman/stable:latest", match.Image)
+	assert.Equal(t, []string{"quay.io/podman/stable:latest", "quay.io/podman/stable", "quay.io/podman", "quay.io", "*.io"}, match.Checked)
+	assert.Equal(t, []*Policy{{Transport: "repository", Name: "quay.io/podman/stable", RepoName: "quay.io/podman/stable", Type: "signed", GPGId: "podman@example.com"}}, match.Policies)
+
+	match, err = matchPolicyWithGPGIDReader(policyPath, registriesDir, "docker://quay.io/other/image:tag", idReader)
+	require.NoError(t, err)
+	assert.Equal(t, []*Policy{{Transport: "repository", Name: "quay.io", RepoName: "quay.io", Type: "accept"}}, match.Policies)
+
+	match, err = matchPolicyWithGPGIDReader(policyPath, registriesDir, "docker-daemon:busybox:latest", idReader)
+	require.NoError(t, err)
+	assert.Equal(t, []*Policy{{Transport: "docker-daemon", Type: "accept"}}, match.Policies)
+
+	match, err = matchPolicyWithGPGIDReader(policyPath, registriesDir, "docker.io/library/busybox", idReader)
+	require.NoError(t, err)
+	assert.Equal(t, []*Policy{{Transport: "all", Name: "* (default)", RepoName: "default", Type: "reject"}}, match.Policies)
+
+	_, err = matchPolicyWithGPGIDReader(policyPath, registriesDir, "Invalid:Image", idReader)
+	assert.Error(t, err)
+}
@@ -175,29 +175,26 @@ type AddPolicyEntriesInput struct {
 	PubKeyFiles []string // For signature enforcement types, paths to public keys files (where the image needs to be signed by at least one key from _each_ of the files). File format depends on Type.
 }
 
-// AddPolicyEntries adds one or more policy entries necessary to implement AddPolicyEntriesInput.
-func AddPolicyEntries(policyPath string, input AddPolicyEntriesInput) error {
-	var (
-		policyContentStruct genericPolicyContent
-		newReposContent     []repoContent
-	)
-	trustType := input.Type
+// newPolicyRequirements returns the policy requirements of the given trust
+// type requiring signatures by the given public keys.
+func newPolicyRequirements(inputType string, pubkeysfile []string) (json.RawMessage, error) {
+	var newReposContent []repoContent
+	trustType := inputType
 	if trustType == "accept" {
 		trustType = "insecureAcceptAnything"
 	}
-	pubkeysfile := input.PubKeyFiles
 
-	// The error messages in validation failures use input.Type instead of trustType to match the user’s input.
+	// The error messages in validation failures use inputType instead of trustType to match the user’s input.
 	switch trustType {
 	case "insecureAcceptAnything", "reject":
 		if len(pubkeysfile) != 0 {
-			return fmt.Errorf("%d public keys unexpectedly provided for trust type %v", len(pubkeysfile), input.Type)
+			return nil, fmt.Errorf("%d public keys unexpectedly provided for trust type %v", len(pubkeysfile), inputType)
 		}
 		newReposContent = append(newReposContent, repoContent{Type: trustType})
 
 	case "signedBy":
 		if len(pubkeysfile) == 0 {
-			return errors.New("at least one public key must be defined for type 'signedBy'")
+			return nil, errors.New("at least one public key must be defined for type 'signedBy'")
 		}
 		for _, filepath := range pubkeysfile {
 			newReposContent = append(newReposContent, repoContent{Type: trustType, KeyType: "GPGKeys", KeyPath: filepath})
@@ -205,16 +202,23 @@ func AddPolicyEntries(policyPath string, input AddPolicyEntriesInput) error {
 
 	case "sigstoreSigned":
 		if len(pubkeysfile) == 0 {
-			return errors.New("at least one public key must be defined for type 'sigstoreSigned'")
+			return nil, errors.New("at least one public key must be defined for type 'sigstoreSigned'")
 		}
 		for _, filepath := range pubkeysfile {
 			newReposContent = append(newReposContent, repoContent{Type: trustType, KeyPath: filepath})
 		}
 
 	default:
-		return fmt.Errorf("unknown trust type %q", input.Type)
+		return nil, fmt.Errorf("unknown trust type %q", inputType)
 	}
-	newReposJSON, err := json.Marshal(newReposContent)
+	return json.Marshal(newReposContent)
+}
+
+// AddPolicyEntries adds one or more policy entries necessary to implement AddPolicyEntriesInput.
+func AddPolicyEntries(policyPath string, input AddPolicyEntriesInput) error {
+	var policyContentStruct genericPolicyContent
+
+	newReposJSON, err := newPolicyRequirements(input.Type, input.PubKeyFiles)
 	if err != nil {
 		return err
 	}
@@ -15,17 +15,17 @@ import (
 // registryConfiguration is one of the files in registriesDirPath configuring lookaside locations, or the result of merging them all.
 // NOTE: Keep this in sync with docs/registries.d.md!
 type registryConfiguration struct {
-	DefaultDocker *registryNamespace `json:"default-docker"`
+	DefaultDocker *registryNamespace `json:"default-docker,omitempty"`
 	// The key is a namespace, using fully-expanded Docker reference format or parent namespaces (per dockerReference.PolicyConfiguration*),
-	Docker map[string]registryNamespace `json:"docker"`
+	Docker map[string]registryNamespace `json:"docker,omitempty"`
 }
 
 // registryNamespace defines lookaside locations for a single namespace.
 type registryNamespace struct {
-	Lookaside        string `json:"lookaside"`         // For reading, and if LookasideStaging is not present, for writing.
-	LookasideStaging string `json:"lookaside-staging"` // For writing only.
-	SigStore         string `json:"sigstore"`          // For reading, and if SigStoreStaging is not present, for writing.
-	SigStoreStaging  string `json:"sigstore-staging"`  // For writing only.
+	Lookaside        string `json:"lookaside,omitempty"`         // For reading, and if LookasideStaging is not present, for writing.
+	LookasideStaging string `json:"lookaside-staging,omitempty"` // For writing only.
+	SigStore         string `json:"sigstore,omitempty"`          // For reading, and if SigStoreStaging is not present, for writing.
+	SigStoreStaging  string `json:"sigstore-staging,omitempty"`  // For writing only.
 }
 
 // systemRegistriesDirPath is the path to registries.d.
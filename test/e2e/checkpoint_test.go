@@ -1040,6 +1040,68 @@ var _ = Describe("Podman checkpoint", func() {
 		os.Remove(preCheckpointFileName)
 	})
 
+	It("podman checkpoint container with --pre-dump-chain", func() {
+		if !criu.MemTrack() {
+			Skip("system (architecture/kernel/CRIU) does not support memory tracking")
+		}
+		localRunString := getRunString([]string{ALPINE, "top"})
+		session := podmanTest.Podman(localRunString)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		cid := session.OutputToString()
+
+		result := podmanTest.Podman([]string{"container", "checkpoint", "--pre-dump-chain", "2", "-P", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitWithError(125, "--pre-dump-chain can not be used with --pre-checkpoint or --with-previous"))
+
+		result = podmanTest.Podman([]string{"container", "checkpoint", "--keep", "--pre-dump-chain", "2", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+
+		inspect := podmanTest.InspectContainer(cid)
+		Expect(inspect[0].State.CheckpointChain).To(HaveLen(2))
+		Expect(inspect[0].State.CheckpointChain[0]).To(HaveSuffix("pre-checkpoint-1"))
+
+		result = podmanTest.Podman([]string{"container", "restore", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+		Expect(podmanTest.GetContainerStatus()).To(ContainSubstring("Up"))
+
+		inspect = podmanTest.InspectContainer(cid)
+		Expect(inspect[0].State.CheckpointChain).To(BeEmpty())
+	})
+
+	It("podman checkpoint container with --pre-dump-chain and export (migration)", func() {
+		if !criu.MemTrack() {
+			Skip("system (architecture/kernel/CRIU) does not support memory tracking")
+		}
+		localRunString := getRunString([]string{ALPINE, "top"})
+		session := podmanTest.Podman(localRunString)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		cid := session.OutputToString()
+		checkpointFileName := filepath.Join(podmanTest.TempDir, "/checkpoint-"+cid+".tar.gz")
+
+		result := podmanTest.Podman([]string{"container", "checkpoint", "--pre-dump-chain", "3", "-e", checkpointFileName, cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"rm", "-t", "0", "-f", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+
+		result = podmanTest.Podman([]string{"container", "restore", "-i", checkpointFileName})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+		Expect(podmanTest.GetContainerStatus()).To(ContainSubstring("Up"))
+
+		os.Remove(checkpointFileName)
+	})
+
 	It("podman checkpoint and restore container with different port mappings", func() {
 		randomPort, err := utils.GetRandomPort()
 		Expect(err).ShouldNot(HaveOccurred())
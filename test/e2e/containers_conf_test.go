@@ -685,4 +685,50 @@ var _ = Describe("Verify podman containers.conf usage", func() {
 			Expect(inspect.OutputToString()).Should(Equal(mode))
 		}
 	})
+
+	It("podman inspect --effective-config", func() {
+		conffile := filepath.Join(podmanTest.TempDir, "container.conf")
+		err := os.WriteFile(conffile, []byte("[containers]\npids_limit = 1234\n[engine]\nstop_timeout = 42\n"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		os.Setenv("CONTAINERS_CONF_OVERRIDE", conffile)
+		if IsRemote() {
+			podmanTest.RestartRemoteService()
+		}
+		session := podmanTest.Podman([]string{"create", "--name", "old", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		// Containers created under a later configuration keep the
+		// settings they were created with.
+		err = os.WriteFile(conffile, []byte("[containers]\npids_limit = 5678\n"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+		if IsRemote() {
+			podmanTest.RestartRemoteService()
+		}
+		session = podmanTest.Podman([]string{"create", "--name", "new", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		format := "{{.EffectiveConfig.containers.pids_limit}} {{.EffectiveConfig.engine.stop_timeout}}"
+		inspect := podmanTest.Podman([]string{"inspect", "--effective-config", "--format", format, "old", "new"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToStringArray()).To(Equal([]string{"1234 42", "5678 10"}))
+
+		inspect = podmanTest.Podman([]string{"container", "inspect", "--effective-config", "--fields", "EffectiveConfig.engine.stop_timeout", "old"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(ContainSubstring(`"stop_timeout": 42`))
+
+		// The snapshot is only shown if requested.
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.EffectiveConfig}}", "old"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("map[]"))
+
+		inspect = podmanTest.Podman([]string{"inspect", "--type", "image", "--effective-config", ALPINE})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitWithError(125, `effective-config is not supported for type "image"`))
+	})
 })
@@ -147,6 +147,27 @@ var _ = Describe("Podman pod start", func() {
 		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
 	})
 
+	It("podman pod start and stop with --parallel", func() {
+		_, ec, podid := podmanTest.CreatePod(map[string][]string{"--name": {"parallelpod"}})
+		Expect(ec).To(Equal(0))
+
+		for i := 0; i < 3; i++ {
+			session := podmanTest.Podman([]string{"create", "--pod", podid, "--name", fmt.Sprintf("ctr%d", i), ALPINE, "top"})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+		}
+
+		session := podmanTest.Podman([]string{"pod", "start", "--parallel", "2", podid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(4))
+
+		session = podmanTest.Podman([]string{"pod", "stop", "--parallel", "1", "-t", "0", podid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+	})
+
 	It("podman pod start multiple pods with bogus", func() {
 		_, ec, podid := podmanTest.CreatePod(map[string][]string{"--name": {"foobar99"}})
 		Expect(ec).To(Equal(0))
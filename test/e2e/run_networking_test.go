@@ -530,6 +530,27 @@ EXPOSE 2004-2005/tcp`, ALPINE)
 		Expect(session.OutputToString()).To(ContainSubstring("mtu 9000"))
 	})
 
+	It("podman run bridge network with --network-opt", func() {
+		net := "mtu-test" + stringid.GenerateRandomID()
+		nc := podmanTest.Podman([]string{"network", "create", "--opt", "mtu=1400", net})
+		nc.WaitWithDefaultTimeout()
+		defer podmanTest.removeNetwork(net)
+		Expect(nc).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"run", "--network", net, "--network-opt", "mtu=1300,tso=off", ALPINE, "cat", "/sys/class/net/eth0/mtu"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("1300"))
+
+		session = podmanTest.Podman([]string{"create", "--network", net, "--network-opt", "mtu=1500", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, fmt.Sprintf("network option mtu=1500 exceeds the MTU 1400 of network %s", net)))
+
+		session = podmanTest.Podman([]string{"create", "--network", "none", "--network-opt", "mtu=1300", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--network-opt can only be set when the network mode is bridge"))
+	})
+
 	It("podman run slirp4netns network with different cidr", func() {
 		slirp4netnsHelp := SystemExec("slirp4netns", []string{"--help"})
 		Expect(slirp4netnsHelp).Should(ExitCleanly())
@@ -1,6 +1,9 @@
 package integration
 
 import (
+	"path/filepath"
+	"time"
+
 	. "github.com/containers/podman/v5/test/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -35,4 +38,39 @@ var _ = Describe("Podman run restart containers", func() {
 		session2.WaitWithDefaultTimeout()
 		Expect(session2).Should(ExitCleanly())
 	})
+
+	It("podman system service --restart-interval restarts exited containers", func() {
+		SkipIfRemote("the restart monitor runs in the local service")
+		// The container exits on its own without a restart policy, as
+		// after a reboot, and keeps running once restarted.
+		session := podmanTest.Podman([]string{"run", "--name", "test", "--restart", "no", ALPINE, "sh", "-c", "test -f /restarted || { touch /restarted; exit 0; }; sleep 100"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		for _, args := range [][]string{
+			{"run", "-d", "--name", "stopped", "--restart", "always", ALPINE, "top"},
+			{"stop", "-t0", "stopped"},
+			{"update", "--restart", "always", "test"},
+		} {
+			session = podmanTest.Podman(args)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+		}
+
+		service := podmanTest.Podman([]string{"system", "service", "--time=0", "--restart-interval=1", "unix://" + filepath.Join(podmanTest.TempDir, "restart.sock")})
+		defer service.Kill()
+
+		Eventually(func(g Gomega) {
+			inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "test"})
+			inspect.WaitWithDefaultTimeout()
+			g.Expect(inspect).Should(ExitCleanly())
+			g.Expect(inspect.OutputToString()).To(Equal("running"))
+		}).WithTimeout(30 * time.Second).WithPolling(time.Second).Should(Succeed())
+
+		// Containers stopped by the user are not restarted.
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "stopped"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("exited"))
+	})
 })
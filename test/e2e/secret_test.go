@@ -403,6 +403,48 @@ var _ = Describe("Podman secret", func() {
 
 	})
 
+	It("podman secret update", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("olddata"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		secrID := session.OutputToString()
+		Expect(session).Should(ExitCleanly())
+
+		ctr := podmanTest.Podman([]string{"run", "-d", "--secret", "a", ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+		ctrID := ctr.OutputToString()
+
+		err = os.WriteFile(secretFilePath, []byte("newdata"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+		session = podmanTest.Podman([]string{"secret", "update", "--notify", "signal", "--signal", "SIGWINCH", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(secrID))
+
+		inspect := podmanTest.Podman([]string{"secret", "inspect", "--format", "{{.Version.Index}}", "a"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("2"))
+
+		// The running container sees the new data and is still running.
+		exec := podmanTest.Podman([]string{"exec", ctrID, "cat", "/run/secrets/a"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(ExitCleanly())
+		Expect(exec.OutputToString()).To(Equal("newdata"))
+
+		session = podmanTest.Podman([]string{"secret", "update", "--notify", "bogus", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `invalid notify option "bogus"`))
+
+		session = podmanTest.Podman([]string{"secret", "update", "bogus", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no such secret"))
+	})
+
 	It("podman secret exists should return true if secret exists", func() {
 		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
 		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0755)
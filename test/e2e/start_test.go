@@ -112,6 +112,24 @@ var _ = Describe("Podman start", func() {
 		Expect(session).Should(ExitCleanly())
 	})
 
+	It("podman start multiple containers in dependency order", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "db", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--name", "app", "--requires", "db", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--name", "web", "--requires", "app", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"start", "web", "app", "db"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{"web", "app", "db"}))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(3))
+	})
+
 	It("podman start multiple containers with bogus", func() {
 		session := podmanTest.Podman([]string{"create", "--name", "foobar99", ALPINE, "ls"})
 		session.WaitWithDefaultTimeout()
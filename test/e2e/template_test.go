@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v5/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman template", func() {
+
+	It("podman template push pull and create", func() {
+		SkipIfRemote("podman generate spec is not supported on the remote client")
+		dest := "oci:" + filepath.Join(tempdir, "templates") + ":1.0"
+
+		session := podmanTest.Podman([]string{"create", "--name", "tplsrc", "--env", "FOO=bar", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"template", "push", "--annotation", "org.opencontainers.image.version=1.0", "tplsrc", dest})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(HavePrefix("sha256:"))
+
+		specFile := filepath.Join(tempdir, "template.json")
+		session = podmanTest.Podman([]string{"template", "pull", "--filename", specFile, dest})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(specFile))
+
+		exec := SystemExec("cat", []string{specFile})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec.OutputToString()).To(ContainSubstring(ALPINE))
+		Expect(exec.OutputToString()).ToNot(ContainSubstring("tplsrc"))
+
+		session = podmanTest.Podman([]string{"template", "create", "--name", "fromtpl", dest})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"inspect", "--format", "{{.Config.Env}} {{.Config.Cmd}}", "fromtpl"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("FOO=bar"))
+		Expect(session.OutputToString()).To(ContainSubstring("[top]"))
+
+		session = podmanTest.Podman([]string{"template", "create", specFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+	})
+
+	It("podman template pull of an image should fail", func() {
+		dest := "oci:" + filepath.Join(tempdir, "image") + ":latest"
+		session := podmanTest.Podman([]string{"push", ALPINE, dest})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"template", "pull", dest})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "not a container template"))
+	})
+
+	It("podman template push of a pod spec should fail", func() {
+		specFile := filepath.Join(tempdir, "pod.json")
+		err := os.WriteFile(specFile, []byte(`{"name": "pod1"}`), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"template", "push", specFile, "oci:" + filepath.Join(tempdir, "templates") + ":pod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "the spec is not a container spec"))
+	})
+})
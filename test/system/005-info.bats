@@ -109,6 +109,29 @@ host.slirp4netns.executable | $expr_path
     is "$db_backend" "$CI_DESIRED_DATABASE" "CI_DESIRED_DATABASE (from .cirrus.yml)"
 }
 
+@test "podman info - database backend section" {
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    db_backend="$output"
+    run_podman info --format '{{.DatabaseBackend.Type}}'
+    is "$output" "$db_backend" "database backend type"
+
+    skip_if_remote "podman system db check is not available remotely"
+    if [[ "$db_backend" != "sqlite" ]]; then
+        skip "maintenance operations are only checked with sqlite"
+    fi
+
+    run_podman '?' system db check
+    if [[ $status -eq 0 ]]; then
+        expected_integrity="ok"
+    else
+        expected_integrity="problems found"
+    fi
+    run_podman info --format json
+    is "$(jq -r .databaseBackend.integrity <<<"$output")" "$expected_integrity" "integrity after check"
+    assert "$(jq -r .databaseBackend.lastCheck <<<"$output")" != "null" "time of last check"
+    assert "$(jq -r .databaseBackend.schemaVersion <<<"$output")" -gt 0 "schema version"
+}
+
 @test "podman info - confirm desired storage driver" {
     if [[ -z "$CI_DESIRED_STORAGE" ]]; then
         # When running in Cirrus, CI_DESIRED_STORAGE *must* be defined
@@ -269,4 +292,17 @@ EOF
     run_podman $safe_opts system reset --force
 }
 
+@test "podman doctor" {
+    skip_if_remote "podman doctor only works for local Podman"
+
+    run_podman '?' doctor
+    for name in "storage driver" "database" "network backend" "conmon" "OCI runtime"; do
+        assert "$output" =~ "\[(PASS|WARN|FAIL|SKIP)\] $name: " "check $name is run"
+    done
+    assert "$output" =~ "\[PASS\] database: no problems detected" "database check passes"
+
+    run_podman 125 doctor --format foo
+    is "$output" 'Error: unknown --format argument: "foo"'
+}
+
 # vim: filetype=sh
@@ -1522,4 +1522,258 @@ search               | $IMAGE           |
     run_podman rm -f -t0 $cname
 }
 
+@test "podman run --provision-script" {
+    cname=c_$(safename)
+    script=$PODMAN_TMPDIR/provision.sh
+    cat >$script <<EOF
+#!/bin/sh
+echo provisioned >>/provisioned
+EOF
+
+    run_podman run -d --name $cname --provision-script $script $IMAGE top
+    run_podman exec $cname cat /provisioned
+    is "$output" "provisioned" "provision script ran on first start"
+    run_podman inspect --format '{{.State.Provisioned}}' $cname
+    is "$output" "true" "container is provisioned"
+
+    run_podman restart -t0 $cname
+    run_podman exec $cname cat /provisioned
+    is "$output" "provisioned" "provision script does not run again"
+    run_podman rm -f -t0 $cname
+
+    cat >$script <<EOF
+#!/bin/sh
+echo oops
+exit 3
+EOF
+    run_podman 125 run -d --name $cname --provision-script $script $IMAGE top
+    assert "$output" =~ "provision script of container .* failed: exit code 3: oops" \
+           "failing provision script"
+    run_podman inspect --format '{{.State.Status}} {{.State.Provisioned}}' $cname
+    is "$output" "exited false" "container is stopped and not provisioned"
+    run_podman rm -f -t0 $cname
+}
+
+
+@test "podman run --on-exit-notify" {
+    local cname=c-$(safename)
+    local script=$PODMAN_TMPDIR/notify.sh
+    local out=$PODMAN_TMPDIR/notified
+    cat >$script <<EOF
+#!/bin/sh
+echo "\$PODMAN_CONTAINER_NAME \$PODMAN_EXIT_CODE" >$out.tmp
+cat >>$out.tmp
+mv $out.tmp $out
+EOF
+    chmod 755 $script
+
+    run_podman 125 run --on-exit-notify pager=123 $IMAGE true
+    is "$output" "Error: invalid on-exit notifier type \"pager\", must be webhook, script or email: invalid argument"
+
+    run_podman 3 run --name $cname --on-exit-notify script=$script \
+               --on-exit-notify-template '{{.ExitCode}}:{{range .Logs}}{{.}}{{end}}' \
+               $IMAGE sh -c 'echo goodbye; exit 3'
+    wait_for_file $out
+    run cat $out
+    assert "$output" = "$cname 3
+3:goodbye" "notification of the exit"
+
+    run_podman inspect --format '{{.Config.OnExitNotify}}' $cname
+    is "$output" "\[script=$script\]"
+
+    # Stopping the container is not an unexpected exit.
+    rm -f $out
+    run_podman start $cname
+    run_podman wait $cname
+    wait_for_file $out
+    rm -f $out
+    run_podman rm -t0 -f $cname
+    run_podman run -d --name $cname --on-exit-notify script=$script $IMAGE top
+    run_podman stop -t0 $cname
+    sleep 2
+    assert "$(ls $out 2>/dev/null)" = "" "no notification when stopped by the user"
+    run_podman rm -t0 -f $cname
+}
+
+@test "podman run - exit history" {
+    local cname=c-$(safename)
+
+    run_podman 3 run --name $cname $IMAGE sh -c 'exit 3'
+    run_podman 3 start -a $cname
+    run_podman inspect --format '{{range .ExitHistory}}{{.ExitCode}} {{end}}' $cname
+    is "$output" "3 3 " "every exit is recorded"
+
+    run_podman inspect --format '{{len .ExitHistory}} {{(index .ExitHistory 1).ExitedAt.After (index .ExitHistory 0).ExitedAt}}' $cname
+    is "$output" "2 \(true\|false\)" "exits have timestamps"
+
+    run_podman rm $cname
+}
+
+
+@test "podman run --proxy-profile" {
+    skip_if_remote "containers.conf override is not applied to the server"
+    local cname=c-$(safename)
+    local ca=$PODMAN_TMPDIR/ca.pem
+    echo "corp-ca-$(random_string)" >$ca
+    containersconf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersconf <<EOF
+[containers.proxy_profiles.corp]
+http_proxy = "http://proxy.example.com:3128"
+no_proxy = "localhost,.example.com"
+ca_mounts = ["$ca:/etc/corp-ca.pem"]
+EOF
+
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman 125 run --rm --proxy-profile nosuch $IMAGE true
+    is "$output" "Error: proxy profile \"nosuch\" is not defined in containers.conf: invalid argument"
+
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman run --name $cname --proxy-profile corp \
+        --env no_proxy=override $IMAGE sh -c 'echo $HTTP_PROXY $http_proxy $no_proxy; cat /etc/corp-ca.pem'
+    is "${lines[0]}" "http://proxy.example.com:3128 http://proxy.example.com:3128 override" "proxy environment"
+    is "${lines[1]}" "$(<$ca)" "CA bundle mounted"
+
+    run_podman inspect --format '{{.Config.ProxyProfile}}' $cname
+    is "$output" "corp"
+    run_podman rm $cname
+}
+
+@test "podman run --security-profile" {
+    skip_if_remote "containers.conf override is not applied to the server"
+    local cname=c-$(safename)
+    containersconf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersconf <<EOF
+[containers]
+security_profile = "baseline"
+EOF
+
+    run_podman 125 run --rm --security-profile nosuch $IMAGE true
+    is "$output" "Error: unsupported security profile \"nosuch\", must be one of \[privileged baseline restricted\]: invalid argument"
+
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman 125 run --rm --privileged $IMAGE true
+    is "$output" "Error: --privileged is not allowed by the \"baseline\" security profile: invalid argument"
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman 125 run --rm --cap-add SYS_ADMIN $IMAGE true
+    is "$output" "Error: adding capability CAP_SYS_ADMIN is not allowed by the \"baseline\" security profile: invalid argument"
+
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman run --name $cname --security-profile restricted --user 1000 \
+        $IMAGE grep -E '^(CapBnd|NoNewPrivs):' /proc/self/status
+    assert "$output" =~ "Security profile \"baseline\" of containers.conf overridden with \"restricted\"" "override is logged"
+    assert "$output" =~ "CapBnd:[[:space:]]+0000000000000400" "only NET_BIND_SERVICE is kept"
+    assert "$output" =~ "NoNewPrivs:[[:space:]]+1" "no-new-privileges is set"
+
+    run_podman inspect --format '{{.Config.SecurityProfile}} {{.Config.SecurityProfileOverridden}}' $cname
+    is "$output" "restricted baseline"
+    run_podman rm $cname
+}
+
+@test "podman run - image usage policy" {
+    skip_if_remote "the policy path is not passed to the server"
+    local policy=$PODMAN_TMPDIR/image-usage-policy.json
+    local repo=${IMAGE%:*}
+
+    cat >$policy <<EOF
+{"deny": ["$repo"]}
+EOF
+    CONTAINERS_IMAGE_USAGE_POLICY="$policy" run_podman 125 run --rm $IMAGE true
+    is "$output" "Error: image $IMAGE: repository $repo is denied by $policy: image denied by usage policy"
+
+    run_podman events --stream=false --since 1m --filter event=policy-denied --format '{{.Type}} {{.Name}} {{.Error}}'
+    assert "$output" =~ "image $IMAGE repository $repo is denied" "denial is reported as event"
+
+    cat >$policy <<EOF
+{"allow": ["$repo"], "maxAge": "87600h"}
+EOF
+    CONTAINERS_IMAGE_USAGE_POLICY="$policy" run_podman run --rm $IMAGE true
+
+    cat >$policy <<EOF
+{"allow": ["registry.example.com"]}
+EOF
+    CONTAINERS_IMAGE_USAGE_POLICY="$policy" run_podman 125 create $IMAGE
+    is "$output" "Error: image $IMAGE: repository $repo is not allowed by $policy: image denied by usage policy"
+
+    cat >$policy <<EOF
+{"maxAge": "30 days"}
+EOF
+    CONTAINERS_IMAGE_USAGE_POLICY="$policy" run_podman 125 create $IMAGE
+    assert "$output" =~ "invalid maxAge in image usage policy $policy" "invalid policy is rejected"
+}
+
+@test "podman run --keyring" {
+    local cname=c-$(safename)
+
+    run_podman 125 run --rm --keyring nosuch $IMAGE true
+    is "$output" "Error: unsupported keyring mode \"nosuch\", must be one of \[private inherit none\]: invalid argument"
+
+    run_podman 125 run --rm --keyring none --security-opt seccomp=unconfined $IMAGE true
+    is "$output" "Error: --keyring=none requires a seccomp profile to block the keyring system calls: invalid argument"
+
+    run_podman run -d --name $cname --keyring inherit $IMAGE top
+    run_podman inspect --format '{{.Config.Keyring}} {{.State.KeyringKeys}}' $cname
+    is "$output" "inherit <nil>" "inherited keyring is not counted"
+    run_podman rm -f -t0 $cname
+
+    run_podman create --name $cname --keyring none $IMAGE true
+    run_podman inspect --format '{{.Config.Keyring}}' $cname
+    is "$output" "none"
+    run_podman rm $cname
+}
+
+@test "podman run --condition" {
+    local cname=c-$(safename)
+    local cond=$PODMAN_TMPDIR/condition
+
+    run_podman 125 run --condition port=1234 $IMAGE true
+    is "$output" "Error: .*unsupported start condition type \"port\", must be one of \[path-exists tcp\]: invalid argument"
+    run_podman 125 run --condition tcp=localhost $IMAGE true
+    is "$output" "Error: .*the address must be in the host:port form: invalid argument"
+
+    run_podman create --name $cname --condition path-exists=$cond --condition-timeout 1s $IMAGE echo started
+    run_podman inspect --format '{{.Config.StartConditions}}' $cname
+    is "$output" "\[path-exists=$cond\]"
+
+    run_podman 125 start $cname
+    is "$output" "Error: .*path-exists=$cond not met after 1s: .*: start condition not met"
+    run_podman inspect --format '{{.State.Status}}' $cname
+    is "$output" "created" "container is not started"
+
+    (sleep 2; touch $cond) &
+    run_podman container rm -f $cname
+    run_podman run --name $cname --condition path-exists=$cond --condition-timeout 30s $IMAGE echo started
+    is "$output" "started" "container starts once the condition is met"
+    run_podman rm $cname
+}
+
+@test "podman run --env-file-template - restart by the cleanup process" {
+    skip_if_remote "the template is resolved in the environment of the server"
+    local cname=c-$(safename)
+    local secret=s-$(safename)
+    local tmpl=$PODMAN_TMPDIR/env.tmpl
+
+    run_podman secret create $secret - <<< "hunter2"
+    cat >$tmpl <<EOF
+DB_HOST={{ env "TEST_DB_HOST" }}
+DB_PASSWORD={{ secret "$secret" }}
+EOF
+
+    # The cleanup process started by conmon restarts the container and
+    # resolves the template again, in the environment inherited from the
+    # podman process that started the container.
+    TEST_DB_HOST=db.example run_podman run -d --name $cname --restart=on-failure:2 \
+        --env-file-template $tmpl $IMAGE sh -c 'echo "$DB_HOST $DB_PASSWORD"; exit 1'
+    for i in {1..20}; do
+        run_podman inspect --format '{{.RestartCount}} {{.State.Status}}' $cname
+        if [[ "$output" == "2 exited" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    is "$output" "2 exited" "container restarted twice by the cleanup process"
+
+    run_podman logs $cname
+    is "$output" "db.example hunter2
+db.example hunter2
+db.example hunter2" "template resolved on every start"
+
+    run_podman rm $cname
+    run_podman secret rm $secret
+}
+
 # vim: filetype=sh
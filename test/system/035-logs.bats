@@ -433,4 +433,36 @@ EOF
     run_podman rm $cname
 }
 
+@test "podman logs --redact" {
+    local cname=c-$(safename)
+    local secret_name=s-$(safename)
+    local secret_value=value-$(random_string 15)
+    containersconf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersconf <<EOF
+[containers]
+log_redact_patterns = ["password=(\\\\S+)"]
+EOF
+
+    echo -n "$secret_value" | run_podman secret create $secret_name -
+    run_podman run --name $cname --secret $secret_name $IMAGE \
+        sh -c "cat /run/secrets/$secret_name; echo; echo password=hunter22 user=me"
+
+    run_podman logs $cname
+    assert "$output" = "$secret_value
+password=hunter22 user=me" "logs are not redacted by default"
+
+    CONTAINERS_CONF_OVERRIDE=$containersconf run_podman logs --redact $cname
+    if is_remote; then
+        # The server does not read the containers.conf of the client.
+        assert "$output" = "********
+password=hunter22 user=me" "secret is redacted"
+    else
+        assert "$output" = "********
+password=******** user=me" "secret and pattern are redacted"
+    fi
+
+    run_podman rm $cname
+    run_podman secret rm $secret_name
+}
+
 # vim: filetype=sh
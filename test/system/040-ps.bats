@@ -247,4 +247,126 @@ load helpers
     run_podman rmi $(pause_image)
 }
 
+@test "podman ps --filter annotation and note" {
+    skip_if_remote "container annotations and notes are not supported on remote clients"
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    if [[ "$output" == "boltdb" ]]; then
+        skip "container annotations and notes require the sqlite or postgres database backend"
+    fi
+
+    local cname1=c1-$(random_string 10)
+    local cname2=c2-$(random_string 10)
+    local team=team-$(random_string 10)
+    run_podman create --name $cname1 $IMAGE
+    run_podman create --name $cname2 $IMAGE
+
+    run_podman container annotate $cname1 owner=$team ticket=OPS-1
+    run_podman container annotate $cname2 owner=other
+    run_podman container annotate $cname1
+    assert "$output" = "owner=$team
+ticket=OPS-1" "annotations are listed sorted by key"
+
+    run_podman ps -a --filter annotation=owner=$team --format '{{.Names}}'
+    is "$output" "$cname1" "filter by annotation"
+
+    run_podman container annotate --rm ticket $cname1
+    run_podman container annotate --format json $cname1
+    assert "$output" !~ "ticket" "annotation was removed"
+
+    run_podman 125 container annotate $cname1 owner
+    is "$output" "Error: invalid annotation \"owner\", must be KEY=VALUE"
+
+    run_podman notes $cname2 "Rotated certs $team"
+    local noteid="$output"
+    run_podman container notes $cname2 "raised memory limit"
+    run_podman notes --format '{{.ID}}:{{.Text}}' $cname2
+    assert "${lines[0]}" = "$noteid:Rotated certs $team" "first note"
+    assert "${#lines[*]}" = 2 "number of notes"
+
+    run_podman ps -a --filter note=rotated --format '{{.Names}}'
+    assert "$output" =~ "$cname2" "filter by note is case insensitive"
+    run_podman ps -a --filter note="certs $team" --format '{{.Names}}'
+    is "$output" "$cname2" "filter by note"
+
+    run_podman notes --rm $noteid $cname2
+    run_podman notes --noheading --format '{{.Text}}' $cname2
+    is "$output" "raised memory limit" "note was removed"
+    run_podman 125 notes --rm $noteid $cname2
+    assert "$output" =~ "no such container note" "removing a missing note"
+
+    run_podman rm $cname1 $cname2
+}
+
+@test "podman --namespace" {
+    skip_if_remote "libpod namespaces are not supported on remote clients"
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    if [[ "$output" == "boltdb" ]]; then
+        skip "libpod namespaces are not supported by the boltdb database backend"
+    fi
+
+    local ns1=ns1-$(random_string 8)
+    local ns2=ns2-$(random_string 8)
+    local cname1=c1-$(safename)
+    local cname2=c2-$(safename)
+    run_podman --namespace $ns1 create --name $cname1 $IMAGE
+    run_podman --namespace $ns2 create --name $cname2 $IMAGE
+
+    run_podman --namespace $ns1 ps -a --format '{{.Names}}'
+    is "$output" "$cname1" "only the container of the namespace is listed"
+    run_podman 125 --namespace $ns1 inspect --type container $cname2
+    assert "$output" =~ "no such container" "container of another namespace"
+
+    run_podman ps -a --format '{{.Names}}'
+    assert "$output" =~ "$cname1" "no namespace lists container 1"
+    assert "$output" =~ "$cname2" "no namespace lists container 2"
+
+    run_podman --namespace $ns2 rm $cname2
+    run_podman rm $cname1
+}
+
+@test "podman group" {
+    skip_if_remote "container groups are not supported on remote clients"
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    if [[ "$output" == "boltdb" ]]; then
+        skip "container groups require the sqlite or postgres database backend"
+    fi
+
+    local group=g-$(random_string 10)
+    local label=tier=$(random_string 10)
+    local cname1=c1-$(safename)
+    local cname2=c2-$(safename)
+    local cname3=c3-$(safename)
+
+    run_podman 125 group create $group
+    is "$output" "Error: at least one --filter must be given"
+    run_podman group create $group --filter label=$label
+    is "$output" "$group"
+    run_podman 125 group create $group --filter label=$label
+    is "$output" "Error: container group $group: container group already exists"
+
+    # Containers created after the group are in it.
+    run_podman create --name $cname1 --label $label $IMAGE
+    run_podman create --name $cname2 --label $label $IMAGE
+    run_podman create --name $cname3 $IMAGE
+
+    run_podman ps -a --filter group=$group --sort names --format '{{.Names}}'
+    is "$output" "$cname1
+$cname2" "containers of the group"
+    run_podman group ls --noheading --format '{{.Name}} {{.Filters}} {{.Members}}'
+    assert "$output" =~ "$group label=$label 2" "group listed"
+
+    run_podman rm @$group
+    run_podman ps -a --format '{{.Names}}'
+    assert "$output" !~ "$cname1" "container 1 removed with @$group"
+    assert "$output" =~ "$cname3" "container 3 not in the group"
+
+    run_podman group rm $group
+    run_podman 125 group rm $group
+    is "$output" "Error: container group $group: no such container group"
+    run_podman rm $cname3
+}
+
 # vim: filetype=sh
@@ -183,6 +183,32 @@ load helpers
     run_podman rm -t 0 -f $cid
 }
 
+@test "podman run --mount image persist" {
+    skip_if_rootless "too hard to test rootless"
+    local cname=c-$(safename)
+
+    run_podman 125 run --rm --mount type=image,src=$IMAGE,dst=/image-mount,persist=true $IMAGE true
+    is "$output" "Error: persist requires a read-write image volume, set rw=true"
+
+    run_podman create --name $cname --mount type=image,src=$IMAGE,dst=/image-mount,subpath=/etc,rw=true,persist=true \
+               $IMAGE sh -c 'cat /image-mount/persisted 2>/dev/null; echo $RANDOM >>/image-mount/persisted; test -f /image-mount/os-release'
+    run_podman inspect --format "{{(index .Mounts 0).Name}}" $cname
+    volname="$output"
+    assert "$volname" != "" "inspect data includes the volume of the image mount"
+    run_podman volume inspect --format '{{.Anonymous}}' $volname
+    is "$output" "true" "the volume of the image mount is anonymous"
+
+    run_podman start --attach $cname
+    assert "$output" = "" "nothing persisted before the first start"
+    run_podman start --attach $cname
+    assert "$output" =~ "^[0-9]+$" "changes persisted across restarts"
+
+    # The volume is in use by the container and removed together with it.
+    run_podman 2 volume rm $volname
+    run_podman rm --volumes $cname
+    run_podman 1 volume exists $volname
+}
+
 @test "podman mount containers.conf" {
     skip_if_remote "remote does not support CONTAINERS_CONF*"
 
@@ -245,4 +245,92 @@ load helpers
     run_podman rm -f -t0 $cid
 }
 
+@test "podman container exec-ls and exec-inspect" {
+    skip_if_remote "exec sessions are listed from the local database"
+
+    run_podman run -d $IMAGE top
+    cid="$output"
+
+    run_podman exec -d $cid sh -c 'exit 3'
+    eid="$output"
+
+    # The detached session is kept after it exited, with its exit code
+    for i in {1..20}; do
+        run_podman container exec-ls --noheading --format '{{.State}}' $cid
+        if [[ "$output" = "stopped" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    run_podman container exec-ls --no-trunc --noheading --format '{{.ID}} {{.State}} {{.ExitCode}} {{.Command}}' $cid
+    assert "$output" = "$eid stopped 3 sh -c exit 3" "exec-ls lists the stopped session"
+
+    run_podman container exec-ls -q
+    assert "$output" =~ "$eid" "exec-ls -q prints the session ID"
+
+    run_podman container exec-inspect ${eid:0:12}
+    assert "$output" =~ "\"ExitCode\": 3" "exec-inspect by ID prefix"
+
+    run_podman 125 container exec-inspect nosuchsession
+    assert "$output" =~ "no exec session with ID nosuchsession found" "exec-inspect of unknown session"
+
+    run_podman rm -f -t0 $cid
+
+    run_podman container exec-ls -q
+    assert "$output" !~ "$eid" "sessions are removed with their container"
+}
+
+@test "podman debug" {
+    cname="c-$(safename)"
+    run_podman run -d --name $cname $IMAGE top
+    cid="$output"
+
+    declare -A target_ns
+    for ns in ipc net pid uts; do
+        run_podman exec $cid readlink /proc/self/ns/$ns
+        target_ns[$ns]="$output"
+    done
+
+    # By default the pid and net namespaces are shared
+    for ns in ipc net pid uts; do
+        run_podman debug --image $IMAGE $cname readlink /proc/self/ns/$ns
+        if [[ "$ns" = "pid" || "$ns" = "net" ]]; then
+            assert "$output" = "${target_ns[$ns]}" "debug container shares the $ns namespace"
+        else
+            assert "$output" != "${target_ns[$ns]}" "debug container has its own $ns namespace"
+        fi
+    done
+
+    run_podman debug --image $IMAGE --share ipc,uts $cname sh -c 'readlink /proc/self/ns/ipc; readlink /proc/self/ns/uts; readlink /proc/self/ns/pid'
+    assert "${lines[0]}" = "${target_ns[ipc]}" "--share ipc"
+    assert "${lines[1]}" = "${target_ns[uts]}" "--share uts"
+    assert "${lines[2]}" != "${target_ns[pid]}" "pid namespace not shared"
+
+    # The root of the target is reachable through its main process
+    content=$(random_string 20)
+    run_podman exec $cid sh -c "echo $content > /tmp/marker"
+    run_podman debug --image $IMAGE --target-root $cname sh -c 'cat $TARGET_ROOT/tmp/marker'
+    assert "$output" = "$content" "--target-root gives access to the root of the target"
+
+    # The debug containers are removed when they exit
+    run_podman ps -a --noheading --no-trunc --format '{{.ID}}'
+    assert "$output" = "$cid" "only the target is left"
+
+    dname="d-$(safename)"
+    run_podman debug --image $IMAGE --rm=false --name $dname $cname true
+    run_podman container exists $dname
+    run_podman rm $dname
+
+    run_podman 125 debug --image $IMAGE --share mnt $cname true
+    is "$output" "Error: invalid namespace \"mnt\" for --share, must be one of ipc, net, pid, user, uts"
+    run_podman 125 debug --image $IMAGE --share net --target-root $cname true
+    is "$output" "Error: --target-root requires sharing the pid namespace of the target"
+
+    run_podman rm -f -t0 $cid
+    run_podman create --name $cname $IMAGE true
+    run_podman 125 debug --image $IMAGE $cname true
+    is "$output" "Error: container $cname is not running, only running containers can be debugged"
+    run_podman rm $cname
+}
+
 # vim: filetype=sh
@@ -620,3 +620,243 @@ EOF
 }
 
 # vim: filetype=sh
+
+@test "podman volume backup" {
+    skip_if_remote "volume backups are not supported on remote clients"
+
+    local volume_name=v-$(random_string)
+    local target=${PODMAN_TMPDIR}/backups
+
+    run_podman volume create $volume_name
+    run_podman volume inspect --format '{{.Mountpoint}}' $volume_name
+    mountpoint="$output"
+    echo "first" > $mountpoint/file
+
+    run_podman 125 volume backup enable --target relative/dir $volume_name
+    is "$output" "Error: invalid volume backup target \"relative/dir\".*" "relative target"
+    run_podman 125 volume backup enable --target $target --schedule monthly $volume_name
+    is "$output" "Error: invalid volume backup schedule \"monthly\".*" "invalid schedule"
+
+    run_podman volume backup enable --keep 2 --target $target $volume_name
+    run_podman volume backup inspect $volume_name
+    assert "$output" =~ '"schedule": "daily"' "default schedule"
+    assert "$output" =~ '"keep": 2' "kept backups"
+
+    # Only the two latest backups are kept.
+    run_podman volume backup run $volume_name
+    first_archive="$output"
+    echo "second" > $mountpoint/file
+    run_podman volume backup run $volume_name
+    run_podman volume backup run $volume_name
+    test ! -e $first_archive || die "oldest backup $first_archive was not removed"
+    run ls $target
+    assert "${#lines[@]}" = 2 "backups kept on the target"
+
+    run_podman volume backup ls --noheading --format '{{.ID}}' $volume_name
+    assert "${#lines[@]}" = 2 "backups recorded in the database"
+    local oldest_id=${lines[0]}
+
+    echo "changed" > $mountpoint/file
+    touch $mountpoint/new
+    run_podman volume backup restore $volume_name $oldest_id
+    is "$(cat $mountpoint/file)" "second" "file restored from backup"
+    test ! -e $mountpoint/new || die "file created after the backup was not removed"
+
+    run_podman volume backup disable $volume_name
+    run_podman 125 volume backup run $volume_name
+    is "$output" "Error: backup policy of volume $volume_name: no such volume backup" "run without policy"
+
+    # Backups outlive their volume and can be restored into a new one.
+    run_podman volume rm $volume_name
+    run_podman volume create $volume_name
+    run_podman volume inspect --format '{{.Mountpoint}}' $volume_name
+    mountpoint="$output"
+    run_podman volume backup restore $volume_name
+    is "$(cat $mountpoint/file)" "second" "latest backup restored into new volume"
+
+    run_podman volume rm $volume_name
+}
+
+@test "podman volume snapshot" {
+    skip_if_remote "volume snapshots are not supported on remote clients"
+
+    local volume_name=v-$(random_string)
+
+    run_podman volume create $volume_name
+    run_podman volume inspect --format '{{.Mountpoint}}' $volume_name
+    mountpoint="$output"
+    echo "first" > $mountpoint/file
+
+    run_podman volume snapshot create --name first $volume_name
+    is "$output" "first" "snapshot name"
+    run_podman 125 volume snapshot create --name first $volume_name
+    is "$output" "Error: volume $volume_name already has a snapshot named first: invalid argument" "duplicate name"
+
+    echo "second" > $mountpoint/file
+    run_podman volume snapshot create $volume_name
+    local second="$output"
+
+    run_podman volume snapshot ls --noheading --format '{{.ID}} {{.Name}} {{.Method}}' $volume_name
+    assert "${#lines[@]}" = 2 "snapshots recorded in the database"
+    assert "${lines[0]}" =~ "^[0-9]+ first (reflink|copy)$" "oldest snapshot first"
+    local first_id=${lines[0]%% *}
+
+    echo "changed" > $mountpoint/file
+    touch $mountpoint/new
+    run_podman volume snapshot restore $volume_name $first_id
+    is "$output" "first" "restored snapshot"
+    is "$(cat $mountpoint/file)" "first" "file restored from snapshot"
+    test ! -e $mountpoint/new || die "file created after the snapshot was not removed"
+
+    run_podman volume snapshot restore $volume_name $second
+    is "$(cat $mountpoint/file)" "second" "file restored from second snapshot"
+
+    # Volumes used by running containers are not restored.
+    run_podman run -d --name c-$volume_name -v $volume_name:/vol $IMAGE top
+    run_podman 125 volume snapshot restore $volume_name first
+    assert "$output" =~ "volume $volume_name is used by running container .*: volume is being used"
+    run_podman rm -f -t0 c-$volume_name
+
+    run_podman volume snapshot rm $volume_name first
+    is "$output" "first" "removed snapshot"
+    run_podman 125 volume snapshot restore $volume_name first
+    is "$output" "Error: snapshot first of volume $volume_name: no such volume snapshot"
+
+    # Snapshots are removed with their volume.
+    run_podman volume rm $volume_name
+    run_podman volume snapshot ls --noheading
+    assert "$output" !~ "$volume_name" "snapshots of removed volume"
+}
+
+@test "podman volume create --opt size" {
+    skip_if_remote "volume mount points are not on the remote client"
+    skip_if_rootless "limiting the size of volumes requires root"
+
+    local volume_name=v-$(safename)
+    run_podman volume create --opt size=16M $volume_name
+    run_podman volume inspect --format '{{.QuotaMethod}} {{.Options.SIZE}}' $volume_name
+    assert "$output" =~ "^(project|loopback) 16M$" "size recorded in the volume"
+
+    # Writing more than the size fails.
+    run_podman 1 run --rm -v $volume_name:/vol $IMAGE dd if=/dev/zero of=/vol/file bs=1M count=32
+    assert "$output" =~ "No space left on device" "volume is limited to its size"
+
+    run_podman volume rm $volume_name
+}
+
+@test "podman volume create --driver nfs and cifs" {
+    skip_if_remote "volume option errors are wrapped differently on remote clients"
+
+    run_podman 125 volume create --driver nfs --opt server=nfs.example.com v-$(safename)
+    is "$output" "Error: must provide the export option when creating a volume with the nfs driver: invalid argument"
+    run_podman 125 volume create --driver cifs --opt server=smb.example.com --opt export=/data v-$(safename)
+    is "$output" "Error: invalid mount option export for driver 'cifs': invalid argument"
+}
+
+@test "podman volume create --opt encrypt" {
+    skip_if_remote "volume mount points are not on the remote client"
+    skip_if_rootless "the decrypted view is only accessible to container users as root"
+    if ! type -p gocryptfs >/dev/null || [[ ! -e /dev/fuse ]]; then
+        skip "encrypted volumes require gocryptfs and FUSE"
+    fi
+
+    local volume_name=v-$(safename)
+    local secret_name=s-$(safename)
+    run_podman 125 volume create --opt encrypt=$secret_name $volume_name
+    is "$output" "Error: reading encryption secret $secret_name: .*no such secret"
+
+    printf '%s' "password-$(random_string 20)" | run_podman secret create $secret_name -
+    run_podman volume create --opt encrypt=$secret_name $volume_name
+    run_podman volume inspect --format '{{.EncryptionSecret}} {{.Mountpoint}}' $volume_name
+    is "$output" "$secret_name .*" "secret recorded in the volume"
+    local mountpoint=${output#* }
+
+    local content=$(random_string 40)
+    run_podman run --rm -v $volume_name:/vol $IMAGE sh -c "echo $content > /vol/file"
+    run_podman run --rm -v $volume_name:/vol $IMAGE cat /vol/file
+    is "$output" "$content" "contents are decrypted inside containers"
+
+    # At rest, neither the file name nor its contents are readable.
+    assert "$(ls $mountpoint)" == "" "decrypted view is not mounted"
+    run grep -r "$content" $(dirname $mountpoint)/encrypted
+    assert "$status" -eq 1 "contents are encrypted at rest"
+
+    run_podman 125 volume snapshot create $volume_name
+    is "$output" "Error: volume $volume_name is encrypted, snapshots would store its contents unencrypted: not yet implemented"
+
+    run_podman volume rm $volume_name
+    run_podman secret rm $secret_name
+}
+
+@test "podman volume create --driver image" {
+    local volume_name=v-$(safename)
+    run_podman volume create --driver image --opt image=$IMAGE --label test=$volume_name $volume_name
+
+    run_podman image inspect --format '{{.ID}}' $IMAGE
+    local image_id=$output
+    run_podman volume inspect --format '{{.Driver}} {{.ImageName}} {{.Image}}' $volume_name
+    is "$output" "image $IMAGE $image_id" "image recorded in the volume"
+
+    run_podman volume ls --filter image=$IMAGE --format '{{.Name}}'
+    assert "$output" =~ "$volume_name" "volume listed by its image"
+    run_podman volume ls --filter image=$image_id --format '{{.Name}} {{.ImageName}}'
+    assert "$output" =~ "$volume_name $IMAGE" "volume listed by its image ID"
+
+    run_podman run --rm -v $volume_name:/vol $IMAGE cat /vol/home/podman/testimage-id
+    assert "$output" != "" "volume holds the contents of the image"
+
+    run_podman volume prune --force --filter image=$IMAGE --filter label=test=$volume_name
+    assert "$output" =~ "$volume_name" "unused image volume is pruned"
+    run_podman 1 volume exists $volume_name
+}
+
+@test "podman container clone --volumes" {
+    local cname=c-$(safename)
+    local vname=v-$(safename)
+
+    run_podman volume create $vname
+    run_podman run --name $cname -v $vname:/data -v /anon $IMAGE \
+        sh -c 'echo named > /data/file; echo anon > /anon/file'
+    run_podman inspect --format '{{range .Mounts}}{{if eq .Destination "/anon"}}{{.Name}}{{end}}{{end}}' $cname
+    local anon=$output
+
+    # The default shares the volumes.
+    run_podman container clone --name $cname-share $cname
+    run_podman inspect --format '{{range .Mounts}}{{.Name}} {{end}}' $cname-share
+    assert "$output" =~ "$vname" "named volume shared"
+    assert "$output" =~ "$anon" "anonymous volume shared"
+
+    # Copies hold the data of the original volumes.
+    run_podman container clone --volumes=copy --name $cname-copy $cname
+    run_podman run --rm -v $vname-$cname-copy:/data $IMAGE cat /data/file
+    is "$output" "named" "named volume copied"
+    run_podman inspect --format '{{range .Mounts}}{{if eq .Destination "/anon"}}{{.Name}}{{end}}{{end}}' $cname-copy
+    local anon_copy=$output
+    assert "$anon_copy" != "$anon" "anonymous volume cloned"
+    run_podman volume inspect --format '{{.Anonymous}}' $anon_copy
+    is "$output" "true" "cloned anonymous volume is anonymous"
+    run_podman run --rm -v $anon_copy:/anon $IMAGE cat /anon/file
+    is "$output" "anon" "anonymous volume copied"
+
+    # New volumes are empty.
+    run_podman container clone --volumes=new --name $cname-new $cname
+    run_podman run --rm -v $vname-$cname-new:/data $IMAGE ls /data
+    is "$output" "" "new named volume is empty"
+
+    # The volumes created for a failed clone are removed.
+    run_podman volume ls -q
+    local volumes=$output
+    run_podman create --name $cname-taken $IMAGE true
+    run_podman 125 container clone --volumes=new --name $cname-taken $cname
+    assert "$output" =~ "\"$cname-taken\".* in use" "clone fails"
+    run_podman 1 volume exists $vname-$cname-taken
+    run_podman volume ls -q
+    is "$output" "$volumes" "no volume left behind by the failed clone"
+
+    # Anonymous volumes are removed with their clone.
+    run_podman rm -v $cname-copy
+    run_podman 1 volume exists $anon_copy
+
+    run_podman rm -v $cname-taken $cname-new $cname-share $cname
+    run_podman volume rm $vname $vname-$cname-copy $vname-$cname-new
+}
@@ -763,4 +763,82 @@ function thingy_with_unique_id() {
     done
 }
 
+@test "podman pod update --publish" {
+    podname="p-$(safename)"
+    port_in=$(random_free_port 5000-5999)
+    port_out=$(random_free_port 6000-6999)
+    port_new=$(random_free_port 7000-7999)
+
+    run_podman pod create --name $podname --network bridge -p $port_out:$port_in
+    run_podman pod start $podname
+
+    # Change the published port of the running pod.
+    run_podman pod update --publish $port_new:$port_in $podname
+    run_podman pod inspect $podname --format '{{range $p, $b := .InfraConfig.PortBindings}}{{$p}} {{(index $b 0).HostPort}}{{end}}'
+    is "$output" "$port_in/tcp $port_new" "pod publishes the new port"
+
+    # The new port forwards to the running pod, the old one is gone.
+    teststring=$(random_string 30)
+    cname="c-$(safename)"
+    run_podman run -d --pod $podname --name $cname $IMAGE nc -l -p $port_in
+    cid="$output"
+    run nc -z 127.0.0.1 $port_out
+    assert "$status" -ne 0 "old port is no longer published"
+    echo "$teststring" | nc 127.0.0.1 $port_new
+    run_podman wait $cid
+    run_podman logs $cid
+    is "$output" "$teststring" "test string received through the new port"
+
+    # Remove all published ports.
+    run_podman pod update --publish "" $podname
+    run_podman pod inspect $podname --format '{{len .InfraConfig.PortBindings}}'
+    is "$output" "0" "pod publishes no ports"
+
+    run_podman 125 pod update $podname
+    is "$output" "Error: must specify at least one configuration option to update"
+
+    run_podman pod rm -t 0 -f $podname
+
+    # Ports can only be published by pods with an infra container.
+    run_podman pod create --name $podname --infra=false
+    run_podman 125 pod update --publish $port_new:$port_in $podname
+    assert "$output" =~ "ports can only be published by pods with an infra container"
+    run_podman pod rm $podname
+}
+
+@test "podman pod exec" {
+    podname="p-$(safename)"
+    c1="c1-$(safename)"
+    c2="c2-$(safename)"
+    run_podman pod create --name $podname
+    run_podman run -d --pod $podname --name $c1 --label role=web $IMAGE top
+    run_podman run -d --pod $podname --name $c2 $IMAGE top
+    run_podman exec $c1 sh -c 'echo 2 > /tmp/rc'
+    run_podman exec $c2 sh -c 'echo 5 > /tmp/rc'
+
+    # The command runs in all containers but the infra container, each line
+    # of output prefixed with the name of its container.
+    run_podman pod exec $podname cat /tmp/rc
+    assert "$(sort <<<"$output")" = "$c1 2
+$c2 5" "output of all containers"
+
+    # The exit code is the highest exit code of all commands
+    run_podman 5 pod exec $podname sh -c 'exit $(cat /tmp/rc)'
+    run_podman 2 pod exec --label role=web $podname sh -c 'exit $(cat /tmp/rc)'
+    run_podman exec $c2 sh -c 'echo 0 > /tmp/rc'
+    run_podman 2 pod exec $podname sh -c 'exit $(cat /tmp/rc)'
+    run_podman exec $c1 sh -c 'echo 0 > /tmp/rc'
+    run_podman pod exec $podname sh -c 'exit $(cat /tmp/rc)'
+
+    # Commands which cannot be run are reported with the exec exit codes
+    run_podman 127 pod exec $podname nosuchcommand
+    assert "$output" =~ "$c1: .*nosuchcommand" "error of the first container"
+    assert "$output" =~ "$c2: .*nosuchcommand" "error of the second container"
+
+    run_podman 125 pod exec --label role=db $podname true
+    is "$output" "Error: no running container in pod $podname matches"
+
+    run_podman pod rm -f -t0 $podname
+}
+
 # vim: filetype=sh
@@ -125,6 +125,58 @@ Log[-1].Output   | \"Uh-oh on stdout!\\\nUh-oh on stderr!\\\n\"
     run_podman rm -f -t0 $ctr
 }
 
+@test "podman healthcheck history" {
+    skip_if_remote "containers.conf override is not applied to the server"
+    ctr="c-h-$(safename)"
+    containersconf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersconf <<EOF
+[engine]
+healthcheck_max_log_count = 2
+EOF
+
+    run_podman run -d --name $ctr                  \
+           --health-cmd /home/podman/healthcheck   \
+           --health-retries=3                      \
+           --health-interval=disable               \
+           $IMAGE /home/podman/pause
+
+    run_podman healthcheck history --format '{{.ExitCode}}' $ctr
+    is "$output" "" "no healthcheck runs of a fresh container"
+
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman healthcheck run $ctr
+    run_podman exec $ctr touch /uh-oh-only-once
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman 1 healthcheck run $ctr
+    CONTAINERS_CONF_OVERRIDE="$containersconf" run_podman healthcheck run $ctr
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    if [[ "$output" == "boltdb" ]]; then
+        run_podman healthcheck history --format '{{.ExitCode}}' $ctr
+        assert "${lines[*]}" == "0 1 0" "healthcheck runs in the run directory"
+    else
+        # Only the two most recent runs are kept.
+        run_podman healthcheck history --format '{{.Status}} {{.ExitCode}} {{.Output}}' $ctr
+        assert "${#lines[@]}" == 2 "two healthcheck runs are kept"
+        assert "${lines[0]}" == "healthy 1 Uh-oh on stdout!" "failed run"
+        assert "${lines[1]}" == "healthy 0 Life is Good on stdout" "passing run"
+
+        run_podman healthcheck history --format json $ctr
+        assert "$(jq -r '.[1].ExitCode' <<<"$output")" == "0" "exit code in JSON"
+
+        # The runs are kept when the container is restarted, while
+        # inspect only shows the runs since it was started.
+        run_podman container restart $ctr
+        run_podman container inspect $ctr --format "{{len .State.Healthcheck.Log}}"
+        is "$output" "0" "log of restarted container is empty"
+        run_podman healthcheck history --format '{{.ExitCode}}' $ctr
+        assert "${lines[*]}" == "1 0" "history of restarted container"
+    fi
+
+    run_podman rm -f -t0 $ctr
+
+    run_podman 125 healthcheck history $ctr
+    assert "$output" =~ "no container with name or ID \"$ctr\" found"
+}
+
 @test "podman wait --condition={healthy,unhealthy}" {
     ctr="healthcheck_c"
 
@@ -102,3 +102,104 @@ function teardown() {
     run_podman --url $URL rm $cname
     systemctl stop $SERVICE_NAME
 }
+
+@test "podman-system-service reloads containers.conf" {
+    skip_if_remote "podman system service unavailable over remote"
+
+    port=$(random_free_port)
+    URL=tcp://127.0.0.1:$port
+    containersconf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersconf <<EOF2
+[containers]
+default_ulimits = ["nofile=500:500"]
+EOF2
+
+    start=$(date +%s)
+    systemd-run --unit=$SERVICE_NAME --setenv=CONTAINERS_CONF_OVERRIDE=$containersconf \
+        $PODMAN --events-backend=file system service $URL --time=0
+    wait_for_port 127.0.0.1 $port
+
+    run_podman --url $URL run --rm $IMAGE sh -c 'ulimit -n'
+    is "$output" "500" "default_ulimits at start"
+
+    # The service reloads when the file changes
+    cat >$containersconf <<EOF2
+[containers]
+default_ulimits = ["nofile=600:600"]
+
+[engine]
+image_volume_mode = "tmpfs"
+EOF2
+
+    for i in {1..20}; do
+        run_podman --events-backend=file events --stream=false --since $start --filter type=system --filter event=reload --format json
+        if [[ -n "$output" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$output" =~ "\"reloaded\":\"containers.default_ulimits\"" "reload event lists applied settings"
+    assert "$output" =~ "\"restart_required\":\"engine.image_volume_mode\"" "reload event lists settings requiring a restart"
+
+    run_podman --url $URL run --rm $IMAGE sh -c 'ulimit -n'
+    is "$output" "600" "default_ulimits after reload"
+
+    systemctl stop $SERVICE_NAME
+}
+
+@test "podman-system-service reaps exec sessions whose owner was killed" {
+    skip_if_remote "exec sessions are listed from the local database"
+
+    cname="c-$(safename)"
+    run_podman run -d --name $cname $IMAGE top
+    cid="$output"
+
+    # Kill an attached podman exec, which cannot remove its session anymore
+    $PODMAN exec $cid sleep 3 </dev/null &>/dev/null &
+    exec_pid=$!
+    for i in {1..20}; do
+        run_podman container exec-ls -q --no-trunc $cid
+        if [[ -n "$output" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    eid="$output"
+    assert "$eid" != "" "exec session was created"
+    kill -9 $exec_pid
+    wait $exec_pid || true
+
+    # Wait for the command of the session to exit
+    for i in {1..20}; do
+        run_podman top $cid args
+        if [[ ! "$output" =~ "sleep 3" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$output" !~ "sleep 3" "command of the exec session exited"
+
+    run_podman container exec-ls -q --no-trunc $cid
+    assert "$output" = "$eid" "session is left behind by the killed podman exec"
+
+    # The service reaps stale sessions when it starts
+    port=$(random_free_port)
+    URL=tcp://127.0.0.1:$port
+    systemd-run --unit=$SERVICE_NAME $PODMAN system service $URL --time=0
+    wait_for_port 127.0.0.1 $port
+
+    for i in {1..20}; do
+        run_podman container exec-ls -q --no-trunc $cid
+        if [[ -z "$output" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$output" = "" "stale exec session was reaped"
+
+    run_podman events --stream=false --since 1m --filter container=$cid --filter event=exec_reaped --format '{{.Status}}'
+    assert "$output" = "exec_reaped" "reaping the session is reported as event"
+
+    systemctl stop $SERVICE_NAME
+    run_podman rm -f -t0 $cid
+}
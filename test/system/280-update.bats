@@ -129,6 +129,17 @@ device-write-iops   = /dev/zero:4000 | - | -
     done
     immediate-assertion-failures
 
+    # The limits are persisted and applied again after a restart
+    run_podman restart -t0 $cid
+    defer-assertion-failures
+    for opt in "${opts[@]}"; do
+        read path op expect <<<"${check[$opt]}"
+        run_podman exec $cid cat /sys/fs/cgroup/$path
+        updated="$(echo $output)"
+        assert "$updated" $op "$expect" "$opt ($path) after restart"
+    done
+    immediate-assertion-failures
+
     # Clean up
     run_podman rm -f -t0 $cid
     if [[ -n "$LOOPDEVICE" ]]; then
@@ -161,4 +172,89 @@ device-write-iops   = /dev/zero:4000 | - | -
     run_podman rm -f -t0 testctr
 }
 
+@test "podman container refresh" {
+    skip_if_remote "podman container refresh is not supported on remote clients"
+
+    image="localhost/i-refresh-$(safename):latest"
+    ctrname="c-$(safename)"
+    tmpdir=$PODMAN_TMPDIR/build
+    mkdir -p $tmpdir
+    cat >$tmpdir/Containerfile <<EOF
+FROM $IMAGE
+ENV VERSION=1
+LABEL version=1
+EOF
+    run_podman build -t $image $tmpdir
+
+    run_podman run -d --name $ctrname -v /data -e MINE=kept $image top
+    cid="$output"
+    run_podman exec $ctrname sh -c "echo persisted > /data/file; touch /discarded"
+
+    # Nothing to do while the container uses the latest image.
+    run_podman container refresh --pull=never $ctrname
+    is "$output" "$cid" "refresh of an up to date container"
+
+    sed -i -e 's/=1/=2/' $tmpdir/Containerfile
+    run_podman build -t $image $tmpdir
+    run_podman inspect --format '{{.Id}}' $image
+    newimage="$output"
+
+    run_podman container refresh --pull=never $ctrname
+    is "$output" "$cid" "container keeps its ID"
+
+    run_podman inspect --format '{{.Name}} {{.Image}} {{.State.Status}} {{.Config.Labels.version}}' $ctrname
+    is "$output" "$ctrname $newimage running 2" "container runs the new image"
+    run_podman exec $ctrname printenv VERSION MINE
+    assert "${lines[*]}" == "2 kept" "environment of the new image and of the user"
+    run_podman exec $ctrname cat /data/file
+    is "$output" "persisted" "anonymous volume is kept"
+    run_podman 1 exec $ctrname test -e /discarded
+
+    run_podman container refresh --pull=never --image $IMAGE $ctrname
+    run_podman exec $ctrname printenv MINE
+    is "$output" "kept" "environment of the user is kept"
+    run_podman 1 exec $ctrname printenv VERSION
+
+    run_podman rm -f -t0 $ctrname
+    run_podman rmi $image
+}
+
+@test "podman container refresh --takeover-ports" {
+    skip_if_remote "podman container refresh is not supported on remote clients"
+
+    image="localhost/i-takeover-$(safename):latest"
+    ctrname="c-$(safename)"
+    port=$(random_free_port)
+    tmpdir=$PODMAN_TMPDIR/build
+    mkdir -p $tmpdir
+    cat >$tmpdir/Containerfile <<EOF
+FROM $IMAGE
+LABEL version=1
+EOF
+    run_podman build -t $image $tmpdir
+
+    run_podman run -d --name $ctrname --publish-socket 127.0.0.1:$port $image top
+    run_podman exec $ctrname sh -c "tr '\\0' '\\n' < /proc/1/environ | grep ^LISTEN_ | sort"
+    assert "${lines[*]}" == "LISTEN_FDNAMES=tcp-$port LISTEN_FDS=1 LISTEN_PID=1" "socket activation environment"
+    run_podman exec $ctrname readlink /proc/1/fd/3
+    assert "$output" =~ "^socket:\[[0-9]+\]$" "published socket is passed as fd 3"
+    socket="$output"
+
+    # Without published sockets there is nothing to take over.
+    run_podman run -d --name $ctrname-plain $image top
+    sed -i -e 's/=1/=2/' $tmpdir/Containerfile
+    run_podman build -t $image $tmpdir
+    run_podman 125 container refresh --pull=never --takeover-ports $ctrname-plain
+    is "$output" ".*has no published sockets to take over.*"
+
+    run_podman container refresh --pull=never --takeover-ports $ctrname
+    run_podman inspect --format '{{.State.Status}} {{.Config.Labels.version}}' $ctrname
+    is "$output" "running 2" "container runs the new image"
+    run_podman exec $ctrname readlink /proc/1/fd/3
+    is "$output" "$socket" "the same socket is handed to the rebuilt container"
+
+    run_podman rm -f -t0 $ctrname $ctrname-plain
+    run_podman rmi $image
+}
+
 # vim: filetype=sh
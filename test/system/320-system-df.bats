@@ -158,4 +158,34 @@ Size           |   ~${size}.*MB |        !0B |            0B
     run_podman volume rm -a
 }
 
+@test "podman system graph" {
+    local pname=p-$(safename)
+    local vname=v-$(safename)
+    local c1=c1-$(safename)
+    local c2=c2-$(safename)
+
+    run_podman pod create --infra=false --name $pname
+    local pid="$output"
+    run_podman create --name $c1 --pod $pname -v $vname:/data $IMAGE true
+    local cid1="$output"
+    run_podman create --name $c2 --pod $pname --requires $c1 $IMAGE true
+    local cid2="$output"
+
+    run_podman system graph
+    assert "${lines[0]}" = "digraph podman {" "DOT output starts with digraph"
+    assert "$output" =~ "\"container:$cid1\" -> \"pod:$pid\"" "container in pod"
+    assert "$output" =~ "\"container:$cid1\" -> \"volume:$vname\"" "container uses volume"
+    assert "$output" =~ "\"container:$cid2\" -> \"container:$cid1\"" "container requires container"
+
+    run_podman system graph --format json
+    actual=$(echo "$output" | jq -r ".Edges[] | select(.From == \"$cid2\" and .Type == \"container\") | .To")
+    assert "$actual" = "$cid1" "JSON edge from $c2 to $c1"
+
+    run_podman 125 system graph --format bogus
+    is "$output" "Error: unknown --format argument: \"bogus\", must be dot or json"
+
+    run_podman pod rm -f $pname
+    run_podman volume rm $vname
+}
+
 # vim: filetype=sh
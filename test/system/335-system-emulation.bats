@@ -0,0 +1,39 @@
+#!/usr/bin/env bats   -*- bats -*-
+#
+# Tests for podman system emulation
+#
+
+load helpers
+
+@test "podman system emulation status" {
+    skip_if_remote "system emulation is not available remotely"
+
+    run_podman info --format '{{.Host.Arch}}'
+    native="linux/$output"
+
+    run_podman system emulation status --format '{{.Platform}}'
+    assert "$output" =~ "linux/" "foreign platforms are listed"
+    assert "$output" !~ "^$native\$" "native platform is not listed"
+
+    run_podman system emulation status --format json
+    assert "$(jq -r .Native <<<"$output")" == "$native" "native platform in JSON"
+}
+
+@test "podman system emulation enable - errors" {
+    skip_if_remote "system emulation is not available remotely"
+
+    run_podman info --format '{{.Host.Arch}}'
+    arch="$output"
+
+    if is_rootless; then
+        run_podman 125 system emulation enable $arch
+        assert "$output" =~ "emulation can only be enabled as root"
+        run_podman 125 system emulation disable
+        assert "$output" =~ "emulation can only be disabled as root"
+    else
+        run_podman 125 system emulation enable $arch
+        assert "$output" =~ "$arch is the native architecture of the host and does not need emulation"
+    fi
+}
+
+# vim: filetype=sh
@@ -396,6 +396,53 @@ load helpers.network
     run_podman network rm -t 0 -f $netname2
 }
 
+@test "podman network reload --pod --project" {
+    skip_if_remote "podman network reload does not have remote support"
+
+    local podname=p-$(safename)
+    local project=proj-$(safename)
+    local port=$(random_free_port)
+
+    run_podman pod create --name $podname -p $port:80
+    run_podman pod start $podname
+    run_podman pod inspect --format '{{.InfraContainerID}}' $podname
+    infraid="$output"
+    # The member shares the network namespace of the infra container, so
+    # only the infra container is reloaded.
+    run_podman run -d --pod $podname $IMAGE top
+    memberid="$output"
+
+    run_podman run -d --label com.docker.compose.project=$project $IMAGE top
+    cid1="$output"
+    run_podman run -d --label io.podman.compose.project=$project $IMAGE top
+    cid2="$output"
+    run_podman run -d --label com.docker.compose.project=other-$project $IMAGE top
+    othercid="$output"
+
+    run_podman network reload --verbose --pod $podname
+    is "$output" "$infraid
+  0.0.0.0:$port->80/tcp" "reloaded the infra container of the pod"
+
+    run_podman network reload --project $project
+    assert "$output" =~ "$cid1" "container with the docker compose label"
+    assert "$output" =~ "$cid2" "container with the podman compose label"
+    assert "$output" !~ "$othercid" "container of another project"
+    assert "${#lines[@]}" = 2 "containers reloaded for the project"
+
+    # Containers selected more than once are reloaded once.
+    run_podman network reload --pod $podname --project $project $infraid
+    assert "${#lines[@]}" = 3 "containers reloaded for the pod and project"
+
+    run_podman 125 network reload --project none-$project
+    is "$output" "Error: no containers found for project \"none-$project\": no such container"
+
+    run_podman 125 network reload
+    is "$output" "Error: specify one or more containers, --pod, --project, --latest or --all"
+
+    run_podman rm -t 0 -f $memberid $cid1 $cid2 $othercid
+    run_podman pod rm -t 0 -f $podname
+}
+
 @test "podman rootless cni adds /usr/sbin to PATH" {
     is_rootless || skip "only meaningful for rootless"
 
@@ -872,6 +919,43 @@ EOF
     is "$output" ".*options ${dns_opt}" "--dns-option was added"
 }
 
+@test "podman run CONTAINERS_CONF_OVERRIDE custom default network" {
+    skip_if_remote "CONTAINERS_CONF_OVERRIDE redirect does not work on remote"
+    local netname=defaultnet-$(random_string 10)
+    local subnet=$(random_rfc1918_subnet)
+    containersconf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersconf <<EOF
+[network]
+default_network = "$netname"
+default_subnet = "$subnet.0/24"
+default_network_options = { mtu = "1400" }
+default_network_dns = false
+EOF
+
+    # Until a container uses it the default network only exists in memory
+    CONTAINERS_CONF_OVERRIDE=$containersconf run_podman network inspect --format '{{.Labels}}' $netname
+    assert "$output" !~ "io.podman.network.default" "default network not created yet"
+
+    CONTAINERS_CONF_OVERRIDE=$containersconf run_podman run --rm $IMAGE ip addr
+    assert "$output" =~ "inet $subnet" "container in default subnet"
+    assert "$output" =~ "mtu 1400" "container uses default network options"
+
+    local format='{{.Labels}} {{.Subnets}} {{.Options}} {{.DNSEnabled}}'
+    run_podman network inspect --format "$format" $netname
+    assert "$output" =~ "io.podman.network.default:true" "default network created on first use"
+    assert "$output" =~ "$subnet.0/24" "default network subnet"
+    assert "$output" =~ "mtu:1400" "default network options"
+    assert "$output" =~ "false$" "default network DNS"
+
+    # It is recreated after its config was removed
+    run_podman network rm $netname
+    CONTAINERS_CONF_OVERRIDE=$containersconf run_podman run --rm $IMAGE true
+    run_podman network inspect --format "$format" $netname
+    assert "$output" =~ "io.podman.network.default:true" "default network recreated"
+
+    run_podman network rm $netname
+}
+
 @test "podman rootless netns works when XDG_RUNTIME_DIR includes symlinks" {
     # regression test for https://github.com/containers/podman/issues/14606
     is_rootless || skip "only meaningful for rootless"
@@ -1039,4 +1123,130 @@ EOF
     run_podman network rm $netname
 }
 
+@test "podman network disturb" {
+    skip_if_remote "podman network disturb is not supported on remote clients"
+    skip_if_rootless "netem requires root"
+    if ! modprobe -n sch_netem &>/dev/null && ! grep -qw sch_netem /proc/modules; then
+        skip "sch_netem kernel module is not available"
+    fi
+
+    cname=c-$(safename)
+    run_podman run -d --name $cname $IMAGE top
+
+    run_podman network disturb --latency 100ms --loss 1% --duration 2s $cname
+    assert "$output" =~ "Disturbing the network of $cname for 2s" "disturb message"
+    run_podman inspect --format '{{len .NetworkSettings.Disturbances}}' $cname
+    is "$output" "0" "disturbance is reverted after the duration"
+
+    $PODMAN network disturb --latency 100ms --duration 1m $cname &
+    disturb_pid=$!
+    for i in {1..20}; do
+        run_podman inspect --format '{{range .NetworkSettings.Disturbances}}{{.Interface}} {{.Latency}}{{end}}' $cname
+        if [[ "$output" == "eth0 100ms" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    is "$output" "eth0 100ms" "active disturbance is listed in inspect"
+    run_podman network disturb --clear $cname
+    run_podman inspect --format '{{len .NetworkSettings.Disturbances}}' $cname
+    is "$output" "0" "disturbance is reverted by --clear"
+    kill $disturb_pid
+    wait $disturb_pid || true
+
+    run_podman 125 network disturb --loss 101 $cname
+    assert "$output" =~ "packet loss must be between 0% and 100%"
+    run_podman 125 network disturb --duration 1s $cname
+    assert "$output" =~ "a latency or a packet loss must be given"
+
+    run_podman rm -f -t0 $cname
+}
+
+@test "podman network reserve" {
+    skip_if_remote "network reservations are not supported on remote clients"
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    if [[ "$output" == "boltdb" ]]; then
+        skip "network reservations require the sqlite or postgres database backend"
+    fi
+
+    local netname=net-$(random_string 10)
+    local subnet=$(random_rfc1918_subnet)
+    local cname1=c1-$(random_string 10)
+    local cname2=c2-$(random_string 10)
+
+    run_podman network create --subnet "${subnet}.0/24" $netname
+
+    run_podman 125 network reserve ip ${subnet}.1 --for name=$cname1
+    is "$output" "Error: ${subnet}.1 is the gateway of network $netname.*" "gateway cannot be reserved"
+    run_podman 125 network reserve ip ${subnet}.2 --for $cname1
+    is "$output" "Error: invalid --for \"$cname1\", must be name=NAME" "--for needs name="
+
+    # The first free address is reserved for a container that does not exist yet.
+    run_podman network reserve ip ${subnet}.2 --for name=$cname1
+    is "$output" "${subnet}.2 reserved on network $netname for $cname1"
+    run_podman 125 network reserve ip ${subnet}.2 --for name=$cname2
+    is "$output" "Error: address ${subnet}.2 on network $netname: network address is reserved" "address reserved twice"
+
+    run_podman network reserve ls --noheading $netname
+    is "$output" "$netname *${subnet}.2 *$cname1 .*" "reservation listed"
+    run_podman network inspect --format '{{range .Reservations}}{{.Address}} {{.Container}} {{.InUse}}{{end}}' $netname
+    is "$output" "${subnet}.2 $cname1 false" "unused reservation in inspect"
+
+    # Other containers are not given the reserved address.
+    run_podman run -d --name $cname2 --network $netname $IMAGE top
+    run_podman inspect --format "{{(index .NetworkSettings.Networks \"$netname\").IPAddress}}" $cname2
+    assert "$output" != "${subnet}.2" "reserved address not given to another container"
+    run_podman 126 run --rm --network $netname:ip=${subnet}.2 $IMAGE true
+    assert "$output" =~ "reserved for $cname1" "reserved address cannot be requested"
+
+    # The container the address is reserved for is given it.
+    run_podman run -d --name $cname1 --network $netname $IMAGE top
+    run_podman inspect --format "{{(index .NetworkSettings.Networks \"$netname\").IPAddress}}" $cname1
+    is "$output" "${subnet}.2" "reserved address given to its container"
+    run_podman network inspect --format '{{range .Reservations}}{{.InUse}}{{end}}' $netname
+    is "$output" "true" "reservation in use"
+
+    run_podman network reserve rm ${subnet}.2
+    run_podman 125 network reserve rm ${subnet}.2
+    is "$output" "Error: address ${subnet}.2: no such network reservation"
+
+    # The reservations of a network are removed with it.
+    run_podman network reserve ip --network $netname ${subnet}.50 --for name=$cname1
+    run_podman rm -f -t0 $cname1 $cname2
+    run_podman network rm $netname
+    run_podman network reserve ls --noheading
+    assert "$output" !~ "$netname" "reservations removed with the network"
+}
+
+@test "podman create fails early on published port conflicts" {
+    local cname1=c1-$(random_string 10)
+    local cname2=c2-$(random_string 10)
+    local port=$(random_free_port)
+
+    run_podman run -d --name $cname1 -p $port:80 $IMAGE top
+    cid1="$output"
+
+    run_podman 125 create --name $cname2 -p 127.0.0.1:$port:8080 $IMAGE top
+    is "$output" "Error: host port $port/tcp is already published by container $cname1 (${cid1:0:12}): port is already in use" \
+       "conflict with a running container"
+
+    # Other protocols and stopped containers do not conflict.
+    run_podman create --name $cname2 -p $port:8080/udp $IMAGE top
+    run_podman rm $cname2
+    run_podman stop -t0 $cname1
+    run_podman create --name $cname2 -p $port:80 $IMAGE top
+    run_podman rm $cname1 $cname2
+
+    # Ports in use on the host are detected as well.
+    nc -l 127.0.0.1 $port &
+    local ncpid=$!
+    wait_for_port 127.0.0.1 $port
+    run_podman 125 create -p 127.0.0.1:$port:80 $IMAGE top
+    assert "$output" =~ "Error: host port $port/tcp is already in use by .*: port is already in use" \
+           "conflict with a process on the host"
+    kill $ncpid
+    wait $ncpid || true
+}
+
 # vim: filetype=sh
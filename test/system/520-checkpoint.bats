@@ -444,4 +444,51 @@ function teardown() {
     run_podman rm -t 0 -f $ctrID
 }
 
+@test "podman container checkpoint ls and prune" {
+    skip_if_remote "listing exported checkpoints is not supported on remote clients"
+
+    run_podman info --format '{{.Host.DatabaseBackend}}'
+    if [[ "$output" == "boltdb" ]]; then
+        skip "checkpoint records require the sqlite or postgres database backend"
+    fi
+
+    local cname=c-$(random_string 10)
+    local archive=$PODMAN_TMPDIR/$cname.tar.zst
+    run_podman run -d --name $cname $IMAGE top
+    local cid="$output"
+
+    run_podman container checkpoint --export=$archive $cname
+    run_podman container checkpoint ls --filter container=$cname \
+               --format '{{.Container}}:{{.Type}}:{{.Compression}}:{{.Path}}'
+    is "$output" "$cname:checkpoint:zstd:$archive" "exported checkpoint is listed"
+
+    # The checkpoint is still listed after the container is removed.
+    run_podman rm -t 0 -f $cname
+    run_podman container checkpoint ls --quiet --filter container=${cid:0:12}
+    is "$output" "$archive" "checkpoint of removed container is listed"
+
+    run_podman 125 container checkpoint ls --filter foo=bar
+    is "$output" "Error: \"foo\" is an invalid checkpoint filter"
+
+    run_podman container checkpoint prune --force --filter container=$cname
+    is "$output" "$archive" "prune prints the removed archive"
+    assert "$(ls $PODMAN_TMPDIR)" !~ "$cname" "archive is removed"
+    run_podman container checkpoint ls --quiet --filter container=$cname
+    is "$output" "" "pruned checkpoint is not listed"
+}
+
+@test "podman container migrate - invalid destination" {
+    local cname=c-$(random_string 10)
+    run_podman run -d --name $cname $IMAGE top
+
+    run_podman 125 container migrate $cname nosuchconnection-$(random_string 5)
+    assert "$output" =~ "Error: connection \".*\" not found" \
+           "migrate to an unknown connection fails"
+
+    run_podman container inspect --format '{{.State.Status}}' $cname
+    is "$output" "running" "container is left running"
+
+    run_podman rm -t 0 -f $cname
+}
+
 # vim: filetype=sh
@@ -42,4 +42,42 @@ load helpers
       is "$output" "$policy" "output should show match content of policy.json"
 }
 
+@test "podman image trust policy" {
+      skip_if_remote "trust only works locally"
+      policypath=$PODMAN_TMPDIR/policy.json
+      registrypath=$PODMAN_TMPDIR/registries.d
+      paths="--policypath=$policypath --registrypath=$registrypath"
+
+      run_podman 125 image trust policy add $paths --type=accept quay.io
+      is "$output" "Error: default trust policy must be set" "scopes need a default"
+
+      run_podman image trust policy add $paths --type=reject default
+      run_podman 125 image trust policy add $paths --type=accept busybox
+      is "$output" "Error: invalid scope \"busybox\".*fully-qualified.*" "scope must be fully-qualified"
+
+      run_podman image trust policy add $paths --type=accept \
+                 --lookaside=https://example.com/sigstore quay.io/podman
+      is "$(< $registrypath/podman-trust.yaml)" ".*quay.io/podman:.*lookaside: https://example.com/sigstore" \
+         "lookaside is written to registries.d"
+
+      run_podman 125 image trust policy add $paths --type=reject quay.io/podman
+      is "$output" "Error: trust policy for quay.io/podman already exists, use --replace to replace it"
+
+      run_podman image trust policy show $paths --test quay.io/podman/stable
+      is "${lines[0]}" "Image: docker://quay.io/podman/stable:latest" "--test shows the image"
+      is "$output" ".*repository  *quay.io/podman  *accept  .*https://example.com/sigstore" \
+         "--test matches quay.io/podman"
+
+      run_podman image trust policy show $paths --test --json docker.io/library/busybox
+      subset=$(jq -r '.policies[0] | .repo_name, .type' <<<"$output" | fmt)
+      is "$subset" "default reject" "--test matches the default"
+
+      run_podman image trust policy remove $paths quay.io/podman
+      assert "$(ls $registrypath)" == "" "lookaside is removed from registries.d"
+      run_podman 125 image trust policy remove $paths quay.io/podman
+      is "$output" "Error: no trust policy for quay.io/podman in $policypath"
+      run_podman 125 image trust policy remove $paths default
+      is "$output" "Error: the default trust policy cannot be removed"
+}
+
 # vim: filetype=sh